@@ -0,0 +1,165 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// AddressFamilies are the address families that the endpoints
+// can be checked on before the stress. Empty for either.
+var AddressFamilies = []string{"", "ipv4", "ipv6"}
+
+// addressFamilyDialTimeout is the timeout to connect to each endpoint
+// of the address family preflight.
+const addressFamilyDialTimeout = 5 * time.Second
+
+// EndpointFamily is the address family that an endpoint was reached on.
+type EndpointFamily struct {
+	Endpoint string
+	// Address is the address that the endpoint resolved to and was reached on.
+	Address string
+	// Family is "ipv4" or "ipv6".
+	Family string
+}
+
+// validateEndpoint returns an error if the endpoint is not of the form
+// "host:port", with an optional scheme such as "http://". IPv6 literals
+// must be bracketed, as "[::1]:2379", since the port is ambiguous otherwise.
+func validateEndpoint(ep string) error {
+	hostPort := ep
+	if i := strings.Index(hostPort, "://"); i >= 0 {
+		hostPort = hostPort[i+3:]
+	}
+	hostPort = strings.TrimSuffix(hostPort, "/")
+	host, port, err := net.SplitHostPort(hostPort)
+	if err != nil {
+		if !strings.HasPrefix(hostPort, "[") && strings.Count(hostPort, ":") > 1 {
+			return fmt.Errorf("endpoint %q: IPv6 literals must be bracketed, as '[host]:port'", ep)
+		}
+		return fmt.Errorf("endpoint %q: %v", ep, err)
+	}
+	if host == "" || port == "" {
+		return fmt.Errorf("endpoint %q: expected 'host:port'", ep)
+	}
+	return nil
+}
+
+// endpointHostPort returns the "host:port" of the endpoint, without its scheme.
+func endpointHostPort(ep string) string {
+	if i := strings.Index(ep, "://"); i >= 0 {
+		ep = ep[i+3:]
+	}
+	return strings.TrimSuffix(ep, "/")
+}
+
+// addressFamilyOf returns "ipv4" or "ipv6" of the address.
+func addressFamilyOf(addr net.Addr) string {
+	if tcp, ok := addr.(*net.TCPAddr); ok && tcp.IP.To4() == nil {
+		return "ipv6"
+	}
+	return "ipv4"
+}
+
+// dialAddressFamily connects to each endpoint on the address family,
+// "ipv4", "ipv6" or empty for either, and returns the family that each
+// endpoint was reached on.
+func dialAddressFamily(endpoints []string, family string, timeout time.Duration) ([]EndpointFamily, error) {
+	network := "tcp"
+	switch family {
+	case "":
+	case "ipv4":
+		network = "tcp4"
+	case "ipv6":
+		network = "tcp6"
+	default:
+		return nil, fmt.Errorf("unknown address family %q (expected ipv4 or ipv6)", family)
+	}
+
+	efs := make([]EndpointFamily, 0, len(endpoints))
+	for _, ep := range endpoints {
+		if err := validateEndpoint(ep); err != nil {
+			return efs, err
+		}
+		conn, err := net.DialTimeout(network, endpointHostPort(ep), timeout)
+		if err != nil {
+			if family != "" {
+				return efs, fmt.Errorf("endpoint %q is not reachable on %s (%v)", ep, family, err)
+			}
+			return efs, fmt.Errorf("endpoint %q is not reachable (%v)", ep, err)
+		}
+		addr := conn.RemoteAddr()
+		conn.Close()
+		efs = append(efs, EndpointFamily{Endpoint: ep, Address: addr.String(), Family: addressFamilyOf(addr)})
+	}
+	return efs, nil
+}
+
+// CheckAddressFamily validates the endpoints of the database, and that
+// they are reachable on 'AddressFamily', before the stress. It logs the
+// address family that each endpoint was reached on. Host names that
+// resolve to both families are resolved again by the database clients,
+// which may prefer the other family unless 'AddressFamily' is set and
+// the hosts only listen on it.
+func (cfg *Config) CheckAddressFamily(databaseID string) ([]EndpointFamily, error) {
+	gcfg, ok := cfg.DatabaseIDToConfigClientMachineAgentControl[databaseID]
+	if !ok {
+		return nil, fmt.Errorf("%q is not found", databaseID)
+	}
+	if databaseID == "mock" {
+		return nil, nil
+	}
+	if len(cfg.Workers) > 0 {
+		// the workers connect to the endpoints, from other machines
+		cfg.lg.Info("skipped address family check of the endpoints of the workers", zap.String("database", databaseID))
+		return nil, nil
+	}
+
+	clusters := map[string][]string{"": gcfg.DatabaseEndpoints}
+	if databaseID == "tikv__v2_1" {
+		clusters[""] = tikvPDEndpoints(gcfg)
+	}
+	names := []string{""}
+	if len(cfg.ClusterEndpoints) > 0 {
+		clusters, names = cfg.ClusterEndpoints, cfg.clusterNames()
+	}
+	var all []EndpointFamily
+	for _, name := range names {
+		efs, err := dialAddressFamily(clusters[name], cfg.AddressFamily, addressFamilyDialTimeout)
+		if err != nil {
+			if name != "" {
+				return all, fmt.Errorf("cluster %q: %v", name, err)
+			}
+			return all, err
+		}
+		for _, ef := range efs {
+			cfg.lg.Info(
+				"reached endpoint",
+				zap.String("database", databaseID),
+				zap.String("cluster", name),
+				zap.String("endpoint", ef.Endpoint),
+				zap.String("address", ef.Address),
+				zap.String("family", ef.Family),
+			)
+		}
+		all = append(all, efs...)
+	}
+	return all, nil
+}
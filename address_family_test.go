@@ -0,0 +1,105 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/coreos/dbtester/dbtesterpb"
+
+	"go.uber.org/zap"
+)
+
+func TestValidateEndpoint(t *testing.T) {
+	for _, ep := range []string{"10.0.0.1:2379", "[::1]:2379", "http://[fe80::1]:8500", "etcd-0:2379", "http://10.0.0.1:2379/"} {
+		if err := validateEndpoint(ep); err != nil {
+			t.Fatalf("%q: unexpected error %v", ep, err)
+		}
+	}
+	for _, ep := range []string{"::1:2379", "fe80::1", "10.0.0.1", "http://::1:8500", ":2379"} {
+		if err := validateEndpoint(ep); err == nil {
+			t.Fatalf("%q: expected error", ep)
+		}
+	}
+}
+
+func TestDialAddressFamily(t *testing.T) {
+	ln, err := net.Listen("tcp4", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	ep := ln.Addr().String()
+
+	efs, err := dialAddressFamily([]string{ep}, "", time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(efs) != 1 || efs[0].Family != "ipv4" || efs[0].Address != ep {
+		t.Fatalf("unexpected %+v", efs)
+	}
+	if _, err = dialAddressFamily([]string{ep}, "ipv6", time.Second); err == nil {
+		t.Fatal("expected error of IPv4 endpoint on ipv6")
+	}
+	if _, err = dialAddressFamily([]string{ep}, "ipv5", time.Second); err == nil {
+		t.Fatal("expected error of unknown address family")
+	}
+
+	ln6, err := net.Listen("tcp6", "[::1]:0")
+	if err != nil {
+		t.Skipf("no IPv6 loopback (%v)", err)
+	}
+	defer ln6.Close()
+	ep6 := ln6.Addr().String()
+	efs, err = dialAddressFamily([]string{ep, "http://" + ep6}, "", time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(efs) != 2 || efs[0].Family != "ipv4" || efs[1].Family != "ipv6" {
+		t.Fatalf("unexpected %+v", efs)
+	}
+	if _, err = dialAddressFamily([]string{ep6}, "ipv6", time.Second); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCheckAddressFamily(t *testing.T) {
+	ln, err := net.Listen("tcp4", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	cfg := &Config{
+		lg: zap.NewNop(),
+		DatabaseIDToConfigClientMachineAgentControl: map[string]dbtesterpb.ConfigClientMachineAgentControl{
+			"etcd__tip": {DatabaseID: "etcd__tip", DatabaseEndpoints: []string{ln.Addr().String()}},
+		},
+		AddressFamily: "ipv4",
+	}
+	efs, err := cfg.CheckAddressFamily("etcd__tip")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(efs) != 1 || efs[0].Family != "ipv4" {
+		t.Fatalf("unexpected %+v", efs)
+	}
+
+	cfg.ClusterEndpoints = map[string][]string{"a": {ln.Addr().String()}, "b": {"::1:2379"}}
+	if _, err = cfg.CheckAddressFamily("etcd__tip"); err == nil {
+		t.Fatal("expected error of unbracketed IPv6 endpoint of cluster b")
+	}
+}
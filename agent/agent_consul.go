@@ -31,8 +31,10 @@ func startConsul(fs *flags, t *transporterServer) error {
 		return fmt.Errorf("Consul binary %q does not exist", globalFlags.consulExec)
 	}
 
-	if err := os.RemoveAll(fs.consulDataDir); err != nil {
-		return err
+	if !t.keepDataDir {
+		if err := os.RemoveAll(fs.consulDataDir); err != nil {
+			return err
+		}
 	}
 
 	peerIPs := strings.Split(t.req.PeerIPsString, "___")
@@ -65,6 +67,10 @@ func startConsul(fs *flags, t *transporterServer) error {
 		return fmt.Errorf("database ID %q is not supported", t.req.DatabaseID)
 	}
 
+	if err := saveDatabaseConfig(fs, t, fs.consulExec, flags, ""); err != nil {
+		return err
+	}
+
 	flagString := strings.Join(flags, " ")
 
 	cmd := exec.Command(fs.consulExec, flags...)
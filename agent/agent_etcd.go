@@ -31,8 +31,10 @@ func startEtcd(fs *flags, t *transporterServer) error {
 		return fmt.Errorf("etcd binary %q does not exist", globalFlags.etcdExec)
 	}
 
-	if err := os.RemoveAll(fs.etcdDataDir); err != nil {
-		return err
+	if !t.keepDataDir {
+		if err := os.RemoveAll(fs.etcdDataDir); err != nil {
+			return err
+		}
 	}
 
 	peerIPs := strings.Split(t.req.PeerIPsString, "___")
@@ -134,6 +136,10 @@ func startEtcd(fs *flags, t *transporterServer) error {
 		return fmt.Errorf("database ID %q is not supported", t.req.DatabaseID)
 	}
 
+	if err := saveDatabaseConfig(fs, t, fs.etcdExec, flags, ""); err != nil {
+		return err
+	}
+
 	flagString := strings.Join(flags, " ")
 
 	cmd := exec.Command(fs.etcdExec, flags...)
@@ -84,8 +84,10 @@ func startZookeeper(fs *flags, t *transporterServer) error {
 	if !exist(fs.javaExec) {
 		return fmt.Errorf("Java binary %q does not exist", globalFlags.javaExec)
 	}
-	if err := os.RemoveAll(fs.zkDataDir); err != nil {
-		return err
+	if !t.keepDataDir {
+		if err := os.RemoveAll(fs.zkDataDir); err != nil {
+			return err
+		}
 	}
 	if err := os.MkdirAll(fs.zkDataDir, 0777); err != nil {
 		return err
@@ -179,6 +181,10 @@ func startZookeeper(fs *flags, t *transporterServer) error {
 		return fmt.Errorf("database ID %q is not supported", t.req.DatabaseID)
 	}
 
+	if err := saveDatabaseConfig(fs, t, fs.javaExec, append(strings.Fields(flagString), fs.zkConfig), zctxt); err != nil {
+		return err
+	}
+
 	args := []string{shell, "-c", fs.javaExec + " " + flagString + " " + fs.zkConfig}
 	cmd := exec.Command(args[0], args[1:]...)
 	cmd.Stdout = t.databaseLogFile
@@ -33,6 +33,7 @@ import (
 type flags struct {
 	agentLog                     string
 	databaseLog                  string
+	databaseConfig               string
 	systemMetricsCSV             string
 	systemMetricsCSVInterpolated string
 
@@ -47,6 +48,7 @@ type flags struct {
 	zkConfig      string
 	etcdDataDir   string
 	consulDataDir string
+	installDir    string
 
 	grpcPort         string
 	diskDevice       string
@@ -73,6 +75,7 @@ func init() {
 
 	Command.PersistentFlags().StringVar(&globalFlags.agentLog, "agent-log", filepath.Join(homeDir(), "agent.log"), "agent log path.")
 	Command.PersistentFlags().StringVar(&globalFlags.databaseLog, "database-log", filepath.Join(homeDir(), "database.log"), "Database log path.")
+	Command.PersistentFlags().StringVar(&globalFlags.databaseConfig, "database-config", filepath.Join(homeDir(), "database-config.txt"), "File path to store the effective database server configuration.")
 	Command.PersistentFlags().StringVar(&globalFlags.systemMetricsCSV, "system-metrics-csv", filepath.Join(homeDir(), "server-system-metrics.csv"), "Raw system metrics data path.")
 	Command.PersistentFlags().StringVar(&globalFlags.systemMetricsCSVInterpolated, "system-metrics-csv-interpolated", filepath.Join(homeDir(), "server-system-metrics-interpolated.csv"), "Interpolated system metrics data path.")
 
@@ -88,6 +91,8 @@ func init() {
 	Command.PersistentFlags().StringVar(&globalFlags.etcdDataDir, "etcd-data-dir", filepath.Join(homeDir(), "etcd.data"), "etcd data directory.")
 	Command.PersistentFlags().StringVar(&globalFlags.consulDataDir, "consul-data-dir", filepath.Join(homeDir(), "consul.data"), "Consul data directory.")
 
+	Command.PersistentFlags().StringVar(&globalFlags.installDir, "install-dir", filepath.Join(homeDir(), "dbtester-install"), "Directory to download the database releases of 'Install' to.")
+
 	Command.PersistentFlags().StringVar(&globalFlags.grpcPort, "agent-port", ":3500", "Port to server agent gRPC server.")
 	Command.PersistentFlags().StringVar(&globalFlags.diskDevice, "disk-device", dn, "Disk device to collect disk statistics metrics from.")
 	Command.PersistentFlags().StringVar(&globalFlags.networkInterface, "network-interface", nt, "Network interface to record in/outgoing packets.")
@@ -0,0 +1,60 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agent
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// saveDatabaseConfig writes the effective database server configuration
+// (command-line flags, configuration file contents), so that two test
+// results can be compared without access to the test machines.
+func saveDatabaseConfig(fs *flags, t *transporterServer, execPath string, flags []string, configFile string) error {
+	buf := new(bytes.Buffer)
+	buf.WriteString(fmt.Sprintf("# database-id: %s\n", t.req.DatabaseID.String()))
+	buf.WriteString(fmt.Sprintf("# database-tag: %s\n", t.req.DatabaseTag))
+	buf.WriteString(fmt.Sprintf("# node: %d\n", t.req.IPIndex+1))
+	buf.WriteString("\n")
+	buf.WriteString(execPath + "\n")
+	for _, line := range groupFlags(flags) {
+		buf.WriteString(line + "\n")
+	}
+	if configFile != "" {
+		buf.WriteString("\n")
+		buf.WriteString(strings.TrimSpace(configFile) + "\n")
+	}
+
+	t.lg.Info("writing database configuration", zap.String("path", fs.databaseConfig))
+	return toFile(buf.String(), fs.databaseConfig)
+}
+
+// groupFlags returns one line per flag, with its value if any.
+// (e.g. "--name etcd-1", "-server").
+func groupFlags(flags []string) []string {
+	var lines []string
+	for i := 0; i < len(flags); i++ {
+		line := flags[i]
+		if strings.HasPrefix(line, "-") && i+1 < len(flags) && !strings.HasPrefix(flags[i+1], "-") {
+			line += " " + flags[i+1]
+			i++
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
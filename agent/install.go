@@ -0,0 +1,308 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agent
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/coreos/dbtester/dbtesterpb"
+
+	"go.uber.org/zap"
+)
+
+// Download URLs of the releases, formatted with the version.
+const (
+	etcdReleaseURL      = "https://github.com/coreos/etcd/releases/download/v%[1]s/etcd-v%[1]s-linux-amd64.tar.gz"
+	consulReleaseURL    = "https://releases.hashicorp.com/consul/%[1]s/consul_%[1]s_linux_amd64.zip"
+	zookeeperReleaseURL = "https://archive.apache.org/dist/zookeeper/zookeeper-%[1]s/zookeeper-%[1]s.tar.gz"
+)
+
+// zookeeperVersion is the only Zookeeper release to install,
+// of the Java class paths of 'JavaClassPathZookeeperr353beta'.
+const zookeeperVersion = "3.5.3-beta"
+
+// releaseURL returns the download URL of the release of the database,
+// and the file to find in the release: the binary of etcd and Consul,
+// or the jar of Zookeeper in its working directory.
+func releaseURL(rdb dbtesterpb.DatabaseID, version string) (url, file string, err error) {
+	version = strings.TrimPrefix(version, "v")
+	if version == "" {
+		return "", "", fmt.Errorf("no version of %q to install", rdb)
+	}
+	switch rdb {
+	case dbtesterpb.DatabaseID_etcd__other,
+		dbtesterpb.DatabaseID_etcd__tip,
+		dbtesterpb.DatabaseID_etcd__v3_2,
+		dbtesterpb.DatabaseID_etcd__v3_3,
+		dbtesterpb.DatabaseID_zetcd__beta,
+		dbtesterpb.DatabaseID_cetcd__beta:
+		return fmt.Sprintf(etcdReleaseURL, version), "etcd", nil
+
+	case dbtesterpb.DatabaseID_zookeeper__r3_5_3_beta:
+		if version != zookeeperVersion {
+			return "", "", fmt.Errorf("Zookeeper %q cannot be installed; the class paths are of %q", version, zookeeperVersion)
+		}
+		return fmt.Sprintf(zookeeperReleaseURL, version), fmt.Sprintf("zookeeper-%s.jar", version), nil
+
+	case dbtesterpb.DatabaseID_consul__v1_0_2:
+		return fmt.Sprintf(consulReleaseURL, version), "consul", nil
+
+	default:
+		return "", "", fmt.Errorf("database %q cannot be installed", rdb)
+	}
+}
+
+// install downloads the release of the database, unless downloaded
+// before, and starts the database with it until 'uninstall'.
+func (t *transporterServer) install(rdb dbtesterpb.DatabaseID, version string) error {
+	if t.running() {
+		return fmt.Errorf("database is still running (pid %d); 'Stop' or 'Shutdown' first", t.pid)
+	}
+	if t.installDir != "" {
+		return fmt.Errorf("%q is already installed; 'Uninstall' first", t.installDir)
+	}
+	url, file, err := releaseURL(rdb, version)
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Join(globalFlags.installDir, fmt.Sprintf("%s-%s", rdb, strings.TrimPrefix(version, "v")))
+	if exist(dir) {
+		t.lg.Info("re-using downloaded release", zap.String("directory", dir))
+	} else {
+		if err = os.MkdirAll(globalFlags.installDir, 0777); err != nil {
+			return err
+		}
+		archive := dir + ".tar.gz"
+		if strings.HasSuffix(url, ".zip") {
+			archive = dir + ".zip"
+		}
+		t.lg.Info("downloading release", zap.String("url", url), zap.String("path", archive))
+		if err = download(url, archive); err != nil {
+			return err
+		}
+		defer os.Remove(archive)
+
+		// extract to a temporary directory first, not to re-use
+		// the partial release of a failed extraction
+		tmp := dir + ".tmp"
+		os.RemoveAll(tmp)
+		if err = extract(archive, tmp); err != nil {
+			os.RemoveAll(tmp)
+			return err
+		}
+		if err = os.Rename(tmp, dir); err != nil {
+			return err
+		}
+	}
+
+	fpath, err := findFile(dir, file)
+	if err != nil {
+		return err
+	}
+	t.origFlags = globalFlags
+	switch file {
+	case "etcd":
+		globalFlags.etcdExec = fpath
+	case "consul":
+		globalFlags.consulExec = fpath
+	default:
+		globalFlags.zkWorkDir = filepath.Dir(fpath)
+	}
+	t.installDir = dir
+	t.lg.Info("installed release", zap.String("database", rdb.String()), zap.String("version", version), zap.String("path", fpath))
+	return nil
+}
+
+// uninstall removes the release of 'install', to start the database
+// with the binaries of the agent flags again.
+func (t *transporterServer) uninstall() error {
+	if t.running() {
+		return fmt.Errorf("database is still running (pid %d); 'Stop' or 'Shutdown' first", t.pid)
+	}
+	if t.installDir == "" {
+		t.lg.Info("no release to uninstall")
+		return nil
+	}
+	globalFlags.etcdExec = t.origFlags.etcdExec
+	globalFlags.consulExec = t.origFlags.consulExec
+	globalFlags.zkWorkDir = t.origFlags.zkWorkDir
+
+	t.lg.Info("removing release", zap.String("directory", t.installDir))
+	if err := os.RemoveAll(t.installDir); err != nil {
+		return err
+	}
+	t.installDir = ""
+	return nil
+}
+
+func download(url, fpath string) error {
+	cli := &http.Client{Timeout: 10 * time.Minute}
+	resp, err := cli.Get(url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("cannot download %q (%s)", url, resp.Status)
+	}
+
+	f, err := openToOverwrite(fpath)
+	if err != nil {
+		return err
+	}
+	if _, err = io.Copy(f, resp.Body); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// extract extracts the '.tar.gz' or '.zip' archive into the directory.
+func extract(archive, dir string) error {
+	switch {
+	case strings.HasSuffix(archive, ".tar.gz"):
+		return extractTarGz(archive, dir)
+	case strings.HasSuffix(archive, ".zip"):
+		return extractZip(archive, dir)
+	default:
+		return fmt.Errorf("unknown archive %q", archive)
+	}
+}
+
+// extractPath returns the path to extract the file of the name to,
+// which must be in the directory.
+func extractPath(dir, name string) (string, error) {
+	fpath := filepath.Join(dir, name)
+	if fpath != filepath.Clean(dir) && !strings.HasPrefix(fpath, filepath.Clean(dir)+string(os.PathSeparator)) {
+		return "", fmt.Errorf("%q is outside of the archive", name)
+	}
+	return fpath, nil
+}
+
+func writeFile(fpath string, r io.Reader, mode os.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(fpath), 0777); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(fpath, os.O_RDWR|os.O_TRUNC|os.O_CREATE, mode)
+	if err != nil {
+		return err
+	}
+	if _, err = io.Copy(f, r); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func extractTarGz(archive, dir string) error {
+	f, err := os.Open(archive)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	gr, err := gzip.NewReader(f)
+	if err != nil {
+		return err
+	}
+	defer gr.Close()
+
+	tr := tar.NewReader(gr)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		fpath, err := extractPath(dir, hdr.Name)
+		if err != nil {
+			return err
+		}
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err = os.MkdirAll(fpath, 0777); err != nil {
+				return err
+			}
+		case tar.TypeReg, tar.TypeRegA:
+			if err = writeFile(fpath, tr, hdr.FileInfo().Mode().Perm()); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func extractZip(archive, dir string) error {
+	zr, err := zip.OpenReader(archive)
+	if err != nil {
+		return err
+	}
+	defer zr.Close()
+
+	for _, zf := range zr.File {
+		fpath, err := extractPath(dir, zf.Name)
+		if err != nil {
+			return err
+		}
+		if zf.FileInfo().IsDir() {
+			if err = os.MkdirAll(fpath, 0777); err != nil {
+				return err
+			}
+			continue
+		}
+		rc, err := zf.Open()
+		if err != nil {
+			return err
+		}
+		err = writeFile(fpath, rc, zf.Mode().Perm())
+		rc.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// findFile returns the path of the regular file of the name
+// in the directory, such as the binary in the release directory.
+func findFile(dir, name string) (string, error) {
+	var found string
+	err := filepath.Walk(dir, func(fpath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if found == "" && info.Mode().IsRegular() && info.Name() == name {
+			found = fpath
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	if found == "" {
+		return "", fmt.Errorf("%q is not found in %q", name, dir)
+	}
+	return found, nil
+}
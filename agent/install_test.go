@@ -0,0 +1,117 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agent
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/coreos/dbtester/dbtesterpb"
+)
+
+func writeTarGz(t *testing.T, fpath string, files map[string]string) {
+	f, err := os.Create(fpath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	gw := gzip.NewWriter(f)
+	tw := tar.NewWriter(gw)
+	for name, body := range files {
+		if err = tw.WriteHeader(&tar.Header{Name: name, Mode: 0755, Size: int64(len(body)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err = tw.Write([]byte(body)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err = gw.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestExtract(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dbtester-install")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	archive := filepath.Join(dir, "etcd.tar.gz")
+	writeTarGz(t, archive, map[string]string{"etcd-v3.3.0-linux-amd64/etcd": "binary"})
+	if err = extract(archive, filepath.Join(dir, "etcd")); err != nil {
+		t.Fatal(err)
+	}
+	fpath, err := findFile(filepath.Join(dir, "etcd"), "etcd")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fpath != filepath.Join(dir, "etcd", "etcd-v3.3.0-linux-amd64", "etcd") {
+		t.Fatalf("unexpected path %q", fpath)
+	}
+	if st, _ := os.Stat(fpath); st.Mode().Perm()&0100 == 0 {
+		t.Fatalf("expected executable, got %v", st.Mode())
+	}
+
+	archive = filepath.Join(dir, "consul.zip")
+	f, err := os.Create(archive)
+	if err != nil {
+		t.Fatal(err)
+	}
+	zw := zip.NewWriter(f)
+	w, err := zw.Create("consul")
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.Write([]byte("binary"))
+	zw.Close()
+	f.Close()
+	if err = extract(archive, filepath.Join(dir, "consul")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = findFile(filepath.Join(dir, "consul"), "consul"); err != nil {
+		t.Fatal(err)
+	}
+
+	archive = filepath.Join(dir, "evil.tar.gz")
+	writeTarGz(t, archive, map[string]string{"../outside": "binary"})
+	if err = extract(archive, filepath.Join(dir, "evil")); err == nil {
+		t.Fatal("expected error of the file outside of the archive")
+	}
+}
+
+func TestReleaseURL(t *testing.T) {
+	url, file, err := releaseURL(dbtesterpb.DatabaseID_etcd__v3_3, "v3.3.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if url != "https://github.com/coreos/etcd/releases/download/v3.3.0/etcd-v3.3.0-linux-amd64.tar.gz" || file != "etcd" {
+		t.Fatalf("unexpected release %q %q", url, file)
+	}
+	if _, _, err = releaseURL(dbtesterpb.DatabaseID_zookeeper__r3_5_3_beta, "3.4.10"); err == nil {
+		t.Fatal("expected error of Zookeeper release of other class paths")
+	}
+	if _, _, err = releaseURL(dbtesterpb.DatabaseID_consul__v1_0_2, ""); err == nil {
+		t.Fatal("expected error of no version")
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agent
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// partitionRules returns the iptables rules to drop the traffic
+// from and to the IP.
+func partitionRules(ip string) [][]string {
+	return [][]string{
+		{"INPUT", "-s", ip, "-j", "DROP"},
+		{"OUTPUT", "-d", ip, "-j", "DROP"},
+	}
+}
+
+func iptables(action string, rule []string) error {
+	args := append([]string{action}, rule...)
+	out, err := exec.Command("iptables", args...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("iptables %s failed (%v, %q)", strings.Join(args, " "), err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// partition drops the traffic from and to the peer IPs.
+func (t *transporterServer) partition(ips []string) error {
+	for _, ip := range ips {
+		if ip == "" {
+			continue
+		}
+		for _, rule := range partitionRules(ip) {
+			if err := iptables("-A", rule); err != nil {
+				return err
+			}
+		}
+		t.partitionedIPs = append(t.partitionedIPs, ip)
+		t.lg.Info("partitioned from peer", zap.String("peer-ip", ip))
+	}
+	return nil
+}
+
+// heal removes all rules added by 'partition'.
+func (t *transporterServer) heal() error {
+	for len(t.partitionedIPs) > 0 {
+		ip := t.partitionedIPs[0]
+		for _, rule := range partitionRules(ip) {
+			if err := iptables("-D", rule); err != nil {
+				return err
+			}
+		}
+		t.partitionedIPs = t.partitionedIPs[1:]
+		t.lg.Info("healed partition from peer", zap.String("peer-ip", ip))
+	}
+	return nil
+}
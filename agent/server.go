@@ -19,11 +19,13 @@ import (
 	"os"
 	"os/exec"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/coreos/dbtester/dbtesterpb"
 	"github.com/coreos/dbtester/pkg/fileinspect"
+	"github.com/coreos/dbtester/pkg/resource"
 
 	"github.com/gyuho/linux-inspect/inspect"
 	"go.uber.org/zap"
@@ -44,6 +46,9 @@ type transporterServer struct {
 	// cmdWait channel is closed
 	// after database process is closed
 	cmdWait chan struct{}
+	// keepDataDir is true to start the database
+	// with the existing data directory, on restarts
+	keepDataDir bool
 
 	pid int64
 
@@ -53,6 +58,15 @@ type transporterServer struct {
 
 	metricsCSV *inspect.CSV
 
+	// installDir is the release directory of 'Install', and origFlags
+	// are the agent flags before it, to restore on 'Uninstall'
+	installDir string
+	origFlags  flags
+
+	// partitionedIPs are the peer IPs that traffic is dropped
+	// from and to, until 'Heal'
+	partitionedIPs []string
+
 	// trigger log uploads to cloud storage
 	// this should be triggered before we shut down
 	// the agent server
@@ -154,68 +168,13 @@ func (t *transporterServer) Transfer(ctx context.Context, req *dbtesterpb.Reques
 	}
 
 	var diskSpaceUsageBytes int64
+	var startedAt time.Time
 	switch req.Operation {
 	case dbtesterpb.Operation_Start:
-		switch t.req.DatabaseID {
-		case dbtesterpb.DatabaseID_etcd__other,
-			dbtesterpb.DatabaseID_etcd__tip,
-			dbtesterpb.DatabaseID_etcd__v3_2,
-			dbtesterpb.DatabaseID_etcd__v3_3,
-			dbtesterpb.DatabaseID_zetcd__beta,
-			dbtesterpb.DatabaseID_cetcd__beta:
-			if err := startEtcd(&globalFlags, t); err != nil {
-				return nil, err
-			}
-			switch t.req.DatabaseID {
-			case dbtesterpb.DatabaseID_zetcd__beta:
-				if err := startZetcd(&globalFlags, t); err != nil {
-					return nil, err
-				}
-				go func() {
-					defer close(t.proxyCmdWait)
-					if err := t.proxyCmd.Wait(); err != nil {
-						t.lg.Warn("zetcd t.proxyCmd.Wait() returned error", zap.Error(err))
-						return
-					}
-					t.lg.Info("exiting zetcd", zap.String("executable-path", t.proxyCmd.Path))
-				}()
-
-			case dbtesterpb.DatabaseID_cetcd__beta:
-				if err := startCetcd(&globalFlags, t); err != nil {
-					return nil, err
-				}
-				go func() {
-					defer close(t.proxyCmdWait)
-					if err := t.proxyCmd.Wait(); err != nil {
-						t.lg.Warn("cetcd t.proxyCmd.Wait() returned error", zap.Error(err))
-						return
-					}
-					t.lg.Info("exiting cetcd", zap.String("executable-path", t.proxyCmd.Path))
-				}()
-			}
-
-		case dbtesterpb.DatabaseID_zookeeper__r3_5_3_beta:
-			if err := startZookeeper(&globalFlags, t); err != nil {
-				return nil, err
-			}
-
-		case dbtesterpb.DatabaseID_consul__v1_0_2:
-			if err := startConsul(&globalFlags, t); err != nil {
-				return nil, err
-			}
-
-		default:
-			return nil, fmt.Errorf("unknown database %q", t.req.DatabaseID)
+		if err := t.startDatabase(); err != nil {
+			return nil, err
 		}
-
-		go func() {
-			defer close(t.cmdWait)
-			if err := t.cmd.Wait(); err != nil {
-				t.lg.Warn("t.cmd.Wait() returned error", zap.Error(err))
-				return
-			}
-			t.lg.Info("exiting", zap.String("executable-path", t.cmd.Path))
-		}()
+		startedAt = time.Now()
 		if err := startMetrics(&globalFlags, t); err != nil {
 			return nil, err
 		}
@@ -230,45 +189,15 @@ func (t *transporterServer) Transfer(ctx context.Context, req *dbtesterpb.Reques
 		time.Sleep(3 * time.Second)
 
 		// TODO: https://github.com/coreos/dbtester/issues/330
-		t.lg.Info("sending", zap.String("syscall", syscall.SIGINT.String()), zap.Int64("pid", t.pid), zap.String("executable-path", t.cmd.Path))
-		if err := t.cmd.Process.Signal(syscall.SIGINT); err != nil {
-			t.lg.Warn("syscall.SIGINT failed", zap.Error(err))
-
-			time.Sleep(3 * time.Second)
-			t.lg.Info("sending", zap.String("syscall", syscall.SIGTERM.String()), zap.Int64("pid", t.pid), zap.String("executable-path", t.cmd.Path))
-			if err := syscall.Kill(int(t.pid), syscall.SIGTERM); err != nil {
-				t.lg.Warn("syscall.Kill failed", zap.Error(err))
-			}
-		}
-
-		time.Sleep(time.Second)
-		<-t.cmdWait
+		t.stopDatabase()
 
 		if t.databaseLogFile != nil {
 			t.databaseLogFile.Sync()
 			t.databaseLogFile.Close()
 		}
-		t.lg.Info("stopped", zap.String("database", t.req.DatabaseID.String()), zap.Int64("pid", t.pid))
-
-		if t.proxyCmd != nil {
-			t.lg.Info("sending", zap.String("syscall", syscall.SIGINT.String()), zap.Int64("pid", t.proxyPid), zap.String("executable-path", t.proxyCmd.Path))
-			if err := t.proxyCmd.Process.Signal(syscall.SIGINT); err != nil {
-				t.lg.Warn("syscall.SIGINT failed", zap.Error(err))
-
-				time.Sleep(3 * time.Second)
-				t.lg.Info("sending", zap.String("syscall", syscall.SIGTERM.String()), zap.Int64("pid", t.proxyPid), zap.String("executable-path", t.proxyCmd.Path))
-				if err := syscall.Kill(int(t.proxyPid), syscall.SIGTERM); err != nil {
-					t.lg.Warn("syscall.Kill failed", zap.Error(err))
-				}
-			}
-
-			<-t.proxyCmdWait
-			t.lg.Info("stopped", zap.String("database", t.req.DatabaseID.String()), zap.Int64("pid", t.proxyPid))
-
-			if t.proxyDatabaseLogfile != nil {
-				t.proxyDatabaseLogfile.Sync()
-				t.proxyDatabaseLogfile.Close()
-			}
+		if t.proxyDatabaseLogfile != nil {
+			t.proxyDatabaseLogfile.Sync()
+			t.proxyDatabaseLogfile.Close()
 		}
 
 		t.uploadSig <- struct{}{}
@@ -286,6 +215,70 @@ func (t *transporterServer) Transfer(ctx context.Context, req *dbtesterpb.Reques
 		}
 		diskSpaceUsageBytes = dbs
 
+	case dbtesterpb.Operation_Shutdown:
+		if t.cmd == nil {
+			return nil, fmt.Errorf("nil command")
+		}
+		t.stopDatabase()
+
+	case dbtesterpb.Operation_Restart:
+		if t.cmd == nil {
+			return nil, fmt.Errorf("nil command")
+		}
+		if t.running() {
+			return nil, fmt.Errorf("database is still running (pid %d); 'Shutdown' first", t.pid)
+		}
+
+		// restart with the data written so far,
+		// to measure the recovery with the data
+		t.keepDataDir = true
+		err := t.startDatabase()
+		t.keepDataDir = false
+		if err != nil {
+			return nil, err
+		}
+		startedAt = time.Now()
+		t.lg.Warn("system metrics are not collected for the restarted process", zap.Int64("pid", t.pid))
+
+		dbs, err := measureDatabasSize(globalFlags, t.req.DatabaseID)
+		if err != nil {
+			return nil, err
+		}
+		diskSpaceUsageBytes = dbs
+
+	case dbtesterpb.Operation_Partition:
+		if err := t.partition(strings.Split(req.PartitionPeerIPsString, "___")); err != nil {
+			return nil, err
+		}
+
+	case dbtesterpb.Operation_Heal:
+		if err := t.heal(); err != nil {
+			return nil, err
+		}
+
+	case dbtesterpb.Operation_Wipe:
+		if err := t.wipe(req.DatabaseID); err != nil {
+			return nil, err
+		}
+
+	case dbtesterpb.Operation_Install:
+		if err := t.install(req.DatabaseID, req.DatabaseVersion); err != nil {
+			return nil, err
+		}
+
+	case dbtesterpb.Operation_Uninstall:
+		if err := t.uninstall(); err != nil {
+			return nil, err
+		}
+
+	case dbtesterpb.Operation_Metrics:
+		resp, err := t.metrics()
+		if err != nil {
+			return nil, err
+		}
+		t.lg.Info("Transfer success!")
+		return resp, nil
+
 	case dbtesterpb.Operation_Heartbeat:
 		t.lg.Info("overwriting clients number", zap.Int64("number", t.req.CurrentClientNumber), zap.String("number-path", t.clientNumPath))
 		if err := toFile(fmt.Sprintf("%d", t.req.CurrentClientNumber), t.clientNumPath); err != nil {
@@ -297,10 +290,115 @@ func (t *transporterServer) Transfer(ctx context.Context, req *dbtesterpb.Reques
 	}
 
 	t.lg.Info("Transfer success!")
-	return &dbtesterpb.Response{Success: true, DiskSpaceUsageBytes: diskSpaceUsageBytes}, nil
+	resp := &dbtesterpb.Response{Success: true, DiskSpaceUsageBytes: diskSpaceUsageBytes}
+	if !startedAt.IsZero() {
+		resp.DatabaseStartUnixNanosecond = startedAt.UnixNano()
+	}
+	return resp, nil
 }
 
-func measureDatabasSize(flg flags, rdb dbtesterpb.DatabaseID) (int64, error) {
+// startDatabase starts the database process, and its proxy if any.
+func (t *transporterServer) startDatabase() error {
+	switch t.req.DatabaseID {
+	case dbtesterpb.DatabaseID_etcd__other,
+		dbtesterpb.DatabaseID_etcd__tip,
+		dbtesterpb.DatabaseID_etcd__v3_2,
+		dbtesterpb.DatabaseID_etcd__v3_3,
+		dbtesterpb.DatabaseID_zetcd__beta,
+		dbtesterpb.DatabaseID_cetcd__beta:
+		if err := startEtcd(&globalFlags, t); err != nil {
+			return err
+		}
+		switch t.req.DatabaseID {
+		case dbtesterpb.DatabaseID_zetcd__beta:
+			if err := startZetcd(&globalFlags, t); err != nil {
+				return err
+			}
+			go func() {
+				defer close(t.proxyCmdWait)
+				if err := t.proxyCmd.Wait(); err != nil {
+					t.lg.Warn("zetcd t.proxyCmd.Wait() returned error", zap.Error(err))
+					return
+				}
+				t.lg.Info("exiting zetcd", zap.String("executable-path", t.proxyCmd.Path))
+			}()
+
+		case dbtesterpb.DatabaseID_cetcd__beta:
+			if err := startCetcd(&globalFlags, t); err != nil {
+				return err
+			}
+			go func() {
+				defer close(t.proxyCmdWait)
+				if err := t.proxyCmd.Wait(); err != nil {
+					t.lg.Warn("cetcd t.proxyCmd.Wait() returned error", zap.Error(err))
+					return
+				}
+				t.lg.Info("exiting cetcd", zap.String("executable-path", t.proxyCmd.Path))
+			}()
+		}
+
+	case dbtesterpb.DatabaseID_zookeeper__r3_5_3_beta:
+		if err := startZookeeper(&globalFlags, t); err != nil {
+			return err
+		}
+
+	case dbtesterpb.DatabaseID_consul__v1_0_2:
+		if err := startConsul(&globalFlags, t); err != nil {
+			return err
+		}
+
+	default:
+		return fmt.Errorf("unknown database %q", t.req.DatabaseID)
+	}
+
+	go func() {
+		defer close(t.cmdWait)
+		if err := t.cmd.Wait(); err != nil {
+			t.lg.Warn("t.cmd.Wait() returned error", zap.Error(err))
+			return
+		}
+		t.lg.Info("exiting", zap.String("executable-path", t.cmd.Path))
+	}()
+	return nil
+}
+
+// stopDatabase stops the database process, and its proxy if any.
+func (t *transporterServer) stopDatabase() {
+	t.lg.Info("sending", zap.String("syscall", syscall.SIGINT.String()), zap.Int64("pid", t.pid), zap.String("executable-path", t.cmd.Path))
+	if err := t.cmd.Process.Signal(syscall.SIGINT); err != nil {
+		t.lg.Warn("syscall.SIGINT failed", zap.Error(err))
+
+		time.Sleep(3 * time.Second)
+		t.lg.Info("sending", zap.String("syscall", syscall.SIGTERM.String()), zap.Int64("pid", t.pid), zap.String("executable-path", t.cmd.Path))
+		if err := syscall.Kill(int(t.pid), syscall.SIGTERM); err != nil {
+			t.lg.Warn("syscall.Kill failed", zap.Error(err))
+		}
+	}
+
+	time.Sleep(time.Second)
+	<-t.cmdWait
+
+	t.lg.Info("stopped", zap.String("database", t.req.DatabaseID.String()), zap.Int64("pid", t.pid))
+
+	if t.proxyCmd != nil {
+		t.lg.Info("sending", zap.String("syscall", syscall.SIGINT.String()), zap.Int64("pid", t.proxyPid), zap.String("executable-path", t.proxyCmd.Path))
+		if err := t.proxyCmd.Process.Signal(syscall.SIGINT); err != nil {
+			t.lg.Warn("syscall.SIGINT failed", zap.Error(err))
+
+			time.Sleep(3 * time.Second)
+			t.lg.Info("sending", zap.String("syscall", syscall.SIGTERM.String()), zap.Int64("pid", t.proxyPid), zap.String("executable-path", t.proxyCmd.Path))
+			if err := syscall.Kill(int(t.proxyPid), syscall.SIGTERM); err != nil {
+				t.lg.Warn("syscall.Kill failed", zap.Error(err))
+			}
+		}
+
+		<-t.proxyCmdWait
+		t.lg.Info("stopped", zap.String("database", t.req.DatabaseID.String()), zap.Int64("pid", t.proxyPid))
+	}
+}
+
+// dataDir returns the data directory of the database.
+func dataDir(flg flags, rdb dbtesterpb.DatabaseID) (string, error) {
 	switch rdb {
 	case dbtesterpb.DatabaseID_etcd__other,
 		dbtesterpb.DatabaseID_etcd__tip,
@@ -308,15 +406,75 @@ func measureDatabasSize(flg flags, rdb dbtesterpb.DatabaseID) (int64, error) {
 		dbtesterpb.DatabaseID_etcd__v3_3,
 		dbtesterpb.DatabaseID_cetcd__beta,
 		dbtesterpb.DatabaseID_zetcd__beta:
-		return fileinspect.Size(flg.etcdDataDir)
+		return flg.etcdDataDir, nil
 
 	case dbtesterpb.DatabaseID_zookeeper__r3_5_3_beta:
-		return fileinspect.Size(flg.zkDataDir)
+		return flg.zkDataDir, nil
 
 	case dbtesterpb.DatabaseID_consul__v1_0_2:
-		return fileinspect.Size(flg.consulDataDir)
+		return flg.consulDataDir, nil
+
+	default:
+		return "", fmt.Errorf("uknown %q", rdb)
+	}
+}
 
+func measureDatabasSize(flg flags, rdb dbtesterpb.DatabaseID) (int64, error) {
+	dir, err := dataDir(flg, rdb)
+	if err != nil {
+		return 0, err
+	}
+	return fileinspect.Size(dir)
+}
+
+// running returns true if the database process is running.
+func (t *transporterServer) running() bool {
+	if t.cmd == nil {
+		return false
+	}
+	select {
+	case <-t.cmdWait:
+		return false
 	default:
-		return 0, fmt.Errorf("uknown %q", rdb)
+		return true
+	}
+}
+
+// wipe removes the data directory of the stopped database.
+func (t *transporterServer) wipe(rdb dbtesterpb.DatabaseID) error {
+	if t.running() {
+		return fmt.Errorf("database is still running (pid %d); 'Stop' or 'Shutdown' first", t.pid)
+	}
+	dir, err := dataDir(globalFlags, rdb)
+	if err != nil {
+		return err
+	}
+	t.lg.Info("removing data directory", zap.String("database", rdb.String()), zap.String("data-directory", dir))
+	return os.RemoveAll(dir)
+}
+
+// metrics returns the CPU and memory usage of the running database
+// process, and the size of its data directory.
+func (t *transporterServer) metrics() (*dbtesterpb.Response, error) {
+	if !t.running() {
+		return nil, fmt.Errorf("database is not running")
+	}
+	u, err := resource.Sample(t.pid, "", globalFlags.diskDevice, globalFlags.networkInterface)
+	if err != nil {
+		return nil, err
+	}
+	dbs, err := measureDatabasSize(globalFlags, t.req.DatabaseID)
+	if err != nil {
+		return nil, err
 	}
+	return &dbtesterpb.Response{
+		Success:              true,
+		DiskSpaceUsageBytes:  dbs,
+		CPUPercent:           u.CPUPercent,
+		VMRSSBytes:           u.VMRSSBytes,
+		DiskReadBytes:        u.DiskReadBytes,
+		DiskWriteBytes:       u.DiskWriteBytes,
+		NetworkReceiveBytes:  u.NetworkReceiveBytes,
+		NetworkTransmitBytes: u.NetworkTransmitBytes,
+	}, nil
 }
@@ -85,6 +85,27 @@ func uploadLog(fs *flags, t *transporterServer) error {
 		}
 	}
 
+	{
+		srcDatabaseConfigPath := fs.databaseConfig
+		dstDatabaseConfigPath := filepath.Base(fs.databaseConfig)
+		if !strings.HasPrefix(filepath.Base(fs.databaseConfig), t.req.DatabaseTag) {
+			dstDatabaseConfigPath = fmt.Sprintf("%s-%d-%s", t.req.DatabaseTag, t.req.IPIndex+1, filepath.Base(fs.databaseConfig))
+		}
+		dstDatabaseConfigPath = filepath.Join(t.req.ConfigClientMachineInitial.GoogleCloudStorageSubDirectory, dstDatabaseConfigPath)
+		t.lg.Info("uploading database configuration", zap.String("source", srcDatabaseConfigPath), zap.String("destination", dstDatabaseConfigPath))
+		for k := 0; k < 30; k++ {
+			if uerr = u.UploadFile(t.req.ConfigClientMachineInitial.GoogleCloudStorageBucketName, srcDatabaseConfigPath, dstDatabaseConfigPath); uerr != nil {
+				t.lg.Warn("upload error; retrying...", zap.Error(uerr))
+				time.Sleep(2 * time.Second)
+				continue
+			}
+			break
+		}
+		if uerr != nil {
+			return uerr
+		}
+	}
+
 	{
 		srcSysMetricsDataPath := fs.systemMetricsCSV
 		dstSysMetricsDataPath := filepath.Base(fs.systemMetricsCSV)
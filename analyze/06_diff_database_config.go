@@ -0,0 +1,48 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analyze
+
+import (
+	"bytes"
+
+	"github.com/coreos/dbtester"
+)
+
+// diffDatabaseConfigs compares the database server configurations that
+// the agents captured in the run of 'cfg' with those of the same
+// database in the base run of 'base', by node. It returns an empty
+// string if there is no base run, or no configuration was captured.
+func diffDatabaseConfigs(cfg, base *dbtester.Config) (string, error) {
+	if base == nil {
+		return "", nil
+	}
+	buf := new(bytes.Buffer)
+	for _, databaseID := range cfg.AllDatabaseIDList {
+		testdata := cfg.DatabaseIDToConfigAnalyzeMachineInitial[databaseID]
+		basedata, ok := base.DatabaseIDToConfigAnalyzeMachineInitial[databaseID]
+		if !ok || len(testdata.ServerDatabaseConfigPathList) == 0 || len(basedata.ServerDatabaseConfigPathList) == 0 {
+			continue
+		}
+		lg.Sugar().Infof("comparing database configuration of %q to the base run", databaseID)
+		tag := cfg.DatabaseIDToConfigClientMachineAgentControl[databaseID].DatabaseTag
+		if err := dbtester.DiffServerConfigs(buf, tag, basedata.ServerDatabaseConfigPathList, testdata.ServerDatabaseConfigPathList); err != nil {
+			return "", err
+		}
+	}
+	if buf.Len() == 0 {
+		return "", nil
+	}
+	return "SERVER-CONFIG-DIFF\n" + buf.String(), nil
+}
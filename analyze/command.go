@@ -40,13 +40,15 @@ var Command = &cobra.Command{
 }
 
 var configPath string
+var baseConfigPath string
 
 func init() {
 	Command.PersistentFlags().StringVarP(&configPath, "config", "c", "", "YAML configuration file path.")
+	Command.PersistentFlags().StringVar(&baseConfigPath, "base-config", "", "YAML configuration file path of a base run, to diff the database server configuration of each database with that of the same database in the base run, in the aggregated summary. Empty to not diff.")
 }
 
 func commandFunc(cmd *cobra.Command, args []string) error {
-	return do(configPath)
+	return do(configPath, baseConfigPath)
 }
 
 type allAggregatedData struct {
@@ -57,11 +59,17 @@ type allAggregatedData struct {
 	allDatabaseIDList           []string
 }
 
-func do(configPath string) error {
+func do(configPath, baseConfigPath string) error {
 	cfg, err := dbtester.ReadConfig(configPath, true)
 	if err != nil {
 		return err
 	}
+	var base *dbtester.Config
+	if baseConfigPath != "" {
+		if base, err = dbtester.ReadConfig(baseConfigPath, true); err != nil {
+			return err
+		}
+	}
 
 	all := &allAggregatedData{
 		title:                       cfg.TestTitle,
@@ -125,6 +133,9 @@ func do(configPath string) error {
 	row18ServerTransmitBytesSum := []string{"SERVER-TOTAL-NETWORK-TX-DATA-SUM"}
 	row18ServerTransmitBytesSumRaw := []string{"SERVER-TOTAL-NETWORK-TX-DATA-BYTES-SUM-RAW"}
 	row21ServerMaxCPUUsage := []string{"SERVER-MAX-CPU-USAGE"}
+	row31ServerCPUSecondsSum := []string{"SERVER-TOTAL-CPU-SECONDS-SUM"}
+	row32ThroughputPerServerCPUSecond := []string{"REQUESTS-PER-SERVER-CPU-SECOND"}
+	row32ThroughputPerServerCPUSecondRaw := []string{"REQUESTS-PER-SERVER-CPU-SECOND-RAW"}
 	row22ServerMaxMemoryUsage := []string{"SERVER-MAX-MEMORY-USAGE"}
 	row26ReadsCompletedDeltaSum := []string{"SERVER-AVG-READS-COMPLETED-DELTA-SUM"}
 	row27SectorsReadDeltaSum := []string{"SERVER-AVG-SECTORS-READS-DELTA-SUM"}
@@ -235,6 +246,13 @@ func do(configPath string) error {
 		row18ServerTransmitBytesSum = append(row18ServerTransmitBytesSum, humanize.Bytes(uint64(transmitBytesNumDeltaSum)))
 		row18ServerTransmitBytesSumRaw = append(row18ServerTransmitBytesSumRaw, fmt.Sprintf("%.2f", transmitBytesNumDeltaSum))
 		row21ServerMaxCPUUsage = append(row21ServerMaxCPUUsage, fmt.Sprintf("%.2f %%", maxAvgCPU))
+		cpuSecondsSum, opsPerCPUSecond, err := requestsPerServerCPUSecond(ad.aggregated, ad.databaseTag)
+		if err != nil {
+			return err
+		}
+		row31ServerCPUSecondsSum = append(row31ServerCPUSecondsSum, fmt.Sprintf("%.2f sec", cpuSecondsSum))
+		row32ThroughputPerServerCPUSecond = append(row32ThroughputPerServerCPUSecond, fmt.Sprintf("%s req/cpu-sec", humanize.Comma(int64(opsPerCPUSecond))))
+		row32ThroughputPerServerCPUSecondRaw = append(row32ThroughputPerServerCPUSecondRaw, fmt.Sprintf("%.2f", opsPerCPUSecond))
 		row26ReadsCompletedDeltaSum = append(row26ReadsCompletedDeltaSum, humanize.Comma(int64(readsCompletedDeltaSum)))
 		row27SectorsReadDeltaSum = append(row27SectorsReadDeltaSum, humanize.Comma(int64(sectorsReadDeltaSum)))
 		row28WritesCompletedDeltaSum = append(row28WritesCompletedDeltaSum, humanize.Comma(int64(writesCompletedDeltaSum)))
@@ -269,6 +287,7 @@ func do(configPath string) error {
 	row23ClientMaxCPU := []string{"CLIENT-MAX-CPU-USAGE"}                               // CPU-NUM
 	row24ClientMaxMemory := []string{"CLIENT-MAX-MEMORY-USAGE"}                         // VMRSS-NUM
 	row25ClientErrorCount := []string{"CLIENT-ERROR-COUNT"}                             // ERROR:
+	row33ClientEmptyResponseCount := []string{"CLIENT-EMPTY-RESPONSE-COUNT"}            // EMPTY-RESPONSE-COUNT
 	row30AvgDiskSpaceUsage := []string{"SERVER-AVG-DISK-SPACE-USAGE"}                   // DISK-SPACE-USAGE
 
 	databaseIDToErrs := make(map[string][]string)
@@ -377,7 +396,7 @@ func do(configPath string) error {
 				return err
 			}
 
-			var totalErrCnt int64
+			var totalErrCnt, emptyCnt int64
 			for _, row := range rows {
 				switch row[0] {
 				case "TOTAL-SECONDS":
@@ -395,6 +414,12 @@ func do(configPath string) error {
 					row06FastestLatency = append(row06FastestLatency, fmt.Sprintf("%s ms", row[1]))
 				case "AVERAGE-LATENCY-MS":
 					row07AverageLatency = append(row07AverageLatency, fmt.Sprintf("%s ms", row[1]))
+				case "EMPTY-RESPONSE-COUNT":
+					iv, err := strconv.ParseInt(row[1], 10, 64)
+					if err != nil {
+						return err
+					}
+					emptyCnt = iv
 				}
 
 				if strings.HasPrefix(row[0], "ERROR:") {
@@ -415,6 +440,7 @@ func do(configPath string) error {
 				}
 			}
 			row25ClientErrorCount = append(row25ClientErrorCount, humanize.Comma(totalErrCnt))
+			row33ClientEmptyResponseCount = append(row33ClientEmptyResponseCount, humanize.Comma(emptyCnt))
 		}
 		{
 			fr, err := dataframe.NewFromCSV(nil, testdata.ClientLatencyThroughputTimeseriesPath)
@@ -524,6 +550,8 @@ func do(configPath string) error {
 		row03MaxThroughput,
 		row04AverageThroughput,
 		row05MinThroughput,
+		row32ThroughputPerServerCPUSecond,
+		row32ThroughputPerServerCPUSecondRaw,
 
 		row06FastestLatency,
 		row07AverageLatency,
@@ -548,11 +576,13 @@ func do(configPath string) error {
 		row20ClientTransmitBytesSumRaw,
 
 		row21ServerMaxCPUUsage,
+		row31ServerCPUSecondsSum,
 		row22ServerMaxMemoryUsage,
 		row23ClientMaxCPU,
 		row24ClientMaxMemory,
 
 		row25ClientErrorCount,
+		row33ClientEmptyResponseCount,
 
 		row26ReadsCompletedDeltaSum,
 		row27SectorsReadDeltaSum,
@@ -582,6 +612,7 @@ func do(configPath string) error {
 		row03MaxThroughput,
 		row04AverageThroughput,
 		row05MinThroughput,
+		row32ThroughputPerServerCPUSecond,
 
 		row06FastestLatency,
 		row07AverageLatency,
@@ -602,11 +633,13 @@ func do(configPath string) error {
 		row20ClientTransmitBytesSum,
 
 		row21ServerMaxCPUUsage,
+		row31ServerCPUSecondsSum,
 		row22ServerMaxMemoryUsage,
 		row23ClientMaxCPU,
 		row24ClientMaxMemory,
 
 		row25ClientErrorCount,
+		row33ClientEmptyResponseCount,
 
 		row26ReadsCompletedDeltaSum,
 		row27SectorsReadDeltaSum,
@@ -635,6 +668,13 @@ func do(configPath string) error {
 	if errs != "" {
 		stxt += "\n" + "\n" + errs
 	}
+	cdiff, err := diffDatabaseConfigs(cfg, base)
+	if err != nil {
+		return err
+	}
+	if cdiff != "" {
+		stxt += "\n" + "\n" + cdiff
+	}
 	if err := toFile(stxt, changeExtToTxt(cfg.ConfigAnalyzeMachineAllAggregatedOutput.AllAggregatedOutputPathTXT)); err != nil {
 		return err
 	}
@@ -1142,6 +1182,44 @@ func do(configPath string) error {
 	return cfg.WriteREADME(stxt)
 }
 
+// requestsPerServerCPUSecond returns the total CPU-seconds spent by the
+// database servers and the requests handled per server CPU-second, from
+// the aggregated frame of a database whose headers are suffixed by tag.
+func requestsPerServerCPUSecond(fr dataframe.Frame, tag string) (cpuSecondsSum, opsPerCPUSecond float64, err error) {
+	var requestsHandled float64
+	for _, col := range fr.Columns() {
+		hdr := strings.TrimSuffix(col.Header(), "-"+tag)
+
+		var sum *float64
+		scale := 1.0
+		switch {
+		case hdr == "AVG-THROUGHPUT":
+			sum = &requestsHandled
+		case strings.HasPrefix(hdr, "CPU-"): // CPU-NUM was converted to CPU-1, CPU-2, CPU-3
+			// CPU-NUM is sampled every second in percent of one core,
+			// so the sum over all server nodes divided by 100 is
+			// the total CPU-seconds spent by the database servers
+			sum, scale = &cpuSecondsSum, 100.0
+		default:
+			continue
+		}
+
+		cnt := col.Count()
+		for j := 0; j < cnt; j++ {
+			vv, err := col.Value(j)
+			if err != nil {
+				return 0, 0, err
+			}
+			fv, _ := vv.Float64()
+			*sum += fv / scale
+		}
+	}
+	if cpuSecondsSum > 0 {
+		opsPerCPUSecond = requestsHandled / cpuSecondsSum
+	}
+	return cpuSecondsSum, opsPerCPUSecond, nil
+}
+
 func changeExtToTxt(fpath string) string {
 	ext := filepath.Ext(fpath)
 	return strings.Replace(fpath, ext, ".txt", -1)
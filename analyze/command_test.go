@@ -0,0 +1,56 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analyze
+
+import (
+	"testing"
+
+	"github.com/gyuho/dataframe"
+)
+
+func TestRequestsPerServerCPUSecond(t *testing.T) {
+	const tag = "etcd-v3.3-go1.9"
+
+	fr := dataframe.New()
+	for hdr, vs := range map[string][]string{
+		"AVG-THROUGHPUT": {"1000", "3000"},
+		"AVG-CPU":        {"50", "50"},
+		"CPU-1":          {"50", "50"},
+		"CPU-2":          {"100", "100"},
+	} {
+		col := dataframe.NewColumn(hdr)
+		for _, v := range vs {
+			col.PushBack(dataframe.NewStringValue(v))
+		}
+		if err := fr.AddColumn(col); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// aggregateAll suffixes every aggregated header with the database tag
+	for _, col := range fr.Columns() {
+		col.UpdateHeader(makeHeader(col.Header(), tag))
+	}
+
+	cpuSeconds, ops, err := requestsPerServerCPUSecond(fr, tag)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cpuSeconds != 3 {
+		t.Fatalf("cpu-seconds expected 3, got %f", cpuSeconds)
+	}
+	if ops != 4000.0/3 {
+		t.Fatalf("requests per server CPU-second expected %f, got %f", 4000.0/3, ops)
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/coreos/dbtester/dbtesterpb"
+
+	"github.com/coreos/etcd/clientv3"
+	"github.com/samuel/go-zookeeper/zk"
+	"golang.org/x/net/context"
+)
+
+// hasClientAuth returns true if the benchmark authenticates
+// with etcd, Consul or Zookeeper.
+func hasClientAuth(opts *dbtesterpb.ConfigClientMachineBenchmarkOptions) bool {
+	return opts.EtcdUsername != "" || opts.ConsulToken != "" || opts.ZookeeperDigestAuth != ""
+}
+
+// withoutClientAuth runs the function with all clients unauthenticated,
+// and restores the credentials after.
+func withoutClientAuth(f func() error) error {
+	user, password, token, auth, acl := etcdAuthUser, etcdAuthPassword, consulToken, zkAuth, zkCreateACL
+	defer func() {
+		etcdAuthUser, etcdAuthPassword, consulToken, zkAuth, zkCreateACL = user, password, token, auth, acl
+	}()
+	etcdAuthUser, etcdAuthPassword, consulToken, zkAuth, zkCreateACL = "", "", "", nil, zk.WorldACL(zk.PermAll)
+	return f()
+}
+
+// runAuthBaseline runs the workload without authentication, to measure
+// the overhead of the authenticated run. The cluster must accept
+// anonymous requests: Consul with the 'allow' default ACL policy, and
+// Zookeeper always does. etcd rejects anonymous requests once auth is
+// enabled, so 'etcd_rbac' measures its overhead instead.
+func (cfg *Config) runAuthBaseline(gcfg dbtesterpb.ConfigClientMachineAgentControl, vals values) (lat time.Duration, err error) {
+	if !hasClientAuth(gcfg.ConfigClientMachineBenchmarkOptions) {
+		return 0, fmt.Errorf("'--auth-overhead' requires '--user', '--consul-token' or '--zk-auth' for %q", gcfg.DatabaseID)
+	}
+	err = withoutClientAuth(func() error {
+		lat, err = cfg.runBaseline(gcfg, vals, "auth baseline without credentials")
+		return err
+	})
+	return lat, err
+}
+
+// runBaseline runs the workload with at most 'maxCalibrationRequests'
+// requests on the largest number of clients, and returns the average
+// latency, to compare the benchmark against.
+func (cfg *Config) runBaseline(gcfg dbtesterpb.ConfigClientMachineAgentControl, vals values, desc string) (time.Duration, error) {
+	// benchmark options are shared by pointer, copy before overwriting
+	copied := gcfg
+	opts := *gcfg.ConfigClientMachineBenchmarkOptions
+	copied.ConfigClientMachineBenchmarkOptions = &opts
+	copied.ConfigClientMachineBenchmarkOptions.Prepopulate = 0
+	copied.ConfigClientMachineBenchmarkOptions.DurationSeconds = 0
+	if n := len(gcfg.ConfigClientMachineBenchmarkOptions.ConnectionClientNumbers); n > 0 {
+		copied.ConfigClientMachineBenchmarkOptions.ConnectionNumber = gcfg.ConfigClientMachineBenchmarkOptions.ConnectionClientNumbers[n-1]
+		copied.ConfigClientMachineBenchmarkOptions.ClientNumber = gcfg.ConfigClientMachineBenchmarkOptions.ConnectionClientNumbers[n-1]
+	}
+	if copied.ConfigClientMachineBenchmarkOptions.RequestNumber > maxCalibrationRequests {
+		copied.ConfigClientMachineBenchmarkOptions.RequestNumber = maxCalibrationRequests
+	}
+	reqN := copied.ConfigClientMachineBenchmarkOptions.RequestNumber
+	clientN := copied.ConfigClientMachineBenchmarkOptions.ClientNumber
+
+	var (
+		h      []ReqHandler
+		done   func()
+		reqGen func(context.Context, chan<- request)
+	)
+	switch copied.ConfigClientMachineBenchmarkOptions.Type {
+	case "write":
+		h, done = newWriteHandlers(cfg.lg, copied)
+		reqGen = func(ctx context.Context, inflightReqs chan<- request) {
+			generateWrites(ctx, copied, 0, vals, inflightReqs)
+		}
+
+	case "read", "read-oneshot":
+		switch copied.DatabaseID {
+		case "etcd__other", "etcd__tip", "etcd__v3_2", "etcd__v3_3":
+		default:
+			return 0, fmt.Errorf("%q is not supported for %s of %q", copied.ConfigClientMachineBenchmarkOptions.Type, desc, copied.DatabaseID)
+		}
+		key := namespaced(copied, sameKey(copied.ConfigClientMachineBenchmarkOptions.KeySizeBytes))
+		cli := mustCreateConnEtcdv3(copied.DatabaseEndpoints)
+		_, err := cli.Do(cfg.runContext(), clientv3.OpPut(key, vals.strings[0]))
+		cli.Close()
+		if err != nil {
+			return 0, err
+		}
+		h, done = newReadHandlers(copied)
+		reqGen = func(ctx context.Context, inflightReqs chan<- request) {
+			generateReads(ctx, copied, key, nil, inflightReqs)
+		}
+
+	default:
+		return 0, fmt.Errorf("%q is not supported for %s", copied.ConfigClientMachineBenchmarkOptions.Type, desc)
+	}
+
+	cfg.lg.Sugar().Infof("%s started [requests: %d | clients: %d]", desc, reqN, clientN)
+	b := newBenchmark(reqN, clientN, h, done, reqGen)
+	b.progress.interval = cfg.ProgressInterval
+	b.ctx = cfg.runContext()
+	b.startRequests()
+	b.waitAll()
+	printStats(b.stats)
+
+	lat := time.Duration(b.stats.Average * float64(time.Second))
+	cfg.lg.Sugar().Infof("%s done [average latency: %v]", desc, lat)
+	return lat, nil
+}
@@ -0,0 +1,67 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/coreos/dbtester/dbtesterpb"
+
+	"github.com/samuel/go-zookeeper/zk"
+	"go.uber.org/zap"
+)
+
+func TestWithoutClientAuth(t *testing.T) {
+	setEtcdAuth("user", "password")
+	consulToken = "token"
+	if err := setZkDigestAuth("user:password"); err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		setEtcdAuth("", "")
+		consulToken, zkAuth, zkCreateACL = "", nil, zk.WorldACL(zk.PermAll)
+	}()
+
+	err := withoutClientAuth(func() error {
+		if etcdAuthUser != "" || etcdAuthPassword != "" || consulToken != "" || zkAuth != nil {
+			t.Fatal("expected no credentials")
+		}
+		if !reflect.DeepEqual(zkCreateACL, zk.WorldACL(zk.PermAll)) {
+			t.Fatalf("expected open ACL, got %+v", zkCreateACL)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if etcdAuthUser != "user" || etcdAuthPassword != "password" || consulToken != "token" || string(zkAuth) != "user:password" {
+		t.Fatal("expected the credentials to be restored")
+	}
+	if !reflect.DeepEqual(zkCreateACL, zk.DigestACL(zk.PermAll, "user", "password")) {
+		t.Fatalf("expected digest ACL, got %+v", zkCreateACL)
+	}
+}
+
+func TestRunAuthBaselineWithoutAuth(t *testing.T) {
+	cfg := &Config{lg: zap.NewNop()}
+	_, err := cfg.runAuthBaseline(dbtesterpb.ConfigClientMachineAgentControl{
+		DatabaseID:                          "consul__v1_0_2",
+		ConfigClientMachineBenchmarkOptions: &dbtesterpb.ConfigClientMachineBenchmarkOptions{Type: "write"},
+	}, values{})
+	if err == nil {
+		t.Fatal("expected error of baseline without credentials configured")
+	}
+}
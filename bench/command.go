@@ -0,0 +1,142 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package bench runs the control tests of the phases of a workload.
+package bench
+
+import (
+	"fmt"
+
+	"github.com/coreos/dbtester"
+	"github.com/coreos/dbtester/control"
+	"github.com/coreos/dbtester/matrix"
+
+	"github.com/coreos/etcd/pkg/netutil"
+	"github.com/gyuho/linux-inspect/df"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+// Command implements 'bench' command.
+var Command = &cobra.Command{
+	Use:   "bench",
+	Short: "Runs workloads of multiple phases.",
+}
+
+var runCommand = &cobra.Command{
+	Use:   "run",
+	Short: "Runs the phases of the workload in order.",
+	Long: `Runs the phases of the workload spec in order (e.g. warmup, write, read
+and teardown) against the database of the base control configuration,
+each with its benchmark options overwriting the base configuration. The
+database is started before the first phase and stopped after the last
+phase, if the base configuration does, and the results of the phases
+that are not warmup are combined into 'output_path_csv'.`,
+	RunE: runCommandFunc,
+}
+
+var specPath string
+var diskDevice string
+var networkInterface string
+var force bool
+
+func init() {
+	dn, err := df.GetDevice("/")
+	if err != nil {
+		lg.Warn("cannot get disk device mounted at '/'", zap.Error(err))
+	}
+	nm, err := netutil.GetDefaultInterfaces()
+	if err != nil {
+		lg.Warn("cannot detect default network interface", zap.Error(err))
+	}
+	var nt string
+	for k := range nm {
+		nt = k
+		break
+	}
+
+	runCommand.PersistentFlags().StringVarP(&specPath, "config", "c", "", "YAML workload spec file path.")
+	runCommand.PersistentFlags().StringVar(&diskDevice, "disk-device", dn, "Disk device to collect disk statistics metrics from.")
+	runCommand.PersistentFlags().StringVar(&networkInterface, "network-interface", nt, "Network interface to record in/outgoing packets.")
+	runCommand.PersistentFlags().BoolVar(&force, "force", false, "Run even if key or value sizes exceed the request size limits of the database.")
+	Command.AddCommand(runCommand)
+}
+
+// PhaseColumns defines the columns of the combined CSV.
+var PhaseColumns = []string{
+	"PHASE",
+	"TYPE",
+	"CLIENT-NUMBER",
+	"TOTAL-SECONDS",
+	"REQUESTS-PER-SECOND",
+	"FASTEST-LATENCY-MS",
+	"AVERAGE-LATENCY-MS",
+	"SLOWEST-LATENCY-MS",
+	"STDDEV-LATENCY-MS",
+	"P50-LATENCY-MS",
+	"P90-LATENCY-MS",
+	"P99-LATENCY-MS",
+	"ERROR-COUNT",
+	"EMPTY-RESPONSE-COUNT",
+}
+
+func runCommandFunc(cmd *cobra.Command, args []string) error {
+	sp, err := ReadSpec(specPath)
+	if err != nil {
+		return err
+	}
+	lg.Info("starting workload", zap.String("spec", specPath), zap.String("database", sp.DatabaseID), zap.Int("phases", len(sp.Phases)))
+
+	steps := make([]control.Step, len(sp.Phases))
+	for i := range sp.Phases {
+		i, ph := i, sp.Phases[i]
+		steps[i] = control.Step{
+			Name:       ph.Name,
+			DatabaseID: sp.DatabaseID,
+			Apply: func(cfg *dbtester.Config) error {
+				return apply(cfg, sp.DatabaseID, ph, i, len(sp.Phases))
+			},
+			Row: func(cfg *dbtester.Config) ([]string, error) {
+				if ph.Warmup {
+					return nil, nil
+				}
+				kv, err := matrix.ReadResult(cfg)
+				if err != nil {
+					return nil, err
+				}
+				opts := cfg.DatabaseIDToConfigClientMachineAgentControl[sp.DatabaseID].ConfigClientMachineBenchmarkOptions
+				row := []string{ph.Name, opts.Type, fmt.Sprintf("%d", opts.ClientNumber)}
+				for _, col := range PhaseColumns[3:] {
+					row = append(row, kv[col])
+				}
+				return row, nil
+			},
+		}
+	}
+	sq := control.Sequence{
+		ConfigPath:       sp.ConfigPath,
+		Cooldown:         sp.Cooldown,
+		Force:            force,
+		DiskDevice:       diskDevice,
+		NetworkInterface: networkInterface,
+		Columns:          PhaseColumns,
+		OutputPathCSV:    sp.OutputPathCSV,
+	}
+	if err = control.RunSteps(sq, steps); err != nil {
+		return err
+	}
+
+	lg.Info("all phases done!", zap.String("path", sp.OutputPathCSV))
+	return nil
+}
@@ -0,0 +1,99 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bench
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/coreos/dbtester"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+)
+
+var compareCommand = &cobra.Command{
+	Use:   "compare <base.json> <current.json>",
+	Short: "Compares the results of two runs, and fails on regressions.",
+	Long: `Compares the results of 'control --output-format json' of the current run
+with those of the base run: the throughput, the average latency, the
+latency percentiles and the error rate. It exits non-zero if any of them
+regresses over the thresholds, such as to gate a release on the results
+of the previous release.
+
+With '--base-server-configs' and '--current-server-configs', it also
+prints the differences of the database server configurations that the
+agents captured in the two runs, to tell what changed between them.`,
+	Args: cobra.ExactArgs(2),
+	RunE: compareCommandFunc,
+}
+
+var thresholds dbtester.CompareThresholds
+var baseServerConfigs []string
+var currentServerConfigs []string
+
+func init() {
+	compareCommand.Flags().Float64Var(&thresholds.ThroughputDropPercent, "max-throughput-drop", 5, "Drop of the requests per second, in percent of the base, over which the throughput regresses.")
+	compareCommand.Flags().Float64Var(&thresholds.LatencyIncreasePercent, "max-latency-increase", 10, "Increase of the average latency and of each latency percentile, in percent of the base, over which the latency regresses.")
+	compareCommand.Flags().Float64Var(&thresholds.ErrorRateIncreasePoints, "max-error-rate-increase", 0.1, "Increase of the percentage of the failed requests, in percentage points, over which the error rate regresses.")
+	compareCommand.Flags().StringSliceVar(&baseServerConfigs, "base-server-configs", nil, "Database server configurations that the agents captured in the base run ('--database-config' of 'agent'), in the order of the nodes.")
+	compareCommand.Flags().StringSliceVar(&currentServerConfigs, "current-server-configs", nil, "Database server configurations that the agents captured in the current run, in the order of the nodes of '--base-server-configs'.")
+	Command.AddCommand(compareCommand)
+}
+
+func compareCommandFunc(cmd *cobra.Command, args []string) error {
+	// a regression is not a misuse of the command
+	cmd.SilenceUsage = true
+
+	if (len(baseServerConfigs) == 0) != (len(currentServerConfigs) == 0) {
+		return fmt.Errorf("both '--base-server-configs' and '--current-server-configs' are required")
+	}
+	ds, err := dbtester.CompareResultFiles(args[0], args[1], thresholds)
+	if err != nil {
+		return err
+	}
+
+	regressed := 0
+	tw := tablewriter.NewWriter(os.Stdout)
+	tw.SetHeader([]string{"METRIC", "BASE", "CURRENT", "DELTA", "REGRESSION"})
+	for _, d := range ds {
+		mark := ""
+		if d.Regressed {
+			mark = "REGRESSED"
+			regressed++
+		}
+		tw.Append([]string{
+			d.Metric,
+			fmt.Sprintf("%.4f", d.Base),
+			fmt.Sprintf("%.4f", d.Current),
+			fmt.Sprintf("%+.2f %s", d.Delta, d.Unit),
+			mark,
+		})
+	}
+	tw.SetAutoFormatHeaders(false)
+	tw.Render()
+
+	if len(baseServerConfigs) > 0 {
+		fmt.Println("\nSERVER-CONFIG-DIFF")
+		if err = dbtester.DiffServerConfigs(os.Stdout, "", baseServerConfigs, currentServerConfigs); err != nil {
+			return err
+		}
+	}
+
+	if regressed > 0 {
+		return fmt.Errorf("%d of %d metrics of %q regressed from %q", regressed, len(ds), args[1], args[0])
+	}
+	return nil
+}
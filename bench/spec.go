@@ -0,0 +1,138 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bench
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"time"
+
+	"github.com/coreos/dbtester"
+	"github.com/coreos/dbtester/dbtesterpb"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Spec defines the phases of a workload, which run in order against
+// one database of the base control configuration.
+type Spec struct {
+	// ConfigPath is the base control configuration file path,
+	// relative to the spec file if not absolute.
+	ConfigPath string `yaml:"config_path"`
+	DatabaseID string `yaml:"database_id"`
+
+	Phases []Phase `yaml:"phases"`
+
+	CooldownString string        `yaml:"cooldown"`
+	OutputPathCSV  string        `yaml:"output_path_csv"`
+	Cooldown       time.Duration `yaml:"-"`
+}
+
+// Phase is one phase of the workload (e.g. warmup, write, read, teardown).
+type Phase struct {
+	Name string `yaml:"name"`
+	// BenchmarkOptions overwrites the benchmark options of the base
+	// configuration for the phase, of the same keys as 'benchmark_options'.
+	BenchmarkOptions map[string]interface{} `yaml:"benchmark_options"`
+	// Warmup is true to leave the results of the phase out of the
+	// combined CSV, as of the phases that warm up the caches of the
+	// database before the measured phases.
+	Warmup bool `yaml:"warmup"`
+}
+
+// ReadSpec reads the workload spec file.
+func ReadSpec(fpath string) (*Spec, error) {
+	bts, err := ioutil.ReadFile(fpath)
+	if err != nil {
+		return nil, err
+	}
+	sp := Spec{}
+	if err = yaml.UnmarshalStrict(bts, &sp); err != nil {
+		return nil, err
+	}
+
+	if sp.ConfigPath == "" {
+		return nil, fmt.Errorf("'config_path' is not given in %q", fpath)
+	}
+	if !filepath.IsAbs(sp.ConfigPath) {
+		sp.ConfigPath = filepath.Join(filepath.Dir(fpath), sp.ConfigPath)
+	}
+	if !dbtesterpb.IsValidDatabaseID(sp.DatabaseID) {
+		return nil, fmt.Errorf("databaseID %q is unknown", sp.DatabaseID)
+	}
+	if len(sp.Phases) == 0 {
+		return nil, fmt.Errorf("'phases' is empty in %q", fpath)
+	}
+	names := make(map[string]struct{})
+	for _, ph := range sp.Phases {
+		if ph.Name == "" {
+			return nil, fmt.Errorf("phase has no 'name' in %q", fpath)
+		}
+		if _, ok := names[ph.Name]; ok {
+			return nil, fmt.Errorf("duplicate phase %q in %q", ph.Name, fpath)
+		}
+		names[ph.Name] = struct{}{}
+	}
+	if sp.CooldownString != "" {
+		sp.Cooldown, err = time.ParseDuration(sp.CooldownString)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if sp.OutputPathCSV == "" {
+		sp.OutputPathCSV = "bench.csv"
+	}
+	return &sp, nil
+}
+
+// apply overwrites the base configuration with the phase, the i-th
+// of n phases. The database is started before the first phase only,
+// and stopped after the last phase only, so that the later phases
+// run on the data of the earlier phases.
+func apply(cfg *dbtester.Config, databaseID string, ph Phase, i, n int) error {
+	gcfg, ok := cfg.DatabaseIDToConfigClientMachineAgentControl[databaseID]
+	if !ok {
+		return fmt.Errorf("%q is not found in the base configuration", databaseID)
+	}
+
+	if len(ph.BenchmarkOptions) > 0 {
+		bts, err := yaml.Marshal(ph.BenchmarkOptions)
+		if err != nil {
+			return err
+		}
+		// unknown keys are typos, not to run the phase with the base options
+		if err = yaml.UnmarshalStrict(bts, gcfg.ConfigClientMachineBenchmarkOptions); err != nil {
+			return fmt.Errorf("phase %q: %v", ph.Name, err)
+		}
+	}
+
+	steps := gcfg.ConfigClientMachineBenchmarkSteps
+	if steps == nil {
+		steps = &dbtesterpb.ConfigClientMachineBenchmarkSteps{}
+		gcfg.ConfigClientMachineBenchmarkSteps = steps
+	}
+	steps.Step1StartDatabase = steps.Step1StartDatabase && i == 0
+	steps.Step2StressDatabase = true
+	steps.Step3StopDatabase = steps.Step3StopDatabase && i == n-1
+	steps.Step4UploadLogs = steps.Step4UploadLogs && i == n-1
+	cfg.DatabaseIDToConfigClientMachineAgentControl[databaseID] = gcfg
+
+	// upload each phase to its own directory
+	if cfg.ConfigClientMachineInitial.GoogleCloudStorageSubDirectory != "" {
+		cfg.ConfigClientMachineInitial.GoogleCloudStorageSubDirectory = filepath.Join(cfg.ConfigClientMachineInitial.GoogleCloudStorageSubDirectory, ph.Name)
+	}
+	return nil
+}
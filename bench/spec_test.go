@@ -0,0 +1,100 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bench
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/coreos/dbtester"
+)
+
+func TestSpec(t *testing.T) {
+	sp, err := ReadSpec("../test-configs/bench-phases-mock.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sp.ConfigPath != filepath.Join("../test-configs", "write-100K-keys-mock.yaml") {
+		t.Fatalf("unexpected config path %q", sp.ConfigPath)
+	}
+	if sp.Cooldown != time.Second || len(sp.Phases) != 4 || !sp.Phases[0].Warmup {
+		t.Fatalf("unexpected spec %+v", sp)
+	}
+
+	n := len(sp.Phases)
+	for i, ph := range sp.Phases {
+		cfg, err := dbtester.ReadConfig(sp.ConfigPath, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		gcfg := cfg.DatabaseIDToConfigClientMachineAgentControl[sp.DatabaseID]
+		gcfg.ConfigClientMachineBenchmarkSteps.Step1StartDatabase = true
+		gcfg.ConfigClientMachineBenchmarkSteps.Step3StopDatabase = true
+		if err = apply(cfg, sp.DatabaseID, ph, i, n); err != nil {
+			t.Fatal(err)
+		}
+
+		gcfg = cfg.DatabaseIDToConfigClientMachineAgentControl[sp.DatabaseID]
+		opts := gcfg.ConfigClientMachineBenchmarkOptions
+		if opts.Type != ph.BenchmarkOptions["type"] {
+			t.Fatalf("phase %q: expected type %v, got %q", ph.Name, ph.BenchmarkOptions["type"], opts.Type)
+		}
+		// not overwritten by the phase
+		if opts.ValueSizeBytes != 1024 {
+			t.Fatalf("phase %q: expected value size 1024, got %d", ph.Name, opts.ValueSizeBytes)
+		}
+		steps := gcfg.ConfigClientMachineBenchmarkSteps
+		if steps.Step1StartDatabase != (i == 0) || !steps.Step2StressDatabase || steps.Step3StopDatabase != (i == n-1) {
+			t.Fatalf("phase %q: unexpected steps %+v", ph.Name, steps)
+		}
+	}
+}
+
+func TestSpecInvalid(t *testing.T) {
+	dir, err := ioutil.TempDir(os.TempDir(), "bench-spec")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	tests := []string{
+		"database_id: mock\nphases:\n- name: a\n",
+		"config_path: a.yaml\ndatabase_id: unknown\nphases:\n- name: a\n",
+		"config_path: a.yaml\ndatabase_id: mock\n",
+		"config_path: a.yaml\ndatabase_id: mock\nphases:\n- name: a\n- name: a\n",
+		"config_path: a.yaml\ndatabase_id: mock\nphase:\n- name: a\n",
+	}
+	for i, s := range tests {
+		fpath := filepath.Join(dir, "spec.yaml")
+		if err = ioutil.WriteFile(fpath, []byte(s), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err = ReadSpec(fpath); err == nil {
+			t.Fatalf("#%d: expected error", i)
+		}
+	}
+
+	cfg, err := dbtester.ReadConfig("../test-configs/write-100K-keys-mock.yaml", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ph := Phase{Name: "typo", BenchmarkOptions: map[string]interface{}{"client_numbr": 1}}
+	if err = apply(cfg, "mock", ph, 0, 1); err == nil {
+		t.Fatal("expected error of unknown benchmark option")
+	}
+}
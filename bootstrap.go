@@ -0,0 +1,211 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/coreos/dbtester/dbtesterpb"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/gyuho/dataframe"
+	consulapi "github.com/hashicorp/consul/api"
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+)
+
+const (
+	// bootstrapTimeout is the maximum time to wait for
+	// the cluster to serve the first linearizable read.
+	bootstrapTimeout = 5 * time.Minute
+	// bootstrapProbeInterval is the interval between read probes.
+	bootstrapProbeInterval = 10 * time.Millisecond
+)
+
+// bootstrapTime is the time for the cluster to serve
+// the first linearizable read after its members start.
+type bootstrapTime struct {
+	// stage is 'start' for the members started with no data,
+	// or 'restart' for the members restarted with their data.
+	stage string
+
+	firstStart time.Time
+	lastStart  time.Time
+	firstRead  time.Time
+
+	diskSpaceUsageBytes int64
+}
+
+// took returns the time from the last member start to the first read,
+// since agents start members one by one. It is 0 if a quorum of members
+// serves the read before the last member starts.
+func (bt bootstrapTime) took() time.Duration {
+	if d := bt.firstRead.Sub(bt.lastStart); d > 0 {
+		return d
+	}
+	return 0
+}
+
+// newBootstrapProbe returns the function that returns nil
+// when a linearizable read succeeds.
+func newBootstrapProbe(gcfg dbtesterpb.ConfigClientMachineAgentControl) (probe func(context.Context) error, done func()) {
+	key := namespaced(gcfg, "dbtester-bootstrap-probe")
+	switch gcfg.DatabaseID {
+	case "etcd__other", "etcd__tip", "etcd__v3_2", "etcd__v3_3":
+		cli := mustCreateConnEtcdv3(gcfg.DatabaseEndpoints)
+		// etcd reads are linearizable by default
+		probe = func(ctx context.Context) error {
+			_, err := cli.Get(ctx, key)
+			return err
+		}
+		done = func() { cli.Close() }
+
+	case "zookeeper__r3_5_3_beta", "zetcd__beta":
+		conns := mustCreateConnsZk(gcfg.DatabaseEndpoints, 1)
+		// sync before read, for Zookeeper reads are served locally
+		probe = func(ctx context.Context) error {
+			if _, err := conns[0].Sync("/"); err != nil {
+				return err
+			}
+			_, _, err := conns[0].Exists("/")
+			return err
+		}
+		done = func() { conns[0].Close() }
+
+	case "consul__v1_0_2", "cetcd__beta":
+		conns := mustCreateConnsConsul(gcfg.DatabaseEndpoints, 1)
+		probe = func(ctx context.Context) error {
+			_, _, err := conns[0].Get(key, &consulapi.QueryOptions{RequireConsistent: true})
+			return err
+		}
+		done = func() {}
+
+	case "redis__v4_0":
+		clis := mustCreateConnsRedis(gcfg.DatabaseEndpoints, 1, gcfg.Flag_Redis_V4_0)
+		probe = func(ctx context.Context) error {
+			_, err := clis[0].Get(ctx, []byte(key))
+			return err
+		}
+		done = closeConnsRedis(clis)
+
+	case "tikv__v2_1":
+		clis := mustCreateConnsTikv(gcfg, 1)
+		probe = func(ctx context.Context) error {
+			_, err := clis[0].Get(ctx, []byte(key))
+			return err
+		}
+		done = closeConnsTikv(clis)
+
+	default:
+		probe = func(context.Context) error { return nil }
+		done = func() {}
+	}
+	return probe, done
+}
+
+// waitFirstRead probes the cluster until a linearizable read succeeds.
+func waitFirstRead(probe func(context.Context) error, timeout time.Duration) (time.Time, error) {
+	deadline := time.Now().Add(timeout)
+	var err error
+	for time.Now().Before(deadline) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		err = probe(ctx)
+		cancel()
+		if err == nil {
+			return time.Now(), nil
+		}
+		time.Sleep(bootstrapProbeInterval)
+	}
+	return time.Time{}, fmt.Errorf("no linearizable read succeeded in %v (%v)", timeout, err)
+}
+
+// MeasureBootstrap measures the time from the start of the database
+// processes to the first linearizable read, with the agent responses
+// to 'Start' or 'Restart' operations, and saves all measurements so far.
+func (cfg *Config) MeasureBootstrap(databaseID, stage string, idxToResp map[int]dbtesterpb.Response) error {
+	gcfg, ok := cfg.DatabaseIDToConfigClientMachineAgentControl[databaseID]
+	if !ok {
+		return fmt.Errorf("%q does not exist", databaseID)
+	}
+
+	if len(idxToResp) == 0 {
+		cfg.lg.Warn("no agent to measure bootstrap time", zap.String("database", databaseID))
+		return nil
+	}
+
+	bt := bootstrapTime{stage: stage}
+	for _, resp := range idxToResp {
+		if resp.DatabaseStartUnixNanosecond == 0 {
+			return fmt.Errorf("no database start time in %q response (agent too old?)", stage)
+		}
+		st := time.Unix(0, resp.DatabaseStartUnixNanosecond)
+		if bt.firstStart.IsZero() || st.Before(bt.firstStart) {
+			bt.firstStart = st
+		}
+		if st.After(bt.lastStart) {
+			bt.lastStart = st
+		}
+		bt.diskSpaceUsageBytes += resp.DiskSpaceUsageBytes
+	}
+
+	probe, done := newBootstrapProbe(gcfg)
+	defer done()
+	var err error
+	if bt.firstRead, err = waitFirstRead(probe, bootstrapTimeout); err != nil {
+		return err
+	}
+	cfg.bootstrapTimes = append(cfg.bootstrapTimes, bt)
+	cfg.lg.Sugar().Infof("cluster served first linearizable read [stage: %s | took: %v | since first member start: %v | disk space usage: %s]",
+		stage, bt.took(), bt.firstRead.Sub(bt.firstStart), humanize.Bytes(uint64(bt.diskSpaceUsageBytes)))
+
+	return cfg.saveBootstrapTimes()
+}
+
+func (cfg *Config) saveBootstrapTimes() error {
+	fpath := cfg.ConfigClientMachineInitial.ClientBootstrapTimePath
+	if fpath == "" {
+		cfg.lg.Warn("'client_bootstrap_time_path' is not set; skipping bootstrap time")
+		return nil
+	}
+
+	c1 := dataframe.NewColumn("STAGE")
+	c2 := dataframe.NewColumn("FIRST-MEMBER-START-UNIX-NANOSECOND")
+	c3 := dataframe.NewColumn("LAST-MEMBER-START-UNIX-NANOSECOND")
+	c4 := dataframe.NewColumn("FIRST-READ-UNIX-NANOSECOND")
+	c5 := dataframe.NewColumn("BOOTSTRAP-TIME-MS")
+	c6 := dataframe.NewColumn("SINCE-FIRST-MEMBER-START-MS")
+	c7 := dataframe.NewColumn("DISK-SPACE-USAGE-BYTES")
+	for _, bt := range cfg.bootstrapTimes {
+		c1.PushBack(dataframe.NewStringValue(bt.stage))
+		c2.PushBack(dataframe.NewStringValue(bt.firstStart.UnixNano()))
+		c3.PushBack(dataframe.NewStringValue(bt.lastStart.UnixNano()))
+		c4.PushBack(dataframe.NewStringValue(bt.firstRead.UnixNano()))
+		c5.PushBack(dataframe.NewStringValue(fmt.Sprintf("%4.4f", toMillisecond(bt.took()))))
+		c6.PushBack(dataframe.NewStringValue(fmt.Sprintf("%4.4f", toMillisecond(bt.firstRead.Sub(bt.firstStart)))))
+		c7.PushBack(dataframe.NewStringValue(bt.diskSpaceUsageBytes))
+	}
+	fr := dataframe.New()
+	for _, col := range []dataframe.Column{c1, c2, c3, c4, c5, c6, c7} {
+		if err := fr.AddColumn(col); err != nil {
+			return err
+		}
+	}
+	if err := saveCSV(fr, fpath); err != nil {
+		return err
+	}
+	cfg.lg.Info("saved bootstrap time", zap.String("path", fpath))
+	return nil
+}
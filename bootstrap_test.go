@@ -0,0 +1,48 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+func TestWaitFirstRead(t *testing.T) {
+	n := 0
+	probe := func(context.Context) error {
+		n++
+		if n < 3 {
+			return errors.New("no leader")
+		}
+		return nil
+	}
+	if _, err := waitFirstRead(probe, time.Minute); err != nil || n != 3 {
+		t.Fatalf("expected first read on 3rd probe, got %d (%v)", n, err)
+	}
+
+	fail := func(context.Context) error { return errors.New("no leader") }
+	if _, err := waitFirstRead(fail, 50*time.Millisecond); err == nil {
+		t.Fatal("expected timeout error")
+	}
+
+	now := time.Now()
+	bt := bootstrapTime{lastStart: now, firstRead: now.Add(-time.Second)}
+	if bt.took() != 0 {
+		t.Fatalf("expected 0 when quorum serves before last member start, got %v", bt.took())
+	}
+}
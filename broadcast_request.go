@@ -45,37 +45,11 @@ func (cfg *Config) BroadcaseRequest(databaseID string, op dbtesterpb.Operation)
 		ep := gcfg.AgentEndpoints[i]
 
 		go func(i int, ep string, req *dbtesterpb.Request) {
-			cfg.lg.Info("sending message",
-				zap.Int("index", i),
-				zap.String("endpoint", ep),
-				zap.String("operation", op.String()),
-				zap.String("database", req.DatabaseID.String()),
-			)
-			conn, err := grpc.Dial(ep, grpc.WithInsecure())
+			resp, err := cfg.transfer(i, ep, req)
 			if err != nil {
-				errc <- fmt.Errorf("%v (%q)", err, ep)
+				errc <- err
 				return
 			}
-			defer conn.Close()
-
-			// give enough timeout
-			// e.g. uploading logs takes longer
-			cli := dbtesterpb.NewTransporterClient(conn)
-			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
-			resp, err := cli.Transfer(ctx, req)
-			cancel()
-			if err != nil {
-				errc <- fmt.Errorf("%v (%q)", err, ep)
-				return
-			}
-			cfg.lg.Info("received response",
-				zap.Int("index", i),
-				zap.String("endpoint", ep),
-				zap.String("operation", op.String()),
-				zap.String("database", req.DatabaseID.String()),
-				zap.String("response", fmt.Sprintf("%+v", resp)),
-			)
-
 			donec <- result{idx: i, r: *resp}
 		}(i, ep, req)
 
@@ -97,3 +71,52 @@ func (cfg *Config) BroadcaseRequest(databaseID string, op dbtesterpb.Operation)
 	}
 	return im, nil
 }
+
+// sendRequest sends request to the endpoint of the index.
+func (cfg *Config) sendRequest(databaseID string, op dbtesterpb.Operation, idx int) (*dbtesterpb.Response, error) {
+	gcfg, ok := cfg.DatabaseIDToConfigClientMachineAgentControl[databaseID]
+	if !ok {
+		return nil, fmt.Errorf("database id %q does not exist", databaseID)
+	}
+	if idx < 0 || idx >= len(gcfg.AgentEndpoints) {
+		return nil, fmt.Errorf("agent index %d is out of range (%d agents)", idx, len(gcfg.AgentEndpoints))
+	}
+	req, err := cfg.ToRequest(databaseID, op, idx)
+	if err != nil {
+		return nil, err
+	}
+	return cfg.transfer(idx, gcfg.AgentEndpoints[idx], req)
+}
+
+func (cfg *Config) transfer(i int, ep string, req *dbtesterpb.Request) (*dbtesterpb.Response, error) {
+	op := req.Operation
+	cfg.lg.Info("sending message",
+		zap.Int("index", i),
+		zap.String("endpoint", ep),
+		zap.String("operation", op.String()),
+		zap.String("database", req.DatabaseID.String()),
+	)
+	conn, err := grpc.Dial(ep, grpc.WithInsecure())
+	if err != nil {
+		return nil, fmt.Errorf("%v (%q)", err, ep)
+	}
+	defer conn.Close()
+
+	// give enough timeout
+	// e.g. uploading logs takes longer
+	cli := dbtesterpb.NewTransporterClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	resp, err := cli.Transfer(ctx, req)
+	cancel()
+	if err != nil {
+		return nil, fmt.Errorf("%v (%q)", err, ep)
+	}
+	cfg.lg.Info("received response",
+		zap.Int("index", i),
+		zap.String("endpoint", ep),
+		zap.String("operation", op.String()),
+		zap.String("database", req.DatabaseID.String()),
+		zap.String("response", fmt.Sprintf("%+v", resp)),
+	)
+	return resp, nil
+}
@@ -0,0 +1,223 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bundle
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// indexPath is the path of the index in the bundle.
+const indexPath = "index.json"
+
+// categories match the file names of a run to what they record,
+// in order; the first match wins, and the rest are "other".
+var categories = []struct {
+	name     string
+	suffixes []string
+	contains []string
+}{
+	{name: "profile", suffixes: []string{".pb.gz", ".pprof", ".folded"}},
+	{name: "timeline", contains: []string{"trace", "timeline", "chaos", "rolling-restart", "failover", "schedule", "convergence", "maintenance"}},
+	{name: "server-metrics", contains: []string{"server-", "database-"}},
+	{name: "summary", contains: []string{"summary", "distribution", "aggregated"}},
+	{name: "timeseries", contains: []string{"timeseries", "by-key-number", "system-metrics"}},
+	{name: "metadata", suffixes: []string{".yaml", ".yml", ".json", ".log", ".txt", ".md"}},
+}
+
+func categorize(name string) string {
+	name = strings.ToLower(path.Base(name))
+	for _, c := range categories {
+		for _, s := range c.suffixes {
+			if strings.HasSuffix(name, s) {
+				return c.name
+			}
+		}
+		for _, s := range c.contains {
+			if strings.Contains(name, s) {
+				return c.name
+			}
+		}
+	}
+	return "other"
+}
+
+// File is a file in the bundle.
+type File struct {
+	// Path is relative to the run directory, with slashes.
+	Path     string    `json:"path"`
+	Category string    `json:"category"`
+	Size     int64     `json:"size"`
+	SHA256   string    `json:"sha256"`
+	ModTime  time.Time `json:"mod-time"`
+}
+
+// Index is the 'index.json' of the bundle.
+type Index struct {
+	RunDirectory string         `json:"run-directory"`
+	Created      time.Time      `json:"created"`
+	Categories   map[string]int `json:"categories"`
+	Files        []File         `json:"files"`
+}
+
+// collect indexes the regular files under 'dir', except 'skip'.
+func collect(dir, skip string) (idx Index, err error) {
+	idx.RunDirectory = filepath.Base(dir)
+	idx.Categories = make(map[string]int)
+	err = filepath.Walk(dir, func(fpath string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !fi.Mode().IsRegular() {
+			return nil
+		}
+		if skip != "" {
+			if same, _ := sameFile(fpath, skip); same {
+				return nil
+			}
+		}
+		rel, err := filepath.Rel(dir, fpath)
+		if err != nil {
+			return err
+		}
+		sum, err := sha256File(fpath)
+		if err != nil {
+			return err
+		}
+		f := File{
+			Path:     filepath.ToSlash(rel),
+			Category: categorize(rel),
+			Size:     fi.Size(),
+			SHA256:   sum,
+			ModTime:  fi.ModTime().UTC(),
+		}
+		idx.Files = append(idx.Files, f)
+		idx.Categories[f.Category]++
+		return nil
+	})
+	sort.Slice(idx.Files, func(i, j int) bool { return idx.Files[i].Path < idx.Files[j].Path })
+	return idx, err
+}
+
+func sameFile(a, b string) (bool, error) {
+	fa, err := os.Stat(a)
+	if err != nil {
+		return false, err
+	}
+	fb, err := os.Stat(b)
+	if err != nil {
+		return false, err
+	}
+	return os.SameFile(fa, fb), nil
+}
+
+func sha256File(fpath string) (string, error) {
+	f, err := os.Open(fpath)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err = io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// Write packs the files of the run directory into the gzipped tarball
+// at 'output', under the directory name, with 'index.json' first.
+func Write(dir, output string) (Index, error) {
+	// create first, so that the output in the run directory is skipped
+	out, err := os.Create(output)
+	if err != nil {
+		return Index{}, err
+	}
+	defer out.Close()
+
+	idx, err := collect(dir, output)
+	if err != nil {
+		return idx, err
+	}
+	if len(idx.Files) == 0 {
+		return idx, fmt.Errorf("no file found in %q", dir)
+	}
+	idx.Created = time.Now().UTC()
+
+	gw := gzip.NewWriter(out)
+	tw := tar.NewWriter(gw)
+
+	bts, err := json.MarshalIndent(idx, "", "  ")
+	if err != nil {
+		return idx, err
+	}
+	if err = tw.WriteHeader(&tar.Header{
+		Name:    path.Join(idx.RunDirectory, indexPath),
+		Mode:    0644,
+		Size:    int64(len(bts)),
+		ModTime: idx.Created,
+	}); err != nil {
+		return idx, err
+	}
+	if _, err = tw.Write(bts); err != nil {
+		return idx, err
+	}
+
+	for _, f := range idx.Files {
+		if err = writeFile(tw, filepath.Join(dir, filepath.FromSlash(f.Path)), path.Join(idx.RunDirectory, f.Path), f); err != nil {
+			return idx, err
+		}
+	}
+
+	if err = tw.Close(); err != nil {
+		return idx, err
+	}
+	if err = gw.Close(); err != nil {
+		return idx, err
+	}
+	return idx, out.Close()
+}
+
+func writeFile(tw *tar.Writer, fpath, name string, f File) error {
+	src, err := os.Open(fpath)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	if err = tw.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    0644,
+		Size:    f.Size,
+		ModTime: f.ModTime,
+	}); err != nil {
+		return err
+	}
+	// files that grew since indexed are cut to the indexed size
+	n, err := io.CopyN(tw, src, f.Size)
+	if err != nil {
+		return fmt.Errorf("%q: %v (copied %d of %d bytes)", fpath, err, n, f.Size)
+	}
+	return nil
+}
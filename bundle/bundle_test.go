@@ -0,0 +1,129 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bundle
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestCategorize(t *testing.T) {
+	tests := map[string]string{
+		"client-latency-distribution-summary.csv":  "summary",
+		"client-latency-throughput-timeseries.csv": "timeseries",
+		"client-system-metrics-interpolated.csv":   "timeseries",
+		"1-server-system-metrics.csv":              "server-metrics",
+		"server-memory-by-key-number.csv":          "server-metrics",
+		"profiles/heap-after-stress.pb.gz":         "profile",
+		"profiles/cpu.folded":                      "profile",
+		"client-request-trace-sample.csv":          "timeline",
+		"client-chaos.csv":                         "timeline",
+		"client-control.log":                       "metadata",
+		"config.yaml":                              "metadata",
+		"AVG-LATENCY-MS-BY-KEY.svg":                "other",
+	}
+	for name, exp := range tests {
+		if c := categorize(name); c != exp {
+			t.Errorf("%q: expected %q, got %q", name, exp, c)
+		}
+	}
+}
+
+func TestWrite(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "bundle")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+
+	dir := filepath.Join(tmp, "run")
+	files := map[string]string{
+		"client-latency-distribution-summary.csv": "TOTAL-SECONDS,1\n",
+		"profiles/cpu.pb.gz":                      "cpu",
+		"client-control.log":                      "log",
+	}
+	for name, data := range files {
+		fpath := filepath.Join(dir, name)
+		if err = os.MkdirAll(filepath.Dir(fpath), 0777); err != nil {
+			t.Fatal(err)
+		}
+		if err = ioutil.WriteFile(fpath, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// the output in the run directory is not bundled
+	output := filepath.Join(dir, "run.tar.gz")
+	idx, err := Write(dir, output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(idx.Files) != 3 {
+		t.Fatalf("expected 3 files, got %+v", idx.Files)
+	}
+	if exp := map[string]int{"summary": 1, "profile": 1, "metadata": 1}; !reflect.DeepEqual(idx.Categories, exp) {
+		t.Fatalf("expected %v, got %v", exp, idx.Categories)
+	}
+
+	f, err := os.Open(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	gr, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr := tar.NewReader(gr)
+	got := make(map[string]string)
+	var names []string
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		bts, err := ioutil.ReadAll(tr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		names = append(names, hdr.Name)
+		got[hdr.Name] = string(bts)
+	}
+	if names[0] != "run/index.json" {
+		t.Fatalf("expected index first, got %q", names)
+	}
+	for name, data := range files {
+		if got["run/"+name] != data {
+			t.Fatalf("%q: expected %q, got %q", name, data, got["run/"+name])
+		}
+	}
+	var rd Index
+	if err = json.Unmarshal([]byte(got["run/index.json"]), &rd); err != nil {
+		t.Fatal(err)
+	}
+	if len(rd.Files) != 3 || rd.Files[2].Path != "profiles/cpu.pb.gz" || rd.Files[2].SHA256 == "" {
+		t.Fatalf("unexpected index %+v", rd)
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bundle
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+// Command implements 'bundle' command.
+var Command = &cobra.Command{
+	Use:   "bundle [run directory]",
+	Short: "Packs the results of a run into one tarball.",
+	Long: `Packs the summary, time series, server metrics, profiles, timelines,
+and metadata in the run directory (the 'path_prefix' of the test) into
+one gzipped tarball, with an 'index.json' of the files and their categories.`,
+	Args: cobra.ExactArgs(1),
+	RunE: commandFunc,
+}
+
+var outputPath string
+
+func init() {
+	Command.PersistentFlags().StringVarP(&outputPath, "output", "o", "", "Tarball path. Empty to write '[run directory].tar.gz'.")
+}
+
+func commandFunc(cmd *cobra.Command, args []string) error {
+	dir := filepath.Clean(args[0])
+	fi, err := os.Stat(dir)
+	if err != nil {
+		return err
+	}
+	if !fi.IsDir() {
+		return fmt.Errorf("%q is not a directory", dir)
+	}
+	output := outputPath
+	if output == "" {
+		abs, err := filepath.Abs(dir)
+		if err != nil {
+			return err
+		}
+		output = abs + ".tar.gz"
+	}
+
+	idx, err := Write(dir, output)
+	if err != nil {
+		os.Remove(output)
+		return err
+	}
+
+	cats := make([]string, 0, len(idx.Categories))
+	for c, n := range idx.Categories {
+		cats = append(cats, fmt.Sprintf("%s=%d", c, n))
+	}
+	sort.Strings(cats)
+	lg.Info("wrote bundle",
+		zap.String("run-directory", dir),
+		zap.String("output", output),
+		zap.Int("files", len(idx.Files)),
+		zap.String("categories", strings.Join(cats, ",")),
+	)
+	return nil
+}
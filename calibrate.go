@@ -0,0 +1,84 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"time"
+
+	"github.com/coreos/dbtester/dbtesterpb"
+
+	"golang.org/x/net/context"
+)
+
+// maxCalibrationRequests is the maximum number of requests
+// to measure the harness overhead.
+const maxCalibrationRequests = 100000
+
+// harnessOverhead is the cost of the benchmark harness itself
+// (request generation, channels, latency report), measured
+// with no-op request handlers against no database.
+type harnessOverhead struct {
+	// latency is the average latency observed with a no-op request,
+	// which is included in every measured latency.
+	latency time.Duration
+	// loop is the time that each client spends per request,
+	// including the time outside of the measured latency.
+	loop time.Duration
+	// rps is the maximum throughput that the harness can generate.
+	rps float64
+}
+
+// calibrateHarness runs the same request generator with the same
+// number of clients against no-op handlers.
+func (cfg *Config) calibrateHarness(gcfg dbtesterpb.ConfigClientMachineAgentControl, vals values) *harnessOverhead {
+	// benchmark options are shared by pointer, copy before overwriting
+	copied := gcfg
+	opts := *gcfg.ConfigClientMachineBenchmarkOptions
+	copied.ConfigClientMachineBenchmarkOptions = &opts
+	copied.ConfigClientMachineBenchmarkOptions.RateLimitRequestsPerSecond = 0
+	copied.ConfigClientMachineBenchmarkOptions.DurationSeconds = 0
+	if copied.ConfigClientMachineBenchmarkOptions.RequestNumber > maxCalibrationRequests {
+		copied.ConfigClientMachineBenchmarkOptions.RequestNumber = maxCalibrationRequests
+	}
+	if n := len(gcfg.ConfigClientMachineBenchmarkOptions.ConnectionClientNumbers); n > 0 {
+		copied.ConfigClientMachineBenchmarkOptions.ClientNumber = gcfg.ConfigClientMachineBenchmarkOptions.ConnectionClientNumbers[n-1]
+	}
+	clientN := copied.ConfigClientMachineBenchmarkOptions.ClientNumber
+	reqN := copied.ConfigClientMachineBenchmarkOptions.RequestNumber
+	if clientN <= 0 || reqN <= 0 {
+		return nil
+	}
+
+	cfg.lg.Sugar().Infof("calibrating harness overhead with %d requests and %d clients", reqN, clientN)
+	h := make([]ReqHandler, clientN)
+	for i := range h {
+		h[i] = func(context.Context, *request) error { return nil }
+	}
+	reqGen := func(ctx context.Context, inflightReqs chan<- request) {
+		generateWrites(ctx, copied, 0, vals, inflightReqs)
+	}
+	b := newBenchmark(reqN, clientN, h, nil, reqGen)
+	b.ctx = cfg.runContext()
+	b.startRequests()
+	b.waitAll()
+
+	ho := &harnessOverhead{
+		latency: time.Duration(b.stats.Average * float64(time.Second)),
+		loop:    time.Duration(int64(b.stats.Total) * clientN / reqN),
+		rps:     b.stats.RPS,
+	}
+	cfg.lg.Sugar().Infof("calibrated harness overhead [latency: %v | loop per request: %v | maximum requests per second: %.4f]", ho.latency, ho.loop, ho.rps)
+	return ho
+}
@@ -0,0 +1,262 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/coreos/dbtester/dbtesterpb"
+
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+)
+
+const (
+	// canaryProbePrefix is the prefix of the keys that the canary probes write.
+	canaryProbePrefix = "dbtester-canary-"
+	// canaryProbeKeys is the number of keys that the probes overwrite
+	// in turn, to not grow the key space of the clusters.
+	canaryProbeKeys = 100
+	// canaryWebhookTimeout is the timeout to post to the webhook.
+	canaryWebhookTimeout = 10 * time.Second
+)
+
+// CanaryOptions configures the canary comparison.
+type CanaryOptions struct {
+	// Interval is the interval between the probes, each of which
+	// writes the same key and value to both clusters at the same time.
+	Interval time.Duration
+	// Window is the number of probes of each comparison.
+	Window int
+	// Windows is the number of windows to compare, 0 to compare
+	// until the context is cancelled.
+	Windows int
+	// ValueSizeBytes is the size of the values of the probes.
+	ValueSizeBytes int64
+
+	// MaxLatencyRatio is the ratio of the canary p99 latency to the
+	// baseline p99 latency, over which the canary diverges.
+	MaxLatencyRatio float64
+	// MinLatencyDiff is the difference of the p99 latencies, under
+	// which the canary does not diverge, to ignore the noise of
+	// sub-millisecond latencies.
+	MinLatencyDiff time.Duration
+	// MaxErrorPercentDiff is the difference of the error rates in
+	// percent, over which the canary diverges.
+	MaxErrorPercentDiff float64
+
+	// Webhook is the URL to post each diverged window to, as JSON.
+	// Empty to not post.
+	Webhook string
+}
+
+// CanaryProbeStats is the probes of one cluster in a window.
+type CanaryProbeStats struct {
+	Probes int     `json:"probes"`
+	Errors int     `json:"errors"`
+	P50Ms  float64 `json:"p50_ms"`
+	P99Ms  float64 `json:"p99_ms"`
+}
+
+func (s CanaryProbeStats) errorPercent() float64 {
+	if s.Probes == 0 {
+		return 0
+	}
+	return 100 * float64(s.Errors) / float64(s.Probes)
+}
+
+// CanaryWindow is the comparison of the canary to the baseline in a window.
+type CanaryWindow struct {
+	DatabaseID string           `json:"database_id"`
+	Index      int              `json:"window"`
+	Start      time.Time        `json:"start"`
+	Baseline   CanaryProbeStats `json:"baseline"`
+	Canary     CanaryProbeStats `json:"canary"`
+
+	// LatencyRatio is the ratio of the canary p99 latency
+	// to the baseline p99 latency.
+	LatencyRatio float64 `json:"latency_ratio"`
+	Diverged     bool    `json:"diverged"`
+	// Reasons are why the canary diverged, empty if not.
+	Reasons []string `json:"reasons,omitempty"`
+}
+
+// compare sets if the canary diverges from the baseline.
+func (w *CanaryWindow) compare(opts CanaryOptions) {
+	if w.Baseline.P99Ms > 0 {
+		w.LatencyRatio = w.Canary.P99Ms / w.Baseline.P99Ms
+	}
+	diffMs := w.Canary.P99Ms - w.Baseline.P99Ms
+	if opts.MaxLatencyRatio > 0 && w.LatencyRatio > opts.MaxLatencyRatio && diffMs > toMillisecond(opts.MinLatencyDiff) {
+		w.Reasons = append(w.Reasons, fmt.Sprintf("p99 latency %.3f ms is %.2fx of baseline %.3f ms", w.Canary.P99Ms, w.LatencyRatio, w.Baseline.P99Ms))
+	}
+	if d := w.Canary.errorPercent() - w.Baseline.errorPercent(); d > opts.MaxErrorPercentDiff {
+		w.Reasons = append(w.Reasons, fmt.Sprintf("error rate %.1f%% is %.1f%% over baseline %.1f%%", w.Canary.errorPercent(), d, w.Baseline.errorPercent()))
+	}
+	w.Diverged = len(w.Reasons) > 0
+}
+
+// canaryTarget probes one cluster.
+type canaryTarget struct {
+	gcfg dbtesterpb.ConfigClientMachineAgentControl
+	h    ReqHandler
+	done func()
+
+	lats []float64
+	errs int
+}
+
+func newCanaryTarget(lg *zap.Logger, gcfg dbtesterpb.ConfigClientMachineAgentControl, endpoints []string) *canaryTarget {
+	gcfg.DatabaseEndpoints = endpoints
+	opts := dbtesterpb.ConfigClientMachineBenchmarkOptions{}
+	if gcfg.ConfigClientMachineBenchmarkOptions != nil {
+		opts = *gcfg.ConfigClientMachineBenchmarkOptions
+	}
+	opts.ConnectionNumber, opts.ClientNumber = 1, 1
+	// the probe keys are overwritten
+	opts.KeySpaceSize, opts.KeyPartitioned, opts.SameKey = canaryProbeKeys, false, false
+	gcfg.ConfigClientMachineBenchmarkOptions = &opts
+
+	h, done := newWriteHandlers(lg, gcfg)
+	return &canaryTarget{gcfg: gcfg, h: h[0], done: done}
+}
+
+func (t *canaryTarget) probe(ctx context.Context, key string, v []byte) {
+	req := newPutRequest(t.gcfg, key, v, string(v))
+	now := time.Now()
+	if err := t.h(ctx, &req); err != nil {
+		t.errs++
+		return
+	}
+	t.lats = append(t.lats, toMillisecond(time.Since(now)))
+}
+
+func (t *canaryTarget) stats() CanaryProbeStats {
+	s := CanaryProbeStats{
+		Probes: len(t.lats) + t.errs,
+		Errors: t.errs,
+		P50Ms:  percentileOf(t.lats, 50),
+		P99Ms:  percentileOf(t.lats, 99),
+	}
+	t.lats, t.errs = t.lats[:0], 0
+	return s
+}
+
+func (t *canaryTarget) close() {
+	if t.done != nil {
+		t.done()
+	}
+}
+
+// Canary mirrors the low-rate probes to the baseline and canary clusters
+// of the database, and compares the latencies and the error rates of
+// each window of probes. It returns the windows, and calls 'onWindow'
+// with each as it is compared, if not nil.
+func Canary(ctx context.Context, lg *zap.Logger, gcfg dbtesterpb.ConfigClientMachineAgentControl, baseline, canary []string, opts CanaryOptions, onWindow func(CanaryWindow)) ([]CanaryWindow, error) {
+	if len(baseline) == 0 || len(canary) == 0 {
+		return nil, fmt.Errorf("both the baseline and the canary endpoints are required")
+	}
+	if opts.Interval <= 0 || opts.Window < 1 {
+		return nil, fmt.Errorf("interval and window must be positive (got %v, %d)", opts.Interval, opts.Window)
+	}
+	if err := checkClientTLS(gcfg.DatabaseID); err != nil {
+		return nil, err
+	}
+	if bopts := gcfg.ConfigClientMachineBenchmarkOptions; bopts != nil {
+		if bopts.EtcdUsername != "" {
+			setEtcdAuth(bopts.EtcdUsername, bopts.EtcdPassword)
+		}
+		if bopts.ConsulToken != "" {
+			consulToken = bopts.ConsulToken
+		}
+	}
+
+	bt := newCanaryTarget(lg, gcfg, baseline)
+	defer bt.close()
+	ct := newCanaryTarget(lg, gcfg, canary)
+	defer ct.close()
+
+	v := randBytes(opts.ValueSizeBytes)
+	tick := time.NewTicker(opts.Interval)
+	defer tick.Stop()
+
+	var (
+		windows []CanaryWindow
+		seq     int64
+	)
+	for wi := 0; opts.Windows == 0 || wi < opts.Windows; wi++ {
+		w := CanaryWindow{DatabaseID: gcfg.DatabaseID, Index: wi, Start: time.Now()}
+		for i := 0; i < opts.Window; i++ {
+			select {
+			case <-ctx.Done():
+				return windows, nil
+			case <-tick.C:
+			}
+			key := fmt.Sprintf("%s%d", canaryProbePrefix, seq%canaryProbeKeys)
+			seq++
+
+			// probes both at the same time, to see the same network and load
+			var wg sync.WaitGroup
+			wg.Add(2)
+			for _, t := range []*canaryTarget{bt, ct} {
+				go func(t *canaryTarget) {
+					defer wg.Done()
+					t.probe(ctx, key, v)
+				}(t)
+			}
+			wg.Wait()
+		}
+		w.Baseline, w.Canary = bt.stats(), ct.stats()
+		w.compare(opts)
+
+		if w.Diverged {
+			lg.Warn("canary diverged from baseline", zap.Int("window", wi), zap.Strings("reasons", w.Reasons))
+			if opts.Webhook != "" {
+				if err := postCanaryWebhook(opts.Webhook, w); err != nil {
+					lg.Warn("failed to post to webhook", zap.String("webhook", opts.Webhook), zap.Error(err))
+				}
+			}
+		} else {
+			lg.Info("canary matches baseline", zap.Int("window", wi), zap.Float64("latency-ratio", w.LatencyRatio))
+		}
+		windows = append(windows, w)
+		if onWindow != nil {
+			onWindow(w)
+		}
+	}
+	return windows, nil
+}
+
+func postCanaryWebhook(url string, w CanaryWindow) error {
+	bts, err := json.Marshal(w)
+	if err != nil {
+		return err
+	}
+	cli := &http.Client{Timeout: canaryWebhookTimeout}
+	resp, err := cli.Post(url, "application/json", bytes.NewReader(bts))
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("webhook responded %q", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package canary
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/coreos/dbtester"
+	"github.com/coreos/dbtester/control"
+	"github.com/coreos/dbtester/dbtesterpb"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+)
+
+// Command implements 'canary' command.
+var Command = &cobra.Command{
+	Use:   "canary",
+	Short: "Compares a canary cluster to a baseline cluster with mirrored probes.",
+	Long: `Writes the same low-rate probes to the baseline and the canary clusters
+at the same time, and compares the p99 latencies and the error rates of
+each window of probes. A window diverges when the canary p99 latency is
+over '--max-latency-ratio' of the baseline (and over '--min-latency-diff'
+slower), or its error rate is over '--max-error-percent-diff' higher.
+Each diverged window is posted to '--webhook' as JSON, and the command
+exits with code 2 if any window diverged, to gate a rollout.`,
+	RunE: commandFunc,
+}
+
+// divergedExitCode is the exit code if any window diverged,
+// distinct from the exit code 1 of failures to run.
+const divergedExitCode = 2
+
+var (
+	databaseID string
+	configPath string
+	baseline   []string
+	canary     []string
+	certFile   string
+	keyFile    string
+	caFile     string
+	opts       dbtester.CanaryOptions
+)
+
+func init() {
+	ids := dbtesterpb.GetAllDatabaseIDs()
+	Command.PersistentFlags().StringVar(&databaseID, "database-id", "", "Database ID of both clusters: "+strings.Join(ids, ", ")+".")
+	Command.PersistentFlags().StringVarP(&configPath, "config", "c", "", "YAML configuration file path, to probe with the benchmark options of '--database-id' (e.g. the credentials and the namespace). Empty for the defaults.")
+	Command.PersistentFlags().StringSliceVar(&baseline, "baseline", nil, "Endpoints of the baseline cluster.")
+	Command.PersistentFlags().StringSliceVar(&canary, "canary", nil, "Endpoints of the canary cluster.")
+	Command.PersistentFlags().StringVar(&certFile, "cert", "", "Client certificate to connect to the database with TLS (etcd, Zookeeper 'secureClientPort' and Consul HTTPS). Requires '--key'.")
+	Command.PersistentFlags().StringVar(&keyFile, "key", "", "Private key of '--cert'.")
+	Command.PersistentFlags().StringVar(&caFile, "cacert", "", "CA certificate to verify the database servers with, to connect with TLS. Empty to verify with the system roots when '--cert' is set.")
+	Command.PersistentFlags().DurationVar(&opts.Interval, "interval", 100*time.Millisecond, "Interval between the probes, each written to both clusters.")
+	Command.PersistentFlags().IntVar(&opts.Window, "window", 100, "Number of probes of each comparison.")
+	Command.PersistentFlags().IntVar(&opts.Windows, "windows", 0, "Number of windows to compare. 0 to compare until interrupted.")
+	Command.PersistentFlags().Int64Var(&opts.ValueSizeBytes, "value-size", 256, "Size of the values of the probes.")
+	Command.PersistentFlags().Float64Var(&opts.MaxLatencyRatio, "max-latency-ratio", 1.5, "Ratio of the canary p99 latency to the baseline p99 latency, over which the canary diverges. 0 to not compare the latencies.")
+	Command.PersistentFlags().DurationVar(&opts.MinLatencyDiff, "min-latency-diff", time.Millisecond, "Difference of the p99 latencies, under which the canary does not diverge, to ignore the noise of fast clusters.")
+	Command.PersistentFlags().Float64Var(&opts.MaxErrorPercentDiff, "max-error-percent-diff", 1, "Difference of the error rates in percent, over which the canary diverges.")
+	Command.PersistentFlags().StringVar(&opts.Webhook, "webhook", "", "URL to post each diverged window to, as JSON. Empty to not post.")
+}
+
+func commandFunc(cmd *cobra.Command, args []string) error {
+	if !dbtesterpb.IsValidDatabaseID(databaseID) {
+		return fmt.Errorf("database id %q is unknown", databaseID)
+	}
+	if err := dbtester.SetClientTLS(certFile, keyFile, caFile); err != nil {
+		return err
+	}
+
+	gcfg := dbtesterpb.ConfigClientMachineAgentControl{DatabaseID: databaseID}
+	if configPath != "" {
+		cfg, err := dbtester.ReadConfig(configPath, false)
+		if err != nil {
+			return err
+		}
+		var ok bool
+		if gcfg, ok = cfg.DatabaseIDToConfigClientMachineAgentControl[databaseID]; !ok {
+			return fmt.Errorf("%q is not found in %q", databaseID, configPath)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	notifier := make(chan os.Signal, 1)
+	signal.Notify(notifier, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(notifier)
+	go func() {
+		select {
+		case sig := <-notifier:
+			lg.Info("received signal; stopping the comparison", zap.String("signal", sig.String()))
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	var diverged int
+	var windows []dbtester.CanaryWindow
+	step := control.Step{
+		Name:       "canary",
+		DatabaseID: databaseID,
+		Run: func(*dbtester.Config) (err error) {
+			lg.Info("comparing canary to baseline", zap.String("database", databaseID), zap.Strings("baseline", baseline), zap.Strings("canary", canary))
+			windows, err = dbtester.Canary(ctx, lg, gcfg, baseline, canary, opts, func(w dbtester.CanaryWindow) {
+				state := "OK"
+				if w.Diverged {
+					state = "DIVERGED (" + strings.Join(w.Reasons, "; ") + ")"
+					diverged++
+				}
+				fmt.Printf("window %d: baseline p99 %.3f ms (%d/%d errors), canary p99 %.3f ms (%d/%d errors): %s\n",
+					w.Index, w.Baseline.P99Ms, w.Baseline.Errors, w.Baseline.Probes, w.Canary.P99Ms, w.Canary.Errors, w.Canary.Probes, state)
+			})
+			return err
+		},
+	}
+	if err := control.RunSteps(control.Sequence{Context: ctx}, []control.Step{step}); err != nil {
+		return err
+	}
+
+	lg.Info("compared canary to baseline", zap.Int("windows", len(windows)), zap.Int("diverged", diverged))
+	if diverged > 0 {
+		fmt.Printf("canary diverged from baseline in %d of %d windows\n", diverged, len(windows))
+		os.Exit(divergedExitCode)
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/coreos/dbtester/dbtesterpb"
+
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+)
+
+func TestCanaryWindowCompare(t *testing.T) {
+	opts := CanaryOptions{MaxLatencyRatio: 1.5, MinLatencyDiff: time.Millisecond, MaxErrorPercentDiff: 1}
+	tests := []struct {
+		baseline, canary CanaryProbeStats
+		diverged         bool
+	}{
+		{CanaryProbeStats{Probes: 100, P99Ms: 2}, CanaryProbeStats{Probes: 100, P99Ms: 2.5}, false},
+		{CanaryProbeStats{Probes: 100, P99Ms: 2}, CanaryProbeStats{Probes: 100, P99Ms: 4}, true},
+		// 3x slower, but under the noise
+		{CanaryProbeStats{Probes: 100, P99Ms: 0.2}, CanaryProbeStats{Probes: 100, P99Ms: 0.6}, false},
+		{CanaryProbeStats{Probes: 100, Errors: 1, P99Ms: 2}, CanaryProbeStats{Probes: 100, Errors: 2, P99Ms: 2}, false},
+		{CanaryProbeStats{Probes: 100, P99Ms: 2}, CanaryProbeStats{Probes: 100, Errors: 5, P99Ms: 2}, true},
+	}
+	for i, tt := range tests {
+		w := CanaryWindow{Baseline: tt.baseline, Canary: tt.canary}
+		w.compare(opts)
+		if w.Diverged != tt.diverged {
+			t.Fatalf("#%d: expected diverged %v, got %v (%q)", i, tt.diverged, w.Diverged, w.Reasons)
+		}
+	}
+}
+
+func TestCanary(t *testing.T) {
+	posted := make(chan CanaryWindow, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		var cw CanaryWindow
+		if err := json.NewDecoder(req.Body).Decode(&cw); err != nil {
+			t.Error(err)
+		}
+		posted <- cw
+	}))
+	defer srv.Close()
+	if err := postCanaryWebhook(srv.URL, CanaryWindow{Index: 3, Diverged: true}); err != nil {
+		t.Fatal(err)
+	}
+	if cw := <-posted; cw.Index != 3 || !cw.Diverged {
+		t.Fatalf("unexpected posted window %+v", cw)
+	}
+
+	gcfg := dbtesterpb.ConfigClientMachineAgentControl{
+		DatabaseID:                          "mock",
+		ConfigClientMachineBenchmarkOptions: &dbtesterpb.ConfigClientMachineBenchmarkOptions{},
+	}
+	opts := CanaryOptions{Interval: time.Millisecond, Window: 5, Windows: 2, ValueSizeBytes: 8, MaxLatencyRatio: 1.5, MinLatencyDiff: time.Second, Webhook: srv.URL}
+	var called int
+	windows, err := Canary(context.Background(), zap.NewNop(), gcfg, []string{"a"}, []string{"b"}, opts, func(CanaryWindow) { called++ })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(windows) != 2 || called != 2 {
+		t.Fatalf("expected 2 windows, got %d (called %d)", len(windows), called)
+	}
+	for _, w := range windows {
+		if w.Diverged || w.Baseline.Probes != 5 || w.Canary.Probes != 5 {
+			t.Fatalf("unexpected window %+v", w)
+		}
+	}
+	if v, ok := mockDB.get(canaryProbePrefix + "9"); !ok || len(v) != 8 {
+		t.Fatalf("expected the last probe written, got %q", v)
+	}
+
+	if _, err = Canary(context.Background(), zap.NewNop(), gcfg, nil, []string{"b"}, opts, nil); err == nil {
+		t.Fatal("expected error of no baseline endpoints")
+	}
+}
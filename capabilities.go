@@ -0,0 +1,495 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/coreos/dbtester/dbtesterpb"
+	"github.com/coreos/dbtester/pkg/redis"
+	"github.com/coreos/dbtester/pkg/tikv"
+
+	"github.com/coreos/etcd/clientv3"
+	"github.com/coreos/etcd/etcdserver/api/v3rpc/rpctypes"
+	humanize "github.com/dustin/go-humanize"
+	consulapi "github.com/hashicorp/consul/api"
+	"github.com/samuel/go-zookeeper/zk"
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+)
+
+const (
+	// capabilityProbeTimeout is the timeout of each capability probe.
+	capabilityProbeTimeout = 5 * time.Second
+	// capabilityMaxValueBytes is the largest value size to probe.
+	capabilityMaxValueBytes = 8 * 1024 * 1024
+	// capabilityProbeKey is the key that capability probes write and delete.
+	capabilityProbeKey = "dbtester-capabilities-probe"
+)
+
+// Capabilities are the features of a database, as probed by
+// writing to and reading from its endpoints.
+type Capabilities struct {
+	DatabaseID string
+	Version    string
+
+	// Txn is true if multiple keys can be written atomically.
+	Txn bool
+	// TTL is true if keys can expire; Zookeeper ephemeral znodes
+	// expire with the session, instead of a per-key TTL.
+	TTL bool
+	// Watch is true if clients are notified of the writes.
+	Watch bool
+	// CAS is true if a key can be written only if unchanged since read.
+	CAS bool
+	// MaxValueBytes is the largest value written, to the KiB,
+	// up to 8 MiB. 0 if unknown.
+	MaxValueBytes int64
+	// AuthEnabled is true if the unauthenticated requests are rejected.
+	AuthEnabled bool
+}
+
+// probeMaxValueBytes returns the largest value size that 'put' succeeds
+// with, to the KiB, by bisecting up to 'capabilityMaxValueBytes'.
+func probeMaxValueBytes(put func(size int) error) int64 {
+	lo, hi := 0, capabilityMaxValueBytes
+	if put(hi) == nil {
+		return int64(hi)
+	}
+	for hi-lo > 1024 {
+		mid := (lo + hi) / 2
+		if put(mid) == nil {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return int64(lo)
+}
+
+// ProbeCapabilities probes the features of the database of 'gcfg'
+// through its endpoints, with the credentials of its benchmark options.
+// It only returns an error if the database cannot be reached at all.
+func ProbeCapabilities(lg *zap.Logger, gcfg dbtesterpb.ConfigClientMachineAgentControl) (Capabilities, error) {
+	if len(gcfg.DatabaseEndpoints) == 0 && gcfg.DatabaseID != "mock" {
+		return Capabilities{}, fmt.Errorf("no endpoint to probe %q", gcfg.DatabaseID)
+	}
+	if opts := gcfg.ConfigClientMachineBenchmarkOptions; opts != nil {
+		if opts.EtcdUsername != "" {
+			setEtcdAuth(opts.EtcdUsername, opts.EtcdPassword)
+		}
+		if opts.ConsulToken != "" {
+			consulToken = opts.ConsulToken
+		}
+	}
+
+	var (
+		caps Capabilities
+		err  error
+	)
+	switch gcfg.DatabaseID {
+	case "etcd__other", "etcd__tip", "etcd__v3_2", "etcd__v3_3":
+		caps, err = probeCapabilitiesEtcdv3(lg, gcfg.DatabaseEndpoints)
+
+	case "zookeeper__r3_5_3_beta", "zetcd__beta":
+		caps, err = probeCapabilitiesZk(lg, gcfg.DatabaseEndpoints)
+
+	case "consul__v1_0_2", "cetcd__beta":
+		caps, err = probeCapabilitiesConsul(lg, gcfg.DatabaseEndpoints)
+
+	case "redis__v4_0":
+		caps, err = probeCapabilitiesRedis(lg, gcfg.DatabaseEndpoints)
+
+	case "tikv__v2_1":
+		caps, err = probeCapabilitiesTikv(lg, tikvPDEndpoints(gcfg))
+
+	case "mock":
+		// same as the mock store, which has no expiry and no watch
+		caps = Capabilities{Version: "mock", Txn: true, CAS: true, MaxValueBytes: capabilityMaxValueBytes}
+
+	default:
+		return Capabilities{}, fmt.Errorf("%q is unknown database ID", gcfg.DatabaseID)
+	}
+	caps.DatabaseID = gcfg.DatabaseID
+	return caps, err
+}
+
+// logProbe logs the probe error, and returns true if there was none.
+func logProbe(lg *zap.Logger, feature string, err error) bool {
+	if err != nil {
+		lg.Info("capability probe failed", zap.String("feature", feature), zap.Error(err))
+		return false
+	}
+	return true
+}
+
+func probeCapabilitiesEtcdv3(lg *zap.Logger, endpoints []string) (caps Capabilities, err error) {
+	cli := mustCreateConnEtcdv3(endpoints)
+	defer cli.Close()
+
+	// for all probes but the max value size
+	ctx, cancel := context.WithTimeout(context.Background(), 4*capabilityProbeTimeout)
+	defer cancel()
+	st, err := cli.Status(ctx, endpoints[0])
+	if err != nil {
+		return caps, err
+	}
+	caps.Version = st.Version
+
+	// credentials are not sent by a client of no user
+	anon, err := clientv3.New(clientv3.Config{Endpoints: endpoints, DialTimeout: capabilityProbeTimeout, TLS: clientTLS})
+	if err != nil {
+		return caps, err
+	}
+	_, err = anon.Get(ctx, capabilityProbeKey)
+	anon.Close()
+	switch err {
+	case rpctypes.ErrUserEmpty, rpctypes.ErrPermissionDenied, rpctypes.ErrInvalidAuthToken, rpctypes.ErrAuthFailed:
+		caps.AuthEnabled = true
+	}
+
+	key := capabilityProbeKey
+	defer cli.Delete(context.Background(), key, clientv3.WithPrefix())
+
+	_, err = cli.Txn(ctx).Then(clientv3.OpPut(key, "txn"), clientv3.OpPut(key+"-txn", "txn")).Commit()
+	caps.Txn = logProbe(lg, "txn", err)
+
+	resp, err := cli.Get(ctx, key)
+	if err == nil && len(resp.Kvs) > 0 {
+		var tresp *clientv3.TxnResponse
+		tresp, err = cli.Txn(ctx).If(clientv3.Compare(clientv3.ModRevision(key), "=", resp.Kvs[0].ModRevision)).Then(clientv3.OpPut(key, "cas")).Commit()
+		if err == nil && !tresp.Succeeded {
+			err = fmt.Errorf("compare-and-swap of unchanged key failed")
+		}
+	} else if err == nil {
+		err = fmt.Errorf("%q not found", key)
+	}
+	caps.CAS = logProbe(lg, "cas", err)
+
+	lresp, err := cli.Grant(ctx, 10)
+	if err == nil {
+		_, err = cli.Put(ctx, key+"-ttl", "ttl", clientv3.WithLease(lresp.ID))
+		cli.Revoke(ctx, lresp.ID)
+	}
+	caps.TTL = logProbe(lg, "ttl", err)
+
+	wctx, wcancel := context.WithCancel(ctx)
+	wch := cli.Watch(wctx, key)
+	if _, err = cli.Put(ctx, key, "watch"); err == nil {
+		select {
+		case wresp := <-wch:
+			err = wresp.Err()
+		case <-time.After(capabilityProbeTimeout):
+			err = fmt.Errorf("no watch event in %v", capabilityProbeTimeout)
+		}
+	}
+	wcancel()
+	caps.Watch = logProbe(lg, "watch", err)
+
+	caps.MaxValueBytes = probeMaxValueBytes(func(size int) error {
+		pctx, pcancel := context.WithTimeout(context.Background(), capabilityProbeTimeout)
+		_, err := cli.Put(pctx, key, string(make([]byte, size)))
+		pcancel()
+		return err
+	})
+	return caps, nil
+}
+
+func probeCapabilitiesZk(lg *zap.Logger, endpoints []string) (caps Capabilities, err error) {
+	conn := mustCreateConnsZk(endpoints, 1)[0]
+	defer conn.Close()
+
+	if _, _, err = conn.Exists("/"); err != nil {
+		return caps, err
+	}
+	// 'srvr' may not be in '4lw.commands.whitelist'
+	caps.Version = "unknown"
+	if stats, ok := zk.FLWSrvr(endpoints[:1], capabilityProbeTimeout); ok && len(stats) > 0 && stats[0].Version != "" {
+		caps.Version = stats[0].Version
+	}
+
+	acls, _, err := conn.GetACL("/")
+	if logProbe(lg, "auth", err) {
+		for _, acl := range acls {
+			if acl.Scheme != "world" {
+				caps.AuthEnabled = true
+			}
+		}
+	}
+
+	path := "/" + capabilityProbeKey
+	conn.Delete(path, -1)
+	if _, err = conn.Create(path, []byte("probe"), zkCreateFlags, zkCreateACL); err != nil {
+		return caps, err
+	}
+	defer conn.Delete(path, -1)
+
+	_, err = conn.Multi(
+		&zk.CreateRequest{Path: path + "-txn", Data: []byte("txn"), Acl: zkCreateACL, Flags: zkCreateFlags},
+		&zk.DeleteRequest{Path: path + "-txn", Version: -1},
+	)
+	caps.Txn = logProbe(lg, "txn", err)
+
+	_, stat, err := conn.Get(path)
+	if err == nil {
+		_, err = conn.Set(path, []byte("cas"), stat.Version)
+	}
+	caps.CAS = logProbe(lg, "cas", err)
+
+	if _, err = conn.Create(path+"-ttl", []byte("ttl"), zk.FlagEphemeral, zkCreateACL); err == nil {
+		conn.Delete(path+"-ttl", -1)
+	}
+	caps.TTL = logProbe(lg, "ttl", err)
+
+	_, _, ech, err := conn.GetW(path)
+	if err == nil {
+		if _, err = conn.Set(path, []byte("watch"), -1); err == nil {
+			select {
+			case ev := <-ech:
+				err = ev.Err
+			case <-time.After(capabilityProbeTimeout):
+				err = fmt.Errorf("no watch event in %v", capabilityProbeTimeout)
+			}
+		}
+	}
+	caps.Watch = logProbe(lg, "watch", err)
+
+	// the server closes the connection of a request over 'jute.maxbuffer',
+	// and the client reconnects in the same session
+	caps.MaxValueBytes = probeMaxValueBytes(func(size int) error {
+		_, err := conn.Set(path, make([]byte, size), -1)
+		return err
+	})
+	return caps, nil
+}
+
+func probeCapabilitiesConsul(lg *zap.Logger, endpoints []string) (caps Capabilities, err error) {
+	cli, err := consulapi.NewClient(newConsulConfig(endpoints[0]))
+	if err != nil {
+		return caps, err
+	}
+	self, err := cli.Agent().Self()
+	if err != nil {
+		return caps, err
+	}
+	caps.Version = "unknown"
+	if v, ok := self["Config"]["Version"].(string); ok {
+		caps.Version = v
+	}
+
+	if _, _, err = cli.ACL().List(nil); err == nil || !strings.Contains(err.Error(), "ACL support disabled") {
+		caps.AuthEnabled = true
+	}
+
+	kv := cli.KV()
+	key := capabilityProbeKey
+	defer kv.DeleteTree(key, nil)
+
+	ok, _, _, err := kv.Txn(consulapi.KVTxnOps{
+		{Verb: consulapi.KVSet, Key: key, Value: []byte("txn")},
+		{Verb: consulapi.KVSet, Key: key + "-txn", Value: []byte("txn")},
+	}, nil)
+	if err == nil && !ok {
+		err = fmt.Errorf("transaction rolled back")
+	}
+	caps.Txn = logProbe(lg, "txn", err)
+
+	pair, meta, err := kv.Get(key, nil)
+	if err == nil && pair != nil {
+		ok, _, err = kv.CAS(&consulapi.KVPair{Key: key, Value: []byte("cas"), ModifyIndex: pair.ModifyIndex}, nil)
+		if err == nil && !ok {
+			err = fmt.Errorf("compare-and-swap of unchanged key failed")
+		}
+	} else if err == nil {
+		err = fmt.Errorf("%q not found", key)
+	}
+	caps.CAS = logProbe(lg, "cas", err)
+
+	id, _, err := cli.Session().Create(&consulapi.SessionEntry{TTL: "10s", Behavior: consulapi.SessionBehaviorDelete}, nil)
+	if err == nil {
+		cli.Session().Destroy(id, nil)
+	}
+	caps.TTL = logProbe(lg, "ttl", err)
+
+	// blocking query, that returns on the next write of the key
+	if meta != nil {
+		go func() {
+			time.Sleep(100 * time.Millisecond)
+			kv.Put(&consulapi.KVPair{Key: key, Value: []byte("watch")}, nil)
+		}()
+		var wmeta *consulapi.QueryMeta
+		_, wmeta, err = kv.Get(key, &consulapi.QueryOptions{WaitIndex: meta.LastIndex, WaitTime: capabilityProbeTimeout})
+		if err == nil && wmeta.LastIndex <= meta.LastIndex {
+			err = fmt.Errorf("blocking query returned no write in %v", capabilityProbeTimeout)
+		}
+	} else if err == nil {
+		err = fmt.Errorf("no index of %q", key)
+	}
+	caps.Watch = logProbe(lg, "watch", err)
+
+	caps.MaxValueBytes = probeMaxValueBytes(func(size int) error {
+		_, err := kv.Put(&consulapi.KVPair{Key: key, Value: make([]byte, size)}, nil)
+		return err
+	})
+	return caps, nil
+}
+
+func probeCapabilitiesRedis(lg *zap.Logger, endpoints []string) (caps Capabilities, err error) {
+	cli := redis.New(endpoints[0], false)
+	defer cli.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 4*capabilityProbeTimeout)
+	defer cancel()
+	info, err := cli.Info(ctx, endpoints[0], "server")
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "NOAUTH") {
+			caps.AuthEnabled = true
+			return caps, nil
+		}
+		return caps, err
+	}
+	caps.Version = "unknown"
+	if v, ok := info["redis_version"]; ok {
+		caps.Version = v
+	}
+
+	key := []byte(capabilityProbeKey)
+	defer cli.Del(ctx, key)
+
+	// MULTI and EXEC of the commands of one key, on the same node
+	_, err = cli.Do(ctx, key, []byte("MULTI"))
+	if err == nil {
+		if _, err = cli.Do(ctx, key, []byte("SET"), key, []byte("txn")); err == nil {
+			_, err = cli.Do(ctx, key, []byte("EXEC"))
+		}
+	}
+	caps.Txn = logProbe(lg, "txn", err)
+
+	// WATCH fails the transaction if the key is written after
+	_, err = cli.Do(ctx, key, []byte("WATCH"), key)
+	if err == nil {
+		if _, err = cli.Do(ctx, key, []byte("MULTI")); err == nil {
+			if _, err = cli.Do(ctx, key, []byte("SET"), key, []byte("cas")); err == nil {
+				var rp interface{}
+				if rp, err = cli.Do(ctx, key, []byte("EXEC")); err == nil {
+					if rps, ok := rp.([]interface{}); !ok || rps == nil {
+						err = fmt.Errorf("compare-and-swap of unchanged key failed")
+					}
+				}
+			}
+		}
+	}
+	caps.CAS = logProbe(lg, "cas", err)
+
+	_, err = cli.Do(ctx, key, []byte("SET"), key, []byte("ttl"), []byte("EX"), []byte("10"))
+	caps.TTL = logProbe(lg, "ttl", err)
+
+	// keyspace notifications, which the watch benchmark enables
+	_, err = cli.Do(ctx, key, []byte("CONFIG"), []byte("GET"), []byte("notify-keyspace-events"))
+	caps.Watch = logProbe(lg, "watch", err)
+
+	caps.MaxValueBytes = probeMaxValueBytes(func(size int) error {
+		ctx, cancel := context.WithTimeout(context.Background(), capabilityProbeTimeout)
+		defer cancel()
+		return cli.Set(ctx, key, make([]byte, size))
+	})
+	return caps, nil
+}
+
+func probeCapabilitiesTikv(lg *zap.Logger, endpoints []string) (caps Capabilities, err error) {
+	cli, err := tikv.New(endpoints)
+	if err != nil {
+		return caps, err
+	}
+	defer cli.Close()
+
+	// PD does not report the version of the stores
+	caps.Version = "unknown"
+
+	key := []byte(capabilityProbeKey)
+	ctx, cancel := context.WithTimeout(context.Background(), 4*capabilityProbeTimeout)
+	defer cancel()
+	defer cli.Delete(ctx, key)
+
+	// the raw key-value API has no transactions, no compare-and-swap,
+	// no expiry and no watch
+	lg.Info("capability not in raw key-value API", zap.Strings("features", []string{"txn", "cas", "ttl", "watch"}))
+
+	caps.MaxValueBytes = probeMaxValueBytes(func(size int) error {
+		ctx, cancel := context.WithTimeout(context.Background(), capabilityProbeTimeout)
+		defer cancel()
+		return cli.Put(ctx, key, make([]byte, size))
+	})
+	return caps, nil
+}
+
+// CapabilityMatrix returns the rows of features by database,
+// with the header of the database IDs.
+func CapabilityMatrix(caps []Capabilities) [][]string {
+	yes := func(v bool) string {
+		if v {
+			return "yes"
+		}
+		return "no"
+	}
+	rows := [][]string{{"FEATURE"}, {"version"}, {"txn"}, {"ttl"}, {"watch"}, {"cas"}, {"max value size"}, {"auth enabled"}}
+	for _, c := range caps {
+		maxValue := "unknown"
+		if c.MaxValueBytes > 0 {
+			maxValue = humanize.IBytes(uint64(c.MaxValueBytes))
+			if c.MaxValueBytes >= capabilityMaxValueBytes {
+				maxValue = ">= " + maxValue
+			}
+		}
+		for i, v := range []string{c.DatabaseID, c.Version, yes(c.Txn), yes(c.TTL), yes(c.Watch), yes(c.CAS), maxValue, yes(c.AuthEnabled)} {
+			rows[i] = append(rows[i], v)
+		}
+	}
+	return rows
+}
+
+// CheckCapabilities returns the problems of the benchmark configuration
+// of the database, that the probed capabilities cannot run.
+func (cfg *Config) CheckCapabilities(databaseID string, caps Capabilities) []string {
+	gcfg, ok := cfg.DatabaseIDToConfigClientMachineAgentControl[databaseID]
+	if !ok {
+		return []string{fmt.Sprintf("%q is not found", databaseID)}
+	}
+	opts := gcfg.ConfigClientMachineBenchmarkOptions
+
+	var problems []string
+	if (opts.Type == "txn" || opts.Type == "bank") && !(caps.Txn && caps.CAS) {
+		problems = append(problems, fmt.Sprintf("%q type requires transactions and compare-and-swap", opts.Type))
+	}
+	if opts.ConfigClientMachineIdentityLease != nil && !caps.TTL {
+		problems = append(problems, "'identity_lease' requires keys with TTL")
+	}
+	if caps.MaxValueBytes > 0 && caps.MaxValueBytes < capabilityMaxValueBytes && opts.ValueSizeBytes > caps.MaxValueBytes {
+		problems = append(problems, fmt.Sprintf("'value_size_bytes' %d exceeds the largest value written %d", opts.ValueSizeBytes, caps.MaxValueBytes))
+	}
+	switch databaseID {
+	case "etcd__other", "etcd__tip", "etcd__v3_2", "etcd__v3_3":
+		if caps.AuthEnabled && opts.EtcdUsername == "" && opts.EtcdRBAC == "" {
+			problems = append(problems, "auth is enabled, but 'ETCD_USERNAME' is not set")
+		}
+	case "consul__v1_0_2":
+		if caps.AuthEnabled && opts.ConsulToken == "" {
+			problems = append(problems, "ACL is enabled, but 'CONSUL_HTTP_TOKEN' is not set")
+		}
+	}
+	return problems
+}
@@ -0,0 +1,125 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package capabilities
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/coreos/dbtester"
+	"github.com/coreos/dbtester/control"
+	"github.com/coreos/dbtester/dbtesterpb"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+// Command implements 'capabilities' command.
+var Command = &cobra.Command{
+	Use:   "capabilities",
+	Short: "Probes the features that the databases support.",
+	RunE:  commandFunc,
+}
+
+var databaseID string
+var configPath string
+var endpoints []string
+
+func init() {
+	ids := dbtesterpb.GetAllDatabaseIDs()
+	Command.PersistentFlags().StringVar(&databaseID, "database-id", "", "Database ID to probe: "+strings.Join(ids, ", ")+". Empty to probe all databases of '--config'.")
+	Command.PersistentFlags().StringVarP(&configPath, "config", "c", "", "YAML configuration file path, to probe its databases and validate its benchmarks against the capabilities.")
+	Command.PersistentFlags().StringSliceVar(&endpoints, "endpoints", nil, "Database endpoints to probe, instead of the endpoints of '--config'.")
+}
+
+func commandFunc(cmd *cobra.Command, args []string) error {
+	var cfg *dbtester.Config
+	var gcfgs []dbtesterpb.ConfigClientMachineAgentControl
+	switch {
+	case len(endpoints) > 0:
+		if !dbtesterpb.IsValidDatabaseID(databaseID) {
+			return fmt.Errorf("database id %q is unknown", databaseID)
+		}
+		gcfgs = append(gcfgs, dbtesterpb.ConfigClientMachineAgentControl{DatabaseID: databaseID, DatabaseEndpoints: endpoints})
+
+	case configPath != "":
+		var err error
+		if cfg, err = dbtester.ReadConfig(configPath, false); err != nil {
+			return err
+		}
+		for id, gcfg := range cfg.DatabaseIDToConfigClientMachineAgentControl {
+			if databaseID == "" || databaseID == id {
+				gcfgs = append(gcfgs, gcfg)
+			}
+		}
+		if len(gcfgs) == 0 {
+			return fmt.Errorf("%q is not found in %q", databaseID, configPath)
+		}
+		sort.Slice(gcfgs, func(i, j int) bool { return gcfgs[i].DatabaseID < gcfgs[j].DatabaseID })
+
+	default:
+		return fmt.Errorf("either '--endpoints' or '--config' is required")
+	}
+
+	var caps []dbtester.Capabilities
+	steps := make([]control.Step, len(gcfgs))
+	for i := range gcfgs {
+		gcfg := gcfgs[i]
+		steps[i] = control.Step{
+			Name:       gcfg.DatabaseID,
+			DatabaseID: gcfg.DatabaseID,
+			Run: func(*dbtester.Config) error {
+				lg.Info("probing capabilities", zap.String("database", gcfg.DatabaseID), zap.Strings("endpoints", gcfg.DatabaseEndpoints))
+				c, err := dbtester.ProbeCapabilities(lg, gcfg)
+				if err != nil {
+					return fmt.Errorf("failed to probe %q (%v)", gcfg.DatabaseID, err)
+				}
+				caps = append(caps, c)
+				return nil
+			},
+		}
+	}
+	if err := control.RunSteps(control.Sequence{}, steps); err != nil {
+		return err
+	}
+
+	rows := dbtester.CapabilityMatrix(caps)
+	tw := tablewriter.NewWriter(os.Stdout)
+	tw.SetHeader(rows[0])
+	for _, row := range rows[1:] {
+		tw.Append(row)
+	}
+	tw.SetAutoFormatHeaders(false)
+	tw.Render()
+
+	if cfg == nil {
+		return nil
+	}
+	problemN := 0
+	for _, c := range caps {
+		for _, p := range cfg.CheckCapabilities(c.DatabaseID, c) {
+			fmt.Printf("%q: %s\n", c.DatabaseID, p)
+			problemN++
+		}
+	}
+	if problemN > 0 {
+		return fmt.Errorf("%d configuration problem(s) found", problemN)
+	}
+	fmt.Println("configuration is supported by all databases")
+	return nil
+}
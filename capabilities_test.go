@@ -0,0 +1,78 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/coreos/dbtester/dbtesterpb"
+
+	"go.uber.org/zap"
+)
+
+func TestProbeMaxValueBytes(t *testing.T) {
+	limit := 512 * 1024
+	n := probeMaxValueBytes(func(size int) error {
+		if size > limit {
+			return errors.New("too large")
+		}
+		return nil
+	})
+	if n > int64(limit) || n < int64(limit-1024) {
+		t.Fatalf("expected %d to the KiB, got %d", limit, n)
+	}
+	if n = probeMaxValueBytes(func(int) error { return nil }); n != capabilityMaxValueBytes {
+		t.Fatalf("expected %d, got %d", capabilityMaxValueBytes, n)
+	}
+}
+
+func TestCapabilities(t *testing.T) {
+	gcfg := dbtesterpb.ConfigClientMachineAgentControl{
+		DatabaseID: "mock",
+		ConfigClientMachineBenchmarkOptions: &dbtesterpb.ConfigClientMachineBenchmarkOptions{
+			Type:                             "txn",
+			ValueSizeBytes:                   1024,
+			ConfigClientMachineIdentityLease: &dbtesterpb.ConfigClientMachineIdentityLease{},
+		},
+	}
+	caps, err := ProbeCapabilities(zap.NewNop(), gcfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := [][]string{
+		{"FEATURE", "mock"},
+		{"version", "mock"},
+		{"txn", "yes"},
+		{"ttl", "no"},
+		{"watch", "no"},
+		{"cas", "yes"},
+		{"max value size", ">= 8.0 MiB"},
+		{"auth enabled", "no"},
+	}
+	if rows := CapabilityMatrix([]Capabilities{caps}); !reflect.DeepEqual(rows, exp) {
+		t.Fatalf("expected %q, got %q", exp, rows)
+	}
+
+	cfg := &Config{DatabaseIDToConfigClientMachineAgentControl: map[string]dbtesterpb.ConfigClientMachineAgentControl{"mock": gcfg}}
+	if ps := cfg.CheckCapabilities("mock", caps); len(ps) != 1 {
+		t.Fatalf("expected 'identity_lease' problem, got %q", ps)
+	}
+	caps.Txn, caps.MaxValueBytes = false, 512
+	if ps := cfg.CheckCapabilities("mock", caps); len(ps) != 3 {
+		t.Fatalf("expected 3 problems, got %q", ps)
+	}
+}
@@ -0,0 +1,140 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cas
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/coreos/dbtester"
+	"github.com/coreos/dbtester/control"
+	"github.com/coreos/dbtester/dbtesterpb"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+// Command implements 'cas' command.
+var Command = &cobra.Command{
+	Use:   "cas",
+	Short: "Benchmarks the compare-and-swap contention on one key.",
+	Long: `Makes the clients increment the counter of one key with compare-and-swap,
+retrying from the read on conflicts, and measures the success rate of the
+swaps and the latency of the increments with the retries: etcd transactions
+comparing the mod revision, Zookeeper sets with the version, and Consul
+check-and-set with the modify index.`,
+	RunE: commandFunc,
+}
+
+var (
+	databaseID string
+	configPath string
+	endpoints  []string
+	certFile   string
+	keyFile    string
+	caFile     string
+	opts       dbtester.CASBenchOptions
+)
+
+func init() {
+	ids := dbtesterpb.GetAllDatabaseIDs()
+	Command.PersistentFlags().StringVar(&databaseID, "database-id", "", "Database ID to benchmark: "+strings.Join(ids, ", ")+". Empty to benchmark all databases of '--config'.")
+	Command.PersistentFlags().StringVarP(&configPath, "config", "c", "", "YAML configuration file path, to benchmark its databases.")
+	Command.PersistentFlags().StringSliceVar(&endpoints, "endpoints", nil, "Database endpoints to benchmark, instead of the endpoints of '--config'.")
+	Command.PersistentFlags().StringVar(&certFile, "cert", "", "Client certificate to connect to the database with TLS (etcd, Zookeeper 'secureClientPort' and Consul HTTPS). Requires '--key'.")
+	Command.PersistentFlags().StringVar(&keyFile, "key", "", "Private key of '--cert'.")
+	Command.PersistentFlags().StringVar(&caFile, "cacert", "", "CA certificate to verify the database servers with, to connect with TLS. Empty to verify with the system roots when '--cert' is set.")
+	Command.PersistentFlags().IntVar(&opts.Clients, "clients", 10, "Number of clients that contend on the key.")
+	Command.PersistentFlags().IntVar(&opts.Connections, "connections", 10, "Number of client connections that the clients share.")
+	Command.PersistentFlags().IntVar(&opts.Increments, "increments", 1000, "Number of successful compare-and-swaps of all clients.")
+}
+
+func commandFunc(cmd *cobra.Command, args []string) error {
+	if err := dbtester.SetClientTLS(certFile, keyFile, caFile); err != nil {
+		return err
+	}
+
+	var gcfgs []dbtesterpb.ConfigClientMachineAgentControl
+	switch {
+	case len(endpoints) > 0:
+		if !dbtesterpb.IsValidDatabaseID(databaseID) {
+			return fmt.Errorf("database id %q is unknown", databaseID)
+		}
+		gcfgs = append(gcfgs, dbtesterpb.ConfigClientMachineAgentControl{DatabaseID: databaseID, DatabaseEndpoints: endpoints})
+
+	case configPath != "":
+		cfg, err := dbtester.ReadConfig(configPath, false)
+		if err != nil {
+			return err
+		}
+		for id, gcfg := range cfg.DatabaseIDToConfigClientMachineAgentControl {
+			if databaseID == "" || databaseID == id {
+				gcfgs = append(gcfgs, gcfg)
+			}
+		}
+		if len(gcfgs) == 0 {
+			return fmt.Errorf("%q is not found in %q", databaseID, configPath)
+		}
+		sort.Slice(gcfgs, func(i, j int) bool { return gcfgs[i].DatabaseID < gcfgs[j].DatabaseID })
+
+	default:
+		return fmt.Errorf("either '--endpoints' or '--config' is required")
+	}
+
+	var rss []dbtester.CASBenchResult
+	steps := make([]control.Step, len(gcfgs))
+	for i := range gcfgs {
+		gcfg := gcfgs[i]
+		steps[i] = control.Step{
+			Name:       gcfg.DatabaseID,
+			DatabaseID: gcfg.DatabaseID,
+			Run: func(*dbtester.Config) error {
+				lg.Info("benchmarking compare-and-swap", zap.String("database", gcfg.DatabaseID), zap.Strings("endpoints", gcfg.DatabaseEndpoints))
+				rs, err := dbtester.CASBench(lg, gcfg, opts)
+				if err != nil {
+					return fmt.Errorf("failed to benchmark %q (%v)", gcfg.DatabaseID, err)
+				}
+				rss = append(rss, rs)
+				return nil
+			},
+		}
+	}
+	if err := control.RunSteps(control.Sequence{}, steps); err != nil {
+		return err
+	}
+
+	tw := tablewriter.NewWriter(os.Stdout)
+	tw.SetHeader([]string{"DATABASE", "CLIENTS", "INCREMENTS", "ATTEMPTS", "ERRORS", "SUCCESS-RATE-PERCENT", "MAX-ATTEMPTS", "P50-MS", "P99-MS", "LOST-UPDATES"})
+	for _, rs := range rss {
+		tw.Append([]string{
+			rs.DatabaseID,
+			fmt.Sprintf("%d", rs.Clients),
+			fmt.Sprintf("%d", rs.Increments),
+			fmt.Sprintf("%d", rs.Attempts),
+			fmt.Sprintf("%d", rs.Errors),
+			fmt.Sprintf("%.2f", rs.SuccessRatePercent),
+			fmt.Sprintf("%d", rs.MaxAttempts),
+			fmt.Sprintf("%.3f", rs.P50Ms),
+			fmt.Sprintf("%.3f", rs.P99Ms),
+			fmt.Sprintf("%d", rs.LostUpdates),
+		})
+	}
+	tw.SetAutoFormatHeaders(false)
+	tw.Render()
+	return nil
+}
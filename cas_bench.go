@@ -0,0 +1,313 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/coreos/dbtester/dbtesterpb"
+
+	"github.com/coreos/etcd/clientv3"
+	consulapi "github.com/hashicorp/consul/api"
+	"github.com/samuel/go-zookeeper/zk"
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+)
+
+// casBenchKey is the key that the clients of the CAS benchmark contend on.
+const casBenchKey = "dbtester-cas-bench"
+
+// CASBenchOptions configures the compare-and-swap benchmark.
+type CASBenchOptions struct {
+	// Clients is the number of clients that contend on the key.
+	Clients int
+	// Connections is the number of client connections that the
+	// clients share, in round-robin order.
+	Connections int
+	// Increments is the number of successful compare-and-swaps of all
+	// clients, each of which increments the counter of the key.
+	Increments int
+}
+
+// CASBenchResult is the optimistic concurrency of a database, of the
+// clients that read the counter and swap it with its increment, and
+// retry from the read on conflicts.
+type CASBenchResult struct {
+	DatabaseID string
+	Clients    int
+	Increments int
+
+	// Attempts is the number of compare-and-swaps, with the conflicts.
+	Attempts int
+	// Errors is the number of increments that failed on errors
+	// other than conflicts.
+	Errors int
+	// SuccessRatePercent is the percentage of the attempts that swapped.
+	SuccessRatePercent float64
+	// MaxAttempts is of the increment with the most retries.
+	MaxAttempts int
+
+	// P50Ms and P99Ms are of the increments from the first read
+	// to the successful swap, with the retries.
+	P50Ms float64
+	P99Ms float64
+
+	// LostUpdates is the number of successful increments missing
+	// from the counter after the benchmark, which must be 0.
+	LostUpdates int
+}
+
+// casBackend reads and swaps the counter of the benchmark key.
+type casBackend struct {
+	// reset writes the counter 0.
+	reset func() error
+	// get reads the counter on the connection, with its version.
+	get func(ctx context.Context, conn int) (v int64, ver int64, err error)
+	// cas writes the counter if the key is still of the version,
+	// and returns false on conflicts.
+	cas func(ctx context.Context, conn int, v, ver int64) (bool, error)
+	// close deletes the key, and closes the connections.
+	close func()
+}
+
+// CASBench measures the compare-and-swap contention of the clients on one
+// key: etcd transactions comparing the mod revision, Zookeeper sets with
+// the version, and Consul check-and-set with the modify index.
+func CASBench(lg *zap.Logger, gcfg dbtesterpb.ConfigClientMachineAgentControl, opts CASBenchOptions) (CASBenchResult, error) {
+	rs := CASBenchResult{DatabaseID: gcfg.DatabaseID, Clients: opts.Clients, Increments: opts.Increments}
+	if opts.Increments < 1 || opts.Clients < 1 || opts.Connections < 1 {
+		return rs, fmt.Errorf("increments, clients and connections must be positive (got %d, %d, %d)", opts.Increments, opts.Clients, opts.Connections)
+	}
+	if opts.Connections > opts.Clients {
+		opts.Connections = opts.Clients
+	}
+	if len(gcfg.DatabaseEndpoints) == 0 {
+		return rs, fmt.Errorf("no endpoint to benchmark %q", gcfg.DatabaseID)
+	}
+	if bopts := gcfg.ConfigClientMachineBenchmarkOptions; bopts != nil {
+		if bopts.EtcdUsername != "" {
+			setEtcdAuth(bopts.EtcdUsername, bopts.EtcdPassword)
+		}
+		if bopts.ConsulToken != "" {
+			consulToken = bopts.ConsulToken
+		}
+	}
+
+	var b *casBackend
+	switch gcfg.DatabaseID {
+	case "etcd__other", "etcd__tip", "etcd__v3_2", "etcd__v3_3":
+		b = newCASBackendEtcdv3(gcfg.DatabaseEndpoints, opts.Connections)
+	case "zookeeper__r3_5_3_beta", "zetcd__beta":
+		b = newCASBackendZk(gcfg.DatabaseEndpoints, opts.Connections)
+	case "consul__v1_0_2", "cetcd__beta":
+		b = newCASBackendConsul(gcfg.DatabaseEndpoints, opts.Connections)
+	default:
+		return rs, fmt.Errorf("%q does not support compare-and-swap", gcfg.DatabaseID)
+	}
+	defer b.close()
+
+	if err := b.reset(); err != nil {
+		return rs, err
+	}
+	return runCASBench(lg, b, opts, rs)
+}
+
+func runCASBench(lg *zap.Logger, b *casBackend, opts CASBenchOptions, rs CASBenchResult) (CASBenchResult, error) {
+	ctx := context.Background()
+	var (
+		mu   sync.Mutex
+		lats []float64
+	)
+	incc := make(chan struct{}, opts.Clients)
+	go func() {
+		for i := 0; i < opts.Increments; i++ {
+			incc <- struct{}{}
+		}
+		close(incc)
+	}()
+
+	var wg sync.WaitGroup
+	wg.Add(opts.Clients)
+	for i := 0; i < opts.Clients; i++ {
+		go func(conn int) {
+			defer wg.Done()
+			for range incc {
+				now := time.Now()
+				attempts, err := casIncrement(ctx, b, conn)
+				took := time.Since(now)
+
+				mu.Lock()
+				rs.Attempts += attempts
+				if rs.MaxAttempts < attempts {
+					rs.MaxAttempts = attempts
+				}
+				if err != nil {
+					rs.Errors++
+				} else {
+					lats = append(lats, float64(took)/float64(time.Millisecond))
+				}
+				mu.Unlock()
+				if err != nil {
+					lg.Warn("increment failed", zap.Int("attempts", attempts), zap.Error(err))
+				}
+			}
+		}(i % opts.Connections)
+	}
+	wg.Wait()
+
+	if rs.Attempts > 0 {
+		rs.SuccessRatePercent = 100 * float64(len(lats)) / float64(rs.Attempts)
+	}
+	rs.P50Ms = percentileOf(lats, 50)
+	rs.P99Ms = percentileOf(lats, 99)
+
+	v, _, err := b.get(ctx, 0)
+	if err != nil {
+		return rs, err
+	}
+	rs.LostUpdates = len(lats) - int(v)
+	if rs.LostUpdates != 0 {
+		lg.Warn("lost updates", zap.Int("increments", len(lats)), zap.Int64("counter", v))
+	}
+	return rs, nil
+}
+
+// casIncrement reads and swaps the counter with its increment until
+// the swap succeeds, and returns the number of the swaps attempted.
+func casIncrement(ctx context.Context, b *casBackend, conn int) (int, error) {
+	for attempts := 1; ; attempts++ {
+		v, ver, err := b.get(ctx, conn)
+		if err != nil {
+			return attempts - 1, err
+		}
+		ok, err := b.cas(ctx, conn, v+1, ver)
+		if err != nil {
+			return attempts, err
+		}
+		if ok {
+			return attempts, nil
+		}
+	}
+}
+
+func parseCounter(v []byte) (int64, error) {
+	return strconv.ParseInt(string(v), 10, 64)
+}
+
+func newCASBackendEtcdv3(endpoints []string, conns int) *casBackend {
+	clis := make([]*clientv3.Client, conns)
+	for i := range clis {
+		clis[i] = mustCreateConnEtcdv3(endpoints)
+	}
+	return &casBackend{
+		reset: func() error {
+			_, err := clis[0].Put(context.Background(), casBenchKey, "0")
+			return err
+		},
+		get: func(ctx context.Context, conn int) (int64, int64, error) {
+			resp, err := clis[conn].Get(ctx, casBenchKey)
+			if err != nil {
+				return 0, 0, err
+			}
+			if len(resp.Kvs) == 0 {
+				return 0, 0, fmt.Errorf("%q not found", casBenchKey)
+			}
+			v, err := parseCounter(resp.Kvs[0].Value)
+			return v, resp.Kvs[0].ModRevision, err
+		},
+		cas: func(ctx context.Context, conn int, v, ver int64) (bool, error) {
+			resp, err := clis[conn].Txn(ctx).
+				If(clientv3.Compare(clientv3.ModRevision(casBenchKey), "=", ver)).
+				Then(clientv3.OpPut(casBenchKey, strconv.FormatInt(v, 10))).
+				Commit()
+			if err != nil {
+				return false, err
+			}
+			return resp.Succeeded, nil
+		},
+		close: func() {
+			clis[0].Delete(context.Background(), casBenchKey)
+			for _, cli := range clis {
+				cli.Close()
+			}
+		},
+	}
+}
+
+func newCASBackendZk(endpoints []string, conns int) *casBackend {
+	zconns := mustCreateConnsZk(endpoints, int64(conns))
+	path := "/" + casBenchKey
+	return &casBackend{
+		reset: func() error {
+			_, err := zconns[0].Set(path, []byte("0"), -1)
+			if err == zk.ErrNoNode {
+				_, err = zconns[0].Create(path, []byte("0"), zkCreateFlags, zkCreateACL)
+			}
+			return err
+		},
+		get: func(ctx context.Context, conn int) (int64, int64, error) {
+			data, stat, err := zconns[conn].Get(path)
+			if err != nil {
+				return 0, 0, err
+			}
+			v, err := parseCounter(data)
+			return v, int64(stat.Version), err
+		},
+		cas: func(ctx context.Context, conn int, v, ver int64) (bool, error) {
+			_, err := zconns[conn].Set(path, []byte(strconv.FormatInt(v, 10)), int32(ver))
+			if err == zk.ErrBadVersion {
+				return false, nil
+			}
+			return err == nil, err
+		},
+		close: func() {
+			zconns[0].Delete(path, -1)
+			for _, c := range zconns {
+				c.Close()
+			}
+		},
+	}
+}
+
+func newCASBackendConsul(endpoints []string, conns int) *casBackend {
+	kvs := mustCreateConnsConsul(endpoints, int64(conns))
+	return &casBackend{
+		reset: func() error {
+			_, err := kvs[0].Put(&consulapi.KVPair{Key: casBenchKey, Value: []byte("0")}, nil)
+			return err
+		},
+		get: func(ctx context.Context, conn int) (int64, int64, error) {
+			pair, _, err := kvs[conn].Get(casBenchKey, (&consulapi.QueryOptions{RequireConsistent: true}).WithContext(ctx))
+			if err != nil {
+				return 0, 0, err
+			}
+			if pair == nil {
+				return 0, 0, fmt.Errorf("%q not found", casBenchKey)
+			}
+			v, err := parseCounter(pair.Value)
+			return v, int64(pair.ModifyIndex), err
+		},
+		cas: func(ctx context.Context, conn int, v, ver int64) (bool, error) {
+			ok, _, err := kvs[conn].CAS(&consulapi.KVPair{Key: casBenchKey, Value: []byte(strconv.FormatInt(v, 10)), ModifyIndex: uint64(ver)}, (&consulapi.WriteOptions{}).WithContext(ctx))
+			return ok, err
+		},
+		close: func() {
+			kvs[0].Delete(casBenchKey, nil)
+		},
+	}
+}
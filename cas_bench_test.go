@@ -0,0 +1,77 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+)
+
+// newCASBackendMem keeps the counter in memory, and yields between
+// the reads and the swaps so that the clients conflict.
+func newCASBackendMem() *casBackend {
+	var (
+		mu       sync.Mutex
+		v, ver   int64
+		readOnce sync.Once
+	)
+	return &casBackend{
+		reset: func() error {
+			mu.Lock()
+			v, ver = 0, 0
+			mu.Unlock()
+			return nil
+		},
+		get: func(ctx context.Context, conn int) (int64, int64, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			return v, ver, nil
+		},
+		cas: func(ctx context.Context, conn int, nv, nver int64) (bool, error) {
+			readOnce.Do(func() { time.Sleep(time.Millisecond) })
+			time.Sleep(10 * time.Microsecond)
+			mu.Lock()
+			defer mu.Unlock()
+			if ver != nver {
+				return false, nil
+			}
+			v, ver = nv, ver+1
+			return true, nil
+		},
+		close: func() {},
+	}
+}
+
+func TestRunCASBench(t *testing.T) {
+	b := newCASBackendMem()
+	opts := CASBenchOptions{Clients: 8, Connections: 2, Increments: 200}
+	rs, err := runCASBench(zap.NewNop(), b, opts, CASBenchResult{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rs.Errors != 0 || rs.LostUpdates != 0 {
+		t.Fatalf("expected no errors or lost updates, got %+v", rs)
+	}
+	if rs.Attempts <= opts.Increments || rs.SuccessRatePercent >= 100 || rs.MaxAttempts < 2 {
+		t.Fatalf("expected conflicts of the clients, got %+v", rs)
+	}
+	if v, _, _ := b.get(context.Background(), 0); v != int64(opts.Increments) {
+		t.Fatalf("expected counter %d, got %d", opts.Increments, v)
+	}
+}
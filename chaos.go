@@ -0,0 +1,484 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/coreos/dbtester/dbtesterpb"
+
+	"github.com/gyuho/dataframe"
+	consulapi "github.com/hashicorp/consul/api"
+	"github.com/samuel/go-zookeeper/zk"
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+)
+
+// chaosEvent is one event of the 'chaos' schedule:
+//
+//	at <duration> kill <leader|nK> [for <duration>]
+//	at <duration> restart <nK>
+//	at <duration> partition <nK,nK|nK,...> [for <duration>]
+//	at <duration> heal
+//
+// Members are 1-indexed in the order of 'peer_ips'. 'heal' removes
+// all partitions and restarts all killed members.
+type chaosEvent struct {
+	at     time.Duration
+	action string
+	// target is 'leader', or the member index
+	target string
+	member int
+	// groups are the member indexes of each side of the partition
+	groups [][]int
+	// dur is the time to undo the event after, if not 0
+	dur time.Duration
+}
+
+func (ev chaosEvent) String() string {
+	s := fmt.Sprintf("at %v %s", ev.at, ev.action)
+	switch ev.action {
+	case "kill", "restart":
+		s += " " + ev.target
+	case "partition":
+		gs := make([]string, len(ev.groups))
+		for i, g := range ev.groups {
+			ns := make([]string, len(g))
+			for j, m := range g {
+				ns[j] = fmt.Sprintf("n%d", m+1)
+			}
+			gs[i] = strings.Join(ns, ",")
+		}
+		s += " " + strings.Join(gs, "|")
+	}
+	if ev.dur > 0 {
+		s += fmt.Sprintf(" for %v", ev.dur)
+	}
+	return s
+}
+
+func parseChaosMember(s string, memberN int) (int, error) {
+	if !strings.HasPrefix(s, "n") {
+		return 0, fmt.Errorf("unknown member %q (expected 'n1' to 'n%d')", s, memberN)
+	}
+	n, err := strconv.Atoi(s[1:])
+	if err != nil || n < 1 || n > memberN {
+		return 0, fmt.Errorf("unknown member %q (expected 'n1' to 'n%d')", s, memberN)
+	}
+	return n - 1, nil
+}
+
+// parseChaos parses the schedule of events separated
+// by ';' or new lines, in the order of time.
+func parseChaos(s string, memberN int) ([]chaosEvent, error) {
+	var evs []chaosEvent
+	for _, line := range strings.FieldsFunc(s, func(r rune) bool { return r == ';' || r == '\n' }) {
+		fs := strings.Fields(line)
+		if len(fs) == 0 || strings.HasPrefix(fs[0], "#") {
+			continue
+		}
+		if len(fs) < 3 || fs[0] != "at" {
+			return nil, fmt.Errorf("%q: expected 'at <duration> <action>'", line)
+		}
+		at, err := time.ParseDuration(fs[1])
+		if err != nil {
+			return nil, fmt.Errorf("%q: %v", line, err)
+		}
+		ev := chaosEvent{at: at, action: fs[2]}
+		args := fs[3:]
+		if n := len(args); n >= 2 && args[n-2] == "for" {
+			if ev.dur, err = time.ParseDuration(args[n-1]); err != nil {
+				return nil, fmt.Errorf("%q: %v", line, err)
+			}
+			args = args[:n-2]
+		}
+
+		switch ev.action {
+		case "kill", "restart":
+			if len(args) != 1 {
+				return nil, fmt.Errorf("%q: expected one member", line)
+			}
+			ev.target = args[0]
+			if ev.target == "leader" && ev.action == "kill" {
+				break
+			}
+			if ev.member, err = parseChaosMember(ev.target, memberN); err != nil {
+				return nil, fmt.Errorf("%q: %v", line, err)
+			}
+			if ev.action == "restart" && ev.dur > 0 {
+				return nil, fmt.Errorf("%q: 'restart' does not take 'for'", line)
+			}
+
+		case "partition":
+			if len(args) != 1 {
+				return nil, fmt.Errorf("%q: expected groups of members (e.g. 'n1,n2|n3')", line)
+			}
+			seen := make(map[int]bool)
+			for _, g := range strings.Split(args[0], "|") {
+				var group []int
+				for _, m := range strings.Split(g, ",") {
+					idx, err := parseChaosMember(m, memberN)
+					if err != nil {
+						return nil, fmt.Errorf("%q: %v", line, err)
+					}
+					if seen[idx] {
+						return nil, fmt.Errorf("%q: member %q is in more than one group", line, m)
+					}
+					seen[idx] = true
+					group = append(group, idx)
+				}
+				ev.groups = append(ev.groups, group)
+			}
+			if len(ev.groups) < 2 {
+				return nil, fmt.Errorf("%q: expected at least 2 groups", line)
+			}
+
+		case "heal":
+			if len(args) != 0 || ev.dur > 0 {
+				return nil, fmt.Errorf("%q: 'heal' takes no arguments", line)
+			}
+
+		default:
+			return nil, fmt.Errorf("%q: unknown action %q (expected 'kill', 'restart', 'partition' or 'heal')", line, ev.action)
+		}
+		evs = append(evs, ev)
+	}
+	if len(evs) == 0 {
+		return nil, fmt.Errorf("no event in %q", s)
+	}
+	sort.SliceStable(evs, func(i, j int) bool { return evs[i].at < evs[j].at })
+	return evs, nil
+}
+
+// chaosRecord is an executed fault injection.
+type chaosRecord struct {
+	since   time.Duration
+	time    time.Time
+	event   string
+	members string
+	err     string
+}
+
+// chaos runs the schedule of fault injections while the benchmark runs.
+type chaos struct {
+	cfg  *Config
+	gcfg dbtesterpb.ConfigClientMachineAgentControl
+	evs  []chaosEvent
+
+	start time.Time
+	// executed is the number of scheduled events executed
+	executed int
+
+	mu          sync.Mutex
+	killed      map[int]bool
+	partitioned map[int]bool
+	records     []chaosRecord
+}
+
+func (c *chaos) record(ev string, members []int, err error) {
+	ns := make([]string, len(members))
+	for i, m := range members {
+		ns[i] = fmt.Sprintf("n%d", m+1)
+	}
+	now := time.Now()
+	rec := chaosRecord{since: now.Sub(c.start), time: now, event: ev, members: strings.Join(ns, " ")}
+	if err != nil {
+		rec.err = err.Error()
+		c.cfg.lg.Warn("chaos event failed", zap.String("event", ev), zap.String("members", rec.members), zap.Error(err))
+	} else {
+		c.cfg.lg.Info("chaos event", zap.String("event", ev), zap.String("members", rec.members))
+	}
+	c.mu.Lock()
+	c.records = append(c.records, rec)
+	c.mu.Unlock()
+}
+
+func (c *chaos) kill(idx int) error {
+	if _, err := c.cfg.sendRequest(c.gcfg.DatabaseID, dbtesterpb.Operation_Shutdown, idx); err != nil {
+		return err
+	}
+	c.mu.Lock()
+	c.killed[idx] = true
+	c.mu.Unlock()
+	return nil
+}
+
+func (c *chaos) restart(idx int) error {
+	if _, err := c.cfg.sendRequest(c.gcfg.DatabaseID, dbtesterpb.Operation_Restart, idx); err != nil {
+		return err
+	}
+	c.mu.Lock()
+	delete(c.killed, idx)
+	c.mu.Unlock()
+	return nil
+}
+
+func (c *chaos) partition(groups [][]int) error {
+	for gi, g := range groups {
+		var others []string
+		for gj, og := range groups {
+			if gi == gj {
+				continue
+			}
+			for _, m := range og {
+				others = append(others, c.gcfg.PeerIPs[m])
+			}
+		}
+		for _, m := range g {
+			req, err := c.cfg.ToRequest(c.gcfg.DatabaseID, dbtesterpb.Operation_Partition, m)
+			if err != nil {
+				return err
+			}
+			req.PartitionPeerIPsString = strings.Join(others, "___")
+			if _, err = c.cfg.transfer(m, c.gcfg.AgentEndpoints[m], req); err != nil {
+				return err
+			}
+			c.mu.Lock()
+			c.partitioned[m] = true
+			c.mu.Unlock()
+		}
+	}
+	return nil
+}
+
+// heal removes all partitions, and restarts all killed members.
+func (c *chaos) heal() (members []int, err error) {
+	c.mu.Lock()
+	var parted, killed []int
+	for m := range c.partitioned {
+		parted = append(parted, m)
+	}
+	for m := range c.killed {
+		killed = append(killed, m)
+	}
+	c.mu.Unlock()
+	sort.Ints(parted)
+	sort.Ints(killed)
+
+	for _, m := range parted {
+		if _, err = c.cfg.sendRequest(c.gcfg.DatabaseID, dbtesterpb.Operation_Heal, m); err != nil {
+			return members, err
+		}
+		c.mu.Lock()
+		delete(c.partitioned, m)
+		c.mu.Unlock()
+		members = append(members, m)
+	}
+	for _, m := range killed {
+		if err = c.restart(m); err != nil {
+			return members, err
+		}
+		members = append(members, m)
+	}
+	return members, nil
+}
+
+// run executes the events, until all events are done or 'stopc' is closed.
+func (c *chaos) run(stopc <-chan struct{}) {
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	// undo after 'for', unless stopped
+	undo := func(d time.Duration, ev string, members []int, f func() error) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			select {
+			case <-time.After(d):
+				c.record(ev, members, f())
+			case <-stopc:
+			}
+		}()
+	}
+
+	for _, ev := range c.evs {
+		select {
+		case <-time.After(time.Until(c.start.Add(ev.at))):
+		case <-stopc:
+			return
+		}
+
+		switch ev.action {
+		case "kill":
+			idx := ev.member
+			if ev.target == "leader" {
+				var err error
+				if idx, err = findLeader(c.gcfg); err != nil {
+					c.record(ev.String(), nil, err)
+					c.executed++
+					continue
+				}
+			}
+			err := c.kill(idx)
+			c.record(ev.String(), []int{idx}, err)
+			if err == nil {
+				c.cfg.spikes.mark(ev.String(), time.Now())
+			}
+			if err == nil && ev.dur > 0 {
+				undo(ev.dur, fmt.Sprintf("restart n%d after %v", idx+1, ev.dur), []int{idx}, func() error { return c.restart(idx) })
+			}
+
+		case "restart":
+			c.record(ev.String(), []int{ev.member}, c.restart(ev.member))
+
+		case "partition":
+			var members []int
+			for _, g := range ev.groups {
+				members = append(members, g...)
+			}
+			err := c.partition(ev.groups)
+			c.record(ev.String(), members, err)
+			if err == nil {
+				c.cfg.spikes.mark(ev.String(), time.Now())
+			}
+			if err == nil && ev.dur > 0 {
+				undo(ev.dur, fmt.Sprintf("heal after %v", ev.dur), members, func() error {
+					_, err := c.heal()
+					return err
+				})
+			}
+
+		case "heal":
+			members, err := c.heal()
+			c.record(ev.String(), members, err)
+		}
+		c.executed++
+	}
+}
+
+// findLeader returns the index of the leader member.
+func findLeader(gcfg dbtesterpb.ConfigClientMachineAgentControl) (int, error) {
+	switch gcfg.DatabaseID {
+	case "etcd__other", "etcd__tip", "etcd__v3_2", "etcd__v3_3":
+		for i, ep := range gcfg.DatabaseEndpoints {
+			cli := mustCreateConnEtcdv3([]string{ep})
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			resp, err := cli.Status(ctx, ep)
+			cancel()
+			cli.Close()
+			if err == nil && resp.Header != nil && resp.Leader == resp.Header.MemberId {
+				return i, nil
+			}
+		}
+
+	case "zookeeper__r3_5_3_beta", "zetcd__beta":
+		stats, _ := zk.FLWSrvr(gcfg.DatabaseEndpoints, 5*time.Second)
+		for i, st := range stats {
+			if st != nil && st.Mode == zk.ModeLeader {
+				return i, nil
+			}
+		}
+
+	case "consul__v1_0_2", "cetcd__beta":
+		cli, err := consulapi.NewClient(newConsulConfig(gcfg.DatabaseEndpoints[0]))
+		if err != nil {
+			return 0, err
+		}
+		leader, err := cli.Status().Leader()
+		if err != nil {
+			return 0, err
+		}
+		host, _, err := net.SplitHostPort(leader)
+		if err != nil {
+			return 0, err
+		}
+		for i, ip := range gcfg.PeerIPs {
+			if ip == host {
+				return i, nil
+			}
+		}
+
+	default:
+		return 0, fmt.Errorf("'kill leader' is not supported for %q", gcfg.DatabaseID)
+	}
+	return 0, fmt.Errorf("no leader found in %q", gcfg.DatabaseEndpoints)
+}
+
+// startChaos starts the 'chaos' schedule from now, and returns the
+// function to stop the schedule, heal the partitions and restart the
+// killed members after the benchmark.
+func (cfg *Config) startChaos(gcfg dbtesterpb.ConfigClientMachineAgentControl) (stop func()) {
+	// validated in 'ReadConfig'
+	evs, _ := parseChaos(gcfg.ConfigClientMachineBenchmarkOptions.Chaos, len(gcfg.PeerIPs))
+	c := &chaos{
+		cfg:         cfg,
+		gcfg:        gcfg,
+		evs:         evs,
+		start:       time.Now(),
+		killed:      make(map[int]bool),
+		partitioned: make(map[int]bool),
+	}
+	cfg.chaos = c
+
+	stopc, donec := make(chan struct{}), make(chan struct{})
+	go func() {
+		c.run(stopc)
+		close(donec)
+	}()
+	return func() {
+		close(stopc)
+		<-donec
+		if members, err := c.heal(); err != nil || len(members) > 0 {
+			c.record("heal after benchmark", members, err)
+		}
+		if c.executed < len(evs) {
+			cfg.lg.Warn("benchmark finished before all chaos events", zap.Int("executed", c.executed), zap.Int("events", len(evs)))
+		}
+	}
+}
+
+func (cfg *Config) saveChaos() {
+	c := cfg.chaos
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	fpath := cfg.ConfigClientMachineInitial.ClientChaosPath
+	if fpath == "" {
+		cfg.lg.Warn("'client_chaos_path' is not set; skipping chaos events")
+		return
+	}
+
+	c1 := dataframe.NewColumn("SINCE-START-MS")
+	c2 := dataframe.NewColumn("UNIX-NANOSECOND")
+	c3 := dataframe.NewColumn("EVENT")
+	c4 := dataframe.NewColumn("MEMBERS")
+	c5 := dataframe.NewColumn("ERROR")
+	for _, rec := range c.records {
+		c1.PushBack(dataframe.NewStringValue(fmt.Sprintf("%4.4f", toMillisecond(rec.since))))
+		c2.PushBack(dataframe.NewStringValue(rec.time.UnixNano()))
+		c3.PushBack(dataframe.NewStringValue(rec.event))
+		c4.PushBack(dataframe.NewStringValue(rec.members))
+		c5.PushBack(dataframe.NewStringValue(rec.err))
+	}
+	fr := dataframe.New()
+	for _, col := range []dataframe.Column{c1, c2, c3, c4, c5} {
+		if err := fr.AddColumn(col); err != nil {
+			panic(err)
+		}
+	}
+	if err := saveCSV(fr, fpath); err != nil {
+		panic(err)
+	}
+	cfg.lg.Info("saved chaos events", zap.String("path", fpath))
+}
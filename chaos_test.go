@@ -0,0 +1,52 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseChaos(t *testing.T) {
+	evs, err := parseChaos("at 4m partition n1,n2|n3,n4,n5 for 30s; at 2m kill leader\nat 6m heal", 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := []chaosEvent{
+		{at: 2 * time.Minute, action: "kill", target: "leader"},
+		{at: 4 * time.Minute, action: "partition", groups: [][]int{{0, 1}, {2, 3, 4}}, dur: 30 * time.Second},
+		{at: 6 * time.Minute, action: "heal"},
+	}
+	if !reflect.DeepEqual(evs, exp) {
+		t.Fatalf("expected %+v, got %+v", exp, evs)
+	}
+	if s := evs[1].String(); s != "at 4m0s partition n1,n2|n3,n4,n5 for 30s" {
+		t.Fatalf("unexpected event string %q", s)
+	}
+
+	for _, s := range []string{
+		"at 1m kill n6",
+		"at 1m partition n1,n2|n2,n3",
+		"at 1m partition n1,n2",
+		"at 1m restart leader",
+		"at soon heal",
+		"kill n1",
+	} {
+		if _, err := parseChaos(s, 5); err == nil {
+			t.Fatalf("expected error on %q", s)
+		}
+	}
+}
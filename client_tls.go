@@ -0,0 +1,82 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"time"
+)
+
+// clientTLS is the TLS configuration that all etcd, Zookeeper and
+// Consul clients connect with, if not nil.
+var clientTLS *tls.Config
+
+// SetClientTLS makes all etcd, Zookeeper and Consul clients connect with
+// TLS, with the client certificate and key if not empty, and verifying
+// the servers with the CA certificate, or with the system roots if empty.
+// It does nothing if all are empty.
+func SetClientTLS(certFile, keyFile, caFile string) error {
+	if certFile == "" && keyFile == "" && caFile == "" {
+		clientTLS = nil
+		return nil
+	}
+	if (certFile == "") != (keyFile == "") {
+		return fmt.Errorf("both the client certificate and key are required (got %q, %q)", certFile, keyFile)
+	}
+
+	tcfg := &tls.Config{}
+	if certFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return err
+		}
+		tcfg.Certificates = []tls.Certificate{cert}
+	}
+	if caFile != "" {
+		pem, err := ioutil.ReadFile(caFile)
+		if err != nil {
+			return err
+		}
+		tcfg.RootCAs = x509.NewCertPool()
+		if !tcfg.RootCAs.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("no CA certificate found in %q", caFile)
+		}
+	}
+	clientTLS = tcfg
+	return nil
+}
+
+// checkClientTLS returns an error if the clients of the database
+// cannot connect with TLS, and 'SetClientTLS' was called.
+func checkClientTLS(databaseID string) error {
+	if clientTLS == nil {
+		return nil
+	}
+	switch databaseID {
+	case "redis__v4_0", "tikv__v2_1":
+		return fmt.Errorf("TLS is not supported for %q", databaseID)
+	}
+	return nil
+}
+
+// dialZkTLS connects to the Zookeeper server with TLS,
+// which Zookeeper serves on its 'secureClientPort'.
+func dialZkTLS(network, address string, timeout time.Duration) (net.Conn, error) {
+	return tls.DialWithDialer(&net.Dialer{Timeout: timeout}, network, address, clientTLS)
+}
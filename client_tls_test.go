@@ -0,0 +1,96 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"crypto/tls"
+	"encoding/pem"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	consulapi "github.com/hashicorp/consul/api"
+)
+
+func TestClientTLS(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Write([]byte(`"10.0.0.1:8300"`))
+	}))
+	defer srv.Close()
+
+	dir, err := ioutil.TempDir("", "client-tls")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	ca := filepath.Join(dir, "ca.pem")
+	if err = ioutil.WriteFile(ca, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw}), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = SetClientTLS("cert.pem", "", ""); err == nil {
+		t.Fatal("expected error of certificate without key")
+	}
+	if err = SetClientTLS("", "", filepath.Join(dir, "none.pem")); err == nil {
+		t.Fatal("expected error of missing CA certificate")
+	}
+	if err = SetClientTLS("", "", ca); err != nil {
+		t.Fatal(err)
+	}
+	defer SetClientTLS("", "", "")
+
+	if err = checkClientTLS("redis__v4_0"); err == nil {
+		t.Fatal("expected error of TLS for Redis")
+	}
+	if err = checkClientTLS("consul__v1_0_2"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Zookeeper
+	addr := strings.TrimPrefix(srv.URL, "https://")
+	conn, err := dialZkTLS("tcp", addr, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = conn.(*tls.Conn).Handshake(); err != nil {
+		t.Fatal(err)
+	}
+	conn.Close()
+
+	// Consul
+	cli, err := consulapi.NewClient(newConsulConfig(addr))
+	if err != nil {
+		t.Fatal(err)
+	}
+	leader, err := cli.Status().Leader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if leader != "10.0.0.1:8300" {
+		t.Fatalf("unexpected leader %q", leader)
+	}
+
+	if err = SetClientTLS("", "", ""); err != nil {
+		t.Fatal(err)
+	}
+	if clientTLS != nil || checkClientTLS("redis__v4_0") != nil {
+		t.Fatal("expected no TLS")
+	}
+}
@@ -0,0 +1,190 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/coreos/dbtester/pkg/tikv"
+
+	consulapi "github.com/hashicorp/consul/api"
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+)
+
+// zkEnsembleConfigPath is the znode of the ensemble configuration,
+// of Zookeeper 3.5 dynamic reconfiguration.
+const zkEnsembleConfigPath = "/zookeeper/config"
+
+// ClusterIdentity identifies the cluster that the endpoints reach.
+type ClusterIdentity struct {
+	// ID is the etcd or TiKV cluster ID in hex, or the Consul
+	// datacenter. Empty for Zookeeper, which has no cluster ID.
+	ID string
+	// MemberN is the number of the etcd members, the Zookeeper
+	// servers of the ensemble configuration, the Consul raft peers,
+	// or the PD members of TiKV.
+	MemberN int
+}
+
+// ProbeClusterIdentity probes the identity of the cluster
+// that the endpoints of the database reach.
+func ProbeClusterIdentity(databaseID string, endpoints []string) (ClusterIdentity, error) {
+	if len(endpoints) == 0 && databaseID != "mock" {
+		return ClusterIdentity{}, fmt.Errorf("no endpoint to probe %q", databaseID)
+	}
+	switch databaseID {
+	case "etcd__other", "etcd__tip", "etcd__v3_2", "etcd__v3_3":
+		cli := mustCreateConnEtcdv3(endpoints)
+		defer cli.Close()
+		ctx, cancel := context.WithTimeout(context.Background(), capabilityProbeTimeout)
+		defer cancel()
+		resp, err := cli.MemberList(ctx)
+		if err != nil {
+			return ClusterIdentity{}, err
+		}
+		return ClusterIdentity{ID: fmt.Sprintf("%x", resp.Header.ClusterId), MemberN: len(resp.Members)}, nil
+
+	case "zookeeper__r3_5_3_beta", "zetcd__beta":
+		conn := mustCreateConnsZk(endpoints, 1)[0]
+		defer conn.Close()
+		data, _, err := conn.Get(zkEnsembleConfigPath)
+		if err != nil {
+			return ClusterIdentity{}, fmt.Errorf("cannot read %q (%v)", zkEnsembleConfigPath, err)
+		}
+		return ClusterIdentity{MemberN: zkEnsembleServerN(string(data))}, nil
+
+	case "consul__v1_0_2", "cetcd__beta":
+		cli, err := consulapi.NewClient(newConsulConfig(endpoints[0]))
+		if err != nil {
+			return ClusterIdentity{}, err
+		}
+		self, err := cli.Agent().Self()
+		if err != nil {
+			return ClusterIdentity{}, err
+		}
+		dc, _ := self["Config"]["Datacenter"].(string)
+		peers, err := cli.Status().Peers()
+		if err != nil {
+			return ClusterIdentity{}, err
+		}
+		return ClusterIdentity{ID: dc, MemberN: len(peers)}, nil
+
+	case "redis__v4_0":
+		return ClusterIdentity{}, fmt.Errorf("%q has no cluster identity to probe", databaseID)
+
+	case "tikv__v2_1":
+		cli, err := tikv.New(endpoints)
+		if err != nil {
+			return ClusterIdentity{}, err
+		}
+		defer cli.Close()
+		ctx, cancel := context.WithTimeout(context.Background(), capabilityProbeTimeout)
+		defer cancel()
+		names, err := cli.PDMembers(ctx)
+		if err != nil {
+			return ClusterIdentity{}, err
+		}
+		return ClusterIdentity{ID: fmt.Sprintf("%x", cli.ClusterID()), MemberN: len(names)}, nil
+
+	case "mock":
+		return ClusterIdentity{ID: "mock", MemberN: 1}, nil
+
+	default:
+		return ClusterIdentity{}, fmt.Errorf("%q is unknown database ID", databaseID)
+	}
+}
+
+// zkEnsembleServerN returns the number of the servers in the
+// ensemble configuration, of lines 'server.<id>=<address>'.
+func zkEnsembleServerN(config string) int {
+	n := 0
+	for _, line := range strings.Split(config, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "server.") {
+			n++
+		}
+	}
+	return n
+}
+
+// checkClusterIdentity returns an error if the identity does not match the
+// expected cluster ID and member count. Empty ID and 0 count are not checked.
+func checkClusterIdentity(id ClusterIdentity, expectID string, expectMemberN int) error {
+	if expectID != "" {
+		if id.ID == "" {
+			return fmt.Errorf("cluster ID is unknown, expected %q", expectID)
+		}
+		want := strings.TrimPrefix(strings.ToLower(expectID), "0x")
+		if want != strings.ToLower(id.ID) {
+			return fmt.Errorf("cluster ID %q does not match expected %q", id.ID, expectID)
+		}
+	}
+	if expectMemberN > 0 && id.MemberN != expectMemberN {
+		return fmt.Errorf("%d members do not match expected %d", id.MemberN, expectMemberN)
+	}
+	return nil
+}
+
+// CheckClusterIdentity probes the clusters that the stress would reach,
+// of 'ClusterEndpoints' or of the database endpoints, and returns an error
+// if any does not match 'ExpectClusterID' and 'ExpectMemberCount', so
+// that a misconfigured run does not load an unintended cluster.
+func (cfg *Config) CheckClusterIdentity(databaseID string) error {
+	if cfg.ExpectClusterID == "" && cfg.ExpectMemberCount == 0 {
+		return nil
+	}
+	gcfg, ok := cfg.DatabaseIDToConfigClientMachineAgentControl[databaseID]
+	if !ok {
+		return fmt.Errorf("%q is not found", databaseID)
+	}
+	if opts := gcfg.ConfigClientMachineBenchmarkOptions; opts != nil {
+		if opts.EtcdUsername != "" {
+			setEtcdAuth(opts.EtcdUsername, opts.EtcdPassword)
+		}
+		if opts.ConsulToken != "" {
+			consulToken = opts.ConsulToken
+		}
+	}
+
+	clusters := map[string][]string{"": gcfg.DatabaseEndpoints}
+	if databaseID == "tikv__v2_1" {
+		clusters[""] = tikvPDEndpoints(gcfg)
+	}
+	names := []string{""}
+	if len(cfg.ClusterEndpoints) > 0 {
+		clusters, names = cfg.ClusterEndpoints, cfg.clusterNames()
+	}
+	for _, name := range names {
+		id, err := ProbeClusterIdentity(databaseID, clusters[name])
+		if err != nil {
+			return fmt.Errorf("cannot probe cluster identity of %q (%v)", databaseID, err)
+		}
+		cfg.lg.Info(
+			"probed cluster identity",
+			zap.String("database", databaseID),
+			zap.String("cluster", name),
+			zap.String("cluster-id", id.ID),
+			zap.Int("members", id.MemberN),
+		)
+		if err = checkClusterIdentity(id, cfg.ExpectClusterID, cfg.ExpectMemberCount); err != nil {
+			if name != "" {
+				return fmt.Errorf("cluster %q: %v", name, err)
+			}
+			return err
+		}
+	}
+	return nil
+}
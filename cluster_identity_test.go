@@ -0,0 +1,70 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"testing"
+
+	"github.com/coreos/dbtester/dbtesterpb"
+
+	"go.uber.org/zap"
+)
+
+func TestZkEnsembleServerN(t *testing.T) {
+	config := "server.1=10.0.0.1:2888:3888:participant;0.0.0.0:2181\nserver.2=10.0.0.2:2888:3888:participant;0.0.0.0:2181\nserver.3=10.0.0.3:2888:3888:observer;0.0.0.0:2181\nversion=100000000"
+	if n := zkEnsembleServerN(config); n != 3 {
+		t.Fatalf("expected 3 servers, got %d", n)
+	}
+}
+
+func TestCheckClusterIdentity(t *testing.T) {
+	id := ClusterIdentity{ID: "cdf818194e3a8c32", MemberN: 3}
+	tests := []struct {
+		expectID string
+		expectN  int
+		ok       bool
+	}{
+		{"", 0, true},
+		{"cdf818194e3a8c32", 3, true},
+		{"0xCDF818194E3A8C32", 0, true},
+		{"ffff", 0, false},
+		{"", 5, false},
+	}
+	for i, tt := range tests {
+		if err := checkClusterIdentity(id, tt.expectID, tt.expectN); (err == nil) != tt.ok {
+			t.Fatalf("#%d: expected ok %v, got %v", i, tt.ok, err)
+		}
+	}
+	if err := checkClusterIdentity(ClusterIdentity{MemberN: 3}, "abc", 3); err == nil {
+		t.Fatal("expected error of unknown cluster ID")
+	}
+}
+
+func TestCheckClusterIdentityMock(t *testing.T) {
+	cfg := &Config{
+		lg: zap.NewNop(),
+		DatabaseIDToConfigClientMachineAgentControl: map[string]dbtesterpb.ConfigClientMachineAgentControl{"mock": {DatabaseID: "mock"}},
+		ExpectClusterID:   "mock",
+		ExpectMemberCount: 1,
+	}
+	if err := cfg.CheckClusterIdentity("mock"); err != nil {
+		t.Fatal(err)
+	}
+	cfg.ClusterEndpoints = map[string][]string{"a": nil, "b": nil}
+	cfg.ExpectMemberCount = 3
+	if err := cfg.CheckClusterIdentity("mock"); err == nil || err.Error() != `cluster "a": 1 members do not match expected 3` {
+		t.Fatalf("unexpected error %v", err)
+	}
+}
@@ -18,9 +18,20 @@
 //	dbtester [command]
 //
 //	Available Commands:
-//	agent       Database 'agent' in remote servers.
-//	analyze     Analyzes test dbtester test results.
-//	control     Controls tests.
+//	agent        Database 'agent' in remote servers.
+//	analyze      Analyzes test dbtester test results.
+//	bench        Runs workloads of multiple phases.
+//	bundle       Packs the results of a run into one tarball.
+//	capabilities Probes the features that the databases support.
+//	cas          Benchmarks the compare-and-swap contention on one key.
+//	collector    Aggregates interim results from many loaders.
+//	control      Controls tests.
+//	lease        Benchmarks the lease life cycles of ephemeral keys.
+//	matrix       Runs tests over all combinations of parameters.
+//	migration    Benchmarks the visibility of the keys across the etcd v2 and v3 APIs.
+//	plot         Plots the results of the runs.
+//	watch        Benchmarks the event delivery latency of watchers.
+//	worker       Generates load for a coordinating 'control'.
 //
 package main
 
@@ -30,7 +41,20 @@ import (
 
 	"github.com/coreos/dbtester/agent"
 	"github.com/coreos/dbtester/analyze"
+	"github.com/coreos/dbtester/bench"
+	"github.com/coreos/dbtester/bundle"
+	"github.com/coreos/dbtester/canary"
+	"github.com/coreos/dbtester/capabilities"
+	"github.com/coreos/dbtester/cas"
+	"github.com/coreos/dbtester/collector"
 	"github.com/coreos/dbtester/control"
+	"github.com/coreos/dbtester/lease"
+	"github.com/coreos/dbtester/matrix"
+	"github.com/coreos/dbtester/migration"
+	"github.com/coreos/dbtester/plot"
+	"github.com/coreos/dbtester/serve"
+	"github.com/coreos/dbtester/watch"
+	"github.com/coreos/dbtester/worker"
 	"github.com/spf13/cobra"
 )
 
@@ -49,7 +73,20 @@ func init() {
 func init() {
 	rootCommand.AddCommand(agent.Command)
 	rootCommand.AddCommand(analyze.Command)
+	rootCommand.AddCommand(bench.Command)
+	rootCommand.AddCommand(bundle.Command)
+	rootCommand.AddCommand(canary.Command)
+	rootCommand.AddCommand(capabilities.Command)
+	rootCommand.AddCommand(cas.Command)
+	rootCommand.AddCommand(collector.Command)
 	rootCommand.AddCommand(control.Command)
+	rootCommand.AddCommand(lease.Command)
+	rootCommand.AddCommand(matrix.Command)
+	rootCommand.AddCommand(migration.Command)
+	rootCommand.AddCommand(plot.Command)
+	rootCommand.AddCommand(serve.Command)
+	rootCommand.AddCommand(watch.Command)
+	rootCommand.AddCommand(worker.Command)
 }
 
 func main() {
@@ -0,0 +1,88 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/coreos/dbtester/dbtesterpb"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+)
+
+type flags struct {
+	grpcPort  string
+	httpPort  string
+	outputCSV string
+}
+
+var globalFlags flags
+
+func init() {
+	Command.PersistentFlags().StringVar(&globalFlags.grpcPort, "collector-port", ":3600", "Port to serve collector gRPC server for loaders.")
+	Command.PersistentFlags().StringVar(&globalFlags.httpPort, "http-port", ":3601", "Port to serve the dashboard ('/') and the combined results ('/results.csv').")
+	Command.PersistentFlags().StringVar(&globalFlags.outputCSV, "output-csv", "", "File path to save the combined results on exit. Empty to not save.")
+}
+
+// Command implements 'collector' command.
+var Command = &cobra.Command{
+	Use:   "collector",
+	Short: "Aggregates interim results from many loaders.",
+	RunE:  commandFunc,
+}
+
+func commandFunc(cmd *cobra.Command, args []string) error {
+	srv := newServer(lg)
+
+	ln, err := net.Listen("tcp", globalFlags.grpcPort)
+	if err != nil {
+		return err
+	}
+	grpcServer := grpc.NewServer()
+	dbtesterpb.RegisterCollectorServer(grpcServer, srv)
+
+	errc := make(chan error, 2)
+	go func() { errc <- grpcServer.Serve(ln) }()
+	go func() { errc <- http.ListenAndServe(globalFlags.httpPort, srv.httpHandler()) }()
+	lg.Info("collector started", zap.String("grpc-server-port", globalFlags.grpcPort), zap.String("http-server-port", globalFlags.httpPort))
+
+	notifier := make(chan os.Signal, 1)
+	signal.Notify(notifier, syscall.SIGINT, syscall.SIGTERM)
+	select {
+	case sig := <-notifier:
+		lg.Info("received signal", zap.String("signal", sig.String()))
+	case err = <-errc:
+	}
+	grpcServer.Stop()
+
+	if globalFlags.outputCSV != "" {
+		f, ferr := os.Create(globalFlags.outputCSV)
+		if ferr != nil {
+			return ferr
+		}
+		defer f.Close()
+		if ferr = srv.writeCSV(f); ferr != nil {
+			return ferr
+		}
+		lg.Info("saved combined results", zap.String("path", globalFlags.outputCSV))
+	}
+	return err
+}
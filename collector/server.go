@@ -0,0 +1,209 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/coreos/dbtester/dbtesterpb"
+
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+)
+
+// dashboardSeconds is the number of latest seconds to show on the dashboard.
+const dashboardSeconds = 10
+
+// loaderStatus is the totals of one loader.
+type loaderStatus struct {
+	databaseID  string
+	databaseTag string
+	requests    int64
+	errors      int64
+	lastSecond  int64
+	lastReport  time.Time
+	done        bool
+}
+
+// secondResult is the combined results of all loaders in one second.
+type secondResult struct {
+	unixSecond   int64
+	requests     int64
+	errors       int64
+	minLatency   int64
+	maxLatency   int64
+	totalLatency int64
+	loaders      map[string]struct{}
+}
+
+func (r *secondResult) avgLatency() int64 {
+	if r.requests == 0 {
+		return 0
+	}
+	return r.totalLatency / r.requests
+}
+
+type collectorServer struct {
+	lg *zap.Logger
+
+	mu      sync.Mutex
+	loaders map[string]*loaderStatus
+	seconds map[int64]*secondResult
+}
+
+func newServer(lg *zap.Logger) *collectorServer {
+	return &collectorServer{
+		lg:      lg,
+		loaders: make(map[string]*loaderStatus),
+		seconds: make(map[int64]*secondResult),
+	}
+}
+
+// Report aggregates the interim results of a loader.
+func (s *collectorServer) Report(ctx context.Context, rs *dbtesterpb.InterimResults) (*dbtesterpb.CollectorResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ld, ok := s.loaders[rs.LoaderID]
+	if !ok {
+		ld = &loaderStatus{databaseID: rs.DatabaseID, databaseTag: rs.DatabaseTag}
+		s.loaders[rs.LoaderID] = ld
+		s.lg.Info("new loader", zap.String("loader", rs.LoaderID), zap.String("database", rs.DatabaseID))
+	}
+	ld.lastReport = time.Now()
+	ld.done = rs.Done
+
+	for _, r := range rs.Results {
+		ld.requests += r.Requests
+		ld.errors += r.Errors
+		if r.UnixSecond > ld.lastSecond {
+			ld.lastSecond = r.UnixSecond
+		}
+
+		sr, ok := s.seconds[r.UnixSecond]
+		if !ok {
+			sr = &secondResult{unixSecond: r.UnixSecond, minLatency: r.MinLatencyMicroseconds, loaders: make(map[string]struct{})}
+			s.seconds[r.UnixSecond] = sr
+		}
+		sr.requests += r.Requests
+		sr.errors += r.Errors
+		sr.totalLatency += r.TotalLatencyMicroseconds
+		if r.MinLatencyMicroseconds < sr.minLatency {
+			sr.minLatency = r.MinLatencyMicroseconds
+		}
+		if r.MaxLatencyMicroseconds > sr.maxLatency {
+			sr.maxLatency = r.MaxLatencyMicroseconds
+		}
+		sr.loaders[rs.LoaderID] = struct{}{}
+	}
+	if rs.Done {
+		s.lg.Info("loader finished", zap.String("loader", rs.LoaderID), zap.Int64("requests", ld.requests), zap.Int64("errors", ld.errors))
+	}
+	return &dbtesterpb.CollectorResponse{Success: true}, nil
+}
+
+// sortedSeconds returns the combined results in time order.
+// It must be called with 's.mu' held.
+func (s *collectorServer) sortedSeconds() []*secondResult {
+	rs := make([]*secondResult, 0, len(s.seconds))
+	for _, r := range s.seconds {
+		rs = append(rs, r)
+	}
+	sort.Slice(rs, func(i, j int) bool { return rs[i].unixSecond < rs[j].unixSecond })
+	return rs
+}
+
+// writeCSV writes the combined results of all loaders by the second.
+func (s *collectorServer) writeCSV(w io.Writer) error {
+	s.mu.Lock()
+	rs := s.sortedSeconds()
+	rows := [][]string{{"UNIX-SECOND", "LOADER-NUM", "REQUESTS", "ERRORS", "MIN-LATENCY-MS", "AVG-LATENCY-MS", "MAX-LATENCY-MS"}}
+	for _, r := range rs {
+		rows = append(rows, []string{
+			fmt.Sprintf("%d", r.unixSecond),
+			fmt.Sprintf("%d", len(r.loaders)),
+			fmt.Sprintf("%d", r.requests),
+			fmt.Sprintf("%d", r.errors),
+			fmt.Sprintf("%f", float64(r.minLatency)/1000),
+			fmt.Sprintf("%f", float64(r.avgLatency())/1000),
+			fmt.Sprintf("%f", float64(r.maxLatency)/1000),
+		})
+	}
+	s.mu.Unlock()
+
+	cw := csv.NewWriter(w)
+	if err := cw.WriteAll(rows); err != nil {
+		return err
+	}
+	return cw.Error()
+}
+
+// writeDashboard writes the status of the loaders,
+// and the combined results of the latest seconds.
+func (s *collectorServer) writeDashboard(w io.Writer) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "LOADER\tDATABASE\tTAG\tREQUESTS\tERRORS\tLAST-SECOND\tLAST-REPORT\tDONE")
+	ids := make([]string, 0, len(s.loaders))
+	for id := range s.loaders {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	var requests, errors int64
+	for _, id := range ids {
+		ld := s.loaders[id]
+		requests += ld.requests
+		errors += ld.errors
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%d\t%d\t%s ago\t%v\n",
+			id, ld.databaseID, ld.databaseTag, ld.requests, ld.errors, ld.lastSecond, time.Since(ld.lastReport).Truncate(time.Second), ld.done)
+	}
+	fmt.Fprintf(tw, "TOTAL\t\t\t%d\t%d\t\t\t\n\n", requests, errors)
+
+	fmt.Fprintln(tw, "UNIX-SECOND\tLOADER-NUM\tREQUESTS\tERRORS\tMIN-LATENCY-MS\tAVG-LATENCY-MS\tMAX-LATENCY-MS")
+	rs := s.sortedSeconds()
+	if len(rs) > dashboardSeconds {
+		rs = rs[len(rs)-dashboardSeconds:]
+	}
+	for _, r := range rs {
+		fmt.Fprintf(tw, "%d\t%d\t%d\t%d\t%.4f\t%.4f\t%.4f\n",
+			r.unixSecond, len(r.loaders), r.requests, r.errors,
+			float64(r.minLatency)/1000, float64(r.avgLatency())/1000, float64(r.maxLatency)/1000)
+	}
+	tw.Flush()
+}
+
+func (s *collectorServer) httpHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		s.writeDashboard(w)
+	})
+	mux.HandleFunc("/results.csv", func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "text/csv")
+		if err := s.writeCSV(w); err != nil {
+			s.lg.Warn("failed to write results", zap.Error(err))
+		}
+	})
+	return mux
+}
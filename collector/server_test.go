@@ -0,0 +1,58 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/coreos/dbtester/dbtesterpb"
+
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+)
+
+func TestServerReport(t *testing.T) {
+	srv := newServer(zap.NewNop())
+	for _, rs := range []*dbtesterpb.InterimResults{
+		{LoaderID: "a", Results: []*dbtesterpb.InterimResult{
+			{UnixSecond: 1, Requests: 2, MinLatencyMicroseconds: 1000, MaxLatencyMicroseconds: 3000, TotalLatencyMicroseconds: 4000},
+		}},
+		{LoaderID: "b", Done: true, Results: []*dbtesterpb.InterimResult{
+			{UnixSecond: 1, Requests: 2, Errors: 1, MinLatencyMicroseconds: 500, MaxLatencyMicroseconds: 2000, TotalLatencyMicroseconds: 4000},
+			{UnixSecond: 2, Requests: 1, MinLatencyMicroseconds: 100, MaxLatencyMicroseconds: 100, TotalLatencyMicroseconds: 100},
+		}},
+	} {
+		if _, err := srv.Report(context.Background(), rs); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := srv.writeCSV(&buf); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected header and 2 seconds, got %q", lines)
+	}
+	if exp := "1,2,4,1,0.500000,2.000000,3.000000"; lines[1] != exp {
+		t.Fatalf("expected %q, got %q", exp, lines[1])
+	}
+	if !srv.loaders["b"].done || srv.loaders["b"].requests != 3 {
+		t.Fatalf("unexpected loader status %+v", srv.loaders["b"])
+	}
+}
@@ -0,0 +1,218 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/coreos/dbtester/dbtesterpb"
+	"github.com/coreos/dbtester/pkg/hdrhistogram"
+
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+)
+
+// collectorInterval is the interval to report interim results.
+const collectorInterval = time.Second
+
+// collectorStream reports the results of the finished seconds to the
+// 'collector' every second, while the benchmark runs. Reports that fail
+// are dropped, so that the collector never slows down the benchmark.
+// The results are also written in OpenMetrics text format, if enabled.
+type collectorStream struct {
+	lg *zap.Logger
+	// cli is nil if there is no collector to report to,
+	// and conn is nil unless dialed to 'collector_endpoint'
+	conn *grpc.ClientConn
+	cli  dbtesterpb.CollectorClient
+	// om is nil if 'client_openmetrics_dir' is not set
+	om *openMetricsWriter
+
+	loaderID    string
+	databaseID  string
+	databaseTag string
+
+	mu   sync.Mutex
+	secs map[int64]*dbtesterpb.InterimResult
+	// lats are the latencies of the successful requests since the
+	// last report, to merge the percentiles; nil if 'cli' is nil
+	lats    *hdrhistogram.Histogram
+	sigfigs int
+
+	stopc chan struct{}
+	donec chan struct{}
+}
+
+// newCollectorStream returns the stream to the collector of 'endpoint',
+// or to 'cli' if not nil, and to the OpenMetrics files in 'openMetricsDir',
+// with 'openMetricsExt' appended to compress them. Either may be empty.
+// The latencies are reported at the significant digits of 'sigfigs'.
+func newCollectorStream(lg *zap.Logger, gcfg dbtesterpb.ConfigClientMachineAgentControl, cli dbtesterpb.CollectorClient, endpoint, openMetricsDir, openMetricsExt string, sigfigs int) (*collectorStream, error) {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown"
+	}
+	s := &collectorStream{
+		lg:          lg,
+		loaderID:    fmt.Sprintf("%s-%d-%s", host, os.Getpid(), gcfg.DatabaseID),
+		databaseID:  gcfg.DatabaseID,
+		databaseTag: gcfg.DatabaseTag,
+		secs:        make(map[int64]*dbtesterpb.InterimResult),
+		stopc:       make(chan struct{}),
+		donec:       make(chan struct{}),
+		cli:         cli,
+	}
+	if cli == nil && endpoint != "" {
+		if s.conn, err = grpc.Dial(endpoint, grpc.WithInsecure()); err != nil {
+			return nil, err
+		}
+		s.cli = dbtesterpb.NewCollectorClient(s.conn)
+	}
+	if s.cli != nil {
+		s.sigfigs = mustLatencyHistogram(sigfigs).sigfigs
+		s.lats = s.newLatencies()
+	}
+	if openMetricsDir != "" {
+		if s.om, err = newOpenMetricsWriter(openMetricsDir, openMetricsExt, s.loaderID, s.databaseID, s.databaseTag); err != nil {
+			if s.conn != nil {
+				s.conn.Close()
+			}
+			return nil, err
+		}
+	}
+	go s.run()
+	return s, nil
+}
+
+// record adds the result of a request to the second it finished in.
+func (s *collectorStream) record(end time.Time, took time.Duration, err error) {
+	sec, us := end.Unix(), int64(took/time.Microsecond)
+
+	s.mu.Lock()
+	r, ok := s.secs[sec]
+	if !ok {
+		r = &dbtesterpb.InterimResult{UnixSecond: sec, MinLatencyMicroseconds: us}
+		s.secs[sec] = r
+	}
+	r.Requests++
+	if err != nil {
+		r.Errors++
+	}
+	if us < r.MinLatencyMicroseconds {
+		r.MinLatencyMicroseconds = us
+	}
+	if us > r.MaxLatencyMicroseconds {
+		r.MaxLatencyMicroseconds = us
+	}
+	r.TotalLatencyMicroseconds += us
+	if s.lats != nil && err == nil {
+		s.lats.Record(us)
+	}
+	s.mu.Unlock()
+}
+
+func (s *collectorStream) newLatencies() *hdrhistogram.Histogram {
+	h, err := hdrhistogram.New(int64(latencyHighest/time.Microsecond), s.sigfigs)
+	if err != nil {
+		// of the significant digits checked by 'newCollectorStream'
+		panic(err)
+	}
+	return h
+}
+
+// latencies removes and returns the encoded latencies since the last call.
+func (s *collectorStream) latencies() []byte {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.lats == nil || s.lats.TotalCount() == 0 {
+		return nil
+	}
+	bts := s.lats.Encode()
+	s.lats = s.newLatencies()
+	return bts
+}
+
+// finished removes and returns the results of the seconds before 'sec'.
+func (s *collectorStream) finished(sec int64) []*dbtesterpb.InterimResult {
+	s.mu.Lock()
+	var rs []*dbtesterpb.InterimResult
+	for k, r := range s.secs {
+		if k < sec {
+			rs = append(rs, r)
+			delete(s.secs, k)
+		}
+	}
+	s.mu.Unlock()
+	sort.Slice(rs, func(i, j int) bool { return rs[i].UnixSecond < rs[j].UnixSecond })
+	return rs
+}
+
+func (s *collectorStream) send(rs []*dbtesterpb.InterimResult, done bool) {
+	if len(rs) == 0 && !done {
+		return
+	}
+	if s.om != nil && len(rs) > 0 {
+		if err := s.om.write(rs); err != nil {
+			s.lg.Warn("failed to write OpenMetrics; dropped", zap.Int("seconds", len(rs)), zap.Error(err))
+		}
+	}
+	if s.cli == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), collectorInterval)
+	_, err := s.cli.Report(ctx, &dbtesterpb.InterimResults{
+		LoaderID:    s.loaderID,
+		DatabaseID:  s.databaseID,
+		DatabaseTag: s.databaseTag,
+		Results:     rs,
+		Done:        done,
+		// of the requests until now, including of the current second
+		LatencyHistogram: s.latencies(),
+	})
+	cancel()
+	if err != nil {
+		s.lg.Warn("failed to report interim results to collector; dropped", zap.Int("seconds", len(rs)), zap.Error(err))
+	}
+}
+
+func (s *collectorStream) run() {
+	defer close(s.donec)
+	ticker := time.NewTicker(collectorInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case now := <-ticker.C:
+			s.send(s.finished(now.Unix()), false)
+		case <-s.stopc:
+			return
+		}
+	}
+}
+
+// close reports all remaining results as the last batch.
+func (s *collectorStream) close() {
+	close(s.stopc)
+	<-s.donec
+	s.send(s.finished(math.MaxInt64), true)
+	if s.conn != nil {
+		s.conn.Close()
+	}
+}
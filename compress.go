@@ -0,0 +1,234 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"compress/gzip"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/gyuho/dataframe"
+)
+
+// Compressions are the compressions of the result files of 'control --compress'.
+// 'zstd' streams through the 'zstd' binary, which must be in $PATH.
+var Compressions = []string{"none", "gzip", "zstd"}
+
+// compressExts are the extensions of the compressed files, by compression.
+var compressExts = map[string]string{"gzip": ".gz", "zstd": ".zst"}
+
+// compressedExt returns the extension of the compression of the file,
+// empty if not compressed.
+func compressedExt(fpath string) string {
+	for _, ext := range compressExts {
+		if strings.HasSuffix(fpath, ext) {
+			return ext
+		}
+	}
+	return ""
+}
+
+// compressedPaths are the paths of the result files that the loader
+// writes, to compress with 'Compress'.
+func (cfg *Config) compressedPaths() []*string {
+	ci := &cfg.ConfigClientMachineInitial
+	ps := []*string{
+		&ci.ServerDiskSpaceUsageSummaryPath,
+		&ci.ClientBootstrapTimePath,
+		&ci.ClientFailoverPath,
+	}
+	for _, p := range cfg.clusterResultPaths() {
+		// a directory of files, each compressed
+		if p != &ci.ClientOpenMetricsDir {
+			ps = append(ps, p)
+		}
+	}
+	return ps
+}
+
+// SetCompress sets 'Compress', and appends the extension of the compression
+// to the paths of the result files, so that they are saved and uploaded
+// compressed. The result files that are read back, such as '--save-keys',
+// are decompressed by their extensions.
+func (cfg *Config) SetCompress(c string) error {
+	if c == "" || c == "none" {
+		cfg.Compress = ""
+		return nil
+	}
+	ext, ok := compressExts[c]
+	if !ok {
+		return fmt.Errorf("unknown compression %q (expected %s)", c, strings.Join(Compressions, ", "))
+	}
+	if c == "zstd" {
+		if _, err := exec.LookPath("zstd"); err != nil {
+			return fmt.Errorf("'zstd' binary is required to compress with zstd (%v)", err)
+		}
+	}
+	cfg.Compress = c
+	for _, p := range cfg.compressedPaths() {
+		if *p != "" && compressedExt(*p) == "" {
+			*p += ext
+		}
+	}
+	return nil
+}
+
+// gzipFile closes the file after the gzip stream.
+type gzipFile struct {
+	*gzip.Writer
+	f *os.File
+}
+
+func (g *gzipFile) Close() error {
+	if err := g.Writer.Close(); err != nil {
+		g.f.Close()
+		return err
+	}
+	return g.f.Close()
+}
+
+// zstdFile streams through the 'zstd' process writing to the file.
+type zstdFile struct {
+	io.WriteCloser
+	cmd *exec.Cmd
+	f   *os.File
+}
+
+func (z *zstdFile) Close() error {
+	err := z.WriteCloser.Close()
+	if werr := z.cmd.Wait(); err == nil {
+		err = werr
+	}
+	if cerr := z.f.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// createFile creates or truncates the file, compressed with the
+// compression of its extension.
+func createFile(fpath string) (io.WriteCloser, error) {
+	f, err := os.OpenFile(fpath, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return nil, err
+	}
+	switch compressedExt(fpath) {
+	case compressExts["gzip"]:
+		return &gzipFile{Writer: gzip.NewWriter(f), f: f}, nil
+	case compressExts["zstd"]:
+		cmd := exec.Command("zstd", "-q", "-c")
+		cmd.Stdout = f
+		w, err := cmd.StdinPipe()
+		if err == nil {
+			err = cmd.Start()
+		}
+		if err != nil {
+			f.Close()
+			return nil, err
+		}
+		return &zstdFile{WriteCloser: w, cmd: cmd, f: f}, nil
+	}
+	return f, nil
+}
+
+// gunzipFile closes the file after the gzip stream.
+type gunzipFile struct {
+	*gzip.Reader
+	f *os.File
+}
+
+func (g *gunzipFile) Close() error {
+	g.Reader.Close()
+	return g.f.Close()
+}
+
+// unzstdFile streams from the 'zstd' process reading the file.
+type unzstdFile struct {
+	io.ReadCloser
+	cmd *exec.Cmd
+	f   *os.File
+}
+
+func (z *unzstdFile) Close() error {
+	z.ReadCloser.Close()
+	// zstd exits on the closed pipe if closed before the end
+	z.cmd.Wait()
+	return z.f.Close()
+}
+
+// openFile opens the file, decompressed with the compression of its extension.
+func openFile(fpath string) (io.ReadCloser, error) {
+	f, err := os.Open(fpath)
+	if err != nil {
+		return nil, err
+	}
+	switch compressedExt(fpath) {
+	case compressExts["gzip"]:
+		r, err := gzip.NewReader(f)
+		if err != nil {
+			f.Close()
+			return nil, err
+		}
+		return &gunzipFile{Reader: r, f: f}, nil
+	case compressExts["zstd"]:
+		cmd := exec.Command("zstd", "-q", "-d", "-c")
+		cmd.Stdin = f
+		r, err := cmd.StdoutPipe()
+		if err == nil {
+			err = cmd.Start()
+		}
+		if err != nil {
+			f.Close()
+			return nil, err
+		}
+		return &unzstdFile{ReadCloser: r, cmd: cmd, f: f}, nil
+	}
+	return f, nil
+}
+
+// saveCSV writes the frame as 'dataframe.Frame.CSV' does, compressed
+// with the compression of the extension.
+func saveCSV(fr dataframe.Frame, fpath string) error {
+	headers, rows := fr.Rows()
+	return WriteCSV(fpath, append([][]string{headers}, rows...))
+}
+
+// saveCSVHorizontal writes the frame as 'dataframe.Frame.CSVHorizontal'
+// does, a column per row, compressed with the compression of the extension.
+func saveCSVHorizontal(fr dataframe.Frame, fpath string) error {
+	var rows [][]string
+	for _, col := range fr.Columns() {
+		rows = append(rows, append([]string{col.Header()}, col.Rows()...))
+	}
+	return WriteCSV(fpath, rows)
+}
+
+// WriteCSV writes the rows to the file, compressed with the
+// compression of the extension.
+func WriteCSV(fpath string, rows [][]string) error {
+	f, err := createFile(fpath)
+	if err != nil {
+		return err
+	}
+	if err = csv.NewWriter(f).WriteAll(rows); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,102 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCompressedFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "compress")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	data := bytes.Repeat([]byte("1000,0.1234,0.5678\n"), 10000)
+	for _, ext := range []string{"", ".gz", ".zst"} {
+		if ext == ".zst" {
+			if _, err = exec.LookPath("zstd"); err != nil {
+				t.Log("skipping zstd; 'zstd' is not in $PATH")
+				continue
+			}
+		}
+		fpath := filepath.Join(dir, "results.csv"+ext)
+		w, err := createFile(fpath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// streamed in chunks
+		for i := 0; i < len(data); i += 4096 {
+			end := i + 4096
+			if end > len(data) {
+				end = len(data)
+			}
+			if _, err = w.Write(data[i:end]); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err = w.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		st, err := os.Stat(fpath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ext != "" && st.Size() >= int64(len(data))/10 {
+			t.Fatalf("%q: expected compressed, got %d bytes of %d", ext, st.Size(), len(data))
+		}
+		r, err := openFile(fpath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := ioutil.ReadAll(r)
+		r.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, data) {
+			t.Fatalf("%q: expected %d bytes, got %d", ext, len(data), len(got))
+		}
+	}
+}
+
+func TestSetCompress(t *testing.T) {
+	cfg := &Config{SaveKeysPath: "/tmp/keys.txt"}
+	cfg.ClientLatencyDistributionSummaryPath = "/tmp/summary.csv"
+	cfg.ClientFailoverPath = "/tmp/failover.csv.gz"
+	cfg.ClientOpenMetricsDir = "/tmp/openmetrics"
+
+	if err := cfg.SetCompress("lz4"); err == nil || !strings.Contains(err.Error(), "unknown compression") {
+		t.Fatalf("expected unknown compression, got %v", err)
+	}
+	if err := cfg.SetCompress("gzip"); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Compress != "gzip" || cfg.ClientLatencyDistributionSummaryPath != "/tmp/summary.csv.gz" || cfg.SaveKeysPath != "/tmp/keys.txt.gz" {
+		t.Fatalf("unexpected compression %q, paths %q, %q", cfg.Compress, cfg.ClientLatencyDistributionSummaryPath, cfg.SaveKeysPath)
+	}
+	// already compressed, unset, or a directory
+	if cfg.ClientFailoverPath != "/tmp/failover.csv.gz" || cfg.ClientChaosPath != "" || cfg.ClientOpenMetricsDir != "/tmp/openmetrics" {
+		t.Fatalf("unexpected paths %q, %q, %q", cfg.ClientFailoverPath, cfg.ClientChaosPath, cfg.ClientOpenMetricsDir)
+	}
+}
@@ -17,12 +17,16 @@ package dbtester
 import (
 	"fmt"
 	"io/ioutil"
+	"net"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/coreos/dbtester/dbtesterpb"
 
 	"go.uber.org/zap"
+	"golang.org/x/net/context"
 	"gopkg.in/yaml.v2"
 )
 
@@ -40,6 +44,98 @@ func MakeTag(desc string) string {
 type Config struct {
 	lg *zap.Logger
 
+	// harnessOverhead is measured before stress tests,
+	// if 'calibrate_harness' is enabled.
+	harnessOverhead *harnessOverhead
+
+	// emptyResponses is the number of reads that
+	// returned empty response in the last report.
+	emptyResponses int64
+	// warmupDiscarded is the number of requests whose results
+	// were discarded in the warmup of the last report.
+	warmupDiscarded int64
+
+	// etcdRBACRootLatency is the average latency of the baseline
+	// run as root, if 'etcd_rbac' is 'restricted'.
+	etcdRBACRootLatency time.Duration
+	// authBaselineLatency is the average latency of the baseline
+	// run without credentials, if 'AuthOverhead' is set.
+	authBaselineLatency time.Duration
+
+	// hedgeStats is set if 'hedge_after_microseconds' is set.
+	hedgeStats *hedgeStats
+
+	// endpointRouter is set if 'health_routing' is set.
+	endpointRouter *endpointRouter
+	// txnStats is set if 'type' is 'txn'.
+	txnStats *txnStats
+	// lockStats is set if 'type' is 'lock'.
+	lockStats *lockStats
+	// bankStats is set if 'type' is 'bank'.
+	bankStats *bankStats
+	// live is set while 'ServeMetrics' serves the live metrics.
+	live *liveMetrics
+	// timeseries is set while 'StreamTimeseries' writes the time series.
+	timeseries *timeseriesStream
+	// loaderCPUQuota is the CPU quota written to the cgroup
+	// of the loader by 'LimitLoaderCPUs', if any.
+	loaderCPUQuota string
+	// readWriteStats is set if 'type' is 'read-write'.
+	readWriteStats *readWriteStats
+	// bootstrapTimes are the measured times to the first
+	// linearizable read after the start or restart of the cluster.
+	bootstrapTimes []bootstrapTime
+	// failoverTrials are the measured client failovers, if 'failover' is set.
+	failoverTrials []failoverTrial
+	// rollingRestart is set if 'rolling_restart' is set.
+	rollingRestart *rollingRestart
+	// collector is set if 'collector_endpoint' is set, or on a worker.
+	collector *collectorStream
+	// collectorClient streams the interim results back to the
+	// coordinator, instead of to 'collector_endpoint', on a worker.
+	collectorClient dbtesterpb.CollectorClient
+	// keyOffset is the index of the first key that 'write'
+	// benchmarks write, on a worker.
+	keyOffset int64
+	// convergenceProbe is set if 'convergence_probe' is set.
+	convergenceProbe *convergenceProbe
+	// chaos is set if 'chaos' is set.
+	chaos *chaos
+	// etcdMetrics is set if 'etcd_metrics' is set.
+	etcdMetrics *etcdMetricsScraper
+	// resources is set if 'resource_monitor' is set.
+	resources *resourceMonitor
+	// sockets is set if 'client_socket_stats_path' is set.
+	sockets *socketStats
+	// maintenance is set if 'auto_compact_seconds' is set.
+	maintenance *maintenance
+	// identityLeases is set if 'identity_lease' is set.
+	identityLeases *identityLeases
+	// learnerReads is set if 'learner_reads' is set.
+	learnerReads *learnerReads
+	// tlsHandshakes is set if 'tls_handshake' is set.
+	tlsHandshakes *tlsHandshakes
+	// sizes is set if 'client_size_histogram_path' is set.
+	sizes *sizeHistogram
+	// responses tracks the response sizes and the loader heap of every run.
+	responses *responseMemory
+	// spikes is set if 'spike_recovery' is set.
+	spikes *spikeRecovery
+	// members is set if 'client_member_breakdown_path'
+	// or 'client_term_change_path' is set.
+	members *memberBreakdown
+	// zones is set if 'client_zone_latency_path' and 'EndpointZones' are set.
+	zones *zoneBreakdown
+	// latencies records the latencies of every run.
+	latencies *latencyHistogram
+	// keys is set if '--save-keys' is set.
+	keys *keyManifest
+	// keysFrom are the keys loaded from '--keys-from'.
+	keysFrom []string
+	// schedule is set if both 'rate_limit_requests_per_second'
+	// and 'request_timeout_milliseconds' are set.
+	schedule *scheduleTracker
+
 	TestTitle       string `yaml:"test_title"`
 	TestDescription string `yaml:"test_description"`
 
@@ -53,6 +149,149 @@ type Config struct {
 	AnalyzePlotPathPrefix                              string                                `yaml:"analyze_plot_path_prefix"`
 	AnalyzePlotList                                    []dbtesterpb.ConfigAnalyzeMachinePlot `yaml:"analyze_plot_list"`
 	dbtesterpb.ConfigAnalyzeMachineREADME              `yaml:"analyze_readme"`
+
+	// Cooldown is the settle period between stages, during which
+	// no load is sent while server metrics are still being collected.
+	// It is set by 'control --cooldown' flag, not by the configuration file.
+	Cooldown time.Duration `yaml:"-"`
+
+	// Force runs the tests even when the configured key or value sizes
+	// exceed the request size limits of the database.
+	// It is set by 'control --force' flag, not by the configuration file.
+	Force bool `yaml:"-"`
+
+	// ProfileDir is the directory to save the CPU and heap profiles of
+	// the loader, with their folded stacks for flamegraphs. Empty to not profile.
+	// It is set by 'control --profile-dir' flag, not by the configuration file.
+	ProfileDir string `yaml:"-"`
+	// ProfilePoints are the points to capture the heap profiles at.
+	// It is set by 'control --profile-points' flag, not by the configuration file.
+	ProfilePoints []string `yaml:"-"`
+
+	// SaveKeysPath is the file to save the keys of the successful writes
+	// of 'write' benchmarks and 'prepopulate', for later runs to read.
+	// It is set by 'control --save-keys' flag, not by the configuration file.
+	SaveKeysPath string `yaml:"-"`
+	// KeysFromPath is the file of the keys to read or delete, as saved by
+	// 'SaveKeysPath', instead of the prepopulated keys.
+	// It is set by 'control --keys-from' flag, not by the configuration file.
+	KeysFromPath string `yaml:"-"`
+
+	// KeysPerRequest is the number of keys that each request of 'read'
+	// benchmarks reads, from the prepopulated keys or '--keys-from'.
+	// It is set by 'control --keys-per-request' flag, not by the configuration file.
+	KeysPerRequest int64 `yaml:"-"`
+
+	// RangeReads is true to read the keys of each request of 'read'
+	// benchmarks as the range of their prefix, of 'KeysPerRequest' keys.
+	// It is set by 'control --range-reads' flag, not by the configuration file.
+	RangeReads bool `yaml:"-"`
+
+	// ClusterEndpoints are the endpoints of the clusters, by name, to stress
+	// at the same time instead of 'database_endpoints', with results saved
+	// by cluster. It is set by 'control --cluster-a' and '--cluster-b' flags,
+	// not by the configuration file.
+	ClusterEndpoints map[string][]string `yaml:"-"`
+
+	// Workers are the endpoints of the 'worker' processes to fan the
+	// stress out to, with results merged from all workers. It is set
+	// by 'control --workers' flag, not by the configuration file.
+	Workers []string `yaml:"-"`
+
+	// OutputFormat is the format of the results of the stress: 'text',
+	// 'json' or 'csv'. It is set by 'control --output-format' flag,
+	// not by the configuration file.
+	OutputFormat string `yaml:"-"`
+	// OutputFile is the file to write the results to, instead of stdout.
+	// It is set by 'control --output-file' flag, not by the configuration file.
+	OutputFile string `yaml:"-"`
+
+	// LatencyResolution is the significant digits, in [1, 5], of the
+	// latencies of the reported summary and percentiles, and of
+	// 'client_latency_hgrm_path'. 0 to use
+	// 'DefaultLatencyResolution'. It is set by 'control --latency-resolution'
+	// flag, not by the configuration file.
+	LatencyResolution int `yaml:"-"`
+
+	// Compress is the compression of the result files, 'gzip' or 'zstd',
+	// empty to not compress. It is set by 'control --compress' flag through
+	// 'SetCompress', not by the configuration file.
+	Compress string `yaml:"-"`
+
+	// ExpectClusterID is the etcd cluster ID in hex, or the Consul datacenter,
+	// that the endpoints must reach before the stress. Empty to not check.
+	// It is set by 'control --expect-cluster-id' flag, not by the configuration file.
+	ExpectClusterID string `yaml:"-"`
+	// ExpectMemberCount is the number of the members that the cluster must
+	// have before the stress. 0 to not check. It is set by
+	// 'control --expect-member-count' flag, not by the configuration file.
+	ExpectMemberCount int `yaml:"-"`
+
+	// AddressFamily is the address family, "ipv4" or "ipv6", that the
+	// endpoints must be reachable on before the stress. Empty for either.
+	// It is set by 'control --address-family' flag, not by the configuration file.
+	AddressFamily string `yaml:"-"`
+
+	// EndpointZones maps the endpoints, or their hosts, to the zones
+	// of the servers, to save the latencies of each server zone to
+	// 'client_zone_latency_path'. It is set by 'control --endpoint-zones'
+	// flag, not by the configuration file.
+	EndpointZones map[string]string `yaml:"-"`
+	// LoaderZone is the zone of the loader. It is set by
+	// 'control --loader-zone' flag, not by the configuration file.
+	LoaderZone string `yaml:"-"`
+
+	// MaxResponseBytes is the limit of the size of each response, over which
+	// the request fails instead of growing the loader heap, such as of
+	// ranges over the whole key space. 0 for no limit. It is set by
+	// 'control --max-response-bytes' flag, not by the configuration file.
+	MaxResponseBytes int64 `yaml:"-"`
+
+	// AuthOverhead is true to run a baseline without credentials before
+	// the stress, to measure the overhead of authentication. It is set by
+	// 'control --auth-overhead' flag, not by the configuration file.
+	AuthOverhead bool `yaml:"-"`
+
+	// DatabaseVersion is the release of the database that the agents
+	// install before starting it, instead of the binaries of their flags.
+	// It is set by 'control launch --database-version' flag, not by the
+	// configuration file.
+	DatabaseVersion string `yaml:"-"`
+
+	// MaxLoaderCPUs is the number of CPUs that the loader is capped to,
+	// recorded in the summary. 0 if not capped. It is set by
+	// 'control --max-loader-cpus' flag through 'LimitLoaderCPUs',
+	// not by the configuration file.
+	MaxLoaderCPUs int `yaml:"-"`
+
+	// ProgressInterval is the interval to print the progress of the stress.
+	// 0 to not print. It is set by 'control --progress-interval' flag,
+	// not by the configuration file.
+	ProgressInterval time.Duration `yaml:"-"`
+
+	// OnProgress is called with the step of 'control' being run, if not nil.
+	OnProgress func(step string) `yaml:"-"`
+
+	// Context is of the run, to cancel the stress with its requests in
+	// flight when done, such as on SIGINT. nil to run to the end.
+	// It is set by 'control', not by the configuration file.
+	Context context.Context `yaml:"-"`
+}
+
+// Progress reports the step of 'control' being run.
+func (cfg *Config) Progress(step string) {
+	if cfg.OnProgress != nil {
+		cfg.OnProgress(step)
+	}
+}
+
+// runContext returns the context of the run, to derive the contexts
+// of the requests from.
+func (cfg *Config) runContext() context.Context {
+	if cfg.Context == nil {
+		return context.Background()
+	}
+	return cfg.Context
 }
 
 // ReadConfig reads control configuration file.
@@ -71,6 +310,7 @@ func ReadConfig(fpath string, analyze bool) (*Config, error) {
 		return nil, lerr
 	}
 	cfg.lg = lg
+	cfg.ProgressInterval = DefaultProgressInterval
 
 	for _, id := range cfg.AllDatabaseIDList {
 		if !dbtesterpb.IsValidDatabaseID(id) {
@@ -88,6 +328,81 @@ func ReadConfig(fpath string, analyze bool) (*Config, error) {
 		cfg.ConfigClientMachineInitial.ClientLatencyDistributionSummaryPath = filepath.Join(cfg.ConfigClientMachineInitial.PathPrefix, cfg.ConfigClientMachineInitial.ClientLatencyDistributionSummaryPath)
 		cfg.ConfigClientMachineInitial.ClientLatencyByKeyNumberPath = filepath.Join(cfg.ConfigClientMachineInitial.PathPrefix, cfg.ConfigClientMachineInitial.ClientLatencyByKeyNumberPath)
 		cfg.ConfigClientMachineInitial.ServerDiskSpaceUsageSummaryPath = filepath.Join(cfg.ConfigClientMachineInitial.PathPrefix, cfg.ConfigClientMachineInitial.ServerDiskSpaceUsageSummaryPath)
+		if cfg.ConfigClientMachineInitial.ClientRequestTraceSamplePath != "" {
+			cfg.ConfigClientMachineInitial.ClientRequestTraceSamplePath = filepath.Join(cfg.ConfigClientMachineInitial.PathPrefix, cfg.ConfigClientMachineInitial.ClientRequestTraceSamplePath)
+		}
+		if cfg.ConfigClientMachineInitial.ClientEndpointTrafficPath != "" {
+			cfg.ConfigClientMachineInitial.ClientEndpointTrafficPath = filepath.Join(cfg.ConfigClientMachineInitial.PathPrefix, cfg.ConfigClientMachineInitial.ClientEndpointTrafficPath)
+		}
+		if cfg.ConfigClientMachineInitial.ClientBootstrapTimePath != "" {
+			cfg.ConfigClientMachineInitial.ClientBootstrapTimePath = filepath.Join(cfg.ConfigClientMachineInitial.PathPrefix, cfg.ConfigClientMachineInitial.ClientBootstrapTimePath)
+		}
+		if cfg.ConfigClientMachineInitial.ClientRollingRestartPath != "" {
+			cfg.ConfigClientMachineInitial.ClientRollingRestartPath = filepath.Join(cfg.ConfigClientMachineInitial.PathPrefix, cfg.ConfigClientMachineInitial.ClientRollingRestartPath)
+		}
+		if cfg.ConfigClientMachineInitial.ClientWorkloadSummaryPath != "" {
+			cfg.ConfigClientMachineInitial.ClientWorkloadSummaryPath = filepath.Join(cfg.ConfigClientMachineInitial.PathPrefix, cfg.ConfigClientMachineInitial.ClientWorkloadSummaryPath)
+		}
+		if cfg.ConfigClientMachineInitial.ClientConvergencePath != "" {
+			cfg.ConfigClientMachineInitial.ClientConvergencePath = filepath.Join(cfg.ConfigClientMachineInitial.PathPrefix, cfg.ConfigClientMachineInitial.ClientConvergencePath)
+		}
+		if cfg.ConfigClientMachineInitial.ClientChaosPath != "" {
+			cfg.ConfigClientMachineInitial.ClientChaosPath = filepath.Join(cfg.ConfigClientMachineInitial.PathPrefix, cfg.ConfigClientMachineInitial.ClientChaosPath)
+		}
+		if cfg.ConfigClientMachineInitial.ClientServerLatencyCorrelationPath != "" {
+			cfg.ConfigClientMachineInitial.ClientServerLatencyCorrelationPath = filepath.Join(cfg.ConfigClientMachineInitial.PathPrefix, cfg.ConfigClientMachineInitial.ClientServerLatencyCorrelationPath)
+		}
+		if cfg.ConfigClientMachineInitial.ClientIdentityLeasePath != "" {
+			cfg.ConfigClientMachineInitial.ClientIdentityLeasePath = filepath.Join(cfg.ConfigClientMachineInitial.PathPrefix, cfg.ConfigClientMachineInitial.ClientIdentityLeasePath)
+		}
+		if cfg.ConfigClientMachineInitial.ClientSchedulePath != "" {
+			cfg.ConfigClientMachineInitial.ClientSchedulePath = filepath.Join(cfg.ConfigClientMachineInitial.PathPrefix, cfg.ConfigClientMachineInitial.ClientSchedulePath)
+		}
+		if cfg.ConfigClientMachineInitial.ClientInterferencePath != "" {
+			cfg.ConfigClientMachineInitial.ClientInterferencePath = filepath.Join(cfg.ConfigClientMachineInitial.PathPrefix, cfg.ConfigClientMachineInitial.ClientInterferencePath)
+		}
+		if cfg.ConfigClientMachineInitial.ClientConvergenceSummaryPath != "" {
+			cfg.ConfigClientMachineInitial.ClientConvergenceSummaryPath = filepath.Join(cfg.ConfigClientMachineInitial.PathPrefix, cfg.ConfigClientMachineInitial.ClientConvergenceSummaryPath)
+		}
+		if cfg.ConfigClientMachineInitial.ClientLearnerReadsPath != "" {
+			cfg.ConfigClientMachineInitial.ClientLearnerReadsPath = filepath.Join(cfg.ConfigClientMachineInitial.PathPrefix, cfg.ConfigClientMachineInitial.ClientLearnerReadsPath)
+		}
+		if cfg.ConfigClientMachineInitial.ClientOpenMetricsDir != "" {
+			cfg.ConfigClientMachineInitial.ClientOpenMetricsDir = filepath.Join(cfg.ConfigClientMachineInitial.PathPrefix, cfg.ConfigClientMachineInitial.ClientOpenMetricsDir)
+		}
+		if cfg.ConfigClientMachineInitial.ClientSizeHistogramPath != "" {
+			cfg.ConfigClientMachineInitial.ClientSizeHistogramPath = filepath.Join(cfg.ConfigClientMachineInitial.PathPrefix, cfg.ConfigClientMachineInitial.ClientSizeHistogramPath)
+		}
+		if cfg.ConfigClientMachineInitial.ClientFailoverPath != "" {
+			cfg.ConfigClientMachineInitial.ClientFailoverPath = filepath.Join(cfg.ConfigClientMachineInitial.PathPrefix, cfg.ConfigClientMachineInitial.ClientFailoverPath)
+		}
+		if cfg.ConfigClientMachineInitial.ClientSpikeRecoveryPath != "" {
+			cfg.ConfigClientMachineInitial.ClientSpikeRecoveryPath = filepath.Join(cfg.ConfigClientMachineInitial.PathPrefix, cfg.ConfigClientMachineInitial.ClientSpikeRecoveryPath)
+		}
+		if cfg.ConfigClientMachineInitial.ClientTLSHandshakePath != "" {
+			cfg.ConfigClientMachineInitial.ClientTLSHandshakePath = filepath.Join(cfg.ConfigClientMachineInitial.PathPrefix, cfg.ConfigClientMachineInitial.ClientTLSHandshakePath)
+		}
+		if cfg.ConfigClientMachineInitial.ClientLatencyHgrmPath != "" {
+			cfg.ConfigClientMachineInitial.ClientLatencyHgrmPath = filepath.Join(cfg.ConfigClientMachineInitial.PathPrefix, cfg.ConfigClientMachineInitial.ClientLatencyHgrmPath)
+		}
+		if cfg.ConfigClientMachineInitial.ClientMemberBreakdownPath != "" {
+			cfg.ConfigClientMachineInitial.ClientMemberBreakdownPath = filepath.Join(cfg.ConfigClientMachineInitial.PathPrefix, cfg.ConfigClientMachineInitial.ClientMemberBreakdownPath)
+		}
+		if cfg.ConfigClientMachineInitial.ClientTermChangePath != "" {
+			cfg.ConfigClientMachineInitial.ClientTermChangePath = filepath.Join(cfg.ConfigClientMachineInitial.PathPrefix, cfg.ConfigClientMachineInitial.ClientTermChangePath)
+		}
+		if cfg.ConfigClientMachineInitial.ClientResourceUsagePath != "" {
+			cfg.ConfigClientMachineInitial.ClientResourceUsagePath = filepath.Join(cfg.ConfigClientMachineInitial.PathPrefix, cfg.ConfigClientMachineInitial.ClientResourceUsagePath)
+		}
+		if cfg.ConfigClientMachineInitial.ClientZoneLatencyPath != "" {
+			cfg.ConfigClientMachineInitial.ClientZoneLatencyPath = filepath.Join(cfg.ConfigClientMachineInitial.PathPrefix, cfg.ConfigClientMachineInitial.ClientZoneLatencyPath)
+		}
+		if cfg.ConfigClientMachineInitial.ClientSocketStatsPath != "" {
+			cfg.ConfigClientMachineInitial.ClientSocketStatsPath = filepath.Join(cfg.ConfigClientMachineInitial.PathPrefix, cfg.ConfigClientMachineInitial.ClientSocketStatsPath)
+		}
+		if cfg.ConfigClientMachineInitial.ClientMaintenancePath != "" {
+			cfg.ConfigClientMachineInitial.ClientMaintenancePath = filepath.Join(cfg.ConfigClientMachineInitial.PathPrefix, cfg.ConfigClientMachineInitial.ClientMaintenancePath)
+		}
 	}
 
 	for databaseID, group := range cfg.DatabaseIDToConfigClientMachineAgentControl {
@@ -97,13 +412,7 @@ func ReadConfig(fpath string, analyze bool) (*Config, error) {
 
 		group.DatabaseID = databaseID
 		group.DatabaseTag = MakeTag(group.DatabaseDescription)
-		group.PeerIPsString = strings.Join(group.PeerIPs, "___")
-		group.DatabaseEndpoints = make([]string, len(group.PeerIPs))
-		group.AgentEndpoints = make([]string, len(group.PeerIPs))
-		for j := range group.PeerIPs {
-			group.DatabaseEndpoints[j] = fmt.Sprintf("%s:%d", group.PeerIPs[j], group.DatabasePortToConnect)
-			group.AgentEndpoints[j] = fmt.Sprintf("%s:%d", group.PeerIPs[j], group.AgentPortToConnect)
-		}
+		setPeerIPs(&group, group.PeerIPs)
 		cfg.DatabaseIDToConfigClientMachineAgentControl[databaseID] = group
 	}
 
@@ -127,12 +436,19 @@ func ReadConfig(fpath string, analyze bool) (*Config, error) {
 			for i := range amc.ServerSystemMetricsInterpolatedPathList {
 				amc.ServerSystemMetricsInterpolatedPathList[i] = amc.PathPrefix + "-" + amc.ServerSystemMetricsInterpolatedPathList[i]
 			}
+			for i := range amc.ServerDatabaseConfigPathList {
+				amc.ServerDatabaseConfigPathList[i] = amc.PathPrefix + "-" + amc.ServerDatabaseConfigPathList[i]
+			}
 			amc.AllAggregatedOutputPath = amc.PathPrefix + "-" + amc.AllAggregatedOutputPath
 		}
 
 		cfg.DatabaseIDToConfigAnalyzeMachineInitial[databaseID] = amc
 	}
 
+	if err = cfg.applySettings(); err != nil {
+		return nil, err
+	}
+
 	for databaseID, ctrl := range cfg.DatabaseIDToConfigClientMachineAgentControl {
 		if databaseID != dbtesterpb.DatabaseID_etcd__other.String() &&
 			databaseID != dbtesterpb.DatabaseID_etcd__tip.String() &&
@@ -141,6 +457,29 @@ func ReadConfig(fpath string, analyze bool) (*Config, error) {
 			ctrl.ConfigClientMachineBenchmarkOptions.ConnectionNumber != ctrl.ConfigClientMachineBenchmarkOptions.ClientNumber {
 			return nil, fmt.Errorf("%q got connected %d != clients %d", databaseID, ctrl.ConfigClientMachineBenchmarkOptions.ConnectionNumber, ctrl.ConfigClientMachineBenchmarkOptions.ClientNumber)
 		}
+
+		opts := ctrl.ConfigClientMachineBenchmarkOptions
+		if opts.ChaosFile != "" {
+			if opts.Chaos != "" {
+				return nil, fmt.Errorf("%q got both 'chaos' and 'chaos_file'", databaseID)
+			}
+			bts, err := ioutil.ReadFile(opts.ChaosFile)
+			if err != nil {
+				return nil, err
+			}
+			opts.Chaos = string(bts)
+		}
+		if opts.Chaos != "" {
+			if len(ctrl.PeerIPs) == 0 {
+				return nil, fmt.Errorf("%q got 'chaos' with no agent", databaseID)
+			}
+			if _, err := parseChaos(opts.Chaos, len(ctrl.PeerIPs)); err != nil {
+				return nil, fmt.Errorf("%q got invalid 'chaos' (%v)", databaseID, err)
+			}
+		}
+		if opts.ConfigClientMachineSpikeRecovery != nil && opts.Chaos == "" && opts.ConfigClientMachineRollingRestart == nil {
+			return nil, fmt.Errorf("%q got 'spike_recovery' with no 'chaos' or 'rolling_restart'", databaseID)
+		}
 	}
 
 	const (
@@ -148,6 +487,8 @@ func ReadConfig(fpath string, analyze bool) (*Config, error) {
 		defaultEtcdClientPort      int64 = 2379
 		defaultZookeeperClientPort int64 = 2181
 		defaultConsulClientPort    int64 = 8500
+		defaultRedisClientPort     int64 = 6379
+		defaultPDClientPort        int64 = 2379
 
 		defaultEtcdSnapshotCount             int64 = 100000
 		defaultEtcdQuotaSizeBytes            int64 = 8000000000
@@ -258,6 +599,20 @@ func ReadConfig(fpath string, analyze bool) (*Config, error) {
 		cfg.DatabaseIDToConfigClientMachineAgentControl[dbtesterpb.DatabaseID_consul__v1_0_2.String()] = v
 	}
 
+	if v, ok := cfg.DatabaseIDToConfigClientMachineAgentControl[dbtesterpb.DatabaseID_redis__v4_0.String()]; ok {
+		if v.DatabasePortToConnect == 0 {
+			v.DatabasePortToConnect = defaultRedisClientPort
+		}
+		cfg.DatabaseIDToConfigClientMachineAgentControl[dbtesterpb.DatabaseID_redis__v4_0.String()] = v
+	}
+
+	if v, ok := cfg.DatabaseIDToConfigClientMachineAgentControl[dbtesterpb.DatabaseID_tikv__v2_1.String()]; ok {
+		if v.DatabasePortToConnect == 0 {
+			v.DatabasePortToConnect = defaultPDClientPort
+		}
+		cfg.DatabaseIDToConfigClientMachineAgentControl[dbtesterpb.DatabaseID_tikv__v2_1.String()] = v
+	}
+
 	// need etcd configs since it's backed by etcd
 	if _, ok := cfg.DatabaseIDToConfigClientMachineAgentControl[dbtesterpb.DatabaseID_zetcd__beta.String()]; ok {
 		_, okOther := cfg.DatabaseIDToConfigClientMachineAgentControl[dbtesterpb.DatabaseID_etcd__other.String()]
@@ -298,6 +653,87 @@ func ReadConfig(fpath string, analyze bool) (*Config, error) {
 
 const maxEtcdQuotaSize = 8000000000
 
+// setPeerIPs sets the peer IPs of the database, with the database
+// and agent endpoints on the peers.
+func setPeerIPs(gcfg *dbtesterpb.ConfigClientMachineAgentControl, ips []string) {
+	gcfg.PeerIPs = ips
+	gcfg.PeerIPsString = strings.Join(ips, "___")
+	gcfg.DatabaseEndpoints = make([]string, len(ips))
+	gcfg.AgentEndpoints = make([]string, len(ips))
+	for j := range ips {
+		gcfg.DatabaseEndpoints[j] = net.JoinHostPort(ips[j], strconv.FormatInt(gcfg.DatabasePortToConnect, 10))
+		gcfg.AgentEndpoints[j] = net.JoinHostPort(ips[j], strconv.FormatInt(gcfg.AgentPortToConnect, 10))
+	}
+}
+
+// SetPeerIPs overwrites the peer IPs of the configured database,
+// such as to run on the nodes of a cluster launched for the test.
+func (cfg *Config) SetPeerIPs(databaseID string, ips []string) error {
+	gcfg, ok := cfg.DatabaseIDToConfigClientMachineAgentControl[databaseID]
+	if !ok {
+		return fmt.Errorf("database ID %q is not defined", databaseID)
+	}
+	if len(ips) == 0 {
+		return fmt.Errorf("no peer IP of %q", databaseID)
+	}
+	setPeerIPs(&gcfg, ips)
+	cfg.DatabaseIDToConfigClientMachineAgentControl[databaseID] = gcfg
+	return nil
+}
+
+// BenchmarkOverride overwrites the configuration of a database with
+// a flag, such as '--rate' of 'control'.
+type BenchmarkOverride struct {
+	// Flag is the name of the flag, such as '--rate'.
+	Flag string
+	// Set is true if the flag is given; the configuration is kept if not.
+	Set bool
+	// Validate returns the error of the value of the flag, if not nil.
+	Validate func() error
+	// Apply overwrites the configuration of the database, if not nil.
+	Apply func(gcfg *dbtesterpb.ConfigClientMachineAgentControl)
+	// Check returns the error of the configuration with all overrides
+	// applied, if not nil, such as of a benchmark type that the flag
+	// is not for.
+	Check func(gcfg dbtesterpb.ConfigClientMachineAgentControl) error
+}
+
+// ApplyBenchmarkOverrides validates the overrides that are set, and
+// applies them in order to the configuration of the database, which is
+// then checked once, so that the checks do not depend on the order of
+// the overrides. A database that is not configured is skipped, as it is
+// not run.
+func (cfg *Config) ApplyBenchmarkOverrides(databaseID string, ovs []BenchmarkOverride) error {
+	for _, ov := range ovs {
+		if !ov.Set || ov.Validate == nil {
+			continue
+		}
+		if err := ov.Validate(); err != nil {
+			return fmt.Errorf("%s: %v", ov.Flag, err)
+		}
+	}
+
+	gcfg, ok := cfg.DatabaseIDToConfigClientMachineAgentControl[databaseID]
+	if !ok {
+		return nil
+	}
+	for _, ov := range ovs {
+		if ov.Set && ov.Apply != nil {
+			ov.Apply(&gcfg)
+		}
+	}
+	cfg.DatabaseIDToConfigClientMachineAgentControl[databaseID] = gcfg
+	for _, ov := range ovs {
+		if !ov.Set || ov.Check == nil {
+			continue
+		}
+		if err := ov.Check(gcfg); err != nil {
+			return fmt.Errorf("%s: %v", ov.Flag, err)
+		}
+	}
+	return nil
+}
+
 // ToRequest converts configuration to 'dbtesterpb.Request'.
 func (cfg *Config) ToRequest(databaseID string, op dbtesterpb.Operation, idx int) (req *dbtesterpb.Request, err error) {
 	gcfg, ok := cfg.DatabaseIDToConfigClientMachineAgentControl[databaseID]
@@ -315,6 +751,7 @@ func (cfg *Config) ToRequest(databaseID string, op dbtesterpb.Operation, idx int
 		PeerIPsString:       gcfg.PeerIPsString,
 		IPIndex:             uint32(idx),
 		CurrentClientNumber: gcfg.ConfigClientMachineBenchmarkOptions.ClientNumber,
+		DatabaseVersion:     cfg.DatabaseVersion,
 		ConfigClientMachineInitial: &dbtesterpb.ConfigClientMachineInitial{
 			GoogleCloudProjectName:         cfg.ConfigClientMachineInitial.GoogleCloudProjectName,
 			GoogleCloudStorageKey:          cfg.ConfigClientMachineInitial.GoogleCloudStorageKey,
@@ -380,6 +817,15 @@ func (cfg *Config) ToRequest(databaseID string, op dbtesterpb.Operation, idx int
 	case dbtesterpb.DatabaseID_zetcd__beta:
 	case dbtesterpb.DatabaseID_cetcd__beta:
 
+	case dbtesterpb.DatabaseID_redis__v4_0:
+		// no agent to start, Redis is run outside of dbtester
+
+	case dbtesterpb.DatabaseID_tikv__v2_1:
+		// no agent to start, TiKV is run outside of dbtester
+
+	case dbtesterpb.DatabaseID_mock:
+		// no agent to start, runs in the control process
+
 	default:
 		err = fmt.Errorf("unknown %v", req.DatabaseID)
 	}
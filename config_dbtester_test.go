@@ -15,6 +15,7 @@
 package dbtester
 
 import (
+	"fmt"
 	"reflect"
 	"testing"
 
@@ -184,6 +185,11 @@ func TestConfig(t *testing.T) {
 					"2017Q1-01-etcd-zookeeper-consul/01-write-1M-keys-client-variable/etcd-tip-go1.8.0-2-server-system-metrics-interpolated.csv",
 					"2017Q1-01-etcd-zookeeper-consul/01-write-1M-keys-client-variable/etcd-tip-go1.8.0-3-server-system-metrics-interpolated.csv",
 				},
+				ServerDatabaseConfigPathList: []string{
+					"2017Q1-01-etcd-zookeeper-consul/01-write-1M-keys-client-variable/etcd-tip-go1.8.0-1-database-config.txt",
+					"2017Q1-01-etcd-zookeeper-consul/01-write-1M-keys-client-variable/etcd-tip-go1.8.0-2-database-config.txt",
+					"2017Q1-01-etcd-zookeeper-consul/01-write-1M-keys-client-variable/etcd-tip-go1.8.0-3-database-config.txt",
+				},
 				AllAggregatedOutputPath: "2017Q1-01-etcd-zookeeper-consul/01-write-1M-keys-client-variable/etcd-tip-go1.8.0-all-aggregated.csv",
 			},
 			"zookeeper__r3_5_3_beta": {
@@ -207,6 +213,11 @@ func TestConfig(t *testing.T) {
 					"2017Q1-01-etcd-zookeeper-consul/01-write-1M-keys-client-variable/zookeeper-r3.5.3-beta-java8-2-server-system-metrics-interpolated.csv",
 					"2017Q1-01-etcd-zookeeper-consul/01-write-1M-keys-client-variable/zookeeper-r3.5.3-beta-java8-3-server-system-metrics-interpolated.csv",
 				},
+				ServerDatabaseConfigPathList: []string{
+					"2017Q1-01-etcd-zookeeper-consul/01-write-1M-keys-client-variable/zookeeper-r3.5.3-beta-java8-1-database-config.txt",
+					"2017Q1-01-etcd-zookeeper-consul/01-write-1M-keys-client-variable/zookeeper-r3.5.3-beta-java8-2-database-config.txt",
+					"2017Q1-01-etcd-zookeeper-consul/01-write-1M-keys-client-variable/zookeeper-r3.5.3-beta-java8-3-database-config.txt",
+				},
 				AllAggregatedOutputPath: "2017Q1-01-etcd-zookeeper-consul/01-write-1M-keys-client-variable/zookeeper-r3.5.3-beta-java8-all-aggregated.csv",
 			},
 			"consul__v1_0_2": {
@@ -230,6 +241,11 @@ func TestConfig(t *testing.T) {
 					"2017Q1-01-etcd-zookeeper-consul/01-write-1M-keys-client-variable/consul-v1.0.2-go1.8.0-2-server-system-metrics-interpolated.csv",
 					"2017Q1-01-etcd-zookeeper-consul/01-write-1M-keys-client-variable/consul-v1.0.2-go1.8.0-3-server-system-metrics-interpolated.csv",
 				},
+				ServerDatabaseConfigPathList: []string{
+					"2017Q1-01-etcd-zookeeper-consul/01-write-1M-keys-client-variable/consul-v1.0.2-go1.8.0-1-database-config.txt",
+					"2017Q1-01-etcd-zookeeper-consul/01-write-1M-keys-client-variable/consul-v1.0.2-go1.8.0-2-database-config.txt",
+					"2017Q1-01-etcd-zookeeper-consul/01-write-1M-keys-client-variable/consul-v1.0.2-go1.8.0-3-database-config.txt",
+				},
 				AllAggregatedOutputPath: "2017Q1-01-etcd-zookeeper-consul/01-write-1M-keys-client-variable/consul-v1.0.2-go1.8.0-all-aggregated.csv",
 			},
 		},
@@ -491,7 +507,10 @@ func TestConfig(t *testing.T) {
 				},
 			},
 		},
+
+		ProgressInterval: DefaultProgressInterval,
 	}
+	expected.lg = cfg.lg
 	if !reflect.DeepEqual(cfg, expected) {
 		t.Fatalf("configuration expected\n%+v\n, got\n%+v\n", expected, cfg)
 	}
@@ -558,3 +577,148 @@ func TestConfig(t *testing.T) {
 		t.Fatalf("configuration expected\n%+v\n, got\n%+v\n", expected2, req2)
 	}
 }
+
+func TestCheckSizeLimits(t *testing.T) {
+	cfg := &Config{DatabaseIDToConfigClientMachineAgentControl: map[string]dbtesterpb.ConfigClientMachineAgentControl{
+		"etcd__tip": {
+			DatabaseID:                          "etcd__tip",
+			ConfigClientMachineBenchmarkOptions: &dbtesterpb.ConfigClientMachineBenchmarkOptions{KeySizeBytes: 256, ValueSizeBytes: 1024 * 1024},
+		},
+		"zookeeper__r3_5_3_beta": {
+			DatabaseID:                          "zookeeper__r3_5_3_beta",
+			ConfigClientMachineBenchmarkOptions: &dbtesterpb.ConfigClientMachineBenchmarkOptions{KeySizeBytes: 256, ValueSizeBytes: 1024 * 1024},
+			Flag_Zookeeper_R3_5_3Beta:           &dbtesterpb.Flag_Zookeeper_R3_5_3Beta{},
+		},
+		"consul__v1_0_2": {
+			DatabaseID:                          "consul__v1_0_2",
+			ConfigClientMachineBenchmarkOptions: &dbtesterpb.ConfigClientMachineBenchmarkOptions{KeySizeBytes: 256, ValueSizeBytes: 1024 * 1024},
+		},
+	}}
+	if err := cfg.CheckSizeLimits("etcd__tip"); err != nil {
+		t.Fatal(err)
+	}
+	if err := cfg.CheckSizeLimits("zookeeper__r3_5_3_beta"); err == nil {
+		t.Fatal("expected error over default jute buffer")
+	}
+	if err := cfg.CheckSizeLimits("consul__v1_0_2"); err == nil {
+		t.Fatal("expected error over Consul value size limit")
+	}
+
+	cfg.DatabaseIDToConfigClientMachineAgentControl["zookeeper__r3_5_3_beta"].Flag_Zookeeper_R3_5_3Beta.JavaDJuteMaxBuffer = 33554432
+	if err := cfg.CheckSizeLimits("zookeeper__r3_5_3_beta"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSetPeerIPs(t *testing.T) {
+	cfg := &Config{DatabaseIDToConfigClientMachineAgentControl: map[string]dbtesterpb.ConfigClientMachineAgentControl{
+		"etcd__tip": {
+			DatabaseID:            "etcd__tip",
+			PeerIPs:               []string{"10.240.0.7", "10.240.0.8", "10.240.0.12"},
+			DatabasePortToConnect: 2379,
+			AgentPortToConnect:    3500,
+		},
+	}}
+	if err := cfg.SetPeerIPs("etcd__tip", []string{"10.0.0.1", "10.0.0.2"}); err != nil {
+		t.Fatal(err)
+	}
+	gcfg := cfg.DatabaseIDToConfigClientMachineAgentControl["etcd__tip"]
+	if gcfg.PeerIPsString != "10.0.0.1___10.0.0.2" {
+		t.Fatalf("unexpected peer IPs %q", gcfg.PeerIPsString)
+	}
+	if !reflect.DeepEqual(gcfg.DatabaseEndpoints, []string{"10.0.0.1:2379", "10.0.0.2:2379"}) {
+		t.Fatalf("unexpected database endpoints %v", gcfg.DatabaseEndpoints)
+	}
+	if !reflect.DeepEqual(gcfg.AgentEndpoints, []string{"10.0.0.1:3500", "10.0.0.2:3500"}) {
+		t.Fatalf("unexpected agent endpoints %v", gcfg.AgentEndpoints)
+	}
+
+	if err := cfg.SetPeerIPs("etcd__tip", nil); err == nil {
+		t.Fatal("expected error of no peer IP")
+	}
+	if err := cfg.SetPeerIPs("consul__v1_0_2", []string{"10.0.0.1"}); err == nil {
+		t.Fatal("expected error of unknown database")
+	}
+}
+
+func TestApplyBenchmarkOverrides(t *testing.T) {
+	positive := func(n int64) func() error {
+		return func() error {
+			if n <= 0 {
+				return fmt.Errorf("must be positive (got %d)", n)
+			}
+			return nil
+		}
+	}
+	txn := func(n int64) BenchmarkOverride {
+		return BenchmarkOverride{
+			Flag:     "--ops-per-txn",
+			Set:      n != 0,
+			Validate: positive(n),
+			Apply: func(gcfg *dbtesterpb.ConfigClientMachineAgentControl) {
+				gcfg.ConfigClientMachineBenchmarkOptions.Type = "txn"
+				gcfg.ConfigClientMachineBenchmarkOptions.TxnKeyNumber = n
+			},
+		}
+	}
+	typ := func(v string) BenchmarkOverride {
+		return BenchmarkOverride{
+			Flag: "--type",
+			Set:  true,
+			Apply: func(gcfg *dbtesterpb.ConfigClientMachineAgentControl) {
+				gcfg.ConfigClientMachineBenchmarkOptions.Type = v
+			},
+		}
+	}
+	preload := BenchmarkOverride{
+		Flag: "--preload",
+		Set:  true,
+		Apply: func(gcfg *dbtesterpb.ConfigClientMachineAgentControl) {
+			gcfg.ConfigClientMachineBenchmarkOptions.Prepopulate = 1000
+		},
+		Check: func(gcfg dbtesterpb.ConfigClientMachineAgentControl) error {
+			if gcfg.ConfigClientMachineBenchmarkOptions.Type != "read" {
+				return fmt.Errorf("only for 'read'")
+			}
+			return nil
+		},
+	}
+	tests := []struct {
+		databaseID string
+		ovs        []BenchmarkOverride
+		ok         bool
+		typ        string
+		keyN       int64
+	}{
+		// not set keeps the configuration
+		{"etcd__tip", []BenchmarkOverride{txn(0)}, true, "write", 2},
+		{"etcd__tip", []BenchmarkOverride{txn(5)}, true, "txn", 5},
+		// in order
+		{"etcd__tip", []BenchmarkOverride{txn(5), txn(3)}, true, "txn", 3},
+		{"etcd__tip", []BenchmarkOverride{txn(-1)}, false, "write", 2},
+		// nothing is applied if any is not valid
+		{"etcd__tip", []BenchmarkOverride{txn(5), txn(-1)}, false, "write", 2},
+		// databases that are not configured are skipped
+		{"consul__v1_0_2", []BenchmarkOverride{txn(5)}, true, "write", 2},
+		// but the flags are validated
+		{"consul__v1_0_2", []BenchmarkOverride{txn(-1)}, false, "write", 2},
+		// checked after all overrides, in any order
+		{"etcd__tip", []BenchmarkOverride{preload, typ("read")}, true, "read", 2},
+		{"etcd__tip", []BenchmarkOverride{typ("read"), preload}, true, "read", 2},
+		{"etcd__tip", []BenchmarkOverride{typ("read"), preload, txn(5)}, false, "txn", 5},
+		{"etcd__tip", []BenchmarkOverride{preload}, false, "write", 2},
+	}
+	for i, tt := range tests {
+		opts := &dbtesterpb.ConfigClientMachineBenchmarkOptions{Type: "write", TxnKeyNumber: 2}
+		cfg := &Config{DatabaseIDToConfigClientMachineAgentControl: map[string]dbtesterpb.ConfigClientMachineAgentControl{
+			"etcd__tip": {DatabaseID: "etcd__tip", ConfigClientMachineBenchmarkOptions: opts},
+		}}
+		err := cfg.ApplyBenchmarkOverrides(tt.databaseID, tt.ovs)
+		if tt.ok != (err == nil) {
+			t.Fatalf("#%d: expected ok %v, got error %v", i, tt.ok, err)
+		}
+		if opts.Type != tt.typ || opts.TxnKeyNumber != tt.keyN {
+			t.Fatalf("#%d: expected %q of %d keys, got %q of %d keys", i, tt.typ, tt.keyN, opts.Type, opts.TxnKeyNumber)
+		}
+	}
+}
@@ -0,0 +1,162 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"bufio"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+)
+
+// settings are the connection settings and secrets from the environment
+// variables, 'NAME_FILE' files and the 'credentials_file', so that they
+// do not appear in the configuration, the shell history or CI logs.
+type settings struct {
+	fromFile map[string]string
+}
+
+func newSettings(credentialsFile string) (*settings, error) {
+	s := &settings{fromFile: make(map[string]string)}
+	if credentialsFile == "" {
+		return s, nil
+	}
+	f, err := os.Open(credentialsFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		idx := strings.Index(line, "=")
+		if idx <= 0 {
+			return nil, fmt.Errorf("%s:%d: expected 'NAME=VALUE'", credentialsFile, n)
+		}
+		s.fromFile[strings.TrimSpace(line[:idx])] = strings.TrimSpace(line[idx+1:])
+	}
+	return s, scanner.Err()
+}
+
+// get returns the value of 'name' from the environment variable, the file
+// of the environment variable 'name_FILE', or the credentials file, in order.
+func (s *settings) get(name string) (string, error) {
+	if v := os.Getenv(name); v != "" {
+		return v, nil
+	}
+	if fpath := os.Getenv(name + "_FILE"); fpath != "" {
+		bts, err := ioutil.ReadFile(fpath)
+		if err != nil {
+			return "", fmt.Errorf("failed to read %s_FILE (%v)", name, err)
+		}
+		return strings.TrimSpace(string(bts)), nil
+	}
+	return s.fromFile[name], nil
+}
+
+// endpoints returns the comma-separated endpoints of 'name', if set.
+func (s *settings) endpoints(name string) ([]string, error) {
+	v, err := s.get(name)
+	if err != nil || v == "" {
+		return nil, err
+	}
+	var eps []string
+	for _, ep := range strings.Split(v, ",") {
+		if ep = strings.TrimSpace(ep); ep != "" {
+			eps = append(eps, ep)
+		}
+	}
+	return eps, nil
+}
+
+// applySettings overrides the connection settings of the configuration
+// with the ones from the environment and the credentials file.
+func (cfg *Config) applySettings() error {
+	s, err := newSettings(cfg.ConfigClientMachineInitial.CredentialsFile)
+	if err != nil {
+		return err
+	}
+
+	if cfg.ConfigClientMachineInitial.GoogleCloudStorageKeyPath == "" {
+		if cfg.ConfigClientMachineInitial.GoogleCloudStorageKeyPath, err = s.get("GOOGLE_APPLICATION_CREDENTIALS"); err != nil {
+			return err
+		}
+	}
+
+	for databaseID, gcfg := range cfg.DatabaseIDToConfigClientMachineAgentControl {
+		var eps []string
+		opts := *gcfg.ConfigClientMachineBenchmarkOptions
+		switch databaseID {
+		case "etcd__other", "etcd__tip", "etcd__v3_2", "etcd__v3_3":
+			if eps, err = s.endpoints("ETCD_ENDPOINTS"); err != nil {
+				return err
+			}
+			if opts.EtcdUsername, err = s.get("ETCD_USERNAME"); err != nil {
+				return err
+			}
+			if opts.EtcdPassword, err = s.get("ETCD_PASSWORD"); err != nil {
+				return err
+			}
+			if opts.EtcdUsername != "" && opts.EtcdRBAC != "" {
+				return fmt.Errorf("%q got both 'ETCD_USERNAME' and 'etcd_rbac'", databaseID)
+			}
+
+		case "zookeeper__r3_5_3_beta", "zetcd__beta":
+			if databaseID == "zookeeper__r3_5_3_beta" {
+				if eps, err = s.endpoints("ZOOKEEPER_ENDPOINTS"); err != nil {
+					return err
+				}
+			}
+			var auth string
+			if auth, err = s.get("ZOOKEEPER_DIGEST_AUTH"); err != nil {
+				return err
+			}
+			if auth != "" {
+				opts.ZookeeperDigestAuth = auth
+			}
+
+		case "consul__v1_0_2", "cetcd__beta":
+			if databaseID == "consul__v1_0_2" {
+				if eps, err = s.endpoints("CONSUL_HTTP_ADDR"); err != nil {
+					return err
+				}
+			}
+			if opts.ConsulToken, err = s.get("CONSUL_HTTP_TOKEN"); err != nil {
+				return err
+			}
+
+		case "redis__v4_0":
+			if eps, err = s.endpoints("REDIS_ENDPOINTS"); err != nil {
+				return err
+			}
+
+		case "tikv__v2_1":
+			if eps, err = s.endpoints("TIKV_PD_ENDPOINTS"); err != nil {
+				return err
+			}
+		}
+		if len(eps) > 0 {
+			gcfg.DatabaseEndpoints = eps
+		}
+		gcfg.ConfigClientMachineBenchmarkOptions = &opts
+		cfg.DatabaseIDToConfigClientMachineAgentControl[databaseID] = gcfg
+	}
+	return nil
+}
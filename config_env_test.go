@@ -0,0 +1,70 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestSettings(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dbtester-settings")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	credPath := filepath.Join(dir, "credentials")
+	cred := "# comment\nETCD_ENDPOINTS = 10.0.0.1:2379, 10.0.0.2:2379\nETCD_PASSWORD=from-file\n"
+	if err = ioutil.WriteFile(credPath, []byte(cred), 0600); err != nil {
+		t.Fatal(err)
+	}
+	tokenPath := filepath.Join(dir, "token")
+	if err = ioutil.WriteFile(tokenPath, []byte("secret-token\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	os.Setenv("ETCD_PASSWORD", "from-env")
+	os.Setenv("CONSUL_HTTP_TOKEN_FILE", tokenPath)
+	defer os.Unsetenv("ETCD_PASSWORD")
+	defer os.Unsetenv("CONSUL_HTTP_TOKEN_FILE")
+
+	s, err := newSettings(credPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	eps, err := s.endpoints("ETCD_ENDPOINTS")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(eps, []string{"10.0.0.1:2379", "10.0.0.2:2379"}) {
+		t.Fatalf("unexpected endpoints %v", eps)
+	}
+	if v, _ := s.get("ETCD_PASSWORD"); v != "from-env" {
+		t.Fatalf("expected environment variable to take precedence, got %q", v)
+	}
+	if v, _ := s.get("CONSUL_HTTP_TOKEN"); v != "secret-token" {
+		t.Fatalf("expected token from CONSUL_HTTP_TOKEN_FILE, got %q", v)
+	}
+
+	if err = ioutil.WriteFile(credPath, []byte("ETCD_PASSWORD\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = newSettings(credPath); err == nil {
+		t.Fatal("expected error for line with no '='")
+	}
+}
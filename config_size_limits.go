@@ -0,0 +1,67 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"fmt"
+)
+
+const (
+	// etcdMaxRequestBytes is the default of etcd '--max-request-bytes'.
+	etcdMaxRequestBytes = 1.5 * 1024 * 1024
+
+	// zookeeperDefaultJuteMaxBuffer is the default of Zookeeper
+	// '-Djute.maxbuffer', when 'java_d_jute_max_buffer' is not set.
+	zookeeperDefaultJuteMaxBuffer = 0xfffff
+
+	// consulMaxValueBytes is the maximum size of Consul KV value.
+	consulMaxValueBytes = 512 * 1024
+)
+
+// CheckSizeLimits returns an error if the configured key or value
+// sizes exceed the request size limit of the database, which would
+// otherwise fail every request with errors from the database servers.
+func (cfg *Config) CheckSizeLimits(databaseID string) error {
+	gcfg, ok := cfg.DatabaseIDToConfigClientMachineAgentControl[databaseID]
+	if !ok {
+		return fmt.Errorf("%q is not found", databaseID)
+	}
+	keySize := gcfg.ConfigClientMachineBenchmarkOptions.KeySizeBytes
+	valSize := gcfg.ConfigClientMachineBenchmarkOptions.ValueSizeBytes
+
+	switch gcfg.DatabaseID {
+	case "etcd__other", "etcd__tip", "etcd__v3_2", "etcd__v3_3", "zetcd__beta", "cetcd__beta":
+		// zetcd and cetcd proxy requests to etcd
+		if keySize+valSize > etcdMaxRequestBytes {
+			return fmt.Errorf("%q key size %d + value size %d exceeds etcd request size limit %d bytes", databaseID, keySize, valSize, int64(etcdMaxRequestBytes))
+		}
+
+	case "zookeeper__r3_5_3_beta":
+		limit := int64(zookeeperDefaultJuteMaxBuffer)
+		if gcfg.Flag_Zookeeper_R3_5_3Beta != nil && gcfg.Flag_Zookeeper_R3_5_3Beta.JavaDJuteMaxBuffer > 0 {
+			limit = int64(gcfg.Flag_Zookeeper_R3_5_3Beta.JavaDJuteMaxBuffer)
+		}
+		// key is prefixed with '/' as znode path
+		if keySize+1+valSize > limit {
+			return fmt.Errorf("%q key size %d + value size %d exceeds Zookeeper jute buffer %d bytes", databaseID, keySize, valSize, limit)
+		}
+
+	case "consul__v1_0_2":
+		if valSize > consulMaxValueBytes {
+			return fmt.Errorf("%q value size %d exceeds Consul KV value size limit %d bytes", databaseID, valSize, consulMaxValueBytes)
+		}
+	}
+	return nil
+}
@@ -17,8 +17,11 @@ package control
 
 import (
 	"fmt"
+	"math"
 	"os"
+	"os/signal"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/coreos/dbtester"
@@ -31,6 +34,7 @@ import (
 	"github.com/gyuho/linux-inspect/top"
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
+	"golang.org/x/net/context"
 )
 
 // Command implements 'control' command.
@@ -44,6 +48,57 @@ var databaseID string
 var configPath string
 var diskDevice string
 var networkInterface string
+var cooldown time.Duration
+var force bool
+var profileDir string
+var profilePointsFlag []string
+var progressInterval time.Duration
+var metricsAddr string
+var timeseriesFile string
+var maxLoaderCPUs int
+var loaderCgroup bool
+var saveKeysPath string
+var keysFromPath string
+var keysPerRequest int64
+var rangeReads bool
+var clusterA []string
+var clusterB []string
+var workers []string
+var readRatio float64
+var rateFlag int64
+var duration time.Duration
+var rampUp time.Duration
+var warmupRequests int64
+var warmupDuration time.Duration
+var autoCompactEvery time.Duration
+var autoDefrag bool
+var keyDist string
+var keyOrder string
+var preload int64
+var etcdIgnoreValue bool
+var etcdIgnoreLease bool
+var etcdAPIVersion string
+var opsPerTxn int64
+var outputFormat string
+var outputFile string
+var latencyResolution int
+var compress string
+var expectClusterID string
+var expectMemberCount int
+var addressFamily string
+var endpointZones []string
+var loaderZone string
+var certFile string
+var keyFile string
+var caFile string
+var maxResponseBytes int64
+var operation string
+var pdEndpoints []string
+var authUser string
+var authPassword string
+var consulToken string
+var zkAuth string
+var authOverhead bool
 
 func init() {
 	dn, err := df.GetDevice("/")
@@ -65,17 +120,420 @@ func init() {
 	Command.PersistentFlags().StringVarP(&configPath, "config", "c", "", "YAML configuration file path.")
 	Command.PersistentFlags().StringVar(&diskDevice, "disk-device", dn, "Disk device to collect disk statistics metrics from.")
 	Command.PersistentFlags().StringVar(&networkInterface, "network-interface", nt, "Network interface to record in/outgoing packets.")
+	Command.PersistentFlags().DurationVar(&cooldown, "cooldown", 0, "Settle period between stages, with no load but server metrics still being collected.")
+	Command.PersistentFlags().BoolVar(&force, "force", false, "Run even if key or value sizes exceed the request size limits of the database.")
+	Command.PersistentFlags().StringVar(&profileDir, "profile-dir", "", "Directory to save the CPU profile of the stress step and the heap profiles, with folded stacks for flamegraphs. Empty to not profile.")
+	Command.PersistentFlags().StringSliceVar(&profilePointsFlag, "profile-points", []string{"before-stress", "after-stress"}, "Points to capture the heap profiles at: "+strings.Join(profilePoints, ", ")+".")
+	Command.PersistentFlags().StringVar(&saveKeysPath, "save-keys", "", "File to save the keys of the successful writes of 'write' benchmarks and 'prepopulate', one per line, for later runs with '--keys-from'.")
+	Command.PersistentFlags().StringVar(&keysFromPath, "keys-from", "", "File of the keys to read or delete, as saved by '--save-keys', instead of the prepopulated keys.")
+	Command.PersistentFlags().Int64Var(&keysPerRequest, "keys-per-request", 1, "Number of keys that each request of 'read' benchmarks reads, from the prepopulated keys or '--keys-from' (etcd range or transaction of gets, pipelined Zookeeper and Consul gets).")
+	Command.PersistentFlags().BoolVar(&rangeReads, "range-reads", false, "'true' to read the prepopulated keys of each request of '--keys-per-request', a power of 10, by the prefix they share: etcd prefix ranges, Consul 'List', and Zookeeper 'Children' of the parent znode with the 'Get' of each matched child.")
+	Command.PersistentFlags().StringSliceVar(&clusterA, "cluster-a", nil, "Endpoints of cluster A, to stress at the same time as '--cluster-b' with the same workload from separate clients, instead of 'database_endpoints'. Results are saved with '-a' and '-b' before the extensions.")
+	Command.PersistentFlags().StringSliceVar(&clusterB, "cluster-b", nil, "Endpoints of cluster B, to stress at the same time as '--cluster-a'.")
+	Command.PersistentFlags().StringSliceVar(&workers, "workers", nil, "Endpoints of 'worker' processes on other client machines, to fan the stress out to, each with its share of the clients, the requests and the rate. Their interim results are merged into the time series and the summary; each worker saves its own percentiles. Empty to stress from this process.")
+	Command.PersistentFlags().Float64Var(&readRatio, "read-ratio", 0, "Ratio of reads, to run a 'read-write' benchmark that interleaves reads and writes from the same clients (e.g. 0.95 for 95% reads and 5% writes), overriding 'type' and 'read_percent'. 0 to use the configuration.")
+	Command.PersistentFlags().Int64Var(&rateFlag, "rate", 0, "Requests per second to offer, paced by a token bucket (or at fixed intervals with 'open_loop'), to measure the latencies at a controlled load instead of at saturation, overriding 'rate_limit_requests_per_second'. 0 to use the configuration.")
+	Command.PersistentFlags().DurationVar(&duration, "duration", 0, "Duration of the stress, to keep issuing requests until it expires (e.g. 10m for a soak test), overriding 'duration_seconds' and 'request_number'. 0 to use the configuration.")
+	Command.PersistentFlags().DurationVar(&rampUp, "ramp-up", 0, "Duration to start the clients one at a time, from one client to all 'client_number' clients at an even pace, overriding 'ramp_up_seconds'. 0 to use the configuration.")
+	Command.PersistentFlags().Int64Var(&warmupRequests, "warmup-requests", 0, "Number of the first requests of the run whose results are discarded from the statistics, overriding 'warmup_request_number'. The requests are part of 'request_number'. 0 to use the configuration.")
+	Command.PersistentFlags().DurationVar(&warmupDuration, "warmup-duration", 0, "Duration from the start of the run whose results are discarded from the statistics, overriding 'warmup_seconds'. 0 to use the configuration.")
+	Command.PersistentFlags().DurationVar(&autoCompactEvery, "auto-compact-every", 0, "Interval to compact the etcd history during the stress through the Maintenance API (e.g. 5m for a soak test), retaining the revisions of the last interval, overriding 'auto_compact_seconds'. Each compaction is saved to 'client_maintenance_path'. 0 to use the configuration.")
+	Command.PersistentFlags().BoolVar(&autoDefrag, "auto-defrag", false, "'true' to defragment each etcd member, one at a time, after each compaction of '--auto-compact-every', overriding 'auto_defrag'.")
+	Command.PersistentFlags().Int64Var(&opsPerTxn, "ops-per-txn", 0, "Number of keys that each transaction reads, compares and writes, to run a 'txn' benchmark (etcd transactions of compare and put, Consul 'Txn', Zookeeper 'Multi'), overriding 'type' and 'txn_key_number'. 0 to use the configuration, which 'control txn' does not allow.")
+	Command.PersistentFlags().BoolVar(&etcdIgnoreValue, "etcd-ignore-value", false, "Write the existing etcd keys with no value and 'WithIgnoreValue', to benchmark \"touch\" writes that update only the revisions, overriding 'etcd_ignore_value'. 'write' requires 'key_space_size'.")
+	Command.PersistentFlags().BoolVar(&etcdIgnoreLease, "etcd-ignore-lease", false, "Write the existing etcd keys with 'WithIgnoreLease', to benchmark updates that keep the leases, overriding 'etcd_ignore_lease'. 'write' requires 'key_space_size'.")
+	Command.PersistentFlags().StringVar(&etcdAPIVersion, "etcd-api-version", "", "etcd client API to benchmark, overriding 'etcd_api_version': 'clientv3' (balancer and retries over all endpoints) or 'grpc' (the versioned KV service on one endpoint per connection).")
+	Command.PersistentFlags().StringVar(&keyDist, "key-dist", "", "Distribution of the keys that reads, and writes of 'key_space_size', access: uniform, zipfian, latest or hotspot, overriding 'key_distribution'. Empty to use the configuration.")
+	Command.PersistentFlags().Int64Var(&preload, "preload", 0, "Number of keys of 'key_size_bytes' and 'value_size_bytes' to write before 'read', 'read-write' and 'mixed' benchmarks, to read at a realistic dataset size, overriding 'prepopulate'. 0 to use the configuration.")
+	Command.PersistentFlags().StringVar(&keyOrder, "key-order", "", "Order of the unique keys that writes put: sequential, random or reverse, overriding 'key_order'. Empty to use the configuration.")
+	Command.PersistentFlags().StringVar(&outputFormat, "output-format", "text", "Format of the results of the stress, with throughput, latency percentiles, error counts and per-second time series: "+strings.Join(dbtester.OutputFormats, ", ")+".")
+	Command.PersistentFlags().StringVar(&outputFile, "output-file", "", "File to write the results of the stress to, in '--output-format'. Empty to print to stdout.")
+	Command.PersistentFlags().IntVar(&latencyResolution, "latency-resolution", dbtester.DefaultLatencyResolution, "Significant digits, 1 to 5, of the latencies in the HDR histogram that the reported latency summary and percentiles are computed from, and that is saved to 'client_latency_hgrm_path'.")
+	Command.PersistentFlags().StringVar(&compress, "compress", "none", "Compression of the result files written by the loader, streamed as they are written, with '.gz' or '.zst' appended to their paths: "+strings.Join(dbtester.Compressions, ", ")+". 'zstd' requires the 'zstd' binary.")
+	Command.PersistentFlags().StringVar(&expectClusterID, "expect-cluster-id", "", "Cluster that the endpoints must reach, or the run aborts before the stress: etcd cluster ID in hex, or Consul datacenter. Zookeeper has no cluster ID. Empty to not check.")
+	Command.PersistentFlags().IntVar(&expectMemberCount, "expect-member-count", 0, "Number of the members that the cluster must have, or the run aborts before the stress: etcd members, Zookeeper servers of '/zookeeper/config', or Consul raft peers. 0 to not check.")
+	Command.PersistentFlags().StringVar(&certFile, "cert", "", "Client certificate to connect to the database with TLS (etcd, Zookeeper 'secureClientPort' and Consul HTTPS). Requires '--key'.")
+	Command.PersistentFlags().StringVar(&keyFile, "key", "", "Private key of '--cert'.")
+	Command.PersistentFlags().StringVar(&caFile, "cacert", "", "CA certificate to verify the database servers with, to connect with TLS. Empty to verify with the system roots when '--cert' is set.")
+	Command.PersistentFlags().StringSliceVar(&endpointZones, "endpoint-zones", nil, "Zones of the endpoints, or of their hosts, as 'endpoint=zone' (e.g. 'etcd1=zone-a,etcd2=zone-b'), to save the latencies of '--loader-zone' to each server zone to 'client_zone_latency_path'. The servers are known from the responses of etcd and the connections of Zookeeper; requests to the other databases are of the 'unknown' zone.")
+	Command.PersistentFlags().StringVar(&loaderZone, "loader-zone", "", "Zone of this loader, of the latencies of '--endpoint-zones'. Empty for 'unknown'.")
+	Command.PersistentFlags().StringVar(&addressFamily, "address-family", "", "Address family that the endpoints must be reachable on, or the run aborts before the stress: ipv4 or ipv6. The family that each endpoint was reached on is logged either way. Empty for either.")
+	Command.PersistentFlags().StringVar(&operation, "operation", "", "Operation to send to the agents of '--database-id', to drive the database nodes remotely instead of running the steps of the configuration: "+strings.Join(operations, ", ")+". Empty to run the steps.")
+	Command.PersistentFlags().Int64Var(&maxResponseBytes, "max-response-bytes", 0, "Limit of the size of each response, over which the request fails as 'too-large' instead of growing the loader memory, such as of ranges over the whole key space (etcd clients refuse to receive them). The peak response size and loader heap are logged and saved to the summary either way. 0 for no limit.")
+	Command.PersistentFlags().StringSliceVar(&pdEndpoints, "pd-endpoints", nil, "PD endpoints of the TiKV cluster of 'tikv__v2_1', overriding 'tikv__v2_1.pd_endpoints'. Empty to use the configuration, or 'database_endpoints' if not set.")
+	Command.PersistentFlags().DurationVar(&progressInterval, "progress-interval", dbtester.DefaultProgressInterval, "Interval to print the progress of the stress, with the current throughput, the error rate and the ETA. 0 to not print.")
+	Command.PersistentFlags().IntVar(&maxLoaderCPUs, "max-loader-cpus", 0, "Number of CPUs to cap the loader to with GOMAXPROCS, when it shares the machine with other processes, recorded in the summary. 0 to not cap.")
+	Command.PersistentFlags().BoolVar(&loaderCgroup, "loader-cgroup", false, "'true' to also cap the loader to '--max-loader-cpus' with the CPU quota of its cgroup (v1 or v2), which requires the permission to write to the cgroup.")
+	Command.PersistentFlags().StringVar(&metricsAddr, "metrics-addr", "", "Address to serve the live progress of the stress at '/metrics' in Prometheus format (e.g. ':9100'), with the requests sent, the errors, the requests in flight and the latency quantiles of the last 10 seconds. Requests of '--workers' are not included. Empty to not serve.")
+	Command.PersistentFlags().StringVar(&timeseriesFile, "timeseries-file", "", "CSV file to write the completed requests, the errors and the average latency of every second to as the stress runs, to locate stalls (e.g. Zookeeper snapshot pauses) in time. Requests of '--workers' are not included. Empty to not write.")
+	Command.PersistentFlags().StringVar(&authUser, "user", "", "etcd user to authenticate as, overriding 'etcd_username'. Empty to use the configuration.")
+	Command.PersistentFlags().StringVar(&authPassword, "password", "", "Password of '--user', overriding 'etcd_password'.")
+	Command.PersistentFlags().StringVar(&consulToken, "consul-token", "", "Consul ACL token to send with every request, overriding 'consul_token'. Empty to use the configuration.")
+	Command.PersistentFlags().StringVar(&zkAuth, "zk-auth", "", "Zookeeper 'user:password' to authenticate with the digest scheme and to create the znodes with the digest ACL of, overriding 'zookeeper_digest_auth'. Empty to use the configuration.")
+	Command.PersistentFlags().BoolVar(&authOverhead, "auth-overhead", false, "'true' to run a baseline of the workload without credentials before the stress, and to save its average latency and the overhead of authentication to the summary. The cluster must accept anonymous requests (e.g. Consul with the 'allow' default ACL policy); use 'etcd_rbac' for etcd with auth enabled.")
 }
 
 func commandFunc(cmd *cobra.Command, args []string) error {
-	if !dbtesterpb.IsValidDatabaseID(databaseID) {
-		return fmt.Errorf("database id %q is unknown", databaseID)
+	if operation != "" {
+		cfg, err := readConfig()
+		if err != nil {
+			return err
+		}
+		return SendOperation(cfg, databaseID, operation)
 	}
+	return runUntilSignal(nil)
+}
 
+// readConfig reads the configuration file, overwritten by the flags.
+func readConfig() (*dbtester.Config, error) {
 	cfg, err := dbtester.ReadConfig(configPath, false)
 	if err != nil {
+		return nil, err
+	}
+	return cfg, applyFlags(cfg)
+}
+
+// applyFlags overwrites the configuration with the flags.
+func applyFlags(cfg *dbtester.Config) (err error) {
+	if !dbtesterpb.IsValidDatabaseID(databaseID) {
+		return fmt.Errorf("database id %q is unknown", databaseID)
+	}
+	if err = dbtester.SetClientTLS(certFile, keyFile, caFile); err != nil {
+		return err
+	}
+	cfg.Cooldown = cooldown
+	cfg.Force = force
+	cfg.ProfileDir = profileDir
+	cfg.ProfilePoints = profilePointsFlag
+	cfg.ProgressInterval = progressInterval
+	cfg.SaveKeysPath = saveKeysPath
+	cfg.KeysFromPath = keysFromPath
+	cfg.KeysPerRequest = keysPerRequest
+	cfg.RangeReads = rangeReads
+	cfg.OutputFormat = outputFormat
+	cfg.OutputFile = outputFile
+	validFormat := false
+	for _, f := range dbtester.OutputFormats {
+		validFormat = validFormat || f == outputFormat
+	}
+	if !validFormat {
+		return fmt.Errorf("unknown '--output-format' %q (expected %s)", outputFormat, strings.Join(dbtester.OutputFormats, ", "))
+	}
+	if latencyResolution < 1 || latencyResolution > 5 {
+		return fmt.Errorf("'--latency-resolution' must be in [1, 5] (got %d)", latencyResolution)
+	}
+	cfg.LatencyResolution = latencyResolution
+	if err = cfg.SetCompress(compress); err != nil {
+		return err
+	}
+	if expectMemberCount < 0 {
+		return fmt.Errorf("'--expect-member-count' must not be negative (got %d)", expectMemberCount)
+	}
+	cfg.ExpectClusterID = expectClusterID
+	cfg.ExpectMemberCount = expectMemberCount
+	validFamily := false
+	for _, f := range dbtester.AddressFamilies {
+		validFamily = validFamily || f == addressFamily
+	}
+	if !validFamily {
+		return fmt.Errorf("unknown '--address-family' %q (expected ipv4 or ipv6)", addressFamily)
+	}
+	cfg.AddressFamily = addressFamily
+	if cfg.EndpointZones, err = dbtester.ParseEndpointZones(endpointZones); err != nil {
 		return err
 	}
+	cfg.LoaderZone = loaderZone
+	if maxResponseBytes < 0 {
+		return fmt.Errorf("'--max-response-bytes' must not be negative (got %d)", maxResponseBytes)
+	}
+	cfg.MaxResponseBytes = maxResponseBytes
+	if maxLoaderCPUs < 0 {
+		return fmt.Errorf("'--max-loader-cpus' must not be negative (got %d)", maxLoaderCPUs)
+	}
+	if loaderCgroup && maxLoaderCPUs == 0 {
+		return fmt.Errorf("'--loader-cgroup' requires '--max-loader-cpus'")
+	}
+	if maxLoaderCPUs > 0 {
+		if err = cfg.LimitLoaderCPUs(maxLoaderCPUs, loaderCgroup); err != nil {
+			return err
+		}
+	}
+	if len(clusterA) > 0 || len(clusterB) > 0 {
+		if len(clusterA) == 0 || len(clusterB) == 0 {
+			return fmt.Errorf("both '--cluster-a' and '--cluster-b' are required")
+		}
+		cfg.ClusterEndpoints = map[string][]string{"a": clusterA, "b": clusterB}
+	}
+	if len(workers) > 0 {
+		if len(cfg.ClusterEndpoints) > 0 {
+			return fmt.Errorf("'--workers' and '--cluster-a' are exclusive")
+		}
+		cfg.Workers = workers
+	}
+	if opsPerTxn != 0 && readRatio != 0 {
+		return fmt.Errorf("'--ops-per-txn' and '--read-ratio' are exclusive")
+	}
+	if err = cfg.ApplyBenchmarkOverrides(databaseID, benchmarkOverrides()); err != nil {
+		return err
+	}
+	cfg.AuthOverhead = authOverhead
+	return nil
+}
+
+// benchmarkOverrides returns the flags that overwrite the configuration
+// of '--database-id'.
+func benchmarkOverrides() []dbtester.BenchmarkOverride {
+	atLeastSecond := func(d time.Duration) func() error {
+		return func() error {
+			if d < time.Second {
+				return fmt.Errorf("must be at least 1s (got %v)", d)
+			}
+			return nil
+		}
+	}
+	return []dbtester.BenchmarkOverride{
+		{
+			Flag: "'--read-ratio'",
+			Set:  readRatio != 0,
+			Validate: func() error {
+				if readRatio < 0 || readRatio >= 1 {
+					return fmt.Errorf("must be in (0, 1) (got %v)", readRatio)
+				}
+				return nil
+			},
+			Apply: func(gcfg *dbtesterpb.ConfigClientMachineAgentControl) {
+				gcfg.ConfigClientMachineBenchmarkOptions.Type = "read-write"
+				gcfg.ConfigClientMachineBenchmarkOptions.ReadPercent = int64(math.Round(readRatio * 100))
+			},
+		},
+		{
+			Flag: "'--rate'",
+			Set:  rateFlag != 0,
+			Validate: func() error {
+				if rateFlag < 0 {
+					return fmt.Errorf("must be positive (got %d)", rateFlag)
+				}
+				return nil
+			},
+			Apply: func(gcfg *dbtesterpb.ConfigClientMachineAgentControl) {
+				gcfg.ConfigClientMachineBenchmarkOptions.RateLimitRequestsPerSecond = rateFlag
+			},
+		},
+		{
+			Flag:     "'--duration'",
+			Set:      duration != 0,
+			Validate: atLeastSecond(duration),
+			Apply: func(gcfg *dbtesterpb.ConfigClientMachineAgentControl) {
+				gcfg.ConfigClientMachineBenchmarkOptions.DurationSeconds = int64(duration / time.Second)
+			},
+		},
+		{
+			Flag:     "'--ramp-up'",
+			Set:      rampUp != 0,
+			Validate: atLeastSecond(rampUp),
+			Apply: func(gcfg *dbtesterpb.ConfigClientMachineAgentControl) {
+				gcfg.ConfigClientMachineBenchmarkOptions.RampUpSeconds = int64(rampUp / time.Second)
+			},
+		},
+		{
+			Flag: "'--warmup-requests'",
+			Set:  warmupRequests != 0,
+			Apply: func(gcfg *dbtesterpb.ConfigClientMachineAgentControl) {
+				gcfg.ConfigClientMachineBenchmarkOptions.WarmupRequestNumber = warmupRequests
+			},
+		},
+		{
+			Flag:     "'--warmup-duration'",
+			Set:      warmupDuration != 0,
+			Validate: atLeastSecond(warmupDuration),
+			Apply: func(gcfg *dbtesterpb.ConfigClientMachineAgentControl) {
+				gcfg.ConfigClientMachineBenchmarkOptions.WarmupSeconds = int64(warmupDuration / time.Second)
+			},
+		},
+		{
+			Flag:     "'--auto-compact-every'",
+			Set:      autoCompactEvery != 0,
+			Validate: atLeastSecond(autoCompactEvery),
+			Apply: func(gcfg *dbtesterpb.ConfigClientMachineAgentControl) {
+				gcfg.ConfigClientMachineBenchmarkOptions.AutoCompactSeconds = int64(autoCompactEvery / time.Second)
+			},
+		},
+		{
+			Flag: "'--auto-defrag'",
+			Set:  autoDefrag,
+			Apply: func(gcfg *dbtesterpb.ConfigClientMachineAgentControl) {
+				gcfg.ConfigClientMachineBenchmarkOptions.AutoDefrag = true
+			},
+		},
+		{
+			Flag: "'--ops-per-txn'",
+			Set:  opsPerTxn != 0,
+			Validate: func() error {
+				if opsPerTxn < 0 {
+					return fmt.Errorf("must be positive (got %d)", opsPerTxn)
+				}
+				return nil
+			},
+			Apply: func(gcfg *dbtesterpb.ConfigClientMachineAgentControl) {
+				gcfg.ConfigClientMachineBenchmarkOptions.Type = "txn"
+				gcfg.ConfigClientMachineBenchmarkOptions.TxnKeyNumber = opsPerTxn
+			},
+		},
+		{
+			Flag: "'--key-dist'",
+			Set:  keyDist != "",
+			Apply: func(gcfg *dbtesterpb.ConfigClientMachineAgentControl) {
+				gcfg.ConfigClientMachineBenchmarkOptions.KeyDistribution = keyDist
+			},
+		},
+		{
+			Flag: "'--key-order'",
+			Set:  keyOrder != "",
+			Apply: func(gcfg *dbtesterpb.ConfigClientMachineAgentControl) {
+				gcfg.ConfigClientMachineBenchmarkOptions.KeyOrder = keyOrder
+			},
+		},
+		{
+			Flag: "'--preload'",
+			Set:  preload != 0,
+			Validate: func() error {
+				if preload < 0 {
+					return fmt.Errorf("must be positive (got %d)", preload)
+				}
+				if keysFromPath != "" {
+					return fmt.Errorf("'--keys-from' is exclusive")
+				}
+				return nil
+			},
+			Apply: func(gcfg *dbtesterpb.ConfigClientMachineAgentControl) {
+				gcfg.ConfigClientMachineBenchmarkOptions.Prepopulate = preload
+			},
+			// of the type that '--read-ratio' or '--ops-per-txn' may set
+			Check: func(gcfg dbtesterpb.ConfigClientMachineAgentControl) error {
+				switch typ := gcfg.ConfigClientMachineBenchmarkOptions.Type; typ {
+				case "read", "read-write", "mixed":
+					return nil
+				default:
+					return fmt.Errorf("only for 'read', 'read-write' and 'mixed' (got %q)", typ)
+				}
+			},
+		},
+		{
+			Flag: "'--etcd-ignore-value'",
+			Set:  etcdIgnoreValue,
+			Apply: func(gcfg *dbtesterpb.ConfigClientMachineAgentControl) {
+				gcfg.ConfigClientMachineBenchmarkOptions.EtcdIgnoreValue = true
+			},
+		},
+		{
+			Flag: "'--etcd-ignore-lease'",
+			Set:  etcdIgnoreLease,
+			Apply: func(gcfg *dbtesterpb.ConfigClientMachineAgentControl) {
+				gcfg.ConfigClientMachineBenchmarkOptions.EtcdIgnoreLease = true
+			},
+		},
+		{
+			Flag: "'--etcd-api-version'",
+			Set:  etcdAPIVersion != "",
+			Apply: func(gcfg *dbtesterpb.ConfigClientMachineAgentControl) {
+				gcfg.ConfigClientMachineBenchmarkOptions.EtcdAPIVersion = etcdAPIVersion
+			},
+		},
+		{
+			Flag: "'--pd-endpoints'",
+			Set:  len(pdEndpoints) > 0,
+			Validate: func() error {
+				if databaseID != "tikv__v2_1" {
+					return fmt.Errorf("only for 'tikv__v2_1' (got %q)", databaseID)
+				}
+				return nil
+			},
+			Apply: func(gcfg *dbtesterpb.ConfigClientMachineAgentControl) {
+				if gcfg.Flag_Tikv_V2_1 == nil {
+					gcfg.Flag_Tikv_V2_1 = &dbtesterpb.Flag_Tikv_V2_1{}
+				}
+				gcfg.Flag_Tikv_V2_1.PDEndpoints = pdEndpoints
+			},
+		},
+		{
+			Flag: "'--user'",
+			Set:  authUser != "",
+			Apply: func(gcfg *dbtesterpb.ConfigClientMachineAgentControl) {
+				gcfg.ConfigClientMachineBenchmarkOptions.EtcdUsername = authUser
+				gcfg.ConfigClientMachineBenchmarkOptions.EtcdPassword = authPassword
+			},
+		},
+		{
+			Flag: "'--password'",
+			Set:  authPassword != "",
+			Validate: func() error {
+				if authUser == "" {
+					return fmt.Errorf("requires '--user'")
+				}
+				return nil
+			},
+			// applied with '--user'
+		},
+		{
+			Flag: "'--consul-token'",
+			Set:  consulToken != "",
+			Apply: func(gcfg *dbtesterpb.ConfigClientMachineAgentControl) {
+				gcfg.ConfigClientMachineBenchmarkOptions.ConsulToken = consulToken
+			},
+		},
+		{
+			Flag: "'--zk-auth'",
+			Set:  zkAuth != "",
+			Apply: func(gcfg *dbtesterpb.ConfigClientMachineAgentControl) {
+				gcfg.ConfigClientMachineBenchmarkOptions.ZookeeperDigestAuth = zkAuth
+			},
+		},
+	}
+}
+
+// runUntilSignal runs the configured steps of the database as the only
+// step of 'RunSteps', with the configuration overwritten by the flags and
+// then by 'apply' if not nil, cancelling the requests in flight on SIGINT
+// or SIGTERM.
+func runUntilSignal(apply func(cfg *dbtester.Config) error) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	notifier := make(chan os.Signal, 1)
+	signal.Notify(notifier, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		select {
+		case sig := <-notifier:
+			// the next signal exits as usual
+			signal.Stop(notifier)
+			lg.Warn("received signal; cancelling the requests in flight", zap.String("signal", sig.String()))
+			cancel()
+		case <-ctx.Done():
+			signal.Stop(notifier)
+		}
+	}()
+
+	sq := Sequence{
+		ConfigPath:       configPath,
+		Force:            force,
+		DiskDevice:       diskDevice,
+		NetworkInterface: networkInterface,
+		Context:          ctx,
+	}
+	return RunSteps(sq, []Step{{
+		Name:       databaseID,
+		DatabaseID: databaseID,
+		Apply: func(cfg *dbtester.Config) error {
+			if err := applyFlags(cfg); err != nil || apply == nil {
+				return err
+			}
+			return apply(cfg)
+		},
+	}})
+}
+
+// Run starts the database, stresses it, and stops it, while collecting
+// client-side system metrics, as configured for the given database ID.
+func Run(cfg *dbtester.Config, databaseID, diskDevice, networkInterface string) (err error) {
 	gcfg, ok := cfg.DatabaseIDToConfigClientMachineAgentControl[databaseID]
 	if !ok {
 		return fmt.Errorf("%q is not found", databaseID)
@@ -86,9 +544,35 @@ func commandFunc(cmd *cobra.Command, args []string) error {
 		case "write":
 		case "read":
 		case "read-oneshot":
+		case "txn":
+		case "lock":
+		case "bank":
+		case "read-write":
+		case "mixed":
 		default:
 			return fmt.Errorf("%q is not supported", gcfg.ConfigClientMachineBenchmarkOptions.Type)
 		}
+
+		if len(cfg.ClusterEndpoints) > 0 && (gcfg.ConfigClientMachineBenchmarkOptions.ConfigClientMachineFailover != nil || gcfg.ConfigClientMachineBenchmarkOptions.MeasureRecovery) {
+			return fmt.Errorf("'failover' and 'measure_recovery' are not supported with '--cluster-a' and '--cluster-b'")
+		}
+		if len(cfg.Workers) > 0 && (gcfg.ConfigClientMachineBenchmarkOptions.ConfigClientMachineFailover != nil || gcfg.ConfigClientMachineBenchmarkOptions.MeasureRecovery) {
+			return fmt.Errorf("'failover' and 'measure_recovery' are not supported with '--workers'")
+		}
+
+		if err = cfg.CheckSizeLimits(databaseID); err != nil {
+			if !cfg.Force {
+				return fmt.Errorf("%v (use '--force' to run anyway)", err)
+			}
+			lg.Warn("running with sizes over the database limit", zap.Error(err))
+		}
+	}
+
+	var prof *profiler
+	if cfg.ProfileDir != "" {
+		if prof, err = newProfiler(cfg.ProfileDir, cfg.ProfilePoints); err != nil {
+			return err
+		}
 	}
 
 	pid := int64(os.Getpid())
@@ -167,9 +651,16 @@ func commandFunc(cmd *cobra.Command, args []string) error {
 	println()
 	if gcfg.ConfigClientMachineBenchmarkSteps.Step1StartDatabase {
 		lg.Info("step 1: starting databases...")
-		if _, err = cfg.BroadcaseRequest(databaseID, dbtesterpb.Operation_Start); err != nil {
+		cfg.Progress("step 1: starting databases")
+		var idxToResp map[int]dbtesterpb.Response
+		if idxToResp, err = cfg.BroadcaseRequest(databaseID, dbtesterpb.Operation_Start); err != nil {
 			return err
 		}
+		if cfg.ConfigClientMachineInitial.ClientBootstrapTimePath != "" {
+			if err = cfg.MeasureBootstrap(databaseID, "start", idxToResp); err != nil {
+				return err
+			}
+		}
 	}
 
 	if gcfg.ConfigClientMachineBenchmarkSteps.Step2StressDatabase {
@@ -177,16 +668,91 @@ func commandFunc(cmd *cobra.Command, args []string) error {
 		time.Sleep(5 * time.Second)
 		println()
 		lg.Info("step 2: starting tests...")
-		if err = cfg.Stress(databaseID); err != nil {
+		cfg.Progress("step 2: starting tests")
+		if _, err = cfg.CheckAddressFamily(databaseID); err != nil {
+			return err
+		}
+		if err = cfg.CheckClusterIdentity(databaseID); err != nil {
+			return err
+		}
+		if metricsAddr != "" {
+			stopMetrics, merr := cfg.ServeMetrics(metricsAddr, databaseID)
+			if merr != nil {
+				return merr
+			}
+			defer stopMetrics()
+		}
+		if timeseriesFile != "" {
+			stopTimeseries, terr := cfg.StreamTimeseries(timeseriesFile)
+			if terr != nil {
+				return terr
+			}
+			defer stopTimeseries()
+		}
+		if err = prof.heap("before-stress"); err != nil {
 			return err
 		}
+		if err = prof.startCPU(); err != nil {
+			return err
+		}
+		switch {
+		case len(cfg.ClusterEndpoints) > 0:
+			err = cfg.StressClusters(databaseID)
+		case len(cfg.Workers) > 0:
+			err = cfg.StressWorkers(databaseID)
+		default:
+			err = cfg.Stress(databaseID)
+		}
+		if perr := prof.stopCPU(); err == nil {
+			err = perr
+		}
+		if err == nil && cfg.Context != nil {
+			// the results so far are saved, but the run is not complete
+			err = cfg.Context.Err()
+		}
+		if err != nil {
+			return err
+		}
+		if err = prof.heap("after-stress"); err != nil {
+			return err
+		}
+
+		if gcfg.ConfigClientMachineBenchmarkOptions.ConfigClientMachineFailover != nil {
+			println()
+			lg.Info("step 2: measuring client failover...")
+			cfg.Progress("step 2: measuring client failover")
+			if err = cfg.MeasureFailover(databaseID); err != nil {
+				return err
+			}
+		}
+
+		if gcfg.ConfigClientMachineBenchmarkOptions.MeasureRecovery {
+			println()
+			lg.Info("step 2: restarting databases with data...")
+			cfg.Progress("step 2: restarting databases with data")
+			if _, err = cfg.BroadcaseRequest(databaseID, dbtesterpb.Operation_Shutdown); err != nil {
+				return err
+			}
+			var idxToResp map[int]dbtesterpb.Response
+			if idxToResp, err = cfg.BroadcaseRequest(databaseID, dbtesterpb.Operation_Restart); err != nil {
+				return err
+			}
+			if err = cfg.MeasureBootstrap(databaseID, "restart", idxToResp); err != nil {
+				return err
+			}
+		}
 	}
 
 	if gcfg.ConfigClientMachineBenchmarkSteps.Step3StopDatabase {
 		println()
+		if cfg.Cooldown > 0 {
+			lg.Info("cooling down before stopping databases", zap.Duration("cooldown", cfg.Cooldown))
+			time.Sleep(cfg.Cooldown)
+		}
 		time.Sleep(5 * time.Second)
 		println()
 		lg.Info("step 3: stopping tests...")
+		cfg.Progress("step 3: stopping tests")
 		var idxToResp map[int]dbtesterpb.Response
 		for i := 0; i < 5; i++ {
 			idxToResp, err = cfg.BroadcaseRequest(databaseID, dbtesterpb.Operation_Stop)
@@ -210,14 +776,41 @@ func commandFunc(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	if err = prof.heap("after-stop"); err != nil {
+		return err
+	}
+
 	close(donec)
 	<-sysdonec
 
-	if gcfg.ConfigClientMachineBenchmarkSteps.Step4UploadLogs {
+	if gcfg.ConfigClientMachineBenchmarkSteps.Step4UploadLogs && (len(cfg.ClusterEndpoints) > 0 || len(cfg.Workers) > 0) {
+		println()
+		time.Sleep(3 * time.Second)
+		println()
+		lg.Info("step 4: uploading logs of clusters or workers...")
+		cfg.Progress("step 4: uploading logs")
+		paths := []string{
+			cfg.ConfigClientMachineInitial.LogPath,
+			cfg.ConfigClientMachineInitial.ClientSystemMetricsPath,
+			cfg.ConfigClientMachineInitial.ClientSystemMetricsInterpolatedPath,
+		}
+		if prof != nil {
+			paths = append(paths, prof.paths...)
+		}
+		paths = append(paths, cfg.ClusterResultPaths()...)
+		for _, fpath := range append(paths, cfg.WorkerResultPaths()...) {
+			if err = cfg.UploadToGoogle(databaseID, fpath); err != nil {
+				return err
+			}
+		}
+	}
+
+	if gcfg.ConfigClientMachineBenchmarkSteps.Step4UploadLogs && len(cfg.ClusterEndpoints) == 0 && len(cfg.Workers) == 0 {
 		println()
 		time.Sleep(3 * time.Second)
 		println()
 		lg.Info("step 4: uploading logs...")
+		cfg.Progress("step 4: uploading logs")
 		if err = cfg.UploadToGoogle(databaseID, cfg.ConfigClientMachineInitial.LogPath); err != nil {
 			return err
 		}
@@ -245,6 +838,134 @@ func commandFunc(cmd *cobra.Command, args []string) error {
 		if err = cfg.UploadToGoogle(databaseID, cfg.ConfigClientMachineInitial.ServerDiskSpaceUsageSummaryPath); err != nil {
 			return err
 		}
+		if gcfg.ConfigClientMachineBenchmarkOptions.TraceSampleNumber > 0 && cfg.ConfigClientMachineInitial.ClientRequestTraceSamplePath != "" {
+			if err = cfg.UploadToGoogle(databaseID, cfg.ConfigClientMachineInitial.ClientRequestTraceSamplePath); err != nil {
+				return err
+			}
+		}
+		if gcfg.ConfigClientMachineBenchmarkOptions.ConfigClientMachineHealthRouting != nil && cfg.ConfigClientMachineInitial.ClientEndpointTrafficPath != "" {
+			if err = cfg.UploadToGoogle(databaseID, cfg.ConfigClientMachineInitial.ClientEndpointTrafficPath); err != nil {
+				return err
+			}
+		}
+		if cfg.ConfigClientMachineInitial.ClientBootstrapTimePath != "" {
+			if err = cfg.UploadToGoogle(databaseID, cfg.ConfigClientMachineInitial.ClientBootstrapTimePath); err != nil {
+				return err
+			}
+		}
+		if gcfg.ConfigClientMachineBenchmarkOptions.ConfigClientMachineFailover != nil && cfg.ConfigClientMachineInitial.ClientFailoverPath != "" {
+			if err = cfg.UploadToGoogle(databaseID, cfg.ConfigClientMachineInitial.ClientFailoverPath); err != nil {
+				return err
+			}
+		}
+		if gcfg.ConfigClientMachineBenchmarkOptions.ConfigClientMachineRollingRestart != nil && cfg.ConfigClientMachineInitial.ClientRollingRestartPath != "" {
+			if err = cfg.UploadToGoogle(databaseID, cfg.ConfigClientMachineInitial.ClientRollingRestartPath); err != nil {
+				return err
+			}
+		}
+		if gcfg.ConfigClientMachineBenchmarkOptions.Chaos != "" && cfg.ConfigClientMachineInitial.ClientChaosPath != "" {
+			if err = cfg.UploadToGoogle(databaseID, cfg.ConfigClientMachineInitial.ClientChaosPath); err != nil {
+				return err
+			}
+		}
+		if prof != nil {
+			for _, fpath := range prof.paths {
+				if err = cfg.UploadToGoogle(databaseID, fpath); err != nil {
+					return err
+				}
+			}
+		}
+		opts := gcfg.ConfigClientMachineBenchmarkOptions
+		if opts.RequestTimeoutMilliseconds > 0 && opts.RateLimitRequestsPerSecond > 0 && opts.Type != "mixed" && len(opts.ConnectionClientNumbers) == 0 && cfg.ConfigClientMachineInitial.ClientSchedulePath != "" {
+			if err = cfg.UploadToGoogle(databaseID, cfg.ConfigClientMachineInitial.ClientSchedulePath); err != nil {
+				return err
+			}
+		}
+		if gcfg.ConfigClientMachineBenchmarkOptions.ConfigClientMachineIdentityLease != nil && cfg.ConfigClientMachineInitial.ClientIdentityLeasePath != "" {
+			if err = cfg.UploadToGoogle(databaseID, cfg.ConfigClientMachineInitial.ClientIdentityLeasePath); err != nil {
+				return err
+			}
+		}
+		if gcfg.ConfigClientMachineBenchmarkOptions.ConfigClientMachineLearnerReads != nil && cfg.ConfigClientMachineInitial.ClientLearnerReadsPath != "" {
+			if err = cfg.UploadToGoogle(databaseID, cfg.ConfigClientMachineInitial.ClientLearnerReadsPath); err != nil {
+				return err
+			}
+		}
+		if gcfg.ConfigClientMachineBenchmarkOptions.ConfigClientMachineTLSHandshake != nil && cfg.ConfigClientMachineInitial.ClientTLSHandshakePath != "" {
+			if err = cfg.UploadToGoogle(databaseID, cfg.ConfigClientMachineInitial.ClientTLSHandshakePath); err != nil {
+				return err
+			}
+		}
+		if gcfg.ConfigClientMachineBenchmarkOptions.ConfigClientMachineEtcdMetrics != nil && cfg.ConfigClientMachineInitial.ClientServerLatencyCorrelationPath != "" {
+			if err = cfg.UploadToGoogle(databaseID, cfg.ConfigClientMachineInitial.ClientServerLatencyCorrelationPath); err != nil {
+				return err
+			}
+		}
+		if gcfg.ConfigClientMachineBenchmarkOptions.ConfigClientMachineConvergenceProbe != nil && cfg.ConfigClientMachineInitial.ClientConvergencePath != "" {
+			if err = cfg.UploadToGoogle(databaseID, cfg.ConfigClientMachineInitial.ClientConvergencePath); err != nil {
+				return err
+			}
+		}
+		if gcfg.ConfigClientMachineBenchmarkOptions.ConfigClientMachineConvergenceProbe != nil && cfg.ConfigClientMachineInitial.ClientConvergenceSummaryPath != "" {
+			if err = cfg.UploadToGoogle(databaseID, cfg.ConfigClientMachineInitial.ClientConvergenceSummaryPath); err != nil {
+				return err
+			}
+		}
+		if cfg.ConfigClientMachineInitial.ClientSizeHistogramPath != "" {
+			if err = cfg.UploadToGoogle(databaseID, cfg.ConfigClientMachineInitial.ClientSizeHistogramPath); err != nil {
+				return err
+			}
+		}
+		if cfg.ConfigClientMachineInitial.ClientLatencyHgrmPath != "" {
+			if err = cfg.UploadToGoogle(databaseID, cfg.ConfigClientMachineInitial.ClientLatencyHgrmPath); err != nil {
+				return err
+			}
+		}
+		if cfg.ConfigClientMachineInitial.ClientMemberBreakdownPath != "" {
+			if err = cfg.UploadToGoogle(databaseID, cfg.ConfigClientMachineInitial.ClientMemberBreakdownPath); err != nil {
+				return err
+			}
+		}
+		if cfg.ConfigClientMachineInitial.ClientTermChangePath != "" {
+			if err = cfg.UploadToGoogle(databaseID, cfg.ConfigClientMachineInitial.ClientTermChangePath); err != nil {
+				return err
+			}
+		}
+		if len(cfg.EndpointZones) > 0 && cfg.ConfigClientMachineInitial.ClientZoneLatencyPath != "" {
+			if err = cfg.UploadToGoogle(databaseID, cfg.ConfigClientMachineInitial.ClientZoneLatencyPath); err != nil {
+				return err
+			}
+		}
+		if cfg.ConfigClientMachineInitial.ClientSocketStatsPath != "" {
+			if err = cfg.UploadToGoogle(databaseID, cfg.ConfigClientMachineInitial.ClientSocketStatsPath); err != nil {
+				return err
+			}
+		}
+		if gcfg.ConfigClientMachineBenchmarkOptions.AutoCompactSeconds > 0 && cfg.ConfigClientMachineInitial.ClientMaintenancePath != "" {
+			if err = cfg.UploadToGoogle(databaseID, cfg.ConfigClientMachineInitial.ClientMaintenancePath); err != nil {
+				return err
+			}
+		}
+		if gcfg.ConfigClientMachineBenchmarkOptions.ConfigClientMachineResourceMonitor != nil && cfg.ConfigClientMachineInitial.ClientResourceUsagePath != "" {
+			if err = cfg.UploadToGoogle(databaseID, cfg.ConfigClientMachineInitial.ClientResourceUsagePath); err != nil {
+				return err
+			}
+		}
+		if gcfg.ConfigClientMachineBenchmarkOptions.ConfigClientMachineSpikeRecovery != nil && cfg.ConfigClientMachineInitial.ClientSpikeRecoveryPath != "" {
+			if err = cfg.UploadToGoogle(databaseID, cfg.ConfigClientMachineInitial.ClientSpikeRecoveryPath); err != nil {
+				return err
+			}
+		}
+		if len(gcfg.ConfigClientMachineBenchmarkOptions.ConfigClientMachineWorkloads) > 0 && cfg.ConfigClientMachineInitial.ClientWorkloadSummaryPath != "" {
+			if err = cfg.UploadToGoogle(databaseID, cfg.ConfigClientMachineInitial.ClientWorkloadSummaryPath); err != nil {
+				return err
+			}
+		}
+		if gcfg.ConfigClientMachineBenchmarkOptions.MeasureInterference && cfg.ConfigClientMachineInitial.ClientInterferencePath != "" {
+			if err = cfg.UploadToGoogle(databaseID, cfg.ConfigClientMachineInitial.ClientInterferencePath); err != nil {
+				return err
+			}
+		}
 	}
 
 	lg.Info("all done!")
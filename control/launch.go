@@ -0,0 +1,139 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package control
+
+import (
+	"fmt"
+
+	"github.com/coreos/dbtester"
+	"github.com/coreos/dbtester/dbtesterpb"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+var launchCommand = &cobra.Command{
+	Use:   "launch",
+	Short: "Launches a fresh cluster of a release, benchmarks it, and tears it down.",
+	Long: `Launches a fresh cluster of '--database-id' on the agents of the peer
+IPs, with the release '--database-version' downloaded by each agent,
+runs the benchmark of the configuration, and tears the cluster down:
+the database is stopped, its data directory is removed, and the release
+is removed from the agents, also when the benchmark fails.
+
+The logs of the database and the agent are uploaded on stop as
+configured by 'step4_upload_logs'.`,
+	RunE: launchCommandFunc,
+}
+
+var launchVersion string
+var launchPeerIPs []string
+var launchClusterSize int
+
+func init() {
+	launchCommand.Flags().StringVar(&launchVersion, "database-version", "", "Release of the database to install on the agents (e.g. '3.3.0' for etcd, '1.0.2' for Consul, '3.5.3-beta' for Zookeeper).")
+	launchCommand.Flags().StringSliceVar(&launchPeerIPs, "peer-ips", nil, "IPs of the agents to launch the cluster on, overriding 'peer_ips'. Empty to use the configuration.")
+	launchCommand.Flags().IntVar(&launchClusterSize, "cluster-size", 0, "Number of the members of the cluster, on the first of the peer IPs. 0 for all peer IPs.")
+	Command.AddCommand(launchCommand)
+}
+
+func launchCommandFunc(cmd *cobra.Command, args []string) (err error) {
+	if launchVersion == "" {
+		return fmt.Errorf("'--database-version' is required")
+	}
+	configure := func(cfg *dbtester.Config) error {
+		return configureCluster(cfg, databaseID, launchVersion, launchPeerIPs, launchClusterSize)
+	}
+	cfg, err := readConfig()
+	if err != nil {
+		return err
+	}
+	if err = configure(cfg); err != nil {
+		return err
+	}
+	if err = launchCluster(cfg, databaseID); err != nil {
+		return err
+	}
+	defer func() {
+		teardownCluster(cfg, databaseID, err != nil)
+	}()
+
+	// the run reads the configuration again, as each step does
+	return runUntilSignal(configure)
+}
+
+// configureCluster configures the database to start on the first 'size'
+// peer IPs, with the release of 'version'.
+func configureCluster(cfg *dbtester.Config, databaseID, version string, peerIPs []string, size int) error {
+	gcfg, ok := cfg.DatabaseIDToConfigClientMachineAgentControl[databaseID]
+	if !ok {
+		return fmt.Errorf("%q is not found", databaseID)
+	}
+	if len(peerIPs) == 0 {
+		peerIPs = gcfg.PeerIPs
+	}
+	switch {
+	case size < 0:
+		return fmt.Errorf("'--cluster-size' must not be negative (got %d)", size)
+	case size > len(peerIPs):
+		return fmt.Errorf("'--cluster-size' %d is larger than the %d peer IPs", size, len(peerIPs))
+	case size > 0:
+		peerIPs = peerIPs[:size]
+	}
+	if err := cfg.SetPeerIPs(databaseID, peerIPs); err != nil {
+		return err
+	}
+
+	// the cluster is of this run only
+	gcfg = cfg.DatabaseIDToConfigClientMachineAgentControl[databaseID]
+	if gcfg.ConfigClientMachineBenchmarkSteps == nil {
+		gcfg.ConfigClientMachineBenchmarkSteps = &dbtesterpb.ConfigClientMachineBenchmarkSteps{Step2StressDatabase: true}
+	}
+	gcfg.ConfigClientMachineBenchmarkSteps.Step1StartDatabase = true
+	gcfg.ConfigClientMachineBenchmarkSteps.Step3StopDatabase = true
+	cfg.DatabaseIDToConfigClientMachineAgentControl[databaseID] = gcfg
+	cfg.DatabaseVersion = version
+	return nil
+}
+
+// launchCluster installs the release of the configured cluster on its agents.
+func launchCluster(cfg *dbtester.Config, databaseID string) error {
+	gcfg := cfg.DatabaseIDToConfigClientMachineAgentControl[databaseID]
+	lg.Info("installing release", zap.String("database-id", databaseID), zap.String("version", cfg.DatabaseVersion), zap.Strings("peer-ips", gcfg.PeerIPs))
+	if err := SendOperation(cfg, databaseID, "install"); err != nil {
+		// some agents may have installed the release
+		if uerr := SendOperation(cfg, databaseID, "uninstall"); uerr != nil {
+			lg.Warn("failed to uninstall", zap.Error(uerr))
+		}
+		return err
+	}
+	return nil
+}
+
+// teardownCluster removes the data of the database and the release from
+// the agents, after shutting the database down if the benchmark failed.
+func teardownCluster(cfg *dbtester.Config, databaseID string, failed bool) {
+	lg.Info("tearing down cluster", zap.String("database-id", databaseID), zap.Bool("failed", failed))
+	ops := []string{"wipe", "uninstall"}
+	if failed {
+		// the database may be running, or not started yet
+		ops = append([]string{"shutdown"}, ops...)
+	}
+	for _, op := range ops {
+		if err := SendOperation(cfg, databaseID, op); err != nil {
+			lg.Warn("failed to tear down", zap.String("operation", op), zap.Error(err))
+		}
+	}
+}
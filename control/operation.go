@@ -0,0 +1,94 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package control
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/coreos/dbtester"
+	"github.com/coreos/dbtester/dbtesterpb"
+
+	"go.uber.org/zap"
+)
+
+// operations are the operations that 'control --operation' sends to
+// the agents, to drive the database nodes without running the steps.
+var operations = []string{"start", "stop", "shutdown", "restart", "wipe", "metrics", "heal", "install", "uninstall"}
+
+// parseOperation returns the agent operation of the name.
+func parseOperation(name string) (dbtesterpb.Operation, error) {
+	for _, op := range operations {
+		if strings.EqualFold(name, op) {
+			return dbtesterpb.Operation(dbtesterpb.Operation_value[strings.Title(op)]), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown operation %q (must be one of %s)", name, strings.Join(operations, ", "))
+}
+
+// SendOperation sends the operation to all agents of the database,
+// and logs their responses.
+func SendOperation(cfg *dbtester.Config, databaseID, name string) error {
+	op, err := parseOperation(name)
+	if err != nil {
+		return err
+	}
+	gcfg, ok := cfg.DatabaseIDToConfigClientMachineAgentControl[databaseID]
+	if !ok {
+		return fmt.Errorf("%q is not found", databaseID)
+	}
+	if len(gcfg.AgentEndpoints) == 0 {
+		return fmt.Errorf("%q has no agent to send %q to", databaseID, op)
+	}
+
+	lg.Info("sending operation to agents", zap.String("operation", op.String()), zap.Strings("agents", gcfg.AgentEndpoints))
+	idxToResp, err := cfg.BroadcaseRequest(databaseID, op)
+	if err != nil {
+		return err
+	}
+	idxs := make([]int, 0, len(idxToResp))
+	for idx := range idxToResp {
+		idxs = append(idxs, idx)
+	}
+	sort.Ints(idxs)
+	for _, idx := range idxs {
+		resp := idxToResp[idx]
+		fields := []zap.Field{
+			zap.String("agent", gcfg.AgentEndpoints[idx]),
+			zap.String("operation", op.String()),
+			zap.Bool("success", resp.Success),
+		}
+		switch op {
+		case dbtesterpb.Operation_Metrics:
+			fields = append(fields,
+				zap.Float64("cpu-percent", resp.CPUPercent),
+				zap.Int64("vmrss-bytes", resp.VMRSSBytes),
+				zap.Int64("disk-space-usage-bytes", resp.DiskSpaceUsageBytes),
+				zap.Int64("disk-read-bytes", resp.DiskReadBytes),
+				zap.Int64("disk-write-bytes", resp.DiskWriteBytes),
+				zap.Int64("network-receive-bytes", resp.NetworkReceiveBytes),
+				zap.Int64("network-transmit-bytes", resp.NetworkTransmitBytes),
+			)
+		case dbtesterpb.Operation_Stop:
+			fields = append(fields, zap.Int64("disk-space-usage-bytes", resp.DiskSpaceUsageBytes))
+		case dbtesterpb.Operation_Start, dbtesterpb.Operation_Restart:
+			fields = append(fields, zap.Time("database-started", time.Unix(0, resp.DatabaseStartUnixNanosecond)))
+		}
+		lg.Info("agent response", fields...)
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package control
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/pprof"
+	"strings"
+
+	"github.com/coreos/dbtester/pkg/flamegraph"
+
+	"go.uber.org/zap"
+)
+
+// profilePoints are the points in 'Run' to capture the heap profiles at.
+var profilePoints = []string{"before-stress", "after-stress", "after-stop"}
+
+// profiler captures the CPU profile of the stress step, and the
+// heap profiles at the configured points, with their folded stacks.
+type profiler struct {
+	dir    string
+	points map[string]bool
+
+	cpuPath string
+	cpuFile *os.File
+
+	// paths are the saved profiles, to upload
+	paths []string
+}
+
+func newProfiler(dir string, points []string) (*profiler, error) {
+	p := &profiler{dir: dir, points: make(map[string]bool)}
+	for _, pt := range points {
+		valid := false
+		for _, v := range profilePoints {
+			valid = valid || pt == v
+		}
+		if !valid {
+			return nil, fmt.Errorf("unknown profile point %q (must be one of %s)", pt, strings.Join(profilePoints, ", "))
+		}
+		p.points[pt] = true
+	}
+	if err := os.MkdirAll(dir, 0777); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (p *profiler) startCPU() error {
+	if p == nil {
+		return nil
+	}
+	p.cpuPath = filepath.Join(p.dir, "cpu.pb.gz")
+	f, err := os.Create(p.cpuPath)
+	if err != nil {
+		return err
+	}
+	if err = pprof.StartCPUProfile(f); err != nil {
+		f.Close()
+		return err
+	}
+	p.cpuFile = f
+	lg.Info("started CPU profile", zap.String("path", p.cpuPath))
+	return nil
+}
+
+func (p *profiler) stopCPU() error {
+	if p == nil || p.cpuFile == nil {
+		return nil
+	}
+	pprof.StopCPUProfile()
+	err := p.cpuFile.Close()
+	p.cpuFile = nil
+	if err != nil {
+		return err
+	}
+	return p.save(p.cpuPath, "cpu")
+}
+
+// heap captures the heap profile, if 'point' is configured.
+func (p *profiler) heap(point string) error {
+	if p == nil || !p.points[point] {
+		return nil
+	}
+	runtime.GC()
+	fpath := filepath.Join(p.dir, fmt.Sprintf("heap-%s.pb.gz", point))
+	f, err := os.Create(fpath)
+	if err != nil {
+		return err
+	}
+	if err = pprof.Lookup("heap").WriteTo(f, 0); err != nil {
+		f.Close()
+		return err
+	}
+	if err = f.Close(); err != nil {
+		return err
+	}
+	return p.save(fpath, "inuse_space")
+}
+
+// save folds the profile for flamegraph.pl, next to the profile.
+func (p *profiler) save(fpath, sampleType string) error {
+	src, err := os.Open(fpath)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	foldedPath := strings.TrimSuffix(fpath, ".pb.gz") + ".folded"
+	dst, err := os.Create(foldedPath)
+	if err != nil {
+		return err
+	}
+	if err = flamegraph.Fold(src, dst, sampleType); err != nil {
+		dst.Close()
+		return err
+	}
+	if err = dst.Close(); err != nil {
+		return err
+	}
+	p.paths = append(p.paths, fpath, foldedPath)
+	lg.Info("saved profile", zap.String("path", fpath), zap.String("folded-path", foldedPath))
+	return nil
+}
@@ -0,0 +1,120 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package control
+
+import (
+	"time"
+
+	"github.com/coreos/dbtester"
+
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+)
+
+// Step is one run of a sequence of runs, such as a phase of a workload,
+// a combination of a matrix, or a benchmark of one database.
+type Step struct {
+	Name       string
+	DatabaseID string
+	// Apply overwrites the base configuration for the step, if not nil.
+	Apply func(cfg *dbtester.Config) error
+	// Run runs the step with its configuration, or nil to run 'control'
+	// for the database of the step.
+	Run func(cfg *dbtester.Config) error
+	// Row returns the result of the step for the combined CSV,
+	// or nil to not save it, as of a warmup. Steps that print their
+	// results instead leave it nil.
+	Row func(cfg *dbtester.Config) ([]string, error)
+}
+
+// Sequence configures the runs of the steps.
+type Sequence struct {
+	// ConfigPath is the base control configuration, read again for each
+	// step. Empty to start each step from an empty configuration.
+	ConfigPath string
+	// Cooldown is the pause between the steps.
+	Cooldown time.Duration
+	Force    bool
+
+	DiskDevice       string
+	NetworkInterface string
+
+	// Context cancels the runs of the steps, if not nil.
+	Context context.Context
+
+	// Columns are the header of the combined CSV.
+	Columns []string
+	// OutputPathCSV is the combined CSV, empty to not save it.
+	OutputPathCSV string
+}
+
+// RunSteps runs the steps in order, each with the base configuration
+// overwritten by the step, and saves the rows of the results to the
+// combined CSV after each step, so that finished results are kept on
+// failures.
+func RunSteps(sq Sequence, steps []Step) error {
+	rows := [][]string{sq.Columns}
+	for i, st := range steps {
+		if i > 0 && sq.Cooldown > 0 {
+			lg.Info("cooling down before next step", zap.Duration("cooldown", sq.Cooldown))
+			time.Sleep(sq.Cooldown)
+		}
+
+		cfg := &dbtester.Config{}
+		if sq.ConfigPath != "" {
+			var err error
+			if cfg, err = dbtester.ReadConfig(sq.ConfigPath, false); err != nil {
+				return err
+			}
+		}
+		if st.Apply != nil {
+			if err := st.Apply(cfg); err != nil {
+				return err
+			}
+		}
+		// the cooldown is slept between the steps, not again in each run
+		cfg.Force = sq.Force
+		if sq.Context != nil {
+			cfg.Context = sq.Context
+		}
+
+		lg.Info("running step", zap.Int("index", i+1), zap.Int("total", len(steps)), zap.String("name", st.Name))
+		run := st.Run
+		if run == nil {
+			run = func(cfg *dbtester.Config) error {
+				return Run(cfg, st.DatabaseID, sq.DiskDevice, sq.NetworkInterface)
+			}
+		}
+		if err := run(cfg); err != nil {
+			return err
+		}
+
+		if st.Row == nil || sq.OutputPathCSV == "" {
+			continue
+		}
+		row, err := st.Row(cfg)
+		if err != nil {
+			return err
+		}
+		if row == nil {
+			continue
+		}
+		rows = append(rows, row)
+		if err = dbtester.WriteCSV(sq.OutputPathCSV, rows); err != nil {
+			return err
+		}
+	}
+	return nil
+}
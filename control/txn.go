@@ -0,0 +1,43 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package control
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var txnCommand = &cobra.Command{
+	Use:   "txn",
+	Short: "Benchmarks multi-key transactions.",
+	Long: `Runs a 'txn' benchmark of the database of the configuration, of
+'--ops-per-txn' keys per transaction: etcd v3 transactions of compare
+and put, Consul 'Txn', and Zookeeper 'Multi'. Run it with the same
+configuration for each '--database-id' to compare the transactional
+throughput across the databases.`,
+	RunE: txnCommandFunc,
+}
+
+func init() {
+	Command.AddCommand(txnCommand)
+}
+
+func txnCommandFunc(cmd *cobra.Command, args []string) error {
+	if opsPerTxn <= 0 {
+		return fmt.Errorf("'--ops-per-txn' must be positive (got %d)", opsPerTxn)
+	}
+	return runUntilSignal(nil)
+}
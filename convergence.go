@@ -0,0 +1,396 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/coreos/dbtester/dbtesterpb"
+
+	"github.com/coreos/etcd/clientv3"
+	"github.com/gyuho/dataframe"
+	consulapi "github.com/hashicorp/consul/api"
+	"github.com/samuel/go-zookeeper/zk"
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+)
+
+const (
+	// convergenceTimeout is the default maximum time
+	// to wait for an endpoint to serve the new value.
+	convergenceTimeout = time.Minute
+	// convergencePollInterval is the interval between stale reads.
+	convergencePollInterval = time.Millisecond
+)
+
+// convergenceSample is the time for one endpoint
+// to serve the value written by a probe.
+type convergenceSample struct {
+	probe    int
+	endpoint string
+	written  time.Time
+	took     time.Duration
+	// lastContact is the time since the endpoint last heard from the
+	// leader, as reported by the endpoint (Consul only).
+	lastContact time.Duration
+	err         string
+}
+
+// convergenceProbe writes a marker value after a burst of writes, and
+// measures how long until each endpoint serves it with stale reads.
+type convergenceProbe struct {
+	lg      *zap.Logger
+	timeout time.Duration
+
+	endpoints []string
+	write     func(ctx context.Context, v []byte) error
+	// reads are the stale reads of the marker from each endpoint,
+	// with the time since the endpoint last heard from the leader if known
+	reads []func(ctx context.Context) ([]byte, time.Duration, error)
+	close func()
+
+	mu      sync.Mutex
+	probeN  int
+	samples []convergenceSample
+}
+
+func newConvergenceProbe(lg *zap.Logger, gcfg dbtesterpb.ConfigClientMachineAgentControl) (*convergenceProbe, error) {
+	p := &convergenceProbe{lg: lg, timeout: convergenceTimeout, endpoints: gcfg.DatabaseEndpoints}
+	if s := gcfg.ConfigClientMachineBenchmarkOptions.ConfigClientMachineConvergenceProbe.TimeoutSeconds; s > 0 {
+		p.timeout = time.Duration(s) * time.Second
+	}
+	wi := gcfg.ConfigClientMachineBenchmarkOptions.ConfigClientMachineConvergenceProbe.WriteEndpointIndex
+	if gcfg.DatabaseID != "mock" && (wi < 0 || wi >= int64(len(gcfg.DatabaseEndpoints))) {
+		return nil, fmt.Errorf("'write_endpoint_index' %d is out of range of %d endpoints", wi, len(gcfg.DatabaseEndpoints))
+	}
+
+	key := namespaced(gcfg, "dbtester-convergence-probe")
+	switch gcfg.DatabaseID {
+	case "etcd__other", "etcd__tip", "etcd__v3_2", "etcd__v3_3":
+		writer := mustCreateConnEtcdv3([]string{gcfg.DatabaseEndpoints[wi]})
+		clients := []*clientv3.Client{writer}
+		p.write = func(ctx context.Context, v []byte) error {
+			_, err := writer.Put(ctx, key, string(v))
+			return err
+		}
+		for _, ep := range gcfg.DatabaseEndpoints {
+			cli := mustCreateConnEtcdv3([]string{ep})
+			clients = append(clients, cli)
+			p.reads = append(p.reads, func(ctx context.Context) ([]byte, time.Duration, error) {
+				resp, err := cli.Get(ctx, key, clientv3.WithSerializable())
+				if err != nil || len(resp.Kvs) == 0 {
+					return nil, 0, err
+				}
+				return resp.Kvs[0].Value, 0, nil
+			})
+		}
+		p.close = func() {
+			for _, cli := range clients {
+				cli.Close()
+			}
+		}
+
+	case "zookeeper__r3_5_3_beta", "zetcd__beta":
+		var conns []*zk.Conn
+		for _, ep := range gcfg.DatabaseEndpoints {
+			conn := mustCreateConnsZk([]string{ep}, 1)[0]
+			conns = append(conns, conn)
+			// without sync, Zookeeper reads are served locally
+			p.reads = append(p.reads, func(ctx context.Context) ([]byte, time.Duration, error) {
+				v, _, err := conn.Get("/" + key)
+				if err == zk.ErrNoNode {
+					return nil, 0, nil
+				}
+				return v, 0, err
+			})
+		}
+		p.write = func(ctx context.Context, v []byte) error {
+			_, err := conns[wi].Set("/"+key, v, -1)
+			if err == zk.ErrNoNode {
+				_, err = conns[wi].Create("/"+key, v, zkCreateFlags, zkCreateACL)
+			}
+			return err
+		}
+		p.close = func() {
+			for _, conn := range conns {
+				conn.Close()
+			}
+		}
+
+	case "consul__v1_0_2", "cetcd__beta":
+		writer := mustCreateConnsConsul([]string{gcfg.DatabaseEndpoints[wi]}, 1)[0]
+		p.write = func(ctx context.Context, v []byte) error {
+			_, err := writer.Put(&consulapi.KVPair{Key: key, Value: v}, nil)
+			return err
+		}
+		for _, ep := range gcfg.DatabaseEndpoints {
+			conn := mustCreateConnsConsul([]string{ep}, 1)[0]
+			// a stale read is served by any server, which reports
+			// how long ago it last heard from the leader
+			p.reads = append(p.reads, func(ctx context.Context) ([]byte, time.Duration, error) {
+				kv, meta, err := conn.Get(key, &consulapi.QueryOptions{AllowStale: true})
+				if err != nil {
+					return nil, 0, err
+				}
+				if kv == nil {
+					return nil, meta.LastContact, nil
+				}
+				return kv.Value, meta.LastContact, nil
+			})
+		}
+		p.close = func() {}
+
+	case "mock":
+		p.endpoints = []string{"mock"}
+		p.write = func(ctx context.Context, v []byte) error {
+			mockDB.put(key, v)
+			return nil
+		}
+		p.reads = append(p.reads, func(ctx context.Context) ([]byte, time.Duration, error) {
+			v, _ := mockDB.get(key)
+			return v, 0, nil
+		})
+		p.close = func() {}
+
+	default:
+		return nil, fmt.Errorf("'convergence_probe' is not supported for %q", gcfg.DatabaseID)
+	}
+	return p, nil
+}
+
+// measure writes a new marker value, and waits until all
+// endpoints serve it, or until the timeout.
+func (p *convergenceProbe) measure() {
+	p.mu.Lock()
+	p.probeN++
+	n := p.probeN
+	p.mu.Unlock()
+
+	v := []byte(fmt.Sprintf("%d-%d", n, time.Now().UnixNano()))
+	ctx, cancel := context.WithTimeout(context.Background(), p.timeout)
+	defer cancel()
+	if err := p.write(ctx, v); err != nil {
+		p.lg.Warn("convergence probe failed to write", zap.Int("probe", n), zap.Error(err))
+		return
+	}
+	written := time.Now()
+
+	samples := make([]convergenceSample, len(p.reads))
+	var wg sync.WaitGroup
+	wg.Add(len(p.reads))
+	for i := range p.reads {
+		go func(i int) {
+			defer wg.Done()
+			samples[i] = convergenceSample{probe: n, endpoint: p.endpoints[i], written: written}
+			var err error
+			for ctx.Err() == nil {
+				var got []byte
+				var lastContact time.Duration
+				if got, lastContact, err = p.reads[i](ctx); err == nil && bytes.Equal(got, v) {
+					samples[i].took = time.Since(written)
+					samples[i].lastContact = lastContact
+					return
+				}
+				time.Sleep(convergencePollInterval)
+			}
+			samples[i].took = time.Since(written)
+			samples[i].err = fmt.Sprintf("not converged in %v (last error: %v)", p.timeout, err)
+		}(i)
+	}
+	wg.Wait()
+
+	var slowest time.Duration
+	for _, s := range samples {
+		if s.took > slowest {
+			slowest = s.took
+		}
+		if s.err != "" {
+			p.lg.Warn("endpoint did not serve the new value", zap.Int("probe", n), zap.String("endpoint", s.endpoint), zap.String("error", s.err))
+		}
+	}
+	p.lg.Sugar().Infof("convergence probe %d [endpoints: %d | slowest: %v]", n, len(samples), slowest)
+
+	p.mu.Lock()
+	p.samples = append(p.samples, samples...)
+	p.mu.Unlock()
+}
+
+// startConvergenceProbe probes every 'interval_seconds' if set, and returns
+// the function to probe after the burst of writes and stop probing.
+func (cfg *Config) startConvergenceProbe(gcfg dbtesterpb.ConfigClientMachineAgentControl) (stop func()) {
+	p := cfg.convergenceProbe
+
+	stopc, donec := make(chan struct{}), make(chan struct{})
+	go func() {
+		defer close(donec)
+		interval := time.Duration(gcfg.ConfigClientMachineBenchmarkOptions.ConfigClientMachineConvergenceProbe.IntervalSeconds) * time.Second
+		if interval <= 0 {
+			return
+		}
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				p.measure()
+			case <-stopc:
+				return
+			}
+		}
+	}()
+	return func() {
+		close(stopc)
+		<-donec
+		p.measure()
+	}
+}
+
+// endpointDivergence summarizes the divergence window of an endpoint;
+// that is, the time after the write until the endpoint serves it.
+type endpointDivergence struct {
+	endpoint     string
+	probeN       int
+	notConverged int
+
+	avg, p99, max  time.Duration
+	maxLastContact time.Duration
+}
+
+// summarizeConvergence returns the divergence of each endpoint,
+// in the order of the endpoints.
+func summarizeConvergence(endpoints []string, samples []convergenceSample) []endpointDivergence {
+	ds := make([]endpointDivergence, len(endpoints))
+	idx := make(map[string]int, len(endpoints))
+	tooks := make([][]float64, len(endpoints))
+	for i, ep := range endpoints {
+		ds[i].endpoint = ep
+		idx[ep] = i
+	}
+	for _, s := range samples {
+		i, ok := idx[s.endpoint]
+		if !ok {
+			continue
+		}
+		d := &ds[i]
+		d.probeN++
+		if s.err != "" {
+			d.notConverged++
+			continue
+		}
+		tooks[i] = append(tooks[i], float64(s.took))
+		if s.took > d.max {
+			d.max = s.took
+		}
+		if s.lastContact > d.maxLastContact {
+			d.maxLastContact = s.lastContact
+		}
+	}
+	for i := range ds {
+		if len(tooks[i]) == 0 {
+			continue
+		}
+		var sum float64
+		for _, v := range tooks[i] {
+			sum += v
+		}
+		ds[i].avg = time.Duration(sum / float64(len(tooks[i])))
+		ds[i].p99 = time.Duration(percentileOf(tooks[i], 99))
+	}
+	return ds
+}
+
+func (cfg *Config) saveConvergence() {
+	p := cfg.convergenceProbe
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	cfg.saveConvergenceSummary(summarizeConvergence(p.endpoints, p.samples))
+
+	fpath := cfg.ConfigClientMachineInitial.ClientConvergencePath
+	if fpath == "" {
+		cfg.lg.Warn("'client_convergence_path' is not set; skipping convergence time")
+		return
+	}
+
+	c1 := dataframe.NewColumn("PROBE")
+	c2 := dataframe.NewColumn("ENDPOINT")
+	c3 := dataframe.NewColumn("WRITE-UNIX-NANOSECOND")
+	c4 := dataframe.NewColumn("CONVERGENCE-MS")
+	c5 := dataframe.NewColumn("LAST-CONTACT-MS")
+	c6 := dataframe.NewColumn("ERROR")
+	for _, s := range p.samples {
+		c1.PushBack(dataframe.NewStringValue(s.probe))
+		c2.PushBack(dataframe.NewStringValue(s.endpoint))
+		c3.PushBack(dataframe.NewStringValue(s.written.UnixNano()))
+		c4.PushBack(dataframe.NewStringValue(fmt.Sprintf("%4.4f", toMillisecond(s.took))))
+		c5.PushBack(dataframe.NewStringValue(fmt.Sprintf("%4.4f", toMillisecond(s.lastContact))))
+		c6.PushBack(dataframe.NewStringValue(s.err))
+	}
+	fr := dataframe.New()
+	for _, col := range []dataframe.Column{c1, c2, c3, c4, c5, c6} {
+		if err := fr.AddColumn(col); err != nil {
+			panic(err)
+		}
+	}
+	if err := saveCSV(fr, fpath); err != nil {
+		panic(err)
+	}
+	cfg.lg.Info("saved convergence time", zap.String("path", fpath))
+}
+
+func (cfg *Config) saveConvergenceSummary(ds []endpointDivergence) {
+	for _, d := range ds {
+		cfg.lg.Sugar().Infof("endpoint %q divergence [probes: %d | not converged: %d | average: %v | p99: %v | max: %v | max last contact: %v]",
+			d.endpoint, d.probeN, d.notConverged, d.avg, d.p99, d.max, d.maxLastContact)
+	}
+
+	fpath := cfg.ConfigClientMachineInitial.ClientConvergenceSummaryPath
+	if fpath == "" {
+		return
+	}
+
+	c1 := dataframe.NewColumn("ENDPOINT")
+	c2 := dataframe.NewColumn("PROBES")
+	c3 := dataframe.NewColumn("NOT-CONVERGED")
+	c4 := dataframe.NewColumn("AVERAGE-DIVERGENCE-MS")
+	c5 := dataframe.NewColumn("P99-DIVERGENCE-MS")
+	c6 := dataframe.NewColumn("MAX-DIVERGENCE-MS")
+	c7 := dataframe.NewColumn("MAX-LAST-CONTACT-MS")
+	for _, d := range ds {
+		c1.PushBack(dataframe.NewStringValue(d.endpoint))
+		c2.PushBack(dataframe.NewStringValue(d.probeN))
+		c3.PushBack(dataframe.NewStringValue(d.notConverged))
+		c4.PushBack(dataframe.NewStringValue(fmt.Sprintf("%4.4f", toMillisecond(d.avg))))
+		c5.PushBack(dataframe.NewStringValue(fmt.Sprintf("%4.4f", toMillisecond(d.p99))))
+		c6.PushBack(dataframe.NewStringValue(fmt.Sprintf("%4.4f", toMillisecond(d.max))))
+		c7.PushBack(dataframe.NewStringValue(fmt.Sprintf("%4.4f", toMillisecond(d.maxLastContact))))
+	}
+	fr := dataframe.New()
+	for _, col := range []dataframe.Column{c1, c2, c3, c4, c5, c6, c7} {
+		if err := fr.AddColumn(col); err != nil {
+			panic(err)
+		}
+	}
+	if err := saveCSV(fr, fpath); err != nil {
+		panic(err)
+	}
+	cfg.lg.Info("saved convergence summary", zap.String("path", fpath))
+}
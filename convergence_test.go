@@ -0,0 +1,70 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"testing"
+	"time"
+
+	"github.com/coreos/dbtester/dbtesterpb"
+
+	"go.uber.org/zap"
+)
+
+func TestConvergenceProbeMock(t *testing.T) {
+	gcfg := dbtesterpb.ConfigClientMachineAgentControl{
+		DatabaseID: "mock",
+		ConfigClientMachineBenchmarkOptions: &dbtesterpb.ConfigClientMachineBenchmarkOptions{
+			ConfigClientMachineConvergenceProbe: &dbtesterpb.ConfigClientMachineConvergenceProbe{TimeoutSeconds: 1},
+		},
+	}
+	p, err := newConvergenceProbe(zap.NewNop(), gcfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer p.close()
+
+	p.measure()
+	p.measure()
+	if len(p.samples) != 2 {
+		t.Fatalf("expected 2 samples, got %d", len(p.samples))
+	}
+	for _, s := range p.samples {
+		if s.err != "" || s.endpoint != "mock" {
+			t.Fatalf("unexpected sample %+v", s)
+		}
+	}
+	if p.samples[1].probe != 2 {
+		t.Fatalf("expected probe 2, got %d", p.samples[1].probe)
+	}
+}
+
+func TestSummarizeConvergence(t *testing.T) {
+	ds := summarizeConvergence([]string{"a", "b"}, []convergenceSample{
+		{probe: 1, endpoint: "a", took: time.Millisecond},
+		{probe: 1, endpoint: "b", took: 3 * time.Millisecond, lastContact: 20 * time.Millisecond},
+		{probe: 2, endpoint: "a", took: 3 * time.Millisecond},
+		{probe: 2, endpoint: "b", took: time.Minute, err: "not converged"},
+	})
+	if len(ds) != 2 {
+		t.Fatalf("expected 2 endpoints, got %d", len(ds))
+	}
+	if a := ds[0]; a.probeN != 2 || a.notConverged != 0 || a.avg != 2*time.Millisecond || a.max != 3*time.Millisecond {
+		t.Fatalf("unexpected divergence of 'a' %+v", a)
+	}
+	if b := ds[1]; b.probeN != 2 || b.notConverged != 1 || b.max != 3*time.Millisecond || b.maxLastContact != 20*time.Millisecond {
+		t.Fatalf("unexpected divergence of 'b' %+v", b)
+	}
+}
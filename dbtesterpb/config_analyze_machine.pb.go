@@ -1,41 +1,6 @@
 // Code generated by protoc-gen-gogo. DO NOT EDIT.
 // source: dbtesterpb/config_analyze_machine.proto
 
-/*
-	Package dbtesterpb is a generated protocol buffer package.
-
-	It is generated from these files:
-		dbtesterpb/config_analyze_machine.proto
-		dbtesterpb/config_client_machine.proto
-		dbtesterpb/database_id.proto
-		dbtesterpb/flag_cetcd.proto
-		dbtesterpb/flag_consul.proto
-		dbtesterpb/flag_etcd.proto
-		dbtesterpb/flag_zetcd.proto
-		dbtesterpb/flag_zookeeper.proto
-		dbtesterpb/message.proto
-
-	It has these top-level messages:
-		ConfigAnalyzeMachineInitial
-		ConfigAnalyzeMachineAllAggregatedOutput
-		ConfigAnalyzeMachinePlot
-		ConfigAnalyzeMachineImage
-		ConfigAnalyzeMachineREADME
-		ConfigClientMachineInitial
-		ConfigClientMachineBenchmarkOptions
-		ConfigClientMachineBenchmarkSteps
-		ConfigClientMachineAgentControl
-		Flag_Cetcd_Beta
-		Flag_Consul_V1_0_2
-		Flag_Etcd_Other
-		Flag_Etcd_Tip
-		Flag_Etcd_V3_2
-		Flag_Etcd_V3_3
-		Flag_Zetcd_Beta
-		Flag_Zookeeper_R3_5_3Beta
-		Request
-		Response
-*/
 package dbtesterpb
 
 import proto "github.com/golang/protobuf/proto"
@@ -50,12 +15,6 @@ var _ = proto.Marshal
 var _ = fmt.Errorf
 var _ = math.Inf
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the proto package it is being compiled against.
-// A compilation error at this line likely means your copy of the
-// proto package needs to be updated.
-const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
-
 // ConfigAnalyzeMachineInitial represents common control options and test data information for analyzer machine.
 type ConfigAnalyzeMachineInitial struct {
 	DatabaseID                              string   `protobuf:"bytes,1,opt,name=DatabaseID,proto3" json:"DatabaseID,omitempty"`
@@ -74,6 +33,7 @@ type ConfigAnalyzeMachineInitial struct {
 	ServerWriteBytesDeltaByKeyNumberPath    string   `protobuf:"bytes,14,opt,name=ServerWriteBytesDeltaByKeyNumberPath,proto3" json:"ServerWriteBytesDeltaByKeyNumberPath,omitempty" yaml:"server_write_bytes_delta_by_key_number_path"`
 	ServerSystemMetricsInterpolatedPathList []string `protobuf:"bytes,15,rep,name=ServerSystemMetricsInterpolatedPathList" json:"ServerSystemMetricsInterpolatedPathList,omitempty" yaml:"server_system_metrics_interpolated_path_list"`
 	AllAggregatedOutputPath                 string   `protobuf:"bytes,16,opt,name=AllAggregatedOutputPath,proto3" json:"AllAggregatedOutputPath,omitempty" yaml:"all_aggregated_output_path"`
+	ServerDatabaseConfigPathList            []string `protobuf:"bytes,17,rep,name=ServerDatabaseConfigPathList" json:"ServerDatabaseConfigPathList,omitempty" yaml:"server_database_config_path_list"`
 }
 
 func (m *ConfigAnalyzeMachineInitial) Reset()         { *m = ConfigAnalyzeMachineInitial{} }
@@ -269,6 +229,23 @@ func (m *ConfigAnalyzeMachineInitial) MarshalTo(dAtA []byte) (int, error) {
 		i = encodeVarintConfigAnalyzeMachine(dAtA, i, uint64(len(m.AllAggregatedOutputPath)))
 		i += copy(dAtA[i:], m.AllAggregatedOutputPath)
 	}
+	if len(m.ServerDatabaseConfigPathList) > 0 {
+		for _, s := range m.ServerDatabaseConfigPathList {
+			dAtA[i] = 0x8a
+			i++
+			dAtA[i] = 0x1
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
 	return i, nil
 }
 
@@ -509,6 +486,12 @@ func (m *ConfigAnalyzeMachineInitial) Size() (n int) {
 	if l > 0 {
 		n += 2 + l + sovConfigAnalyzeMachine(uint64(l))
 	}
+	if len(m.ServerDatabaseConfigPathList) > 0 {
+		for _, s := range m.ServerDatabaseConfigPathList {
+			l = len(s)
+			n += 2 + l + sovConfigAnalyzeMachine(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -1094,6 +1077,35 @@ func (m *ConfigAnalyzeMachineInitial) Unmarshal(dAtA []byte) error {
 			}
 			m.AllAggregatedOutputPath = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 17:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ServerDatabaseConfigPathList", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigAnalyzeMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfigAnalyzeMachine
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ServerDatabaseConfigPathList = append(m.ServerDatabaseConfigPathList, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipConfigAnalyzeMachine(dAtA[iNdEx:])
@@ -1775,68 +1787,70 @@ func init() {
 }
 
 var fileDescriptorConfigAnalyzeMachine = []byte{
-	// 999 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x56, 0xcf, 0x6e, 0xdc, 0x44,
-	0x18, 0xaf, 0x9b, 0x26, 0x90, 0x49, 0xd3, 0x96, 0x01, 0xb5, 0x4b, 0x82, 0xd6, 0xc1, 0x69, 0x48,
-	0xaa, 0x42, 0x52, 0x12, 0x28, 0x12, 0x27, 0x76, 0xb3, 0x3d, 0x44, 0x34, 0x10, 0x39, 0x0b, 0x84,
-	0xd3, 0x68, 0xec, 0x9d, 0x78, 0x47, 0xf1, 0x3f, 0x79, 0xc6, 0x65, 0x0d, 0x57, 0x24, 0x24, 0x24,
-	0x24, 0xb8, 0x71, 0xe2, 0xc8, 0xb3, 0xf4, 0xc8, 0x13, 0x18, 0x08, 0x6f, 0xe0, 0x17, 0xa0, 0x9a,
-	0x6f, 0x9c, 0xc4, 0xbb, 0xf1, 0xfe, 0xb9, 0xad, 0xe7, 0xfb, 0xfd, 0xfb, 0x3e, 0xcf, 0x8e, 0x07,
-	0x6d, 0xf6, 0x1c, 0xc9, 0x84, 0x64, 0x49, 0xec, 0xec, 0xb8, 0x51, 0x78, 0xca, 0x3d, 0x42, 0x43,
-	0xea, 0x67, 0xdf, 0x33, 0x12, 0x50, 0xb7, 0xcf, 0x43, 0xb6, 0x1d, 0x27, 0x91, 0x8c, 0x30, 0xba,
-	0x02, 0xae, 0x7c, 0xe0, 0x71, 0xd9, 0x4f, 0x9d, 0x6d, 0x37, 0x0a, 0x76, 0xbc, 0xc8, 0x8b, 0x76,
-	0x00, 0xe2, 0xa4, 0xa7, 0xf0, 0x04, 0x0f, 0xf0, 0x4b, 0x53, 0xad, 0xbf, 0x97, 0xd1, 0xea, 0x3e,
-	0x68, 0xb7, 0xb4, 0xf4, 0xa1, 0x56, 0x3e, 0x08, 0xb9, 0xe4, 0xd4, 0xc7, 0x4d, 0x84, 0x3a, 0x54,
-	0x52, 0x87, 0x0a, 0x76, 0xd0, 0x69, 0x18, 0x6b, 0xc6, 0xd6, 0xa2, 0x5d, 0x59, 0xc1, 0x6b, 0x68,
-	0xe9, 0xe2, 0xa9, 0x4b, 0xbd, 0xc6, 0x4d, 0x00, 0x54, 0x97, 0xf0, 0x13, 0xf4, 0xe6, 0xc5, 0x63,
-	0x87, 0x09, 0x37, 0xe1, 0xb1, 0xe4, 0x51, 0xd8, 0x98, 0x03, 0x64, 0x5d, 0x09, 0x3f, 0x45, 0xe8,
-	0x88, 0xca, 0xfe, 0x51, 0xc2, 0x4e, 0xf9, 0xa0, 0x71, 0x4b, 0x01, 0xdb, 0xf7, 0x8b, 0xdc, 0xc4,
-	0x19, 0x0d, 0xfc, 0x4f, 0xad, 0x98, 0xca, 0x3e, 0x89, 0xa1, 0x68, 0xd9, 0x15, 0x24, 0xfe, 0xd1,
-	0x40, 0xeb, 0xfb, 0x3e, 0x67, 0xa1, 0x3c, 0xce, 0x84, 0x64, 0xc1, 0x21, 0x93, 0x09, 0x77, 0xc5,
-	0x41, 0xa8, 0x26, 0x13, 0xf9, 0x54, 0xb2, 0x9e, 0x42, 0x37, 0xe6, 0x41, 0x71, 0xb7, 0xc8, 0xcd,
-	0x6d, 0xad, 0xe8, 0x02, 0x89, 0x08, 0x60, 0x91, 0x40, 0xd3, 0x08, 0xaf, 0xf0, 0x88, 0x32, 0xb5,
-	0xec, 0x59, 0xe4, 0xf1, 0xcf, 0x06, 0xda, 0xd0, 0xb8, 0xe7, 0x54, 0xb2, 0xd0, 0xcd, 0xba, 0xfd,
-	0x24, 0x4a, 0xbd, 0x7e, 0x9c, 0xca, 0x2e, 0x0f, 0x98, 0x60, 0x09, 0x67, 0x02, 0x82, 0x2c, 0x40,
-	0x90, 0x8f, 0x8a, 0xdc, 0x7c, 0x32, 0x14, 0xc4, 0xd7, 0x3c, 0x22, 0x2f, 0x89, 0x44, 0x5e, 0x32,
-	0xcb, 0x28, 0xb3, 0x59, 0xe0, 0x1f, 0xd0, 0xda, 0x10, 0xb0, 0xc3, 0x85, 0x4c, 0xb8, 0x93, 0xaa,
-	0x41, 0xb7, 0x7c, 0x1f, 0x62, 0xbc, 0x06, 0x31, 0x76, 0x8a, 0xdc, 0x7c, 0x5c, 0x1b, 0xa3, 0x57,
-	0xe1, 0x10, 0xea, 0xfb, 0x65, 0x82, 0xa9, 0xc2, 0xf8, 0x57, 0x03, 0x6d, 0x8e, 0x05, 0x1d, 0xb1,
-	0xc4, 0x65, 0xa1, 0xe4, 0x3e, 0x83, 0x10, 0xaf, 0x43, 0x88, 0xa7, 0x45, 0x6e, 0xee, 0x4e, 0x0f,
-	0x11, 0x5f, 0x72, 0xcb, 0x2c, 0xb3, 0xda, 0xe0, 0x9f, 0x0c, 0xf4, 0x70, 0x2c, 0xf6, 0x38, 0x0d,
-	0x02, 0x9a, 0x64, 0x90, 0x67, 0x11, 0xf2, 0xec, 0x15, 0xb9, 0xb9, 0x33, 0x3d, 0x8f, 0xd0, 0xc4,
-	0x32, 0xcc, 0x4c, 0x06, 0x38, 0x46, 0xef, 0x0c, 0xe1, 0xda, 0xd9, 0xe7, 0x2c, 0xfb, 0x22, 0x0d,
-	0x1c, 0x96, 0x40, 0x00, 0x04, 0x01, 0xde, 0x2f, 0x72, 0x73, 0xab, 0x36, 0x80, 0x93, 0x91, 0x33,
-	0x96, 0x91, 0x10, 0x18, 0xa5, 0xf3, 0x44, 0x45, 0x9c, 0x21, 0xf3, 0x98, 0x25, 0x2f, 0x58, 0xd2,
-	0xe1, 0xe2, 0xec, 0x38, 0xa6, 0x2e, 0xfb, 0x4a, 0x50, 0x8f, 0x55, 0xbb, 0x5e, 0x1a, 0xdd, 0x0a,
-	0x02, 0x08, 0xaa, 0xdb, 0x33, 0x22, 0x14, 0x85, 0xa4, 0x8a, 0x33, 0xd2, 0xf1, 0x34, 0x5d, 0x1c,
-	0xa0, 0x55, 0x0d, 0x39, 0x64, 0x41, 0x94, 0x5c, 0xeb, 0xf5, 0x36, 0xd8, 0x3e, 0x2e, 0x72, 0x73,
-	0x73, 0xc8, 0x36, 0x00, 0x74, 0x6d, 0xab, 0x93, 0xf4, 0xd4, 0x5b, 0x5e, 0xd7, 0x75, 0x9b, 0xd1,
-	0x5e, 0x3b, 0x93, 0x4c, 0x74, 0x98, 0x2f, 0xe9, 0xa8, 0xef, 0x32, 0xf8, 0x7e, 0x5c, 0xe4, 0xe6,
-	0x87, 0x43, 0xbe, 0x09, 0xa3, 0x3d, 0xe2, 0x28, 0x1a, 0xe9, 0x29, 0x5e, 0x6d, 0x82, 0x59, 0x1c,
-	0xd4, 0x61, 0xf0, 0x50, 0xe3, 0xbe, 0x49, 0xb8, 0x64, 0xe3, 0xa3, 0xdc, 0x19, 0xdd, 0xff, 0x65,
-	0x94, 0xef, 0x14, 0x6d, 0x6a, 0x96, 0x99, 0x3c, 0xf0, 0x6f, 0x06, 0xda, 0xd4, 0xc0, 0x89, 0x27,
-	0xd8, 0x73, 0x2e, 0x64, 0xe3, 0xee, 0xda, 0xdc, 0xd6, 0x62, 0xfb, 0x93, 0x22, 0x37, 0xf7, 0x86,
-	0xf2, 0x4c, 0x3b, 0x24, 0x89, 0xcf, 0x85, 0xb4, 0xec, 0x59, 0x7d, 0x30, 0x41, 0x0f, 0x5a, 0xbe,
-	0xdf, 0xf2, 0xbc, 0x84, 0x79, 0xaa, 0xf0, 0x65, 0x2a, 0xe3, 0x54, 0xc2, 0x48, 0xee, 0xc1, 0x48,
-	0x36, 0x8a, 0xdc, 0x7c, 0x57, 0x47, 0x50, 0x67, 0x0f, 0xbd, 0x44, 0x92, 0x08, 0xa0, 0xe5, 0x04,
-	0xc6, 0xa9, 0x58, 0xff, 0xab, 0x43, 0xa8, 0xe6, 0x0b, 0x57, 0x83, 0xc7, 0x1c, 0xad, 0x8c, 0x91,
-	0xd9, 0x3f, 0xfe, 0x5a, 0x7f, 0xfd, 0xda, 0x8f, 0x8a, 0xdc, 0xdc, 0x98, 0x96, 0x87, 0xb8, 0xe2,
-	0x85, 0x65, 0x4f, 0x10, 0x9b, 0x60, 0xd5, 0x3d, 0xe9, 0xea, 0xef, 0xe8, 0x8c, 0x56, 0x72, 0x20,
-	0xc7, 0x5b, 0x75, 0x4f, 0xba, 0xd6, 0x1f, 0x37, 0x51, 0xa3, 0x6e, 0x02, 0x47, 0x7e, 0x24, 0xf1,
-	0x23, 0xb4, 0xb0, 0x1f, 0xf9, 0x69, 0x10, 0x96, 0xed, 0xbd, 0x51, 0xe4, 0xe6, 0x72, 0x79, 0xe0,
-	0xc0, 0xba, 0x65, 0x97, 0x00, 0xbc, 0x89, 0xe6, 0x4f, 0x5a, 0x03, 0x2e, 0xca, 0x74, 0x15, 0xe4,
-	0x80, 0xd0, 0x01, 0x17, 0x96, 0xad, 0xeb, 0x0a, 0xf8, 0x2d, 0x00, 0xe7, 0x46, 0x81, 0xd9, 0x05,
-	0x10, 0xea, 0xf8, 0x33, 0xb4, 0x3c, 0x3c, 0x62, 0xfd, 0xb1, 0x5f, 0x29, 0x72, 0xf3, 0xbe, 0x26,
-	0x5c, 0x9b, 0xe9, 0x30, 0x01, 0xef, 0xa3, 0x3b, 0x57, 0x0b, 0xb0, 0x71, 0xe7, 0x61, 0xe3, 0xae,
-	0x16, 0xb9, 0xf9, 0xe0, 0xba, 0x84, 0xde, 0x9c, 0x23, 0x14, 0xeb, 0x17, 0x03, 0xbd, 0x5d, 0x7b,
-	0x09, 0x0a, 0xa8, 0xc7, 0xf0, 0x7b, 0x68, 0xbe, 0xcb, 0xa5, 0xcf, 0xca, 0x01, 0xdd, 0x2b, 0x72,
-	0xf3, 0xb6, 0x56, 0x96, 0x6a, 0xd9, 0xb2, 0x75, 0x19, 0xaf, 0xa3, 0x5b, 0xb0, 0x6d, 0xf5, 0x74,
-	0xee, 0x16, 0xb9, 0xb9, 0x74, 0x75, 0x61, 0xb1, 0x6c, 0x28, 0x2a, 0x50, 0x37, 0x8b, 0x59, 0x39,
-	0x99, 0x0a, 0x48, 0x66, 0x31, 0xb3, 0x6c, 0x28, 0x5a, 0x7f, 0x1a, 0x68, 0xa5, 0x2e, 0x8f, 0xfd,
-	0xac, 0xd5, 0x39, 0x7c, 0xa6, 0xee, 0x47, 0x95, 0x7f, 0x89, 0x31, 0x7a, 0x3f, 0x1a, 0xfa, 0x5b,
-	0x54, 0x90, 0xf8, 0x08, 0x2d, 0x40, 0x47, 0xea, 0x05, 0xce, 0x6d, 0x2d, 0xed, 0x6e, 0x6c, 0x5f,
-	0xdd, 0x1b, 0xb7, 0xc7, 0xf6, 0x5f, 0x7d, 0x7d, 0x1c, 0xe8, 0x96, 0x5d, 0xea, 0xb4, 0xdf, 0x7a,
-	0xf9, 0x6f, 0xf3, 0xc6, 0xcb, 0xf3, 0xa6, 0xf1, 0xd7, 0x79, 0xd3, 0xf8, 0xe7, 0xbc, 0x69, 0xfc,
-	0xfe, 0x5f, 0xf3, 0x86, 0xb3, 0x00, 0x57, 0xcb, 0xbd, 0x57, 0x01, 0x00, 0x00, 0xff, 0xff, 0x47,
-	0x8f, 0xbe, 0x24, 0xc0, 0x0a, 0x00, 0x00,
+	// 1029 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x56, 0xcf, 0x6e, 0xdc, 0x44,
+	0x18, 0xaf, 0xb3, 0x4d, 0xa0, 0x93, 0x26, 0x6d, 0x07, 0xd4, 0x2e, 0x49, 0xb5, 0x0e, 0x4e, 0x43,
+	0x52, 0x15, 0x92, 0x92, 0x40, 0x91, 0x38, 0xb1, 0x9b, 0xed, 0x21, 0xa2, 0x81, 0xc8, 0x59, 0x20,
+	0x9c, 0x46, 0xb3, 0xde, 0x89, 0x77, 0x14, 0xff, 0x93, 0x67, 0x5c, 0x62, 0xb8, 0x22, 0x21, 0x21,
+	0x21, 0xc1, 0x8d, 0x13, 0x47, 0x9e, 0xa5, 0xc7, 0x3e, 0x81, 0x05, 0xe1, 0x0d, 0xfc, 0x02, 0xa0,
+	0xf9, 0xc6, 0xd9, 0x78, 0x37, 0xde, 0x3f, 0xdc, 0xd6, 0xfe, 0x7e, 0xff, 0xbe, 0x6f, 0xc7, 0x33,
+	0x83, 0x36, 0x7b, 0x5d, 0xc9, 0x84, 0x64, 0x71, 0xd4, 0xdd, 0x71, 0xc2, 0xe0, 0x94, 0xbb, 0x84,
+	0x06, 0xd4, 0x4b, 0xbf, 0x67, 0xc4, 0xa7, 0x4e, 0x9f, 0x07, 0x6c, 0x3b, 0x8a, 0x43, 0x19, 0x62,
+	0x74, 0x05, 0x5c, 0xf9, 0xc0, 0xe5, 0xb2, 0x9f, 0x74, 0xb7, 0x9d, 0xd0, 0xdf, 0x71, 0x43, 0x37,
+	0xdc, 0x01, 0x48, 0x37, 0x39, 0x85, 0x27, 0x78, 0x80, 0x5f, 0x9a, 0x6a, 0xbd, 0x5e, 0x46, 0xab,
+	0xfb, 0xa0, 0xdd, 0xd4, 0xd2, 0x87, 0x5a, 0xf9, 0x20, 0xe0, 0x92, 0x53, 0x0f, 0x37, 0x10, 0x6a,
+	0x53, 0x49, 0xbb, 0x54, 0xb0, 0x83, 0x76, 0xdd, 0x58, 0x33, 0xb6, 0x6e, 0xd9, 0xa5, 0x37, 0x78,
+	0x0d, 0x2d, 0x5e, 0x3e, 0x75, 0xa8, 0x5b, 0x9f, 0x03, 0x40, 0xf9, 0x15, 0x7e, 0x8a, 0xde, 0xba,
+	0x7c, 0x6c, 0x33, 0xe1, 0xc4, 0x3c, 0x92, 0x3c, 0x0c, 0xea, 0x35, 0x40, 0x56, 0x95, 0xf0, 0x33,
+	0x84, 0x8e, 0xa8, 0xec, 0x1f, 0xc5, 0xec, 0x94, 0x9f, 0xd7, 0x6f, 0x2a, 0x60, 0xeb, 0x7e, 0x9e,
+	0x99, 0x38, 0xa5, 0xbe, 0xf7, 0xa9, 0x15, 0x51, 0xd9, 0x27, 0x11, 0x14, 0x2d, 0xbb, 0x84, 0xc4,
+	0x3f, 0x1a, 0x68, 0x7d, 0xdf, 0xe3, 0x2c, 0x90, 0xc7, 0xa9, 0x90, 0xcc, 0x3f, 0x64, 0x32, 0xe6,
+	0x8e, 0x38, 0x08, 0xd4, 0x64, 0x42, 0x8f, 0x4a, 0xd6, 0x53, 0xe8, 0xfa, 0x3c, 0x28, 0xee, 0xe6,
+	0x99, 0xb9, 0xad, 0x15, 0x1d, 0x20, 0x11, 0x01, 0x2c, 0xe2, 0x6b, 0x1a, 0xe1, 0x25, 0x1e, 0x51,
+	0xa6, 0x96, 0x3d, 0x8b, 0x3c, 0xfe, 0xd9, 0x40, 0x1b, 0x1a, 0xf7, 0x82, 0x4a, 0x16, 0x38, 0x69,
+	0xa7, 0x1f, 0x87, 0x89, 0xdb, 0x8f, 0x12, 0xd9, 0xe1, 0x3e, 0x13, 0x2c, 0xe6, 0x4c, 0x40, 0x90,
+	0x05, 0x08, 0xf2, 0x51, 0x9e, 0x99, 0x4f, 0x87, 0x82, 0x78, 0x9a, 0x47, 0xe4, 0x80, 0x48, 0xe4,
+	0x80, 0x59, 0x44, 0x99, 0xcd, 0x02, 0xff, 0x80, 0xd6, 0x86, 0x80, 0x6d, 0x2e, 0x64, 0xcc, 0xbb,
+	0x89, 0x1a, 0x74, 0xd3, 0xf3, 0x20, 0xc6, 0x1b, 0x10, 0x63, 0x27, 0xcf, 0xcc, 0x27, 0x95, 0x31,
+	0x7a, 0x25, 0x0e, 0xa1, 0x9e, 0x57, 0x24, 0x98, 0x2a, 0x8c, 0x7f, 0x35, 0xd0, 0xe6, 0x58, 0xd0,
+	0x11, 0x8b, 0x1d, 0x16, 0x48, 0xee, 0x31, 0x08, 0xf1, 0x26, 0x84, 0x78, 0x96, 0x67, 0xe6, 0xee,
+	0xf4, 0x10, 0xd1, 0x80, 0x5b, 0x64, 0x99, 0xd5, 0x06, 0xff, 0x64, 0xa0, 0x47, 0x63, 0xb1, 0xc7,
+	0x89, 0xef, 0xd3, 0x38, 0x85, 0x3c, 0xb7, 0x20, 0xcf, 0x5e, 0x9e, 0x99, 0x3b, 0xd3, 0xf3, 0x08,
+	0x4d, 0x2c, 0xc2, 0xcc, 0x64, 0x80, 0x23, 0xf4, 0x70, 0x08, 0xd7, 0x4a, 0x3f, 0x67, 0xe9, 0x17,
+	0x89, 0xdf, 0x65, 0x31, 0x04, 0x40, 0x10, 0xe0, 0xfd, 0x3c, 0x33, 0xb7, 0x2a, 0x03, 0x74, 0x53,
+	0x72, 0xc6, 0x52, 0x12, 0x00, 0xa3, 0x70, 0x9e, 0xa8, 0x88, 0x53, 0x64, 0x1e, 0xb3, 0xf8, 0x25,
+	0x8b, 0xdb, 0x5c, 0x9c, 0x1d, 0x47, 0xd4, 0x61, 0x5f, 0x09, 0xea, 0xb2, 0x72, 0xd7, 0x8b, 0xa3,
+	0x4b, 0x41, 0x00, 0x41, 0x75, 0x7b, 0x46, 0x84, 0xa2, 0x90, 0x44, 0x71, 0x46, 0x3a, 0x9e, 0xa6,
+	0x8b, 0x7d, 0xb4, 0xaa, 0x21, 0x87, 0xcc, 0x0f, 0xe3, 0x6b, 0xbd, 0xde, 0x06, 0xdb, 0x27, 0x79,
+	0x66, 0x6e, 0x0e, 0xd9, 0xfa, 0x80, 0xae, 0x6c, 0x75, 0x92, 0x9e, 0xfa, 0x97, 0xd7, 0x75, 0xdd,
+	0x66, 0xb4, 0xd7, 0x4a, 0x25, 0x13, 0x6d, 0xe6, 0x49, 0x3a, 0xea, 0xbb, 0x04, 0xbe, 0x1f, 0xe7,
+	0x99, 0xf9, 0xe1, 0x90, 0x6f, 0xcc, 0x68, 0x8f, 0x74, 0x15, 0x8d, 0xf4, 0x14, 0xaf, 0x32, 0xc1,
+	0x2c, 0x0e, 0x6a, 0x33, 0x78, 0xa4, 0x71, 0xdf, 0xc4, 0x5c, 0xb2, 0xf1, 0x51, 0x96, 0x47, 0xd7,
+	0x7f, 0x11, 0xe5, 0x3b, 0x45, 0x9b, 0x9a, 0x65, 0x26, 0x0f, 0xfc, 0x9b, 0x81, 0x36, 0x35, 0x70,
+	0xe2, 0x0e, 0xf6, 0x82, 0x0b, 0x59, 0xbf, 0xb3, 0x56, 0xdb, 0xba, 0xd5, 0xfa, 0x24, 0xcf, 0xcc,
+	0xbd, 0xa1, 0x3c, 0xd3, 0x36, 0x49, 0xe2, 0x71, 0x21, 0x2d, 0x7b, 0x56, 0x1f, 0x4c, 0xd0, 0x83,
+	0xa6, 0xe7, 0x35, 0x5d, 0x37, 0x66, 0xae, 0x2a, 0x7c, 0x99, 0xc8, 0x28, 0x91, 0x30, 0x92, 0xbb,
+	0x30, 0x92, 0x8d, 0x3c, 0x33, 0xdf, 0xd5, 0x11, 0xd4, 0xde, 0x43, 0x07, 0x48, 0x12, 0x02, 0xb4,
+	0x98, 0xc0, 0x38, 0x15, 0x1c, 0xa2, 0x87, 0xc5, 0xea, 0x2c, 0x8e, 0x1a, 0x7d, 0xdc, 0x0d, 0x1a,
+	0xbd, 0xb7, 0x56, 0xab, 0x5c, 0x7b, 0xbd, 0x02, 0x4e, 0x8a, 0xa3, 0xb7, 0xd4, 0xdc, 0x44, 0x41,
+	0xeb, 0x5f, 0xb5, 0xeb, 0x55, 0x1c, 0xa9, 0x15, 0x01, 0x31, 0x47, 0x2b, 0x63, 0x72, 0xef, 0x1f,
+	0x7f, 0xad, 0x8f, 0xdb, 0xd6, 0xe3, 0x3c, 0x33, 0x37, 0xa6, 0x0d, 0x80, 0x38, 0xe2, 0xa5, 0x65,
+	0x4f, 0x10, 0x9b, 0x60, 0xd5, 0x39, 0xe9, 0xd4, 0xe7, 0xfe, 0x87, 0x95, 0x3c, 0x97, 0xe3, 0xad,
+	0x3a, 0x27, 0x1d, 0xeb, 0x8f, 0x39, 0x54, 0xaf, 0x9a, 0xc0, 0x91, 0x17, 0x4a, 0xfc, 0x18, 0x2d,
+	0xec, 0x87, 0x5e, 0xe2, 0x07, 0x45, 0x7b, 0xf7, 0xf2, 0xcc, 0x5c, 0x2a, 0x76, 0x38, 0x78, 0x6f,
+	0xd9, 0x05, 0x00, 0x6f, 0xa2, 0xf9, 0x93, 0xe6, 0x39, 0x17, 0xf5, 0xb9, 0x51, 0xe4, 0x39, 0xa1,
+	0xe7, 0x5c, 0x58, 0xb6, 0xae, 0x2b, 0xe0, 0xb7, 0x00, 0xac, 0x8d, 0x02, 0xd3, 0x4b, 0x20, 0xd4,
+	0xf1, 0x67, 0x68, 0x69, 0x78, 0xc4, 0xfa, 0x76, 0xb1, 0x92, 0x67, 0xe6, 0x7d, 0x4d, 0xb8, 0x36,
+	0xd3, 0x61, 0x02, 0xde, 0x47, 0xcb, 0x57, 0x2f, 0x60, 0x01, 0xcd, 0xc3, 0x02, 0x5a, 0xcd, 0x33,
+	0xf3, 0xc1, 0x75, 0x09, 0xbd, 0x60, 0x46, 0x28, 0xd6, 0x2f, 0x06, 0x7a, 0xa7, 0xf2, 0xd6, 0xe5,
+	0x53, 0x97, 0xe1, 0xf7, 0xd0, 0x7c, 0x87, 0x4b, 0x8f, 0x15, 0x03, 0xba, 0x9b, 0x67, 0xe6, 0x6d,
+	0xad, 0x2c, 0xd5, 0x6b, 0xcb, 0xd6, 0x65, 0xbc, 0x8e, 0x6e, 0xc2, 0x77, 0xa2, 0xa7, 0x73, 0x27,
+	0xcf, 0xcc, 0xc5, 0xab, 0x1b, 0x92, 0x65, 0x43, 0x51, 0x81, 0x3a, 0x69, 0xc4, 0xea, 0xb5, 0x51,
+	0x90, 0x4c, 0x23, 0x66, 0xd9, 0x50, 0xb4, 0xfe, 0x34, 0xd0, 0x4a, 0x55, 0x1e, 0xfb, 0x79, 0xb3,
+	0x7d, 0xf8, 0x5c, 0x5d, 0xc8, 0x4a, 0x9f, 0xa5, 0x31, 0x7a, 0x21, 0x1b, 0xfa, 0x0e, 0x4b, 0x48,
+	0x7c, 0x84, 0x16, 0xa0, 0x23, 0xf5, 0x07, 0xd6, 0xb6, 0x16, 0x77, 0x37, 0xb6, 0xaf, 0x2e, 0xaa,
+	0xdb, 0x63, 0xfb, 0x2f, 0xff, 0x7d, 0x1c, 0xe8, 0x96, 0x5d, 0xe8, 0xb4, 0xde, 0x7e, 0xf5, 0x77,
+	0xe3, 0xc6, 0xab, 0x8b, 0x86, 0xf1, 0xfa, 0xa2, 0x61, 0xfc, 0x75, 0xd1, 0x30, 0x7e, 0xff, 0xa7,
+	0x71, 0xa3, 0xbb, 0x00, 0x77, 0xd9, 0xbd, 0xff, 0x02, 0x00, 0x00, 0xff, 0xff, 0x72, 0x06, 0xa5,
+	0x9f, 0x31, 0x0b, 0x00, 0x00,
 }
@@ -27,11 +27,70 @@ type ConfigClientMachineInitial struct {
 	ClientLatencyDistributionSummaryPath    string `protobuf:"bytes,8,opt,name=ClientLatencyDistributionSummaryPath,proto3" json:"ClientLatencyDistributionSummaryPath,omitempty" yaml:"client_latency_distribution_summary_path"`
 	ClientLatencyByKeyNumberPath            string `protobuf:"bytes,9,opt,name=ClientLatencyByKeyNumberPath,proto3" json:"ClientLatencyByKeyNumberPath,omitempty" yaml:"client_latency_by_key_number_path"`
 	ServerDiskSpaceUsageSummaryPath         string `protobuf:"bytes,10,opt,name=ServerDiskSpaceUsageSummaryPath,proto3" json:"ServerDiskSpaceUsageSummaryPath,omitempty" yaml:"server_disk_space_usage_summary_path"`
-	GoogleCloudProjectName                  string `protobuf:"bytes,100,opt,name=GoogleCloudProjectName,proto3" json:"GoogleCloudProjectName,omitempty" yaml:"google_cloud_project_name"`
-	GoogleCloudStorageKeyPath               string `protobuf:"bytes,101,opt,name=GoogleCloudStorageKeyPath,proto3" json:"GoogleCloudStorageKeyPath,omitempty" yaml:"google_cloud_storage_key_path"`
-	GoogleCloudStorageKey                   string `protobuf:"bytes,102,opt,name=GoogleCloudStorageKey,proto3" json:"GoogleCloudStorageKey,omitempty"`
-	GoogleCloudStorageBucketName            string `protobuf:"bytes,103,opt,name=GoogleCloudStorageBucketName,proto3" json:"GoogleCloudStorageBucketName,omitempty" yaml:"google_cloud_storage_bucket_name"`
-	GoogleCloudStorageSubDirectory          string `protobuf:"bytes,104,opt,name=GoogleCloudStorageSubDirectory,proto3" json:"GoogleCloudStorageSubDirectory,omitempty" yaml:"google_cloud_storage_sub_directory"`
+	ClientRequestTraceSamplePath            string `protobuf:"bytes,11,opt,name=ClientRequestTraceSamplePath,proto3" json:"ClientRequestTraceSamplePath,omitempty" yaml:"client_request_trace_sample_path"`
+	ClientEndpointTrafficPath               string `protobuf:"bytes,12,opt,name=ClientEndpointTrafficPath,proto3" json:"ClientEndpointTrafficPath,omitempty" yaml:"client_endpoint_traffic_path"`
+	ClientBootstrapTimePath                 string `protobuf:"bytes,13,opt,name=ClientBootstrapTimePath,proto3" json:"ClientBootstrapTimePath,omitempty" yaml:"client_bootstrap_time_path"`
+	ClientRollingRestartPath                string `protobuf:"bytes,14,opt,name=ClientRollingRestartPath,proto3" json:"ClientRollingRestartPath,omitempty" yaml:"client_rolling_restart_path"`
+	ClientWorkloadSummaryPath               string `protobuf:"bytes,15,opt,name=ClientWorkloadSummaryPath,proto3" json:"ClientWorkloadSummaryPath,omitempty" yaml:"client_workload_summary_path"`
+	// CollectorEndpoint is the gRPC endpoint of the 'collector',
+	// to report the interim results every second. Empty to disable.
+	CollectorEndpoint                  string `protobuf:"bytes,16,opt,name=CollectorEndpoint,proto3" json:"CollectorEndpoint,omitempty" yaml:"collector_endpoint"`
+	ClientConvergencePath              string `protobuf:"bytes,17,opt,name=ClientConvergencePath,proto3" json:"ClientConvergencePath,omitempty" yaml:"client_convergence_path"`
+	ClientChaosPath                    string `protobuf:"bytes,18,opt,name=ClientChaosPath,proto3" json:"ClientChaosPath,omitempty" yaml:"client_chaos_path"`
+	ClientServerLatencyCorrelationPath string `protobuf:"bytes,19,opt,name=ClientServerLatencyCorrelationPath,proto3" json:"ClientServerLatencyCorrelationPath,omitempty" yaml:"client_server_latency_correlation_path"`
+	ClientIdentityLeasePath            string `protobuf:"bytes,20,opt,name=ClientIdentityLeasePath,proto3" json:"ClientIdentityLeasePath,omitempty" yaml:"client_identity_lease_path"`
+	// CredentialsFile is the file of 'NAME=VALUE' lines for the connection
+	// settings and secrets, with the same names as the environment variables
+	// (e.g. 'ETCD_ENDPOINTS', 'CONSUL_HTTP_TOKEN'). Environment variables
+	// take precedence over the file.
+	CredentialsFile              string `protobuf:"bytes,21,opt,name=CredentialsFile,proto3" json:"CredentialsFile,omitempty" yaml:"credentials_file"`
+	ClientSchedulePath           string `protobuf:"bytes,22,opt,name=ClientSchedulePath,proto3" json:"ClientSchedulePath,omitempty" yaml:"client_schedule_path"`
+	ClientInterferencePath       string `protobuf:"bytes,23,opt,name=ClientInterferencePath,proto3" json:"ClientInterferencePath,omitempty" yaml:"client_interference_path"`
+	ClientConvergenceSummaryPath string `protobuf:"bytes,24,opt,name=ClientConvergenceSummaryPath,proto3" json:"ClientConvergenceSummaryPath,omitempty" yaml:"client_convergence_summary_path"`
+	ClientLearnerReadsPath       string `protobuf:"bytes,25,opt,name=ClientLearnerReadsPath,proto3" json:"ClientLearnerReadsPath,omitempty" yaml:"client_learner_reads_path"`
+	// ClientOpenMetricsDir is the directory to write the results of each
+	// second in OpenMetrics text format every second, one file per write,
+	// to backfill into a time series database later. Empty to disable.
+	ClientOpenMetricsDir string `protobuf:"bytes,26,opt,name=ClientOpenMetricsDir,proto3" json:"ClientOpenMetricsDir,omitempty" yaml:"client_openmetrics_dir"`
+	// ClientSizeHistogramPath is the path to save the histograms of the
+	// request and response sizes of all requests. Empty to not record the sizes.
+	ClientSizeHistogramPath string `protobuf:"bytes,27,opt,name=ClientSizeHistogramPath,proto3" json:"ClientSizeHistogramPath,omitempty" yaml:"client_size_histogram_path"`
+	ClientFailoverPath      string `protobuf:"bytes,28,opt,name=ClientFailoverPath,proto3" json:"ClientFailoverPath,omitempty" yaml:"client_failover_path"`
+	// ClientSpikeRecoveryPath is the path to save the recovery time
+	// of the p99 latency after each fault.
+	ClientSpikeRecoveryPath string `protobuf:"bytes,29,opt,name=ClientSpikeRecoveryPath,proto3" json:"ClientSpikeRecoveryPath,omitempty" yaml:"client_spike_recovery_path"`
+	// ClientTLSHandshakePath is the path to save the TLS handshake durations.
+	ClientTLSHandshakePath string `protobuf:"bytes,30,opt,name=ClientTLSHandshakePath,proto3" json:"ClientTLSHandshakePath,omitempty" yaml:"client_tls_handshake_path"`
+	// ClientLatencyHgrmPath is the path to save the latency histogram
+	// in the .hgrm format.
+	ClientLatencyHgrmPath          string `protobuf:"bytes,31,opt,name=ClientLatencyHgrmPath,proto3" json:"ClientLatencyHgrmPath,omitempty" yaml:"client_latency_hgrm_path"`
+	GoogleCloudProjectName         string `protobuf:"bytes,100,opt,name=GoogleCloudProjectName,proto3" json:"GoogleCloudProjectName,omitempty" yaml:"google_cloud_project_name"`
+	GoogleCloudStorageKeyPath      string `protobuf:"bytes,101,opt,name=GoogleCloudStorageKeyPath,proto3" json:"GoogleCloudStorageKeyPath,omitempty" yaml:"google_cloud_storage_key_path"`
+	GoogleCloudStorageKey          string `protobuf:"bytes,102,opt,name=GoogleCloudStorageKey,proto3" json:"GoogleCloudStorageKey,omitempty"`
+	GoogleCloudStorageBucketName   string `protobuf:"bytes,103,opt,name=GoogleCloudStorageBucketName,proto3" json:"GoogleCloudStorageBucketName,omitempty" yaml:"google_cloud_storage_bucket_name"`
+	GoogleCloudStorageSubDirectory string `protobuf:"bytes,104,opt,name=GoogleCloudStorageSubDirectory,proto3" json:"GoogleCloudStorageSubDirectory,omitempty" yaml:"google_cloud_storage_sub_directory"`
+	// ClientMemberBreakdownPath is the path to save the requests and the
+	// latencies of each member that served them, from the etcd response
+	// headers. Empty to not attribute the requests.
+	ClientMemberBreakdownPath string `protobuf:"bytes,32,opt,name=ClientMemberBreakdownPath,proto3" json:"ClientMemberBreakdownPath,omitempty" yaml:"client_member_breakdown_path"`
+	// ClientTermChangePath is the path to save the raft term changes
+	// seen in the etcd response headers, with the member that served
+	// the first request of each new term.
+	ClientTermChangePath string `protobuf:"bytes,33,opt,name=ClientTermChangePath,proto3" json:"ClientTermChangePath,omitempty" yaml:"client_term_change_path"`
+	// ClientResourceUsagePath is the path to save the resource usage of
+	// the database processes of 'resource_monitor', with the throughput
+	// of each second.
+	ClientResourceUsagePath string `protobuf:"bytes,34,opt,name=ClientResourceUsagePath,proto3" json:"ClientResourceUsagePath,omitempty" yaml:"client_resource_usage_path"`
+	// ClientZoneLatencyPath is the path to save the requests and the
+	// latencies of each pair of the loader zone and the server zone, of
+	// 'control --endpoint-zones'.
+	ClientZoneLatencyPath string `protobuf:"bytes,35,opt,name=ClientZoneLatencyPath,proto3" json:"ClientZoneLatencyPath,omitempty" yaml:"client_zone_latency_path"`
+	// ClientSocketStatsPath is the path to save the TCP sockets of the loader host to each database endpoint
+	// of each second, to catch the ephemeral port exhaustion and the conntrack limits.
+	ClientSocketStatsPath string `protobuf:"bytes,36,opt,name=ClientSocketStatsPath,proto3" json:"ClientSocketStatsPath,omitempty" yaml:"client_socket_stats_path"`
+	// ClientMaintenancePath is the path to save the timeline of the compactions
+	// and the defragmentations of 'auto_compact_seconds'.
+	ClientMaintenancePath string `protobuf:"bytes,37,opt,name=ClientMaintenancePath,proto3" json:"ClientMaintenancePath,omitempty" yaml:"client_maintenance_path"`
 }
 
 func (m *ConfigClientMachineInitial) Reset()         { *m = ConfigClientMachineInitial{} }
@@ -53,6 +112,167 @@ type ConfigClientMachineBenchmarkOptions struct {
 	KeySizeBytes               int64   `protobuf:"varint,8,opt,name=KeySizeBytes,proto3" json:"KeySizeBytes,omitempty" yaml:"key_size_bytes"`
 	ValueSizeBytes             int64   `protobuf:"varint,9,opt,name=ValueSizeBytes,proto3" json:"ValueSizeBytes,omitempty" yaml:"value_size_bytes"`
 	StaleRead                  bool    `protobuf:"varint,10,opt,name=StaleRead,proto3" json:"StaleRead,omitempty" yaml:"stale_read"`
+	CalibrateHarness           bool    `protobuf:"varint,11,opt,name=CalibrateHarness,proto3" json:"CalibrateHarness,omitempty" yaml:"calibrate_harness"`
+	// Prepopulate is the number of keys to write before 'read',
+	// so that reads are spread over existing keys.
+	Prepopulate int64 `protobuf:"varint,12,opt,name=Prepopulate,proto3" json:"Prepopulate,omitempty" yaml:"prepopulate"`
+	// ZookeeperDigestAuth is 'user:password' for Zookeeper 'digest' scheme.
+	// If not empty, znodes are created with the digest ACL of the user,
+	// and all clients are authenticated as the user.
+	ZookeeperDigestAuth string `protobuf:"bytes,13,opt,name=ZookeeperDigestAuth,proto3" json:"ZookeeperDigestAuth,omitempty" yaml:"zookeeper_digest_auth"`
+	// EtcdRBAC is 'root' or 'restricted', to enable etcd authentication
+	// and run the workload as root, or as a user with key-prefix permissions.
+	// 'restricted' also runs a baseline as root to report the overhead.
+	EtcdRBAC string `protobuf:"bytes,14,opt,name=EtcdRBAC,proto3" json:"EtcdRBAC,omitempty" yaml:"etcd_rbac"`
+	// TraceSampleNumber is the number of requests to sample evenly,
+	// to save their request and response metadata.
+	TraceSampleNumber int64 `protobuf:"varint,15,opt,name=TraceSampleNumber,proto3" json:"TraceSampleNumber,omitempty" yaml:"trace_sample_number"`
+	// HedgeAfterMicroseconds is the duration after which 'read' sends
+	// a duplicate read to another endpoint, if the first has not returned.
+	HedgeAfterMicroseconds           int64                             `protobuf:"varint,16,opt,name=HedgeAfterMicroseconds,proto3" json:"HedgeAfterMicroseconds,omitempty" yaml:"hedge_after_microseconds"`
+	ConfigClientMachineHealthRouting *ConfigClientMachineHealthRouting `protobuf:"bytes,17,opt,name=ConfigClientMachineHealthRouting" json:"ConfigClientMachineHealthRouting,omitempty" yaml:"health_routing"`
+	// KeySpaceSize is the number of keys that 'write' picks randomly from,
+	// instead of writing sequential keys once. 0 to write sequential keys.
+	KeySpaceSize int64 `protobuf:"varint,18,opt,name=KeySpaceSize,proto3" json:"KeySpaceSize,omitempty" yaml:"key_space_size"`
+	// KeyPartitioned is true to shard the key space across clients,
+	// so that each client owns a disjoint key range. Otherwise,
+	// all clients share the whole key space.
+	KeyPartitioned bool `protobuf:"varint,19,opt,name=KeyPartitioned,proto3" json:"KeyPartitioned,omitempty" yaml:"key_partitioned"`
+	// Namespace is the prefix of all keys (e.g. 'tenant-1/'), to benchmark
+	// a tenant of a multi-tenant cluster. Same as etcd clientv3 namespace,
+	// it is a raw key prefix. For Zookeeper, parent znodes are created.
+	Namespace string `protobuf:"bytes,20,opt,name=Namespace,proto3" json:"Namespace,omitempty" yaml:"namespace"`
+	// TxnKeyNumber is the number of keys that each 'txn' transaction
+	// reads, checks and writes.
+	TxnKeyNumber int64 `protobuf:"varint,21,opt,name=TxnKeyNumber,proto3" json:"TxnKeyNumber,omitempty" yaml:"txn_key_number"`
+	// TxnOverlapPercent is the probability of each key of a 'txn'
+	// transaction being shared by all clients, instead of owned by
+	// the client. Shared keys conflict with other clients' transactions.
+	TxnOverlapPercent int64 `protobuf:"varint,22,opt,name=TxnOverlapPercent,proto3" json:"TxnOverlapPercent,omitempty" yaml:"txn_overlap_percent"`
+	// MeasureRecovery is true to restart all members with their data
+	// after 'step2_stress_database', and measure the time until the
+	// cluster serves the first linearizable read.
+	MeasureRecovery                   bool                               `protobuf:"varint,23,opt,name=MeasureRecovery,proto3" json:"MeasureRecovery,omitempty" yaml:"measure_recovery"`
+	ConfigClientMachineRollingRestart *ConfigClientMachineRollingRestart `protobuf:"bytes,24,opt,name=ConfigClientMachineRollingRestart" json:"ConfigClientMachineRollingRestart,omitempty" yaml:"rolling_restart"`
+	// OpenLoop is true to send requests at the fixed intervals of
+	// 'rate_limit_requests_per_second' regardless of completions, and
+	// measure the latency from the scheduled time, so that the queueing
+	// delay of a saturated database is included in the latency.
+	OpenLoop bool `protobuf:"varint,25,opt,name=OpenLoop,proto3" json:"OpenLoop,omitempty" yaml:"open_loop"`
+	// ConfigClientMachineWorkloads are the workloads of 'mixed' type,
+	// that run concurrently on the prepopulated keys.
+	ConfigClientMachineWorkloads        []*ConfigClientMachineWorkload       `protobuf:"bytes,26,rep,name=ConfigClientMachineWorkloads" json:"ConfigClientMachineWorkloads,omitempty" yaml:"workloads"`
+	ConfigClientMachineConvergenceProbe *ConfigClientMachineConvergenceProbe `protobuf:"bytes,27,opt,name=ConfigClientMachineConvergenceProbe" json:"ConfigClientMachineConvergenceProbe,omitempty" yaml:"convergence_probe"`
+	// Chaos is the fault injection schedule while the benchmark runs
+	// (e.g. 'at 2m kill leader; at 4m partition n1,n2|n3,n4,n5 for 30s; at 6m heal').
+	Chaos string `protobuf:"bytes,28,opt,name=Chaos,proto3" json:"Chaos,omitempty" yaml:"chaos"`
+	// ChaosFile is the file of the 'chaos' schedule, one event per line.
+	ChaosFile                        string                            `protobuf:"bytes,29,opt,name=ChaosFile,proto3" json:"ChaosFile,omitempty" yaml:"chaos_file"`
+	ConfigClientMachineEtcdMetrics   *ConfigClientMachineEtcdMetrics   `protobuf:"bytes,30,opt,name=ConfigClientMachineEtcdMetrics" json:"ConfigClientMachineEtcdMetrics,omitempty" yaml:"etcd_metrics"`
+	ConfigClientMachineIdentityLease *ConfigClientMachineIdentityLease `protobuf:"bytes,31,opt,name=ConfigClientMachineIdentityLease" json:"ConfigClientMachineIdentityLease,omitempty" yaml:"identity_lease"`
+	// ConsulToken is the ACL token of all Consul clients.
+	// It is read from 'CONSUL_HTTP_TOKEN', not from the configuration file.
+	ConsulToken string `protobuf:"bytes,32,opt,name=ConsulToken,proto3" json:"ConsulToken,omitempty" yaml:"-"`
+	// EtcdUsername and EtcdPassword are the credentials of all etcd clients.
+	// They are read from 'ETCD_USERNAME' and 'ETCD_PASSWORD', not from the configuration file.
+	EtcdUsername string `protobuf:"bytes,33,opt,name=EtcdUsername,proto3" json:"EtcdUsername,omitempty" yaml:"-"`
+	EtcdPassword string `protobuf:"bytes,34,opt,name=EtcdPassword,proto3" json:"EtcdPassword,omitempty" yaml:"-"`
+	// RequestTimeoutMilliseconds is the deadline of each request from its
+	// scheduled time. With 'rate_limit_requests_per_second', the intervals
+	// where the rate could not be sustained and the backlog are reported.
+	RequestTimeoutMilliseconds int64 `protobuf:"varint,35,opt,name=RequestTimeoutMilliseconds,proto3" json:"RequestTimeoutMilliseconds,omitempty" yaml:"request_timeout_milliseconds"`
+	// MeasureInterference is true to run each of the 'mixed' workloads
+	// alone before running them all at once, and report the latency of
+	// each workload with and without the others (noisy neighbors).
+	MeasureInterference             bool                             `protobuf:"varint,36,opt,name=MeasureInterference,proto3" json:"MeasureInterference,omitempty" yaml:"measure_interference"`
+	ConfigClientMachineLearnerReads *ConfigClientMachineLearnerReads `protobuf:"bytes,37,opt,name=ConfigClientMachineLearnerReads" json:"ConfigClientMachineLearnerReads,omitempty" yaml:"learner_reads"`
+	// TimeSeriesDownsampleSeconds is the bucket to downsample the time series
+	// to, beyond the recent window of 'time_series_full_resolution_seconds',
+	// so that the memory of multi-day runs stays bounded. 0 to keep every second.
+	TimeSeriesDownsampleSeconds int64 `protobuf:"varint,38,opt,name=TimeSeriesDownsampleSeconds,proto3" json:"TimeSeriesDownsampleSeconds,omitempty" yaml:"time_series_downsample_seconds"`
+	// TimeSeriesFullResolutionSeconds is the recent window of the time series
+	// kept at full resolution, when downsampled. 3600 by default.
+	TimeSeriesFullResolutionSeconds int64                        `protobuf:"varint,39,opt,name=TimeSeriesFullResolutionSeconds,proto3" json:"TimeSeriesFullResolutionSeconds,omitempty" yaml:"time_series_full_resolution_seconds"`
+	ConfigClientMachineFailover     *ConfigClientMachineFailover `protobuf:"bytes,40,opt,name=ConfigClientMachineFailover" json:"ConfigClientMachineFailover,omitempty" yaml:"failover"`
+	// ValueTemplate is 'json', 'yaml' or 'protobuf', to write values that
+	// resemble configuration payloads, instead of random bytes. Values are
+	// padded or truncated to 'value_size_bytes'.
+	ValueTemplate string `protobuf:"bytes,41,opt,name=ValueTemplate,proto3" json:"ValueTemplate,omitempty" yaml:"value_template"`
+	// ValueSeed is the seed of 'value_template', so that runs write the same values.
+	ValueSeed int64 `protobuf:"varint,42,opt,name=ValueSeed,proto3" json:"ValueSeed,omitempty" yaml:"value_seed"`
+	// ConfigClientMachineSpikeRecovery measures the recovery time of the p99
+	// latency after each injected fault. Nil to not measure.
+	ConfigClientMachineSpikeRecovery *ConfigClientMachineSpikeRecovery `protobuf:"bytes,43,opt,name=ConfigClientMachineSpikeRecovery" json:"ConfigClientMachineSpikeRecovery,omitempty" yaml:"spike_recovery"`
+	// ReadPercent is the percent of reads of 'read-write', interleaved with
+	// writes from the same clients. For example, 95 for 95% reads and 5% writes.
+	ReadPercent int64 `protobuf:"varint,44,opt,name=ReadPercent,proto3" json:"ReadPercent,omitempty" yaml:"read_percent"`
+	// KeyDistribution is the distribution of the keys that reads, and writes
+	// of 'key_space_size', access: 'uniform', 'zipfian', 'latest' or 'hotspot'.
+	// Empty to read the keys in order, and write uniformly random keys.
+	KeyDistribution string `protobuf:"bytes,45,opt,name=KeyDistribution,proto3" json:"KeyDistribution,omitempty" yaml:"key_distribution"`
+	// ReadPercentEnd is the percent of reads at the end of 'read-write',
+	// drifting linearly from 'read_percent' over the requests. For example,
+	// 'read_percent' 90 and 'read_percent_end' 50. 0 to not drift.
+	ReadPercentEnd int64 `protobuf:"varint,46,opt,name=ReadPercentEnd,proto3" json:"ReadPercentEnd,omitempty" yaml:"read_percent_end"`
+	// ConfigClientMachineTLSHandshake measures the TLS handshakes of new
+	// connections while the benchmark runs. Nil to not measure.
+	ConfigClientMachineTLSHandshake *ConfigClientMachineTLSHandshake `protobuf:"bytes,47,opt,name=ConfigClientMachineTLSHandshake" json:"ConfigClientMachineTLSHandshake,omitempty" yaml:"tls_handshake"`
+	// DurationSeconds is the duration of the run, to issue requests until
+	// it expires instead of 'request_number'. 0 to run 'request_number'.
+	DurationSeconds int64 `protobuf:"varint,48,opt,name=DurationSeconds,proto3" json:"DurationSeconds,omitempty" yaml:"duration_seconds"`
+	// RampUpSeconds is the duration to start the clients, one at a time,
+	// at an even pace from one client to all 'client_number' clients,
+	// to find the concurrency where the latencies degrade. 0 to start all.
+	RampUpSeconds int64 `protobuf:"varint,49,opt,name=RampUpSeconds,proto3" json:"RampUpSeconds,omitempty" yaml:"ramp_up_seconds"`
+	// EtcdIgnoreValue writes the existing keys of etcd with no value and
+	// 'WithIgnoreValue', to update their revisions but keep their values,
+	// as "touch" writes. 'write' requires 'key_space_size'.
+	EtcdIgnoreValue bool `protobuf:"varint,50,opt,name=EtcdIgnoreValue,proto3" json:"EtcdIgnoreValue,omitempty" yaml:"etcd_ignore_value"`
+	// EtcdIgnoreLease writes the existing keys of etcd with
+	// 'WithIgnoreLease', to keep their leases, as metadata updates.
+	// 'write' requires 'key_space_size'.
+	EtcdIgnoreLease bool `protobuf:"varint,51,opt,name=EtcdIgnoreLease,proto3" json:"EtcdIgnoreLease,omitempty" yaml:"etcd_ignore_lease"`
+	// EtcdAPIVersion is the etcd client API that the benchmarks issue the
+	// requests with: 'clientv3' (default), of the vendored client with its
+	// balancer and retries, or 'grpc', of the KV service of the versioned
+	// proto directly on one endpoint per connection, with neither.
+	EtcdAPIVersion string `protobuf:"bytes,52,opt,name=EtcdAPIVersion,proto3" json:"EtcdAPIVersion,omitempty" yaml:"etcd_api_version"`
+	// ConfigClientMachineResourceMonitor is set to sample the resource
+	// usage of the database processes every second of the run.
+	ConfigClientMachineResourceMonitor *ConfigClientMachineResourceMonitor `protobuf:"bytes,53,opt,name=ConfigClientMachineResourceMonitor" json:"ConfigClientMachineResourceMonitor,omitempty" yaml:"resource_monitor"`
+	// LockKeyNumber is the number of keys whose locks the clients of
+	// 'lock' contend for. 1 by default, for all clients to contend.
+	LockKeyNumber int64 `protobuf:"varint,54,opt,name=LockKeyNumber,proto3" json:"LockKeyNumber,omitempty" yaml:"lock_key_number"`
+	// LockHoldMilliseconds is the time to hold each 'lock' lock.
+	LockHoldMilliseconds int64 `protobuf:"varint,55,opt,name=LockHoldMilliseconds,proto3" json:"LockHoldMilliseconds,omitempty" yaml:"lock_hold_milliseconds"`
+	// LockInvalidatePercent is the probability of invalidating the session
+	// that holds a 'lock' lock, instead of releasing the lock.
+	LockInvalidatePercent int64 `protobuf:"varint,56,opt,name=LockInvalidatePercent,proto3" json:"LockInvalidatePercent,omitempty" yaml:"lock_invalidate_percent"`
+	// ConsulLockDelayMilliseconds is the lock-delay of the Consul sessions
+	// of 'lock', for which the lock of an invalidated session cannot be
+	// acquired. 0 for the Consul default of 15 seconds.
+	ConsulLockDelayMilliseconds int64 `protobuf:"varint,57,opt,name=ConsulLockDelayMilliseconds,proto3" json:"ConsulLockDelayMilliseconds,omitempty" yaml:"consul_lock_delay_milliseconds"`
+	// BankAccountNumber is the number of accounts that 'bank' transfers
+	// between. 10 by default.
+	BankAccountNumber int64 `protobuf:"varint,58,opt,name=BankAccountNumber,proto3" json:"BankAccountNumber,omitempty" yaml:"bank_account_number"`
+	// BankInitialBalance is the initial balance of each 'bank' account,
+	// whose total is checked after the transfers. 100 by default.
+	BankInitialBalance int64 `protobuf:"varint,59,opt,name=BankInitialBalance,proto3" json:"BankInitialBalance,omitempty" yaml:"bank_initial_balance"`
+	// WarmupRequestNumber is the number of the first requests of the run whose results are discarded,
+	// to leave the connection establishment and the cache warmup out of the statistics. 'request_number' includes them.
+	WarmupRequestNumber int64 `protobuf:"varint,60,opt,name=WarmupRequestNumber,proto3" json:"WarmupRequestNumber,omitempty" yaml:"warmup_request_number"`
+	// WarmupSeconds is the duration from the start of the run whose results are discarded,
+	// as of 'warmup_request_number'.
+	WarmupSeconds int64 `protobuf:"varint,61,opt,name=WarmupSeconds,proto3" json:"WarmupSeconds,omitempty" yaml:"warmup_seconds"`
+	// AutoCompactSeconds is the interval to compact the etcd history during the run, through the Maintenance API,
+	// retaining the revisions of the last interval. 0 to not compact.
+	AutoCompactSeconds int64 `protobuf:"varint,62,opt,name=AutoCompactSeconds,proto3" json:"AutoCompactSeconds,omitempty" yaml:"auto_compact_seconds"`
+	// AutoDefrag is true to defragment each etcd member after each compaction
+	// of 'auto_compact_seconds'.
+	AutoDefrag bool `protobuf:"varint,63,opt,name=AutoDefrag,proto3" json:"AutoDefrag,omitempty" yaml:"auto_defrag"`
+	// KeyOrder is the order of the unique keys to write: 'sequential',
+	// 'random' or 'reverse'. Empty for 'sequential'.
+	KeyOrder string `protobuf:"bytes,64,opt,name=KeyOrder,proto3" json:"KeyOrder,omitempty" yaml:"key_order"`
 }
 
 func (m *ConfigClientMachineBenchmarkOptions) Reset()         { *m = ConfigClientMachineBenchmarkOptions{} }
@@ -62,6 +282,131 @@ func (*ConfigClientMachineBenchmarkOptions) Descriptor() ([]byte, []int) {
 	return fileDescriptorConfigClientMachine, []int{1}
 }
 
+// ConfigClientMachineIdentityLease represents each client keeping alive
+// its own lease-backed identity key while the benchmark runs, which adds
+// the background keepalive load of the real fleets.
+type ConfigClientMachineIdentityLease struct {
+	// TTLSeconds is the TTL of the identity leases. 10 by default.
+	TTLSeconds int64 `protobuf:"varint,1,opt,name=TTLSeconds,proto3" json:"TTLSeconds,omitempty" yaml:"ttl_seconds"`
+}
+
+func (m *ConfigClientMachineIdentityLease) Reset()         { *m = ConfigClientMachineIdentityLease{} }
+func (m *ConfigClientMachineIdentityLease) String() string { return proto.CompactTextString(m) }
+func (*ConfigClientMachineIdentityLease) ProtoMessage()    {}
+func (*ConfigClientMachineIdentityLease) Descriptor() ([]byte, []int) {
+	return fileDescriptorConfigClientMachine, []int{2}
+}
+
+// ConfigClientMachineEtcdMetrics represents scraping the etcd metrics
+// while the benchmark runs, to correlate the client latency with the
+// server disk, raft and network latency.
+type ConfigClientMachineEtcdMetrics struct {
+	// ScrapeIntervalSeconds is the interval to scrape '/metrics'. 1 by default.
+	ScrapeIntervalSeconds int64 `protobuf:"varint,1,opt,name=ScrapeIntervalSeconds,proto3" json:"ScrapeIntervalSeconds,omitempty" yaml:"scrape_interval_seconds"`
+}
+
+func (m *ConfigClientMachineEtcdMetrics) Reset()         { *m = ConfigClientMachineEtcdMetrics{} }
+func (m *ConfigClientMachineEtcdMetrics) String() string { return proto.CompactTextString(m) }
+func (*ConfigClientMachineEtcdMetrics) ProtoMessage()    {}
+func (*ConfigClientMachineEtcdMetrics) Descriptor() ([]byte, []int) {
+	return fileDescriptorConfigClientMachine, []int{3}
+}
+
+// ConfigClientMachineConvergenceProbe represents measuring the time
+// for all endpoints to serve a new value with stale reads, after the
+// writes of the benchmark.
+type ConfigClientMachineConvergenceProbe struct {
+	// IntervalSeconds is the interval to probe while the benchmark runs.
+	// 0 to probe only after each burst of writes; that is, after the
+	// benchmark, or after each of 'connection_client_numbers'.
+	IntervalSeconds int64 `protobuf:"varint,1,opt,name=IntervalSeconds,proto3" json:"IntervalSeconds,omitempty" yaml:"interval_seconds"`
+	// TimeoutSeconds is the maximum time to wait for an endpoint.
+	TimeoutSeconds int64 `protobuf:"varint,2,opt,name=TimeoutSeconds,proto3" json:"TimeoutSeconds,omitempty" yaml:"timeout_seconds"`
+	// WriteEndpointIndex is the index of the endpoint to write the marker
+	// through, so that the divergence of the other endpoints is measured
+	// from the same server.
+	WriteEndpointIndex int64 `protobuf:"varint,3,opt,name=WriteEndpointIndex,proto3" json:"WriteEndpointIndex,omitempty" yaml:"write_endpoint_index"`
+}
+
+func (m *ConfigClientMachineConvergenceProbe) Reset()         { *m = ConfigClientMachineConvergenceProbe{} }
+func (m *ConfigClientMachineConvergenceProbe) String() string { return proto.CompactTextString(m) }
+func (*ConfigClientMachineConvergenceProbe) ProtoMessage()    {}
+func (*ConfigClientMachineConvergenceProbe) Descriptor() ([]byte, []int) {
+	return fileDescriptorConfigClientMachine, []int{4}
+}
+
+// ConfigClientMachineWorkload represents one of the concurrent workloads.
+type ConfigClientMachineWorkload struct {
+	Name string `protobuf:"bytes,1,opt,name=Name,proto3" json:"Name,omitempty" yaml:"name"`
+	// Type is 'write', 'read', 'delete' or 'watch'.
+	Type string `protobuf:"bytes,2,opt,name=Type,proto3" json:"Type,omitempty" yaml:"type"`
+	// Percent is the share of 'request_number' and 'client_number'.
+	Percent int64 `protobuf:"varint,3,opt,name=Percent,proto3" json:"Percent,omitempty" yaml:"percent"`
+	// RateLimitRequestsPerSecond is the rate limit of this workload only,
+	// or the guaranteed rate of a 'guaranteed' workload.
+	RateLimitRequestsPerSecond int64 `protobuf:"varint,4,opt,name=RateLimitRequestsPerSecond,proto3" json:"RateLimitRequestsPerSecond,omitempty" yaml:"rate_limit_requests_per_second"`
+	// Priority is 'guaranteed' or 'best-effort', to throttle the
+	// 'best-effort' workloads while a 'guaranteed' one falls behind.
+	Priority string `protobuf:"bytes,5,opt,name=Priority,proto3" json:"Priority,omitempty" yaml:"priority"`
+	// RateShare is the weight of a 'best-effort' workload in the rate
+	// of 'rate_limit_requests_per_second' left by the 'guaranteed' ones.
+	RateShare int64 `protobuf:"varint,6,opt,name=RateShare,proto3" json:"RateShare,omitempty" yaml:"rate_share"`
+	// Watchers is the number of watchers of a 'watch' workload, which
+	// watch the keys of the other workloads while they run.
+	Watchers int64 `protobuf:"varint,7,opt,name=Watchers,proto3" json:"Watchers,omitempty" yaml:"watchers"`
+}
+
+func (m *ConfigClientMachineWorkload) Reset()         { *m = ConfigClientMachineWorkload{} }
+func (m *ConfigClientMachineWorkload) String() string { return proto.CompactTextString(m) }
+func (*ConfigClientMachineWorkload) ProtoMessage()    {}
+func (*ConfigClientMachineWorkload) Descriptor() ([]byte, []int) {
+	return fileDescriptorConfigClientMachine, []int{5}
+}
+
+// ConfigClientMachineRollingRestart represents restarting
+// the members one at a time, while the workload runs.
+type ConfigClientMachineRollingRestart struct {
+	// StartSecond is the second since the start of
+	// the benchmark to shut down the first member.
+	StartSecond int64 `protobuf:"varint,1,opt,name=StartSecond,proto3" json:"StartSecond,omitempty" yaml:"start_second"`
+	// DownSeconds is the duration that each member stays down.
+	DownSeconds int64 `protobuf:"varint,2,opt,name=DownSeconds,proto3" json:"DownSeconds,omitempty" yaml:"down_seconds"`
+	// PauseSeconds is the pause after restarting a member,
+	// before shutting down the next member.
+	PauseSeconds int64 `protobuf:"varint,3,opt,name=PauseSeconds,proto3" json:"PauseSeconds,omitempty" yaml:"pause_seconds"`
+}
+
+func (m *ConfigClientMachineRollingRestart) Reset()         { *m = ConfigClientMachineRollingRestart{} }
+func (m *ConfigClientMachineRollingRestart) String() string { return proto.CompactTextString(m) }
+func (*ConfigClientMachineRollingRestart) ProtoMessage()    {}
+func (*ConfigClientMachineRollingRestart) Descriptor() ([]byte, []int) {
+	return fileDescriptorConfigClientMachine, []int{6}
+}
+
+// ConfigClientMachineHealthRouting represents client-side health-aware
+// routing, that stops sending requests to failing endpoints.
+type ConfigClientMachineHealthRouting struct {
+	// ErrorThreshold is the number of consecutive errors
+	// to mark an endpoint unhealthy. 0 to disable.
+	ErrorThreshold int64 `protobuf:"varint,1,opt,name=ErrorThreshold,proto3" json:"ErrorThreshold,omitempty" yaml:"error_threshold"`
+	// ProbeIntervalMilliseconds is the interval to re-probe unhealthy endpoints.
+	ProbeIntervalMilliseconds int64 `protobuf:"varint,2,opt,name=ProbeIntervalMilliseconds,proto3" json:"ProbeIntervalMilliseconds,omitempty" yaml:"probe_interval_milliseconds"`
+	// BlackoutEndpointIndex is the index of endpoint whose requests
+	// fail on the client side, to simulate a partial outage.
+	BlackoutEndpointIndex int64 `protobuf:"varint,3,opt,name=BlackoutEndpointIndex,proto3" json:"BlackoutEndpointIndex,omitempty" yaml:"blackout_endpoint_index"`
+	// BlackoutStartSecond is the second since the start of the benchmark to start the blackout.
+	BlackoutStartSecond int64 `protobuf:"varint,4,opt,name=BlackoutStartSecond,proto3" json:"BlackoutStartSecond,omitempty" yaml:"blackout_start_second"`
+	// BlackoutDurationSeconds is the duration of the blackout. 0 to disable.
+	BlackoutDurationSeconds int64 `protobuf:"varint,5,opt,name=BlackoutDurationSeconds,proto3" json:"BlackoutDurationSeconds,omitempty" yaml:"blackout_duration_seconds"`
+}
+
+func (m *ConfigClientMachineHealthRouting) Reset()         { *m = ConfigClientMachineHealthRouting{} }
+func (m *ConfigClientMachineHealthRouting) String() string { return proto.CompactTextString(m) }
+func (*ConfigClientMachineHealthRouting) ProtoMessage()    {}
+func (*ConfigClientMachineHealthRouting) Descriptor() ([]byte, []int) {
+	return fileDescriptorConfigClientMachine, []int{7}
+}
+
 // ConfigClientMachineBenchmarkSteps represents benchmark steps.
 type ConfigClientMachineBenchmarkSteps struct {
 	Step1StartDatabase  bool `protobuf:"varint,1,opt,name=Step1StartDatabase,proto3" json:"Step1StartDatabase,omitempty" yaml:"step1_start_database"`
@@ -74,7 +419,7 @@ func (m *ConfigClientMachineBenchmarkSteps) Reset()         { *m = ConfigClientM
 func (m *ConfigClientMachineBenchmarkSteps) String() string { return proto.CompactTextString(m) }
 func (*ConfigClientMachineBenchmarkSteps) ProtoMessage()    {}
 func (*ConfigClientMachineBenchmarkSteps) Descriptor() ([]byte, []int) {
-	return fileDescriptorConfigClientMachine, []int{2}
+	return fileDescriptorConfigClientMachine, []int{8}
 }
 
 // ConfigClientMachineAgentControl represents control options on client machine.
@@ -96,6 +441,9 @@ type ConfigClientMachineAgentControl struct {
 	Flag_Consul_V1_0_2                  *Flag_Consul_V1_0_2                  `protobuf:"bytes,300,opt,name=flag__consul__v1_0_2,json=flagConsulV102" json:"flag__consul__v1_0_2,omitempty" yaml:"consul__v1_0_2"`
 	Flag_Cetcd_Beta                     *Flag_Cetcd_Beta                     `protobuf:"bytes,400,opt,name=flag__cetcd__beta,json=flagCetcdBeta" json:"flag__cetcd__beta,omitempty" yaml:"cetcd__beta"`
 	Flag_Zetcd_Beta                     *Flag_Zetcd_Beta                     `protobuf:"bytes,500,opt,name=flag__zetcd__beta,json=flagZetcdBeta" json:"flag__zetcd__beta,omitempty" yaml:"zetcd__beta"`
+	Flag_Redis_V4_0                     *Flag_Redis_V4_0                     `protobuf:"bytes,600,opt,name=flag__redis__v4_0,json=flagRedisV40" json:"flag__redis__v4_0,omitempty" yaml:"redis__v4_0"`
+	Flag_Tikv_V2_1                      *Flag_Tikv_V2_1                      `protobuf:"bytes,700,opt,name=flag__tikv__v2_1,json=flagTikvV21" json:"flag__tikv__v2_1,omitempty" yaml:"tikv__v2_1"`
+	Flag_Mock                           *Flag_Mock                           `protobuf:"bytes,900,opt,name=flag__mock,json=flagMock" json:"flag__mock,omitempty" yaml:"mock"`
 	ConfigClientMachineBenchmarkOptions *ConfigClientMachineBenchmarkOptions `protobuf:"bytes,1000,opt,name=ConfigClientMachineBenchmarkOptions" json:"ConfigClientMachineBenchmarkOptions,omitempty" yaml:"benchmark_options"`
 	ConfigClientMachineBenchmarkSteps   *ConfigClientMachineBenchmarkSteps   `protobuf:"bytes,1001,opt,name=ConfigClientMachineBenchmarkSteps" json:"ConfigClientMachineBenchmarkSteps,omitempty" yaml:"benchmark_steps"`
 }
@@ -104,14 +452,132 @@ func (m *ConfigClientMachineAgentControl) Reset()         { *m = ConfigClientMac
 func (m *ConfigClientMachineAgentControl) String() string { return proto.CompactTextString(m) }
 func (*ConfigClientMachineAgentControl) ProtoMessage()    {}
 func (*ConfigClientMachineAgentControl) Descriptor() ([]byte, []int) {
-	return fileDescriptorConfigClientMachine, []int{3}
+	return fileDescriptorConfigClientMachine, []int{9}
+}
+
+// ConfigClientMachineLearnerReads represents serializable reads from etcd
+// learner members, compared with the voting members.
+type ConfigClientMachineLearnerReads struct {
+	// Endpoints are the client endpoints of the learner members.
+	Endpoints []string `protobuf:"bytes,1,rep,name=Endpoints" json:"Endpoints,omitempty" yaml:"endpoints"`
+	// IntervalMilliseconds is the interval to read from every member.
+	IntervalMilliseconds int64 `protobuf:"varint,2,opt,name=IntervalMilliseconds,proto3" json:"IntervalMilliseconds,omitempty" yaml:"interval_milliseconds"`
+}
+
+func (m *ConfigClientMachineLearnerReads) Reset()         { *m = ConfigClientMachineLearnerReads{} }
+func (m *ConfigClientMachineLearnerReads) String() string { return proto.CompactTextString(m) }
+func (*ConfigClientMachineLearnerReads) ProtoMessage()    {}
+func (*ConfigClientMachineLearnerReads) Descriptor() ([]byte, []int) {
+	return fileDescriptorConfigClientMachine, []int{10}
+}
+
+// ConfigClientMachineFailover represents measuring the client-observed
+// failover time, when the member serving the client is killed.
+type ConfigClientMachineFailover struct {
+	// Trials is the number of kills for each client mode. 1 by default.
+	Trials int64 `protobuf:"varint,1,opt,name=Trials,proto3" json:"Trials,omitempty" yaml:"trials"`
+	// ProbeIntervalMilliseconds is the interval between reads. 10 by default.
+	ProbeIntervalMilliseconds int64 `protobuf:"varint,2,opt,name=ProbeIntervalMilliseconds,proto3" json:"ProbeIntervalMilliseconds,omitempty" yaml:"probe_interval_milliseconds"`
+	// RequestTimeoutMilliseconds is the timeout of each read, including
+	// the retries of clientv3. 1000 by default.
+	RequestTimeoutMilliseconds int64 `protobuf:"varint,3,opt,name=RequestTimeoutMilliseconds,proto3" json:"RequestTimeoutMilliseconds,omitempty" yaml:"request_timeout_milliseconds"`
+	// TimeoutSeconds is the maximum time to wait for the failover. 30 by default.
+	TimeoutSeconds int64 `protobuf:"varint,4,opt,name=TimeoutSeconds,proto3" json:"TimeoutSeconds,omitempty" yaml:"timeout_seconds"`
+}
+
+func (m *ConfigClientMachineFailover) Reset()         { *m = ConfigClientMachineFailover{} }
+func (m *ConfigClientMachineFailover) String() string { return proto.CompactTextString(m) }
+func (*ConfigClientMachineFailover) ProtoMessage()    {}
+func (*ConfigClientMachineFailover) Descriptor() ([]byte, []int) {
+	return fileDescriptorConfigClientMachine, []int{11}
+}
+
+// ConfigClientMachineSpikeRecovery represents measuring the time for the
+// p99 latency to recover after each fault injected by 'chaos' or
+// 'rolling_restart'.
+type ConfigClientMachineSpikeRecovery struct {
+	// WithinPercent is how close to the baseline the p99 latency must
+	// return, in percent over the baseline. 20 by default.
+	WithinPercent int64 `protobuf:"varint,1,opt,name=WithinPercent,proto3" json:"WithinPercent,omitempty" yaml:"within_percent"`
+	// BaselineSeconds is the window before each event of the baseline
+	// p99 latency. 10 by default.
+	BaselineSeconds int64 `protobuf:"varint,2,opt,name=BaselineSeconds,proto3" json:"BaselineSeconds,omitempty" yaml:"baseline_seconds"`
+}
+
+func (m *ConfigClientMachineSpikeRecovery) Reset()         { *m = ConfigClientMachineSpikeRecovery{} }
+func (m *ConfigClientMachineSpikeRecovery) String() string { return proto.CompactTextString(m) }
+func (*ConfigClientMachineSpikeRecovery) ProtoMessage()    {}
+func (*ConfigClientMachineSpikeRecovery) Descriptor() ([]byte, []int) {
+	return fileDescriptorConfigClientMachine, []int{12}
+}
+
+// ConfigClientMachineTLSHandshake represents dialing the endpoints with TLS
+// while the benchmark runs, to measure the cost of full and resumed
+// handshakes on new connections.
+type ConfigClientMachineTLSHandshake struct {
+	// Endpoints are the TLS endpoints to dial. 'database_endpoints' by default.
+	Endpoints []string `protobuf:"bytes,1,rep,name=Endpoints" json:"Endpoints,omitempty" yaml:"endpoints"`
+	// CAFile is the CA certificate to verify the servers.
+	CAFile string `protobuf:"bytes,2,opt,name=CAFile,proto3" json:"CAFile,omitempty" yaml:"ca_file"`
+	// CertFile is the client certificate, if the servers require one.
+	CertFile string `protobuf:"bytes,3,opt,name=CertFile,proto3" json:"CertFile,omitempty" yaml:"cert_file"`
+	// KeyFile is the key of 'cert_file'.
+	KeyFile string `protobuf:"bytes,4,opt,name=KeyFile,proto3" json:"KeyFile,omitempty" yaml:"key_file"`
+	// InsecureSkipVerify is true to not verify the server certificates.
+	InsecureSkipVerify bool `protobuf:"varint,5,opt,name=InsecureSkipVerify,proto3" json:"InsecureSkipVerify,omitempty" yaml:"insecure_skip_verify"`
+	// IntervalMilliseconds is the interval between the dials to every
+	// endpoint. 100 by default.
+	IntervalMilliseconds int64 `protobuf:"varint,6,opt,name=IntervalMilliseconds,proto3" json:"IntervalMilliseconds,omitempty" yaml:"interval_milliseconds"`
+}
+
+func (m *ConfigClientMachineTLSHandshake) Reset()         { *m = ConfigClientMachineTLSHandshake{} }
+func (m *ConfigClientMachineTLSHandshake) String() string { return proto.CompactTextString(m) }
+func (*ConfigClientMachineTLSHandshake) ProtoMessage()    {}
+func (*ConfigClientMachineTLSHandshake) Descriptor() ([]byte, []int) {
+	return fileDescriptorConfigClientMachine, []int{13}
+}
+
+// ConfigClientMachineResourceMonitor represents sampling the resource
+// usage of the database processes while the benchmark runs, to correlate
+// the throughput with the CPU, memory, disk and network usage.
+type ConfigClientMachineResourceMonitor struct {
+	// Agents is true to sample the database process of every agent,
+	// with the agent 'Metrics' operation.
+	Agents bool `protobuf:"varint,1,opt,name=Agents,proto3" json:"Agents,omitempty" yaml:"agents"`
+	// Programs are the names of the local processes to sample,
+	// for the databases that run on the client machine.
+	Programs []string `protobuf:"bytes,2,rep,name=Programs" json:"Programs,omitempty" yaml:"programs"`
+	// DiskDevice is the local disk device to sample, with 'programs'.
+	DiskDevice string `protobuf:"bytes,3,opt,name=DiskDevice,proto3" json:"DiskDevice,omitempty" yaml:"disk_device"`
+	// NetworkInterface is the local network interface to sample, with 'programs'.
+	NetworkInterface string `protobuf:"bytes,4,opt,name=NetworkInterface,proto3" json:"NetworkInterface,omitempty" yaml:"network_interface"`
+	// IntervalSeconds is the interval between the samples. 1 by default.
+	IntervalSeconds int64 `protobuf:"varint,5,opt,name=IntervalSeconds,proto3" json:"IntervalSeconds,omitempty" yaml:"interval_seconds"`
+}
+
+func (m *ConfigClientMachineResourceMonitor) Reset()         { *m = ConfigClientMachineResourceMonitor{} }
+func (m *ConfigClientMachineResourceMonitor) String() string { return proto.CompactTextString(m) }
+func (*ConfigClientMachineResourceMonitor) ProtoMessage()    {}
+func (*ConfigClientMachineResourceMonitor) Descriptor() ([]byte, []int) {
+	return fileDescriptorConfigClientMachine, []int{14}
 }
 
 func init() {
 	proto.RegisterType((*ConfigClientMachineInitial)(nil), "dbtesterpb.ConfigClientMachineInitial")
 	proto.RegisterType((*ConfigClientMachineBenchmarkOptions)(nil), "dbtesterpb.ConfigClientMachineBenchmarkOptions")
+	proto.RegisterType((*ConfigClientMachineIdentityLease)(nil), "dbtesterpb.ConfigClientMachineIdentityLease")
+	proto.RegisterType((*ConfigClientMachineEtcdMetrics)(nil), "dbtesterpb.ConfigClientMachineEtcdMetrics")
+	proto.RegisterType((*ConfigClientMachineConvergenceProbe)(nil), "dbtesterpb.ConfigClientMachineConvergenceProbe")
+	proto.RegisterType((*ConfigClientMachineWorkload)(nil), "dbtesterpb.ConfigClientMachineWorkload")
+	proto.RegisterType((*ConfigClientMachineRollingRestart)(nil), "dbtesterpb.ConfigClientMachineRollingRestart")
+	proto.RegisterType((*ConfigClientMachineHealthRouting)(nil), "dbtesterpb.ConfigClientMachineHealthRouting")
 	proto.RegisterType((*ConfigClientMachineBenchmarkSteps)(nil), "dbtesterpb.ConfigClientMachineBenchmarkSteps")
 	proto.RegisterType((*ConfigClientMachineAgentControl)(nil), "dbtesterpb.ConfigClientMachineAgentControl")
+	proto.RegisterType((*ConfigClientMachineLearnerReads)(nil), "dbtesterpb.ConfigClientMachineLearnerReads")
+	proto.RegisterType((*ConfigClientMachineFailover)(nil), "dbtesterpb.ConfigClientMachineFailover")
+	proto.RegisterType((*ConfigClientMachineSpikeRecovery)(nil), "dbtesterpb.ConfigClientMachineSpikeRecovery")
+	proto.RegisterType((*ConfigClientMachineTLSHandshake)(nil), "dbtesterpb.ConfigClientMachineTLSHandshake")
+	proto.RegisterType((*ConfigClientMachineResourceMonitor)(nil), "dbtesterpb.ConfigClientMachineResourceMonitor")
 }
 func (m *ConfigClientMachineInitial) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
@@ -188,6 +654,196 @@ func (m *ConfigClientMachineInitial) MarshalTo(dAtA []byte) (int, error) {
 		i = encodeVarintConfigClientMachine(dAtA, i, uint64(len(m.ServerDiskSpaceUsageSummaryPath)))
 		i += copy(dAtA[i:], m.ServerDiskSpaceUsageSummaryPath)
 	}
+	if len(m.ClientRequestTraceSamplePath) > 0 {
+		dAtA[i] = 0x5a
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(len(m.ClientRequestTraceSamplePath)))
+		i += copy(dAtA[i:], m.ClientRequestTraceSamplePath)
+	}
+	if len(m.ClientEndpointTrafficPath) > 0 {
+		dAtA[i] = 0x62
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(len(m.ClientEndpointTrafficPath)))
+		i += copy(dAtA[i:], m.ClientEndpointTrafficPath)
+	}
+	if len(m.ClientBootstrapTimePath) > 0 {
+		dAtA[i] = 0x6a
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(len(m.ClientBootstrapTimePath)))
+		i += copy(dAtA[i:], m.ClientBootstrapTimePath)
+	}
+	if len(m.ClientRollingRestartPath) > 0 {
+		dAtA[i] = 0x72
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(len(m.ClientRollingRestartPath)))
+		i += copy(dAtA[i:], m.ClientRollingRestartPath)
+	}
+	if len(m.ClientWorkloadSummaryPath) > 0 {
+		dAtA[i] = 0x7a
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(len(m.ClientWorkloadSummaryPath)))
+		i += copy(dAtA[i:], m.ClientWorkloadSummaryPath)
+	}
+	if len(m.CollectorEndpoint) > 0 {
+		dAtA[i] = 0x82
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(len(m.CollectorEndpoint)))
+		i += copy(dAtA[i:], m.CollectorEndpoint)
+	}
+	if len(m.ClientConvergencePath) > 0 {
+		dAtA[i] = 0x8a
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(len(m.ClientConvergencePath)))
+		i += copy(dAtA[i:], m.ClientConvergencePath)
+	}
+	if len(m.ClientChaosPath) > 0 {
+		dAtA[i] = 0x92
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(len(m.ClientChaosPath)))
+		i += copy(dAtA[i:], m.ClientChaosPath)
+	}
+	if len(m.ClientServerLatencyCorrelationPath) > 0 {
+		dAtA[i] = 0x9a
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(len(m.ClientServerLatencyCorrelationPath)))
+		i += copy(dAtA[i:], m.ClientServerLatencyCorrelationPath)
+	}
+	if len(m.ClientIdentityLeasePath) > 0 {
+		dAtA[i] = 0xa2
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(len(m.ClientIdentityLeasePath)))
+		i += copy(dAtA[i:], m.ClientIdentityLeasePath)
+	}
+	if len(m.CredentialsFile) > 0 {
+		dAtA[i] = 0xaa
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(len(m.CredentialsFile)))
+		i += copy(dAtA[i:], m.CredentialsFile)
+	}
+	if len(m.ClientSchedulePath) > 0 {
+		dAtA[i] = 0xb2
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(len(m.ClientSchedulePath)))
+		i += copy(dAtA[i:], m.ClientSchedulePath)
+	}
+	if len(m.ClientInterferencePath) > 0 {
+		dAtA[i] = 0xba
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(len(m.ClientInterferencePath)))
+		i += copy(dAtA[i:], m.ClientInterferencePath)
+	}
+	if len(m.ClientConvergenceSummaryPath) > 0 {
+		dAtA[i] = 0xc2
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(len(m.ClientConvergenceSummaryPath)))
+		i += copy(dAtA[i:], m.ClientConvergenceSummaryPath)
+	}
+	if len(m.ClientLearnerReadsPath) > 0 {
+		dAtA[i] = 0xca
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(len(m.ClientLearnerReadsPath)))
+		i += copy(dAtA[i:], m.ClientLearnerReadsPath)
+	}
+	if len(m.ClientOpenMetricsDir) > 0 {
+		dAtA[i] = 0xd2
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(len(m.ClientOpenMetricsDir)))
+		i += copy(dAtA[i:], m.ClientOpenMetricsDir)
+	}
+	if len(m.ClientSizeHistogramPath) > 0 {
+		dAtA[i] = 0xda
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(len(m.ClientSizeHistogramPath)))
+		i += copy(dAtA[i:], m.ClientSizeHistogramPath)
+	}
+	if len(m.ClientFailoverPath) > 0 {
+		dAtA[i] = 0xe2
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(len(m.ClientFailoverPath)))
+		i += copy(dAtA[i:], m.ClientFailoverPath)
+	}
+	if len(m.ClientSpikeRecoveryPath) > 0 {
+		dAtA[i] = 0xea
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(len(m.ClientSpikeRecoveryPath)))
+		i += copy(dAtA[i:], m.ClientSpikeRecoveryPath)
+	}
+	if len(m.ClientTLSHandshakePath) > 0 {
+		dAtA[i] = 0xf2
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(len(m.ClientTLSHandshakePath)))
+		i += copy(dAtA[i:], m.ClientTLSHandshakePath)
+	}
+	if len(m.ClientLatencyHgrmPath) > 0 {
+		dAtA[i] = 0xfa
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(len(m.ClientLatencyHgrmPath)))
+		i += copy(dAtA[i:], m.ClientLatencyHgrmPath)
+	}
+	if len(m.ClientMemberBreakdownPath) > 0 {
+		dAtA[i] = 0x82
+		i++
+		dAtA[i] = 0x2
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(len(m.ClientMemberBreakdownPath)))
+		i += copy(dAtA[i:], m.ClientMemberBreakdownPath)
+	}
+	if len(m.ClientTermChangePath) > 0 {
+		dAtA[i] = 0x8a
+		i++
+		dAtA[i] = 0x2
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(len(m.ClientTermChangePath)))
+		i += copy(dAtA[i:], m.ClientTermChangePath)
+	}
+	if len(m.ClientResourceUsagePath) > 0 {
+		dAtA[i] = 0x92
+		i++
+		dAtA[i] = 0x2
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(len(m.ClientResourceUsagePath)))
+		i += copy(dAtA[i:], m.ClientResourceUsagePath)
+	}
+	if len(m.ClientZoneLatencyPath) > 0 {
+		dAtA[i] = 0x9a
+		i++
+		dAtA[i] = 0x2
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(len(m.ClientZoneLatencyPath)))
+		i += copy(dAtA[i:], m.ClientZoneLatencyPath)
+	}
 	if len(m.GoogleCloudProjectName) > 0 {
 		dAtA[i] = 0xa2
 		i++
@@ -228,6 +884,22 @@ func (m *ConfigClientMachineInitial) MarshalTo(dAtA []byte) (int, error) {
 		i = encodeVarintConfigClientMachine(dAtA, i, uint64(len(m.GoogleCloudStorageSubDirectory)))
 		i += copy(dAtA[i:], m.GoogleCloudStorageSubDirectory)
 	}
+	if len(m.ClientSocketStatsPath) > 0 {
+		dAtA[i] = 0xa2
+		i++
+		dAtA[i] = 0x2
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(len(m.ClientSocketStatsPath)))
+		i += copy(dAtA[i:], m.ClientSocketStatsPath)
+	}
+	if len(m.ClientMaintenancePath) > 0 {
+		dAtA[i] = 0xaa
+		i++
+		dAtA[i] = 0x2
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(len(m.ClientMaintenancePath)))
+		i += copy(dAtA[i:], m.ClientMaintenancePath)
+	}
 	return i, nil
 }
 
@@ -320,518 +992,5688 @@ func (m *ConfigClientMachineBenchmarkOptions) MarshalTo(dAtA []byte) (int, error
 		}
 		i++
 	}
-	return i, nil
-}
-
-func (m *ConfigClientMachineBenchmarkSteps) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
-}
-
-func (m *ConfigClientMachineBenchmarkSteps) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Step1StartDatabase {
-		dAtA[i] = 0x8
+	if m.CalibrateHarness {
+		dAtA[i] = 0x58
 		i++
-		if m.Step1StartDatabase {
+		if m.CalibrateHarness {
 			dAtA[i] = 1
 		} else {
 			dAtA[i] = 0
 		}
 		i++
 	}
-	if m.Step2StressDatabase {
-		dAtA[i] = 0x10
+	if m.Prepopulate != 0 {
+		dAtA[i] = 0x60
 		i++
-		if m.Step2StressDatabase {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(m.Prepopulate))
+	}
+	if len(m.ZookeeperDigestAuth) > 0 {
+		dAtA[i] = 0x6a
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(len(m.ZookeeperDigestAuth)))
+		i += copy(dAtA[i:], m.ZookeeperDigestAuth)
+	}
+	if len(m.EtcdRBAC) > 0 {
+		dAtA[i] = 0x72
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(len(m.EtcdRBAC)))
+		i += copy(dAtA[i:], m.EtcdRBAC)
+	}
+	if m.TraceSampleNumber != 0 {
+		dAtA[i] = 0x78
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(m.TraceSampleNumber))
+	}
+	if m.HedgeAfterMicroseconds != 0 {
+		dAtA[i] = 0x80
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(m.HedgeAfterMicroseconds))
+	}
+	if m.ConfigClientMachineHealthRouting != nil {
+		dAtA[i] = 0x8a
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(m.ConfigClientMachineHealthRouting.Size()))
+		n3, err := m.ConfigClientMachineHealthRouting.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
 		}
+		i += n3
+	}
+	if m.KeySpaceSize != 0 {
+		dAtA[i] = 0x90
 		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(m.KeySpaceSize))
 	}
-	if m.Step3StopDatabase {
-		dAtA[i] = 0x18
+	if m.KeyPartitioned {
+		dAtA[i] = 0x98
 		i++
-		if m.Step3StopDatabase {
+		dAtA[i] = 0x1
+		i++
+		if m.KeyPartitioned {
 			dAtA[i] = 1
 		} else {
 			dAtA[i] = 0
 		}
 		i++
 	}
-	if m.Step4UploadLogs {
-		dAtA[i] = 0x20
+	if len(m.Namespace) > 0 {
+		dAtA[i] = 0xa2
 		i++
-		if m.Step4UploadLogs {
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(len(m.Namespace)))
+		i += copy(dAtA[i:], m.Namespace)
+	}
+	if m.TxnKeyNumber != 0 {
+		dAtA[i] = 0xa8
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(m.TxnKeyNumber))
+	}
+	if m.TxnOverlapPercent != 0 {
+		dAtA[i] = 0xb0
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(m.TxnOverlapPercent))
+	}
+	if m.MeasureRecovery {
+		dAtA[i] = 0xb8
+		i++
+		dAtA[i] = 0x1
+		i++
+		if m.MeasureRecovery {
 			dAtA[i] = 1
 		} else {
 			dAtA[i] = 0
 		}
 		i++
 	}
-	return i, nil
-}
-
-func (m *ConfigClientMachineAgentControl) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
-}
-
-func (m *ConfigClientMachineAgentControl) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.DatabaseID) > 0 {
-		dAtA[i] = 0xa
+	if m.ConfigClientMachineRollingRestart != nil {
+		dAtA[i] = 0xc2
 		i++
-		i = encodeVarintConfigClientMachine(dAtA, i, uint64(len(m.DatabaseID)))
-		i += copy(dAtA[i:], m.DatabaseID)
-	}
-	if len(m.DatabaseDescription) > 0 {
-		dAtA[i] = 0x12
+		dAtA[i] = 0x1
 		i++
-		i = encodeVarintConfigClientMachine(dAtA, i, uint64(len(m.DatabaseDescription)))
-		i += copy(dAtA[i:], m.DatabaseDescription)
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(m.ConfigClientMachineRollingRestart.Size()))
+		n4, err := m.ConfigClientMachineRollingRestart.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n4
 	}
-	if len(m.DatabaseTag) > 0 {
-		dAtA[i] = 0x1a
+	if m.OpenLoop {
+		dAtA[i] = 0xc8
+		i++
+		dAtA[i] = 0x1
+		i++
+		if m.OpenLoop {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
 		i++
-		i = encodeVarintConfigClientMachine(dAtA, i, uint64(len(m.DatabaseTag)))
-		i += copy(dAtA[i:], m.DatabaseTag)
 	}
-	if len(m.PeerIPs) > 0 {
-		for _, s := range m.PeerIPs {
-			dAtA[i] = 0x22
+	if len(m.ConfigClientMachineWorkloads) > 0 {
+		for _, msg := range m.ConfigClientMachineWorkloads {
+			dAtA[i] = 0xd2
 			i++
-			l = len(s)
-			for l >= 1<<7 {
-				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
-				l >>= 7
-				i++
-			}
-			dAtA[i] = uint8(l)
+			dAtA[i] = 0x1
 			i++
-			i += copy(dAtA[i:], s)
+			i = encodeVarintConfigClientMachine(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
 		}
 	}
-	if len(m.PeerIPsString) > 0 {
-		dAtA[i] = 0x2a
+	if m.ConfigClientMachineConvergenceProbe != nil {
+		dAtA[i] = 0xda
 		i++
-		i = encodeVarintConfigClientMachine(dAtA, i, uint64(len(m.PeerIPsString)))
-		i += copy(dAtA[i:], m.PeerIPsString)
-	}
-	if m.AgentPortToConnect != 0 {
-		dAtA[i] = 0x30
+		dAtA[i] = 0x1
 		i++
-		i = encodeVarintConfigClientMachine(dAtA, i, uint64(m.AgentPortToConnect))
-	}
-	if len(m.AgentEndpoints) > 0 {
-		for _, s := range m.AgentEndpoints {
-			dAtA[i] = 0x3a
-			i++
-			l = len(s)
-			for l >= 1<<7 {
-				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
-				l >>= 7
-				i++
-			}
-			dAtA[i] = uint8(l)
-			i++
-			i += copy(dAtA[i:], s)
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(m.ConfigClientMachineConvergenceProbe.Size()))
+		n5, err := m.ConfigClientMachineConvergenceProbe.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
 		}
+		i += n5
 	}
-	if m.DatabasePortToConnect != 0 {
-		dAtA[i] = 0x40
+	if len(m.Chaos) > 0 {
+		dAtA[i] = 0xe2
 		i++
-		i = encodeVarintConfigClientMachine(dAtA, i, uint64(m.DatabasePortToConnect))
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(len(m.Chaos)))
+		i += copy(dAtA[i:], m.Chaos)
 	}
-	if len(m.DatabaseEndpoints) > 0 {
-		for _, s := range m.DatabaseEndpoints {
-			dAtA[i] = 0x4a
-			i++
-			l = len(s)
-			for l >= 1<<7 {
-				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
-				l >>= 7
-				i++
-			}
-			dAtA[i] = uint8(l)
-			i++
-			i += copy(dAtA[i:], s)
-		}
+	if len(m.ChaosFile) > 0 {
+		dAtA[i] = 0xea
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(len(m.ChaosFile)))
+		i += copy(dAtA[i:], m.ChaosFile)
 	}
-	if m.Flag_Etcd_Other != nil {
-		dAtA[i] = 0xa2
+	if m.ConfigClientMachineEtcdMetrics != nil {
+		dAtA[i] = 0xf2
 		i++
-		dAtA[i] = 0x6
+		dAtA[i] = 0x1
 		i++
-		i = encodeVarintConfigClientMachine(dAtA, i, uint64(m.Flag_Etcd_Other.Size()))
-		n3, err := m.Flag_Etcd_Other.MarshalTo(dAtA[i:])
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(m.ConfigClientMachineEtcdMetrics.Size()))
+		n6, err := m.ConfigClientMachineEtcdMetrics.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n3
+		i += n6
 	}
-	if m.Flag_Etcd_Tip != nil {
-		dAtA[i] = 0xaa
+	if m.ConfigClientMachineIdentityLease != nil {
+		dAtA[i] = 0xfa
 		i++
-		dAtA[i] = 0x6
+		dAtA[i] = 0x1
 		i++
-		i = encodeVarintConfigClientMachine(dAtA, i, uint64(m.Flag_Etcd_Tip.Size()))
-		n4, err := m.Flag_Etcd_Tip.MarshalTo(dAtA[i:])
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(m.ConfigClientMachineIdentityLease.Size()))
+		n7, err := m.ConfigClientMachineIdentityLease.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n4
+		i += n7
 	}
-	if m.Flag_Etcd_V3_2 != nil {
-		dAtA[i] = 0xb2
+	if len(m.ConsulToken) > 0 {
+		dAtA[i] = 0x82
 		i++
-		dAtA[i] = 0x6
+		dAtA[i] = 0x2
 		i++
-		i = encodeVarintConfigClientMachine(dAtA, i, uint64(m.Flag_Etcd_V3_2.Size()))
-		n5, err := m.Flag_Etcd_V3_2.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(len(m.ConsulToken)))
+		i += copy(dAtA[i:], m.ConsulToken)
+	}
+	if len(m.EtcdUsername) > 0 {
+		dAtA[i] = 0x8a
+		i++
+		dAtA[i] = 0x2
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(len(m.EtcdUsername)))
+		i += copy(dAtA[i:], m.EtcdUsername)
+	}
+	if len(m.EtcdPassword) > 0 {
+		dAtA[i] = 0x92
+		i++
+		dAtA[i] = 0x2
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(len(m.EtcdPassword)))
+		i += copy(dAtA[i:], m.EtcdPassword)
+	}
+	if m.RequestTimeoutMilliseconds != 0 {
+		dAtA[i] = 0x98
+		i++
+		dAtA[i] = 0x2
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(m.RequestTimeoutMilliseconds))
+	}
+	if m.MeasureInterference {
+		dAtA[i] = 0xa0
+		i++
+		dAtA[i] = 0x2
+		i++
+		if m.MeasureInterference {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
 		}
-		i += n5
+		i++
 	}
-	if m.Flag_Etcd_V3_3 != nil {
-		dAtA[i] = 0xba
+	if m.ConfigClientMachineLearnerReads != nil {
+		dAtA[i] = 0xaa
 		i++
-		dAtA[i] = 0x6
+		dAtA[i] = 0x2
 		i++
-		i = encodeVarintConfigClientMachine(dAtA, i, uint64(m.Flag_Etcd_V3_3.Size()))
-		n6, err := m.Flag_Etcd_V3_3.MarshalTo(dAtA[i:])
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(m.ConfigClientMachineLearnerReads.Size()))
+		n19, err := m.ConfigClientMachineLearnerReads.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n6
+		i += n19
 	}
-	if m.Flag_Zookeeper_R3_5_3Beta != nil {
+	if m.TimeSeriesDownsampleSeconds != 0 {
+		dAtA[i] = 0xb0
+		i++
+		dAtA[i] = 0x2
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(m.TimeSeriesDownsampleSeconds))
+	}
+	if m.TimeSeriesFullResolutionSeconds != 0 {
+		dAtA[i] = 0xb8
+		i++
+		dAtA[i] = 0x2
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(m.TimeSeriesFullResolutionSeconds))
+	}
+	if m.ConfigClientMachineFailover != nil {
 		dAtA[i] = 0xc2
 		i++
-		dAtA[i] = 0xc
+		dAtA[i] = 0x2
 		i++
-		i = encodeVarintConfigClientMachine(dAtA, i, uint64(m.Flag_Zookeeper_R3_5_3Beta.Size()))
-		n7, err := m.Flag_Zookeeper_R3_5_3Beta.MarshalTo(dAtA[i:])
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(m.ConfigClientMachineFailover.Size()))
+		n20, err := m.ConfigClientMachineFailover.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n7
+		i += n20
 	}
-	if m.Flag_Consul_V1_0_2 != nil {
-		dAtA[i] = 0xe2
+	if len(m.ValueTemplate) > 0 {
+		dAtA[i] = 0xca
 		i++
-		dAtA[i] = 0x12
+		dAtA[i] = 0x2
 		i++
-		i = encodeVarintConfigClientMachine(dAtA, i, uint64(m.Flag_Consul_V1_0_2.Size()))
-		n8, err := m.Flag_Consul_V1_0_2.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n8
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(len(m.ValueTemplate)))
+		i += copy(dAtA[i:], m.ValueTemplate)
 	}
-	if m.Flag_Cetcd_Beta != nil {
-		dAtA[i] = 0x82
+	if m.ValueSeed != 0 {
+		dAtA[i] = 0xd0
 		i++
-		dAtA[i] = 0x19
+		dAtA[i] = 0x2
 		i++
-		i = encodeVarintConfigClientMachine(dAtA, i, uint64(m.Flag_Cetcd_Beta.Size()))
-		n9, err := m.Flag_Cetcd_Beta.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n9
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(m.ValueSeed))
 	}
-	if m.Flag_Zetcd_Beta != nil {
-		dAtA[i] = 0xa2
+	if m.ConfigClientMachineSpikeRecovery != nil {
+		dAtA[i] = 0xda
 		i++
-		dAtA[i] = 0x1f
+		dAtA[i] = 0x2
 		i++
-		i = encodeVarintConfigClientMachine(dAtA, i, uint64(m.Flag_Zetcd_Beta.Size()))
-		n10, err := m.Flag_Zetcd_Beta.MarshalTo(dAtA[i:])
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(m.ConfigClientMachineSpikeRecovery.Size()))
+		n21, err := m.ConfigClientMachineSpikeRecovery.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n10
+		i += n21
 	}
-	if m.ConfigClientMachineBenchmarkOptions != nil {
-		dAtA[i] = 0xc2
+	if m.ReadPercent != 0 {
+		dAtA[i] = 0xe0
 		i++
-		dAtA[i] = 0x3e
+		dAtA[i] = 0x2
 		i++
-		i = encodeVarintConfigClientMachine(dAtA, i, uint64(m.ConfigClientMachineBenchmarkOptions.Size()))
-		n11, err := m.ConfigClientMachineBenchmarkOptions.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n11
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(m.ReadPercent))
 	}
-	if m.ConfigClientMachineBenchmarkSteps != nil {
-		dAtA[i] = 0xca
+	if len(m.KeyDistribution) > 0 {
+		dAtA[i] = 0xea
 		i++
-		dAtA[i] = 0x3e
+		dAtA[i] = 0x2
 		i++
-		i = encodeVarintConfigClientMachine(dAtA, i, uint64(m.ConfigClientMachineBenchmarkSteps.Size()))
-		n12, err := m.ConfigClientMachineBenchmarkSteps.MarshalTo(dAtA[i:])
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(len(m.KeyDistribution)))
+		i += copy(dAtA[i:], m.KeyDistribution)
+	}
+	if m.ReadPercentEnd != 0 {
+		dAtA[i] = 0xf0
+		i++
+		dAtA[i] = 0x2
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(m.ReadPercentEnd))
+	}
+	if m.ConfigClientMachineTLSHandshake != nil {
+		dAtA[i] = 0xfa
+		i++
+		dAtA[i] = 0x2
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(m.ConfigClientMachineTLSHandshake.Size()))
+		n22, err := m.ConfigClientMachineTLSHandshake.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n12
+		i += n22
 	}
-	return i, nil
-}
-
-func encodeVarintConfigClientMachine(dAtA []byte, offset int, v uint64) int {
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+	if m.DurationSeconds != 0 {
+		dAtA[i] = 0x80
+		i++
+		dAtA[i] = 0x3
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(m.DurationSeconds))
 	}
-	dAtA[offset] = uint8(v)
-	return offset + 1
-}
-func (m *ConfigClientMachineInitial) Size() (n int) {
-	var l int
-	_ = l
-	l = len(m.PathPrefix)
-	if l > 0 {
-		n += 1 + l + sovConfigClientMachine(uint64(l))
+	if m.RampUpSeconds != 0 {
+		dAtA[i] = 0x88
+		i++
+		dAtA[i] = 0x3
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(m.RampUpSeconds))
 	}
-	l = len(m.LogPath)
-	if l > 0 {
-		n += 1 + l + sovConfigClientMachine(uint64(l))
+	if m.EtcdIgnoreValue {
+		dAtA[i] = 0x90
+		i++
+		dAtA[i] = 0x3
+		i++
+		if m.EtcdIgnoreValue {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
 	}
-	l = len(m.ClientSystemMetricsPath)
-	if l > 0 {
-		n += 1 + l + sovConfigClientMachine(uint64(l))
+	if m.EtcdIgnoreLease {
+		dAtA[i] = 0x98
+		i++
+		dAtA[i] = 0x3
+		i++
+		if m.EtcdIgnoreLease {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
 	}
-	l = len(m.ClientSystemMetricsInterpolatedPath)
-	if l > 0 {
-		n += 1 + l + sovConfigClientMachine(uint64(l))
+	if len(m.EtcdAPIVersion) > 0 {
+		dAtA[i] = 0xa2
+		i++
+		dAtA[i] = 0x3
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(len(m.EtcdAPIVersion)))
+		i += copy(dAtA[i:], m.EtcdAPIVersion)
 	}
-	l = len(m.ClientLatencyThroughputTimeseriesPath)
-	if l > 0 {
-		n += 1 + l + sovConfigClientMachine(uint64(l))
+	if m.ConfigClientMachineResourceMonitor != nil {
+		dAtA[i] = 0xaa
+		i++
+		dAtA[i] = 0x3
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(m.ConfigClientMachineResourceMonitor.Size()))
+		n25, err := m.ConfigClientMachineResourceMonitor.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n25
 	}
-	l = len(m.ClientLatencyDistributionAllPath)
-	if l > 0 {
-		n += 1 + l + sovConfigClientMachine(uint64(l))
+	if m.LockKeyNumber != 0 {
+		dAtA[i] = 0xb0
+		i++
+		dAtA[i] = 0x3
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(m.LockKeyNumber))
 	}
-	l = len(m.ClientLatencyDistributionPercentilePath)
-	if l > 0 {
-		n += 1 + l + sovConfigClientMachine(uint64(l))
+	if m.LockHoldMilliseconds != 0 {
+		dAtA[i] = 0xb8
+		i++
+		dAtA[i] = 0x3
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(m.LockHoldMilliseconds))
 	}
-	l = len(m.ClientLatencyDistributionSummaryPath)
-	if l > 0 {
-		n += 1 + l + sovConfigClientMachine(uint64(l))
+	if m.LockInvalidatePercent != 0 {
+		dAtA[i] = 0xc0
+		i++
+		dAtA[i] = 0x3
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(m.LockInvalidatePercent))
 	}
-	l = len(m.ClientLatencyByKeyNumberPath)
-	if l > 0 {
-		n += 1 + l + sovConfigClientMachine(uint64(l))
+	if m.ConsulLockDelayMilliseconds != 0 {
+		dAtA[i] = 0xc8
+		i++
+		dAtA[i] = 0x3
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(m.ConsulLockDelayMilliseconds))
 	}
-	l = len(m.ServerDiskSpaceUsageSummaryPath)
-	if l > 0 {
-		n += 1 + l + sovConfigClientMachine(uint64(l))
+	if m.BankAccountNumber != 0 {
+		dAtA[i] = 0xd0
+		i++
+		dAtA[i] = 0x3
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(m.BankAccountNumber))
 	}
-	l = len(m.GoogleCloudProjectName)
-	if l > 0 {
-		n += 2 + l + sovConfigClientMachine(uint64(l))
+	if m.BankInitialBalance != 0 {
+		dAtA[i] = 0xd8
+		i++
+		dAtA[i] = 0x3
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(m.BankInitialBalance))
 	}
-	l = len(m.GoogleCloudStorageKeyPath)
-	if l > 0 {
-		n += 2 + l + sovConfigClientMachine(uint64(l))
+	if m.WarmupRequestNumber != 0 {
+		dAtA[i] = 0xe0
+		i++
+		dAtA[i] = 0x3
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(m.WarmupRequestNumber))
 	}
-	l = len(m.GoogleCloudStorageKey)
-	if l > 0 {
-		n += 2 + l + sovConfigClientMachine(uint64(l))
+	if m.WarmupSeconds != 0 {
+		dAtA[i] = 0xe8
+		i++
+		dAtA[i] = 0x3
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(m.WarmupSeconds))
 	}
-	l = len(m.GoogleCloudStorageBucketName)
-	if l > 0 {
-		n += 2 + l + sovConfigClientMachine(uint64(l))
+	if m.AutoCompactSeconds != 0 {
+		dAtA[i] = 0xf0
+		i++
+		dAtA[i] = 0x3
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(m.AutoCompactSeconds))
 	}
-	l = len(m.GoogleCloudStorageSubDirectory)
-	if l > 0 {
-		n += 2 + l + sovConfigClientMachine(uint64(l))
+	if m.AutoDefrag {
+		dAtA[i] = 0xf8
+		i++
+		dAtA[i] = 0x3
+		i++
+		if m.AutoDefrag {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
 	}
-	return n
+	if len(m.KeyOrder) > 0 {
+		dAtA[i] = 0x82
+		i++
+		dAtA[i] = 0x4
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(len(m.KeyOrder)))
+		i += copy(dAtA[i:], m.KeyOrder)
+	}
+	return i, nil
 }
 
-func (m *ConfigClientMachineBenchmarkOptions) Size() (n int) {
-	var l int
-	_ = l
-	l = len(m.Type)
-	if l > 0 {
-		n += 1 + l + sovConfigClientMachine(uint64(l))
-	}
-	if m.RequestNumber != 0 {
-		n += 1 + sovConfigClientMachine(uint64(m.RequestNumber))
-	}
-	if m.ConnectionNumber != 0 {
-		n += 1 + sovConfigClientMachine(uint64(m.ConnectionNumber))
-	}
-	if m.ClientNumber != 0 {
-		n += 1 + sovConfigClientMachine(uint64(m.ClientNumber))
-	}
-	if len(m.ConnectionClientNumbers) > 0 {
-		l = 0
-		for _, e := range m.ConnectionClientNumbers {
-			l += sovConfigClientMachine(uint64(e))
-		}
-		n += 1 + sovConfigClientMachine(uint64(l)) + l
-	}
-	if m.RateLimitRequestsPerSecond != 0 {
-		n += 1 + sovConfigClientMachine(uint64(m.RateLimitRequestsPerSecond))
+func (m *ConfigClientMachineIdentityLease) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	if m.SameKey {
-		n += 2
+	return dAtA[:n], nil
+}
+
+func (m *ConfigClientMachineIdentityLease) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.TTLSeconds != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(m.TTLSeconds))
 	}
-	if m.KeySizeBytes != 0 {
-		n += 1 + sovConfigClientMachine(uint64(m.KeySizeBytes))
+	return i, nil
+}
+
+func (m *ConfigClientMachineEtcdMetrics) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	if m.ValueSizeBytes != 0 {
-		n += 1 + sovConfigClientMachine(uint64(m.ValueSizeBytes))
+	return dAtA[:n], nil
+}
+
+func (m *ConfigClientMachineEtcdMetrics) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.ScrapeIntervalSeconds != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(m.ScrapeIntervalSeconds))
 	}
-	if m.StaleRead {
-		n += 2
+	return i, nil
+}
+
+func (m *ConfigClientMachineConvergenceProbe) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *ConfigClientMachineBenchmarkSteps) Size() (n int) {
+func (m *ConfigClientMachineConvergenceProbe) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if m.Step1StartDatabase {
-		n += 2
+	if m.IntervalSeconds != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(m.IntervalSeconds))
 	}
-	if m.Step2StressDatabase {
-		n += 2
+	if m.TimeoutSeconds != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(m.TimeoutSeconds))
 	}
-	if m.Step3StopDatabase {
-		n += 2
+	if m.WriteEndpointIndex != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(m.WriteEndpointIndex))
 	}
-	if m.Step4UploadLogs {
-		n += 2
+	return i, nil
+}
+
+func (m *ConfigClientMachineWorkload) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *ConfigClientMachineAgentControl) Size() (n int) {
+func (m *ConfigClientMachineWorkload) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	l = len(m.DatabaseID)
-	if l > 0 {
-		n += 1 + l + sovConfigClientMachine(uint64(l))
-	}
-	l = len(m.DatabaseDescription)
-	if l > 0 {
-		n += 1 + l + sovConfigClientMachine(uint64(l))
+	if len(m.Name) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(len(m.Name)))
+		i += copy(dAtA[i:], m.Name)
 	}
-	l = len(m.DatabaseTag)
-	if l > 0 {
-		n += 1 + l + sovConfigClientMachine(uint64(l))
+	if len(m.Type) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(len(m.Type)))
+		i += copy(dAtA[i:], m.Type)
 	}
-	if len(m.PeerIPs) > 0 {
-		for _, s := range m.PeerIPs {
-			l = len(s)
-			n += 1 + l + sovConfigClientMachine(uint64(l))
-		}
+	if m.Percent != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(m.Percent))
 	}
-	l = len(m.PeerIPsString)
-	if l > 0 {
-		n += 1 + l + sovConfigClientMachine(uint64(l))
+	if m.RateLimitRequestsPerSecond != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(m.RateLimitRequestsPerSecond))
 	}
-	if m.AgentPortToConnect != 0 {
-		n += 1 + sovConfigClientMachine(uint64(m.AgentPortToConnect))
+	if len(m.Priority) > 0 {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(len(m.Priority)))
+		i += copy(dAtA[i:], m.Priority)
 	}
-	if len(m.AgentEndpoints) > 0 {
-		for _, s := range m.AgentEndpoints {
-			l = len(s)
-			n += 1 + l + sovConfigClientMachine(uint64(l))
-		}
+	if m.RateShare != 0 {
+		dAtA[i] = 0x30
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(m.RateShare))
 	}
-	if m.DatabasePortToConnect != 0 {
-		n += 1 + sovConfigClientMachine(uint64(m.DatabasePortToConnect))
+	if m.Watchers != 0 {
+		dAtA[i] = 0x38
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(m.Watchers))
 	}
-	if len(m.DatabaseEndpoints) > 0 {
-		for _, s := range m.DatabaseEndpoints {
-			l = len(s)
-			n += 1 + l + sovConfigClientMachine(uint64(l))
-		}
+	return i, nil
+}
+
+func (m *ConfigClientMachineRollingRestart) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	if m.Flag_Etcd_Other != nil {
-		l = m.Flag_Etcd_Other.Size()
-		n += 2 + l + sovConfigClientMachine(uint64(l))
+	return dAtA[:n], nil
+}
+
+func (m *ConfigClientMachineRollingRestart) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.StartSecond != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(m.StartSecond))
 	}
-	if m.Flag_Etcd_Tip != nil {
-		l = m.Flag_Etcd_Tip.Size()
-		n += 2 + l + sovConfigClientMachine(uint64(l))
+	if m.DownSeconds != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(m.DownSeconds))
 	}
-	if m.Flag_Etcd_V3_2 != nil {
-		l = m.Flag_Etcd_V3_2.Size()
-		n += 2 + l + sovConfigClientMachine(uint64(l))
+	if m.PauseSeconds != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(m.PauseSeconds))
 	}
-	if m.Flag_Etcd_V3_3 != nil {
-		l = m.Flag_Etcd_V3_3.Size()
-		n += 2 + l + sovConfigClientMachine(uint64(l))
+	return i, nil
+}
+
+func (m *ConfigClientMachineHealthRouting) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	if m.Flag_Zookeeper_R3_5_3Beta != nil {
-		l = m.Flag_Zookeeper_R3_5_3Beta.Size()
-		n += 2 + l + sovConfigClientMachine(uint64(l))
+	return dAtA[:n], nil
+}
+
+func (m *ConfigClientMachineHealthRouting) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.ErrorThreshold != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(m.ErrorThreshold))
 	}
-	if m.Flag_Consul_V1_0_2 != nil {
-		l = m.Flag_Consul_V1_0_2.Size()
-		n += 2 + l + sovConfigClientMachine(uint64(l))
+	if m.ProbeIntervalMilliseconds != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(m.ProbeIntervalMilliseconds))
 	}
-	if m.Flag_Cetcd_Beta != nil {
-		l = m.Flag_Cetcd_Beta.Size()
-		n += 2 + l + sovConfigClientMachine(uint64(l))
+	if m.BlackoutEndpointIndex != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(m.BlackoutEndpointIndex))
 	}
-	if m.Flag_Zetcd_Beta != nil {
-		l = m.Flag_Zetcd_Beta.Size()
-		n += 2 + l + sovConfigClientMachine(uint64(l))
+	if m.BlackoutStartSecond != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(m.BlackoutStartSecond))
 	}
-	if m.ConfigClientMachineBenchmarkOptions != nil {
-		l = m.ConfigClientMachineBenchmarkOptions.Size()
-		n += 2 + l + sovConfigClientMachine(uint64(l))
+	if m.BlackoutDurationSeconds != 0 {
+		dAtA[i] = 0x28
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(m.BlackoutDurationSeconds))
 	}
-	if m.ConfigClientMachineBenchmarkSteps != nil {
-		l = m.ConfigClientMachineBenchmarkSteps.Size()
-		n += 2 + l + sovConfigClientMachine(uint64(l))
+	return i, nil
+}
+
+func (m *ConfigClientMachineBenchmarkSteps) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func sovConfigClientMachine(x uint64) (n int) {
-	for {
-		n++
-		x >>= 7
-		if x == 0 {
-			break
+func (m *ConfigClientMachineBenchmarkSteps) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Step1StartDatabase {
+		dAtA[i] = 0x8
+		i++
+		if m.Step1StartDatabase {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.Step2StressDatabase {
+		dAtA[i] = 0x10
+		i++
+		if m.Step2StressDatabase {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.Step3StopDatabase {
+		dAtA[i] = 0x18
+		i++
+		if m.Step3StopDatabase {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.Step4UploadLogs {
+		dAtA[i] = 0x20
+		i++
+		if m.Step4UploadLogs {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	return i, nil
+}
+
+func (m *ConfigClientMachineAgentControl) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ConfigClientMachineAgentControl) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.DatabaseID) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(len(m.DatabaseID)))
+		i += copy(dAtA[i:], m.DatabaseID)
+	}
+	if len(m.DatabaseDescription) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(len(m.DatabaseDescription)))
+		i += copy(dAtA[i:], m.DatabaseDescription)
+	}
+	if len(m.DatabaseTag) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(len(m.DatabaseTag)))
+		i += copy(dAtA[i:], m.DatabaseTag)
+	}
+	if len(m.PeerIPs) > 0 {
+		for _, s := range m.PeerIPs {
+			dAtA[i] = 0x22
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if len(m.PeerIPsString) > 0 {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(len(m.PeerIPsString)))
+		i += copy(dAtA[i:], m.PeerIPsString)
+	}
+	if m.AgentPortToConnect != 0 {
+		dAtA[i] = 0x30
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(m.AgentPortToConnect))
+	}
+	if len(m.AgentEndpoints) > 0 {
+		for _, s := range m.AgentEndpoints {
+			dAtA[i] = 0x3a
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if m.DatabasePortToConnect != 0 {
+		dAtA[i] = 0x40
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(m.DatabasePortToConnect))
+	}
+	if len(m.DatabaseEndpoints) > 0 {
+		for _, s := range m.DatabaseEndpoints {
+			dAtA[i] = 0x4a
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if m.Flag_Etcd_Other != nil {
+		dAtA[i] = 0xa2
+		i++
+		dAtA[i] = 0x6
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(m.Flag_Etcd_Other.Size()))
+		n8, err := m.Flag_Etcd_Other.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n8
+	}
+	if m.Flag_Etcd_Tip != nil {
+		dAtA[i] = 0xaa
+		i++
+		dAtA[i] = 0x6
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(m.Flag_Etcd_Tip.Size()))
+		n9, err := m.Flag_Etcd_Tip.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n9
+	}
+	if m.Flag_Etcd_V3_2 != nil {
+		dAtA[i] = 0xb2
+		i++
+		dAtA[i] = 0x6
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(m.Flag_Etcd_V3_2.Size()))
+		n10, err := m.Flag_Etcd_V3_2.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n10
+	}
+	if m.Flag_Etcd_V3_3 != nil {
+		dAtA[i] = 0xba
+		i++
+		dAtA[i] = 0x6
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(m.Flag_Etcd_V3_3.Size()))
+		n11, err := m.Flag_Etcd_V3_3.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n11
+	}
+	if m.Flag_Zookeeper_R3_5_3Beta != nil {
+		dAtA[i] = 0xc2
+		i++
+		dAtA[i] = 0xc
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(m.Flag_Zookeeper_R3_5_3Beta.Size()))
+		n12, err := m.Flag_Zookeeper_R3_5_3Beta.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n12
+	}
+	if m.Flag_Consul_V1_0_2 != nil {
+		dAtA[i] = 0xe2
+		i++
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(m.Flag_Consul_V1_0_2.Size()))
+		n13, err := m.Flag_Consul_V1_0_2.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n13
+	}
+	if m.Flag_Cetcd_Beta != nil {
+		dAtA[i] = 0x82
+		i++
+		dAtA[i] = 0x19
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(m.Flag_Cetcd_Beta.Size()))
+		n14, err := m.Flag_Cetcd_Beta.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n14
+	}
+	if m.Flag_Zetcd_Beta != nil {
+		dAtA[i] = 0xa2
+		i++
+		dAtA[i] = 0x1f
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(m.Flag_Zetcd_Beta.Size()))
+		n15, err := m.Flag_Zetcd_Beta.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n15
+	}
+	if m.Flag_Redis_V4_0 != nil {
+		dAtA[i] = 0xc2
+		i++
+		dAtA[i] = 0x25
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(m.Flag_Redis_V4_0.Size()))
+		n23, err := m.Flag_Redis_V4_0.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n23
+	}
+	if m.Flag_Tikv_V2_1 != nil {
+		dAtA[i] = 0xe2
+		i++
+		dAtA[i] = 0x2b
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(m.Flag_Tikv_V2_1.Size()))
+		n24, err := m.Flag_Tikv_V2_1.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n24
+	}
+	if m.Flag_Mock != nil {
+		dAtA[i] = 0xa2
+		i++
+		dAtA[i] = 0x38
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(m.Flag_Mock.Size()))
+		n16, err := m.Flag_Mock.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n16
+	}
+	if m.ConfigClientMachineBenchmarkOptions != nil {
+		dAtA[i] = 0xc2
+		i++
+		dAtA[i] = 0x3e
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(m.ConfigClientMachineBenchmarkOptions.Size()))
+		n17, err := m.ConfigClientMachineBenchmarkOptions.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n17
+	}
+	if m.ConfigClientMachineBenchmarkSteps != nil {
+		dAtA[i] = 0xca
+		i++
+		dAtA[i] = 0x3e
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(m.ConfigClientMachineBenchmarkSteps.Size()))
+		n18, err := m.ConfigClientMachineBenchmarkSteps.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n18
+	}
+	return i, nil
+}
+
+func (m *ConfigClientMachineLearnerReads) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ConfigClientMachineLearnerReads) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Endpoints) > 0 {
+		for _, s := range m.Endpoints {
+			dAtA[i] = 0xa
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if m.IntervalMilliseconds != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(m.IntervalMilliseconds))
+	}
+	return i, nil
+}
+
+func (m *ConfigClientMachineFailover) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ConfigClientMachineFailover) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Trials != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(m.Trials))
+	}
+	if m.ProbeIntervalMilliseconds != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(m.ProbeIntervalMilliseconds))
+	}
+	if m.RequestTimeoutMilliseconds != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(m.RequestTimeoutMilliseconds))
+	}
+	if m.TimeoutSeconds != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(m.TimeoutSeconds))
+	}
+	return i, nil
+}
+
+func (m *ConfigClientMachineSpikeRecovery) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ConfigClientMachineSpikeRecovery) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.WithinPercent != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(m.WithinPercent))
+	}
+	if m.BaselineSeconds != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(m.BaselineSeconds))
+	}
+	return i, nil
+}
+
+func (m *ConfigClientMachineTLSHandshake) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ConfigClientMachineTLSHandshake) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Endpoints) > 0 {
+		for _, s := range m.Endpoints {
+			dAtA[i] = 0xa
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if len(m.CAFile) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(len(m.CAFile)))
+		i += copy(dAtA[i:], m.CAFile)
+	}
+	if len(m.CertFile) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(len(m.CertFile)))
+		i += copy(dAtA[i:], m.CertFile)
+	}
+	if len(m.KeyFile) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(len(m.KeyFile)))
+		i += copy(dAtA[i:], m.KeyFile)
+	}
+	if m.InsecureSkipVerify {
+		dAtA[i] = 0x28
+		i++
+		if m.InsecureSkipVerify {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.IntervalMilliseconds != 0 {
+		dAtA[i] = 0x30
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(m.IntervalMilliseconds))
+	}
+	return i, nil
+}
+
+func (m *ConfigClientMachineResourceMonitor) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ConfigClientMachineResourceMonitor) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Agents {
+		dAtA[i] = 0x8
+		i++
+		if m.Agents {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if len(m.Programs) > 0 {
+		for _, s := range m.Programs {
+			dAtA[i] = 0x12
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if len(m.DiskDevice) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(len(m.DiskDevice)))
+		i += copy(dAtA[i:], m.DiskDevice)
+	}
+	if len(m.NetworkInterface) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(len(m.NetworkInterface)))
+		i += copy(dAtA[i:], m.NetworkInterface)
+	}
+	if m.IntervalSeconds != 0 {
+		dAtA[i] = 0x28
+		i++
+		i = encodeVarintConfigClientMachine(dAtA, i, uint64(m.IntervalSeconds))
+	}
+	return i, nil
+}
+
+func encodeVarintConfigClientMachine(dAtA []byte, offset int, v uint64) int {
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return offset + 1
+}
+func (m *ConfigClientMachineInitial) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.PathPrefix)
+	if l > 0 {
+		n += 1 + l + sovConfigClientMachine(uint64(l))
+	}
+	l = len(m.LogPath)
+	if l > 0 {
+		n += 1 + l + sovConfigClientMachine(uint64(l))
+	}
+	l = len(m.ClientSystemMetricsPath)
+	if l > 0 {
+		n += 1 + l + sovConfigClientMachine(uint64(l))
+	}
+	l = len(m.ClientSystemMetricsInterpolatedPath)
+	if l > 0 {
+		n += 1 + l + sovConfigClientMachine(uint64(l))
+	}
+	l = len(m.ClientLatencyThroughputTimeseriesPath)
+	if l > 0 {
+		n += 1 + l + sovConfigClientMachine(uint64(l))
+	}
+	l = len(m.ClientLatencyDistributionAllPath)
+	if l > 0 {
+		n += 1 + l + sovConfigClientMachine(uint64(l))
+	}
+	l = len(m.ClientLatencyDistributionPercentilePath)
+	if l > 0 {
+		n += 1 + l + sovConfigClientMachine(uint64(l))
+	}
+	l = len(m.ClientLatencyDistributionSummaryPath)
+	if l > 0 {
+		n += 1 + l + sovConfigClientMachine(uint64(l))
+	}
+	l = len(m.ClientLatencyByKeyNumberPath)
+	if l > 0 {
+		n += 1 + l + sovConfigClientMachine(uint64(l))
+	}
+	l = len(m.ServerDiskSpaceUsageSummaryPath)
+	if l > 0 {
+		n += 1 + l + sovConfigClientMachine(uint64(l))
+	}
+	l = len(m.ClientRequestTraceSamplePath)
+	if l > 0 {
+		n += 1 + l + sovConfigClientMachine(uint64(l))
+	}
+	l = len(m.ClientEndpointTrafficPath)
+	if l > 0 {
+		n += 1 + l + sovConfigClientMachine(uint64(l))
+	}
+	l = len(m.ClientBootstrapTimePath)
+	if l > 0 {
+		n += 1 + l + sovConfigClientMachine(uint64(l))
+	}
+	l = len(m.ClientRollingRestartPath)
+	if l > 0 {
+		n += 1 + l + sovConfigClientMachine(uint64(l))
+	}
+	l = len(m.ClientWorkloadSummaryPath)
+	if l > 0 {
+		n += 1 + l + sovConfigClientMachine(uint64(l))
+	}
+	l = len(m.CollectorEndpoint)
+	if l > 0 {
+		n += 2 + l + sovConfigClientMachine(uint64(l))
+	}
+	l = len(m.ClientConvergencePath)
+	if l > 0 {
+		n += 2 + l + sovConfigClientMachine(uint64(l))
+	}
+	l = len(m.ClientChaosPath)
+	if l > 0 {
+		n += 2 + l + sovConfigClientMachine(uint64(l))
+	}
+	l = len(m.ClientServerLatencyCorrelationPath)
+	if l > 0 {
+		n += 2 + l + sovConfigClientMachine(uint64(l))
+	}
+	l = len(m.ClientIdentityLeasePath)
+	if l > 0 {
+		n += 2 + l + sovConfigClientMachine(uint64(l))
+	}
+	l = len(m.CredentialsFile)
+	if l > 0 {
+		n += 2 + l + sovConfigClientMachine(uint64(l))
+	}
+	l = len(m.ClientSchedulePath)
+	if l > 0 {
+		n += 2 + l + sovConfigClientMachine(uint64(l))
+	}
+	l = len(m.ClientInterferencePath)
+	if l > 0 {
+		n += 2 + l + sovConfigClientMachine(uint64(l))
+	}
+	l = len(m.ClientConvergenceSummaryPath)
+	if l > 0 {
+		n += 2 + l + sovConfigClientMachine(uint64(l))
+	}
+	l = len(m.ClientLearnerReadsPath)
+	if l > 0 {
+		n += 2 + l + sovConfigClientMachine(uint64(l))
+	}
+	l = len(m.ClientOpenMetricsDir)
+	if l > 0 {
+		n += 2 + l + sovConfigClientMachine(uint64(l))
+	}
+	l = len(m.ClientSizeHistogramPath)
+	if l > 0 {
+		n += 2 + l + sovConfigClientMachine(uint64(l))
+	}
+	l = len(m.ClientFailoverPath)
+	if l > 0 {
+		n += 2 + l + sovConfigClientMachine(uint64(l))
+	}
+	l = len(m.ClientSpikeRecoveryPath)
+	if l > 0 {
+		n += 2 + l + sovConfigClientMachine(uint64(l))
+	}
+	l = len(m.ClientTLSHandshakePath)
+	if l > 0 {
+		n += 2 + l + sovConfigClientMachine(uint64(l))
+	}
+	l = len(m.ClientLatencyHgrmPath)
+	if l > 0 {
+		n += 2 + l + sovConfigClientMachine(uint64(l))
+	}
+	l = len(m.ClientMemberBreakdownPath)
+	if l > 0 {
+		n += 2 + l + sovConfigClientMachine(uint64(l))
+	}
+	l = len(m.ClientTermChangePath)
+	if l > 0 {
+		n += 2 + l + sovConfigClientMachine(uint64(l))
+	}
+	l = len(m.ClientResourceUsagePath)
+	if l > 0 {
+		n += 2 + l + sovConfigClientMachine(uint64(l))
+	}
+	l = len(m.ClientZoneLatencyPath)
+	if l > 0 {
+		n += 2 + l + sovConfigClientMachine(uint64(l))
+	}
+	l = len(m.GoogleCloudProjectName)
+	if l > 0 {
+		n += 2 + l + sovConfigClientMachine(uint64(l))
+	}
+	l = len(m.GoogleCloudStorageKeyPath)
+	if l > 0 {
+		n += 2 + l + sovConfigClientMachine(uint64(l))
+	}
+	l = len(m.GoogleCloudStorageKey)
+	if l > 0 {
+		n += 2 + l + sovConfigClientMachine(uint64(l))
+	}
+	l = len(m.GoogleCloudStorageBucketName)
+	if l > 0 {
+		n += 2 + l + sovConfigClientMachine(uint64(l))
+	}
+	l = len(m.GoogleCloudStorageSubDirectory)
+	if l > 0 {
+		n += 2 + l + sovConfigClientMachine(uint64(l))
+	}
+	l = len(m.ClientSocketStatsPath)
+	if l > 0 {
+		n += 2 + l + sovConfigClientMachine(uint64(l))
+	}
+	l = len(m.ClientMaintenancePath)
+	if l > 0 {
+		n += 2 + l + sovConfigClientMachine(uint64(l))
+	}
+	return n
+}
+
+func (m *ConfigClientMachineBenchmarkOptions) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Type)
+	if l > 0 {
+		n += 1 + l + sovConfigClientMachine(uint64(l))
+	}
+	if m.RequestNumber != 0 {
+		n += 1 + sovConfigClientMachine(uint64(m.RequestNumber))
+	}
+	if m.ConnectionNumber != 0 {
+		n += 1 + sovConfigClientMachine(uint64(m.ConnectionNumber))
+	}
+	if m.ClientNumber != 0 {
+		n += 1 + sovConfigClientMachine(uint64(m.ClientNumber))
+	}
+	if len(m.ConnectionClientNumbers) > 0 {
+		l = 0
+		for _, e := range m.ConnectionClientNumbers {
+			l += sovConfigClientMachine(uint64(e))
+		}
+		n += 1 + sovConfigClientMachine(uint64(l)) + l
+	}
+	if m.RateLimitRequestsPerSecond != 0 {
+		n += 1 + sovConfigClientMachine(uint64(m.RateLimitRequestsPerSecond))
+	}
+	if m.SameKey {
+		n += 2
+	}
+	if m.KeySizeBytes != 0 {
+		n += 1 + sovConfigClientMachine(uint64(m.KeySizeBytes))
+	}
+	if m.ValueSizeBytes != 0 {
+		n += 1 + sovConfigClientMachine(uint64(m.ValueSizeBytes))
+	}
+	if m.StaleRead {
+		n += 2
+	}
+	if m.CalibrateHarness {
+		n += 2
+	}
+	if m.Prepopulate != 0 {
+		n += 1 + sovConfigClientMachine(uint64(m.Prepopulate))
+	}
+	l = len(m.ZookeeperDigestAuth)
+	if l > 0 {
+		n += 1 + l + sovConfigClientMachine(uint64(l))
+	}
+	l = len(m.EtcdRBAC)
+	if l > 0 {
+		n += 1 + l + sovConfigClientMachine(uint64(l))
+	}
+	if m.TraceSampleNumber != 0 {
+		n += 1 + sovConfigClientMachine(uint64(m.TraceSampleNumber))
+	}
+	if m.HedgeAfterMicroseconds != 0 {
+		n += 2 + sovConfigClientMachine(uint64(m.HedgeAfterMicroseconds))
+	}
+	if m.ConfigClientMachineHealthRouting != nil {
+		l = m.ConfigClientMachineHealthRouting.Size()
+		n += 2 + l + sovConfigClientMachine(uint64(l))
+	}
+	if m.KeySpaceSize != 0 {
+		n += 2 + sovConfigClientMachine(uint64(m.KeySpaceSize))
+	}
+	if m.KeyPartitioned {
+		n += 3
+	}
+	l = len(m.Namespace)
+	if l > 0 {
+		n += 2 + l + sovConfigClientMachine(uint64(l))
+	}
+	if m.TxnKeyNumber != 0 {
+		n += 2 + sovConfigClientMachine(uint64(m.TxnKeyNumber))
+	}
+	if m.TxnOverlapPercent != 0 {
+		n += 2 + sovConfigClientMachine(uint64(m.TxnOverlapPercent))
+	}
+	if m.MeasureRecovery {
+		n += 3
+	}
+	if m.ConfigClientMachineRollingRestart != nil {
+		l = m.ConfigClientMachineRollingRestart.Size()
+		n += 2 + l + sovConfigClientMachine(uint64(l))
+	}
+	if m.OpenLoop {
+		n += 3
+	}
+	if len(m.ConfigClientMachineWorkloads) > 0 {
+		for _, e := range m.ConfigClientMachineWorkloads {
+			l = e.Size()
+			n += 2 + l + sovConfigClientMachine(uint64(l))
+		}
+	}
+	if m.ConfigClientMachineConvergenceProbe != nil {
+		l = m.ConfigClientMachineConvergenceProbe.Size()
+		n += 2 + l + sovConfigClientMachine(uint64(l))
+	}
+	l = len(m.Chaos)
+	if l > 0 {
+		n += 2 + l + sovConfigClientMachine(uint64(l))
+	}
+	l = len(m.ChaosFile)
+	if l > 0 {
+		n += 2 + l + sovConfigClientMachine(uint64(l))
+	}
+	if m.ConfigClientMachineEtcdMetrics != nil {
+		l = m.ConfigClientMachineEtcdMetrics.Size()
+		n += 2 + l + sovConfigClientMachine(uint64(l))
+	}
+	if m.ConfigClientMachineIdentityLease != nil {
+		l = m.ConfigClientMachineIdentityLease.Size()
+		n += 2 + l + sovConfigClientMachine(uint64(l))
+	}
+	l = len(m.ConsulToken)
+	if l > 0 {
+		n += 2 + l + sovConfigClientMachine(uint64(l))
+	}
+	l = len(m.EtcdUsername)
+	if l > 0 {
+		n += 2 + l + sovConfigClientMachine(uint64(l))
+	}
+	l = len(m.EtcdPassword)
+	if l > 0 {
+		n += 2 + l + sovConfigClientMachine(uint64(l))
+	}
+	if m.RequestTimeoutMilliseconds != 0 {
+		n += 2 + sovConfigClientMachine(uint64(m.RequestTimeoutMilliseconds))
+	}
+	if m.MeasureInterference {
+		n += 3
+	}
+	if m.ConfigClientMachineLearnerReads != nil {
+		l = m.ConfigClientMachineLearnerReads.Size()
+		n += 2 + l + sovConfigClientMachine(uint64(l))
+	}
+	if m.TimeSeriesDownsampleSeconds != 0 {
+		n += 2 + sovConfigClientMachine(uint64(m.TimeSeriesDownsampleSeconds))
+	}
+	if m.TimeSeriesFullResolutionSeconds != 0 {
+		n += 2 + sovConfigClientMachine(uint64(m.TimeSeriesFullResolutionSeconds))
+	}
+	if m.ConfigClientMachineFailover != nil {
+		l = m.ConfigClientMachineFailover.Size()
+		n += 2 + l + sovConfigClientMachine(uint64(l))
+	}
+	l = len(m.ValueTemplate)
+	if l > 0 {
+		n += 2 + l + sovConfigClientMachine(uint64(l))
+	}
+	if m.ValueSeed != 0 {
+		n += 2 + sovConfigClientMachine(uint64(m.ValueSeed))
+	}
+	if m.ConfigClientMachineSpikeRecovery != nil {
+		l = m.ConfigClientMachineSpikeRecovery.Size()
+		n += 2 + l + sovConfigClientMachine(uint64(l))
+	}
+	if m.ReadPercent != 0 {
+		n += 2 + sovConfigClientMachine(uint64(m.ReadPercent))
+	}
+	l = len(m.KeyDistribution)
+	if l > 0 {
+		n += 2 + l + sovConfigClientMachine(uint64(l))
+	}
+	if m.ReadPercentEnd != 0 {
+		n += 2 + sovConfigClientMachine(uint64(m.ReadPercentEnd))
+	}
+	if m.ConfigClientMachineTLSHandshake != nil {
+		l = m.ConfigClientMachineTLSHandshake.Size()
+		n += 2 + l + sovConfigClientMachine(uint64(l))
+	}
+	if m.DurationSeconds != 0 {
+		n += 2 + sovConfigClientMachine(uint64(m.DurationSeconds))
+	}
+	if m.RampUpSeconds != 0 {
+		n += 2 + sovConfigClientMachine(uint64(m.RampUpSeconds))
+	}
+	if m.EtcdIgnoreValue {
+		n += 3
+	}
+	if m.EtcdIgnoreLease {
+		n += 3
+	}
+	l = len(m.EtcdAPIVersion)
+	if l > 0 {
+		n += 2 + l + sovConfigClientMachine(uint64(l))
+	}
+	if m.ConfigClientMachineResourceMonitor != nil {
+		l = m.ConfigClientMachineResourceMonitor.Size()
+		n += 2 + l + sovConfigClientMachine(uint64(l))
+	}
+	if m.LockKeyNumber != 0 {
+		n += 2 + sovConfigClientMachine(uint64(m.LockKeyNumber))
+	}
+	if m.LockHoldMilliseconds != 0 {
+		n += 2 + sovConfigClientMachine(uint64(m.LockHoldMilliseconds))
+	}
+	if m.LockInvalidatePercent != 0 {
+		n += 2 + sovConfigClientMachine(uint64(m.LockInvalidatePercent))
+	}
+	if m.ConsulLockDelayMilliseconds != 0 {
+		n += 2 + sovConfigClientMachine(uint64(m.ConsulLockDelayMilliseconds))
+	}
+	if m.BankAccountNumber != 0 {
+		n += 2 + sovConfigClientMachine(uint64(m.BankAccountNumber))
+	}
+	if m.BankInitialBalance != 0 {
+		n += 2 + sovConfigClientMachine(uint64(m.BankInitialBalance))
+	}
+	if m.WarmupRequestNumber != 0 {
+		n += 2 + sovConfigClientMachine(uint64(m.WarmupRequestNumber))
+	}
+	if m.WarmupSeconds != 0 {
+		n += 2 + sovConfigClientMachine(uint64(m.WarmupSeconds))
+	}
+	if m.AutoCompactSeconds != 0 {
+		n += 2 + sovConfigClientMachine(uint64(m.AutoCompactSeconds))
+	}
+	if m.AutoDefrag {
+		n += 3
+	}
+	l = len(m.KeyOrder)
+	if l > 0 {
+		n += 2 + l + sovConfigClientMachine(uint64(l))
+	}
+	return n
+}
+
+func (m *ConfigClientMachineIdentityLease) Size() (n int) {
+	var l int
+	_ = l
+	if m.TTLSeconds != 0 {
+		n += 1 + sovConfigClientMachine(uint64(m.TTLSeconds))
+	}
+	return n
+}
+
+func (m *ConfigClientMachineEtcdMetrics) Size() (n int) {
+	var l int
+	_ = l
+	if m.ScrapeIntervalSeconds != 0 {
+		n += 1 + sovConfigClientMachine(uint64(m.ScrapeIntervalSeconds))
+	}
+	return n
+}
+
+func (m *ConfigClientMachineConvergenceProbe) Size() (n int) {
+	var l int
+	_ = l
+	if m.IntervalSeconds != 0 {
+		n += 1 + sovConfigClientMachine(uint64(m.IntervalSeconds))
+	}
+	if m.TimeoutSeconds != 0 {
+		n += 1 + sovConfigClientMachine(uint64(m.TimeoutSeconds))
+	}
+	if m.WriteEndpointIndex != 0 {
+		n += 1 + sovConfigClientMachine(uint64(m.WriteEndpointIndex))
+	}
+	return n
+}
+
+func (m *ConfigClientMachineWorkload) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovConfigClientMachine(uint64(l))
+	}
+	l = len(m.Type)
+	if l > 0 {
+		n += 1 + l + sovConfigClientMachine(uint64(l))
+	}
+	if m.Percent != 0 {
+		n += 1 + sovConfigClientMachine(uint64(m.Percent))
+	}
+	if m.RateLimitRequestsPerSecond != 0 {
+		n += 1 + sovConfigClientMachine(uint64(m.RateLimitRequestsPerSecond))
+	}
+	l = len(m.Priority)
+	if l > 0 {
+		n += 1 + l + sovConfigClientMachine(uint64(l))
+	}
+	if m.RateShare != 0 {
+		n += 1 + sovConfigClientMachine(uint64(m.RateShare))
+	}
+	if m.Watchers != 0 {
+		n += 1 + sovConfigClientMachine(uint64(m.Watchers))
+	}
+	return n
+}
+
+func (m *ConfigClientMachineRollingRestart) Size() (n int) {
+	var l int
+	_ = l
+	if m.StartSecond != 0 {
+		n += 1 + sovConfigClientMachine(uint64(m.StartSecond))
+	}
+	if m.DownSeconds != 0 {
+		n += 1 + sovConfigClientMachine(uint64(m.DownSeconds))
+	}
+	if m.PauseSeconds != 0 {
+		n += 1 + sovConfigClientMachine(uint64(m.PauseSeconds))
+	}
+	return n
+}
+
+func (m *ConfigClientMachineHealthRouting) Size() (n int) {
+	var l int
+	_ = l
+	if m.ErrorThreshold != 0 {
+		n += 1 + sovConfigClientMachine(uint64(m.ErrorThreshold))
+	}
+	if m.ProbeIntervalMilliseconds != 0 {
+		n += 1 + sovConfigClientMachine(uint64(m.ProbeIntervalMilliseconds))
+	}
+	if m.BlackoutEndpointIndex != 0 {
+		n += 1 + sovConfigClientMachine(uint64(m.BlackoutEndpointIndex))
+	}
+	if m.BlackoutStartSecond != 0 {
+		n += 1 + sovConfigClientMachine(uint64(m.BlackoutStartSecond))
+	}
+	if m.BlackoutDurationSeconds != 0 {
+		n += 1 + sovConfigClientMachine(uint64(m.BlackoutDurationSeconds))
+	}
+	return n
+}
+
+func (m *ConfigClientMachineBenchmarkSteps) Size() (n int) {
+	var l int
+	_ = l
+	if m.Step1StartDatabase {
+		n += 2
+	}
+	if m.Step2StressDatabase {
+		n += 2
+	}
+	if m.Step3StopDatabase {
+		n += 2
+	}
+	if m.Step4UploadLogs {
+		n += 2
+	}
+	return n
+}
+
+func (m *ConfigClientMachineAgentControl) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.DatabaseID)
+	if l > 0 {
+		n += 1 + l + sovConfigClientMachine(uint64(l))
+	}
+	l = len(m.DatabaseDescription)
+	if l > 0 {
+		n += 1 + l + sovConfigClientMachine(uint64(l))
+	}
+	l = len(m.DatabaseTag)
+	if l > 0 {
+		n += 1 + l + sovConfigClientMachine(uint64(l))
+	}
+	if len(m.PeerIPs) > 0 {
+		for _, s := range m.PeerIPs {
+			l = len(s)
+			n += 1 + l + sovConfigClientMachine(uint64(l))
+		}
+	}
+	l = len(m.PeerIPsString)
+	if l > 0 {
+		n += 1 + l + sovConfigClientMachine(uint64(l))
+	}
+	if m.AgentPortToConnect != 0 {
+		n += 1 + sovConfigClientMachine(uint64(m.AgentPortToConnect))
+	}
+	if len(m.AgentEndpoints) > 0 {
+		for _, s := range m.AgentEndpoints {
+			l = len(s)
+			n += 1 + l + sovConfigClientMachine(uint64(l))
+		}
+	}
+	if m.DatabasePortToConnect != 0 {
+		n += 1 + sovConfigClientMachine(uint64(m.DatabasePortToConnect))
+	}
+	if len(m.DatabaseEndpoints) > 0 {
+		for _, s := range m.DatabaseEndpoints {
+			l = len(s)
+			n += 1 + l + sovConfigClientMachine(uint64(l))
+		}
+	}
+	if m.Flag_Etcd_Other != nil {
+		l = m.Flag_Etcd_Other.Size()
+		n += 2 + l + sovConfigClientMachine(uint64(l))
+	}
+	if m.Flag_Etcd_Tip != nil {
+		l = m.Flag_Etcd_Tip.Size()
+		n += 2 + l + sovConfigClientMachine(uint64(l))
+	}
+	if m.Flag_Etcd_V3_2 != nil {
+		l = m.Flag_Etcd_V3_2.Size()
+		n += 2 + l + sovConfigClientMachine(uint64(l))
+	}
+	if m.Flag_Etcd_V3_3 != nil {
+		l = m.Flag_Etcd_V3_3.Size()
+		n += 2 + l + sovConfigClientMachine(uint64(l))
+	}
+	if m.Flag_Zookeeper_R3_5_3Beta != nil {
+		l = m.Flag_Zookeeper_R3_5_3Beta.Size()
+		n += 2 + l + sovConfigClientMachine(uint64(l))
+	}
+	if m.Flag_Consul_V1_0_2 != nil {
+		l = m.Flag_Consul_V1_0_2.Size()
+		n += 2 + l + sovConfigClientMachine(uint64(l))
+	}
+	if m.Flag_Cetcd_Beta != nil {
+		l = m.Flag_Cetcd_Beta.Size()
+		n += 2 + l + sovConfigClientMachine(uint64(l))
+	}
+	if m.Flag_Zetcd_Beta != nil {
+		l = m.Flag_Zetcd_Beta.Size()
+		n += 2 + l + sovConfigClientMachine(uint64(l))
+	}
+	if m.Flag_Redis_V4_0 != nil {
+		l = m.Flag_Redis_V4_0.Size()
+		n += 2 + l + sovConfigClientMachine(uint64(l))
+	}
+	if m.Flag_Tikv_V2_1 != nil {
+		l = m.Flag_Tikv_V2_1.Size()
+		n += 2 + l + sovConfigClientMachine(uint64(l))
+	}
+	if m.Flag_Mock != nil {
+		l = m.Flag_Mock.Size()
+		n += 2 + l + sovConfigClientMachine(uint64(l))
+	}
+	if m.ConfigClientMachineBenchmarkOptions != nil {
+		l = m.ConfigClientMachineBenchmarkOptions.Size()
+		n += 2 + l + sovConfigClientMachine(uint64(l))
+	}
+	if m.ConfigClientMachineBenchmarkSteps != nil {
+		l = m.ConfigClientMachineBenchmarkSteps.Size()
+		n += 2 + l + sovConfigClientMachine(uint64(l))
+	}
+	return n
+}
+
+func (m *ConfigClientMachineLearnerReads) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.Endpoints) > 0 {
+		for _, s := range m.Endpoints {
+			l = len(s)
+			n += 1 + l + sovConfigClientMachine(uint64(l))
+		}
+	}
+	if m.IntervalMilliseconds != 0 {
+		n += 1 + sovConfigClientMachine(uint64(m.IntervalMilliseconds))
+	}
+	return n
+}
+
+func (m *ConfigClientMachineFailover) Size() (n int) {
+	var l int
+	_ = l
+	if m.Trials != 0 {
+		n += 1 + sovConfigClientMachine(uint64(m.Trials))
+	}
+	if m.ProbeIntervalMilliseconds != 0 {
+		n += 1 + sovConfigClientMachine(uint64(m.ProbeIntervalMilliseconds))
+	}
+	if m.RequestTimeoutMilliseconds != 0 {
+		n += 1 + sovConfigClientMachine(uint64(m.RequestTimeoutMilliseconds))
+	}
+	if m.TimeoutSeconds != 0 {
+		n += 1 + sovConfigClientMachine(uint64(m.TimeoutSeconds))
+	}
+	return n
+}
+
+func (m *ConfigClientMachineSpikeRecovery) Size() (n int) {
+	var l int
+	_ = l
+	if m.WithinPercent != 0 {
+		n += 1 + sovConfigClientMachine(uint64(m.WithinPercent))
+	}
+	if m.BaselineSeconds != 0 {
+		n += 1 + sovConfigClientMachine(uint64(m.BaselineSeconds))
+	}
+	return n
+}
+
+func (m *ConfigClientMachineTLSHandshake) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.Endpoints) > 0 {
+		for _, s := range m.Endpoints {
+			l = len(s)
+			n += 1 + l + sovConfigClientMachine(uint64(l))
+		}
+	}
+	l = len(m.CAFile)
+	if l > 0 {
+		n += 1 + l + sovConfigClientMachine(uint64(l))
+	}
+	l = len(m.CertFile)
+	if l > 0 {
+		n += 1 + l + sovConfigClientMachine(uint64(l))
+	}
+	l = len(m.KeyFile)
+	if l > 0 {
+		n += 1 + l + sovConfigClientMachine(uint64(l))
+	}
+	if m.InsecureSkipVerify {
+		n += 2
+	}
+	if m.IntervalMilliseconds != 0 {
+		n += 1 + sovConfigClientMachine(uint64(m.IntervalMilliseconds))
+	}
+	return n
+}
+
+func (m *ConfigClientMachineResourceMonitor) Size() (n int) {
+	var l int
+	_ = l
+	if m.Agents {
+		n += 2
+	}
+	if len(m.Programs) > 0 {
+		for _, s := range m.Programs {
+			l = len(s)
+			n += 1 + l + sovConfigClientMachine(uint64(l))
+		}
+	}
+	l = len(m.DiskDevice)
+	if l > 0 {
+		n += 1 + l + sovConfigClientMachine(uint64(l))
+	}
+	l = len(m.NetworkInterface)
+	if l > 0 {
+		n += 1 + l + sovConfigClientMachine(uint64(l))
+	}
+	if m.IntervalSeconds != 0 {
+		n += 1 + sovConfigClientMachine(uint64(m.IntervalSeconds))
+	}
+	return n
+}
+
+func sovConfigClientMachine(x uint64) (n int) {
+	for {
+		n++
+		x >>= 7
+		if x == 0 {
+			break
+		}
+	}
+	return n
+}
+func sozConfigClientMachine(x uint64) (n int) {
+	return sovConfigClientMachine(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *ConfigClientMachineInitial) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowConfigClientMachine
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ConfigClientMachineInitial: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ConfigClientMachineInitial: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PathPrefix", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfigClientMachine
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PathPrefix = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LogPath", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfigClientMachine
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.LogPath = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ClientSystemMetricsPath", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfigClientMachine
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ClientSystemMetricsPath = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ClientSystemMetricsInterpolatedPath", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfigClientMachine
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ClientSystemMetricsInterpolatedPath = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ClientLatencyThroughputTimeseriesPath", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfigClientMachine
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ClientLatencyThroughputTimeseriesPath = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ClientLatencyDistributionAllPath", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfigClientMachine
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ClientLatencyDistributionAllPath = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ClientLatencyDistributionPercentilePath", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfigClientMachine
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ClientLatencyDistributionPercentilePath = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ClientLatencyDistributionSummaryPath", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfigClientMachine
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ClientLatencyDistributionSummaryPath = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ClientLatencyByKeyNumberPath", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfigClientMachine
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ClientLatencyByKeyNumberPath = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ServerDiskSpaceUsageSummaryPath", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfigClientMachine
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ServerDiskSpaceUsageSummaryPath = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ClientRequestTraceSamplePath", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfigClientMachine
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ClientRequestTraceSamplePath = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ClientEndpointTrafficPath", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfigClientMachine
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ClientEndpointTrafficPath = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ClientBootstrapTimePath", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfigClientMachine
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ClientBootstrapTimePath = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 14:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ClientRollingRestartPath", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfigClientMachine
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ClientRollingRestartPath = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 15:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ClientWorkloadSummaryPath", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfigClientMachine
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ClientWorkloadSummaryPath = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 16:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CollectorEndpoint", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfigClientMachine
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CollectorEndpoint = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 17:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ClientConvergencePath", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfigClientMachine
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ClientConvergencePath = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 18:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ClientChaosPath", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfigClientMachine
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ClientChaosPath = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 19:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ClientServerLatencyCorrelationPath", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfigClientMachine
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ClientServerLatencyCorrelationPath = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 20:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ClientIdentityLeasePath", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfigClientMachine
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ClientIdentityLeasePath = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 21:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CredentialsFile", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfigClientMachine
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CredentialsFile = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 22:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ClientSchedulePath", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfigClientMachine
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ClientSchedulePath = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 23:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ClientInterferencePath", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfigClientMachine
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ClientInterferencePath = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 24:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ClientConvergenceSummaryPath", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfigClientMachine
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ClientConvergenceSummaryPath = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 25:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ClientLearnerReadsPath", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfigClientMachine
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ClientLearnerReadsPath = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 26:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ClientOpenMetricsDir", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfigClientMachine
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ClientOpenMetricsDir = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 27:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ClientSizeHistogramPath", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfigClientMachine
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ClientSizeHistogramPath = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 28:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ClientFailoverPath", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfigClientMachine
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ClientFailoverPath = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 29:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ClientSpikeRecoveryPath", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfigClientMachine
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ClientSpikeRecoveryPath = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 30:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ClientTLSHandshakePath", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfigClientMachine
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ClientTLSHandshakePath = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 31:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ClientLatencyHgrmPath", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfigClientMachine
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ClientLatencyHgrmPath = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 32:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ClientMemberBreakdownPath", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfigClientMachine
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ClientMemberBreakdownPath = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 33:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ClientTermChangePath", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfigClientMachine
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ClientTermChangePath = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 34:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ClientResourceUsagePath", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfigClientMachine
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ClientResourceUsagePath = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 35:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ClientZoneLatencyPath", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfigClientMachine
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ClientZoneLatencyPath = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 100:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GoogleCloudProjectName", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfigClientMachine
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.GoogleCloudProjectName = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 101:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GoogleCloudStorageKeyPath", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfigClientMachine
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.GoogleCloudStorageKeyPath = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 102:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GoogleCloudStorageKey", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfigClientMachine
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.GoogleCloudStorageKey = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 103:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GoogleCloudStorageBucketName", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfigClientMachine
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.GoogleCloudStorageBucketName = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 104:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GoogleCloudStorageSubDirectory", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfigClientMachine
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.GoogleCloudStorageSubDirectory = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 36:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ClientSocketStatsPath", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfigClientMachine
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ClientSocketStatsPath = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 37:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ClientMaintenancePath", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfigClientMachine
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ClientMaintenancePath = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipConfigClientMachine(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthConfigClientMachine
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ConfigClientMachineBenchmarkOptions) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowConfigClientMachine
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ConfigClientMachineBenchmarkOptions: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ConfigClientMachineBenchmarkOptions: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfigClientMachine
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Type = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RequestNumber", wireType)
+			}
+			m.RequestNumber = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RequestNumber |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConnectionNumber", wireType)
+			}
+			m.ConnectionNumber = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ConnectionNumber |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ClientNumber", wireType)
+			}
+			m.ClientNumber = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ClientNumber |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType == 0 {
+				var v int64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowConfigClientMachine
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= (int64(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.ConnectionClientNumbers = append(m.ConnectionClientNumbers, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowConfigClientMachine
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= (int(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthConfigClientMachine
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				for iNdEx < postIndex {
+					var v int64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowConfigClientMachine
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= (int64(b) & 0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.ConnectionClientNumbers = append(m.ConnectionClientNumbers, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConnectionClientNumbers", wireType)
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RateLimitRequestsPerSecond", wireType)
+			}
+			m.RateLimitRequestsPerSecond = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RateLimitRequestsPerSecond |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SameKey", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.SameKey = bool(v != 0)
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field KeySizeBytes", wireType)
+			}
+			m.KeySizeBytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.KeySizeBytes |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ValueSizeBytes", wireType)
+			}
+			m.ValueSizeBytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ValueSizeBytes |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StaleRead", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.StaleRead = bool(v != 0)
+		case 11:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CalibrateHarness", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.CalibrateHarness = bool(v != 0)
+		case 12:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Prepopulate", wireType)
+			}
+			m.Prepopulate = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Prepopulate |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ZookeeperDigestAuth", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfigClientMachine
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ZookeeperDigestAuth = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 14:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EtcdRBAC", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfigClientMachine
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.EtcdRBAC = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 15:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TraceSampleNumber", wireType)
+			}
+			m.TraceSampleNumber = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TraceSampleNumber |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 16:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HedgeAfterMicroseconds", wireType)
+			}
+			m.HedgeAfterMicroseconds = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.HedgeAfterMicroseconds |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 17:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConfigClientMachineHealthRouting", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConfigClientMachine
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ConfigClientMachineHealthRouting == nil {
+				m.ConfigClientMachineHealthRouting = &ConfigClientMachineHealthRouting{}
+			}
+			if err := m.ConfigClientMachineHealthRouting.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 18:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field KeySpaceSize", wireType)
+			}
+			m.KeySpaceSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.KeySpaceSize |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 19:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field KeyPartitioned", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.KeyPartitioned = bool(v != 0)
+		case 20:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Namespace", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfigClientMachine
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Namespace = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 21:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TxnKeyNumber", wireType)
+			}
+			m.TxnKeyNumber = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TxnKeyNumber |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 22:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TxnOverlapPercent", wireType)
+			}
+			m.TxnOverlapPercent = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TxnOverlapPercent |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 23:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MeasureRecovery", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.MeasureRecovery = bool(v != 0)
+		case 24:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConfigClientMachineRollingRestart", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConfigClientMachine
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ConfigClientMachineRollingRestart == nil {
+				m.ConfigClientMachineRollingRestart = &ConfigClientMachineRollingRestart{}
+			}
+			if err := m.ConfigClientMachineRollingRestart.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 25:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OpenLoop", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.OpenLoop = bool(v != 0)
+		case 26:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConfigClientMachineWorkloads", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConfigClientMachine
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ConfigClientMachineWorkloads = append(m.ConfigClientMachineWorkloads, &ConfigClientMachineWorkload{})
+			if err := m.ConfigClientMachineWorkloads[len(m.ConfigClientMachineWorkloads)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 27:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConfigClientMachineConvergenceProbe", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConfigClientMachine
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ConfigClientMachineConvergenceProbe == nil {
+				m.ConfigClientMachineConvergenceProbe = &ConfigClientMachineConvergenceProbe{}
+			}
+			if err := m.ConfigClientMachineConvergenceProbe.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 28:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Chaos", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfigClientMachine
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Chaos = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 29:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChaosFile", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfigClientMachine
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ChaosFile = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 30:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConfigClientMachineEtcdMetrics", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConfigClientMachine
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ConfigClientMachineEtcdMetrics == nil {
+				m.ConfigClientMachineEtcdMetrics = &ConfigClientMachineEtcdMetrics{}
+			}
+			if err := m.ConfigClientMachineEtcdMetrics.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 31:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConfigClientMachineIdentityLease", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConfigClientMachine
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ConfigClientMachineIdentityLease == nil {
+				m.ConfigClientMachineIdentityLease = &ConfigClientMachineIdentityLease{}
+			}
+			if err := m.ConfigClientMachineIdentityLease.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 32:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConsulToken", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfigClientMachine
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ConsulToken = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 33:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EtcdUsername", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfigClientMachine
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.EtcdUsername = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 34:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EtcdPassword", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfigClientMachine
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.EtcdPassword = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 35:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RequestTimeoutMilliseconds", wireType)
+			}
+			m.RequestTimeoutMilliseconds = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RequestTimeoutMilliseconds |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 36:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MeasureInterference", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.MeasureInterference = bool(v != 0)
+		case 37:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConfigClientMachineLearnerReads", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConfigClientMachine
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ConfigClientMachineLearnerReads == nil {
+				m.ConfigClientMachineLearnerReads = &ConfigClientMachineLearnerReads{}
+			}
+			if err := m.ConfigClientMachineLearnerReads.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 38:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TimeSeriesDownsampleSeconds", wireType)
+			}
+			m.TimeSeriesDownsampleSeconds = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TimeSeriesDownsampleSeconds |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 39:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TimeSeriesFullResolutionSeconds", wireType)
+			}
+			m.TimeSeriesFullResolutionSeconds = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TimeSeriesFullResolutionSeconds |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 40:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConfigClientMachineFailover", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConfigClientMachine
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ConfigClientMachineFailover == nil {
+				m.ConfigClientMachineFailover = &ConfigClientMachineFailover{}
+			}
+			if err := m.ConfigClientMachineFailover.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 41:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ValueTemplate", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfigClientMachine
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ValueTemplate = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 42:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ValueSeed", wireType)
+			}
+			m.ValueSeed = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ValueSeed |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 43:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConfigClientMachineSpikeRecovery", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConfigClientMachine
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ConfigClientMachineSpikeRecovery == nil {
+				m.ConfigClientMachineSpikeRecovery = &ConfigClientMachineSpikeRecovery{}
+			}
+			if err := m.ConfigClientMachineSpikeRecovery.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 44:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReadPercent", wireType)
+			}
+			m.ReadPercent = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ReadPercent |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 45:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field KeyDistribution", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfigClientMachine
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.KeyDistribution = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 46:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReadPercentEnd", wireType)
+			}
+			m.ReadPercentEnd = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ReadPercentEnd |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 47:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConfigClientMachineTLSHandshake", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConfigClientMachine
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ConfigClientMachineTLSHandshake == nil {
+				m.ConfigClientMachineTLSHandshake = &ConfigClientMachineTLSHandshake{}
+			}
+			if err := m.ConfigClientMachineTLSHandshake.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 48:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DurationSeconds", wireType)
+			}
+			m.DurationSeconds = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DurationSeconds |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 49:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RampUpSeconds", wireType)
+			}
+			m.RampUpSeconds = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RampUpSeconds |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 50:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EtcdIgnoreValue", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.EtcdIgnoreValue = bool(v != 0)
+		case 51:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EtcdIgnoreLease", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.EtcdIgnoreLease = bool(v != 0)
+		case 52:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EtcdAPIVersion", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfigClientMachine
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.EtcdAPIVersion = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 53:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConfigClientMachineResourceMonitor", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConfigClientMachine
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ConfigClientMachineResourceMonitor == nil {
+				m.ConfigClientMachineResourceMonitor = &ConfigClientMachineResourceMonitor{}
+			}
+			if err := m.ConfigClientMachineResourceMonitor.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 54:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LockKeyNumber", wireType)
+			}
+			m.LockKeyNumber = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LockKeyNumber |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 55:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LockHoldMilliseconds", wireType)
+			}
+			m.LockHoldMilliseconds = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LockHoldMilliseconds |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 56:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LockInvalidatePercent", wireType)
+			}
+			m.LockInvalidatePercent = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LockInvalidatePercent |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 57:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConsulLockDelayMilliseconds", wireType)
+			}
+			m.ConsulLockDelayMilliseconds = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ConsulLockDelayMilliseconds |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 58:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BankAccountNumber", wireType)
+			}
+			m.BankAccountNumber = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BankAccountNumber |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 59:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BankInitialBalance", wireType)
+			}
+			m.BankInitialBalance = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BankInitialBalance |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 60:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field WarmupRequestNumber", wireType)
+			}
+			m.WarmupRequestNumber = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.WarmupRequestNumber |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 61:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field WarmupSeconds", wireType)
+			}
+			m.WarmupSeconds = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.WarmupSeconds |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 62:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AutoCompactSeconds", wireType)
+			}
+			m.AutoCompactSeconds = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.AutoCompactSeconds |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 63:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AutoDefrag", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.AutoDefrag = bool(v != 0)
+		case 64:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field KeyOrder", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfigClientMachine
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.KeyOrder = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipConfigClientMachine(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthConfigClientMachine
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ConfigClientMachineIdentityLease) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowConfigClientMachine
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ConfigClientMachineIdentityLease: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ConfigClientMachineIdentityLease: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TTLSeconds", wireType)
+			}
+			m.TTLSeconds = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TTLSeconds |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipConfigClientMachine(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthConfigClientMachine
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ConfigClientMachineEtcdMetrics) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowConfigClientMachine
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ConfigClientMachineEtcdMetrics: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ConfigClientMachineEtcdMetrics: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ScrapeIntervalSeconds", wireType)
+			}
+			m.ScrapeIntervalSeconds = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ScrapeIntervalSeconds |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipConfigClientMachine(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthConfigClientMachine
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ConfigClientMachineConvergenceProbe) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowConfigClientMachine
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ConfigClientMachineConvergenceProbe: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ConfigClientMachineConvergenceProbe: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IntervalSeconds", wireType)
+			}
+			m.IntervalSeconds = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.IntervalSeconds |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TimeoutSeconds", wireType)
+			}
+			m.TimeoutSeconds = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TimeoutSeconds |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field WriteEndpointIndex", wireType)
+			}
+			m.WriteEndpointIndex = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.WriteEndpointIndex |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipConfigClientMachine(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthConfigClientMachine
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ConfigClientMachineWorkload) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowConfigClientMachine
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ConfigClientMachineWorkload: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ConfigClientMachineWorkload: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfigClientMachine
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfigClientMachine
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Type = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Percent", wireType)
+			}
+			m.Percent = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Percent |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RateLimitRequestsPerSecond", wireType)
+			}
+			m.RateLimitRequestsPerSecond = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RateLimitRequestsPerSecond |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Priority", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfigClientMachine
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Priority = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RateShare", wireType)
+			}
+			m.RateShare = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RateShare |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Watchers", wireType)
+			}
+			m.Watchers = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Watchers |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipConfigClientMachine(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthConfigClientMachine
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ConfigClientMachineRollingRestart) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowConfigClientMachine
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ConfigClientMachineRollingRestart: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ConfigClientMachineRollingRestart: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StartSecond", wireType)
+			}
+			m.StartSecond = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.StartSecond |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DownSeconds", wireType)
+			}
+			m.DownSeconds = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DownSeconds |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PauseSeconds", wireType)
+			}
+			m.PauseSeconds = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.PauseSeconds |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipConfigClientMachine(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthConfigClientMachine
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ConfigClientMachineHealthRouting) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowConfigClientMachine
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ConfigClientMachineHealthRouting: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ConfigClientMachineHealthRouting: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ErrorThreshold", wireType)
+			}
+			m.ErrorThreshold = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ErrorThreshold |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProbeIntervalMilliseconds", wireType)
+			}
+			m.ProbeIntervalMilliseconds = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ProbeIntervalMilliseconds |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlackoutEndpointIndex", wireType)
+			}
+			m.BlackoutEndpointIndex = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BlackoutEndpointIndex |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlackoutStartSecond", wireType)
+			}
+			m.BlackoutStartSecond = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BlackoutStartSecond |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlackoutDurationSeconds", wireType)
+			}
+			m.BlackoutDurationSeconds = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BlackoutDurationSeconds |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipConfigClientMachine(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthConfigClientMachine
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ConfigClientMachineBenchmarkSteps) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowConfigClientMachine
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ConfigClientMachineBenchmarkSteps: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ConfigClientMachineBenchmarkSteps: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Step1StartDatabase", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Step1StartDatabase = bool(v != 0)
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Step2StressDatabase", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Step2StressDatabase = bool(v != 0)
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Step3StopDatabase", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Step3StopDatabase = bool(v != 0)
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Step4UploadLogs", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfigClientMachine
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Step4UploadLogs = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipConfigClientMachine(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthConfigClientMachine
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
 		}
 	}
-	return n
-}
-func sozConfigClientMachine(x uint64) (n int) {
-	return sovConfigClientMachine(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
 }
-func (m *ConfigClientMachineInitial) Unmarshal(dAtA []byte) error {
+func (m *ConfigClientMachineAgentControl) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -854,15 +6696,15 @@ func (m *ConfigClientMachineInitial) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ConfigClientMachineInitial: wiretype end group for non-group")
+			return fmt.Errorf("proto: ConfigClientMachineAgentControl: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ConfigClientMachineInitial: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ConfigClientMachineAgentControl: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PathPrefix", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field DatabaseID", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -887,11 +6729,11 @@ func (m *ConfigClientMachineInitial) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.PathPrefix = string(dAtA[iNdEx:postIndex])
+			m.DatabaseID = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LogPath", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field DatabaseDescription", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -916,11 +6758,11 @@ func (m *ConfigClientMachineInitial) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.LogPath = string(dAtA[iNdEx:postIndex])
+			m.DatabaseDescription = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ClientSystemMetricsPath", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field DatabaseTag", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -945,11 +6787,11 @@ func (m *ConfigClientMachineInitial) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ClientSystemMetricsPath = string(dAtA[iNdEx:postIndex])
+			m.DatabaseTag = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ClientSystemMetricsInterpolatedPath", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field PeerIPs", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -974,11 +6816,11 @@ func (m *ConfigClientMachineInitial) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ClientSystemMetricsInterpolatedPath = string(dAtA[iNdEx:postIndex])
+			m.PeerIPs = append(m.PeerIPs, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
 		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ClientLatencyThroughputTimeseriesPath", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field PeerIPsString", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -1003,13 +6845,13 @@ func (m *ConfigClientMachineInitial) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ClientLatencyThroughputTimeseriesPath = string(dAtA[iNdEx:postIndex])
+			m.PeerIPsString = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 6:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ClientLatencyDistributionAllPath", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AgentPortToConnect", wireType)
 			}
-			var stringLen uint64
+			m.AgentPortToConnect = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowConfigClientMachine
@@ -1019,24 +6861,14 @@ func (m *ConfigClientMachineInitial) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				m.AgentPortToConnect |= (int64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthConfigClientMachine
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.ClientLatencyDistributionAllPath = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
 		case 7:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ClientLatencyDistributionPercentilePath", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field AgentEndpoints", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -1061,13 +6893,13 @@ func (m *ConfigClientMachineInitial) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ClientLatencyDistributionPercentilePath = string(dAtA[iNdEx:postIndex])
+			m.AgentEndpoints = append(m.AgentEndpoints, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
 		case 8:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ClientLatencyDistributionSummaryPath", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DatabasePortToConnect", wireType)
 			}
-			var stringLen uint64
+			m.DatabasePortToConnect = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowConfigClientMachine
@@ -1077,24 +6909,14 @@ func (m *ConfigClientMachineInitial) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				m.DatabasePortToConnect |= (int64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthConfigClientMachine
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.ClientLatencyDistributionSummaryPath = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
 		case 9:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ClientLatencyByKeyNumberPath", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field DatabaseEndpoints", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -1119,13 +6941,13 @@ func (m *ConfigClientMachineInitial) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ClientLatencyByKeyNumberPath = string(dAtA[iNdEx:postIndex])
+			m.DatabaseEndpoints = append(m.DatabaseEndpoints, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
-		case 10:
+		case 100:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ServerDiskSpaceUsageSummaryPath", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Flag_Etcd_Other", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowConfigClientMachine
@@ -1135,55 +6957,30 @@ func (m *ConfigClientMachineInitial) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthConfigClientMachine
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ServerDiskSpaceUsageSummaryPath = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 100:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field GoogleCloudProjectName", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowConfigClientMachine
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthConfigClientMachine
+			if m.Flag_Etcd_Other == nil {
+				m.Flag_Etcd_Other = &Flag_Etcd_Other{}
 			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
+			if err := m.Flag_Etcd_Other.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-			m.GoogleCloudProjectName = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 101:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field GoogleCloudStorageKeyPath", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Flag_Etcd_Tip", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowConfigClientMachine
@@ -1193,26 +6990,30 @@ func (m *ConfigClientMachineInitial) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthConfigClientMachine
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.GoogleCloudStorageKeyPath = string(dAtA[iNdEx:postIndex])
+			if m.Flag_Etcd_Tip == nil {
+				m.Flag_Etcd_Tip = &Flag_Etcd_Tip{}
+			}
+			if err := m.Flag_Etcd_Tip.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		case 102:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field GoogleCloudStorageKey", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Flag_Etcd_V3_2", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowConfigClientMachine
@@ -1222,55 +7023,30 @@ func (m *ConfigClientMachineInitial) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthConfigClientMachine
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.GoogleCloudStorageKey = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 103:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field GoogleCloudStorageBucketName", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowConfigClientMachine
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthConfigClientMachine
+			if m.Flag_Etcd_V3_2 == nil {
+				m.Flag_Etcd_V3_2 = &Flag_Etcd_V3_2{}
 			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
+			if err := m.Flag_Etcd_V3_2.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-			m.GoogleCloudStorageBucketName = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 104:
+		case 103:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field GoogleCloudStorageSubDirectory", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Flag_Etcd_V3_3", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowConfigClientMachine
@@ -1280,76 +7056,30 @@ func (m *ConfigClientMachineInitial) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthConfigClientMachine
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.GoogleCloudStorageSubDirectory = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipConfigClientMachine(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthConfigClientMachine
 			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *ConfigClientMachineBenchmarkOptions) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowConfigClientMachine
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: ConfigClientMachineBenchmarkOptions: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ConfigClientMachineBenchmarkOptions: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Flag_Etcd_V3_3 == nil {
+				m.Flag_Etcd_V3_3 = &Flag_Etcd_V3_3{}
+			}
+			if err := m.Flag_Etcd_V3_3.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 200:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Flag_Zookeeper_R3_5_3Beta", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowConfigClientMachine
@@ -1359,26 +7089,30 @@ func (m *ConfigClientMachineBenchmarkOptions) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthConfigClientMachine
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Type = string(dAtA[iNdEx:postIndex])
+			if m.Flag_Zookeeper_R3_5_3Beta == nil {
+				m.Flag_Zookeeper_R3_5_3Beta = &Flag_Zookeeper_R3_5_3Beta{}
+			}
+			if err := m.Flag_Zookeeper_R3_5_3Beta.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RequestNumber", wireType)
+		case 300:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Flag_Consul_V1_0_2", wireType)
 			}
-			m.RequestNumber = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowConfigClientMachine
@@ -1388,16 +7122,30 @@ func (m *ConfigClientMachineBenchmarkOptions) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.RequestNumber |= (int64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ConnectionNumber", wireType)
+			if msglen < 0 {
+				return ErrInvalidLengthConfigClientMachine
 			}
-			m.ConnectionNumber = 0
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Flag_Consul_V1_0_2 == nil {
+				m.Flag_Consul_V1_0_2 = &Flag_Consul_V1_0_2{}
+			}
+			if err := m.Flag_Consul_V1_0_2.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 400:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Flag_Cetcd_Beta", wireType)
+			}
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowConfigClientMachine
@@ -1407,16 +7155,30 @@ func (m *ConfigClientMachineBenchmarkOptions) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.ConnectionNumber |= (int64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 4:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ClientNumber", wireType)
+			if msglen < 0 {
+				return ErrInvalidLengthConfigClientMachine
 			}
-			m.ClientNumber = 0
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Flag_Cetcd_Beta == nil {
+				m.Flag_Cetcd_Beta = &Flag_Cetcd_Beta{}
+			}
+			if err := m.Flag_Cetcd_Beta.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 500:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Flag_Zetcd_Beta", wireType)
+			}
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowConfigClientMachine
@@ -1426,78 +7188,30 @@ func (m *ConfigClientMachineBenchmarkOptions) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.ClientNumber |= (int64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 5:
-			if wireType == 0 {
-				var v int64
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowConfigClientMachine
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					v |= (int64(b) & 0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				m.ConnectionClientNumbers = append(m.ConnectionClientNumbers, v)
-			} else if wireType == 2 {
-				var packedLen int
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowConfigClientMachine
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					packedLen |= (int(b) & 0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				if packedLen < 0 {
-					return ErrInvalidLengthConfigClientMachine
-				}
-				postIndex := iNdEx + packedLen
-				if postIndex > l {
-					return io.ErrUnexpectedEOF
-				}
-				for iNdEx < postIndex {
-					var v int64
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowConfigClientMachine
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						v |= (int64(b) & 0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					m.ConnectionClientNumbers = append(m.ConnectionClientNumbers, v)
-				}
-			} else {
-				return fmt.Errorf("proto: wrong wireType = %d for field ConnectionClientNumbers", wireType)
+			if msglen < 0 {
+				return ErrInvalidLengthConfigClientMachine
 			}
-		case 6:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RateLimitRequestsPerSecond", wireType)
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
 			}
-			m.RateLimitRequestsPerSecond = 0
+			if m.Flag_Zetcd_Beta == nil {
+				m.Flag_Zetcd_Beta = &Flag_Zetcd_Beta{}
+			}
+			if err := m.Flag_Zetcd_Beta.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 600:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Flag_Redis_V4_0", wireType)
+			}
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowConfigClientMachine
@@ -1507,16 +7221,30 @@ func (m *ConfigClientMachineBenchmarkOptions) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.RateLimitRequestsPerSecond |= (int64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 7:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SameKey", wireType)
+			if msglen < 0 {
+				return ErrInvalidLengthConfigClientMachine
 			}
-			var v int
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Flag_Redis_V4_0 == nil {
+				m.Flag_Redis_V4_0 = &Flag_Redis_V4_0{}
+			}
+			if err := m.Flag_Redis_V4_0.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 700:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Flag_Tikv_V2_1", wireType)
+			}
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowConfigClientMachine
@@ -1526,17 +7254,30 @@ func (m *ConfigClientMachineBenchmarkOptions) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= (int(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.SameKey = bool(v != 0)
-		case 8:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field KeySizeBytes", wireType)
+			if msglen < 0 {
+				return ErrInvalidLengthConfigClientMachine
 			}
-			m.KeySizeBytes = 0
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Flag_Tikv_V2_1 == nil {
+				m.Flag_Tikv_V2_1 = &Flag_Tikv_V2_1{}
+			}
+			if err := m.Flag_Tikv_V2_1.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 900:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Flag_Mock", wireType)
+			}
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowConfigClientMachine
@@ -1546,16 +7287,30 @@ func (m *ConfigClientMachineBenchmarkOptions) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.KeySizeBytes |= (int64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 9:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ValueSizeBytes", wireType)
+			if msglen < 0 {
+				return ErrInvalidLengthConfigClientMachine
 			}
-			m.ValueSizeBytes = 0
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Flag_Mock == nil {
+				m.Flag_Mock = &Flag_Mock{}
+			}
+			if err := m.Flag_Mock.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 1000:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConfigClientMachineBenchmarkOptions", wireType)
+			}
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowConfigClientMachine
@@ -1565,16 +7320,30 @@ func (m *ConfigClientMachineBenchmarkOptions) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.ValueSizeBytes |= (int64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 10:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field StaleRead", wireType)
+			if msglen < 0 {
+				return ErrInvalidLengthConfigClientMachine
 			}
-			var v int
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ConfigClientMachineBenchmarkOptions == nil {
+				m.ConfigClientMachineBenchmarkOptions = &ConfigClientMachineBenchmarkOptions{}
+			}
+			if err := m.ConfigClientMachineBenchmarkOptions.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 1001:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConfigClientMachineBenchmarkSteps", wireType)
+			}
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowConfigClientMachine
@@ -1584,12 +7353,25 @@ func (m *ConfigClientMachineBenchmarkOptions) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= (int(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.StaleRead = bool(v != 0)
+			if msglen < 0 {
+				return ErrInvalidLengthConfigClientMachine
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ConfigClientMachineBenchmarkSteps == nil {
+				m.ConfigClientMachineBenchmarkSteps = &ConfigClientMachineBenchmarkSteps{}
+			}
+			if err := m.ConfigClientMachineBenchmarkSteps.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipConfigClientMachine(dAtA[iNdEx:])
@@ -1611,7 +7393,7 @@ func (m *ConfigClientMachineBenchmarkOptions) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ConfigClientMachineBenchmarkSteps) Unmarshal(dAtA []byte) error {
+func (m *ConfigClientMachineLearnerReads) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1634,37 +7416,17 @@ func (m *ConfigClientMachineBenchmarkSteps) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ConfigClientMachineBenchmarkSteps: wiretype end group for non-group")
+			return fmt.Errorf("proto: ConfigClientMachineLearnerReads: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ConfigClientMachineBenchmarkSteps: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ConfigClientMachineLearnerReads: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Step1StartDatabase", wireType)
-			}
-			var v int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowConfigClientMachine
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				v |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			m.Step1StartDatabase = bool(v != 0)
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Step2StressDatabase", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Endpoints", wireType)
 			}
-			var v int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowConfigClientMachine
@@ -1674,37 +7436,26 @@ func (m *ConfigClientMachineBenchmarkSteps) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.Step2StressDatabase = bool(v != 0)
-		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Step3StopDatabase", wireType)
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfigClientMachine
 			}
-			var v int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowConfigClientMachine
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				v |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
 			}
-			m.Step3StopDatabase = bool(v != 0)
-		case 4:
+			m.Endpoints = append(m.Endpoints, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Step4UploadLogs", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field IntervalMilliseconds", wireType)
 			}
-			var v int
+			m.IntervalMilliseconds = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowConfigClientMachine
@@ -1714,12 +7465,11 @@ func (m *ConfigClientMachineBenchmarkSteps) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= (int(b) & 0x7F) << shift
+				m.IntervalMilliseconds |= (int64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.Step4UploadLogs = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipConfigClientMachine(dAtA[iNdEx:])
@@ -1741,7 +7491,7 @@ func (m *ConfigClientMachineBenchmarkSteps) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ConfigClientMachineAgentControl) Unmarshal(dAtA []byte) error {
+func (m *ConfigClientMachineFailover) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1764,17 +7514,17 @@ func (m *ConfigClientMachineAgentControl) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ConfigClientMachineAgentControl: wiretype end group for non-group")
+			return fmt.Errorf("proto: ConfigClientMachineFailover: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ConfigClientMachineAgentControl: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ConfigClientMachineFailover: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DatabaseID", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Trials", wireType)
 			}
-			var stringLen uint64
+			m.Trials = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowConfigClientMachine
@@ -1784,26 +7534,16 @@ func (m *ConfigClientMachineAgentControl) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				m.Trials |= (int64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthConfigClientMachine
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.DatabaseID = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
 		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DatabaseDescription", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProbeIntervalMilliseconds", wireType)
 			}
-			var stringLen uint64
+			m.ProbeIntervalMilliseconds = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowConfigClientMachine
@@ -1813,26 +7553,16 @@ func (m *ConfigClientMachineAgentControl) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				m.ProbeIntervalMilliseconds |= (int64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthConfigClientMachine
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.DatabaseDescription = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
 		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DatabaseTag", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RequestTimeoutMilliseconds", wireType)
 			}
-			var stringLen uint64
+			m.RequestTimeoutMilliseconds = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowConfigClientMachine
@@ -1842,26 +7572,16 @@ func (m *ConfigClientMachineAgentControl) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				m.RequestTimeoutMilliseconds |= (int64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthConfigClientMachine
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.DatabaseTag = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
 		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PeerIPs", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TimeoutSeconds", wireType)
 			}
-			var stringLen uint64
+			m.TimeoutSeconds = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowConfigClientMachine
@@ -1871,55 +7591,66 @@ func (m *ConfigClientMachineAgentControl) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				m.TimeoutSeconds |= (int64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipConfigClientMachine(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
 				return ErrInvalidLengthConfigClientMachine
 			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.PeerIPs = append(m.PeerIPs, string(dAtA[iNdEx:postIndex]))
-			iNdEx = postIndex
-		case 5:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PeerIPsString", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowConfigClientMachine
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthConfigClientMachine
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ConfigClientMachineSpikeRecovery) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowConfigClientMachine
 			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
+			if iNdEx >= l {
 				return io.ErrUnexpectedEOF
 			}
-			m.PeerIPsString = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 6:
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ConfigClientMachineSpikeRecovery: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ConfigClientMachineSpikeRecovery: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AgentPortToConnect", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field WithinPercent", wireType)
 			}
-			m.AgentPortToConnect = 0
+			m.WithinPercent = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowConfigClientMachine
@@ -1929,16 +7660,16 @@ func (m *ConfigClientMachineAgentControl) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.AgentPortToConnect |= (int64(b) & 0x7F) << shift
+				m.WithinPercent |= (int64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 7:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AgentEndpoints", wireType)
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BaselineSeconds", wireType)
 			}
-			var stringLen uint64
+			m.BaselineSeconds = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowConfigClientMachine
@@ -1948,43 +7679,64 @@ func (m *ConfigClientMachineAgentControl) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				m.BaselineSeconds |= (int64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipConfigClientMachine(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
 				return ErrInvalidLengthConfigClientMachine
 			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.AgentEndpoints = append(m.AgentEndpoints, string(dAtA[iNdEx:postIndex]))
-			iNdEx = postIndex
-		case 8:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DatabasePortToConnect", wireType)
-			}
-			m.DatabasePortToConnect = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowConfigClientMachine
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.DatabasePortToConnect |= (int64(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ConfigClientMachineTLSHandshake) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowConfigClientMachine
 			}
-		case 9:
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ConfigClientMachineTLSHandshake: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ConfigClientMachineTLSHandshake: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DatabaseEndpoints", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Endpoints", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2009,13 +7761,13 @@ func (m *ConfigClientMachineAgentControl) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.DatabaseEndpoints = append(m.DatabaseEndpoints, string(dAtA[iNdEx:postIndex]))
+			m.Endpoints = append(m.Endpoints, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
-		case 100:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Flag_Etcd_Other", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field CAFile", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowConfigClientMachine
@@ -2025,30 +7777,26 @@ func (m *ConfigClientMachineAgentControl) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthConfigClientMachine
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Flag_Etcd_Other == nil {
-				m.Flag_Etcd_Other = &Flag_Etcd_Other{}
-			}
-			if err := m.Flag_Etcd_Other.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.CAFile = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 101:
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Flag_Etcd_Tip", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field CertFile", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowConfigClientMachine
@@ -2058,30 +7806,26 @@ func (m *ConfigClientMachineAgentControl) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthConfigClientMachine
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Flag_Etcd_Tip == nil {
-				m.Flag_Etcd_Tip = &Flag_Etcd_Tip{}
-			}
-			if err := m.Flag_Etcd_Tip.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.CertFile = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 102:
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Flag_Etcd_V3_2", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field KeyFile", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowConfigClientMachine
@@ -2091,30 +7835,26 @@ func (m *ConfigClientMachineAgentControl) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthConfigClientMachine
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Flag_Etcd_V3_2 == nil {
-				m.Flag_Etcd_V3_2 = &Flag_Etcd_V3_2{}
-			}
-			if err := m.Flag_Etcd_V3_2.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.KeyFile = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 103:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Flag_Etcd_V3_3", wireType)
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InsecureSkipVerify", wireType)
 			}
-			var msglen int
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowConfigClientMachine
@@ -2124,30 +7864,17 @@ func (m *ConfigClientMachineAgentControl) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				v |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthConfigClientMachine
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Flag_Etcd_V3_3 == nil {
-				m.Flag_Etcd_V3_3 = &Flag_Etcd_V3_3{}
-			}
-			if err := m.Flag_Etcd_V3_3.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 200:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Flag_Zookeeper_R3_5_3Beta", wireType)
+			m.InsecureSkipVerify = bool(v != 0)
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IntervalMilliseconds", wireType)
 			}
-			var msglen int
+			m.IntervalMilliseconds = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowConfigClientMachine
@@ -2157,30 +7884,66 @@ func (m *ConfigClientMachineAgentControl) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				m.IntervalMilliseconds |= (int64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipConfigClientMachine(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
 				return ErrInvalidLengthConfigClientMachine
 			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Flag_Zookeeper_R3_5_3Beta == nil {
-				m.Flag_Zookeeper_R3_5_3Beta = &Flag_Zookeeper_R3_5_3Beta{}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ConfigClientMachineResourceMonitor) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowConfigClientMachine
 			}
-			if err := m.Flag_Zookeeper_R3_5_3Beta.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
 			}
-			iNdEx = postIndex
-		case 300:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Flag_Consul_V1_0_2", wireType)
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
 			}
-			var msglen int
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ConfigClientMachineResourceMonitor: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ConfigClientMachineResourceMonitor: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Agents", wireType)
+			}
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowConfigClientMachine
@@ -2190,30 +7953,17 @@ func (m *ConfigClientMachineAgentControl) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				v |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthConfigClientMachine
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Flag_Consul_V1_0_2 == nil {
-				m.Flag_Consul_V1_0_2 = &Flag_Consul_V1_0_2{}
-			}
-			if err := m.Flag_Consul_V1_0_2.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 400:
+			m.Agents = bool(v != 0)
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Flag_Cetcd_Beta", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Programs", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowConfigClientMachine
@@ -2223,30 +7973,26 @@ func (m *ConfigClientMachineAgentControl) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthConfigClientMachine
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Flag_Cetcd_Beta == nil {
-				m.Flag_Cetcd_Beta = &Flag_Cetcd_Beta{}
-			}
-			if err := m.Flag_Cetcd_Beta.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Programs = append(m.Programs, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
-		case 500:
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Flag_Zetcd_Beta", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field DiskDevice", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowConfigClientMachine
@@ -2256,30 +8002,26 @@ func (m *ConfigClientMachineAgentControl) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthConfigClientMachine
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Flag_Zetcd_Beta == nil {
-				m.Flag_Zetcd_Beta = &Flag_Zetcd_Beta{}
-			}
-			if err := m.Flag_Zetcd_Beta.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.DiskDevice = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 1000:
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ConfigClientMachineBenchmarkOptions", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field NetworkInterface", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowConfigClientMachine
@@ -2289,30 +8031,26 @@ func (m *ConfigClientMachineAgentControl) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthConfigClientMachine
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.ConfigClientMachineBenchmarkOptions == nil {
-				m.ConfigClientMachineBenchmarkOptions = &ConfigClientMachineBenchmarkOptions{}
-			}
-			if err := m.ConfigClientMachineBenchmarkOptions.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.NetworkInterface = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 1001:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ConfigClientMachineBenchmarkSteps", wireType)
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IntervalSeconds", wireType)
 			}
-			var msglen int
+			m.IntervalSeconds = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowConfigClientMachine
@@ -2322,25 +8060,11 @@ func (m *ConfigClientMachineAgentControl) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				m.IntervalSeconds |= (int64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthConfigClientMachine
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.ConfigClientMachineBenchmarkSteps == nil {
-				m.ConfigClientMachineBenchmarkSteps = &ConfigClientMachineBenchmarkSteps{}
-			}
-			if err := m.ConfigClientMachineBenchmarkSteps.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipConfigClientMachine(dAtA[iNdEx:])
@@ -2472,112 +8196,293 @@ func init() {
 }
 
 var fileDescriptorConfigClientMachine = []byte{
-	// 1711 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x58, 0xcd, 0x6e, 0xdb, 0xc8,
-	0x1d, 0x5f, 0x45, 0xc9, 0xc6, 0x1e, 0xe7, 0x73, 0x12, 0x27, 0x8a, 0xe3, 0x78, 0x1c, 0x26, 0xe9,
-	0x7a, 0xb1, 0x8d, 0x9d, 0x48, 0xd9, 0x05, 0x5a, 0xb4, 0x68, 0x57, 0xf6, 0xb6, 0x0d, 0xe2, 0xdd,
-	0xa8, 0x94, 0x37, 0x45, 0x83, 0xa2, 0xd3, 0x11, 0x35, 0xa6, 0xb8, 0xa6, 0x38, 0x2c, 0x67, 0x18,
-	0x54, 0xee, 0xad, 0x28, 0x50, 0xb4, 0xa7, 0x3d, 0xee, 0xb1, 0x0f, 0xd0, 0xe7, 0x28, 0x72, 0xec,
-	0x13, 0x10, 0x6d, 0xf6, 0xd2, 0x5e, 0x89, 0x3e, 0xc0, 0x62, 0xfe, 0x24, 0xa5, 0xa1, 0x44, 0xc9,
-	0xba, 0x89, 0xf3, 0xff, 0x7d, 0xcd, 0x70, 0xbe, 0x28, 0xf4, 0xbd, 0x7e, 0x4f, 0x71, 0xa9, 0x78,
-	0x14, 0xf6, 0xf6, 0x1c, 0x11, 0x1c, 0x7b, 0x2e, 0x75, 0x7c, 0x8f, 0x07, 0x8a, 0x0e, 0x99, 0x33,
-	0xf0, 0x02, 0xbe, 0x1b, 0x46, 0x42, 0x09, 0x8c, 0x26, 0xb8, 0x8d, 0xc7, 0xae, 0xa7, 0x06, 0x71,
-	0x6f, 0xd7, 0x11, 0xc3, 0x3d, 0x57, 0xb8, 0x62, 0x0f, 0x20, 0xbd, 0xf8, 0x18, 0x9e, 0xe0, 0x01,
-	0x7e, 0x65, 0xd4, 0x8d, 0x0d, 0xc3, 0xe2, 0xd8, 0x67, 0x2e, 0xe5, 0xca, 0xe9, 0xe7, 0x35, 0x32,
-	0x5d, 0x3b, 0x15, 0xe2, 0x84, 0xf3, 0x90, 0x47, 0x39, 0x60, 0x73, 0x1a, 0xe0, 0x88, 0x40, 0xc6,
-	0x7e, 0x5e, 0xbd, 0x3b, 0x43, 0x37, 0xb4, 0x67, 0x8a, 0xce, 0xa4, 0x68, 0x7d, 0x7b, 0x09, 0x6d,
-	0xec, 0x43, 0x7f, 0xf7, 0xa1, 0xbb, 0x9f, 0x67, 0xbd, 0x7d, 0x1e, 0x78, 0xca, 0x63, 0x3e, 0xfe,
-	0x04, 0xa1, 0x0e, 0x53, 0x83, 0x4e, 0xc4, 0x8f, 0xbd, 0x3f, 0x34, 0x6a, 0xdb, 0xb5, 0x9d, 0xd5,
-	0xf6, 0xad, 0x34, 0x21, 0x78, 0xc4, 0x86, 0xfe, 0x0f, 0xad, 0x90, 0xa9, 0x01, 0x0d, 0xa1, 0x68,
-	0xd9, 0x06, 0x12, 0x3f, 0x46, 0x17, 0x0f, 0x85, 0xab, 0x1b, 0x1a, 0xe7, 0x80, 0x74, 0x23, 0x4d,
-	0xc8, 0xd5, 0x8c, 0xe4, 0x0b, 0x97, 0x6a, 0xa2, 0x65, 0x17, 0x18, 0x4c, 0xd1, 0xed, 0xcc, 0xbe,
-	0x3b, 0x92, 0x8a, 0x0f, 0x3f, 0xe7, 0x2a, 0xf2, 0x1c, 0x09, 0xf4, 0x3a, 0xd0, 0x1f, 0xa5, 0x09,
-	0xb9, 0x9f, 0xd1, 0xf3, 0xd7, 0x22, 0x01, 0x49, 0x87, 0x19, 0x34, 0x17, 0x9c, 0xa7, 0x82, 0xff,
-	0x5c, 0x43, 0x0f, 0x2a, 0x6a, 0xcf, 0x03, 0x3d, 0x2c, 0xc2, 0x67, 0x8a, 0xf7, 0xc1, 0xed, 0x3c,
-	0xb8, 0x35, 0xd3, 0x84, 0xec, 0x2e, 0x72, 0xf3, 0x0c, 0x5e, 0x6e, 0xbd, 0x8c, 0x3c, 0xfe, 0x5b,
-	0x0d, 0x3d, 0xca, 0x70, 0x87, 0x4c, 0xf1, 0xc0, 0x19, 0x1d, 0x0d, 0x22, 0x11, 0xbb, 0x83, 0x30,
-	0x56, 0x47, 0xde, 0x90, 0x4b, 0x1e, 0x79, 0x3c, 0xeb, 0xf6, 0x05, 0x08, 0xf2, 0x2c, 0x4d, 0xc8,
-	0x93, 0x52, 0x10, 0x3f, 0xe3, 0x51, 0x35, 0x26, 0x52, 0x35, 0x66, 0xe6, 0x51, 0x96, 0xb3, 0xc0,
-	0x7f, 0x44, 0xdb, 0x25, 0xe0, 0x81, 0x27, 0x55, 0xe4, 0xf5, 0x62, 0xe5, 0x89, 0xe0, 0x53, 0xdf,
-	0x87, 0x18, 0xef, 0x43, 0x8c, 0xbd, 0x34, 0x21, 0x1f, 0x55, 0xc6, 0xe8, 0x1b, 0x1c, 0xca, 0x7c,
-	0x3f, 0x4f, 0x70, 0xa6, 0x30, 0xfe, 0xba, 0x86, 0x3e, 0x98, 0x0b, 0xea, 0xf0, 0xc8, 0xe1, 0x81,
-	0xf2, 0x7c, 0x0e, 0x21, 0x2e, 0x42, 0x88, 0x4f, 0xd2, 0x84, 0x34, 0xcf, 0x0e, 0x11, 0x8e, 0xb9,
-	0x79, 0x96, 0x65, 0x6d, 0xf0, 0x5f, 0x6a, 0xe8, 0xe1, 0x5c, 0x6c, 0x37, 0x1e, 0x0e, 0x59, 0x34,
-	0x82, 0x3c, 0x2b, 0x90, 0xa7, 0x95, 0x26, 0x64, 0xef, 0xec, 0x3c, 0x32, 0x23, 0xe6, 0x61, 0x96,
-	0x32, 0xc0, 0x21, 0xda, 0x2c, 0xe1, 0xda, 0xa3, 0x17, 0x7c, 0xf4, 0x45, 0x3c, 0xec, 0xf1, 0x08,
-	0x02, 0xac, 0x42, 0x80, 0xef, 0xa7, 0x09, 0xd9, 0xa9, 0x0c, 0xd0, 0x1b, 0xd1, 0x13, 0x3e, 0xa2,
-	0x01, 0x30, 0x72, 0xe7, 0x85, 0x8a, 0x78, 0x84, 0x48, 0x97, 0x47, 0x6f, 0x78, 0x74, 0xe0, 0xc9,
-	0x93, 0x6e, 0xc8, 0x1c, 0xfe, 0xa5, 0x64, 0x2e, 0x37, 0x7b, 0x8d, 0xa6, 0xa7, 0x82, 0x04, 0x82,
-	0xee, 0xed, 0x09, 0x95, 0x9a, 0x42, 0x63, 0xcd, 0x99, 0xea, 0xf1, 0x59, 0xba, 0xf8, 0x37, 0xe8,
-	0xd6, 0xcf, 0x85, 0x70, 0x7d, 0xbe, 0xef, 0x8b, 0xb8, 0xdf, 0x89, 0xc4, 0x57, 0xdc, 0x51, 0x5f,
-	0xb0, 0x21, 0x6f, 0xf4, 0xc1, 0xf1, 0x61, 0x9a, 0x90, 0xed, 0xcc, 0xd1, 0x05, 0x1c, 0x75, 0x34,
-	0x90, 0x86, 0x19, 0x92, 0x06, 0x6c, 0xc8, 0x2d, 0x7b, 0x8e, 0x06, 0x3e, 0x46, 0x77, 0x8c, 0x4a,
-	0x57, 0x89, 0x88, 0xb9, 0xfc, 0x05, 0xcf, 0xba, 0xc4, 0xc1, 0x60, 0x27, 0x4d, 0xc8, 0xc3, 0x0a,
-	0x03, 0x99, 0x81, 0x61, 0x28, 0xb3, 0xbe, 0xcc, 0x97, 0xc2, 0xcf, 0xd0, 0x7a, 0x65, 0xb1, 0x71,
-	0xac, 0x3d, 0xec, 0xea, 0x22, 0x16, 0x68, 0x73, 0xb6, 0xd0, 0x8e, 0x9d, 0x13, 0x9e, 0x8d, 0x80,
-	0x0b, 0x01, 0x3f, 0x4a, 0x13, 0xf2, 0xc1, 0x82, 0x80, 0x3d, 0x20, 0xe4, 0x03, 0xb1, 0x50, 0x10,
-	0xc7, 0x68, 0x6b, 0xb6, 0xde, 0x8d, 0x7b, 0x07, 0x5e, 0xc4, 0x1d, 0x25, 0xa2, 0x51, 0x63, 0x00,
-	0x96, 0x8f, 0xd3, 0x84, 0x7c, 0xb8, 0xc0, 0x52, 0xc6, 0x3d, 0xda, 0x2f, 0x38, 0x96, 0x7d, 0x86,
-	0xa8, 0xf5, 0xcf, 0x0b, 0xe8, 0x41, 0xc5, 0x29, 0xd3, 0xe6, 0x81, 0x33, 0x18, 0xb2, 0xe8, 0xe4,
-	0x65, 0xa8, 0x97, 0x80, 0xc4, 0x0f, 0xd0, 0xf9, 0xa3, 0x51, 0xc8, 0xf3, 0x83, 0xe6, 0x6a, 0x9a,
-	0x90, 0xb5, 0x2c, 0x84, 0x1a, 0x85, 0xdc, 0xb2, 0xa1, 0x88, 0x7f, 0x82, 0x2e, 0xdb, 0xfc, 0xf7,
-	0x31, 0x97, 0x2a, 0x9b, 0xc0, 0x70, 0xc2, 0xd4, 0xdb, 0x77, 0xd2, 0x84, 0xac, 0x67, 0xe8, 0x28,
-	0x2b, 0xe7, 0x0b, 0xc0, 0xb2, 0xcb, 0x78, 0xfc, 0x0b, 0x74, 0x6d, 0x5f, 0x04, 0x01, 0x77, 0xb4,
-	0x69, 0xae, 0x51, 0x07, 0x8d, 0xcd, 0x34, 0x21, 0x8d, 0x7c, 0x49, 0x8d, 0x11, 0x63, 0x99, 0x19,
-	0x16, 0xfe, 0x11, 0xba, 0x94, 0x75, 0x28, 0x57, 0x39, 0x0f, 0x2a, 0x8d, 0x34, 0x21, 0x37, 0x4b,
-	0x0b, 0xb3, 0x50, 0x28, 0xa1, 0xf1, 0x6f, 0xd1, 0xed, 0x89, 0xa2, 0x59, 0x91, 0x8d, 0x0b, 0xdb,
-	0xf5, 0x9d, 0xba, 0x39, 0xf5, 0x8d, 0x38, 0x25, 0x4d, 0xa9, 0x0f, 0xbd, 0x6a, 0x11, 0xec, 0xa1,
-	0x0d, 0x9b, 0x29, 0x7e, 0xe8, 0x0d, 0x3d, 0x95, 0x8f, 0x80, 0xec, 0xf0, 0xa8, 0xcb, 0x1d, 0x11,
-	0xf4, 0x61, 0x6b, 0xaf, 0xb7, 0x3f, 0x4c, 0x13, 0xf2, 0x28, 0x1f, 0x35, 0xa6, 0x38, 0xf5, 0x35,
-	0x98, 0xe6, 0x03, 0x28, 0xf5, 0x6e, 0x4a, 0x25, 0xe0, 0x2d, 0x7b, 0x81, 0x98, 0x3e, 0xef, 0xbb,
-	0x6c, 0x08, 0x13, 0x5e, 0xef, 0xd6, 0x2b, 0xe6, 0x79, 0x2f, 0xd9, 0x10, 0x16, 0x91, 0x65, 0x17,
-	0x18, 0xfc, 0x63, 0x74, 0xe9, 0x05, 0x1f, 0x75, 0xbd, 0x53, 0xde, 0x1e, 0x29, 0x2e, 0x61, 0x47,
-	0x2d, 0xbd, 0x41, 0xbd, 0xe6, 0xa4, 0x77, 0xca, 0x69, 0x4f, 0xd7, 0x2d, 0xbb, 0x04, 0xc7, 0xfb,
-	0xe8, 0xca, 0x2b, 0xe6, 0xc7, 0x7c, 0x22, 0xb0, 0x0a, 0x02, 0x77, 0xd3, 0x84, 0xdc, 0xce, 0x04,
-	0xde, 0xe8, 0x7a, 0x49, 0x62, 0x8a, 0x82, 0x5b, 0x68, 0xb5, 0xab, 0x98, 0xcf, 0x6d, 0xce, 0xfa,
-	0xb0, 0xb9, 0xad, 0xb4, 0xd7, 0xd3, 0x84, 0x5c, 0xcf, 0x43, 0xeb, 0x12, 0x8d, 0x38, 0xeb, 0x5b,
-	0xf6, 0x04, 0x67, 0x25, 0xe7, 0xd0, 0xfd, 0x45, 0x13, 0xb9, 0xab, 0x78, 0x28, 0xf1, 0x4b, 0x84,
-	0xf5, 0x8f, 0xa7, 0x5d, 0xc5, 0x22, 0x75, 0xc0, 0x14, 0xeb, 0x31, 0x99, 0x4d, 0xea, 0x95, 0x36,
-	0x49, 0x13, 0x72, 0xb7, 0xf0, 0xe0, 0xe1, 0x53, 0x2a, 0x35, 0x88, 0xf6, 0x73, 0x94, 0x65, 0x57,
-	0x50, 0xb1, 0x8d, 0x6e, 0xe8, 0xd6, 0x66, 0x57, 0x45, 0x5c, 0xca, 0xb1, 0xe2, 0x39, 0x50, 0xdc,
-	0x4e, 0x13, 0xb2, 0x39, 0x51, 0x6c, 0x52, 0x09, 0x28, 0x43, 0xb2, 0x8a, 0x8c, 0x0f, 0xd1, 0x75,
-	0xdd, 0xdc, 0xea, 0x2a, 0x11, 0x8e, 0x15, 0xeb, 0xa0, 0xb8, 0x95, 0x26, 0x64, 0x63, 0xa2, 0xd8,
-	0xd2, 0xcb, 0x3e, 0x34, 0xf4, 0x66, 0x89, 0xf8, 0x67, 0xe8, 0xaa, 0x6e, 0x7c, 0xf6, 0x65, 0xe8,
-	0x0b, 0xd6, 0x3f, 0x14, 0xae, 0x84, 0xc5, 0xb0, 0x62, 0x2e, 0x29, 0xad, 0xf5, 0x8c, 0xc6, 0x80,
-	0xa0, 0xbe, 0x70, 0xa5, 0x65, 0x4f, 0x93, 0xac, 0x3f, 0x5d, 0x41, 0xa4, 0x62, 0x80, 0x3f, 0x75,
-	0x79, 0xa0, 0xf6, 0x45, 0xa0, 0x22, 0x01, 0x97, 0xd2, 0xc2, 0xf7, 0xf9, 0xc1, 0xec, 0xa5, 0xb4,
-	0xc8, 0x49, 0xbd, 0xbe, 0x65, 0x1b, 0x48, 0xfc, 0x4b, 0x74, 0xa3, 0x78, 0x3a, 0xe0, 0xd2, 0x89,
-	0x3c, 0xd8, 0x75, 0xf2, 0x0b, 0xaa, 0xf1, 0x5e, 0xc6, 0x02, 0xfd, 0x09, 0xca, 0xb2, 0xab, 0xb8,
-	0xf8, 0x07, 0x68, 0xad, 0x68, 0x3e, 0x62, 0x6e, 0x7e, 0x59, 0xbd, 0x9d, 0x26, 0xe4, 0xc6, 0x94,
-	0x94, 0x62, 0xae, 0x65, 0x9b, 0x58, 0xbd, 0x64, 0x3a, 0x9c, 0x47, 0xcf, 0x3b, 0x7a, 0xa4, 0xea,
-	0xe5, 0x2b, 0x72, 0xc8, 0x79, 0x44, 0xbd, 0x50, 0x5a, 0x76, 0x81, 0xc1, 0x3f, 0x45, 0x97, 0xf3,
-	0x9f, 0x5d, 0x15, 0x79, 0x81, 0x9b, 0xdf, 0x10, 0x37, 0xd2, 0x84, 0xdc, 0x2a, 0x93, 0xf4, 0xfb,
-	0xf7, 0x02, 0xd7, 0xb2, 0xcb, 0x04, 0xdc, 0x41, 0x18, 0x86, 0xb1, 0x23, 0x22, 0x75, 0x24, 0xf2,
-	0x4d, 0x23, 0xdf, 0x06, 0x8c, 0x39, 0xc4, 0x34, 0x86, 0x86, 0x22, 0x52, 0x54, 0x09, 0x9a, 0xef,
-	0x3b, 0x96, 0x5d, 0xc1, 0xc5, 0x6d, 0x74, 0x05, 0x5a, 0x3f, 0x0b, 0xfa, 0xa1, 0xf0, 0x02, 0x25,
-	0x1b, 0x17, 0xa1, 0x27, 0x46, 0xa8, 0x4c, 0x8d, 0x17, 0x00, 0xcb, 0x9e, 0x62, 0xe0, 0x5f, 0xa3,
-	0xf5, 0x62, 0x54, 0xca, 0xc1, 0xb2, 0x3d, 0xe1, 0x41, 0x9a, 0x10, 0x32, 0x35, 0x96, 0x33, 0xd9,
-	0xaa, 0x15, 0xf0, 0x0b, 0x74, 0xbd, 0x28, 0x4c, 0x12, 0xae, 0x42, 0xc2, 0x7b, 0x69, 0x42, 0xee,
-	0x4c, 0xc9, 0x1a, 0x21, 0x67, 0x79, 0x98, 0xa2, 0xeb, 0xf0, 0xf1, 0x04, 0x5f, 0x6d, 0x94, 0x0a,
-	0x35, 0xe0, 0x11, 0xdc, 0x50, 0xd6, 0x9a, 0xf7, 0x76, 0x27, 0x5f, 0x58, 0xbb, 0x33, 0x20, 0x73,
-	0x6a, 0x1a, 0xcd, 0x96, 0x7d, 0x59, 0x43, 0x3f, 0x53, 0x4e, 0xff, 0xa5, 0x7e, 0xc6, 0xbf, 0x42,
-	0x57, 0x4d, 0xae, 0xf2, 0x42, 0xb8, 0x9f, 0xac, 0x35, 0xef, 0xce, 0x93, 0x57, 0x5e, 0xd8, 0xbe,
-	0x99, 0x26, 0xe4, 0x9a, 0x29, 0xae, 0xbc, 0xd0, 0xb2, 0xd7, 0x0a, 0xe9, 0x23, 0x2f, 0xc4, 0xaf,
-	0xd1, 0x35, 0x93, 0xf5, 0xa6, 0x45, 0x9b, 0x70, 0x2b, 0x59, 0x6b, 0x6e, 0xce, 0x53, 0xd6, 0x18,
-	0x73, 0x37, 0x9c, 0xb4, 0x1a, 0xda, 0xaf, 0x5a, 0xcd, 0x0a, 0xed, 0x16, 0x5c, 0x5a, 0x16, 0x6b,
-	0xb7, 0x2a, 0xb5, 0x5b, 0x25, 0xed, 0x16, 0xfe, 0x6b, 0x0d, 0x6d, 0x66, 0xc4, 0xf1, 0xc7, 0x30,
-	0xa5, 0x51, 0x8b, 0x7e, 0x4c, 0x5b, 0xb4, 0xc7, 0x15, 0x6b, 0xbc, 0xad, 0x81, 0xd3, 0xce, 0xac,
-	0x53, 0x35, 0xa1, 0x7d, 0x3f, 0x4d, 0xc8, 0xbd, 0xcc, 0xb5, 0x1a, 0x61, 0xd9, 0xeb, 0x5a, 0xe0,
-	0x75, 0x51, 0xb4, 0x5b, 0x1f, 0xb7, 0xda, 0x5c, 0x31, 0xfc, 0x15, 0xba, 0x99, 0x29, 0x67, 0x9f,
-	0xdd, 0x94, 0xbe, 0x79, 0x4a, 0x9f, 0xd0, 0x66, 0xe3, 0x1f, 0xe7, 0x20, 0xc2, 0xf6, 0x6c, 0x84,
-	0x32, 0xd0, 0x3c, 0xdb, 0xca, 0x15, 0xcb, 0xbe, 0xa2, 0x09, 0xfb, 0xd0, 0xf8, 0xea, 0xe9, 0x93,
-	0x26, 0xfe, 0x5d, 0x31, 0xd3, 0x9c, 0x6c, 0x68, 0xa0, 0xaf, 0x5f, 0xd7, 0xe7, 0x4d, 0x35, 0x03,
-	0x65, 0x4e, 0x35, 0xa3, 0x39, 0x9f, 0x6a, 0xfb, 0xba, 0x05, 0x7a, 0x33, 0x76, 0x38, 0x35, 0x1c,
-	0xfe, 0x3f, 0xd7, 0xe1, 0xb4, 0xda, 0xe1, 0x74, 0xc6, 0xe1, 0xf5, 0xd8, 0xe1, 0xef, 0xb5, 0xa5,
-	0x2e, 0x7c, 0x8d, 0xff, 0x5e, 0x04, 0xd3, 0x3d, 0xd3, 0x74, 0x09, 0x9e, 0x79, 0xaa, 0xf4, 0x8a,
-	0x1a, 0x15, 0x59, 0x51, 0x7f, 0x8b, 0x2f, 0x71, 0xd7, 0xfc, 0xa6, 0xb6, 0xc4, 0x51, 0xde, 0xf8,
-	0x5f, 0x16, 0xf0, 0xf1, 0xb2, 0x01, 0x81, 0x65, 0x6e, 0x80, 0x93, 0x78, 0xfa, 0xf8, 0x93, 0x96,
-	0x7d, 0xb6, 0x69, 0xfb, 0xe6, 0xdb, 0xff, 0x6c, 0xbd, 0xf7, 0xf6, 0xdd, 0x56, 0xed, 0x5f, 0xef,
-	0xb6, 0x6a, 0xff, 0x7e, 0xb7, 0x55, 0xfb, 0xe6, 0xdb, 0xad, 0xf7, 0x7a, 0xef, 0xc3, 0x3f, 0x36,
-	0xad, 0xef, 0x02, 0x00, 0x00, 0xff, 0xff, 0x25, 0x94, 0x69, 0xa1, 0xab, 0x12, 0x00, 0x00,
+	// 4595 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x7b, 0x5b, 0x8f, 0x1c, 0x49,
+	0x56, 0xff, 0x96, 0xcb, 0x97, 0x76, 0xb6, 0x67, 0x6c, 0xe7, 0xd8, 0x9e, 0xf4, 0x65, 0x9c, 0xed,
+	0xf4, 0x5c, 0x3c, 0x9e, 0xf1, 0xad, 0xda, 0x33, 0xff, 0xdd, 0xfd, 0xef, 0xb2, 0xeb, 0xee, 0x9e,
+	0xc1, 0x96, 0xed, 0x71, 0x93, 0xd5, 0xe3, 0x81, 0x59, 0x44, 0x10, 0x95, 0x15, 0x5d, 0x95, 0x5b,
+	0x59, 0x19, 0x49, 0x64, 0x54, 0xdb, 0x6d, 0x24, 0xa4, 0x95, 0x56, 0x82, 0x81, 0x07, 0x56, 0xe2,
+	0x61, 0x57, 0xe2, 0x01, 0x9e, 0x81, 0x8f, 0xc0, 0x07, 0x18, 0xde, 0xe0, 0x09, 0x04, 0x52, 0x09,
+	0x86, 0x17, 0x78, 0x2d, 0xf1, 0x01, 0xd0, 0x39, 0x11, 0x99, 0x19, 0x91, 0x95, 0xd5, 0xd5, 0x0b,
+	0x2b, 0xde, 0xba, 0x33, 0x7e, 0xbf, 0xdf, 0x89, 0x8c, 0xcb, 0x89, 0x73, 0x4e, 0x64, 0x39, 0xef,
+	0xf6, 0x7b, 0x92, 0xe5, 0x92, 0x89, 0xac, 0x77, 0x27, 0xe2, 0xe9, 0x6e, 0x3c, 0x20, 0x51, 0x12,
+	0xb3, 0x54, 0x92, 0x31, 0x8d, 0x86, 0x71, 0xca, 0x6e, 0x67, 0x82, 0x4b, 0xee, 0x3a, 0x15, 0xee,
+	0xd2, 0xad, 0x41, 0x2c, 0x87, 0x93, 0xde, 0xed, 0x88, 0x8f, 0xef, 0x0c, 0xf8, 0x80, 0xdf, 0x41,
+	0x48, 0x6f, 0xb2, 0x8b, 0xff, 0xe1, 0x3f, 0xf8, 0x97, 0xa2, 0x5e, 0xba, 0x64, 0x98, 0xd8, 0x4d,
+	0xe8, 0x80, 0x30, 0x19, 0xf5, 0x75, 0x9b, 0x5f, 0x6f, 0x7b, 0xc5, 0xf9, 0x88, 0xb1, 0x8c, 0x09,
+	0x0d, 0xb8, 0x52, 0x07, 0x44, 0x3c, 0xcd, 0x27, 0x89, 0x6e, 0xbd, 0x3c, 0x47, 0x37, 0xb4, 0xe7,
+	0x1a, 0x23, 0xa3, 0x71, 0xae, 0x53, 0x63, 0x1e, 0x8d, 0x16, 0x11, 0x05, 0xeb, 0xc7, 0xf9, 0x22,
+	0xa2, 0x8c, 0x47, 0x7b, 0xaa, 0x2d, 0xf8, 0x87, 0x35, 0xe7, 0xd2, 0x26, 0x0e, 0xe2, 0x26, 0x8e,
+	0xe1, 0x53, 0x35, 0x84, 0x8f, 0xd2, 0x58, 0xc6, 0x34, 0x71, 0x3f, 0x76, 0x9c, 0x6d, 0x2a, 0x87,
+	0xdb, 0x82, 0xed, 0xc6, 0x2f, 0xbd, 0xd6, 0x5a, 0xeb, 0xc6, 0xc9, 0x8d, 0x0b, 0xb3, 0xa9, 0xef,
+	0xee, 0xd3, 0x71, 0xf2, 0xdd, 0x20, 0xa3, 0x72, 0x48, 0x32, 0x6c, 0x0c, 0x42, 0x03, 0xe9, 0xde,
+	0x72, 0x4e, 0x3c, 0xe1, 0x03, 0x78, 0xe0, 0x1d, 0x41, 0xd2, 0x1b, 0xb3, 0xa9, 0x7f, 0x5a, 0x91,
+	0x12, 0x3e, 0x20, 0x40, 0x0c, 0xc2, 0x02, 0xe3, 0x12, 0xe7, 0x4d, 0x65, 0xbe, 0xbb, 0x9f, 0x4b,
+	0x36, 0x7e, 0xca, 0xa4, 0x88, 0xa3, 0x1c, 0xe9, 0x6d, 0xa4, 0xbf, 0x33, 0x9b, 0xfa, 0xd7, 0x14,
+	0x5d, 0xcf, 0x75, 0x8e, 0x48, 0x32, 0x56, 0x50, 0x2d, 0xb8, 0x48, 0xc5, 0xfd, 0x69, 0xcb, 0xb9,
+	0xde, 0xd0, 0xf6, 0x28, 0x85, 0x51, 0xe1, 0x09, 0x95, 0xac, 0x8f, 0xd6, 0x8e, 0xa2, 0xb5, 0xce,
+	0x6c, 0xea, 0xdf, 0x3e, 0xc8, 0x5a, 0x6c, 0xf0, 0xb4, 0xe9, 0xc3, 0xc8, 0xbb, 0x7f, 0xdc, 0x72,
+	0xde, 0x51, 0xb8, 0x27, 0x54, 0xb2, 0x34, 0xda, 0xdf, 0x19, 0x0a, 0x3e, 0x19, 0x0c, 0xb3, 0x89,
+	0xdc, 0x89, 0xc7, 0x2c, 0x67, 0x22, 0x66, 0xea, 0xb5, 0x8f, 0x61, 0x47, 0xee, 0xcf, 0xa6, 0xfe,
+	0x5d, 0xab, 0x23, 0x89, 0xe2, 0x11, 0x59, 0x12, 0x89, 0x2c, 0x99, 0xba, 0x2b, 0x87, 0x33, 0xe1,
+	0xfe, 0xbe, 0xb3, 0x66, 0x01, 0xb7, 0xe2, 0x5c, 0x8a, 0xb8, 0x37, 0x91, 0x31, 0x4f, 0x1f, 0x24,
+	0x09, 0x76, 0xe3, 0x38, 0x76, 0xe3, 0xce, 0x6c, 0xea, 0x7f, 0xd0, 0xd8, 0x8d, 0xbe, 0xc1, 0x21,
+	0x34, 0x49, 0x74, 0x0f, 0x96, 0x0a, 0xbb, 0x3f, 0x6b, 0x39, 0xef, 0x2d, 0x04, 0x6d, 0x33, 0x11,
+	0xb1, 0x54, 0xc6, 0x09, 0xc3, 0x4e, 0x9c, 0xc0, 0x4e, 0x7c, 0x3c, 0x9b, 0xfa, 0x9d, 0xe5, 0x9d,
+	0xc8, 0x4a, 0xae, 0xee, 0xcb, 0x61, 0xcd, 0xb8, 0x7f, 0xd8, 0x72, 0xde, 0x5e, 0x88, 0xed, 0x4e,
+	0xc6, 0x63, 0x2a, 0xf6, 0xb1, 0x3f, 0x2b, 0xd8, 0x9f, 0xf5, 0xd9, 0xd4, 0xbf, 0xb3, 0xbc, 0x3f,
+	0xb9, 0x22, 0xea, 0xce, 0x1c, 0xca, 0x80, 0x9b, 0x39, 0x57, 0x2c, 0xdc, 0xc6, 0xfe, 0x63, 0xb6,
+	0xff, 0xd9, 0x64, 0xdc, 0x63, 0x02, 0x3b, 0x70, 0x12, 0x3b, 0xf0, 0xe1, 0x6c, 0xea, 0xdf, 0x68,
+	0xec, 0x40, 0x6f, 0x9f, 0x8c, 0xd8, 0x3e, 0x49, 0x91, 0xa1, 0x2d, 0x1f, 0xa8, 0xe8, 0xee, 0x3b,
+	0x7e, 0x97, 0x89, 0x3d, 0x26, 0xb6, 0xe2, 0x7c, 0xd4, 0xcd, 0x68, 0xc4, 0x3e, 0xcf, 0xe9, 0x80,
+	0x99, 0x6f, 0xed, 0xd4, 0x97, 0x42, 0x8e, 0x04, 0x78, 0xdb, 0x11, 0xc9, 0x81, 0x42, 0x26, 0xc0,
+	0xa9, 0xbd, 0xf1, 0x32, 0x5d, 0x97, 0x17, 0x2f, 0x1b, 0xb2, 0xdf, 0x9b, 0xb0, 0x5c, 0xee, 0x08,
+	0x1a, 0xb1, 0x2e, 0x1d, 0x67, 0x7a, 0xf6, 0x57, 0xd1, 0xee, 0x07, 0xb3, 0xa9, 0xff, 0x9e, 0xf5,
+	0xb2, 0x42, 0xc1, 0x89, 0x04, 0x3c, 0xc9, 0x91, 0x60, 0xbf, 0x6b, 0xb3, 0xa0, 0xcb, 0x9c, 0x8b,
+	0xaa, 0xfd, 0x93, 0xb4, 0x9f, 0xf1, 0x38, 0x05, 0xc0, 0xee, 0x6e, 0x1c, 0xa1, 0xb5, 0x53, 0x68,
+	0xed, 0xbd, 0xd9, 0xd4, 0xbf, 0x6e, 0x59, 0x63, 0x1a, 0x4b, 0xa4, 0x02, 0x6b, 0x4b, 0x8b, 0x95,
+	0x2a, 0x9f, 0xb6, 0xc1, 0xb9, 0xcc, 0xa5, 0xa0, 0x19, 0xec, 0x3f, 0x34, 0xf2, 0xda, 0x02, 0x9f,
+	0xd6, 0x2b, 0x90, 0xb8, 0xa7, 0x6d, 0x9f, 0x36, 0xa7, 0xe2, 0xf6, 0x1c, 0x4f, 0xbf, 0x27, 0x4f,
+	0x92, 0x38, 0x1d, 0x84, 0x2c, 0x97, 0x54, 0x48, 0xb4, 0xf0, 0x3a, 0x5a, 0x78, 0x77, 0x36, 0xf5,
+	0x03, 0x7b, 0xd0, 0x14, 0x94, 0x08, 0x85, 0xd5, 0x26, 0x16, 0xea, 0x54, 0x63, 0xf5, 0x05, 0x17,
+	0xa3, 0x84, 0xd3, 0xbe, 0xb9, 0x22, 0x4e, 0x2f, 0x18, 0xab, 0x17, 0x1a, 0x5b, 0x5b, 0x09, 0x8b,
+	0x95, 0xdc, 0xc7, 0xce, 0xd9, 0x4d, 0x9e, 0x24, 0x2c, 0x92, 0x5c, 0x14, 0x63, 0xe9, 0x9d, 0x41,
+	0xf9, 0xb7, 0x66, 0x53, 0xff, 0xa2, 0x96, 0x2f, 0x20, 0xe5, 0x6c, 0x04, 0xe1, 0x3c, 0xcf, 0xfd,
+	0x4d, 0xe7, 0xbc, 0xb2, 0xb4, 0xc9, 0xd3, 0x3d, 0x26, 0x06, 0x2c, 0x8d, 0xd4, 0xb0, 0x9f, 0x45,
+	0xc1, 0x60, 0x36, 0xf5, 0xaf, 0x5a, 0xfd, 0x8d, 0x2a, 0x9c, 0xee, 0x6a, 0xb3, 0x80, 0xfb, 0xa9,
+	0x73, 0x5a, 0x37, 0x0c, 0x29, 0x57, 0x7e, 0xda, 0x45, 0xcd, 0x2b, 0xb3, 0xa9, 0xef, 0xd9, 0x9a,
+	0x80, 0xd0, 0x6a, 0x75, 0x92, 0xfb, 0x93, 0x96, 0x13, 0xe8, 0xe3, 0x02, 0x37, 0x87, 0xde, 0x94,
+	0x9b, 0x5c, 0x08, 0x96, 0x50, 0x74, 0x4d, 0xa0, 0xfd, 0x06, 0x6a, 0xdf, 0x9b, 0x4d, 0xfd, 0x5b,
+	0xf6, 0x61, 0xa4, 0x36, 0x5e, 0xb1, 0xdb, 0xa3, 0x8a, 0xa6, 0x0d, 0x1e, 0x42, 0xbc, 0x5a, 0x9e,
+	0x8f, 0xfa, 0xe0, 0x03, 0xe5, 0xfe, 0x13, 0x46, 0x73, 0x35, 0x4e, 0xe7, 0x16, 0x2c, 0xcf, 0x58,
+	0x23, 0x49, 0x02, 0x50, 0x7b, 0x79, 0xce, 0xa9, 0xb8, 0x9f, 0x38, 0xa7, 0x37, 0x05, 0xc3, 0xc7,
+	0x34, 0xc9, 0x3f, 0x8d, 0x13, 0xe6, 0x9d, 0x47, 0xe1, 0xcb, 0xb3, 0xa9, 0xff, 0xa6, 0x16, 0xae,
+	0x00, 0x64, 0x37, 0x4e, 0x18, 0x8c, 0x95, 0xcd, 0x71, 0x9f, 0x39, 0xae, 0x7e, 0x9b, 0x68, 0xc8,
+	0xfa, 0x13, 0xed, 0x14, 0x2e, 0xa0, 0x92, 0x3f, 0x9b, 0xfa, 0x97, 0xed, 0xa1, 0xd1, 0x20, 0xdd,
+	0xb9, 0x06, 0xaa, 0xfb, 0xdb, 0xce, 0x85, 0x5f, 0xe7, 0x7c, 0x90, 0xb0, 0xcd, 0x84, 0x4f, 0xfa,
+	0xdb, 0x82, 0xff, 0x98, 0x45, 0xf2, 0x33, 0x3a, 0x66, 0x5e, 0x1f, 0x45, 0xdf, 0x9e, 0x4d, 0xfd,
+	0x35, 0x25, 0x3a, 0x40, 0x1c, 0x89, 0x00, 0x48, 0x32, 0x85, 0x24, 0x29, 0x1d, 0xb3, 0x20, 0x5c,
+	0xa0, 0xe1, 0xee, 0x3a, 0x17, 0x8d, 0x96, 0xae, 0xe4, 0x82, 0x0e, 0xd8, 0x63, 0xa6, 0x36, 0x0c,
+	0x43, 0x03, 0x37, 0x66, 0x53, 0xff, 0xed, 0x06, 0x03, 0xb9, 0x02, 0xa3, 0xeb, 0xd6, 0x3b, 0x66,
+	0xa1, 0x94, 0x7b, 0xdf, 0x39, 0xdf, 0xd8, 0xe8, 0xed, 0x82, 0x8d, 0xb0, 0xb9, 0x11, 0x7c, 0xed,
+	0x7c, 0xc3, 0xc6, 0x24, 0x1a, 0x31, 0x35, 0x02, 0x83, 0xba, 0xaf, 0x6d, 0xec, 0x60, 0x0f, 0x09,
+	0x7a, 0x20, 0x0e, 0x14, 0x74, 0x27, 0xce, 0xd5, 0xf9, 0xf6, 0xee, 0xa4, 0xb7, 0x15, 0x0b, 0xdc,
+	0xb4, 0xfb, 0xde, 0x10, 0x4d, 0xde, 0x9a, 0x4d, 0xfd, 0xf7, 0x0f, 0x30, 0x99, 0x4f, 0x7a, 0xa4,
+	0x5f, 0x70, 0x82, 0x70, 0x89, 0xa8, 0xfb, 0x23, 0xe7, 0x82, 0x5e, 0x96, 0xa9, 0x64, 0x62, 0x97,
+	0x89, 0xd2, 0x07, 0xbc, 0x89, 0xe6, 0xae, 0xcf, 0xa6, 0xbe, 0x6f, 0xaf, 0x6d, 0x03, 0xa8, 0x47,
+	0x7f, 0x81, 0x84, 0x9b, 0x3a, 0x57, 0xe6, 0xdc, 0x83, 0xe9, 0x16, 0x3d, 0x34, 0x71, 0x73, 0x36,
+	0xf5, 0xdf, 0x5d, 0xe8, 0x66, 0x6c, 0xcf, 0x78, 0xa0, 0x1e, 0x2c, 0x58, 0x7d, 0x76, 0x33, 0x2a,
+	0x52, 0x26, 0x42, 0x46, 0xfb, 0xca, 0xf9, 0x5c, 0xac, 0x2f, 0x58, 0x6d, 0x29, 0x51, 0x40, 0x22,
+	0x00, 0x69, 0xbf, 0x4d, 0x5d, 0xc3, 0xfd, 0xdc, 0x39, 0xa7, 0x5a, 0x9e, 0x65, 0x2c, 0xd5, 0x71,
+	0xeb, 0x56, 0x2c, 0xbc, 0x4b, 0xa8, 0x7d, 0x6d, 0x36, 0xf5, 0xdf, 0xb2, 0xb4, 0x79, 0xc6, 0xd2,
+	0x22, 0x0c, 0xee, 0xc7, 0x22, 0x08, 0x1b, 0xe9, 0x46, 0x44, 0x1f, 0xbf, 0x62, 0x0f, 0xe3, 0x5c,
+	0xf2, 0x81, 0xa0, 0x63, 0xec, 0xf5, 0xe5, 0x45, 0x11, 0x7d, 0xfc, 0x8a, 0x91, 0x61, 0x01, 0xad,
+	0x45, 0xf4, 0x75, 0x95, 0xca, 0x2f, 0x7c, 0x4a, 0xe3, 0x84, 0xef, 0xe9, 0xc8, 0xe8, 0xca, 0x02,
+	0xbf, 0xb0, 0xab, 0x41, 0xb6, 0x5f, 0x30, 0xa9, 0x46, 0x8f, 0xb3, 0x78, 0xc4, 0x42, 0x16, 0x41,
+	0x8b, 0x9a, 0xd1, 0xb7, 0x16, 0xf5, 0x18, 0x90, 0x44, 0x68, 0x68, 0xad, 0xc7, 0x75, 0x95, 0x6a,
+	0x1e, 0x77, 0x9e, 0x74, 0x1f, 0xd2, 0xb4, 0x9f, 0x0f, 0xe9, 0x48, 0x2d, 0xca, 0xab, 0x0b, 0xe6,
+	0x51, 0x26, 0x39, 0x19, 0x16, 0x48, 0x7b, 0x1e, 0xeb, 0x1a, 0xee, 0x6f, 0x15, 0xa7, 0x9e, 0xf6,
+	0xf7, 0x0f, 0x07, 0x42, 0x0d, 0xb7, 0xbf, 0x60, 0xc5, 0x17, 0xc7, 0xc7, 0x70, 0x20, 0xc6, 0xf6,
+	0xb1, 0x57, 0x53, 0xa8, 0x82, 0x80, 0xa7, 0x0c, 0x02, 0xc6, 0x0d, 0xc1, 0xe8, 0xa8, 0xcf, 0x5f,
+	0xa8, 0x43, 0x6a, 0x6d, 0x41, 0x10, 0x30, 0x46, 0x2c, 0xe9, 0x15, 0x60, 0x3b, 0x08, 0x68, 0x50,
+	0x72, 0x9f, 0x17, 0x2b, 0x71, 0x87, 0x89, 0xf1, 0xe6, 0x90, 0xa6, 0x03, 0x35, 0x3a, 0xd7, 0x16,
+	0x1c, 0xdb, 0x92, 0x89, 0x31, 0x9c, 0xb3, 0xe9, 0xa0, 0x18, 0x9b, 0x46, 0x7e, 0x35, 0xb1, 0x21,
+	0xcb, 0xf9, 0x44, 0xe8, 0x10, 0x14, 0xa5, 0x83, 0x05, 0x13, 0x2b, 0x34, 0x52, 0x47, 0xb4, 0xd6,
+	0xc4, 0xce, 0xa9, 0x54, 0x43, 0xff, 0x25, 0x4f, 0x99, 0x1e, 0x3c, 0x94, 0xbf, 0xbe, 0x60, 0xe8,
+	0x5f, 0xf1, 0x94, 0x95, 0xe3, 0x6f, 0x0d, 0x7d, 0x4d, 0xa1, 0x92, 0xee, 0x72, 0xf0, 0xa9, 0x5d,
+	0x49, 0xa5, 0xda, 0xfa, 0x6f, 0x2f, 0x90, 0xce, 0x11, 0x47, 0x72, 0x00, 0xda, 0xd2, 0x35, 0x85,
+	0x2a, 0x4c, 0x7a, 0x4a, 0xc1, 0xf9, 0xa5, 0xb4, 0x70, 0x91, 0xef, 0x2c, 0x18, 0xef, 0x71, 0x85,
+	0xb3, 0x95, 0x6b, 0x02, 0xc1, 0x57, 0x37, 0x9d, 0xeb, 0x0d, 0x35, 0x85, 0x0d, 0x96, 0x46, 0xc3,
+	0x31, 0x15, 0xa3, 0x67, 0x19, 0x44, 0x21, 0xb9, 0x7b, 0xdd, 0x39, 0xba, 0xb3, 0x9f, 0x31, 0x5d,
+	0x56, 0x38, 0x3d, 0x9b, 0xfa, 0xab, 0xca, 0xa0, 0xdc, 0xcf, 0x58, 0x10, 0x62, 0xa3, 0xfb, 0x03,
+	0xe7, 0x35, 0x1d, 0xc7, 0xab, 0x74, 0x05, 0xeb, 0x09, 0xed, 0x8d, 0x8b, 0xb3, 0xa9, 0x7f, 0x5e,
+	0xa1, 0x8b, 0x44, 0x40, 0xa5, 0x3b, 0x41, 0x68, 0xe3, 0xdd, 0x87, 0xce, 0x99, 0x4d, 0x9e, 0xa6,
+	0x2c, 0x02, 0xa3, 0x5a, 0xa3, 0x8d, 0x1a, 0x66, 0xd4, 0x56, 0x22, 0x4a, 0x99, 0x39, 0x96, 0xfb,
+	0x3d, 0xe7, 0x94, 0x7a, 0x21, 0xad, 0x72, 0x14, 0x55, 0xbc, 0xd9, 0xd4, 0x3f, 0x67, 0x0d, 0x54,
+	0xa1, 0x60, 0xa1, 0xdd, 0xdf, 0x71, 0xde, 0xac, 0x14, 0xcd, 0x96, 0xdc, 0x3b, 0xb6, 0xd6, 0xbe,
+	0xd1, 0xb6, 0xf6, 0x7f, 0xd5, 0x1d, 0x4b, 0x33, 0x87, 0x55, 0xd8, 0x2c, 0xe2, 0xc6, 0xce, 0xa5,
+	0x90, 0x4a, 0xf6, 0x24, 0x1e, 0xc7, 0x45, 0xe6, 0x93, 0x6f, 0x33, 0xd1, 0x65, 0x11, 0x4f, 0xfb,
+	0x98, 0xc8, 0xb7, 0x37, 0xde, 0x9f, 0x4d, 0xfd, 0x77, 0xf4, 0xa8, 0x51, 0xc9, 0x48, 0x02, 0xe0,
+	0x22, 0x93, 0xca, 0x21, 0x77, 0x26, 0x39, 0xe2, 0x83, 0xf0, 0x00, 0x31, 0xa8, 0xee, 0x74, 0xe9,
+	0x18, 0xc3, 0x0d, 0xc8, 0xcd, 0x57, 0xcc, 0xea, 0x4e, 0x4e, 0xc7, 0x18, 0xc2, 0x04, 0x61, 0x81,
+	0x71, 0xbf, 0xef, 0x9c, 0x7a, 0xcc, 0xf6, 0xc1, 0x85, 0x6f, 0xec, 0x4b, 0x96, 0x7b, 0x2b, 0xf5,
+	0x19, 0x84, 0x88, 0x07, 0xbd, 0x7f, 0x0f, 0xda, 0x83, 0xd0, 0x82, 0xbb, 0x9b, 0xce, 0xeb, 0xcf,
+	0x69, 0x32, 0x61, 0x95, 0xc0, 0x49, 0x14, 0x30, 0xe2, 0xc8, 0x3d, 0x68, 0xb7, 0x24, 0x6a, 0x14,
+	0x77, 0xdd, 0x39, 0xd9, 0x95, 0x34, 0x61, 0x70, 0xf0, 0x61, 0x2a, 0xbb, 0xb2, 0x71, 0x7e, 0x36,
+	0xf5, 0xcf, 0xea, 0x4e, 0x43, 0x13, 0x1e, 0x97, 0x41, 0x58, 0xe1, 0x70, 0xe9, 0xd0, 0x24, 0xee,
+	0xc1, 0x58, 0x3d, 0x84, 0x73, 0x33, 0xcf, 0x31, 0x1d, 0x5d, 0xb1, 0x96, 0x4e, 0x81, 0x20, 0x43,
+	0x05, 0x81, 0xa5, 0x53, 0x63, 0xb9, 0xdf, 0x76, 0x56, 0xb7, 0x05, 0xcb, 0x78, 0x36, 0x81, 0x6d,
+	0x8f, 0x59, 0x66, 0xdb, 0x2a, 0xa4, 0x55, 0x8d, 0x41, 0x68, 0x42, 0xdd, 0xd0, 0x79, 0xe3, 0xcb,
+	0xa2, 0xc0, 0xb8, 0x15, 0x0f, 0x58, 0x2e, 0x1f, 0x4c, 0xca, 0x14, 0x72, 0x6d, 0x36, 0xf5, 0xaf,
+	0x28, 0x85, 0xb2, 0x0a, 0x49, 0xfa, 0x88, 0x22, 0x74, 0x02, 0x5b, 0xb4, 0x89, 0xec, 0xde, 0x75,
+	0x56, 0x3e, 0x91, 0x51, 0x3f, 0xdc, 0x78, 0xb0, 0xa9, 0x33, 0xc5, 0x73, 0xb3, 0xa9, 0x7f, 0x46,
+	0x09, 0x41, 0xc5, 0x91, 0x88, 0x1e, 0x8d, 0x82, 0xb0, 0x44, 0xb9, 0x4f, 0x9c, 0xb3, 0x46, 0x1a,
+	0xad, 0xd7, 0xff, 0x69, 0x7c, 0x8b, 0xab, 0xb3, 0xa9, 0x7f, 0x49, 0x51, 0xad, 0x54, 0xbc, 0xd8,
+	0x05, 0xf3, 0x44, 0x08, 0xcf, 0x1e, 0xb2, 0xfe, 0x80, 0x3d, 0xd8, 0x95, 0x4c, 0x3c, 0x8d, 0x23,
+	0xc1, 0xd5, 0xaa, 0xcb, 0x31, 0xe7, 0x6b, 0x9b, 0x6e, 0x6d, 0x08, 0x38, 0x42, 0x01, 0x48, 0xc6,
+	0x06, 0x32, 0x08, 0x17, 0x48, 0xb8, 0x7f, 0xd6, 0x72, 0xd6, 0x1a, 0xbc, 0xcf, 0x43, 0x46, 0x13,
+	0x39, 0x0c, 0xf9, 0x44, 0xc6, 0xe9, 0x00, 0x53, 0xc1, 0xd5, 0xce, 0x87, 0xb7, 0xab, 0xca, 0xe8,
+	0xed, 0x65, 0x1c, 0x73, 0xc1, 0x0e, 0xb1, 0x81, 0x08, 0xd5, 0x02, 0xf5, 0xae, 0x25, 0xe4, 0x62,
+	0x0f, 0x40, 0x05, 0x04, 0x16, 0xa5, 0xe7, 0x36, 0xee, 0x81, 0x0c, 0xc7, 0x2f, 0x7e, 0xc5, 0xf4,
+	0x1e, 0x28, 0xe0, 0xee, 0x86, 0xf3, 0x3a, 0x46, 0xfe, 0x42, 0xc6, 0xb0, 0xf3, 0x59, 0x1f, 0x93,
+	0xc3, 0x95, 0x8d, 0x4b, 0xb3, 0xa9, 0x7f, 0xa1, 0x12, 0xc8, 0x2a, 0x40, 0x10, 0xd6, 0x18, 0x6e,
+	0xc7, 0x39, 0x09, 0x31, 0x39, 0x1a, 0xf1, 0xce, 0xd5, 0xa7, 0x3d, 0x2d, 0x9a, 0x82, 0xb0, 0x82,
+	0x41, 0xb7, 0x77, 0x5e, 0xa6, 0x65, 0xad, 0xc8, 0x3b, 0x5f, 0xef, 0xb6, 0x7c, 0x99, 0x1a, 0xb5,
+	0xa6, 0x20, 0xb4, 0xe0, 0xb8, 0x6c, 0x5e, 0xa6, 0xcf, 0xf6, 0x98, 0x48, 0x68, 0xa6, 0xcb, 0x6d,
+	0xde, 0x85, 0xb9, 0x65, 0xf3, 0x32, 0x25, 0x5c, 0x61, 0x8a, 0xf2, 0x5d, 0x10, 0xce, 0x13, 0x21,
+	0xa3, 0x7c, 0xca, 0x68, 0x3e, 0x11, 0x65, 0x5c, 0x85, 0xe1, 0xfc, 0x8a, 0xe9, 0x09, 0xc6, 0x0a,
+	0x50, 0x06, 0x65, 0x41, 0x58, 0xe7, 0xb8, 0x3f, 0x6f, 0x39, 0xd7, 0x1a, 0xe6, 0xcb, 0xae, 0x7e,
+	0x60, 0x14, 0xbf, 0xda, 0xb9, 0xb5, 0x64, 0x85, 0xd8, 0x24, 0x73, 0x3a, 0x6a, 0x95, 0x96, 0x20,
+	0x5c, 0x6e, 0x13, 0xf6, 0x25, 0x84, 0xd1, 0x4f, 0x38, 0xcf, 0x30, 0xb6, 0x5f, 0x31, 0x27, 0x08,
+	0x02, 0x6f, 0x92, 0x70, 0x9e, 0x05, 0x61, 0x89, 0x82, 0x4a, 0xc2, 0x95, 0x06, 0xdd, 0xa2, 0xc6,
+	0x92, 0x7b, 0x97, 0xd6, 0xda, 0x37, 0x56, 0x3b, 0xef, 0x2d, 0x79, 0x8d, 0x02, 0x6f, 0xda, 0x2b,
+	0xaa, 0x38, 0x39, 0xe4, 0x27, 0x07, 0x98, 0x70, 0xff, 0xa2, 0xd5, 0x78, 0xdc, 0x9b, 0xc5, 0x13,
+	0xc1, 0x7b, 0x0c, 0xe3, 0xfe, 0xd5, 0xce, 0x9d, 0x25, 0x5d, 0xa9, 0xd3, 0x6a, 0xa7, 0x74, 0x55,
+	0xa8, 0x81, 0x46, 0x28, 0xbb, 0x2f, 0x97, 0x70, 0xdf, 0x75, 0x8e, 0x61, 0xf1, 0x45, 0xa7, 0x07,
+	0x67, 0x66, 0x53, 0xff, 0x94, 0x56, 0x84, 0xc7, 0x41, 0xa8, 0x9a, 0xe1, 0x90, 0xc0, 0x3f, 0xb0,
+	0x58, 0xa1, 0x82, 0x7e, 0xe3, 0x90, 0x40, 0xac, 0x2e, 0x53, 0x54, 0x38, 0xf7, 0x4f, 0x5a, 0xce,
+	0xd5, 0x86, 0x4e, 0x80, 0xeb, 0xd4, 0xf9, 0x10, 0xc6, 0xf7, 0xab, 0x9d, 0x9b, 0x4b, 0xde, 0xdc,
+	0x60, 0x6c, 0xbc, 0x39, 0x9b, 0xfa, 0x6f, 0x18, 0xfe, 0x58, 0x67, 0x5c, 0x41, 0xb8, 0xc4, 0xd4,
+	0x22, 0xef, 0x67, 0x95, 0x67, 0x3c, 0xff, 0x50, 0xde, 0xcf, 0xe2, 0x98, 0x7b, 0xde, 0xae, 0x03,
+	0x35, 0x7b, 0x3f, 0x8b, 0xec, 0xde, 0x76, 0x56, 0x37, 0xf1, 0x12, 0x6c, 0x87, 0x8f, 0x58, 0xaa,
+	0x73, 0x86, 0x53, 0xb3, 0xa9, 0xbf, 0xa2, 0x14, 0x6f, 0x05, 0xa1, 0x09, 0x70, 0xef, 0x3a, 0xa7,
+	0xe0, 0xa5, 0x3e, 0xcf, 0x99, 0x00, 0xbf, 0xe4, 0x5d, 0x6b, 0x20, 0x58, 0x88, 0x82, 0xb1, 0x4d,
+	0xf3, 0xfc, 0x05, 0x17, 0x7d, 0x2f, 0x58, 0xc4, 0x28, 0x10, 0xee, 0xc0, 0xb9, 0x54, 0x14, 0x88,
+	0xe3, 0x31, 0xe3, 0x13, 0xf9, 0x34, 0x4e, 0x92, 0xb8, 0x38, 0x88, 0xae, 0xa3, 0x93, 0x32, 0xd2,
+	0x9a, 0xb2, 0xdc, 0xac, 0xc0, 0x64, 0x6c, 0xa0, 0x21, 0x5a, 0x5a, 0x28, 0xe5, 0xfe, 0x86, 0xf3,
+	0x86, 0x76, 0x41, 0x66, 0x29, 0x01, 0x23, 0xf8, 0x15, 0x33, 0x55, 0x2d, 0x5c, 0x97, 0x59, 0x8a,
+	0x08, 0xc2, 0x26, 0xae, 0xfb, 0xa7, 0x2d, 0xc7, 0x6f, 0x18, 0x74, 0x33, 0xb9, 0xc7, 0x30, 0x7e,
+	0xb5, 0xf3, 0xc1, 0x92, 0x49, 0x36, 0x29, 0x66, 0x28, 0x6b, 0x95, 0x10, 0x82, 0x70, 0x99, 0x35,
+	0x77, 0xe4, 0x5c, 0x86, 0x77, 0xef, 0xe2, 0xf5, 0xd2, 0x16, 0x7f, 0x91, 0xaa, 0x28, 0xa0, 0xab,
+	0x87, 0xf3, 0xdd, 0x7a, 0xf8, 0x89, 0x05, 0x6e, 0x7d, 0x6b, 0xd5, 0x2f, 0xe1, 0xa4, 0x1c, 0xd0,
+	0x83, 0xd4, 0xdc, 0x97, 0x8e, 0x5f, 0x35, 0x7f, 0x3a, 0x49, 0x12, 0xc8, 0xc9, 0x12, 0x75, 0x8d,
+	0xa2, 0x0d, 0xbe, 0x87, 0x06, 0x6f, 0xcf, 0xa6, 0xfe, 0xcd, 0x79, 0x83, 0xbb, 0x93, 0x24, 0x21,
+	0xa2, 0xe4, 0x54, 0x56, 0x97, 0xc9, 0xba, 0x7f, 0xe0, 0x5c, 0x6e, 0x18, 0x89, 0xa2, 0x8e, 0xe0,
+	0xdd, 0x58, 0x6b, 0x1d, 0xc2, 0xdb, 0x16, 0x70, 0x33, 0x6c, 0x2e, 0x0a, 0x14, 0x41, 0x78, 0x90,
+	0x01, 0xc8, 0x86, 0x30, 0xb0, 0xdd, 0x61, 0xe3, 0x0c, 0x23, 0xc9, 0xf7, 0x71, 0x9d, 0x1b, 0x9b,
+	0x53, 0x85, 0xc2, 0x52, 0xb7, 0x07, 0xa1, 0x8d, 0x07, 0x17, 0x87, 0x0f, 0xba, 0x8c, 0xf5, 0xbd,
+	0x9b, 0x38, 0x48, 0x86, 0x8b, 0x53, 0xe4, 0x9c, 0x41, 0xf8, 0x50, 0xe1, 0x16, 0x39, 0x15, 0xab,
+	0xc4, 0xe1, 0x7d, 0x70, 0x28, 0xa7, 0x62, 0x71, 0xcc, 0x7e, 0xdb, 0xb5, 0x94, 0x66, 0xa7, 0x62,
+	0x91, 0xdd, 0xef, 0x38, 0xab, 0xb0, 0xf6, 0x8a, 0xb0, 0xe2, 0x43, 0x7c, 0x19, 0xc3, 0x71, 0xc2,
+	0xd2, 0xad, 0xe2, 0x09, 0x13, 0x0b, 0x91, 0xc4, 0x63, 0x66, 0x5d, 0xbf, 0x79, 0xb7, 0xea, 0xb5,
+	0xe9, 0x11, 0xb3, 0x6f, 0xf2, 0x82, 0xb0, 0xce, 0x81, 0xcc, 0xc4, 0x50, 0xfd, 0x24, 0xed, 0x7b,
+	0xb7, 0xeb, 0x99, 0x89, 0xd9, 0x09, 0xb8, 0xb6, 0x08, 0xc2, 0x1a, 0x05, 0x6e, 0x42, 0x9b, 0x76,
+	0x97, 0x59, 0xe0, 0xf1, 0xee, 0xcc, 0x8f, 0xed, 0xcd, 0x25, 0x1c, 0x73, 0x33, 0x5b, 0x75, 0xa4,
+	0xe6, 0xcd, 0x6c, 0x52, 0x61, 0x78, 0xb6, 0x26, 0x82, 0x9a, 0xfb, 0xe9, 0x6e, 0xfd, 0xc5, 0xfa,
+	0x1a, 0x50, 0x6d, 0x9e, 0x3a, 0xc7, 0xfd, 0xa1, 0xf3, 0x5a, 0x48, 0xc7, 0xd9, 0xe7, 0x59, 0x21,
+	0x72, 0x0f, 0x45, 0xcc, 0x20, 0x89, 0x8e, 0x33, 0x32, 0xc9, 0x2a, 0x0d, 0x9b, 0x00, 0x17, 0x2e,
+	0xe0, 0xb3, 0x1f, 0x0d, 0x52, 0x2e, 0x18, 0xae, 0x47, 0xaf, 0x53, 0xcf, 0xbf, 0xf0, 0x7c, 0x8c,
+	0x11, 0x41, 0x70, 0xfd, 0x06, 0x61, 0x9d, 0x64, 0xeb, 0xa8, 0x33, 0x70, 0xfd, 0x20, 0x1d, 0x7d,
+	0xb0, 0xd5, 0x49, 0x30, 0xe1, 0xf0, 0xe8, 0xc1, 0xf6, 0xa3, 0xe7, 0x4c, 0xe4, 0xb0, 0x6c, 0xee,
+	0xd7, 0x97, 0x0d, 0xca, 0xd0, 0x2c, 0x26, 0x7b, 0x0a, 0x11, 0x84, 0x35, 0x8a, 0xfb, 0xe7, 0x70,
+	0xfb, 0xd3, 0x10, 0x0b, 0xea, 0xba, 0xd2, 0x53, 0x9e, 0xc6, 0x92, 0x0b, 0xef, 0x23, 0x9c, 0xf3,
+	0xdb, 0xcb, 0x02, 0x50, 0x9b, 0x65, 0x2f, 0x3d, 0xd5, 0x44, 0xc6, 0xaa, 0x0d, 0xee, 0x85, 0x96,
+	0x0a, 0xc0, 0xa4, 0x3d, 0xe1, 0xd1, 0xa8, 0x0a, 0xf9, 0x3f, 0xae, 0x4f, 0x5a, 0xc2, 0xa3, 0x91,
+	0x15, 0xf3, 0xdb, 0x04, 0xa8, 0x28, 0xc3, 0x83, 0x87, 0x3c, 0xe9, 0x5b, 0x47, 0xea, 0xff, 0x43,
+	0x21, 0xa3, 0xa2, 0x8c, 0x42, 0x43, 0x9e, 0xf4, 0x6b, 0x87, 0x69, 0x23, 0x1d, 0xea, 0x55, 0xf0,
+	0xfc, 0x51, 0xba, 0x47, 0x93, 0xb8, 0x4f, 0x25, 0x2b, 0x36, 0xfe, 0xb7, 0x51, 0xd7, 0xa8, 0x57,
+	0xa1, 0x6e, 0x5c, 0xe2, 0x2a, 0x1f, 0xd0, 0x2c, 0x00, 0x67, 0x97, 0x0a, 0x3e, 0xa0, 0x79, 0x8b,
+	0x25, 0x74, 0xdf, 0xea, 0xf7, 0x77, 0xea, 0x67, 0x97, 0xfa, 0x9e, 0x87, 0xa0, 0x99, 0x3e, 0xc0,
+	0x6b, 0xfd, 0x3f, 0x48, 0x0d, 0x52, 0xa2, 0x0d, 0x9a, 0x8e, 0x1e, 0x44, 0x11, 0x9f, 0x94, 0x95,
+	0xa4, 0xef, 0xd6, 0x53, 0xa2, 0x1e, 0x4d, 0x47, 0x84, 0x2a, 0x4c, 0x95, 0x49, 0xcf, 0x11, 0xa1,
+	0x0a, 0x0e, 0x0f, 0xf5, 0xe7, 0x3a, 0x1b, 0x34, 0xa1, 0x10, 0x5a, 0xfc, 0x7f, 0x94, 0x33, 0x42,
+	0x0b, 0x94, 0x8b, 0x15, 0x88, 0xf4, 0x14, 0x2a, 0x08, 0x1b, 0xa8, 0x50, 0x6e, 0xf8, 0x82, 0x8a,
+	0xf1, 0x24, 0xb3, 0x8b, 0x6e, 0xdf, 0x43, 0x45, 0xa3, 0xdc, 0xf0, 0x02, 0x41, 0xa4, 0x5e, 0x7b,
+	0x6b, 0x22, 0xc3, 0xa1, 0xa5, 0x1e, 0x17, 0x7e, 0xe0, 0xfb, 0xf5, 0x2c, 0x52, 0xab, 0x55, 0x6e,
+	0xc0, 0xc2, 0xc3, 0x5b, 0x3e, 0x98, 0x48, 0xbe, 0xc9, 0xc7, 0x19, 0x8d, 0x64, 0xa1, 0xf2, 0x6b,
+	0xf5, 0xb7, 0xa4, 0x13, 0xc9, 0x49, 0xa4, 0x40, 0x95, 0x56, 0x03, 0x15, 0x3e, 0x6b, 0x82, 0xa7,
+	0x5b, 0x6c, 0x57, 0xd0, 0x81, 0xf7, 0x03, 0x74, 0x05, 0x46, 0x35, 0x06, 0x85, 0xfa, 0xd8, 0x18,
+	0x84, 0x06, 0x12, 0x12, 0xb4, 0xc7, 0x6c, 0xff, 0x99, 0xe8, 0x33, 0xe1, 0xfd, 0xb0, 0x9e, 0x41,
+	0xc3, 0x96, 0xe0, 0xd0, 0x14, 0x84, 0x25, 0x2a, 0xf8, 0x72, 0x79, 0x38, 0x0e, 0xbd, 0xd9, 0xd9,
+	0x79, 0x52, 0xbc, 0x56, 0xab, 0x5e, 0x1b, 0x92, 0x32, 0xa9, 0xde, 0xc6, 0x40, 0x06, 0xaf, 0x96,
+	0x25, 0x1e, 0xb0, 0x67, 0xba, 0x91, 0xa0, 0x99, 0x8a, 0x1e, 0xf7, 0x68, 0x62, 0x1b, 0x31, 0xf6,
+	0x4c, 0x8e, 0x30, 0x15, 0x7b, 0xee, 0x51, 0xc3, 0x60, 0xb3, 0x40, 0xf0, 0x93, 0x23, 0x87, 0x4a,
+	0xfa, 0xe0, 0x28, 0x69, 0xb6, 0x6d, 0x38, 0xaa, 0x79, 0xa3, 0x75, 0x0e, 0xd4, 0x3f, 0x74, 0x68,
+	0x5d, 0xa8, 0x1c, 0xa9, 0xbb, 0xa5, 0x22, 0x30, 0x2f, 0x45, 0x6a, 0x0c, 0x58, 0x45, 0x5f, 0x88,
+	0x58, 0xb2, 0xe2, 0x43, 0x81, 0x47, 0x69, 0x9f, 0xbd, 0xf4, 0xda, 0xf5, 0x55, 0xf4, 0x02, 0x30,
+	0xd5, 0xf7, 0x1e, 0x31, 0xa0, 0x82, 0xb0, 0x81, 0x1a, 0xfc, 0xbc, 0xed, 0x5c, 0x3e, 0x20, 0x33,
+	0x86, 0xfa, 0x36, 0xde, 0xaa, 0xce, 0xd5, 0xb7, 0xd5, 0xcd, 0x29, 0x36, 0x96, 0x45, 0xf0, 0x23,
+	0x07, 0x15, 0xc1, 0x3f, 0x74, 0x4e, 0x14, 0xde, 0x4e, 0xf5, 0xd7, 0x9d, 0x4d, 0xfd, 0xd7, 0x15,
+	0xae, 0xf4, 0x6e, 0x05, 0x64, 0x49, 0x25, 0xf8, 0xe8, 0xaf, 0xb2, 0x12, 0x7c, 0xc7, 0x59, 0xd9,
+	0x16, 0x31, 0x17, 0xb1, 0xdc, 0xd7, 0x9f, 0xac, 0x19, 0x31, 0x6d, 0xa6, 0x5b, 0x82, 0xb0, 0x04,
+	0x41, 0xfc, 0x09, 0x72, 0xdd, 0x21, 0x15, 0xcc, 0x3b, 0x5e, 0x8f, 0x3f, 0xb1, 0x2b, 0x39, 0xb4,
+	0x05, 0x61, 0x85, 0x03, 0x2b, 0x5f, 0x50, 0x19, 0x0d, 0xa1, 0x56, 0x7e, 0x02, 0x39, 0x86, 0x95,
+	0x17, 0xba, 0x25, 0x08, 0x4b, 0x50, 0xf0, 0x8f, 0x87, 0x29, 0xf1, 0x40, 0x00, 0xd9, 0x85, 0x3f,
+	0xf4, 0xc0, 0xb4, 0xea, 0x01, 0x24, 0xa2, 0xca, 0x61, 0x30, 0xb1, 0x40, 0x85, 0xb4, 0xc4, 0x5e,
+	0x8c, 0x06, 0x15, 0x6f, 0xbc, 0xca, 0x95, 0x68, 0x62, 0xe1, 0x16, 0x61, 0x9b, 0x4e, 0xf2, 0x32,
+	0x35, 0x6a, 0xd7, 0x6f, 0x11, 0x32, 0x68, 0xad, 0xc8, 0x16, 0x3a, 0xf8, 0xe7, 0xf6, 0xf2, 0xea,
+	0x26, 0xec, 0x96, 0x4f, 0x84, 0xe0, 0x62, 0x67, 0x28, 0x58, 0x0e, 0x07, 0xac, 0xd7, 0xaa, 0xef,
+	0x16, 0x06, 0xed, 0x44, 0x16, 0x00, 0x88, 0x52, 0x2c, 0x86, 0xdb, 0x77, 0x2e, 0xe2, 0x0e, 0x2e,
+	0x76, 0xa2, 0x75, 0x24, 0xaa, 0xf7, 0x35, 0x3e, 0x2f, 0xc2, 0x6a, 0x4c, 0xe5, 0x3d, 0xec, 0xf3,
+	0x70, 0xb1, 0x10, 0x38, 0xa8, 0x8d, 0x84, 0x46, 0x23, 0x3e, 0x91, 0x4d, 0xdb, 0xd2, 0x70, 0x50,
+	0x3d, 0x0d, 0x9b, 0xdb, 0x99, 0xcd, 0x02, 0x70, 0x90, 0x15, 0x0d, 0xe6, 0x24, 0x1f, 0xad, 0x1f,
+	0x64, 0xa5, 0xae, 0x3d, 0xdb, 0x4d, 0x64, 0xb8, 0xc2, 0x29, 0x1e, 0xd7, 0xe3, 0xe3, 0x63, 0x6b,
+	0x2d, 0xfb, 0x0a, 0xa7, 0xd4, 0x9d, 0x0f, 0x94, 0x17, 0x89, 0x04, 0xd3, 0x23, 0xce, 0xb5, 0x83,
+	0x2e, 0xce, 0xba, 0x92, 0x65, 0xe8, 0xc7, 0xe0, 0x8f, 0x7b, 0xd8, 0xb3, 0x2d, 0x2a, 0x69, 0x0f,
+	0xe2, 0xd9, 0x56, 0xbd, 0x9c, 0x90, 0x03, 0x46, 0xbf, 0x55, 0x5f, 0xa3, 0x82, 0xb0, 0x81, 0x0a,
+	0x43, 0x05, 0x4f, 0x3b, 0x5d, 0x29, 0x58, 0x9e, 0x97, 0x8a, 0x47, 0x50, 0xd1, 0x18, 0x2a, 0x50,
+	0xec, 0x90, 0x1c, 0x51, 0x86, 0x64, 0x13, 0x19, 0xc2, 0x1c, 0x78, 0xbc, 0xde, 0x95, 0x3c, 0x2b,
+	0x15, 0xdb, 0xa8, 0x68, 0x84, 0x39, 0xa0, 0xb8, 0x4e, 0x72, 0xc9, 0x33, 0x43, 0x6f, 0x9e, 0x08,
+	0xf1, 0x3b, 0x3c, 0xbc, 0xff, 0x79, 0x06, 0x8e, 0xf5, 0x09, 0x1f, 0xe4, 0xde, 0xd1, 0x7a, 0xfc,
+	0x0e, 0x5a, 0xf7, 0xc9, 0x04, 0x11, 0x24, 0xe1, 0x03, 0x38, 0x46, 0x6a, 0xa4, 0xe0, 0x8f, 0xce,
+	0x34, 0xe6, 0x5a, 0x0f, 0x06, 0xea, 0xeb, 0x0b, 0x29, 0x38, 0x7e, 0xf2, 0x5c, 0xd8, 0x7d, 0xb4,
+	0x35, 0xff, 0xc9, 0x73, 0xd1, 0x4f, 0x12, 0xf7, 0x83, 0xd0, 0x40, 0x42, 0x99, 0xa7, 0xf8, 0x6f,
+	0x8b, 0xe5, 0x91, 0x88, 0xf1, 0x96, 0x53, 0xfb, 0x75, 0x63, 0x5e, 0x4a, 0x81, 0x7e, 0x85, 0x0a,
+	0xc2, 0x26, 0x2e, 0x7a, 0x19, 0xfd, 0x78, 0x87, 0x0e, 0xf4, 0xa7, 0xd0, 0xa6, 0x97, 0x29, 0xa4,
+	0x24, 0x04, 0x2a, 0x26, 0x16, 0xae, 0xe8, 0xb6, 0x19, 0x13, 0x8f, 0xb6, 0x61, 0xa4, 0xda, 0x35,
+	0xbf, 0xcc, 0x98, 0x20, 0x71, 0x96, 0x07, 0x61, 0x81, 0x81, 0xa8, 0x5f, 0xff, 0xd9, 0x95, 0x02,
+	0x2e, 0x48, 0x94, 0x33, 0x37, 0x1c, 0x46, 0x41, 0x82, 0xf9, 0xc7, 0x3b, 0x0f, 0x9b, 0xe0, 0x6e,
+	0x3b, 0x2e, 0x0e, 0xe3, 0x36, 0x17, 0x72, 0x87, 0xeb, 0x4b, 0x4a, 0xed, 0xe1, 0x8d, 0x35, 0x44,
+	0x01, 0x43, 0x32, 0x2e, 0x24, 0xc1, 0x60, 0x0d, 0x61, 0x10, 0xa4, 0xcd, 0x71, 0xc1, 0x8b, 0xe1,
+	0xd3, 0x62, 0x5f, 0x83, 0xef, 0x6f, 0xdb, 0x9d, 0x52, 0x6a, 0x85, 0x47, 0x80, 0x33, 0xdf, 0x66,
+	0xc0, 0xfd, 0x79, 0x31, 0x2a, 0x76, 0xc7, 0x56, 0xea, 0x17, 0x4d, 0xe5, 0x58, 0xce, 0xf5, 0xad,
+	0x59, 0x01, 0xbe, 0x59, 0x2c, 0x1a, 0xaa, 0x1e, 0x9e, 0x5c, 0x6b, 0xdb, 0xdf, 0x2c, 0x96, 0xb2,
+	0x46, 0x27, 0xe7, 0x79, 0x2e, 0x71, 0xce, 0xe2, 0x97, 0xf9, 0xf8, 0x43, 0x03, 0x42, 0xb8, 0x1c,
+	0x32, 0x81, 0xdf, 0xa3, 0xad, 0x76, 0xde, 0x32, 0x33, 0xc0, 0x39, 0x90, 0xb9, 0x34, 0x8d, 0xc7,
+	0x41, 0xf8, 0x1a, 0x40, 0x21, 0x16, 0x7c, 0x06, 0xff, 0xbb, 0x5f, 0x38, 0xa7, 0x4d, 0xae, 0x8c,
+	0x33, 0xfc, 0x1a, 0x6d, 0xb5, 0x73, 0x79, 0x91, 0xbc, 0x8c, 0xb3, 0xb9, 0x6b, 0x41, 0x78, 0x18,
+	0x84, 0xab, 0x85, 0xf4, 0x4e, 0x9c, 0xb9, 0x5f, 0x3a, 0x67, 0x4c, 0xd6, 0xde, 0x3a, 0xe9, 0xe0,
+	0x37, 0x68, 0xab, 0x9d, 0x2b, 0x8b, 0x94, 0x01, 0x63, 0x9e, 0xfa, 0xd5, 0x53, 0x43, 0xfb, 0xf9,
+	0x7a, 0xa7, 0x41, 0x7b, 0xdd, 0x1b, 0x2c, 0xd5, 0x5e, 0x6f, 0xd4, 0x5e, 0xb7, 0xb4, 0xd7, 0xdd,
+	0xaf, 0x5a, 0xce, 0x15, 0x45, 0xac, 0x6e, 0x4e, 0x89, 0x58, 0x27, 0x1f, 0x91, 0x75, 0xd2, 0x63,
+	0x92, 0x7a, 0x5f, 0xb7, 0xd0, 0xd2, 0x8d, 0x79, 0x4b, 0xcd, 0x04, 0x33, 0xb3, 0x6d, 0x46, 0x04,
+	0xe1, 0x79, 0x10, 0x28, 0x6f, 0x64, 0xc3, 0xf5, 0x8f, 0xd6, 0x37, 0x98, 0xa4, 0xee, 0x8f, 0x9d,
+	0x73, 0x4a, 0x59, 0x67, 0x96, 0x64, 0xef, 0x1e, 0xb9, 0x4b, 0x3a, 0xde, 0xdf, 0x1c, 0xc1, 0x2e,
+	0xac, 0xcd, 0x77, 0xc1, 0x06, 0x9a, 0xa9, 0x94, 0xdd, 0x12, 0x84, 0xaf, 0x03, 0x41, 0x25, 0xa4,
+	0xcf, 0xef, 0xdd, 0xed, 0xb8, 0xbf, 0x5b, 0xac, 0xb4, 0x48, 0x0d, 0x0d, 0xbe, 0xeb, 0xcf, 0xda,
+	0x8b, 0x96, 0x9a, 0x81, 0x32, 0x97, 0x9a, 0xf1, 0x58, 0x2f, 0xb5, 0x4d, 0x78, 0x82, 0x6f, 0x53,
+	0x5a, 0x78, 0x65, 0x58, 0xf8, 0xaf, 0x85, 0x16, 0x5e, 0x35, 0x5b, 0x78, 0x35, 0x67, 0xe1, 0xcb,
+	0xd2, 0x42, 0xb9, 0x5b, 0xf0, 0x57, 0x2e, 0x84, 0xec, 0xdd, 0x27, 0x77, 0xbd, 0x7f, 0x3a, 0xba,
+	0xc8, 0x82, 0x81, 0x32, 0x2d, 0x18, 0x8f, 0x83, 0xf0, 0x14, 0x40, 0x43, 0x78, 0xf2, 0xfc, 0xfe,
+	0x5d, 0xf7, 0x47, 0xc5, 0xc2, 0x83, 0x5f, 0xca, 0x10, 0xb2, 0xd7, 0x21, 0xf7, 0xbc, 0xbf, 0x3d,
+	0xb6, 0x68, 0xe5, 0x55, 0x20, 0x73, 0xe5, 0x55, 0x4f, 0xf5, 0xca, 0xdb, 0x89, 0x47, 0x7b, 0xcf,
+	0x3b, 0xf7, 0xdc, 0x4f, 0x1d, 0x47, 0xf1, 0xe0, 0xf7, 0x3b, 0xde, 0x4f, 0x4f, 0xa0, 0xec, 0x85,
+	0x79, 0x59, 0x68, 0x36, 0x13, 0x02, 0xf8, 0x3f, 0x08, 0x57, 0xa0, 0xf1, 0x29, 0x8f, 0x46, 0xee,
+	0x5f, 0xb6, 0x0e, 0xf5, 0x99, 0x8d, 0xf7, 0x1f, 0x27, 0x0e, 0x75, 0xf1, 0x56, 0xe7, 0x99, 0x67,
+	0x6b, 0xaf, 0x68, 0x23, 0x5c, 0x35, 0x36, 0x5f, 0xbc, 0xd5, 0x25, 0xdc, 0x5f, 0xb4, 0x0e, 0x11,
+	0xd0, 0x78, 0xff, 0x79, 0xe2, 0x50, 0x77, 0xad, 0x36, 0xcb, 0x3c, 0x06, 0xaa, 0xee, 0x41, 0x10,
+	0x90, 0x37, 0xdf, 0xb5, 0xda, 0xf4, 0xe0, 0xaf, 0x97, 0x5f, 0xa1, 0xc0, 0x8d, 0x79, 0xe5, 0xda,
+	0x5b, 0xe8, 0xda, 0x4d, 0x8f, 0x58, 0x79, 0xf4, 0x0a, 0xe6, 0xee, 0x38, 0xe7, 0x0e, 0x08, 0x99,
+	0x8d, 0x93, 0x70, 0x41, 0xb0, 0xdc, 0xc8, 0x0e, 0xfe, 0xe5, 0xc8, 0x81, 0x17, 0x0f, 0xee, 0xfb,
+	0xce, 0xf1, 0x1d, 0x11, 0xd3, 0xa4, 0xc8, 0xae, 0xcf, 0xce, 0xa6, 0xfe, 0x6b, 0xc5, 0x47, 0x19,
+	0xf0, 0x3c, 0x08, 0x35, 0xe0, 0xff, 0x28, 0xb0, 0x3f, 0xf8, 0x76, 0xad, 0xfd, 0xab, 0xbb, 0x5d,
+	0x9b, 0xaf, 0x0c, 0x1c, 0xfd, 0x65, 0x2b, 0x03, 0xc1, 0x5f, 0x1d, 0xe2, 0x7e, 0x03, 0xab, 0x58,
+	0xb1, 0x1c, 0xc6, 0xc5, 0xcf, 0x86, 0xf4, 0x48, 0x9b, 0x55, 0x2c, 0x6c, 0xae, 0xca, 0x8d, 0x36,
+	0x1e, 0x4a, 0x21, 0x1b, 0x34, 0x67, 0x09, 0x28, 0x5b, 0xc3, 0x6d, 0x94, 0x42, 0x7a, 0x1a, 0x60,
+	0x94, 0x42, 0x6a, 0x9c, 0xe0, 0xab, 0xf6, 0xd2, 0xfb, 0x82, 0xff, 0xd1, 0xc2, 0xbd, 0xe9, 0x1c,
+	0xdf, 0x7c, 0x80, 0x37, 0xdf, 0x2a, 0x64, 0x35, 0x4a, 0x0c, 0x11, 0xd5, 0xd7, 0xde, 0x1a, 0x01,
+	0x75, 0xb0, 0x4d, 0x26, 0x24, 0xa2, 0xdb, 0xf5, 0x3a, 0x58, 0xc4, 0x84, 0xd4, 0xf8, 0x12, 0x05,
+	0xf1, 0xe8, 0x63, 0xb6, 0x8f, 0x84, 0xa3, 0xf5, 0x3a, 0x01, 0x14, 0xce, 0x14, 0xbe, 0xc0, 0x40,
+	0x8e, 0xf3, 0x28, 0xcd, 0x59, 0x34, 0x11, 0xac, 0x3b, 0x8a, 0xb3, 0xe7, 0x4c, 0xc4, 0xbb, 0xaa,
+	0xc2, 0x60, 0xe5, 0x38, 0xb1, 0xc6, 0x90, 0x7c, 0x14, 0x67, 0x50, 0x71, 0x8f, 0x77, 0xf7, 0x83,
+	0xb0, 0x81, 0xba, 0x70, 0x5b, 0x1e, 0xff, 0x5f, 0x6d, 0xcb, 0xbf, 0x3b, 0x72, 0x98, 0x52, 0x3e,
+	0xec, 0x4e, 0x8c, 0x4b, 0x73, 0x9d, 0xa5, 0x19, 0xbb, 0x13, 0x23, 0x58, 0xd8, 0x9d, 0x0a, 0xa0,
+	0x0a, 0x2a, 0xf8, 0x95, 0x33, 0xac, 0x8e, 0x7a, 0xe0, 0xae, 0x5b, 0xb0, 0xa0, 0xa2, 0xfe, 0xc4,
+	0x74, 0x25, 0xce, 0x47, 0x5b, 0x6c, 0x2f, 0x8e, 0x8a, 0xc9, 0x30, 0xd3, 0x15, 0xf8, 0x75, 0x56,
+	0x1f, 0x1b, 0x83, 0xd0, 0x40, 0xc2, 0xb7, 0x6d, 0x9f, 0x31, 0x09, 0x1f, 0x79, 0xa8, 0x9b, 0x65,
+	0x1a, 0x15, 0x33, 0x63, 0xf8, 0xfd, 0x54, 0x21, 0xf4, 0x95, 0x34, 0x7e, 0x1c, 0x34, 0xc7, 0x6a,
+	0x2a, 0xf1, 0x1d, 0xfb, 0xe5, 0x4b, 0x7c, 0x1b, 0xe7, 0xbe, 0xfe, 0xb7, 0xab, 0xdf, 0xfa, 0xfa,
+	0x9b, 0xab, 0xad, 0xbf, 0xff, 0xe6, 0x6a, 0xeb, 0x5f, 0xbf, 0xb9, 0xda, 0xfa, 0xc5, 0xbf, 0x5f,
+	0xfd, 0x56, 0xef, 0x38, 0xfe, 0x4c, 0x75, 0xfd, 0xbf, 0x07, 0x00, 0x5b, 0xb1, 0xda, 0x90, 0xf5,
+	0x3b, 0x00, 0x00,
 }
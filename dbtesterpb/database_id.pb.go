@@ -32,6 +32,12 @@ const (
 	DatabaseID_zetcd__beta DatabaseID = 300
 	// https://github.com/coreos/cetcd/releases
 	DatabaseID_cetcd__beta DatabaseID = 400
+	// https://github.com/antirez/redis/releases
+	DatabaseID_redis__v4_0 DatabaseID = 500
+	// https://github.com/pingcap/tikv/releases
+	DatabaseID_tikv__v2_1 DatabaseID = 600
+	// in-process mock database, to test the benchmark harness
+	DatabaseID_mock DatabaseID = 900
 )
 
 var DatabaseID_name = map[int32]string{
@@ -43,6 +49,9 @@ var DatabaseID_name = map[int32]string{
 	200: "consul__v1_0_2",
 	300: "zetcd__beta",
 	400: "cetcd__beta",
+	500: "redis__v4_0",
+	600: "tikv__v2_1",
+	900: "mock",
 }
 var DatabaseID_value = map[string]int32{
 	"etcd__other":            0,
@@ -53,6 +62,9 @@ var DatabaseID_value = map[string]int32{
 	"consul__v1_0_2":         200,
 	"zetcd__beta":            300,
 	"cetcd__beta":            400,
+	"redis__v4_0":            500,
+	"tikv__v2_1":             600,
+	"mock":                   900,
 }
 
 func (x DatabaseID) String() string {
@@ -67,20 +79,22 @@ func init() {
 func init() { proto.RegisterFile("dbtesterpb/database_id.proto", fileDescriptorDatabaseId) }
 
 var fileDescriptorDatabaseId = []byte{
-	// 237 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x54, 0x8f, 0x4d, 0x4a, 0xc4, 0x40,
-	0x10, 0x85, 0xd3, 0x33, 0x20, 0x58, 0x83, 0x63, 0xd3, 0x8a, 0x8b, 0x41, 0xfa, 0x00, 0x82, 0x13,
-	0x9d, 0xc6, 0x0b, 0xc8, 0x6c, 0x3c, 0x45, 0x91, 0x4e, 0x97, 0x99, 0xe0, 0x4f, 0x85, 0x4e, 0x65,
-	0x16, 0x73, 0x0a, 0x97, 0x6e, 0xdd, 0x7b, 0x90, 0x2c, 0x3d, 0x82, 0xc6, 0x8b, 0x88, 0x1d, 0x41,
-	0xdd, 0xd5, 0xf7, 0xd5, 0xab, 0x07, 0x05, 0xa7, 0xc1, 0x0b, 0xb5, 0x42, 0xb1, 0xf1, 0x79, 0x28,
-	0xa4, 0xf0, 0x45, 0x4b, 0x58, 0x87, 0x65, 0x13, 0x59, 0xd8, 0xc0, 0xef, 0x76, 0x71, 0x5e, 0xd5,
-	0xb2, 0xe9, 0xfc, 0xb2, 0xe4, 0x87, 0xbc, 0xe2, 0x8a, 0xf3, 0x14, 0xf1, 0xdd, 0x6d, 0xa2, 0x04,
-	0x69, 0x1a, 0x4f, 0xcf, 0x5e, 0x14, 0xc0, 0xfa, 0xa7, 0xf0, 0x66, 0x6d, 0x0e, 0x61, 0x46, 0x52,
-	0x06, 0x44, 0x96, 0x0d, 0x45, 0x9d, 0x99, 0x03, 0xd8, 0x1f, 0x85, 0xd4, 0x8d, 0x56, 0x66, 0x0e,
-	0x30, 0xe2, 0xd6, 0xe1, 0x4a, 0x4f, 0xfe, 0xb1, 0xd3, 0x53, 0xb3, 0x80, 0x93, 0x1d, 0xf3, 0x1d,
-	0x51, 0x43, 0x11, 0x31, 0x3a, 0xbc, 0x42, 0x87, 0x9e, 0xa4, 0xd0, 0xc1, 0x1c, 0xc1, 0xbc, 0xe4,
-	0xc7, 0xb6, 0xbb, 0x47, 0xdc, 0x5e, 0xe2, 0x05, 0xae, 0x74, 0xaf, 0x8c, 0x86, 0xd9, 0x6e, 0x6c,
-	0x48, 0xa9, 0xd7, 0xc9, 0xb7, 0x29, 0xff, 0x98, 0xa7, 0xe9, 0xf5, 0x71, 0xff, 0x61, 0xb3, 0x7e,
-	0xb0, 0xea, 0x6d, 0xb0, 0xea, 0x7d, 0xb0, 0xea, 0xf9, 0xd3, 0x66, 0x7e, 0x2f, 0x3d, 0xe0, 0xbe,
-	0x02, 0x00, 0x00, 0xff, 0xff, 0xe8, 0xf3, 0x61, 0x28, 0x1b, 0x01, 0x00, 0x00,
+	// 270 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x54, 0x8f, 0x41, 0x4e, 0xc3, 0x30,
+	0x10, 0x45, 0xeb, 0xa6, 0x02, 0x75, 0x2a, 0x5a, 0xcb, 0x20, 0x16, 0x15, 0xca, 0x01, 0x90, 0x68,
+	0xda, 0x04, 0x2e, 0x80, 0xba, 0xe1, 0x14, 0xa3, 0x38, 0x36, 0x69, 0x14, 0x8a, 0x23, 0x67, 0xe2,
+	0x45, 0xd7, 0x1c, 0x80, 0x25, 0x87, 0xe0, 0x20, 0xd9, 0xc1, 0x92, 0x25, 0x84, 0x2b, 0x70, 0x00,
+	0x54, 0x07, 0x09, 0xd8, 0xcd, 0x7b, 0xf3, 0xe7, 0x4b, 0x03, 0x67, 0x4a, 0x92, 0xae, 0x49, 0xdb,
+	0x4a, 0x46, 0x2a, 0xa5, 0x54, 0xa6, 0xb5, 0xc6, 0x42, 0x2d, 0x2a, 0x6b, 0xc8, 0x08, 0xf8, 0xdd,
+	0xce, 0x2f, 0xf2, 0x82, 0x36, 0x8d, 0x5c, 0x64, 0x66, 0x1b, 0xe5, 0x26, 0x37, 0x91, 0x8f, 0xc8,
+	0xe6, 0xd6, 0x93, 0x07, 0x3f, 0xf5, 0xa7, 0xe7, 0x2f, 0x0c, 0x60, 0xfd, 0x53, 0x78, 0xb3, 0x16,
+	0x33, 0x98, 0x68, 0xca, 0x14, 0xa2, 0xa1, 0x8d, 0xb6, 0x7c, 0x20, 0x8e, 0x60, 0xdc, 0x0b, 0x2a,
+	0x2a, 0xce, 0xc4, 0x14, 0xa0, 0x47, 0x97, 0x60, 0xcc, 0x87, 0xff, 0x38, 0xe1, 0x81, 0x98, 0xc3,
+	0xe9, 0xce, 0x98, 0x52, 0xeb, 0x4a, 0x5b, 0x44, 0x9b, 0xe0, 0x15, 0x26, 0x28, 0x35, 0xa5, 0x5c,
+	0x89, 0x63, 0x98, 0x66, 0xe6, 0xbe, 0x6e, 0xee, 0x10, 0xdd, 0x0a, 0x97, 0x18, 0xf3, 0x96, 0x09,
+	0x0e, 0x93, 0x5d, 0xdf, 0xe0, 0x53, 0xcf, 0xc3, 0xbd, 0xc9, 0xfe, 0x98, 0xc7, 0x60, 0x6f, 0xac,
+	0x56, 0x45, 0x8d, 0xe8, 0x2e, 0x71, 0xc9, 0xbf, 0x02, 0x31, 0x03, 0xa0, 0xa2, 0x74, 0x88, 0x2e,
+	0xc6, 0x15, 0x7f, 0x1b, 0x89, 0x31, 0x8c, 0xb6, 0x26, 0x2b, 0xf9, 0xc3, 0xe1, 0xf5, 0x49, 0xfb,
+	0x11, 0x0e, 0xda, 0x2e, 0x64, 0xaf, 0x5d, 0xc8, 0xde, 0xbb, 0x90, 0x3d, 0x7d, 0x86, 0x03, 0x79,
+	0xe0, 0xdf, 0x4d, 0xbe, 0x07, 0x00, 0x87, 0x12, 0xfc, 0x58, 0x49, 0x01, 0x00, 0x00,
 }
@@ -24,17 +24,55 @@ const (
 	Operation_Start     Operation = 0
 	Operation_Stop      Operation = 1
 	Operation_Heartbeat Operation = 2
+	// Shutdown stops the database process, keeping its data,
+	// without finishing the test.
+	Operation_Shutdown Operation = 3
+	// Restart starts the database process again with its existing data,
+	// after 'Shutdown'.
+	Operation_Restart Operation = 4
+	// Partition drops the traffic from and to 'PartitionPeerIPsString'.
+	Operation_Partition Operation = 5
+	// Heal removes all partitions of the agent machine.
+	Operation_Heal Operation = 6
+	// Wipe removes the data directory of the database,
+	// after 'Stop' or 'Shutdown'.
+	Operation_Wipe Operation = 7
+	// Metrics returns the CPU and memory usage of the database
+	// process, and the size of its data directory.
+	Operation_Metrics Operation = 8
+	// Install downloads the release 'DatabaseVersion' of the database,
+	// to start it with instead of the binaries of the agent flags.
+	Operation_Install Operation = 9
+	// Uninstall removes the release of 'Install', to start the
+	// database with the binaries of the agent flags again.
+	Operation_Uninstall Operation = 10
 )
 
 var Operation_name = map[int32]string{
-	0: "Start",
-	1: "Stop",
-	2: "Heartbeat",
+	0:  "Start",
+	1:  "Stop",
+	2:  "Heartbeat",
+	3:  "Shutdown",
+	4:  "Restart",
+	5:  "Partition",
+	6:  "Heal",
+	7:  "Wipe",
+	8:  "Metrics",
+	9:  "Install",
+	10: "Uninstall",
 }
 var Operation_value = map[string]int32{
 	"Start":     0,
 	"Stop":      1,
 	"Heartbeat": 2,
+	"Shutdown":  3,
+	"Restart":   4,
+	"Partition": 5,
+	"Heal":      6,
+	"Wipe":      7,
+	"Metrics":   8,
+	"Install":   9,
+	"Uninstall": 10,
 }
 
 func (x Operation) String() string {
@@ -54,14 +92,19 @@ type Request struct {
 	IPIndex                    uint32                      `protobuf:"varint,6,opt,name=IPIndex,proto3" json:"IPIndex,omitempty"`
 	CurrentClientNumber        int64                       `protobuf:"varint,7,opt,name=CurrentClientNumber,proto3" json:"CurrentClientNumber,omitempty"`
 	ConfigClientMachineInitial *ConfigClientMachineInitial `protobuf:"bytes,8,opt,name=ConfigClientMachineInitial" json:"ConfigClientMachineInitial,omitempty"`
-	Flag_Etcd_Other            *Flag_Etcd_Other            `protobuf:"bytes,100,opt,name=flag__etcd__other,json=flagEtcdOther" json:"flag__etcd__other,omitempty"`
-	Flag_Etcd_Tip              *Flag_Etcd_Tip              `protobuf:"bytes,101,opt,name=flag__etcd__tip,json=flagEtcdTip" json:"flag__etcd__tip,omitempty"`
-	Flag_Etcd_V3_2             *Flag_Etcd_V3_2             `protobuf:"bytes,102,opt,name=flag__etcd__v3_2,json=flagEtcdV32" json:"flag__etcd__v3_2,omitempty"`
-	Flag_Etcd_V3_3             *Flag_Etcd_V3_3             `protobuf:"bytes,103,opt,name=flag__etcd__v3_3,json=flagEtcdV33" json:"flag__etcd__v3_3,omitempty"`
-	Flag_Zookeeper_R3_5_3Beta  *Flag_Zookeeper_R3_5_3Beta  `protobuf:"bytes,200,opt,name=flag__zookeeper__r3_5_3_beta,json=flagZookeeperR353Beta" json:"flag__zookeeper__r3_5_3_beta,omitempty"`
-	Flag_Consul_V1_0_2         *Flag_Consul_V1_0_2         `protobuf:"bytes,300,opt,name=flag__consul__v1_0_2,json=flagConsulV102" json:"flag__consul__v1_0_2,omitempty"`
-	Flag_Cetcd_Beta            *Flag_Cetcd_Beta            `protobuf:"bytes,400,opt,name=flag__cetcd__beta,json=flagCetcdBeta" json:"flag__cetcd__beta,omitempty"`
-	Flag_Zetcd_Beta            *Flag_Zetcd_Beta            `protobuf:"bytes,500,opt,name=flag__zetcd__beta,json=flagZetcdBeta" json:"flag__zetcd__beta,omitempty"`
+	// PartitionPeerIPsString is the peer IPs to partition from on 'Partition',
+	// encoded in the same way as 'PeerIPsString'.
+	PartitionPeerIPsString string `protobuf:"bytes,9,opt,name=PartitionPeerIPsString,proto3" json:"PartitionPeerIPsString,omitempty"`
+	// DatabaseVersion is the release of the database to download on 'Install'.
+	DatabaseVersion           string                     `protobuf:"bytes,10,opt,name=DatabaseVersion,proto3" json:"DatabaseVersion,omitempty"`
+	Flag_Etcd_Other           *Flag_Etcd_Other           `protobuf:"bytes,100,opt,name=flag__etcd__other,json=flagEtcdOther" json:"flag__etcd__other,omitempty"`
+	Flag_Etcd_Tip             *Flag_Etcd_Tip             `protobuf:"bytes,101,opt,name=flag__etcd__tip,json=flagEtcdTip" json:"flag__etcd__tip,omitempty"`
+	Flag_Etcd_V3_2            *Flag_Etcd_V3_2            `protobuf:"bytes,102,opt,name=flag__etcd__v3_2,json=flagEtcdV32" json:"flag__etcd__v3_2,omitempty"`
+	Flag_Etcd_V3_3            *Flag_Etcd_V3_3            `protobuf:"bytes,103,opt,name=flag__etcd__v3_3,json=flagEtcdV33" json:"flag__etcd__v3_3,omitempty"`
+	Flag_Zookeeper_R3_5_3Beta *Flag_Zookeeper_R3_5_3Beta `protobuf:"bytes,200,opt,name=flag__zookeeper__r3_5_3_beta,json=flagZookeeperR353Beta" json:"flag__zookeeper__r3_5_3_beta,omitempty"`
+	Flag_Consul_V1_0_2        *Flag_Consul_V1_0_2        `protobuf:"bytes,300,opt,name=flag__consul__v1_0_2,json=flagConsulV102" json:"flag__consul__v1_0_2,omitempty"`
+	Flag_Cetcd_Beta           *Flag_Cetcd_Beta           `protobuf:"bytes,400,opt,name=flag__cetcd__beta,json=flagCetcdBeta" json:"flag__cetcd__beta,omitempty"`
+	Flag_Zetcd_Beta           *Flag_Zetcd_Beta           `protobuf:"bytes,500,opt,name=flag__zetcd__beta,json=flagZetcdBeta" json:"flag__zetcd__beta,omitempty"`
 }
 
 func (m *Request) Reset()                    { *m = Request{} }
@@ -74,6 +117,21 @@ type Response struct {
 	// DiskSpaceUsageBytes is the data size of the database on disk in bytes.
 	// It measures after database is requested to stop.
 	DiskSpaceUsageBytes int64 `protobuf:"varint,2,opt,name=DiskSpaceUsageBytes,proto3" json:"DiskSpaceUsageBytes,omitempty"`
+	// DatabaseStartUnixNanosecond is the time that the
+	// database process is started, on 'Start' and 'Restart'.
+	DatabaseStartUnixNanosecond int64 `protobuf:"varint,3,opt,name=DatabaseStartUnixNanosecond,proto3" json:"DatabaseStartUnixNanosecond,omitempty"`
+	// CPUPercent is the CPU usage of the database process, on 'Metrics'.
+	CPUPercent float64 `protobuf:"fixed64,4,opt,name=CPUPercent,proto3" json:"CPUPercent,omitempty"`
+	// VMRSSBytes is the resident memory of the database process, on 'Metrics'.
+	VMRSSBytes int64 `protobuf:"varint,5,opt,name=VMRSSBytes,proto3" json:"VMRSSBytes,omitempty"`
+	// DiskReadBytes and DiskWriteBytes are the total bytes read and written
+	// of the disk device of the agent, on 'Metrics'.
+	DiskReadBytes  int64 `protobuf:"varint,6,opt,name=DiskReadBytes,proto3" json:"DiskReadBytes,omitempty"`
+	DiskWriteBytes int64 `protobuf:"varint,7,opt,name=DiskWriteBytes,proto3" json:"DiskWriteBytes,omitempty"`
+	// NetworkReceiveBytes and NetworkTransmitBytes are the total bytes
+	// received and sent of the network interface of the agent, on 'Metrics'.
+	NetworkReceiveBytes  int64 `protobuf:"varint,8,opt,name=NetworkReceiveBytes,proto3" json:"NetworkReceiveBytes,omitempty"`
+	NetworkTransmitBytes int64 `protobuf:"varint,9,opt,name=NetworkTransmitBytes,proto3" json:"NetworkTransmitBytes,omitempty"`
 }
 
 func (m *Response) Reset()                    { *m = Response{} }
@@ -226,6 +284,18 @@ func (m *Request) MarshalTo(dAtA []byte) (int, error) {
 		}
 		i += n1
 	}
+	if len(m.PartitionPeerIPsString) > 0 {
+		dAtA[i] = 0x4a
+		i++
+		i = encodeVarintMessage(dAtA, i, uint64(len(m.PartitionPeerIPsString)))
+		i += copy(dAtA[i:], m.PartitionPeerIPsString)
+	}
+	if len(m.DatabaseVersion) > 0 {
+		dAtA[i] = 0x52
+		i++
+		i = encodeVarintMessage(dAtA, i, uint64(len(m.DatabaseVersion)))
+		i += copy(dAtA[i:], m.DatabaseVersion)
+	}
 	if m.Flag_Etcd_Other != nil {
 		dAtA[i] = 0xa2
 		i++
@@ -355,9 +425,62 @@ func (m *Response) MarshalTo(dAtA []byte) (int, error) {
 		i++
 		i = encodeVarintMessage(dAtA, i, uint64(m.DiskSpaceUsageBytes))
 	}
+	if m.DatabaseStartUnixNanosecond != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintMessage(dAtA, i, uint64(m.DatabaseStartUnixNanosecond))
+	}
+	if m.CPUPercent != 0 {
+		dAtA[i] = 0x21
+		i++
+		i = encodeFixed64Message(dAtA, i, uint64(math.Float64bits(float64(m.CPUPercent))))
+	}
+	if m.VMRSSBytes != 0 {
+		dAtA[i] = 0x28
+		i++
+		i = encodeVarintMessage(dAtA, i, uint64(m.VMRSSBytes))
+	}
+	if m.DiskReadBytes != 0 {
+		dAtA[i] = 0x30
+		i++
+		i = encodeVarintMessage(dAtA, i, uint64(m.DiskReadBytes))
+	}
+	if m.DiskWriteBytes != 0 {
+		dAtA[i] = 0x38
+		i++
+		i = encodeVarintMessage(dAtA, i, uint64(m.DiskWriteBytes))
+	}
+	if m.NetworkReceiveBytes != 0 {
+		dAtA[i] = 0x40
+		i++
+		i = encodeVarintMessage(dAtA, i, uint64(m.NetworkReceiveBytes))
+	}
+	if m.NetworkTransmitBytes != 0 {
+		dAtA[i] = 0x48
+		i++
+		i = encodeVarintMessage(dAtA, i, uint64(m.NetworkTransmitBytes))
+	}
 	return i, nil
 }
 
+func encodeFixed64Message(dAtA []byte, offset int, v uint64) int {
+	dAtA[offset] = uint8(v)
+	dAtA[offset+1] = uint8(v >> 8)
+	dAtA[offset+2] = uint8(v >> 16)
+	dAtA[offset+3] = uint8(v >> 24)
+	dAtA[offset+4] = uint8(v >> 32)
+	dAtA[offset+5] = uint8(v >> 40)
+	dAtA[offset+6] = uint8(v >> 48)
+	dAtA[offset+7] = uint8(v >> 56)
+	return offset + 8
+}
+func encodeFixed32Message(dAtA []byte, offset int, v uint32) int {
+	dAtA[offset] = uint8(v)
+	dAtA[offset+1] = uint8(v >> 8)
+	dAtA[offset+2] = uint8(v >> 16)
+	dAtA[offset+3] = uint8(v >> 24)
+	return offset + 4
+}
 func encodeVarintMessage(dAtA []byte, offset int, v uint64) int {
 	for v >= 1<<7 {
 		dAtA[offset] = uint8(v&0x7f | 0x80)
@@ -397,6 +520,14 @@ func (m *Request) Size() (n int) {
 		l = m.ConfigClientMachineInitial.Size()
 		n += 1 + l + sovMessage(uint64(l))
 	}
+	l = len(m.PartitionPeerIPsString)
+	if l > 0 {
+		n += 1 + l + sovMessage(uint64(l))
+	}
+	l = len(m.DatabaseVersion)
+	if l > 0 {
+		n += 1 + l + sovMessage(uint64(l))
+	}
 	if m.Flag_Etcd_Other != nil {
 		l = m.Flag_Etcd_Other.Size()
 		n += 2 + l + sovMessage(uint64(l))
@@ -441,6 +572,27 @@ func (m *Response) Size() (n int) {
 	if m.DiskSpaceUsageBytes != 0 {
 		n += 1 + sovMessage(uint64(m.DiskSpaceUsageBytes))
 	}
+	if m.DatabaseStartUnixNanosecond != 0 {
+		n += 1 + sovMessage(uint64(m.DatabaseStartUnixNanosecond))
+	}
+	if m.CPUPercent != 0 {
+		n += 9
+	}
+	if m.VMRSSBytes != 0 {
+		n += 1 + sovMessage(uint64(m.VMRSSBytes))
+	}
+	if m.DiskReadBytes != 0 {
+		n += 1 + sovMessage(uint64(m.DiskReadBytes))
+	}
+	if m.DiskWriteBytes != 0 {
+		n += 1 + sovMessage(uint64(m.DiskWriteBytes))
+	}
+	if m.NetworkReceiveBytes != 0 {
+		n += 1 + sovMessage(uint64(m.NetworkReceiveBytes))
+	}
+	if m.NetworkTransmitBytes != 0 {
+		n += 1 + sovMessage(uint64(m.NetworkTransmitBytes))
+	}
 	return n
 }
 
@@ -673,6 +825,64 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PartitionPeerIPsString", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthMessage
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PartitionPeerIPsString = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DatabaseVersion", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthMessage
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DatabaseVersion = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		case 100:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field Flag_Etcd_Other", wireType)
@@ -1026,6 +1236,138 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DatabaseStartUnixNanosecond", wireType)
+			}
+			m.DatabaseStartUnixNanosecond = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DatabaseStartUnixNanosecond |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CPUPercent", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += 8
+			v = uint64(dAtA[iNdEx-8])
+			v |= uint64(dAtA[iNdEx-7]) << 8
+			v |= uint64(dAtA[iNdEx-6]) << 16
+			v |= uint64(dAtA[iNdEx-5]) << 24
+			v |= uint64(dAtA[iNdEx-4]) << 32
+			v |= uint64(dAtA[iNdEx-3]) << 40
+			v |= uint64(dAtA[iNdEx-2]) << 48
+			v |= uint64(dAtA[iNdEx-1]) << 56
+			m.CPUPercent = float64(math.Float64frombits(v))
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field VMRSSBytes", wireType)
+			}
+			m.VMRSSBytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.VMRSSBytes |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DiskReadBytes", wireType)
+			}
+			m.DiskReadBytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DiskReadBytes |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DiskWriteBytes", wireType)
+			}
+			m.DiskWriteBytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DiskWriteBytes |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NetworkReceiveBytes", wireType)
+			}
+			m.NetworkReceiveBytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.NetworkReceiveBytes |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NetworkTransmitBytes", wireType)
+			}
+			m.NetworkTransmitBytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.NetworkTransmitBytes |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipMessage(dAtA[iNdEx:])
@@ -1155,50 +1497,63 @@ var (
 func init() { proto.RegisterFile("dbtesterpb/message.proto", fileDescriptorMessage) }
 
 var fileDescriptorMessage = []byte{
-	// 712 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x94, 0xdf, 0x4e, 0x1b, 0x47,
-	0x14, 0xc6, 0xbd, 0x98, 0x3f, 0xf6, 0x58, 0xa6, 0xee, 0x00, 0xd5, 0xc8, 0x50, 0x77, 0x85, 0x2a,
-	0x64, 0x21, 0xd5, 0x06, 0xaf, 0x68, 0xaf, 0x8b, 0x69, 0x8b, 0xa5, 0x26, 0xa0, 0xb1, 0xe1, 0x82,
-	0x9b, 0xd1, 0xec, 0xfa, 0x78, 0x59, 0x61, 0xef, 0x6c, 0x66, 0xc7, 0x28, 0xe1, 0x2e, 0x6f, 0x90,
-	0xcb, 0x3c, 0x44, 0x1e, 0x84, 0xcb, 0x3c, 0x42, 0x42, 0x5e, 0x21, 0x0f, 0x10, 0xed, 0xac, 0x17,
-	0x0f, 0xd8, 0x4e, 0xee, 0x7c, 0xbe, 0xef, 0x9b, 0xdf, 0xce, 0x1f, 0x9f, 0x83, 0x48, 0xdf, 0x55,
-	0x10, 0x2b, 0x90, 0x91, 0xdb, 0x1c, 0x41, 0x1c, 0x73, 0x1f, 0x1a, 0x91, 0x14, 0x4a, 0x60, 0x34,
-	0x75, 0xaa, 0x7f, 0xf8, 0x81, 0xba, 0x1e, 0xbb, 0x0d, 0x4f, 0x8c, 0x9a, 0xbe, 0xf0, 0x45, 0x53,
-	0x47, 0xdc, 0xf1, 0x40, 0x57, 0xba, 0xd0, 0xbf, 0xd2, 0xa5, 0xd5, 0x1d, 0x03, 0xda, 0xe7, 0x8a,
-	0xbb, 0x3c, 0x06, 0x16, 0xf4, 0x27, 0x6e, 0xd5, 0x70, 0x07, 0x43, 0xee, 0x33, 0x50, 0x5e, 0xe6,
-	0xfd, 0xf6, 0xdc, 0xbb, 0x13, 0xe2, 0x06, 0x20, 0x02, 0x39, 0x07, 0xad, 0x03, 0x9e, 0x08, 0xe3,
-	0xf1, 0x70, 0xe2, 0x6e, 0xcf, 0x2c, 0x37, 0xd8, 0x33, 0xa6, 0x67, 0x98, 0x7b, 0x86, 0xe9, 0x89,
-	0x70, 0x10, 0xf8, 0xcc, 0x1b, 0x06, 0x10, 0x2a, 0x36, 0xe2, 0xde, 0x75, 0x10, 0x4e, 0x6e, 0x65,
-	0xf7, 0x6d, 0x01, 0xad, 0x51, 0x78, 0x35, 0x86, 0x58, 0x61, 0x07, 0x15, 0xcf, 0x22, 0x90, 0x5c,
-	0x05, 0x22, 0x24, 0x96, 0x6d, 0xd5, 0xd7, 0x5b, 0x5b, 0x8d, 0x29, 0xa7, 0xf1, 0x68, 0xd2, 0x69,
-	0x0e, 0xef, 0xa3, 0x4a, 0x4f, 0x06, 0xbe, 0x0f, 0xf2, 0x7f, 0xe1, 0x5f, 0x44, 0x43, 0xc1, 0xfb,
-	0x64, 0xc9, 0xb6, 0xea, 0x05, 0x3a, 0xa3, 0xe3, 0x3f, 0x11, 0x3a, 0x99, 0x5c, 0x5f, 0xe7, 0x84,
-	0xe4, 0xf5, 0x17, 0x7e, 0x31, 0xbf, 0x30, 0x75, 0xa9, 0x91, 0xc4, 0x36, 0x2a, 0x65, 0x55, 0x8f,
-	0xfb, 0x64, 0xd9, 0xb6, 0xea, 0x45, 0x6a, 0x4a, 0xf8, 0x77, 0x54, 0x3e, 0x07, 0x90, 0x9d, 0xf3,
-	0xb8, 0xab, 0x64, 0x10, 0xfa, 0x64, 0x45, 0x67, 0x9e, 0x8a, 0x98, 0xa0, 0xb5, 0xce, 0x79, 0x27,
-	0xec, 0xc3, 0x6b, 0xb2, 0x6a, 0x5b, 0xf5, 0x32, 0xcd, 0x4a, 0x7c, 0x80, 0x36, 0xda, 0x63, 0x29,
-	0x21, 0x54, 0x6d, 0x7d, 0x4b, 0x2f, 0xc7, 0x23, 0x17, 0x24, 0x59, 0xb3, 0xad, 0x7a, 0x9e, 0xce,
-	0xb3, 0xf0, 0x00, 0x55, 0xdb, 0xfa, 0x5e, 0x53, 0xf5, 0x45, 0x7a, 0xab, 0x9d, 0x30, 0x50, 0x01,
-	0x1f, 0x92, 0x82, 0x6d, 0xd5, 0x4b, 0xad, 0x3d, 0xf3, 0x6c, 0x8b, 0xd3, 0xf4, 0x3b, 0x24, 0xfc,
-	0x1f, 0xfa, 0x59, 0x3f, 0xae, 0xfe, 0x57, 0x31, 0x26, 0xd4, 0x35, 0x48, 0xd2, 0xd7, 0xf8, 0x5f,
-	0x4d, 0xfc, 0x4c, 0x88, 0x96, 0x13, 0xe9, 0x1f, 0xe5, 0xf5, 0xcf, 0x92, 0x12, 0xff, 0x8d, 0x7e,
-	0x32, 0x33, 0x2a, 0x88, 0x08, 0x68, 0xcc, 0xf6, 0x22, 0x8c, 0x0a, 0x22, 0x5a, 0xca, 0x20, 0xbd,
-	0x20, 0xc2, 0x6d, 0x54, 0x31, 0xfd, 0x5b, 0x87, 0xb5, 0xc8, 0x40, 0x33, 0x76, 0x16, 0x31, 0x92,
-	0xcc, 0x14, 0x72, 0xe9, 0xb4, 0xe6, 0x40, 0x1c, 0xe2, 0xff, 0x10, 0xe2, 0x98, 0x10, 0x07, 0x0f,
-	0xd0, 0x4e, 0x1a, 0x78, 0xec, 0x27, 0xc6, 0xa4, 0xc3, 0x8e, 0x98, 0xc3, 0x5c, 0x50, 0x9c, 0xdc,
-	0x5b, 0x9a, 0x58, 0x9f, 0x25, 0xce, 0x5f, 0x40, 0xb7, 0x12, 0xf7, 0x2a, 0xf3, 0xa8, 0x73, 0xe4,
-	0x1c, 0x83, 0xe2, 0xf8, 0x0c, 0x6d, 0xa6, 0xcb, 0xd2, 0xb6, 0x64, 0xec, 0xf6, 0x90, 0x1d, 0xb0,
-	0x16, 0xf9, 0xb0, 0xa4, 0xf9, 0xf6, 0x2c, 0xff, 0x69, 0x90, 0xae, 0x27, 0x6a, 0x5b, 0x6b, 0x97,
-	0x87, 0x07, 0x2d, 0x7c, 0x9a, 0x3d, 0xa7, 0x97, 0x1e, 0x4d, 0xef, 0xf6, 0x5d, 0x7e, 0xd1, 0x7b,
-	0x1a, 0xa9, 0xf4, 0x3d, 0xdb, 0x89, 0xa0, 0xb7, 0xf6, 0x48, 0xba, 0x33, 0x48, 0x5f, 0x17, 0x92,
-	0xee, 0x9e, 0x93, 0xae, 0x32, 0xd2, 0xee, 0x25, 0x2a, 0x50, 0x88, 0x23, 0x11, 0xc6, 0x90, 0xb4,
-	0x48, 0x77, 0xec, 0x79, 0x10, 0xc7, 0x7a, 0x02, 0x14, 0x68, 0x56, 0x26, 0x2d, 0x72, 0x12, 0xc4,
-	0x37, 0xdd, 0x88, 0x7b, 0x70, 0x91, 0xcc, 0xd5, 0xe3, 0x37, 0x0a, 0x62, 0xdd, 0xeb, 0x79, 0x3a,
-	0xcf, 0xda, 0x6f, 0x1a, 0xf3, 0x04, 0x17, 0xd1, 0x4a, 0x57, 0x71, 0xa9, 0x2a, 0x39, 0x5c, 0x40,
-	0xcb, 0x5d, 0x25, 0xa2, 0x8a, 0x85, 0xcb, 0xa8, 0x78, 0x0a, 0x5c, 0x2a, 0x17, 0xb8, 0xaa, 0x2c,
-	0xb5, 0xfe, 0x45, 0xa5, 0x9e, 0xe4, 0x61, 0x1c, 0x09, 0xa9, 0x40, 0xe2, 0xbf, 0x50, 0x41, 0x97,
-	0x03, 0x90, 0x78, 0xc3, 0x3c, 0xd1, 0x64, 0x60, 0x55, 0x37, 0x9f, 0x8a, 0xe9, 0x11, 0x76, 0x73,
-	0xc7, 0x9b, 0xf7, 0x9f, 0x6b, 0xb9, 0xfb, 0x87, 0x9a, 0xf5, 0xf1, 0xa1, 0x66, 0x7d, 0x7a, 0xa8,
-	0x59, 0xef, 0xbf, 0xd4, 0x72, 0xee, 0xaa, 0x9e, 0x78, 0xce, 0xb7, 0x00, 0x00, 0x00, 0xff, 0xff,
-	0x9b, 0x7a, 0x8c, 0xc2, 0x23, 0x06, 0x00, 0x00,
+	// 920 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x55, 0xdd, 0x6e, 0xdb, 0x36,
+	0x14, 0x8e, 0xea, 0xfc, 0xc8, 0xf4, 0x92, 0x6a, 0x6c, 0x5a, 0x08, 0x4e, 0xe6, 0x19, 0xc1, 0x10,
+	0x18, 0x05, 0x96, 0xa4, 0x16, 0xda, 0xdd, 0x6e, 0x71, 0xb6, 0xc5, 0xc0, 0x92, 0x18, 0x74, 0x9c,
+	0x02, 0xbd, 0x11, 0x68, 0xe9, 0x58, 0x21, 0x62, 0x8b, 0x1a, 0x49, 0xa7, 0x5d, 0x9e, 0x61, 0x17,
+	0xbb, 0x1a, 0xf6, 0x10, 0x7b, 0x90, 0x5c, 0xee, 0x11, 0xb6, 0xf4, 0x15, 0xf6, 0x00, 0x03, 0x29,
+	0xff, 0xd0, 0xb1, 0xdd, 0xdd, 0xe9, 0x7c, 0xdf, 0x77, 0x3e, 0x1d, 0x1e, 0x92, 0x87, 0xc8, 0x8f,
+	0xbb, 0x0a, 0xa4, 0x02, 0x91, 0x75, 0x0f, 0x07, 0x20, 0x25, 0x4d, 0xe0, 0x20, 0x13, 0x5c, 0x71,
+	0x8c, 0xa6, 0x4c, 0xf9, 0xeb, 0x84, 0xa9, 0xeb, 0x61, 0xf7, 0x20, 0xe2, 0x83, 0xc3, 0x84, 0x27,
+	0xfc, 0xd0, 0x48, 0xba, 0xc3, 0x9e, 0x89, 0x4c, 0x60, 0xbe, 0xf2, 0xd4, 0xf2, 0xae, 0x65, 0x1a,
+	0x53, 0x45, 0xbb, 0x54, 0x42, 0xc8, 0xe2, 0x11, 0x5b, 0xb6, 0xd8, 0x5e, 0x9f, 0x26, 0x21, 0xa8,
+	0x68, 0xcc, 0x7d, 0xf9, 0x98, 0xbb, 0xe3, 0xfc, 0x06, 0x20, 0x03, 0xb1, 0xc0, 0xda, 0x08, 0x22,
+	0x9e, 0xca, 0x61, 0x7f, 0xc4, 0xee, 0xcc, 0xa5, 0x5b, 0xde, 0x73, 0x64, 0x64, 0x91, 0xfb, 0x16,
+	0x19, 0xf1, 0xb4, 0xc7, 0x92, 0x30, 0xea, 0x33, 0x48, 0x55, 0x38, 0xa0, 0xd1, 0x35, 0x4b, 0x47,
+	0x5d, 0xd9, 0xfb, 0xe8, 0xa2, 0x0d, 0x02, 0x3f, 0x0f, 0x41, 0x2a, 0x1c, 0xa0, 0xe2, 0x45, 0x06,
+	0x82, 0x2a, 0xc6, 0x53, 0xdf, 0xa9, 0x3a, 0xb5, 0xad, 0xfa, 0xf3, 0x83, 0xa9, 0xcf, 0xc1, 0x84,
+	0x24, 0x53, 0x1d, 0x7e, 0x89, 0xbc, 0x4b, 0xc1, 0x92, 0x04, 0xc4, 0x4f, 0x3c, 0xe9, 0x64, 0x7d,
+	0x4e, 0x63, 0xff, 0x49, 0xd5, 0xa9, 0xb9, 0x64, 0x0e, 0xc7, 0x6f, 0x10, 0x3a, 0x19, 0xb5, 0xaf,
+	0x79, 0xe2, 0x17, 0xcc, 0x1f, 0x5e, 0xd8, 0x7f, 0x98, 0xb2, 0xc4, 0x52, 0xe2, 0x2a, 0x2a, 0x8d,
+	0xa3, 0x4b, 0x9a, 0xf8, 0xab, 0x55, 0xa7, 0x56, 0x24, 0x36, 0x84, 0xbf, 0x42, 0x9b, 0x2d, 0x00,
+	0xd1, 0x6c, 0xc9, 0xb6, 0x12, 0x2c, 0x4d, 0xfc, 0x35, 0xa3, 0x99, 0x05, 0xb1, 0x8f, 0x36, 0x9a,
+	0xad, 0x66, 0x1a, 0xc3, 0x07, 0x7f, 0xbd, 0xea, 0xd4, 0x36, 0xc9, 0x38, 0xc4, 0x47, 0xe8, 0x59,
+	0x63, 0x28, 0x04, 0xa4, 0xaa, 0x61, 0xba, 0x74, 0x3e, 0x1c, 0x74, 0x41, 0xf8, 0x1b, 0x55, 0xa7,
+	0x56, 0x20, 0x8b, 0x28, 0xdc, 0x43, 0xe5, 0x86, 0xe9, 0x6b, 0x8e, 0x9e, 0xe5, 0x5d, 0x6d, 0xa6,
+	0x4c, 0x31, 0xda, 0xf7, 0xdd, 0xaa, 0x53, 0x2b, 0xd5, 0xf7, 0xed, 0xb5, 0x2d, 0x57, 0x93, 0x4f,
+	0x38, 0xe1, 0x37, 0xe8, 0x45, 0x8b, 0x0a, 0xc5, 0x74, 0xb3, 0x67, 0x97, 0x58, 0x34, 0x4b, 0x5c,
+	0xc2, 0xe2, 0x1f, 0xd1, 0xe7, 0xe6, 0x50, 0x98, 0xd3, 0x18, 0x86, 0x5c, 0x5d, 0x83, 0xf0, 0x63,
+	0x53, 0xd6, 0x17, 0x76, 0x59, 0x73, 0x22, 0xb2, 0xa9, 0xa1, 0xef, 0x55, 0x14, 0x5f, 0xe8, 0x10,
+	0x7f, 0x87, 0x9e, 0xda, 0x1a, 0xc5, 0x32, 0x1f, 0x8c, 0xcd, 0xce, 0x32, 0x1b, 0xc5, 0x32, 0x52,
+	0x1a, 0x9b, 0x5c, 0xb2, 0x0c, 0x37, 0x90, 0x67, 0xf3, 0xb7, 0x41, 0x58, 0xf7, 0x7b, 0xc6, 0x63,
+	0x77, 0x99, 0x87, 0xd6, 0x4c, 0x4d, 0xae, 0x82, 0xfa, 0x02, 0x93, 0xc0, 0x4f, 0xfe, 0xd7, 0x24,
+	0xb0, 0x4d, 0x02, 0xdc, 0x43, 0xbb, 0xb9, 0x60, 0x72, 0x0f, 0xc3, 0x50, 0x04, 0xe1, 0xeb, 0x30,
+	0x08, 0xbb, 0xa0, 0xa8, 0x7f, 0xef, 0x18, 0xc7, 0xda, 0xbc, 0xe3, 0xe2, 0x04, 0xf2, 0x5c, 0xb3,
+	0xef, 0xc6, 0x1c, 0x09, 0x5e, 0x07, 0xc7, 0xa0, 0x28, 0xbe, 0x40, 0xdb, 0x79, 0x5a, 0x7e, 0x9d,
+	0xc3, 0xf0, 0xf6, 0x55, 0x78, 0x14, 0xd6, 0xfd, 0x3f, 0x9f, 0x18, 0xff, 0xea, 0xbc, 0xff, 0xac,
+	0x90, 0x6c, 0x69, 0xb4, 0x61, 0xb0, 0xab, 0x57, 0x47, 0x75, 0x7c, 0x3a, 0xde, 0xce, 0x28, 0x5f,
+	0x9a, 0xa9, 0xf6, 0xb7, 0xc2, 0xb2, 0xfd, 0xb4, 0x54, 0xf9, 0x7e, 0x36, 0x34, 0x60, 0x4a, 0x9b,
+	0x38, 0xdd, 0x59, 0x4e, 0xff, 0x2e, 0x75, 0xba, 0x7b, 0xec, 0xf4, 0x6e, 0xe2, 0x54, 0x43, 0x4f,
+	0xc7, 0x77, 0xf0, 0x0a, 0x84, 0xd4, 0x53, 0x03, 0x99, 0x33, 0xf9, 0x18, 0xde, 0xfb, 0xb5, 0x80,
+	0x5c, 0x02, 0x32, 0xe3, 0xa9, 0x04, 0x7d, 0x0b, 0xdb, 0xc3, 0x28, 0x02, 0x29, 0xcd, 0x90, 0x71,
+	0xc9, 0x38, 0xd4, 0xb7, 0xf0, 0x84, 0xc9, 0x9b, 0x76, 0x46, 0x23, 0xe8, 0xe8, 0xd1, 0x7d, 0xfc,
+	0x8b, 0x02, 0x69, 0xc6, 0x49, 0x81, 0x2c, 0xa2, 0xf0, 0xb7, 0x68, 0x67, 0xfc, 0xaf, 0xb6, 0xa2,
+	0x42, 0x75, 0x52, 0xf6, 0xe1, 0x9c, 0xa6, 0x5c, 0x42, 0xc4, 0xd3, 0xd8, 0x8c, 0x98, 0x02, 0xf9,
+	0x94, 0x04, 0x57, 0x10, 0x6a, 0xb4, 0x3a, 0x2d, 0x10, 0x11, 0xa4, 0xca, 0x8c, 0x16, 0x87, 0x58,
+	0x88, 0xe6, 0xaf, 0xce, 0x48, 0xbb, 0x9d, 0x97, 0xb2, 0x66, 0x0c, 0x2d, 0x44, 0x4f, 0x1e, 0x5d,
+	0x18, 0x01, 0x1a, 0xe7, 0x92, 0x75, 0x23, 0x99, 0x05, 0xf1, 0x3e, 0xda, 0xd2, 0xc0, 0x5b, 0xc1,
+	0xd4, 0x68, 0x51, 0xf9, 0x68, 0x79, 0x84, 0xea, 0x0e, 0x9c, 0x83, 0x7a, 0xcf, 0xc5, 0x0d, 0x81,
+	0x08, 0xd8, 0xed, 0x48, 0xec, 0xe6, 0x1d, 0x58, 0x40, 0xe1, 0x3a, 0xda, 0x1e, 0xc1, 0x97, 0x82,
+	0xa6, 0x72, 0xc0, 0x54, 0x9e, 0x52, 0x34, 0x29, 0x0b, 0xb9, 0x97, 0xbf, 0x3b, 0xd6, 0xa4, 0xc7,
+	0x45, 0xb4, 0x66, 0x1a, 0xe3, 0xad, 0x60, 0x17, 0xad, 0xb6, 0x15, 0xcf, 0x3c, 0x07, 0x6f, 0xa2,
+	0xe2, 0x29, 0x50, 0xa1, 0xba, 0x40, 0x95, 0xf7, 0x04, 0x7f, 0x86, 0xdc, 0xf6, 0xf5, 0x50, 0xc5,
+	0xfc, 0x7d, 0xea, 0x15, 0x70, 0x49, 0xbf, 0x19, 0xd2, 0xe4, 0xac, 0x6a, 0xe5, 0x64, 0x04, 0x79,
+	0x6b, 0xda, 0xe2, 0x14, 0x68, 0xdf, 0x5b, 0xd7, 0x5f, 0x6f, 0x59, 0x06, 0xde, 0x86, 0xd6, 0x9f,
+	0x81, 0x12, 0x2c, 0x92, 0x9e, 0xab, 0x83, 0x66, 0x2a, 0x15, 0xed, 0xf7, 0xbd, 0xa2, 0x4e, 0xee,
+	0xa4, 0x6c, 0x14, 0xa2, 0xfa, 0x0f, 0xa8, 0x64, 0x2a, 0xcd, 0xb8, 0x50, 0x20, 0xf0, 0x37, 0xc8,
+	0x35, 0x61, 0x0f, 0x04, 0x7e, 0x66, 0x1f, 0xcd, 0xd1, 0x8b, 0x55, 0xde, 0x9e, 0x05, 0xf3, 0x03,
+	0xb6, 0xb7, 0x72, 0xbc, 0x7d, 0xff, 0x4f, 0x65, 0xe5, 0xfe, 0xa1, 0xe2, 0xfc, 0xf5, 0x50, 0x71,
+	0xfe, 0x7e, 0xa8, 0x38, 0x7f, 0x7c, 0xac, 0xac, 0x74, 0xd7, 0xcd, 0x93, 0x17, 0xfc, 0x37, 0x00,
+	0x30, 0x1d, 0xf1, 0x52, 0x24, 0x08, 0x00, 0x00,
 }
@@ -55,6 +55,10 @@ func GetRGBI(databaseID string, i int) color.Color {
 		return color.RGBA{251, 206, 0, 255} // yellow
 	case "cetcd__beta":
 		return color.RGBA{205, 220, 57, 255} // lime
+	case "redis__v4_0":
+		return color.RGBA{121, 85, 72, 255} // brown
+	case "tikv__v2_1":
+		return color.RGBA{156, 39, 176, 255} // purple
 	}
 	return plotutil.Color(i)
 }
@@ -77,6 +81,10 @@ func GetRGBII(databaseID string, i int) color.Color {
 		return color.RGBA{245, 247, 166, 255} // light-yellow
 	case "cetcd__beta":
 		return color.RGBA{238, 255, 65, 255} // light-lime
+	case "redis__v4_0":
+		return color.RGBA{188, 170, 164, 255} // light-brown
+	case "tikv__v2_1":
+		return color.RGBA{206, 147, 216, 255} // light-purple
 	}
 	return plotutil.Color(i)
 }
@@ -99,6 +107,10 @@ func GetRGBIII(databaseID string, i int) color.Color {
 		return color.RGBA{229, 255, 0, 255} // deep-yellow
 	case "cetcd__beta":
 		return color.RGBA{205, 220, 57, 255} // deep-lime
+	case "redis__v4_0":
+		return color.RGBA{62, 39, 35, 255} // deep-brown
+	case "tikv__v2_1":
+		return color.RGBA{74, 20, 140, 255} // deep-purple
 	}
 	return plotutil.Color(i)
 }
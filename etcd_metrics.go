@@ -0,0 +1,379 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/coreos/dbtester/dbtesterpb"
+
+	"github.com/coreos/etcd/pkg/report"
+	"github.com/gyuho/dataframe"
+	"go.uber.org/zap"
+)
+
+// etcdSignal is a server-side metric to correlate with the client latency.
+type etcdSignal struct {
+	name string
+	// category is where the slowness comes from: 'disk', 'raft' or 'network'
+	category string
+	metric   string
+	// histogram is true for latency histograms in seconds,
+	// averaged in each scrape interval, or false for gauges
+	histogram bool
+}
+
+var etcdSignals = []etcdSignal{
+	{name: "wal-fsync", category: "disk", metric: "etcd_disk_wal_fsync_duration_seconds", histogram: true},
+	{name: "backend-commit", category: "disk", metric: "etcd_disk_backend_commit_duration_seconds", histogram: true},
+	{name: "proposals-pending", category: "raft", metric: "etcd_server_proposals_pending"},
+	{name: "peer-round-trip", category: "network", metric: "etcd_network_peer_round_trip_time_seconds", histogram: true},
+}
+
+func (sg etcdSignal) unit() string {
+	if sg.histogram {
+		return "ms"
+	}
+	return "count"
+}
+
+// parseMetrics returns the values of the metrics in Prometheus text format,
+// summed over all labels, keyed by the name with no labels.
+func parseMetrics(r io.Reader) map[string]float64 {
+	vs := make(map[string]float64)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		txt := scanner.Text()
+		if txt == "" || strings.HasPrefix(txt, "#") {
+			continue
+		}
+		idx := strings.LastIndex(txt, " ")
+		if idx < 0 {
+			continue
+		}
+		v, err := strconv.ParseFloat(txt[idx+1:], 64)
+		if err != nil {
+			continue
+		}
+		name := txt[:idx]
+		if i := strings.Index(name, "{"); i >= 0 {
+			name = name[:i]
+		}
+		vs[name] += v
+	}
+	return vs
+}
+
+// etcdMetricsSample is the metrics of all endpoints at a scrape.
+type etcdMetricsSample struct {
+	unixSecond int64
+	values     map[string]float64
+}
+
+// etcdMetricsScraper scrapes the '/metrics' of all endpoints.
+type etcdMetricsScraper struct {
+	lg        *zap.Logger
+	endpoints []string
+	interval  time.Duration
+
+	mu      sync.Mutex
+	samples []etcdMetricsSample
+
+	stopOnce sync.Once
+	stopc    chan struct{}
+	donec    chan struct{}
+}
+
+func newEtcdMetricsScraper(lg *zap.Logger, gcfg dbtesterpb.ConfigClientMachineAgentControl) *etcdMetricsScraper {
+	s := &etcdMetricsScraper{
+		lg:       lg,
+		interval: time.Second,
+		stopc:    make(chan struct{}),
+		donec:    make(chan struct{}),
+	}
+	if sec := gcfg.ConfigClientMachineBenchmarkOptions.ConfigClientMachineEtcdMetrics.ScrapeIntervalSeconds; sec > 0 {
+		s.interval = time.Duration(sec) * time.Second
+	}
+	for _, ep := range gcfg.DatabaseEndpoints {
+		if !strings.HasPrefix(ep, "http://") {
+			ep = "http://" + ep
+		}
+		s.endpoints = append(s.endpoints, ep+"/metrics")
+	}
+	go s.run()
+	return s
+}
+
+func (s *etcdMetricsScraper) scrape(now time.Time) {
+	sample := etcdMetricsSample{unixSecond: now.Unix(), values: make(map[string]float64)}
+	for _, ep := range s.endpoints {
+		resp, err := http.Get(ep)
+		if err != nil {
+			// skip the scrape, to not mix partial sums
+			s.lg.Warn("failed to scrape metrics", zap.String("endpoint", ep), zap.Error(err))
+			return
+		}
+		for k, v := range parseMetrics(resp.Body) {
+			sample.values[k] += v
+		}
+		gracefulClose(resp)
+	}
+	s.mu.Lock()
+	s.samples = append(s.samples, sample)
+	s.mu.Unlock()
+}
+
+func (s *etcdMetricsScraper) run() {
+	defer close(s.donec)
+	s.scrape(time.Now())
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case now := <-ticker.C:
+			s.scrape(now)
+		case <-s.stopc:
+			return
+		}
+	}
+}
+
+func (s *etcdMetricsScraper) stop() {
+	s.stopOnce.Do(func() {
+		close(s.stopc)
+		<-s.donec
+	})
+}
+
+// signalPoint is the value of a signal in the scrape
+// interval that ends at 'unixSecond'.
+type signalPoint struct {
+	unixSecond int64
+	value      float64
+}
+
+// series returns the values of the signal in each scrape interval.
+// Histograms are the average latency in milliseconds in the interval.
+func (s *etcdMetricsScraper) series(sg etcdSignal) []signalPoint {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var ps []signalPoint
+	for i := 1; i < len(s.samples); i++ {
+		prev, cur := s.samples[i-1].values, s.samples[i].values
+		p := signalPoint{unixSecond: s.samples[i].unixSecond}
+		if !sg.histogram {
+			p.value = cur[sg.metric]
+		} else if n := cur[sg.metric+"_count"] - prev[sg.metric+"_count"]; n > 0 {
+			p.value = 1000 * (cur[sg.metric+"_sum"] - prev[sg.metric+"_sum"]) / n
+		}
+		ps = append(ps, p)
+	}
+	return ps
+}
+
+// clientWindows returns the average client latency in milliseconds
+// in each scrape interval (previous scrape, scrape], weighted by the
+// throughput of each second. Intervals with no request are skipped.
+func clientWindows(ts report.TimeSeries, ps []signalPoint) (lats []float64, idxs []int) {
+	for i, p := range ps {
+		from := int64(math.MinInt64)
+		if i > 0 {
+			from = ps[i-1].unixSecond
+		}
+		var total float64
+		var n int64
+		for _, pt := range ts {
+			if pt.Timestamp > from && pt.Timestamp <= p.unixSecond {
+				total += toMillisecond(pt.AvgLatency) * float64(pt.ThroughPut)
+				n += pt.ThroughPut
+			}
+		}
+		if n > 0 {
+			lats = append(lats, total/float64(n))
+			idxs = append(idxs, i)
+		}
+	}
+	return lats, idxs
+}
+
+// pearson returns the correlation coefficient of xs and ys,
+// or 0 if either does not vary.
+func pearson(xs, ys []float64) float64 {
+	n := float64(len(xs))
+	if n == 0 {
+		return 0
+	}
+	var mx, my float64
+	for i := range xs {
+		mx += xs[i]
+		my += ys[i]
+	}
+	mx, my = mx/n, my/n
+	var sxy, sxx, syy float64
+	for i := range xs {
+		dx, dy := xs[i]-mx, ys[i]-my
+		sxy += dx * dy
+		sxx += dx * dx
+		syy += dy * dy
+	}
+	if sxx == 0 || syy == 0 {
+		return 0
+	}
+	return sxy / math.Sqrt(sxx*syy)
+}
+
+func percentileOf(vs []float64, pct float64) float64 {
+	if len(vs) == 0 {
+		return 0
+	}
+	sorted := append([]float64(nil), vs...)
+	sort.Float64s(sorted)
+	idx := int(float64(len(sorted)) * pct / 100)
+	if idx >= len(sorted) {
+		idx = len(sorted) - 1
+	}
+	return sorted[idx]
+}
+
+// spikeFactor is the ratio to the median to be a spike, or elevated.
+const spikeFactor = 2
+
+// signalCorrelation is the correlation of a server signal
+// with the client latency over the scrape intervals.
+type signalCorrelation struct {
+	signal etcdSignal
+
+	samples  int
+	p50      float64
+	p90      float64
+	p99      float64
+	coeff    float64
+	spikes   int
+	elevated int
+}
+
+// correlate correlates the client latency with the signal. A client
+// latency spike is an interval with the average latency over twice the
+// median, and the signal is elevated if it is over twice its median.
+func correlate(ts report.TimeSeries, sg etcdSignal, ps []signalPoint) signalCorrelation {
+	lats, idxs := clientWindows(ts, ps)
+	vs := make([]float64, len(idxs))
+	for i, idx := range idxs {
+		vs[i] = ps[idx].value
+	}
+	sc := signalCorrelation{
+		signal:  sg,
+		samples: len(vs),
+		p50:     percentileOf(vs, 50),
+		p90:     percentileOf(vs, 90),
+		p99:     percentileOf(vs, 99),
+		coeff:   pearson(lats, vs),
+	}
+	latMedian := percentileOf(lats, 50)
+	for i := range lats {
+		if lats[i] > spikeFactor*latMedian {
+			sc.spikes++
+			if vs[i] > spikeFactor*sc.p50 {
+				sc.elevated++
+			}
+		}
+	}
+	return sc
+}
+
+func (sc signalCorrelation) elevatedPercent() float64 {
+	if sc.spikes == 0 {
+		return 0
+	}
+	return 100 * float64(sc.elevated) / float64(sc.spikes)
+}
+
+// saveLatencyCorrelation stops scraping, and saves the correlations
+// of the client latency with each server signal.
+func (cfg *Config) saveLatencyCorrelation(st runStats) {
+	s := cfg.etcdMetrics
+	if s == nil {
+		return
+	}
+	s.stop()
+
+	scs := make([]signalCorrelation, len(etcdSignals))
+	for i, sg := range etcdSignals {
+		scs[i] = correlate(st.TimeSeries, sg, s.series(sg))
+		cfg.lg.Sugar().Infof("client latency correlation with %s %s [coefficient: %.4f | p50: %.4f %s | p99: %.4f %s | elevated in %d out of %d client latency spikes]",
+			sg.category, sg.name, scs[i].coeff, scs[i].p50, sg.unit(), scs[i].p99, sg.unit(), scs[i].elevated, scs[i].spikes)
+	}
+
+	// point at the most correlated category, if any
+	best := -1
+	for i := range scs {
+		if scs[i].coeff >= 0.5 && (best < 0 || scs[i].coeff > scs[best].coeff) {
+			best = i
+		}
+	}
+	if best >= 0 {
+		cfg.lg.Sugar().Infof("client latency follows server %s latency (%s, coefficient %.4f)", scs[best].signal.category, scs[best].signal.name, scs[best].coeff)
+	} else {
+		cfg.lg.Info("client latency is not correlated with server disk, raft or network metrics")
+	}
+
+	fpath := cfg.ConfigClientMachineInitial.ClientServerLatencyCorrelationPath
+	if fpath == "" {
+		cfg.lg.Warn("'client_server_latency_correlation_path' is not set; skipping latency correlation")
+		return
+	}
+	c1 := dataframe.NewColumn("SIGNAL")
+	c2 := dataframe.NewColumn("CATEGORY")
+	c3 := dataframe.NewColumn("UNIT")
+	c4 := dataframe.NewColumn("SAMPLES")
+	c5 := dataframe.NewColumn("P50")
+	c6 := dataframe.NewColumn("P90")
+	c7 := dataframe.NewColumn("P99")
+	c8 := dataframe.NewColumn("CORRELATION-COEFFICIENT")
+	c9 := dataframe.NewColumn("CLIENT-LATENCY-SPIKES")
+	c10 := dataframe.NewColumn("ELEVATED-IN-SPIKES-PERCENT")
+	for _, sc := range scs {
+		c1.PushBack(dataframe.NewStringValue(sc.signal.name))
+		c2.PushBack(dataframe.NewStringValue(sc.signal.category))
+		c3.PushBack(dataframe.NewStringValue(sc.signal.unit()))
+		c4.PushBack(dataframe.NewStringValue(sc.samples))
+		c5.PushBack(dataframe.NewStringValue(fmt.Sprintf("%4.4f", sc.p50)))
+		c6.PushBack(dataframe.NewStringValue(fmt.Sprintf("%4.4f", sc.p90)))
+		c7.PushBack(dataframe.NewStringValue(fmt.Sprintf("%4.4f", sc.p99)))
+		c8.PushBack(dataframe.NewStringValue(fmt.Sprintf("%4.4f", sc.coeff)))
+		c9.PushBack(dataframe.NewStringValue(sc.spikes))
+		c10.PushBack(dataframe.NewStringValue(fmt.Sprintf("%4.4f", sc.elevatedPercent())))
+	}
+	fr := dataframe.New()
+	for _, col := range []dataframe.Column{c1, c2, c3, c4, c5, c6, c7, c8, c9, c10} {
+		if err := fr.AddColumn(col); err != nil {
+			panic(err)
+		}
+	}
+	if err := saveCSV(fr, fpath); err != nil {
+		panic(err)
+	}
+	cfg.lg.Info("saved latency correlation", zap.String("path", fpath))
+}
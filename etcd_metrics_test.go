@@ -0,0 +1,72 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/coreos/etcd/pkg/report"
+)
+
+func TestParseMetrics(t *testing.T) {
+	txt := `# HELP etcd_disk_wal_fsync_duration_seconds The latency distributions of fsync called by wal.
+# TYPE etcd_disk_wal_fsync_duration_seconds histogram
+etcd_disk_wal_fsync_duration_seconds_bucket{le="0.001"} 2
+etcd_disk_wal_fsync_duration_seconds_sum 0.5
+etcd_disk_wal_fsync_duration_seconds_count 10
+etcd_network_peer_round_trip_time_seconds_sum{To="a"} 1
+etcd_network_peer_round_trip_time_seconds_sum{To="b"} 2
+`
+	vs := parseMetrics(strings.NewReader(txt))
+	if vs["etcd_disk_wal_fsync_duration_seconds_sum"] != 0.5 || vs["etcd_disk_wal_fsync_duration_seconds_count"] != 10 {
+		t.Fatalf("unexpected fsync metrics %v", vs)
+	}
+	if v := vs["etcd_network_peer_round_trip_time_seconds_sum"]; v != 3 {
+		t.Fatalf("expected peer round trip summed over labels 3, got %v", v)
+	}
+}
+
+func TestCorrelate(t *testing.T) {
+	// client latency spikes at second 4, when fsync spikes
+	fsyncMs := []float64{1, 1, 1, 10, 1}
+	clientMs := []float64{5, 5, 5, 50, 5}
+
+	s := &etcdMetricsScraper{}
+	var sum, count float64
+	s.samples = append(s.samples, etcdMetricsSample{unixSecond: 0, values: map[string]float64{}})
+	var ts report.TimeSeries
+	for i := range fsyncMs {
+		sum += fsyncMs[i] / 1000
+		count++
+		s.samples = append(s.samples, etcdMetricsSample{unixSecond: int64(i + 1), values: map[string]float64{
+			"etcd_disk_wal_fsync_duration_seconds_sum":   sum,
+			"etcd_disk_wal_fsync_duration_seconds_count": count,
+		}})
+		ts = append(ts, report.DataPoint{Timestamp: int64(i + 1), AvgLatency: time.Duration(clientMs[i] * float64(time.Millisecond)), ThroughPut: 10})
+	}
+
+	sc := correlate(ts, etcdSignals[0], s.series(etcdSignals[0]))
+	if sc.samples != 5 {
+		t.Fatalf("expected 5 samples, got %d", sc.samples)
+	}
+	if sc.coeff < 0.99 {
+		t.Fatalf("expected correlation close to 1, got %f", sc.coeff)
+	}
+	if sc.spikes != 1 || sc.elevated != 1 {
+		t.Fatalf("expected fsync elevated in 1 out of 1 spikes, got %d out of %d", sc.elevated, sc.spikes)
+	}
+}
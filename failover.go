@@ -0,0 +1,354 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/coreos/dbtester/dbtesterpb"
+
+	"github.com/coreos/etcd/clientv3"
+	"github.com/coreos/etcd/etcdserver/etcdserverpb"
+	"github.com/gyuho/dataframe"
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+)
+
+const (
+	// failoverAutoRetry reads with one clientv3 client of all endpoints,
+	// which retries the failed reads on the other endpoints.
+	failoverAutoRetry = "auto-retry"
+	// failoverNoRetry reads through a pool of warm connections, one per
+	// member, with no retry, and switches to the next connection on error.
+	failoverNoRetry = "no-retry"
+)
+
+// failoverRead is a read of the failover probe.
+type failoverRead struct {
+	start, end time.Time
+	// member is the ID of the member that served the read
+	member uint64
+	err    error
+}
+
+// failoverTrial is the client-observed failover, from the kill of the
+// member serving the client to the first read served by another member.
+type failoverTrial struct {
+	mode  string
+	trial int
+	// member is the agent index of the killed member
+	member int
+
+	kill time.Time
+	// firstErr is zero if the client saw no error; that is,
+	// clientv3 retried the failed reads on another member
+	firstErr  time.Time
+	recovered time.Time
+
+	errN    int
+	slowest time.Duration
+}
+
+// took returns the time from the first error to the first success,
+// or from the kill if the client saw no error.
+func (ft failoverTrial) took() time.Duration {
+	if ft.recovered.IsZero() {
+		return 0
+	}
+	if ft.firstErr.IsZero() {
+		return ft.recovered.Sub(ft.kill)
+	}
+	return ft.recovered.Sub(ft.firstErr)
+}
+
+// summarizeFailover fills in the trial with the reads that completed
+// after the kill of member 'killed'. The reads must be in order.
+func summarizeFailover(ft *failoverTrial, reads []failoverRead, killed uint64) {
+	for _, rd := range reads {
+		if rd.end.Before(ft.kill) {
+			continue
+		}
+		if d := rd.end.Sub(rd.start); d > ft.slowest {
+			ft.slowest = d
+		}
+		if rd.err != nil {
+			ft.errN++
+			if ft.firstErr.IsZero() {
+				ft.firstErr = rd.end
+			}
+			continue
+		}
+		if rd.member != killed {
+			ft.recovered = rd.end
+			return
+		}
+	}
+}
+
+// memberIndex returns the index of the endpoint of the member 'id'.
+func memberIndex(members []*etcdserverpb.Member, endpoints []string, id uint64) (int, error) {
+	for _, m := range members {
+		if m.ID != id {
+			continue
+		}
+		for _, u := range m.ClientURLs {
+			pu, err := url.Parse(u)
+			if err != nil {
+				continue
+			}
+			for i, ep := range endpoints {
+				if pu.Host == ep {
+					return i, nil
+				}
+			}
+		}
+		return 0, fmt.Errorf("member %x client URLs %q not found in %q", id, m.ClientURLs, endpoints)
+	}
+	return 0, fmt.Errorf("member %x not found", id)
+}
+
+// newFailoverReader returns the function that reads the probe key,
+// and returns the ID of the member that served the read.
+func newFailoverReader(gcfg dbtesterpb.ConfigClientMachineAgentControl, mode string) (read func(context.Context) (uint64, error), done func(), err error) {
+	key := namespaced(gcfg, "dbtester-failover-probe")
+	switch mode {
+	case failoverAutoRetry:
+		cli := mustCreateConnEtcdv3(gcfg.DatabaseEndpoints)
+		read = func(ctx context.Context) (uint64, error) {
+			resp, err := cli.Get(ctx, key)
+			if err != nil {
+				return 0, err
+			}
+			return resp.Header.MemberId, nil
+		}
+		done = func() { cli.Close() }
+
+	case failoverNoRetry:
+		clis := make([]*clientv3.Client, len(gcfg.DatabaseEndpoints))
+		kvs := make([]etcdserverpb.KVClient, len(gcfg.DatabaseEndpoints))
+		for i, ep := range gcfg.DatabaseEndpoints {
+			clis[i] = mustCreateConnEtcdv3([]string{ep})
+			// raw gRPC client, to bypass the retries of clientv3
+			kvs[i] = etcdserverpb.NewKVClient(clis[i].ActiveConnection())
+		}
+		done = func() {
+			for _, cli := range clis {
+				cli.Close()
+			}
+		}
+		// warm up all connections, so that the failover does not dial
+		for i, kv := range kvs {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			_, err = kv.Range(ctx, &etcdserverpb.RangeRequest{Key: []byte(key)})
+			cancel()
+			if err != nil {
+				done()
+				return nil, nil, fmt.Errorf("failed to warm up connection to %q (%v)", gcfg.DatabaseEndpoints[i], err)
+			}
+		}
+		// only called by the probe, one read at a time
+		cur := 0
+		read = func(ctx context.Context) (uint64, error) {
+			resp, err := kvs[cur].Range(ctx, &etcdserverpb.RangeRequest{Key: []byte(key)})
+			if err != nil {
+				cur = (cur + 1) % len(kvs)
+				return 0, err
+			}
+			return resp.Header.MemberId, nil
+		}
+	}
+	return read, done, nil
+}
+
+// MeasureFailover kills the member serving the client while reading
+// continuously, and measures the time for the client to fail over to
+// another member, with and without the retries of clientv3. The killed
+// member is restarted after each trial.
+func (cfg *Config) MeasureFailover(databaseID string) error {
+	gcfg, ok := cfg.DatabaseIDToConfigClientMachineAgentControl[databaseID]
+	if !ok {
+		return fmt.Errorf("%q does not exist", databaseID)
+	}
+	switch databaseID {
+	case "etcd__other", "etcd__tip", "etcd__v3_2", "etcd__v3_3":
+	default:
+		return fmt.Errorf("'failover' is not supported for %q", databaseID)
+	}
+	if len(gcfg.DatabaseEndpoints) < 3 {
+		return fmt.Errorf("'failover' requires at least 3 members, got %d", len(gcfg.DatabaseEndpoints))
+	}
+
+	fcfg := gcfg.ConfigClientMachineBenchmarkOptions.ConfigClientMachineFailover
+	trials := 1
+	if fcfg.Trials > 0 {
+		trials = int(fcfg.Trials)
+	}
+	for _, mode := range []string{failoverAutoRetry, failoverNoRetry} {
+		for i := 0; i < trials; i++ {
+			ft, err := cfg.failover(gcfg, mode, i)
+			if err != nil {
+				return err
+			}
+			cfg.failoverTrials = append(cfg.failoverTrials, ft)
+			cfg.lg.Sugar().Infof("client failed over [mode: %s | trial: %d | member: %d | failover: %v | since kill: %v | errors: %d | slowest read: %v]",
+				ft.mode, ft.trial, ft.member, ft.took(), ft.recovered.Sub(ft.kill), ft.errN, ft.slowest)
+		}
+	}
+	return cfg.saveFailover()
+}
+
+func (cfg *Config) failover(gcfg dbtesterpb.ConfigClientMachineAgentControl, mode string, trial int) (failoverTrial, error) {
+	fcfg := gcfg.ConfigClientMachineBenchmarkOptions.ConfigClientMachineFailover
+	interval, reqTimeout, timeout := 10*time.Millisecond, time.Second, 30*time.Second
+	if fcfg.ProbeIntervalMilliseconds > 0 {
+		interval = time.Duration(fcfg.ProbeIntervalMilliseconds) * time.Millisecond
+	}
+	if fcfg.RequestTimeoutMilliseconds > 0 {
+		reqTimeout = time.Duration(fcfg.RequestTimeoutMilliseconds) * time.Millisecond
+	}
+	if fcfg.TimeoutSeconds > 0 {
+		timeout = time.Duration(fcfg.TimeoutSeconds) * time.Second
+	}
+	ft := failoverTrial{mode: mode, trial: trial}
+
+	read, done, err := newFailoverReader(gcfg, mode)
+	if err != nil {
+		return ft, err
+	}
+	defer done()
+
+	// find the member serving the client
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	var killed uint64
+	killed, err = read(ctx)
+	cancel()
+	if err != nil {
+		return ft, fmt.Errorf("failover probe failed before kill (%v)", err)
+	}
+	cli := mustCreateConnEtcdv3(gcfg.DatabaseEndpoints)
+	ctx, cancel = context.WithTimeout(context.Background(), 5*time.Second)
+	mresp, err := cli.MemberList(ctx)
+	cancel()
+	cli.Close()
+	if err != nil {
+		return ft, err
+	}
+	if ft.member, err = memberIndex(mresp.Members, gcfg.DatabaseEndpoints, killed); err != nil {
+		return ft, err
+	}
+
+	stopc, donec := make(chan struct{}), make(chan struct{})
+	var reads []failoverRead
+	go func() {
+		defer close(donec)
+		for {
+			select {
+			case <-stopc:
+				return
+			default:
+			}
+			rd := failoverRead{start: time.Now()}
+			ctx, cancel := context.WithTimeout(context.Background(), reqTimeout)
+			rd.member, rd.err = read(ctx)
+			cancel()
+			rd.end = time.Now()
+			reads = append(reads, rd)
+			if rd.err == nil && rd.member != killed {
+				return
+			}
+			time.Sleep(interval)
+		}
+	}()
+
+	ft.kill = time.Now()
+	cfg.lg.Info("failover: killing member serving the client", zap.String("mode", mode), zap.Int("index", ft.member))
+	if _, err = cfg.sendRequest(gcfg.DatabaseID, dbtesterpb.Operation_Shutdown, ft.member); err != nil {
+		close(stopc)
+		<-donec
+		return ft, err
+	}
+	select {
+	case <-donec:
+	case <-time.After(timeout):
+		close(stopc)
+		<-donec
+		cfg.lg.Warn("failover: client did not fail over", zap.String("mode", mode), zap.Duration("timeout", timeout))
+	}
+	summarizeFailover(&ft, reads, killed)
+
+	cfg.lg.Info("failover: restarting member", zap.Int("index", ft.member))
+	if _, err = cfg.sendRequest(gcfg.DatabaseID, dbtesterpb.Operation_Restart, ft.member); err != nil {
+		return ft, err
+	}
+	restarted := mustCreateConnEtcdv3([]string{gcfg.DatabaseEndpoints[ft.member]})
+	defer restarted.Close()
+	_, err = waitFirstRead(func(ctx context.Context) error {
+		_, err := restarted.Get(ctx, namespaced(gcfg, "dbtester-failover-probe"), clientv3.WithSerializable())
+		return err
+	}, bootstrapTimeout)
+	return ft, err
+}
+
+func (cfg *Config) saveFailover() error {
+	fpath := cfg.ConfigClientMachineInitial.ClientFailoverPath
+	if fpath == "" {
+		cfg.lg.Warn("'client_failover_path' is not set; skipping failover time")
+		return nil
+	}
+
+	c1 := dataframe.NewColumn("MODE")
+	c2 := dataframe.NewColumn("TRIAL")
+	c3 := dataframe.NewColumn("MEMBER")
+	c4 := dataframe.NewColumn("KILL-UNIX-NANOSECOND")
+	c5 := dataframe.NewColumn("FIRST-ERROR-UNIX-NANOSECOND")
+	c6 := dataframe.NewColumn("RECOVERED-UNIX-NANOSECOND")
+	c7 := dataframe.NewColumn("ERRORS")
+	c8 := dataframe.NewColumn("FAILOVER-MS")
+	c9 := dataframe.NewColumn("SINCE-KILL-MS")
+	c10 := dataframe.NewColumn("SLOWEST-READ-MS")
+	for _, ft := range cfg.failoverTrials {
+		firstErr, recovered, sinceKill := int64(0), int64(0), time.Duration(0)
+		if !ft.firstErr.IsZero() {
+			firstErr = ft.firstErr.UnixNano()
+		}
+		if !ft.recovered.IsZero() {
+			recovered = ft.recovered.UnixNano()
+			sinceKill = ft.recovered.Sub(ft.kill)
+		}
+		c1.PushBack(dataframe.NewStringValue(ft.mode))
+		c2.PushBack(dataframe.NewStringValue(ft.trial))
+		c3.PushBack(dataframe.NewStringValue(ft.member))
+		c4.PushBack(dataframe.NewStringValue(ft.kill.UnixNano()))
+		c5.PushBack(dataframe.NewStringValue(firstErr))
+		c6.PushBack(dataframe.NewStringValue(recovered))
+		c7.PushBack(dataframe.NewStringValue(ft.errN))
+		c8.PushBack(dataframe.NewStringValue(fmt.Sprintf("%4.4f", toMillisecond(ft.took()))))
+		c9.PushBack(dataframe.NewStringValue(fmt.Sprintf("%4.4f", toMillisecond(sinceKill))))
+		c10.PushBack(dataframe.NewStringValue(fmt.Sprintf("%4.4f", toMillisecond(ft.slowest))))
+	}
+	fr := dataframe.New()
+	for _, col := range []dataframe.Column{c1, c2, c3, c4, c5, c6, c7, c8, c9, c10} {
+		if err := fr.AddColumn(col); err != nil {
+			return err
+		}
+	}
+	if err := saveCSV(fr, fpath); err != nil {
+		return err
+	}
+	cfg.lg.Info("saved failover time", zap.String("path", fpath))
+	return nil
+}
@@ -0,0 +1,82 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/coreos/etcd/etcdserver/etcdserverpb"
+)
+
+func TestSummarizeFailover(t *testing.T) {
+	kill := time.Unix(100, 0)
+	at := func(ms int) time.Time { return kill.Add(time.Duration(ms) * time.Millisecond) }
+	errUnavailable := errors.New("unavailable")
+
+	// no retry: errors on the killed member, and then another member serves
+	reads := []failoverRead{
+		{start: at(-20), end: at(-19), member: 1},
+		{start: at(-5), end: at(3), member: 1},
+		{start: at(10), end: at(12), err: errUnavailable},
+		{start: at(20), end: at(70), err: errUnavailable},
+		{start: at(80), end: at(81), member: 2},
+		{start: at(90), end: at(91), member: 2},
+	}
+	ft := failoverTrial{kill: kill}
+	summarizeFailover(&ft, reads, 1)
+	if ft.errN != 2 || !ft.firstErr.Equal(at(12)) || !ft.recovered.Equal(at(81)) || ft.slowest != 50*time.Millisecond {
+		t.Fatalf("unexpected trial %+v", ft)
+	}
+	if ft.took() != 69*time.Millisecond {
+		t.Fatalf("expected failover 69ms, got %v", ft.took())
+	}
+
+	// auto retry: the read blocks until another member serves it
+	reads = []failoverRead{
+		{start: at(-5), end: at(-4), member: 1},
+		{start: at(5), end: at(305), member: 3},
+	}
+	ft = failoverTrial{kill: kill}
+	summarizeFailover(&ft, reads, 1)
+	if ft.errN != 0 || !ft.firstErr.IsZero() || ft.took() != 305*time.Millisecond {
+		t.Fatalf("unexpected trial %+v", ft)
+	}
+
+	// never recovered
+	ft = failoverTrial{kill: kill}
+	summarizeFailover(&ft, []failoverRead{{start: at(1), end: at(2), err: errUnavailable}}, 1)
+	if !ft.recovered.IsZero() || ft.took() != 0 {
+		t.Fatalf("unexpected trial %+v", ft)
+	}
+}
+
+func TestMemberIndex(t *testing.T) {
+	members := []*etcdserverpb.Member{
+		{ID: 1, ClientURLs: []string{"http://10.0.0.1:2379"}},
+		{ID: 2, ClientURLs: []string{"http://127.0.0.1:2379", "http://10.0.0.2:2379"}},
+	}
+	eps := []string{"10.0.0.1:2379", "10.0.0.2:2379"}
+	if idx, err := memberIndex(members, eps, 2); err != nil || idx != 1 {
+		t.Fatalf("expected 1, got %d (%v)", idx, err)
+	}
+	if _, err := memberIndex(members, eps, 3); err == nil {
+		t.Fatal("expected error for unknown member")
+	}
+	if _, err := memberIndex(members, eps[:1], 2); err == nil {
+		t.Fatal("expected error for unknown endpoint")
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// failureSignature is a known failure of the databases, of the
+// error messages of the clients, with what it means and how to fix it,
+// for the readers of the report who are not experts of the database.
+type failureSignature struct {
+	name string
+	// patterns are the substrings of the error messages of the failure
+	patterns []string
+	// explanation is what happened
+	explanation string
+	// remediation is what to change to avoid it
+	remediation string
+}
+
+// failureSignatures are the known failures, matched in the order.
+var failureSignatures = []failureSignature{
+	{
+		name: "quota-exceeded",
+		patterns: []string{
+			"mvcc: database space exceeded",
+			"OOM command not allowed",
+		},
+		explanation: "The database reached its storage quota and rejects all writes until space is reclaimed. In etcd, the NOSPACE alarm is raised when the backend exceeds '--quota-backend-bytes'.",
+		remediation: "Raise the quota (etcd '--quota-backend-bytes', Redis 'maxmemory'), or compact and defragment during the run with '--auto-compact-every' and '--auto-defrag'; in etcd, disarm the NOSPACE alarm after reclaiming the space.",
+	},
+	{
+		name: "too-many-requests",
+		patterns: []string{
+			"etcdserver: too many requests",
+			"response code: 429",
+			"rate limit exceeded",
+		},
+		explanation: "The database shed the load: the requests arrived faster than it could apply them, so it rejected them instead of queueing. In etcd, the committed entries ran ahead of the applied ones by more than 5000.",
+		remediation: "Lower the rate with 'rate_limit_requests_per_second' or the number of clients, or give the database faster disks and more CPUs; the throughput before the rejections is the saturation point.",
+	},
+	{
+		name: "session-expired",
+		patterns: []string{
+			"zk: session has been expired by the server",
+			"requested lease not found",
+			"invalid session",
+		},
+		explanation: "The session or lease of the client expired on the server, so the ephemeral keys and locks of it are gone. The client did not heartbeat within the session timeout or the lease TTL, usually as the database or the client was stalled.",
+		remediation: "Raise the session timeout or the lease TTL, or lower the load on the client machine; check the database for the stalls of disk syncs or of elections at the time of the expirations.",
+	},
+	{
+		name: "leader-lost",
+		patterns: []string{
+			"etcdserver: no leader",
+			"etcdserver: leader changed",
+			"possibly due to previous leader failure",
+			"No cluster leader",
+			"not a leader",
+		},
+		explanation: "The cluster lost its leader and rejected the requests until a new one was elected. The elections come of a leader that failed, or of the heartbeats delayed by slow disks or networks.",
+		remediation: "If no failure was injected, check the disk sync and the network latencies of the members, and raise the heartbeat interval and the election timeout to over the round-trip time between the members.",
+	},
+}
+
+// failureDiagnosis is the errors of the run matched to a signature.
+type failureDiagnosis struct {
+	signature *failureSignature
+	// errors is the number of the errors matched
+	errors int
+	// messages are the error messages matched, sorted
+	messages []string
+}
+
+// diagnoseFailures matches the error messages of the error distribution
+// to the known failures, and returns the matched ones, most frequent first.
+func diagnoseFailures(errDist map[string]int) []failureDiagnosis {
+	byName := make(map[string]*failureDiagnosis)
+	for msg, n := range errDist {
+		sig := matchFailureSignature(msg)
+		if sig == nil {
+			continue
+		}
+		d, ok := byName[sig.name]
+		if !ok {
+			d = &failureDiagnosis{signature: sig}
+			byName[sig.name] = d
+		}
+		d.errors += n
+		d.messages = append(d.messages, msg)
+	}
+
+	ds := make([]failureDiagnosis, 0, len(byName))
+	for _, d := range byName {
+		sort.Strings(d.messages)
+		ds = append(ds, *d)
+	}
+	sort.Slice(ds, func(i, j int) bool {
+		if ds[i].errors != ds[j].errors {
+			return ds[i].errors > ds[j].errors
+		}
+		return ds[i].signature.name < ds[j].signature.name
+	})
+	return ds
+}
+
+// matchFailureSignature returns the signature of the error message, or nil.
+func matchFailureSignature(msg string) *failureSignature {
+	for i := range failureSignatures {
+		for _, p := range failureSignatures[i].patterns {
+			if strings.Contains(msg, p) {
+				return &failureSignatures[i]
+			}
+		}
+	}
+	return nil
+}
+
+// writeFailureDiagnoses writes the explanations of the matched failures.
+func writeFailureDiagnoses(w io.Writer, ds []failureDiagnosis) error {
+	for _, d := range ds {
+		if _, err := fmt.Fprintf(w, "FAILURE %q : %d errors\n  what happened: %s\n  remediation: %s\n",
+			d.signature.name, d.errors, d.signature.explanation, d.signature.remediation); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/coreos/etcd/etcdserver/api/v3rpc/rpctypes"
+	"github.com/samuel/go-zookeeper/zk"
+)
+
+func TestDiagnoseFailures(t *testing.T) {
+	ds := diagnoseFailures(map[string]int{
+		rpctypes.ErrGRPCNoSpace.Error():                     3,
+		rpctypes.ErrTooManyRequests.Error():                 5,
+		"Unexpected response code: 429 (rate limited)":      2,
+		zk.ErrSessionExpired.Error():                        1,
+		rpctypes.ErrGRPCNoLeader.Error():                    4,
+		"Unexpected response code: 500 (No cluster leader)": 4,
+		"context deadline exceeded":                         10,
+	})
+	exp := []struct {
+		name   string
+		errors int
+	}{
+		{"leader-lost", 8},
+		{"too-many-requests", 7},
+		{"quota-exceeded", 3},
+		{"session-expired", 1},
+	}
+	if len(ds) != len(exp) {
+		t.Fatalf("expected %d failures, got %+v", len(exp), ds)
+	}
+	for i := range exp {
+		if ds[i].signature.name != exp[i].name || ds[i].errors != exp[i].errors {
+			t.Fatalf("#%d: expected %s of %d errors, got %s of %d", i, exp[i].name, exp[i].errors, ds[i].signature.name, ds[i].errors)
+		}
+	}
+	if len(ds[0].messages) != 2 {
+		t.Fatalf("expected the messages of etcd and Consul, got %v", ds[0].messages)
+	}
+
+	var buf bytes.Buffer
+	if err := writeFailureDiagnoses(&buf, ds); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(buf.String(), `FAILURE "leader-lost" : 8 errors`) || strings.Count(buf.String(), "remediation: ") != 4 {
+		t.Fatalf("unexpected diagnoses %q", buf.String())
+	}
+}
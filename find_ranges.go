@@ -71,8 +71,14 @@ func FindRangesLatency(data report.TimeSeries, unit int64, totalRequests int64)
 	// and we want to map number of keys to latency
 	// so the range is the key
 	// and the value is the cumulative throughput
-	for _, ts := range data {
-		cumulKeyN += ts.ThroughPut
+	for i, ts := range data {
+		// downsampled data points span multiple seconds,
+		// and their throughput is the average per second
+		span := int64(1)
+		if i+1 < len(data) && data[i+1].Timestamp-ts.Timestamp > 1 {
+			span = data[i+1].Timestamp - ts.Timestamp
+		}
+		cumulKeyN += ts.ThroughPut * span
 		if cumulKeyN < unit {
 			// not enough data points yet
 			continue
@@ -0,0 +1,183 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/coreos/dbtester/dbtesterpb"
+
+	"github.com/coreos/etcd/clientv3"
+	"github.com/gyuho/dataframe"
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+)
+
+// identityLeaseTTL is the default TTL of the identity leases.
+const identityLeaseTTL = 10 * time.Second
+
+// identityLease is the lease-backed identity key of one client.
+type identityLease struct {
+	client  int
+	key     string
+	leaseID clientv3.LeaseID
+
+	keepAlives int64
+	// lost is when the keepalive stopped before the benchmark finished
+	lost time.Time
+}
+
+// identityLeases keeps alive one identity lease per client,
+// as the heartbeats of the real fleets do.
+type identityLeases struct {
+	lg      *zap.Logger
+	clients []*clientv3.Client
+
+	cancel func()
+	wg     sync.WaitGroup
+
+	mu     sync.Mutex
+	leases []*identityLease
+}
+
+func newIdentityLeases(lg *zap.Logger, gcfg dbtesterpb.ConfigClientMachineAgentControl) (*identityLeases, error) {
+	switch gcfg.DatabaseID {
+	case "etcd__other", "etcd__tip", "etcd__v3_2", "etcd__v3_3":
+	default:
+		return nil, fmt.Errorf("'identity_lease' is not supported for %q", gcfg.DatabaseID)
+	}
+	ttl := identityLeaseTTL
+	if s := gcfg.ConfigClientMachineBenchmarkOptions.ConfigClientMachineIdentityLease.TTLSeconds; s > 0 {
+		ttl = time.Duration(s) * time.Second
+	}
+
+	clients := mustCreateClientsEtcdv3(gcfg.DatabaseEndpoints, etcdv3ClientCfg{
+		totalConns:   gcfg.ConfigClientMachineBenchmarkOptions.ConnectionNumber,
+		totalClients: gcfg.ConfigClientMachineBenchmarkOptions.ClientNumber,
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	ls := &identityLeases{lg: lg, clients: clients, cancel: cancel}
+
+	for i, cli := range clients {
+		resp, err := cli.Grant(ctx, int64(ttl.Seconds()))
+		if err != nil {
+			ls.close()
+			return nil, fmt.Errorf("failed to grant identity lease for client %d (%v)", i, err)
+		}
+		l := &identityLease{client: i, key: namespaced(gcfg, fmt.Sprintf("dbtester-identity/%d", i)), leaseID: resp.ID}
+		ls.leases = append(ls.leases, l)
+		if _, err = cli.Put(ctx, l.key, fmt.Sprintf("%x", resp.ID), clientv3.WithLease(resp.ID)); err != nil {
+			ls.close()
+			return nil, fmt.Errorf("failed to put identity key for client %d (%v)", i, err)
+		}
+		ch, err := cli.KeepAlive(ctx, resp.ID)
+		if err != nil {
+			ls.close()
+			return nil, fmt.Errorf("failed to keep alive identity lease for client %d (%v)", i, err)
+		}
+		ls.wg.Add(1)
+		go ls.consume(ctx, l, ch)
+	}
+	lg.Sugar().Infof("keeping alive %d identity leases with TTL %v", len(clients), ttl)
+	return ls, nil
+}
+
+// consume drains the keepalive responses, until the context is
+// canceled or the lease is lost.
+func (ls *identityLeases) consume(ctx context.Context, l *identityLease, ch <-chan *clientv3.LeaseKeepAliveResponse) {
+	defer ls.wg.Done()
+	for range ch {
+		atomic.AddInt64(&l.keepAlives, 1)
+	}
+	if ctx.Err() == nil {
+		ls.mu.Lock()
+		l.lost = time.Now()
+		ls.mu.Unlock()
+		ls.lg.Warn("identity lease lost", zap.Int("client", l.client), zap.String("key", l.key))
+	}
+}
+
+// close stops the keepalives, and revokes the leases
+// to delete the identity keys.
+func (ls *identityLeases) close() {
+	ls.cancel()
+	ls.wg.Wait()
+
+	for _, l := range ls.leases {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		_, err := ls.clients[l.client].Revoke(ctx, l.leaseID)
+		cancel()
+		if err != nil && l.lost.IsZero() {
+			ls.lg.Warn("failed to revoke identity lease", zap.Int("client", l.client), zap.Error(err))
+		}
+	}
+	for _, cli := range ls.clients {
+		cli.Close()
+	}
+}
+
+func (cfg *Config) saveIdentityLeases() {
+	ls := cfg.identityLeases
+	if ls == nil {
+		return
+	}
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+
+	var keepAlives int64
+	var lost int
+	for _, l := range ls.leases {
+		keepAlives += atomic.LoadInt64(&l.keepAlives)
+		if !l.lost.IsZero() {
+			lost++
+		}
+	}
+	cfg.lg.Sugar().Infof("identity leases [clients: %d | keepalives: %d | lost: %d]", len(ls.leases), keepAlives, lost)
+
+	fpath := cfg.ConfigClientMachineInitial.ClientIdentityLeasePath
+	if fpath == "" {
+		cfg.lg.Warn("'client_identity_lease_path' is not set; skipping identity leases")
+		return
+	}
+	c1 := dataframe.NewColumn("CLIENT")
+	c2 := dataframe.NewColumn("KEY")
+	c3 := dataframe.NewColumn("LEASE-ID")
+	c4 := dataframe.NewColumn("KEEPALIVES")
+	c5 := dataframe.NewColumn("LOST-UNIX-NANOSECOND")
+	for _, l := range ls.leases {
+		c1.PushBack(dataframe.NewStringValue(l.client))
+		c2.PushBack(dataframe.NewStringValue(l.key))
+		c3.PushBack(dataframe.NewStringValue(fmt.Sprintf("%x", l.leaseID)))
+		c4.PushBack(dataframe.NewStringValue(atomic.LoadInt64(&l.keepAlives)))
+		lostNano := int64(0)
+		if !l.lost.IsZero() {
+			lostNano = l.lost.UnixNano()
+		}
+		c5.PushBack(dataframe.NewStringValue(lostNano))
+	}
+	fr := dataframe.New()
+	for _, col := range []dataframe.Column{c1, c2, c3, c4, c5} {
+		if err := fr.AddColumn(col); err != nil {
+			panic(err)
+		}
+	}
+	if err := saveCSV(fr, fpath); err != nil {
+		panic(err)
+	}
+	cfg.lg.Info("saved identity leases", zap.String("path", fpath))
+}
@@ -0,0 +1,269 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build integration
+// +build integration
+
+package dbtester
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/coreos/dbtester/dbtesterpb"
+	"github.com/coreos/dbtester/pkg/testbackend"
+
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+)
+
+// The integration tests run each benchmark with small totals against the
+// single-node databases of 'pkg/testbackend', with
+//
+//	DBTESTER_INTEGRATION=1 go test -tags integration -run Integration .
+//
+// or against the databases of 'scripts/docker-compose.integration.yml'
+// with 'scripts/integration-tests.sh'.
+
+const integrationConfig = `test_title: integration test of %[1]s
+config_client_machine_initial:
+  path_prefix: %[2]s
+  log_path: client-control.log
+  client_system_metrics_path: client-system-metrics.csv
+  client_system_metrics_interpolated_path: client-system-metrics-interpolated.csv
+  client_latency_throughput_timeseries_path: client-latency-throughput-timeseries.csv
+  client_latency_distribution_all_path: client-latency-distribution-all.csv
+  client_latency_distribution_percentile_path: client-latency-distribution-percentile.csv
+  client_latency_distribution_summary_path: client-latency-distribution-summary.csv
+  client_latency_by_key_number_path: client-latency-by-key-number.csv
+  server_disk_space_usage_summary_path: server-disk-space-usage-summary.csv
+all_database_id_list: [%[1]s]
+datatbase_id_to_config_client_machine_agent_control:
+  %[1]s:
+    database_description: %[1]s in a container
+    peer_ips: [%[3]s]
+    database_port_to_connect: %[4]s
+    %[1]s: {}
+    benchmark_options:
+      type: %[5]s
+      request_number: 1000
+      prepopulate: %[6]d
+      connection_number: 10
+      client_number: 10
+      key_size_bytes: 64
+      value_size_bytes: 256
+    benchmark_steps:
+      step1_start_database: false
+      step2_stress_database: true
+      step3_stop_database: false
+      step4_upload_logs: false
+`
+
+// integrationGroup returns the configuration of the benchmarks of the database.
+func integrationGroup(b *testbackend.Backend) dbtesterpb.ConfigClientMachineAgentControl {
+	return dbtesterpb.ConfigClientMachineAgentControl{DatabaseID: b.DatabaseID, DatabaseEndpoints: []string{b.Endpoint}}
+}
+
+func TestIntegrationStress(t *testing.T) {
+	tests := []struct {
+		name        string
+		typ         string
+		prepopulate int64
+		// keysPerRequest is of the range reads if not 0
+		keysPerRequest int64
+	}{
+		{name: "write", typ: "write"},
+		{name: "read", typ: "read"},
+		{name: "range", typ: "read", prepopulate: 1000, keysPerRequest: 10},
+	}
+	for _, id := range testbackend.DatabaseIDs() {
+		for _, tt := range tests {
+			t.Run(id+"/"+tt.name, func(t *testing.T) {
+				b := testbackend.Require(t, id)
+				defer b.Stop()
+
+				dir, err := ioutil.TempDir("", "integration")
+				if err != nil {
+					t.Fatal(err)
+				}
+				defer os.RemoveAll(dir)
+				host, port, err := net.SplitHostPort(b.Endpoint)
+				if err != nil {
+					t.Fatal(err)
+				}
+				fpath := filepath.Join(dir, "config.yaml")
+				if err = ioutil.WriteFile(fpath, []byte(fmt.Sprintf(integrationConfig, id, dir, host, port, tt.typ, tt.prepopulate)), 0644); err != nil {
+					t.Fatal(err)
+				}
+				cfg, err := ReadConfig(fpath, false)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if tt.keysPerRequest > 0 {
+					cfg.KeysPerRequest, cfg.RangeReads = tt.keysPerRequest, true
+				}
+				if err = cfg.Stress(id); err != nil {
+					t.Fatal(err)
+				}
+
+				out, err := ioutil.ReadFile(cfg.ConfigClientMachineInitial.ClientLatencyDistributionSummaryPath)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(out) == 0 {
+					t.Fatal("expected the latency summary")
+				}
+			})
+		}
+	}
+}
+
+func TestIntegrationCAS(t *testing.T) {
+	for _, id := range testbackend.DatabaseIDs() {
+		t.Run(id, func(t *testing.T) {
+			b := testbackend.Require(t, id)
+			defer b.Stop()
+
+			rs, err := CASBench(zap.NewNop(), integrationGroup(b), CASBenchOptions{Clients: 4, Connections: 2, Increments: 100})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if rs.Attempts < 100 || rs.LostUpdates != 0 {
+				t.Fatalf("unexpected result %+v", rs)
+			}
+		})
+	}
+}
+
+func TestIntegrationLease(t *testing.T) {
+	for _, id := range testbackend.DatabaseIDs() {
+		t.Run(id, func(t *testing.T) {
+			b := testbackend.Require(t, id)
+			defer b.Stop()
+
+			// Consul sessions have TTLs of at least 10 seconds
+			rs, err := LeaseBench(zap.NewNop(), integrationGroup(b), LeaseBenchOptions{Leases: 50, Clients: 5, Connections: 5, TTL: 10 * time.Second})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if rs.Errors != 0 || len(rs.Phases) == 0 {
+				t.Fatalf("unexpected result %+v", rs)
+			}
+		})
+	}
+}
+
+func TestIntegrationWatch(t *testing.T) {
+	for _, id := range testbackend.DatabaseIDs() {
+		t.Run(id, func(t *testing.T) {
+			b := testbackend.Require(t, id)
+			defer b.Stop()
+
+			backends, err := WatchBackends(id, false)
+			if err != nil {
+				t.Fatal(err)
+			}
+			opts := WatchBenchOptions{Watchers: 10, Connections: 2, Puts: 10, PutInterval: 100 * time.Millisecond, Timeout: 10 * time.Second}
+			for _, backend := range backends {
+				rs, err := WatchBench(zap.NewNop(), integrationGroup(b), backend, opts)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if rs.Events == 0 || rs.Errors != 0 {
+					t.Fatalf("unexpected result of %q %+v", backend, rs)
+				}
+			}
+		})
+	}
+}
+
+func TestIntegrationWatchWorkload(t *testing.T) {
+	b := testbackend.Require(t, "etcd__tip")
+	defer b.Stop()
+
+	gcfg := integrationGroup(b)
+	gcfg.ConfigClientMachineBenchmarkOptions = &dbtesterpb.ConfigClientMachineBenchmarkOptions{Namespace: "watch-workload/"}
+	cfg := &Config{lg: zap.NewNop()}
+	w, err := cfg.startWorkloadWatchers(gcfg, &dbtesterpb.ConfigClientMachineWorkload{Name: "watch", Type: "watch", Watchers: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cli := mustCreateConnEtcdv3(gcfg.DatabaseEndpoints)
+	defer cli.Close()
+	for i := 0; i < 10; i++ {
+		if _, err = cli.Put(context.Background(), fmt.Sprintf("watch-workload/%d", i), "v"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	time.Sleep(time.Second)
+	rs := w.stop()
+	if rs.watchers != 5 || rs.events != 50 || rs.errors != 0 {
+		t.Fatalf("unexpected result %+v", rs)
+	}
+}
+
+func TestIntegrationEtcdRBAC(t *testing.T) {
+	b := testbackend.Require(t, "etcd__tip")
+	defer b.Stop()
+
+	eps := []string{b.Endpoint}
+	disable, err := setupEtcdRBAC(eps)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// set up again as root, as after a run that did not finish
+	again, err := setupEtcdRBAC(eps)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = again(); err != nil {
+		t.Fatal(err)
+	}
+	if err = disable(); err != nil {
+		t.Fatal(err)
+	}
+
+	// anonymous requests are accepted again
+	cli := mustCreateConnEtcdv3(eps)
+	defer cli.Close()
+	if _, err = cli.Put(context.Background(), "rbac", "v"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestIntegrationMigration(t *testing.T) {
+	b := testbackend.Require(t, "etcd__tip")
+	defer b.Stop()
+
+	rss, err := MigrationBench(zap.NewNop(), integrationGroup(b), MigrationBenchOptions{
+		Keys:       50,
+		Clients:    5,
+		V2V3Prefix: testbackend.EtcdV2V3Prefix,
+		Directions: []string{MigrationV2ToV3, MigrationV3ToV2},
+		Timeout:    5 * time.Second,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, rs := range rss {
+		if rs.Errors != 0 || rs.Invisible != 0 || rs.Mismatches != 0 {
+			t.Fatalf("unexpected result %+v", rs)
+		}
+	}
+}
@@ -0,0 +1,158 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"fmt"
+	mrand "math/rand"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/coreos/dbtester/dbtesterpb"
+)
+
+const (
+	// zipfianS is the skew of 'zipfian' and 'latest'; the higher,
+	// the more requests go to the hottest keys.
+	zipfianS = 1.1
+
+	// 'hotspot' sends hotspotOpFraction of the requests
+	// to the first hotspotKeyFraction of the keys.
+	hotspotKeyFraction = 0.2
+	hotspotOpFraction  = 0.8
+)
+
+// keyDistributions returns the next key index in [0, n) for each
+// distribution, and is called from one goroutine.
+var keyDistributions = map[string]func(rnd *mrand.Rand, n int64) func() int64{
+	"uniform": func(rnd *mrand.Rand, n int64) func() int64 {
+		return func() int64 { return rnd.Int63n(n) }
+	},
+	// the lower the index, the hotter the key
+	"zipfian": func(rnd *mrand.Rand, n int64) func() int64 {
+		z := mrand.NewZipf(rnd, zipfianS, 1, uint64(n-1))
+		return func() int64 { return int64(z.Uint64()) }
+	},
+	// the higher the index, the more recently written, the hotter the key
+	"latest": func(rnd *mrand.Rand, n int64) func() int64 {
+		z := mrand.NewZipf(rnd, zipfianS, 1, uint64(n-1))
+		return func() int64 { return n - 1 - int64(z.Uint64()) }
+	},
+	"hotspot": func(rnd *mrand.Rand, n int64) func() int64 {
+		hot := int64(float64(n) * hotspotKeyFraction)
+		if hot < 1 {
+			hot = 1
+		}
+		return func() int64 {
+			if hot == n || rnd.Float64() < hotspotOpFraction {
+				return rnd.Int63n(hot)
+			}
+			return hot + rnd.Int63n(n-hot)
+		}
+	},
+}
+
+func validateKeyDistribution(name string) error {
+	if _, ok := keyDistributions[name]; name == "" || ok {
+		return nil
+	}
+	names := make([]string, 0, len(keyDistributions))
+	for k := range keyDistributions {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	return fmt.Errorf("unknown 'key_distribution' %q (expected %s)", name, strings.Join(names, ", "))
+}
+
+// newKeyIndexer returns the function that returns the key index of
+// the i-th request, in [0, n). It returns the keys in order if 'name'
+// is empty. The distribution is validated in 'Stress'.
+func newKeyIndexer(name string, n int64) func(i int64) int64 {
+	if name == "" || n <= 1 {
+		return func(i int64) int64 { return i % n }
+	}
+	next := keyDistributions[name](mrand.New(mrand.NewSource(time.Now().UnixNano())), n)
+	return func(int64) int64 { return next() }
+}
+
+// validateKeyOrder returns an error if 'key_order' is unknown, or is not
+// of the unique keys of a fixed number of requests to order.
+func validateKeyOrder(gcfg dbtesterpb.ConfigClientMachineAgentControl) error {
+	opts := gcfg.ConfigClientMachineBenchmarkOptions
+	switch opts.KeyOrder {
+	case "", "sequential":
+		return nil
+	case "random", "reverse":
+	default:
+		return fmt.Errorf("unknown 'key_order' %q (expected sequential, random or reverse)", opts.KeyOrder)
+	}
+	switch {
+	case opts.SameKey || opts.KeySpaceSize > 0:
+		return fmt.Errorf("'key_order' %q is of the unique keys, not of 'same_key' or 'key_space_size'", opts.KeyOrder)
+	case runDuration(gcfg) > 0 || opts.RequestNumber <= 0:
+		return fmt.Errorf("'key_order' %q requires 'request_number', not a duration", opts.KeyOrder)
+	}
+	return nil
+}
+
+// newKeyOrder returns the function that returns the index of the i-th
+// of the n unique keys to write, in [0, n). The keys are in order if
+// 'order' is empty. 'random' returns each index once, in a random order.
+func newKeyOrder(order string, n int64) func(i int64) int64 {
+	switch order {
+	case "reverse":
+		return func(i int64) int64 { return n - 1 - i }
+	case "random":
+		return newKeyPermutation(mrand.New(mrand.NewSource(time.Now().UnixNano())), n)
+	default:
+		return func(i int64) int64 { return i }
+	}
+}
+
+// newKeyPermutation returns the indexes of a random permutation of
+// [0, n), without holding all n indexes: a full-period linear congruential
+// generator modulo the power of two of at least n, whose output is mixed
+// by a bijection, skipping the indexes of n and over.
+func newKeyPermutation(rnd *mrand.Rand, n int64) func(int64) int64 {
+	if n <= 1 {
+		return func(int64) int64 { return 0 }
+	}
+	bits := uint(1)
+	for int64(1)<<bits < n {
+		bits++
+	}
+	mask := uint64(1)<<bits - 1
+	half := (bits + 1) / 2
+
+	// full period of 2^bits, with 'a' of 1 mod 4 and odd 'c'
+	a := uint64(rnd.Int63())<<2 | 1
+	c := uint64(rnd.Int63())<<1 | 1
+	mul := uint64(rnd.Int63())<<1 | 1
+	x := uint64(rnd.Int63()) & mask
+	return func(int64) int64 {
+		for {
+			x = (a*x + c) & mask
+			// xor-shifts and the odd multiplier are each one-to-one in 2^bits,
+			// and hide the short periods of the low bits of the generator
+			y := x ^ x>>half
+			y = (y * mul) & mask
+			y ^= y >> half
+			if y < uint64(n) {
+				return int64(y)
+			}
+		}
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"testing"
+
+	"github.com/coreos/dbtester/dbtesterpb"
+)
+
+func TestKeyDistributions(t *testing.T) {
+	const n, reqN = 100, 10000
+	tests := []struct {
+		name string
+		// expected minimum share of the requests to the hottest 20% of keys
+		hot     float64
+		hottest func(idx int64) bool
+	}{
+		{"", 0.19, func(idx int64) bool { return idx < 20 }},
+		{"uniform", 0.15, func(idx int64) bool { return idx < 20 }},
+		{"zipfian", 0.7, func(idx int64) bool { return idx < 20 }},
+		{"latest", 0.7, func(idx int64) bool { return idx >= 80 }},
+		{"hotspot", 0.75, func(idx int64) bool { return idx < 20 }},
+	}
+	for _, tt := range tests {
+		if err := validateKeyDistribution(tt.name); err != nil {
+			t.Fatal(err)
+		}
+		keyIndex := newKeyIndexer(tt.name, n)
+		hot := 0
+		for i := int64(0); i < reqN; i++ {
+			idx := keyIndex(i)
+			if idx < 0 || idx >= n {
+				t.Fatalf("%q: index %d out of [0, %d)", tt.name, idx, n)
+			}
+			if tt.hottest(idx) {
+				hot++
+			}
+		}
+		if share := float64(hot) / reqN; share < tt.hot {
+			t.Fatalf("%q: expected at least %.2f of requests to hot keys, got %.2f", tt.name, tt.hot, share)
+		}
+	}
+	if err := validateKeyDistribution("pareto"); err == nil {
+		t.Fatal("expected error for unknown distribution")
+	}
+}
+
+func TestKeyOrder(t *testing.T) {
+	const n = 1000
+	for _, order := range []string{"", "sequential", "random", "reverse"} {
+		keyIndex := newKeyOrder(order, n)
+		seen := make(map[int64]bool, n)
+		inOrder := true
+		for i := int64(0); i < n; i++ {
+			idx := keyIndex(i)
+			if idx < 0 || idx >= n || seen[idx] {
+				t.Fatalf("%q: index %d out of [0, %d) or repeated", order, idx, n)
+			}
+			seen[idx] = true
+			if idx != i {
+				inOrder = false
+			}
+		}
+		if inOrder != (order == "" || order == "sequential") {
+			t.Fatalf("%q: unexpected order (in order %v)", order, inOrder)
+		}
+	}
+	if idx := newKeyOrder("reverse", n)(0); idx != n-1 {
+		t.Fatalf("expected the last key first, got %d", idx)
+	}
+
+	gcfg := dbtesterpb.ConfigClientMachineAgentControl{
+		ConfigClientMachineBenchmarkOptions: &dbtesterpb.ConfigClientMachineBenchmarkOptions{RequestNumber: n, KeyOrder: "random"},
+	}
+	if err := validateKeyOrder(gcfg); err != nil {
+		t.Fatal(err)
+	}
+	gcfg.ConfigClientMachineBenchmarkOptions.KeySpaceSize = 10
+	if err := validateKeyOrder(gcfg); err == nil {
+		t.Fatal("expected error of 'key_order' with 'key_space_size'")
+	}
+	gcfg.ConfigClientMachineBenchmarkOptions.KeySpaceSize = 0
+	gcfg.ConfigClientMachineBenchmarkOptions.KeyOrder = "shuffled"
+	if err := validateKeyOrder(gcfg); err == nil {
+		t.Fatal("expected error of unknown 'key_order'")
+	}
+}
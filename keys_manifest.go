@@ -0,0 +1,94 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+)
+
+// keyManifest saves the keys of the successful writes, one per line,
+// so that later runs read or delete exactly the keys that exist.
+// Keys are written as they succeed, so a key overwritten multiple
+// times appears multiple times; duplicates are dropped on load.
+type keyManifest struct {
+	mu sync.Mutex
+	f  io.WriteCloser
+	w  *bufio.Writer
+	n  int64
+}
+
+func newKeyManifest(fpath string) (*keyManifest, error) {
+	f, err := createFile(fpath)
+	if err != nil {
+		return nil, err
+	}
+	return &keyManifest{f: f, w: bufio.NewWriter(f)}, nil
+}
+
+func (m *keyManifest) add(key string) {
+	m.mu.Lock()
+	m.w.WriteString(key)
+	m.w.WriteByte('\n')
+	m.n++
+	m.mu.Unlock()
+}
+
+// close flushes the keys, and returns the number of keys written.
+func (m *keyManifest) close() (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err := m.w.Flush(); err != nil {
+		m.f.Close()
+		return m.n, err
+	}
+	return m.n, m.f.Close()
+}
+
+// readKeyManifest returns the unique keys of the manifest, in the order written.
+func readKeyManifest(fpath string) ([]string, error) {
+	f, err := openFile(fpath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var keys []string
+	seen := make(map[string]struct{})
+	sc := bufio.NewScanner(f)
+	// keys can be larger than the default token size
+	sc.Buffer(make([]byte, 64*1024), 64*1024*1024)
+	for sc.Scan() {
+		k := strings.TrimSuffix(sc.Text(), "\r")
+		if k == "" {
+			continue
+		}
+		if _, ok := seen[k]; ok {
+			continue
+		}
+		seen[k] = struct{}{}
+		keys = append(keys, k)
+	}
+	if err = sc.Err(); err != nil {
+		return nil, err
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("no keys in %q", fpath)
+	}
+	return keys, nil
+}
@@ -0,0 +1,67 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/coreos/etcd/clientv3"
+)
+
+func TestKeyManifest(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dbtester-keys")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	fpath := filepath.Join(dir, "keys.manifest")
+
+	m, err := newKeyManifest(fpath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, req := range []request{
+		{etcdv3Op: clientv3.OpPut("a", "1")},
+		{zkOp: zkOp{key: "/b", value: []byte("1")}},
+		{consulOp: consulOp{key: "c"}},
+		{mockOp: mockOp{key: "a"}},
+		{mockOp: mockOp{key: "d"}},
+	} {
+		m.add(req.key())
+	}
+	if n, err := m.close(); err != nil || n != 5 {
+		t.Fatalf("expected 5 keys, got %d (%v)", n, err)
+	}
+
+	keys, err := readKeyManifest(fpath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exp := []string{"a", "b", "c", "d"}; !reflect.DeepEqual(keys, exp) {
+		t.Fatalf("expected %q, got %q", exp, keys)
+	}
+
+	empty := filepath.Join(dir, "empty.manifest")
+	if err = ioutil.WriteFile(empty, []byte("\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = readKeyManifest(empty); err == nil {
+		t.Fatal("expected error on empty manifest")
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"bufio"
+	"sync"
+	"time"
+
+	"github.com/coreos/dbtester/pkg/hdrhistogram"
+
+	"go.uber.org/zap"
+)
+
+// DefaultLatencyResolution is the default significant digits of the latencies.
+const DefaultLatencyResolution = 3
+
+// latencyHighest is the highest latency to record; slower requests are
+// recorded as this.
+const latencyHighest = time.Hour
+
+// latencyHistogram records the latencies in microseconds with the fixed
+// significant digits, so that the tail keeps its resolution however many
+// requests are made. Each client records into its own histogram, which
+// is merged when the client stops, so that the requests do not contend
+// on a lock. The reported summary and percentiles are of the merged
+// histogram.
+type latencyHistogram struct {
+	sigfigs int
+
+	mu sync.Mutex
+	h  *hdrhistogram.Histogram
+}
+
+func newLatencyHistogram(sigfigs int) (*latencyHistogram, error) {
+	if sigfigs == 0 {
+		sigfigs = DefaultLatencyResolution
+	}
+	h, err := hdrhistogram.New(int64(latencyHighest/time.Microsecond), sigfigs)
+	if err != nil {
+		return nil, err
+	}
+	return &latencyHistogram{sigfigs: sigfigs, h: h}, nil
+}
+
+// mustLatencyHistogram is 'newLatencyHistogram' of valid significant digits.
+func mustLatencyHistogram(sigfigs int) *latencyHistogram {
+	lh, err := newLatencyHistogram(sigfigs)
+	if err != nil {
+		panic(err)
+	}
+	return lh
+}
+
+// clientLatencies records the latencies of one client.
+type clientLatencies struct {
+	h *hdrhistogram.Histogram
+}
+
+// client returns the histogram of a client, to be merged with 'merge'.
+func (lh *latencyHistogram) client() *clientLatencies {
+	h, err := hdrhistogram.New(int64(latencyHighest/time.Microsecond), lh.sigfigs)
+	if err != nil {
+		// the same arguments as of 'newLatencyHistogram'
+		panic(err)
+	}
+	return &clientLatencies{h: h}
+}
+
+func (cl *clientLatencies) record(lat time.Duration) {
+	cl.h.Record(int64(lat / time.Microsecond))
+}
+
+// merge adds the latencies recorded by the client.
+func (lh *latencyHistogram) merge(cl *clientLatencies) {
+	lh.mu.Lock()
+	defer lh.mu.Unlock()
+	if err := lh.h.Merge(cl.h); err != nil {
+		// the same arguments as of 'newLatencyHistogram'
+		panic(err)
+	}
+}
+
+// saveLatencyHistogram writes the percentile distribution of the latencies
+// in milliseconds, in the .hgrm format.
+func (cfg *Config) saveLatencyHistogram() {
+	lh := cfg.latencies
+	if lh == nil {
+		return
+	}
+	lh.mu.Lock()
+	defer lh.mu.Unlock()
+
+	if lh.h.TotalCount() == 0 {
+		cfg.lg.Warn("no latency recorded; skipping latency histogram")
+		return
+	}
+	ms := func(pct float64) float64 { return float64(lh.h.ValueAtPercentile(pct)) / 1000 }
+	cfg.lg.Sugar().Infof("latency histogram [requests: %d | p99: %.3f ms | p99.9: %.3f ms | p99.99: %.3f ms | max: %.3f ms]",
+		lh.h.TotalCount(), ms(99), ms(99.9), ms(99.99), float64(lh.h.Max())/1000)
+
+	fpath := cfg.ConfigClientMachineInitial.ClientLatencyHgrmPath
+	if fpath == "" {
+		cfg.lg.Warn("'client_latency_hgrm_path' is not set; skipping latency histogram")
+		return
+	}
+	f, err := createFile(fpath)
+	if err != nil {
+		panic(err)
+	}
+	w := bufio.NewWriter(f)
+	if err = lh.h.WritePercentiles(w, 1000, 5); err != nil {
+		panic(err)
+	}
+	if err = w.Flush(); err != nil {
+		panic(err)
+	}
+	if err = f.Close(); err != nil {
+		panic(err)
+	}
+	cfg.lg.Info("saved latency histogram", zap.String("path", fpath))
+}
@@ -0,0 +1,98 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func TestSaveLatencyHistogram(t *testing.T) {
+	dir, err := ioutil.TempDir("", "latency-histogram")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	if _, err = newLatencyHistogram(6); err == nil {
+		t.Fatal("expected error for 6 significant digits")
+	}
+	lh, err := newLatencyHistogram(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// recorded by two clients
+	cl1, cl2 := lh.client(), lh.client()
+	for i := 1; i <= 1000; i++ {
+		cl := cl1
+		if i%2 == 0 {
+			cl = cl2
+		}
+		cl.record(time.Duration(i) * time.Millisecond)
+	}
+	// slower than the highest
+	cl2.record(2 * latencyHighest)
+	lh.merge(cl1)
+	lh.merge(cl2)
+
+	cfg := &Config{lg: zap.NewNop(), latencies: lh}
+	cfg.ConfigClientMachineInitial.ClientLatencyHgrmPath = filepath.Join(dir, "latency.hgrm")
+	cfg.saveLatencyHistogram()
+
+	bts, err := ioutil.ReadFile(cfg.ConfigClientMachineInitial.ClientLatencyHgrmPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(bts), "Total count    =         1001]") {
+		t.Fatalf("unexpected histogram\n%s", bts)
+	}
+	if !strings.Contains(string(bts), "#[Max     =  3600000.000") {
+		t.Fatalf("expected the max clamped to an hour\n%s", bts)
+	}
+}
+
+func TestRunStatsLatencyResolution(t *testing.T) {
+	tests := []struct {
+		sigfigs int
+		p50     float64
+	}{
+		// 12345 microseconds at 1 and 3 significant digits
+		{1, 0.012799},
+		{3, 0.012351},
+	}
+	for i, tt := range tests {
+		lh := mustLatencyHistogram(tt.sigfigs)
+		cl := lh.client()
+		for j := 0; j < 10; j++ {
+			cl.record(12345 * time.Microsecond)
+		}
+		cl.record(50 * time.Millisecond)
+		lh.merge(cl)
+
+		st := newRunStats(lh.h, time.Second, nil, nil)
+		if got := st.percentile(50); got != tt.p50 {
+			t.Fatalf("#%d: expected p50 %f, got %f", i, tt.p50, got)
+		}
+		if st.requests() != 11 || st.RPS != 11 || st.Fastest != 0.012345 || st.Slowest != 0.05 {
+			t.Fatalf("#%d: unexpected summary %+v", i, st)
+		}
+	}
+}
@@ -0,0 +1,249 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/coreos/dbtester/dbtesterpb"
+
+	"github.com/coreos/etcd/clientv3"
+	"github.com/gyuho/dataframe"
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+)
+
+// learnerReadInterval is the default interval to read from every member.
+const learnerReadInterval = 100 * time.Millisecond
+
+// memberReads are the serializable reads from one member.
+type memberReads struct {
+	endpoint string
+	learner  bool
+	cli      *clientv3.Client
+
+	lats   []float64
+	errN   int
+	revLag []int64
+}
+
+// learnerReads periodically reads from the learner and voting members,
+// to compare the latency and staleness of serializable reads. Staleness
+// is the number of revisions behind the linearizable read of the leader.
+type learnerReads struct {
+	lg       *zap.Logger
+	key      string
+	interval time.Duration
+	// leader serves the linearizable reads of the latest revision
+	leader *clientv3.Client
+
+	stopc, donec chan struct{}
+
+	mu      sync.Mutex
+	members []*memberReads
+}
+
+func newLearnerReads(lg *zap.Logger, gcfg dbtesterpb.ConfigClientMachineAgentControl) (*learnerReads, error) {
+	switch gcfg.DatabaseID {
+	case "etcd__other", "etcd__tip", "etcd__v3_2", "etcd__v3_3":
+	default:
+		return nil, fmt.Errorf("'learner_reads' is not supported for %q", gcfg.DatabaseID)
+	}
+	lcfg := gcfg.ConfigClientMachineBenchmarkOptions.ConfigClientMachineLearnerReads
+	if len(lcfg.Endpoints) == 0 {
+		return nil, fmt.Errorf("'learner_reads' requires 'endpoints' of the learners")
+	}
+
+	lr := &learnerReads{
+		lg:       lg,
+		key:      namespaced(gcfg, "dbtester-learner-read"),
+		interval: learnerReadInterval,
+		leader:   mustCreateConnEtcdv3(gcfg.DatabaseEndpoints),
+		stopc:    make(chan struct{}),
+		donec:    make(chan struct{}),
+	}
+	if ms := lcfg.IntervalMilliseconds; ms > 0 {
+		lr.interval = time.Duration(ms) * time.Millisecond
+	}
+	for _, ep := range gcfg.DatabaseEndpoints {
+		lr.members = append(lr.members, &memberReads{endpoint: ep, cli: mustCreateConnEtcdv3([]string{ep})})
+	}
+	for _, ep := range lcfg.Endpoints {
+		lr.members = append(lr.members, &memberReads{endpoint: ep, learner: true, cli: mustCreateConnEtcdv3([]string{ep})})
+	}
+	go lr.run()
+	lg.Sugar().Infof("reading from %d learners and %d voting members every %v", len(lcfg.Endpoints), len(gcfg.DatabaseEndpoints), lr.interval)
+	return lr, nil
+}
+
+func (lr *learnerReads) run() {
+	defer close(lr.donec)
+	ticker := time.NewTicker(lr.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			lr.read()
+		case <-lr.stopc:
+			return
+		}
+	}
+}
+
+// read gets the latest revision from the leader, and then reads
+// from every member at once with serializable reads.
+func (lr *learnerReads) read() {
+	ctx, cancel := context.WithTimeout(context.Background(), lr.interval*10)
+	defer cancel()
+	resp, err := lr.leader.Get(ctx, lr.key)
+	if err != nil {
+		lr.lg.Warn("failed to read latest revision", zap.Error(err))
+		return
+	}
+	latest := resp.Header.Revision
+
+	type result struct {
+		took time.Duration
+		rev  int64
+		err  error
+	}
+	rs := make([]result, len(lr.members))
+	var wg sync.WaitGroup
+	wg.Add(len(lr.members))
+	for i, m := range lr.members {
+		go func(i int, m *memberReads) {
+			defer wg.Done()
+			now := time.Now()
+			resp, err := m.cli.Get(ctx, lr.key, clientv3.WithSerializable())
+			rs[i] = result{took: time.Since(now), err: err}
+			if err == nil {
+				rs[i].rev = resp.Header.Revision
+			}
+		}(i, m)
+	}
+	wg.Wait()
+
+	lr.mu.Lock()
+	defer lr.mu.Unlock()
+	for i, m := range lr.members {
+		if rs[i].err != nil {
+			m.errN++
+			continue
+		}
+		m.lats = append(m.lats, rs[i].took.Seconds())
+		lag := latest - rs[i].rev
+		if lag < 0 {
+			// the member applied more writes after the leader read
+			lag = 0
+		}
+		m.revLag = append(m.revLag, lag)
+	}
+}
+
+func (lr *learnerReads) stop() {
+	close(lr.stopc)
+	<-lr.donec
+	lr.leader.Close()
+	for _, m := range lr.members {
+		m.cli.Close()
+	}
+}
+
+// memberReadSummary summarizes the reads from a member.
+type memberReadSummary struct {
+	endpoint string
+	role     string
+	readN    int
+	errN     int
+
+	avgLatency, p99Latency float64
+	avgRevLag              float64
+	maxRevLag              int64
+}
+
+func summarizeMemberReads(m *memberReads) memberReadSummary {
+	s := memberReadSummary{endpoint: m.endpoint, role: "voter", readN: len(m.lats), errN: m.errN}
+	if m.learner {
+		s.role = "learner"
+	}
+	if len(m.lats) == 0 {
+		return s
+	}
+	for _, v := range m.lats {
+		s.avgLatency += v
+	}
+	s.avgLatency /= float64(len(m.lats))
+	s.p99Latency = percentileOf(m.lats, 99)
+	for _, v := range m.revLag {
+		s.avgRevLag += float64(v)
+		if v > s.maxRevLag {
+			s.maxRevLag = v
+		}
+	}
+	s.avgRevLag /= float64(len(m.revLag))
+	return s
+}
+
+func (cfg *Config) saveLearnerReads() {
+	lr := cfg.learnerReads
+	if lr == nil {
+		return
+	}
+	lr.mu.Lock()
+	defer lr.mu.Unlock()
+
+	ss := make([]memberReadSummary, len(lr.members))
+	for i, m := range lr.members {
+		ss[i] = summarizeMemberReads(m)
+		cfg.lg.Sugar().Infof("%s %q reads [reads: %d | errors: %d | average latency: %.4f ms | p99 latency: %.4f ms | average revision lag: %.2f | max revision lag: %d]",
+			ss[i].role, ss[i].endpoint, ss[i].readN, ss[i].errN, 1000*ss[i].avgLatency, 1000*ss[i].p99Latency, ss[i].avgRevLag, ss[i].maxRevLag)
+	}
+
+	fpath := cfg.ConfigClientMachineInitial.ClientLearnerReadsPath
+	if fpath == "" {
+		cfg.lg.Warn("'client_learner_reads_path' is not set; skipping learner reads")
+		return
+	}
+	c1 := dataframe.NewColumn("ENDPOINT")
+	c2 := dataframe.NewColumn("ROLE")
+	c3 := dataframe.NewColumn("READS")
+	c4 := dataframe.NewColumn("ERRORS")
+	c5 := dataframe.NewColumn("AVERAGE-LATENCY-MS")
+	c6 := dataframe.NewColumn("P99-LATENCY-MS")
+	c7 := dataframe.NewColumn("AVERAGE-REVISION-LAG")
+	c8 := dataframe.NewColumn("MAX-REVISION-LAG")
+	for _, s := range ss {
+		c1.PushBack(dataframe.NewStringValue(s.endpoint))
+		c2.PushBack(dataframe.NewStringValue(s.role))
+		c3.PushBack(dataframe.NewStringValue(s.readN))
+		c4.PushBack(dataframe.NewStringValue(s.errN))
+		c5.PushBack(dataframe.NewStringValue(fmt.Sprintf("%4.4f", 1000*s.avgLatency)))
+		c6.PushBack(dataframe.NewStringValue(fmt.Sprintf("%4.4f", 1000*s.p99Latency)))
+		c7.PushBack(dataframe.NewStringValue(fmt.Sprintf("%4.4f", s.avgRevLag)))
+		c8.PushBack(dataframe.NewStringValue(s.maxRevLag))
+	}
+	fr := dataframe.New()
+	for _, col := range []dataframe.Column{c1, c2, c3, c4, c5, c6, c7, c8} {
+		if err := fr.AddColumn(col); err != nil {
+			panic(err)
+		}
+	}
+	if err := saveCSV(fr, fpath); err != nil {
+		panic(err)
+	}
+	cfg.lg.Info("saved learner reads", zap.String("path", fpath))
+}
@@ -0,0 +1,40 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import "testing"
+
+func TestSummarizeMemberReads(t *testing.T) {
+	s := summarizeMemberReads(&memberReads{
+		endpoint: "learner:2379",
+		learner:  true,
+		lats:     []float64{0.001, 0.003},
+		revLag:   []int64{0, 4},
+		errN:     1,
+	})
+	if s.role != "learner" || s.readN != 2 || s.errN != 1 {
+		t.Fatalf("unexpected summary %+v", s)
+	}
+	if s.avgLatency != 0.002 || s.p99Latency != 0.003 {
+		t.Fatalf("unexpected latency %+v", s)
+	}
+	if s.avgRevLag != 2 || s.maxRevLag != 4 {
+		t.Fatalf("unexpected revision lag %+v", s)
+	}
+
+	if s = summarizeMemberReads(&memberReads{endpoint: "voter:2379", errN: 3}); s.role != "voter" || s.readN != 0 || s.avgRevLag != 0 {
+		t.Fatalf("unexpected summary with no reads %+v", s)
+	}
+}
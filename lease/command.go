@@ -0,0 +1,144 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lease
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/coreos/dbtester"
+	"github.com/coreos/dbtester/control"
+	"github.com/coreos/dbtester/dbtesterpb"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+// Command implements 'lease' command.
+var Command = &cobra.Command{
+	Use:   "lease",
+	Short: "Benchmarks the lease life cycles of ephemeral keys.",
+	Long: `Creates leases with a key attached to each, as the ephemeral keys of
+service discovery, and measures the latency and the throughput of each
+request of the life cycle: etcd v3 lease grant, keepalive and attach,
+Zookeeper ephemeral node creation, and Consul session creation, renewal
+and acquire of a key with the session.`,
+	RunE: commandFunc,
+}
+
+var (
+	databaseID string
+	configPath string
+	endpoints  []string
+	certFile   string
+	keyFile    string
+	caFile     string
+	opts       dbtester.LeaseBenchOptions
+)
+
+func init() {
+	ids := dbtesterpb.GetAllDatabaseIDs()
+	Command.PersistentFlags().StringVar(&databaseID, "database-id", "", "Database ID to benchmark: "+strings.Join(ids, ", ")+". Empty to benchmark all databases of '--config'.")
+	Command.PersistentFlags().StringVarP(&configPath, "config", "c", "", "YAML configuration file path, to benchmark its databases.")
+	Command.PersistentFlags().StringSliceVar(&endpoints, "endpoints", nil, "Database endpoints to benchmark, instead of the endpoints of '--config'.")
+	Command.PersistentFlags().StringVar(&certFile, "cert", "", "Client certificate to connect to the database with TLS (etcd, Zookeeper 'secureClientPort' and Consul HTTPS). Requires '--key'.")
+	Command.PersistentFlags().StringVar(&keyFile, "key", "", "Private key of '--cert'.")
+	Command.PersistentFlags().StringVar(&caFile, "cacert", "", "CA certificate to verify the database servers with, to connect with TLS. Empty to verify with the system roots when '--cert' is set.")
+	Command.PersistentFlags().IntVar(&opts.Leases, "leases", 10000, "Number of leases to create, each with its key.")
+	Command.PersistentFlags().IntVar(&opts.Clients, "clients", 100, "Number of clients that create the leases at the same time.")
+	Command.PersistentFlags().IntVar(&opts.Connections, "connections", 10, "Number of client connections that the clients share.")
+	Command.PersistentFlags().DurationVar(&opts.TTL, "ttl", 10*time.Second, "TTL of the leases, after which the keys are deleted. Consul sessions have TTLs of at least 10 seconds; Zookeeper nodes live as long as the connection.")
+}
+
+func commandFunc(cmd *cobra.Command, args []string) error {
+	if err := dbtester.SetClientTLS(certFile, keyFile, caFile); err != nil {
+		return err
+	}
+
+	var gcfgs []dbtesterpb.ConfigClientMachineAgentControl
+	switch {
+	case len(endpoints) > 0:
+		if !dbtesterpb.IsValidDatabaseID(databaseID) {
+			return fmt.Errorf("database id %q is unknown", databaseID)
+		}
+		gcfgs = append(gcfgs, dbtesterpb.ConfigClientMachineAgentControl{DatabaseID: databaseID, DatabaseEndpoints: endpoints})
+
+	case configPath != "":
+		cfg, err := dbtester.ReadConfig(configPath, false)
+		if err != nil {
+			return err
+		}
+		for id, gcfg := range cfg.DatabaseIDToConfigClientMachineAgentControl {
+			if databaseID == "" || databaseID == id {
+				gcfgs = append(gcfgs, gcfg)
+			}
+		}
+		if len(gcfgs) == 0 {
+			return fmt.Errorf("%q is not found in %q", databaseID, configPath)
+		}
+		sort.Slice(gcfgs, func(i, j int) bool { return gcfgs[i].DatabaseID < gcfgs[j].DatabaseID })
+
+	default:
+		return fmt.Errorf("either '--endpoints' or '--config' is required")
+	}
+
+	var rss []dbtester.LeaseBenchResult
+	steps := make([]control.Step, len(gcfgs))
+	for i := range gcfgs {
+		gcfg := gcfgs[i]
+		steps[i] = control.Step{
+			Name:       gcfg.DatabaseID,
+			DatabaseID: gcfg.DatabaseID,
+			Run: func(*dbtester.Config) error {
+				lg.Info("benchmarking leases", zap.String("database", gcfg.DatabaseID), zap.Strings("endpoints", gcfg.DatabaseEndpoints))
+				rs, err := dbtester.LeaseBench(lg, gcfg, opts)
+				if err != nil {
+					return fmt.Errorf("failed to benchmark %q (%v)", gcfg.DatabaseID, err)
+				}
+				rss = append(rss, rs)
+				return nil
+			},
+		}
+	}
+	if err := control.RunSteps(control.Sequence{}, steps); err != nil {
+		return err
+	}
+
+	tw := tablewriter.NewWriter(os.Stdout)
+	tw.SetHeader([]string{"DATABASE", "LEASES", "CLIENTS", "ERRORS", "LEASES-PER-SECOND", "PHASE", "REQUESTS", "REQUESTS-PER-SECOND", "P50-MS", "P99-MS"})
+	for _, rs := range rss {
+		for _, ph := range rs.Phases {
+			tw.Append([]string{
+				rs.DatabaseID,
+				fmt.Sprintf("%d", rs.Leases),
+				fmt.Sprintf("%d", rs.Clients),
+				fmt.Sprintf("%d", rs.Errors),
+				fmt.Sprintf("%.1f", rs.LeasesPerSecond),
+				ph.Phase,
+				fmt.Sprintf("%d", ph.Count),
+				fmt.Sprintf("%.1f", ph.RequestsPerSecond),
+				fmt.Sprintf("%.3f", ph.P50Ms),
+				fmt.Sprintf("%.3f", ph.P99Ms),
+			})
+		}
+	}
+	tw.SetAutoFormatHeaders(false)
+	tw.Render()
+	return nil
+}
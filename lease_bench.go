@@ -0,0 +1,289 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/coreos/dbtester/dbtesterpb"
+
+	"github.com/coreos/etcd/clientv3"
+	consulapi "github.com/hashicorp/consul/api"
+	"github.com/samuel/go-zookeeper/zk"
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+)
+
+// leaseBenchPrefix is the prefix of the keys that the lease benchmark
+// attaches to the leases.
+const leaseBenchPrefix = "dbtester-lease-"
+
+// LeaseBenchOptions configures the lease benchmark.
+type LeaseBenchOptions struct {
+	// Leases is the number of leases to create, each with its key.
+	Leases int
+	// Clients is the number of clients that create the leases at the same time.
+	Clients int
+	// Connections is the number of client connections that the
+	// clients share, in round-robin order.
+	Connections int
+	// TTL is the TTL of the leases. Consul sessions have TTLs of
+	// at least 10 seconds. Zookeeper nodes live as long as the
+	// session of the connection.
+	TTL time.Duration
+}
+
+// LeasePhaseResult is the latency and the throughput of
+// one phase of the lease life cycle.
+type LeasePhaseResult struct {
+	Phase string
+	// Count is the number of successful requests of the phase.
+	Count int
+	// RequestsPerSecond is of the phase, over the whole benchmark.
+	RequestsPerSecond float64
+
+	P50Ms float64
+	P99Ms float64
+}
+
+// LeaseBenchResult is the lease life cycles of a database.
+type LeaseBenchResult struct {
+	DatabaseID string
+	Leases     int
+	Clients    int
+
+	// Errors is the number of lease life cycles that failed.
+	Errors int
+	// LeasesPerSecond is the complete life cycles per second.
+	LeasesPerSecond float64
+
+	Phases []LeasePhaseResult
+}
+
+// leaseBackend creates the leases and their keys.
+type leaseBackend struct {
+	// phases are the requests of each life cycle, in order
+	phases []string
+	// cycle runs the life cycle of the lease with the sequence number,
+	// on the connection, and calls 'record' with the latency of each phase.
+	cycle func(ctx context.Context, conn int, seq int64, record func(phase int, took time.Duration)) error
+	close func()
+}
+
+// LeaseBench measures the lease life cycles, as of the ephemeral keys of
+// service discovery: etcd v3 lease grant, keepalive and attach to a key,
+// Zookeeper ephemeral node creation, and Consul session creation, renewal
+// and acquire of a key with the session.
+func LeaseBench(lg *zap.Logger, gcfg dbtesterpb.ConfigClientMachineAgentControl, opts LeaseBenchOptions) (LeaseBenchResult, error) {
+	rs := LeaseBenchResult{DatabaseID: gcfg.DatabaseID, Leases: opts.Leases, Clients: opts.Clients}
+	if opts.Leases < 1 || opts.Clients < 1 || opts.Connections < 1 {
+		return rs, fmt.Errorf("leases, clients and connections must be positive (got %d, %d, %d)", opts.Leases, opts.Clients, opts.Connections)
+	}
+	if opts.Connections > opts.Clients {
+		opts.Connections = opts.Clients
+	}
+	if len(gcfg.DatabaseEndpoints) == 0 {
+		return rs, fmt.Errorf("no endpoint to benchmark %q", gcfg.DatabaseID)
+	}
+	if bopts := gcfg.ConfigClientMachineBenchmarkOptions; bopts != nil {
+		if bopts.EtcdUsername != "" {
+			setEtcdAuth(bopts.EtcdUsername, bopts.EtcdPassword)
+		}
+		if bopts.ConsulToken != "" {
+			consulToken = bopts.ConsulToken
+		}
+	}
+
+	var b *leaseBackend
+	switch gcfg.DatabaseID {
+	case "etcd__other", "etcd__tip", "etcd__v3_2", "etcd__v3_3":
+		b = newLeaseBackendEtcdv3(gcfg.DatabaseEndpoints, opts)
+	case "zookeeper__r3_5_3_beta", "zetcd__beta":
+		b = newLeaseBackendZk(gcfg.DatabaseEndpoints, opts)
+	case "consul__v1_0_2":
+		b = newLeaseBackendConsul(gcfg.DatabaseEndpoints, opts)
+	default:
+		return rs, fmt.Errorf("%q does not support leases", gcfg.DatabaseID)
+	}
+	defer b.close()
+
+	lg.Info("benchmarking leases", zap.String("database", gcfg.DatabaseID), zap.Strings("phases", b.phases), zap.Int("leases", opts.Leases))
+	return runLeaseBench(lg, b, opts, rs), nil
+}
+
+func runLeaseBench(lg *zap.Logger, b *leaseBackend, opts LeaseBenchOptions, rs LeaseBenchResult) LeaseBenchResult {
+	var (
+		mu   sync.Mutex
+		lats = make([][]float64, len(b.phases))
+		errN int
+	)
+	seqc := make(chan int64, opts.Clients)
+	go func() {
+		for seq := 0; seq < opts.Leases; seq++ {
+			seqc <- int64(seq)
+		}
+		close(seqc)
+	}()
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	wg.Add(opts.Clients)
+	for i := 0; i < opts.Clients; i++ {
+		go func(i int) {
+			defer wg.Done()
+			for seq := range seqc {
+				err := b.cycle(context.Background(), i%opts.Connections, seq, func(phase int, took time.Duration) {
+					mu.Lock()
+					lats[phase] = append(lats[phase], float64(took)/float64(time.Millisecond))
+					mu.Unlock()
+				})
+				if err != nil {
+					mu.Lock()
+					errN++
+					mu.Unlock()
+					lg.Warn("lease failed", zap.Int64("lease", seq), zap.Error(err))
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+	took := time.Since(start).Seconds()
+
+	rs.Errors = errN
+	rs.LeasesPerSecond = float64(opts.Leases-errN) / took
+	for i, phase := range b.phases {
+		rs.Phases = append(rs.Phases, LeasePhaseResult{
+			Phase:             phase,
+			Count:             len(lats[i]),
+			RequestsPerSecond: float64(len(lats[i])) / took,
+			P50Ms:             percentileOf(lats[i], 50),
+			P99Ms:             percentileOf(lats[i], 99),
+		})
+	}
+	return rs
+}
+
+// timeLeasePhase calls 'f', and records its latency as of the phase if it succeeds.
+func timeLeasePhase(phase int, record func(int, time.Duration), f func() error) error {
+	now := time.Now()
+	if err := f(); err != nil {
+		return err
+	}
+	record(phase, time.Since(now))
+	return nil
+}
+
+func newLeaseBackendEtcdv3(endpoints []string, opts LeaseBenchOptions) *leaseBackend {
+	clis := make([]*clientv3.Client, opts.Connections)
+	for i := range clis {
+		clis[i] = mustCreateConnEtcdv3(endpoints)
+	}
+	ttl := int64(opts.TTL / time.Second)
+	if ttl < 1 {
+		ttl = 1
+	}
+	return &leaseBackend{
+		phases: []string{"grant", "keepalive", "attach"},
+		cycle: func(ctx context.Context, conn int, seq int64, record func(int, time.Duration)) error {
+			cli := clis[conn]
+			var id clientv3.LeaseID
+			if err := timeLeasePhase(0, record, func() error {
+				resp, err := cli.Grant(ctx, ttl)
+				if err == nil {
+					id = resp.ID
+				}
+				return err
+			}); err != nil {
+				return err
+			}
+			if err := timeLeasePhase(1, record, func() error {
+				_, err := cli.KeepAliveOnce(ctx, id)
+				return err
+			}); err != nil {
+				return err
+			}
+			return timeLeasePhase(2, record, func() error {
+				_, err := cli.Put(ctx, fmt.Sprintf("%s%d", leaseBenchPrefix, seq), "", clientv3.WithLease(id))
+				return err
+			})
+		},
+		close: func() {
+			// the leases expire with their keys
+			for _, cli := range clis {
+				cli.Close()
+			}
+		},
+	}
+}
+
+func newLeaseBackendZk(endpoints []string, opts LeaseBenchOptions) *leaseBackend {
+	conns := mustCreateConnsZk(endpoints, int64(opts.Connections))
+	return &leaseBackend{
+		phases: []string{"create-ephemeral"},
+		cycle: func(ctx context.Context, conn int, seq int64, record func(int, time.Duration)) error {
+			return timeLeasePhase(0, record, func() error {
+				_, err := conns[conn].Create(fmt.Sprintf("/%s%d", leaseBenchPrefix, seq), nil, zk.FlagEphemeral, zkCreateACL)
+				return err
+			})
+		},
+		close: func() {
+			// the ephemeral nodes are deleted with the sessions
+			for _, c := range conns {
+				c.Close()
+			}
+		},
+	}
+}
+
+func newLeaseBackendConsul(endpoints []string, opts LeaseBenchOptions) *leaseBackend {
+	clis := mustCreateClientsConsul(endpoints, int64(opts.Connections))
+	ttl := opts.TTL
+	if ttl < 10*time.Second {
+		// the minimum TTL of the sessions
+		ttl = 10 * time.Second
+	}
+	return &leaseBackend{
+		phases: []string{"session-create", "session-renew", "acquire"},
+		cycle: func(ctx context.Context, conn int, seq int64, record func(int, time.Duration)) error {
+			cli := clis[conn]
+			wopts := (&consulapi.WriteOptions{}).WithContext(ctx)
+			var id string
+			if err := timeLeasePhase(0, record, func() error {
+				var err error
+				id, _, err = cli.Session().Create(&consulapi.SessionEntry{TTL: ttl.String(), Behavior: consulapi.SessionBehaviorDelete}, wopts)
+				return err
+			}); err != nil {
+				return err
+			}
+			if err := timeLeasePhase(1, record, func() error {
+				_, _, err := cli.Session().Renew(id, wopts)
+				return err
+			}); err != nil {
+				return err
+			}
+			return timeLeasePhase(2, record, func() error {
+				ok, _, err := cli.KV().Acquire(&consulapi.KVPair{Key: fmt.Sprintf("%s%d", leaseBenchPrefix, seq), Session: id}, wopts)
+				if err == nil && !ok {
+					err = fmt.Errorf("failed to acquire with session %q", id)
+				}
+				return err
+			})
+		},
+		// the keys are deleted with the sessions when they expire
+		close: func() {},
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/coreos/dbtester/dbtesterpb"
+
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+)
+
+func TestRunLeaseBench(t *testing.T) {
+	b := &leaseBackend{
+		phases: []string{"grant", "attach"},
+		cycle: func(ctx context.Context, conn int, seq int64, record func(int, time.Duration)) error {
+			if err := timeLeasePhase(0, record, func() error { return nil }); err != nil {
+				return err
+			}
+			// every tenth attach fails
+			return timeLeasePhase(1, record, func() error {
+				if seq%10 == 0 {
+					return errors.New("attach failed")
+				}
+				return nil
+			})
+		},
+		close: func() {},
+	}
+	opts := LeaseBenchOptions{Leases: 50, Clients: 4, Connections: 2}
+	rs := runLeaseBench(zap.NewNop(), b, opts, LeaseBenchResult{})
+	if rs.Errors != 5 {
+		t.Fatalf("expected 5 errors, got %d", rs.Errors)
+	}
+	if len(rs.Phases) != 2 || rs.Phases[0].Count != 50 || rs.Phases[1].Count != 45 {
+		t.Fatalf("expected 50 grants and 45 attaches, got %+v", rs.Phases)
+	}
+	if rs.LeasesPerSecond <= 0 || rs.Phases[1].RequestsPerSecond != rs.LeasesPerSecond {
+		t.Fatalf("unexpected throughput %+v", rs)
+	}
+}
+
+func TestLeaseBenchUnsupported(t *testing.T) {
+	gcfg := dbtesterpb.ConfigClientMachineAgentControl{DatabaseID: "redis__v4_0", DatabaseEndpoints: []string{"127.0.0.1:6379"}}
+	if _, err := LeaseBench(zap.NewNop(), gcfg, LeaseBenchOptions{Leases: 1, Clients: 1, Connections: 1}); err == nil {
+		t.Fatal("expected error of Redis")
+	}
+}
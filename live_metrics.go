@@ -0,0 +1,186 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/coreos/dbtester/pkg/hdrhistogram"
+
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+)
+
+// liveWindow is the window of the latency quantiles of the live metrics.
+const liveWindow = 10 * time.Second
+
+// liveQuantiles are the latency quantiles of the live metrics.
+var liveQuantiles = []float64{0.5, 0.9, 0.99, 0.999}
+
+// liveMetrics publishes the progress of the stress as it runs, in the
+// Prometheus text format, so that long runs can be watched on a
+// dashboard. The counters are of all the stages of the run.
+type liveMetrics struct {
+	labels string
+
+	// sent, errors and inflight are updated atomically
+	sent     int64
+	errors   int64
+	inflight int64
+
+	mu sync.Mutex
+	// completed and totalLatency are of all the completed requests
+	completed    int64
+	totalLatency time.Duration
+	// cur records the latencies of the current window, and prev
+	// is of the last complete window, of which are the quantiles
+	cur, prev *hdrhistogram.Histogram
+	rotated   time.Time
+}
+
+func newLiveMetrics(databaseID, databaseTag string) (*liveMetrics, error) {
+	m := &liveMetrics{
+		labels: fmt.Sprintf(`database_id="%s",database_tag="%s"`,
+			escapeOpenMetricsLabel(databaseID), escapeOpenMetricsLabel(databaseTag)),
+		rotated: time.Now(),
+	}
+	var err error
+	if m.cur, err = hdrhistogram.New(int64(latencyHighest/time.Microsecond), DefaultLatencyResolution); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// begin counts a request sent.
+func (m *liveMetrics) begin() {
+	atomic.AddInt64(&m.sent, 1)
+	atomic.AddInt64(&m.inflight, 1)
+}
+
+// end counts the response of a request, or its cancellation.
+func (m *liveMetrics) end() {
+	atomic.AddInt64(&m.inflight, -1)
+}
+
+// record records the latency of a completed request.
+func (m *liveMetrics) record(lat time.Duration, err error) {
+	if err != nil {
+		atomic.AddInt64(&m.errors, 1)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.rotate(time.Now())
+	m.completed++
+	m.totalLatency += lat
+	m.cur.Record(int64(lat / time.Microsecond))
+}
+
+// rotate starts a new window if the current one is over.
+// It must be called with 'mu' held.
+func (m *liveMetrics) rotate(now time.Time) {
+	if now.Sub(m.rotated) < liveWindow {
+		return
+	}
+	fresh, err := hdrhistogram.New(int64(latencyHighest/time.Microsecond), DefaultLatencyResolution)
+	if err != nil {
+		// the same arguments as of 'newLiveMetrics'
+		panic(err)
+	}
+	if now.Sub(m.rotated) < 2*liveWindow {
+		m.prev = m.cur
+	} else {
+		// no requests completed in the last window
+		m.prev = nil
+	}
+	m.cur, m.rotated = fresh, now
+}
+
+// format formats the metrics in the Prometheus text format.
+func (m *liveMetrics) format() []byte {
+	var buf bytes.Buffer
+
+	counters := []struct {
+		name, typ, help string
+		value           int64
+	}{
+		{"dbtester_requests_sent_total", "counter", "Total number of requests sent.", atomic.LoadInt64(&m.sent)},
+		{"dbtester_requests_errors_total", "counter", "Total number of failed requests.", atomic.LoadInt64(&m.errors)},
+		{"dbtester_requests_in_flight", "gauge", "Number of requests waiting for their responses.", atomic.LoadInt64(&m.inflight)},
+	}
+	for _, c := range counters {
+		fmt.Fprintf(&buf, "# HELP %s %s\n", c.name, c.help)
+		fmt.Fprintf(&buf, "# TYPE %s %s\n", c.name, c.typ)
+		fmt.Fprintf(&buf, "%s{%s} %d\n", c.name, m.labels, c.value)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.rotate(time.Now())
+
+	const name = "dbtester_request_duration_seconds"
+	fmt.Fprintf(&buf, "# HELP %s Latency of the completed requests, with the quantiles of the last %v.\n", name, liveWindow)
+	fmt.Fprintf(&buf, "# TYPE %s summary\n", name)
+	if m.prev != nil && m.prev.TotalCount() > 0 {
+		for _, q := range liveQuantiles {
+			v := time.Duration(m.prev.ValueAtPercentile(100*q)) * time.Microsecond
+			fmt.Fprintf(&buf, "%s{%s,quantile=\"%g\"} %g\n", name, m.labels, q, v.Seconds())
+		}
+	}
+	fmt.Fprintf(&buf, "%s_sum{%s} %g\n", name, m.labels, m.totalLatency.Seconds())
+	fmt.Fprintf(&buf, "%s_count{%s} %d\n", name, m.labels, m.completed)
+	return buf.Bytes()
+}
+
+func (m *liveMetrics) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	w.Write(m.format())
+}
+
+// ServeMetrics serves the live metrics of the stress of the database
+// at '/metrics' of the address, until the returned function is called.
+// The metrics are of the requests of this process, not of its workers.
+func (cfg *Config) ServeMetrics(addr, databaseID string) (stop func(), err error) {
+	gcfg, ok := cfg.DatabaseIDToConfigClientMachineAgentControl[databaseID]
+	if !ok {
+		return nil, fmt.Errorf("%q is not found", databaseID)
+	}
+	m, err := newLiveMetrics(databaseID, gcfg.DatabaseTag)
+	if err != nil {
+		return nil, err
+	}
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", m)
+	srv := &http.Server{Handler: mux}
+	go srv.Serve(ln)
+	cfg.live = m
+	cfg.lg.Info("serving live metrics", zap.String("address", ln.Addr().String()))
+
+	return func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		srv.Shutdown(ctx)
+		cancel()
+		cfg.live = nil
+	}, nil
+}
@@ -0,0 +1,102 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"errors"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/coreos/dbtester/dbtesterpb"
+
+	"go.uber.org/zap"
+)
+
+func TestLiveMetrics(t *testing.T) {
+	m, err := newLiveMetrics("mock", "mock-tag")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 1; i <= 3; i++ {
+		m.begin()
+	}
+	m.end()
+	m.record(time.Millisecond, nil)
+	m.end()
+	m.record(3*time.Millisecond, errors.New("fail"))
+
+	// the quantiles are of the last complete window
+	m.rotated = m.rotated.Add(-liveWindow)
+	out := string(m.format())
+	for _, exp := range []string{
+		`dbtester_requests_sent_total{database_id="mock",database_tag="mock-tag"} 3`,
+		`dbtester_requests_errors_total{database_id="mock",database_tag="mock-tag"} 1`,
+		`dbtester_requests_in_flight{database_id="mock",database_tag="mock-tag"} 1`,
+		`dbtester_request_duration_seconds{database_id="mock",database_tag="mock-tag",quantile="0.5"} 0.001`,
+		`dbtester_request_duration_seconds{database_id="mock",database_tag="mock-tag",quantile="0.999"} 0.003`,
+		`dbtester_request_duration_seconds_sum{database_id="mock",database_tag="mock-tag"} 0.004`,
+		`dbtester_request_duration_seconds_count{database_id="mock",database_tag="mock-tag"} 2`,
+	} {
+		if !strings.Contains(out, exp+"\n") {
+			t.Fatalf("expected %q in\n%s", exp, out)
+		}
+	}
+
+	// no requests completed in the last window
+	m.rotated = m.rotated.Add(-2 * liveWindow)
+	if out = string(m.format()); strings.Contains(out, `quantile="`) {
+		t.Fatalf("expected no quantiles, got\n%s", out)
+	}
+}
+
+func TestServeMetrics(t *testing.T) {
+	cfg := &Config{
+		lg: zap.NewNop(),
+		DatabaseIDToConfigClientMachineAgentControl: map[string]dbtesterpb.ConfigClientMachineAgentControl{
+			"mock": {DatabaseID: "mock"},
+		},
+	}
+	if _, err := cfg.ServeMetrics("127.0.0.1:0", "etcd__tip"); err == nil {
+		t.Fatal("expected error of unknown database ID")
+	}
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := ln.Addr().String()
+	ln.Close()
+	stop, err := cfg.ServeMetrics(addr, "mock")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stop()
+
+	resp, err := http.Get("http://" + addr + "/metrics")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	bts, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(bts), "# TYPE dbtester_requests_sent_total counter") {
+		t.Fatalf("unexpected metrics %q", bts)
+	}
+}
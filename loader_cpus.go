@@ -0,0 +1,104 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"bufio"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// cgroupCPUPeriod is the period of the CPU quota that the loader
+// writes to its cgroup, in microseconds.
+const cgroupCPUPeriod = 100000
+
+// LimitLoaderCPUs caps the CPUs that the loader uses to 'n', with
+// GOMAXPROCS, and also with the CPU quota of the cgroup of the process
+// if 'cgroup' is true, so that the goroutines of the runtime, of the
+// garbage collector, and of the cgo calls are capped as well. Writing
+// the quota requires the permission to the cgroup, such as of root or
+// of a delegated cgroup.
+func (cfg *Config) LimitLoaderCPUs(n int, cgroup bool) error {
+	if n < 1 {
+		return fmt.Errorf("loader CPUs must be positive (got %d)", n)
+	}
+	prev := runtime.GOMAXPROCS(n)
+	cfg.MaxLoaderCPUs = n
+	cfg.lg.Info("limited loader CPUs", zap.Int("gomaxprocs", n), zap.Int("previous-gomaxprocs", prev))
+	if !cgroup {
+		return nil
+	}
+
+	fpath, quota, err := cgroupCPUQuota("/proc/self/cgroup", "/sys/fs/cgroup", n)
+	if err != nil {
+		return err
+	}
+	if err = ioutil.WriteFile(fpath, []byte(quota), 0644); err != nil {
+		return fmt.Errorf("failed to limit the cgroup of the loader (%v)", err)
+	}
+	cfg.loaderCPUQuota = quota
+	cfg.lg.Info("limited loader cgroup", zap.String("path", fpath), zap.String("quota", quota))
+	return nil
+}
+
+// cgroupCPUQuota returns the file of the CPU quota of the cgroup of
+// the process, with cgroups v2 or v1 mounted at 'root', and the quota
+// of 'n' CPUs to write to the file.
+func cgroupCPUQuota(procCgroupPath, root string, n int) (fpath, quota string, err error) {
+	f, err := os.Open(procCgroupPath)
+	if err != nil {
+		return "", "", err
+	}
+	defer f.Close()
+
+	var v2 string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		// e.g. "4:cpu,cpuacct:/user.slice" or "0::/user.slice"
+		fields := strings.SplitN(scanner.Text(), ":", 3)
+		if len(fields) != 3 {
+			continue
+		}
+		if fields[1] == "" {
+			v2 = fields[2]
+			continue
+		}
+		for _, ctrl := range strings.Split(fields[1], ",") {
+			if ctrl != "cpu" {
+				continue
+			}
+			dir := filepath.Join(root, fields[1], fields[2])
+			if _, err = os.Stat(dir); os.IsNotExist(err) {
+				// e.g. mounted as 'cpu' and linked as 'cpu,cpuacct'
+				dir = filepath.Join(root, "cpu", fields[2])
+			}
+			return filepath.Join(dir, "cpu.cfs_quota_us"), strconv.Itoa(n * cgroupCPUPeriod), nil
+		}
+	}
+	if err = scanner.Err(); err != nil {
+		return "", "", err
+	}
+	if v2 != "" {
+		return filepath.Join(root, v2, "cpu.max"), fmt.Sprintf("%d %d", n*cgroupCPUPeriod, cgroupCPUPeriod), nil
+	}
+	return "", "", fmt.Errorf("no cpu cgroup in %q", procCgroupPath)
+}
@@ -0,0 +1,82 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func TestCgroupCPUQuota(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cgroup")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	if err = os.MkdirAll(filepath.Join(dir, "cpu,cpuacct", "user.slice"), 0777); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		cgroup string
+		path   string
+		quota  string
+	}{
+		{"12:memory:/user.slice\n4:cpu,cpuacct:/user.slice\n", filepath.Join(dir, "cpu,cpuacct", "user.slice", "cpu.cfs_quota_us"), "200000"},
+		{"4:cpuacct,cpu:/loader\n", filepath.Join(dir, "cpu", "loader", "cpu.cfs_quota_us"), "200000"},
+		{"0::/user.slice/loader.scope\n", filepath.Join(dir, "user.slice", "loader.scope", "cpu.max"), "200000 100000"},
+	}
+	for i, tt := range tests {
+		fpath := filepath.Join(dir, "cgroup")
+		if err = ioutil.WriteFile(fpath, []byte(tt.cgroup), 0644); err != nil {
+			t.Fatal(err)
+		}
+		path, quota, err := cgroupCPUQuota(fpath, dir, 2)
+		if err != nil {
+			t.Fatalf("#%d: %v", i, err)
+		}
+		if path != tt.path || quota != tt.quota {
+			t.Fatalf("#%d: expected %q of %q, got %q of %q", i, tt.quota, tt.path, quota, path)
+		}
+	}
+
+	fpath := filepath.Join(dir, "cgroup")
+	if err = ioutil.WriteFile(fpath, []byte("12:memory:/user.slice\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err = cgroupCPUQuota(fpath, dir, 2); err == nil {
+		t.Fatal("expected error of no cpu cgroup")
+	}
+}
+
+func TestLimitLoaderCPUs(t *testing.T) {
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(0))
+
+	cfg := &Config{lg: zap.NewNop()}
+	if err := cfg.LimitLoaderCPUs(0, false); err == nil {
+		t.Fatal("expected error of 0 CPUs")
+	}
+	if err := cfg.LimitLoaderCPUs(1, false); err != nil {
+		t.Fatal(err)
+	}
+	if runtime.GOMAXPROCS(0) != 1 || cfg.MaxLoaderCPUs != 1 {
+		t.Fatalf("expected 1 CPU, got GOMAXPROCS %d", runtime.GOMAXPROCS(0))
+	}
+}
@@ -0,0 +1,293 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/coreos/dbtester/dbtesterpb"
+
+	"github.com/coreos/etcd/clientv3"
+	"github.com/gyuho/dataframe"
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+)
+
+// maintenanceTimeout is the timeout of each compaction and defragmentation,
+// which block the member for as long as they take.
+const maintenanceTimeout = 5 * time.Minute
+
+// maintenanceClient is the etcd client to run the maintenance with,
+// as implemented by '*clientv3.Client'.
+type maintenanceClient interface {
+	Status(ctx context.Context, endpoint string) (*clientv3.StatusResponse, error)
+	Compact(ctx context.Context, rev int64, opts ...clientv3.CompactOption) (*clientv3.CompactResponse, error)
+	Defragment(ctx context.Context, endpoint string) (*clientv3.DefragmentResponse, error)
+}
+
+// maintenanceRecord is an executed compaction or defragmentation.
+type maintenanceRecord struct {
+	since time.Duration
+	time  time.Time
+	// event is 'compact' or 'defrag'
+	event string
+	// endpoint is of the defragmented member, empty for compactions
+	endpoint string
+	revision int64
+	took     time.Duration
+	// dbSizeBefore and dbSizeAfter are of the defragmented
+	// member, or -1 if unknown
+	dbSizeBefore int64
+	dbSizeAfter  int64
+	err          string
+}
+
+// maintenance compacts the etcd history at every interval while the
+// benchmark runs, and defragments the members after each compaction,
+// as of the maintenance policy of production clusters.
+type maintenance struct {
+	lg        *zap.Logger
+	cli       maintenanceClient
+	endpoints []string
+	interval  time.Duration
+	defrag    bool
+	spikes    *spikeRecovery
+
+	start time.Time
+	// compactRev is the revision at the previous interval,
+	// to compact at, to retain the revisions of the last interval
+	compactRev int64
+
+	mu      sync.Mutex
+	records []maintenanceRecord
+}
+
+// validateMaintenance returns an error if 'auto_compact_seconds'
+// or 'auto_defrag' is set for other than etcd.
+func validateMaintenance(gcfg dbtesterpb.ConfigClientMachineAgentControl) error {
+	opts := gcfg.ConfigClientMachineBenchmarkOptions
+	switch {
+	case opts.AutoCompactSeconds < 0:
+		return fmt.Errorf("'auto_compact_seconds' must not be negative (got %d)", opts.AutoCompactSeconds)
+	case opts.AutoDefrag && opts.AutoCompactSeconds == 0:
+		return fmt.Errorf("'auto_defrag' requires 'auto_compact_seconds'")
+	case opts.AutoCompactSeconds == 0:
+		return nil
+	}
+	switch gcfg.DatabaseID {
+	case "etcd__other", "etcd__tip", "etcd__v3_2", "etcd__v3_3":
+	default:
+		return fmt.Errorf("'auto_compact_seconds' is only for etcd (got %q)", gcfg.DatabaseID)
+	}
+	if len(gcfg.DatabaseEndpoints) == 0 {
+		return fmt.Errorf("'auto_compact_seconds' requires 'database_endpoints'")
+	}
+	return nil
+}
+
+func (m *maintenance) record(rec maintenanceRecord, err error) {
+	rec.since = rec.time.Sub(m.start)
+	fields := []zap.Field{zap.String("event", rec.event), zap.Int64("revision", rec.revision), zap.Duration("took", rec.took)}
+	if rec.endpoint != "" {
+		fields = append(fields, zap.String("endpoint", rec.endpoint), zap.Int64("db-size-before", rec.dbSizeBefore), zap.Int64("db-size-after", rec.dbSizeAfter))
+	}
+	if err != nil {
+		rec.err = err.Error()
+		m.lg.Warn("maintenance failed", append(fields, zap.Error(err))...)
+	} else {
+		m.lg.Info("maintenance", fields...)
+		m.spikes.mark(fmt.Sprintf("%s at revision %d", rec.event, rec.revision), rec.time)
+	}
+	m.mu.Lock()
+	m.records = append(m.records, rec)
+	m.mu.Unlock()
+}
+
+// status returns the status of the member, or of any member if empty.
+func (m *maintenance) status(endpoint string) (*clientv3.StatusResponse, error) {
+	eps := m.endpoints
+	if endpoint != "" {
+		eps = []string{endpoint}
+	}
+	var err error
+	for _, ep := range eps {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		var resp *clientv3.StatusResponse
+		resp, err = m.cli.Status(ctx, ep)
+		cancel()
+		if err == nil {
+			return resp, nil
+		}
+	}
+	return nil, err
+}
+
+func (m *maintenance) dbSize(endpoint string) int64 {
+	resp, err := m.status(endpoint)
+	if err != nil {
+		return -1
+	}
+	return resp.DbSize
+}
+
+// maintain compacts at the revision of the previous interval,
+// and defragments the members one at a time.
+func (m *maintenance) maintain() {
+	resp, err := m.status("")
+	if err != nil {
+		m.record(maintenanceRecord{time: time.Now(), event: "compact", revision: m.compactRev, dbSizeBefore: -1, dbSizeAfter: -1}, err)
+		return
+	}
+	rev := resp.Header.Revision
+
+	compactRev := m.compactRev
+	m.compactRev = rev
+	if compactRev == 0 {
+		return
+	}
+
+	start := time.Now()
+	ctx, cancel := context.WithTimeout(context.Background(), maintenanceTimeout)
+	_, err = m.cli.Compact(ctx, compactRev, clientv3.WithCompactPhysical())
+	cancel()
+	m.record(maintenanceRecord{time: start, event: "compact", revision: compactRev, took: time.Since(start), dbSizeBefore: -1, dbSizeAfter: -1}, err)
+	if err != nil || !m.defrag {
+		return
+	}
+
+	for _, ep := range m.endpoints {
+		rec := maintenanceRecord{event: "defrag", endpoint: ep, revision: compactRev, dbSizeBefore: m.dbSize(ep)}
+		rec.time = time.Now()
+		ctx, cancel := context.WithTimeout(context.Background(), maintenanceTimeout)
+		_, err = m.cli.Defragment(ctx, ep)
+		cancel()
+		rec.took = time.Since(rec.time)
+		rec.dbSizeAfter = m.dbSize(ep)
+		m.record(rec, err)
+	}
+}
+
+// run maintains at every interval, until 'stopc' is closed.
+func (m *maintenance) run(stopc <-chan struct{}) {
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			m.maintain()
+		case <-stopc:
+			return
+		}
+	}
+}
+
+// startMaintenance starts the compactions of 'auto_compact_seconds'
+// from now, and returns the function to stop them after the benchmark.
+func (cfg *Config) startMaintenance(gcfg dbtesterpb.ConfigClientMachineAgentControl) (stop func()) {
+	cli := mustCreateConnEtcdv3(gcfg.DatabaseEndpoints)
+	m := &maintenance{
+		lg:        cfg.lg,
+		cli:       cli,
+		endpoints: gcfg.DatabaseEndpoints,
+		interval:  time.Duration(gcfg.ConfigClientMachineBenchmarkOptions.AutoCompactSeconds) * time.Second,
+		defrag:    gcfg.ConfigClientMachineBenchmarkOptions.AutoDefrag,
+		spikes:    cfg.spikes,
+		start:     time.Now(),
+	}
+	if resp, err := m.status(""); err == nil {
+		m.compactRev = resp.Header.Revision
+	}
+	cfg.maintenance = m
+
+	stopc, donec := make(chan struct{}), make(chan struct{})
+	go func() {
+		m.run(stopc)
+		close(donec)
+	}()
+	return func() {
+		close(stopc)
+		<-donec
+		cli.Close()
+	}
+}
+
+// counts returns the numbers of the compactions
+// and the defragmentations done.
+func (m *maintenance) counts() (compactions, defrags int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, rec := range m.records {
+		if rec.err != "" {
+			continue
+		}
+		switch rec.event {
+		case "compact":
+			compactions++
+		case "defrag":
+			defrags++
+		}
+	}
+	return compactions, defrags
+}
+
+func (cfg *Config) saveMaintenance() {
+	m := cfg.maintenance
+	if m == nil {
+		return
+	}
+	compactions, defrags := m.counts()
+	cfg.lg.Sugar().Infof("maintenance [compactions: %d | defragmentations: %d]", compactions, defrags)
+
+	fpath := cfg.ConfigClientMachineInitial.ClientMaintenancePath
+	if fpath == "" {
+		cfg.lg.Warn("'client_maintenance_path' is not set; skipping maintenance events")
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	c1 := dataframe.NewColumn("SINCE-START-MS")
+	c2 := dataframe.NewColumn("UNIX-NANOSECOND")
+	c3 := dataframe.NewColumn("EVENT")
+	c4 := dataframe.NewColumn("ENDPOINT")
+	c5 := dataframe.NewColumn("REVISION")
+	c6 := dataframe.NewColumn("TOOK-MS")
+	c7 := dataframe.NewColumn("DB-SIZE-BEFORE-BYTES")
+	c8 := dataframe.NewColumn("DB-SIZE-AFTER-BYTES")
+	c9 := dataframe.NewColumn("ERROR")
+	for _, rec := range m.records {
+		c1.PushBack(dataframe.NewStringValue(fmt.Sprintf("%4.4f", toMillisecond(rec.since))))
+		c2.PushBack(dataframe.NewStringValue(rec.time.UnixNano()))
+		c3.PushBack(dataframe.NewStringValue(rec.event))
+		c4.PushBack(dataframe.NewStringValue(rec.endpoint))
+		c5.PushBack(dataframe.NewStringValue(rec.revision))
+		c6.PushBack(dataframe.NewStringValue(fmt.Sprintf("%4.4f", toMillisecond(rec.took))))
+		c7.PushBack(dataframe.NewStringValue(rec.dbSizeBefore))
+		c8.PushBack(dataframe.NewStringValue(rec.dbSizeAfter))
+		c9.PushBack(dataframe.NewStringValue(rec.err))
+	}
+	fr := dataframe.New()
+	for _, col := range []dataframe.Column{c1, c2, c3, c4, c5, c6, c7, c8, c9} {
+		if err := fr.AddColumn(col); err != nil {
+			panic(err)
+		}
+	}
+	if err := saveCSV(fr, fpath); err != nil {
+		panic(err)
+	}
+	cfg.lg.Info("saved maintenance events", zap.String("path", fpath))
+}
@@ -0,0 +1,140 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/coreos/dbtester/dbtesterpb"
+
+	"github.com/coreos/etcd/clientv3"
+	"github.com/coreos/etcd/etcdserver/etcdserverpb"
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+)
+
+type fakeMaintenanceClient struct {
+	rev        int64
+	dbSize     map[string]int64
+	compacted  []int64
+	defragged  []string
+	compactErr error
+}
+
+func (c *fakeMaintenanceClient) Status(ctx context.Context, ep string) (*clientv3.StatusResponse, error) {
+	return &clientv3.StatusResponse{Header: &etcdserverpb.ResponseHeader{Revision: c.rev}, DbSize: c.dbSize[ep]}, nil
+}
+
+func (c *fakeMaintenanceClient) Compact(ctx context.Context, rev int64, opts ...clientv3.CompactOption) (*clientv3.CompactResponse, error) {
+	if c.compactErr != nil {
+		return nil, c.compactErr
+	}
+	c.compacted = append(c.compacted, rev)
+	return &clientv3.CompactResponse{}, nil
+}
+
+func (c *fakeMaintenanceClient) Defragment(ctx context.Context, ep string) (*clientv3.DefragmentResponse, error) {
+	c.defragged = append(c.defragged, ep)
+	c.dbSize[ep] /= 2
+	return &clientv3.DefragmentResponse{}, nil
+}
+
+func TestMaintenance(t *testing.T) {
+	cli := &fakeMaintenanceClient{rev: 10, dbSize: map[string]int64{"a:2379": 100, "b:2379": 200}}
+	m := &maintenance{
+		lg:        zap.NewNop(),
+		cli:       cli,
+		endpoints: []string{"a:2379", "b:2379"},
+		defrag:    true,
+		start:     time.Now(),
+	}
+
+	// the first interval only records the revision to compact at
+	m.maintain()
+	if len(cli.compacted) != 0 || m.compactRev != 10 {
+		t.Fatalf("unexpected compactions %v at %d", cli.compacted, m.compactRev)
+	}
+
+	cli.rev = 25
+	m.maintain()
+	if !reflect.DeepEqual(cli.compacted, []int64{10}) {
+		t.Fatalf("expected compaction at the revision of the previous interval, got %v", cli.compacted)
+	}
+	if !reflect.DeepEqual(cli.defragged, []string{"a:2379", "b:2379"}) {
+		t.Fatalf("unexpected defragmentations %v", cli.defragged)
+	}
+	if len(m.records) != 3 {
+		t.Fatalf("expected 3 records, got %+v", m.records)
+	}
+	if rec := m.records[2]; rec.event != "defrag" || rec.endpoint != "b:2379" || rec.dbSizeBefore != 200 || rec.dbSizeAfter != 100 {
+		t.Fatalf("unexpected defragmentation %+v", rec)
+	}
+
+	cli.compactErr = fmt.Errorf("mvcc: required revision has been compacted")
+	cli.rev = 40
+	m.maintain()
+	if compactions, defrags := m.counts(); compactions != 1 || defrags != 2 {
+		t.Fatalf("unexpected counts %d, %d", compactions, defrags)
+	}
+	if rec := m.records[len(m.records)-1]; rec.err == "" || rec.revision != 25 {
+		t.Fatalf("expected failed compaction at 25, got %+v", rec)
+	}
+
+	dir, err := ioutil.TempDir("", "maintenance")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	cfg := &Config{lg: zap.NewNop(), maintenance: m}
+	cfg.ConfigClientMachineInitial.ClientMaintenancePath = filepath.Join(dir, "maintenance.csv")
+	cfg.saveMaintenance()
+	bts, err := ioutil.ReadFile(cfg.ConfigClientMachineInitial.ClientMaintenancePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(bts)), "\n")
+	if len(lines) != 5 || !strings.HasPrefix(lines[0], "SINCE-START-MS,UNIX-NANOSECOND,EVENT,ENDPOINT,REVISION") {
+		t.Fatalf("unexpected CSV %q", bts)
+	}
+}
+
+func TestValidateMaintenance(t *testing.T) {
+	gcfg := dbtesterpb.ConfigClientMachineAgentControl{
+		DatabaseID:                          "etcd__tip",
+		DatabaseEndpoints:                   []string{"a:2379"},
+		ConfigClientMachineBenchmarkOptions: &dbtesterpb.ConfigClientMachineBenchmarkOptions{AutoCompactSeconds: 300, AutoDefrag: true},
+	}
+	if err := validateMaintenance(gcfg); err != nil {
+		t.Fatal(err)
+	}
+
+	gcfg.DatabaseID = "consul__v1_0_2"
+	if err := validateMaintenance(gcfg); err == nil {
+		t.Fatal("expected error of other than etcd")
+	}
+
+	gcfg.DatabaseID = "etcd__tip"
+	gcfg.ConfigClientMachineBenchmarkOptions.AutoCompactSeconds = 0
+	if err := validateMaintenance(gcfg); err == nil {
+		t.Fatal("expected error of 'auto_defrag' with no compaction")
+	}
+}
@@ -0,0 +1,252 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package matrix runs the control tests over a grid of parameters.
+package matrix
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/coreos/dbtester"
+	"github.com/coreos/dbtester/control"
+
+	"github.com/coreos/etcd/pkg/netutil"
+	"github.com/gyuho/linux-inspect/df"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+// Command implements 'matrix' command.
+var Command = &cobra.Command{
+	Use:   "matrix",
+	Short: "Runs tests over all combinations of parameters.",
+	RunE:  commandFunc,
+}
+
+var specPath string
+var diskDevice string
+var networkInterface string
+var force bool
+
+func init() {
+	dn, err := df.GetDevice("/")
+	if err != nil {
+		lg.Warn("cannot get disk device mounted at '/'", zap.Error(err))
+	}
+	nm, err := netutil.GetDefaultInterfaces()
+	if err != nil {
+		lg.Warn("cannot detect default network interface", zap.Error(err))
+	}
+	var nt string
+	for k := range nm {
+		nt = k
+		break
+	}
+
+	Command.PersistentFlags().StringVarP(&specPath, "spec", "s", "", "YAML matrix spec file path.")
+	Command.PersistentFlags().StringVar(&diskDevice, "disk-device", dn, "Disk device to collect disk statistics metrics from.")
+	Command.PersistentFlags().StringVar(&networkInterface, "network-interface", nt, "Network interface to record in/outgoing packets.")
+	Command.PersistentFlags().BoolVar(&force, "force", false, "Run even if key or value sizes exceed the request size limits of the database.")
+}
+
+// MatrixColumns defines the columns of the combined CSV.
+var MatrixColumns = []string{
+	"DATABASE-ID",
+	"CLIENT-NUMBER",
+	"VALUE-SIZE-BYTES",
+	"STALE-READ",
+	"TOTAL-SECONDS",
+	"REQUESTS-PER-SECOND",
+	"FASTEST-LATENCY-MS",
+	"AVERAGE-LATENCY-MS",
+	"SLOWEST-LATENCY-MS",
+	"STDDEV-LATENCY-MS",
+	"P50-LATENCY-MS",
+	"P90-LATENCY-MS",
+	"P99-LATENCY-MS",
+	"ERROR-COUNT",
+	"EMPTY-RESPONSE-COUNT",
+}
+
+func commandFunc(cmd *cobra.Command, args []string) error {
+	sp, err := ReadSpec(specPath)
+	if err != nil {
+		return err
+	}
+
+	cs := sp.Combinations()
+	lg.Info("starting matrix", zap.String("spec", specPath), zap.Int("combinations", len(cs)))
+
+	// fills the combinations from the base configuration, to name them,
+	// and to fail on unknown database IDs before the first run
+	steps := make([]control.Step, len(cs))
+	for i := range cs {
+		base, err := dbtester.ReadConfig(sp.ConfigPath, false)
+		if err != nil {
+			return err
+		}
+		c, err := sp.apply(base, cs[i])
+		if err != nil {
+			return err
+		}
+		steps[i] = control.Step{
+			Name:       c.Name(),
+			DatabaseID: c.DatabaseID,
+			Apply: func(cfg *dbtester.Config) error {
+				_, err := sp.apply(cfg, c)
+				return err
+			},
+			Row: func(cfg *dbtester.Config) ([]string, error) {
+				return readResult(cfg, c)
+			},
+		}
+	}
+	sq := control.Sequence{
+		ConfigPath:       sp.ConfigPath,
+		Cooldown:         sp.Cooldown,
+		Force:            force,
+		DiskDevice:       diskDevice,
+		NetworkInterface: networkInterface,
+		Columns:          MatrixColumns,
+		OutputPathCSV:    sp.OutputPathCSV,
+	}
+	if err = control.RunSteps(sq, steps); err != nil {
+		return err
+	}
+
+	lg.Info("all combinations done!", zap.String("path", sp.OutputPathCSV))
+	return nil
+}
+
+// apply overwrites the base configuration with the combination.
+// Zero values in the combination are filled from the base configuration,
+// as is the stale read when 'stale_read_list' is empty.
+func (sp *Spec) apply(cfg *dbtester.Config, c Combination) (Combination, error) {
+	gcfg, ok := cfg.DatabaseIDToConfigClientMachineAgentControl[c.DatabaseID]
+	if !ok {
+		return c, fmt.Errorf("%q is not found in the base configuration", c.DatabaseID)
+	}
+
+	if c.ClientNumber == 0 {
+		c.ClientNumber = gcfg.ConfigClientMachineBenchmarkOptions.ClientNumber
+	}
+	if c.ValueSizeBytes == 0 {
+		c.ValueSizeBytes = gcfg.ConfigClientMachineBenchmarkOptions.ValueSizeBytes
+	}
+	if len(sp.StaleReadList) == 0 {
+		c.StaleRead = gcfg.ConfigClientMachineBenchmarkOptions.StaleRead
+	}
+
+	gcfg.ConfigClientMachineBenchmarkOptions.ConnectionClientNumbers = nil
+	gcfg.ConfigClientMachineBenchmarkOptions.ClientNumber = c.ClientNumber
+	gcfg.ConfigClientMachineBenchmarkOptions.ConnectionNumber = c.ClientNumber
+	gcfg.ConfigClientMachineBenchmarkOptions.ValueSizeBytes = c.ValueSizeBytes
+	gcfg.ConfigClientMachineBenchmarkOptions.StaleRead = c.StaleRead
+	cfg.DatabaseIDToConfigClientMachineAgentControl[c.DatabaseID] = gcfg
+
+	// upload each run to its own directory
+	if cfg.ConfigClientMachineInitial.GoogleCloudStorageSubDirectory != "" {
+		cfg.ConfigClientMachineInitial.GoogleCloudStorageSubDirectory = filepath.Join(cfg.ConfigClientMachineInitial.GoogleCloudStorageSubDirectory, c.Name())
+	}
+	return c, nil
+}
+
+// ReadResult reads the latency distribution summary and percentiles of
+// the last run, by the columns of 'MatrixColumns' of the results, with
+// the counts of all errors added up in 'ERROR-COUNT'.
+func ReadResult(cfg *dbtester.Config) (map[string]string, error) {
+	kv := make(map[string]string)
+
+	summary, err := readCSV(cfg.ConfigClientMachineInitial.ClientLatencyDistributionSummaryPath)
+	if err != nil {
+		return nil, err
+	}
+	var errCnt int64
+	for _, row := range summary {
+		if len(row) < 2 {
+			continue
+		}
+		if strings.HasPrefix(row[0], "ERROR") {
+			n, err := strconv.ParseInt(row[1], 10, 64)
+			if err != nil {
+				return nil, err
+			}
+			errCnt += n
+			continue
+		}
+		kv[row[0]] = row[1]
+	}
+	kv["ERROR-COUNT"] = fmt.Sprintf("%d", errCnt)
+
+	pctls, err := readCSV(cfg.ConfigClientMachineInitial.ClientLatencyDistributionPercentilePath)
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range pctls {
+		if len(row) < 2 {
+			continue
+		}
+		switch row[0] {
+		case "p50":
+			kv["P50-LATENCY-MS"] = row[1]
+		case "p90":
+			kv["P90-LATENCY-MS"] = row[1]
+		case "p99":
+			kv["P99-LATENCY-MS"] = row[1]
+		}
+	}
+	return kv, nil
+}
+
+// readResult returns the row of the last run for the combined CSV.
+func readResult(cfg *dbtester.Config, c Combination) ([]string, error) {
+	kv, err := ReadResult(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return []string{
+		c.DatabaseID,
+		fmt.Sprintf("%d", c.ClientNumber),
+		fmt.Sprintf("%d", c.ValueSizeBytes),
+		fmt.Sprintf("%v", c.StaleRead),
+		kv["TOTAL-SECONDS"],
+		kv["REQUESTS-PER-SECOND"],
+		kv["FASTEST-LATENCY-MS"],
+		kv["AVERAGE-LATENCY-MS"],
+		kv["SLOWEST-LATENCY-MS"],
+		kv["STDDEV-LATENCY-MS"],
+		kv["P50-LATENCY-MS"],
+		kv["P90-LATENCY-MS"],
+		kv["P99-LATENCY-MS"],
+		kv["ERROR-COUNT"],
+		kv["EMPTY-RESPONSE-COUNT"],
+	}, nil
+}
+
+func readCSV(fpath string) ([][]string, error) {
+	f, err := os.Open(fpath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	rd := csv.NewReader(f)
+	rd.FieldsPerRecord = -1
+	return rd.ReadAll()
+}
@@ -0,0 +1,128 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matrix
+
+import (
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	"github.com/coreos/dbtester/dbtesterpb"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Spec defines the grid of parameters to run.
+// Every combination of the lists is run against
+// the base control configuration.
+type Spec struct {
+	// ConfigPath is the base control configuration file path.
+	ConfigPath string `yaml:"config_path"`
+
+	DatabaseIDList     []string      `yaml:"database_id_list"`
+	ClientNumberList   []int64       `yaml:"client_number_list"`
+	ValueSizeBytesList []int64       `yaml:"value_size_bytes_list"`
+	StaleReadList      []bool        `yaml:"stale_read_list"`
+	CooldownString     string        `yaml:"cooldown"`
+	OutputPathCSV      string        `yaml:"output_path_csv"`
+	Cooldown           time.Duration `yaml:"-"`
+}
+
+// Combination is one point in the parameter grid.
+type Combination struct {
+	DatabaseID     string
+	ClientNumber   int64
+	ValueSizeBytes int64
+	StaleRead      bool
+}
+
+// Name returns the name of the combination,
+// used as the sub-directory of each run.
+func (c Combination) Name() string {
+	consistency := "linearizable"
+	if c.StaleRead {
+		consistency = "stale"
+	}
+	return fmt.Sprintf("%s-%d-clients-%d-bytes-%s", c.DatabaseID, c.ClientNumber, c.ValueSizeBytes, consistency)
+}
+
+// ReadSpec reads the matrix spec file.
+func ReadSpec(fpath string) (*Spec, error) {
+	bts, err := ioutil.ReadFile(fpath)
+	if err != nil {
+		return nil, err
+	}
+	sp := Spec{}
+	if err = yaml.Unmarshal(bts, &sp); err != nil {
+		return nil, err
+	}
+
+	if sp.ConfigPath == "" {
+		return nil, fmt.Errorf("'config_path' is not given in %q", fpath)
+	}
+	if len(sp.DatabaseIDList) == 0 {
+		return nil, fmt.Errorf("'database_id_list' is empty in %q", fpath)
+	}
+	for _, id := range sp.DatabaseIDList {
+		if !dbtesterpb.IsValidDatabaseID(id) {
+			return nil, fmt.Errorf("databaseID %q is unknown", id)
+		}
+	}
+	if sp.CooldownString != "" {
+		sp.Cooldown, err = time.ParseDuration(sp.CooldownString)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if sp.OutputPathCSV == "" {
+		sp.OutputPathCSV = "matrix.csv"
+	}
+	return &sp, nil
+}
+
+// Combinations expands the grid into all combinations.
+// Empty lists are left to the values in the base configuration
+// (zero value in the returned combinations).
+func (sp *Spec) Combinations() []Combination {
+	clients := sp.ClientNumberList
+	if len(clients) == 0 {
+		clients = []int64{0}
+	}
+	sizes := sp.ValueSizeBytesList
+	if len(sizes) == 0 {
+		sizes = []int64{0}
+	}
+	staleReads := sp.StaleReadList
+	if len(staleReads) == 0 {
+		staleReads = []bool{false}
+	}
+
+	var cs []Combination
+	for _, id := range sp.DatabaseIDList {
+		for _, cn := range clients {
+			for _, vs := range sizes {
+				for _, sr := range staleReads {
+					cs = append(cs, Combination{
+						DatabaseID:     id,
+						ClientNumber:   cn,
+						ValueSizeBytes: vs,
+						StaleRead:      sr,
+					})
+				}
+			}
+		}
+	}
+	return cs
+}
@@ -0,0 +1,91 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matrix
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/coreos/dbtester"
+	"github.com/coreos/dbtester/dbtesterpb"
+)
+
+func TestCombinations(t *testing.T) {
+	sp := &Spec{
+		DatabaseIDList:     []string{"etcd__tip", "zookeeper__r3_5_3_beta"},
+		ClientNumberList:   []int64{1, 100},
+		ValueSizeBytesList: []int64{256},
+		StaleReadList:      []bool{false, true},
+	}
+	cs := sp.Combinations()
+	if len(cs) != 8 {
+		t.Fatalf("expected 8 combinations, got %d", len(cs))
+	}
+	expected := Combination{DatabaseID: "etcd__tip", ClientNumber: 100, ValueSizeBytes: 256, StaleRead: true}
+	if !reflect.DeepEqual(cs[3], expected) {
+		t.Fatalf("expected %+v, got %+v", expected, cs[3])
+	}
+	if cs[3].Name() != "etcd__tip-100-clients-256-bytes-stale" {
+		t.Fatalf("unexpected name %q", cs[3].Name())
+	}
+
+	sp = &Spec{DatabaseIDList: []string{"consul__v1_0_2"}}
+	cs = sp.Combinations()
+	expected = Combination{DatabaseID: "consul__v1_0_2"}
+	if len(cs) != 1 || !reflect.DeepEqual(cs[0], expected) {
+		t.Fatalf("expected [%+v], got %+v", expected, cs)
+	}
+}
+
+func TestApplyStaleRead(t *testing.T) {
+	baseConfig := func() *dbtester.Config {
+		return &dbtester.Config{
+			DatabaseIDToConfigClientMachineAgentControl: map[string]dbtesterpb.ConfigClientMachineAgentControl{
+				"etcd__tip": {
+					DatabaseID: "etcd__tip",
+					ConfigClientMachineBenchmarkOptions: &dbtesterpb.ConfigClientMachineBenchmarkOptions{
+						ClientNumber:   10,
+						ValueSizeBytes: 256,
+						StaleRead:      true,
+					},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		staleReadList []bool
+		expected      bool
+	}{
+		// empty list keeps the base configuration
+		{nil, true},
+		{[]bool{false}, false},
+		{[]bool{true}, true},
+	}
+	for i, tt := range tests {
+		sp := &Spec{DatabaseIDList: []string{"etcd__tip"}, StaleReadList: tt.staleReadList}
+		cfg := baseConfig()
+		c, err := sp.apply(cfg, sp.Combinations()[0])
+		if err != nil {
+			t.Fatalf("#%d: %v", i, err)
+		}
+		if c.StaleRead != tt.expected {
+			t.Fatalf("#%d: combination stale read expected %v, got %v", i, tt.expected, c.StaleRead)
+		}
+		if sr := cfg.DatabaseIDToConfigClientMachineAgentControl["etcd__tip"].ConfigClientMachineBenchmarkOptions.StaleRead; sr != tt.expected {
+			t.Fatalf("#%d: configuration stale read expected %v, got %v", i, tt.expected, sr)
+		}
+	}
+}
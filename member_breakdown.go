@@ -0,0 +1,176 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gyuho/dataframe"
+	"go.uber.org/zap"
+)
+
+type memberStats struct {
+	requests     int64
+	totalLatency time.Duration
+	slowest      time.Duration
+	// raftTerm and revision are the latest of the responses
+	raftTerm uint64
+	revision int64
+}
+
+type termChange struct {
+	at     time.Time
+	before uint64
+	after  uint64
+	// member served the first request of the new term
+	member   string
+	revision int64
+}
+
+// memberBreakdown attributes each request to the member that served it,
+// and detects the raft term changes, from the response headers that
+// the request handlers record in the traces. It needs no polling of
+// the members, so the leader changes are seen as the clients see them.
+type memberBreakdown struct {
+	lg *zap.Logger
+
+	mu      sync.Mutex
+	members map[string]*memberStats
+	term    uint64
+	changes []termChange
+}
+
+func newMemberBreakdown(lg *zap.Logger) *memberBreakdown {
+	return &memberBreakdown{lg: lg, members: make(map[string]*memberStats)}
+}
+
+// record records the request of the trace, if its member is known.
+func (m *memberBreakdown) record(end time.Time, tr *requestTrace, took time.Duration) {
+	if tr.member == "" {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ms, ok := m.members[tr.member]
+	if !ok {
+		ms = &memberStats{}
+		m.members[tr.member] = ms
+	}
+	ms.requests++
+	ms.totalLatency += took
+	if ms.slowest < took {
+		ms.slowest = took
+	}
+	if ms.raftTerm < tr.raftTerm {
+		ms.raftTerm = tr.raftTerm
+	}
+	if ms.revision < tr.revision {
+		ms.revision = tr.revision
+	}
+
+	// concurrent requests may finish out of order,
+	// so only the increases of the term are changes
+	if tr.raftTerm <= m.term {
+		return
+	}
+	if m.term != 0 {
+		m.changes = append(m.changes, termChange{at: end, before: m.term, after: tr.raftTerm, member: tr.member, revision: tr.revision})
+		m.lg.Warn("raft term changed", zap.Uint64("before", m.term), zap.Uint64("after", tr.raftTerm), zap.String("member", tr.member))
+	}
+	m.term = tr.raftTerm
+}
+
+func (cfg *Config) saveMemberBreakdown() {
+	m := cfg.members
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	names := make([]string, 0, len(m.members))
+	var total int64
+	for name, ms := range m.members {
+		names = append(names, name)
+		total += ms.requests
+	}
+	if total == 0 {
+		// e.g. of databases whose responses do not tell the member
+		cfg.lg.Warn("no members recorded in the responses")
+	}
+	sort.Strings(names)
+	cfg.lg.Sugar().Infof("member breakdown [members: %d | requests: %d | term changes: %d]", len(names), total, len(m.changes))
+
+	if fpath := cfg.ConfigClientMachineInitial.ClientMemberBreakdownPath; fpath != "" {
+		c1 := dataframe.NewColumn("MEMBER")
+		c2 := dataframe.NewColumn("REQUESTS")
+		c3 := dataframe.NewColumn("REQUESTS-PERCENT")
+		c4 := dataframe.NewColumn("AVERAGE-LATENCY-MS")
+		c5 := dataframe.NewColumn("SLOWEST-LATENCY-MS")
+		c6 := dataframe.NewColumn("RAFT-TERM")
+		c7 := dataframe.NewColumn("REVISION")
+		for _, name := range names {
+			ms := m.members[name]
+			c1.PushBack(dataframe.NewStringValue(name))
+			c2.PushBack(dataframe.NewStringValue(ms.requests))
+			c3.PushBack(dataframe.NewStringValue(fmt.Sprintf("%4.4f", 100*float64(ms.requests)/float64(total))))
+			c4.PushBack(dataframe.NewStringValue(fmt.Sprintf("%4.4f", toMillisecond(ms.totalLatency/time.Duration(ms.requests)))))
+			c5.PushBack(dataframe.NewStringValue(fmt.Sprintf("%4.4f", toMillisecond(ms.slowest))))
+			c6.PushBack(dataframe.NewStringValue(fmt.Sprintf("%d", ms.raftTerm)))
+			c7.PushBack(dataframe.NewStringValue(ms.revision))
+		}
+		fr := dataframe.New()
+		for _, col := range []dataframe.Column{c1, c2, c3, c4, c5, c6, c7} {
+			if err := fr.AddColumn(col); err != nil {
+				panic(err)
+			}
+		}
+		if err := saveCSV(fr, fpath); err != nil {
+			panic(err)
+		}
+		cfg.lg.Info("saved member breakdown", zap.String("path", fpath))
+	}
+
+	fpath := cfg.ConfigClientMachineInitial.ClientTermChangePath
+	if fpath == "" {
+		return
+	}
+	t1 := dataframe.NewColumn("UNIX-NANOSECOND")
+	t2 := dataframe.NewColumn("RAFT-TERM-BEFORE")
+	t3 := dataframe.NewColumn("RAFT-TERM")
+	t4 := dataframe.NewColumn("MEMBER")
+	t5 := dataframe.NewColumn("REVISION")
+	for _, ch := range m.changes {
+		t1.PushBack(dataframe.NewStringValue(ch.at.UnixNano()))
+		t2.PushBack(dataframe.NewStringValue(fmt.Sprintf("%d", ch.before)))
+		t3.PushBack(dataframe.NewStringValue(fmt.Sprintf("%d", ch.after)))
+		t4.PushBack(dataframe.NewStringValue(ch.member))
+		t5.PushBack(dataframe.NewStringValue(ch.revision))
+	}
+	fr := dataframe.New()
+	for _, col := range []dataframe.Column{t1, t2, t3, t4, t5} {
+		if err := fr.AddColumn(col); err != nil {
+			panic(err)
+		}
+	}
+	if err := saveCSV(fr, fpath); err != nil {
+		panic(err)
+	}
+	cfg.lg.Info("saved term changes", zap.String("path", fpath), zap.Int("changes", len(m.changes)))
+}
@@ -0,0 +1,84 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func TestMemberBreakdown(t *testing.T) {
+	m := newMemberBreakdown(zap.NewNop())
+	now := time.Now()
+	for i, tr := range []requestTrace{
+		{member: "a", raftTerm: 2, revision: 10},
+		{member: "b", raftTerm: 2, revision: 11},
+		{member: "", raftTerm: 9},
+		{member: "b", raftTerm: 3, revision: 12},
+		// finished after the request of the new term
+		{member: "a", raftTerm: 2, revision: 11},
+		{member: "a", raftTerm: 3, revision: 13},
+	} {
+		m.record(now.Add(time.Duration(i)*time.Second), &tr, time.Duration(i+1)*time.Millisecond)
+	}
+	if len(m.members) != 2 || m.members["a"].requests != 3 || m.members["b"].requests != 2 {
+		t.Fatalf("unexpected members %+v", m.members)
+	}
+	if a := m.members["a"]; a.slowest != 6*time.Millisecond || a.totalLatency != 12*time.Millisecond || a.revision != 13 {
+		t.Fatalf("unexpected stats of member a %+v", a)
+	}
+	if len(m.changes) != 1 || m.changes[0].before != 2 || m.changes[0].after != 3 || m.changes[0].member != "b" {
+		t.Fatalf("expected a change of term 2 to 3 by member b, got %+v", m.changes)
+	}
+
+	dir, err := ioutil.TempDir("", "members")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	cfg := &Config{lg: zap.NewNop(), members: m}
+	cfg.ConfigClientMachineInitial.ClientMemberBreakdownPath = filepath.Join(dir, "members.csv")
+	cfg.ConfigClientMachineInitial.ClientTermChangePath = filepath.Join(dir, "terms.csv")
+	cfg.saveMemberBreakdown()
+
+	bts, err := ioutil.ReadFile(cfg.ConfigClientMachineInitial.ClientMemberBreakdownPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(bts)), "\n")
+	if exp := "a,3,60.0000,4.0000,6.0000,3,13"; len(lines) != 3 || lines[1] != exp {
+		t.Fatalf("expected %q, got %q", exp, lines)
+	}
+	bts, err = ioutil.ReadFile(cfg.ConfigClientMachineInitial.ClientTermChangePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lines = strings.Split(strings.TrimSpace(string(bts)), "\n"); len(lines) != 2 || !strings.HasSuffix(lines[1], ",2,3,b,12") {
+		t.Fatalf("unexpected term changes %q", lines)
+	}
+
+	// saved with the headers, of no changes
+	cfg.members = newMemberBreakdown(zap.NewNop())
+	cfg.saveMemberBreakdown()
+	if bts, err = ioutil.ReadFile(cfg.ConfigClientMachineInitial.ClientTermChangePath); err != nil || strings.Count(string(bts), "\n") != 1 {
+		t.Fatalf("expected the headers only, got %q (%v)", bts, err)
+	}
+}
@@ -0,0 +1,148 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migration
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/coreos/dbtester"
+	"github.com/coreos/dbtester/control"
+	"github.com/coreos/dbtester/dbtesterpb"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+// Command implements 'migration' command.
+var Command = &cobra.Command{
+	Use:   "migration",
+	Short: "Benchmarks the visibility of the keys across the etcd v2 and v3 APIs.",
+	Long: `Writes keys through the etcd v2 API and reads them through the v3 API,
+and vice versa, on the clusters that emulate the v2 API with the v3 store
+('--experimental-enable-v2v3'), as during the migration from v2 to v3. It
+measures how long after each write and delete is acknowledged through one
+API it is visible through the other, and checks that the values read are
+the values written.`,
+	RunE: commandFunc,
+}
+
+var (
+	databaseID string
+	configPath string
+	endpoints  []string
+	certFile   string
+	keyFile    string
+	caFile     string
+	opts       dbtester.MigrationBenchOptions
+)
+
+func init() {
+	Command.PersistentFlags().StringVar(&databaseID, "database-id", "", "etcd database ID to benchmark. Empty to benchmark all etcd databases of '--config', or 'etcd__tip' of '--endpoints'.")
+	Command.PersistentFlags().StringVarP(&configPath, "config", "c", "", "YAML configuration file path, to benchmark its etcd databases.")
+	Command.PersistentFlags().StringSliceVar(&endpoints, "endpoints", nil, "etcd endpoints to benchmark, instead of the endpoints of '--config'.")
+	Command.PersistentFlags().StringVar(&certFile, "cert", "", "Client certificate to connect to etcd with TLS. Requires '--key'.")
+	Command.PersistentFlags().StringVar(&keyFile, "key", "", "Private key of '--cert'.")
+	Command.PersistentFlags().StringVar(&caFile, "cacert", "", "CA certificate to verify the etcd servers with, to connect with TLS. Empty to verify with the system roots when '--cert' is set.")
+	Command.PersistentFlags().IntVar(&opts.Keys, "keys", 1000, "Number of keys to write through one API and to read through the other, of each direction.")
+	Command.PersistentFlags().IntVar(&opts.Clients, "clients", 10, "Number of keys to write at a time.")
+	Command.PersistentFlags().StringVar(&opts.V2V3Prefix, "v2v3-prefix", "", "Prefix of the v3 keys of the v2 emulation, as of '--experimental-enable-v2v3' of the etcd servers.")
+	Command.PersistentFlags().StringSliceVar(&opts.Directions, "directions", []string{dbtester.MigrationV2ToV3, dbtester.MigrationV3ToV2}, "Directions to benchmark: 'v2-to-v3' to write through the v2 API and to read through the v3 API, and 'v3-to-v2'.")
+	Command.PersistentFlags().DurationVar(&opts.Timeout, "timeout", 5*time.Second, "Duration to read each key through the other API, until the write or the delete is taken as invisible.")
+}
+
+func commandFunc(cmd *cobra.Command, args []string) error {
+	if err := dbtester.SetClientTLS(certFile, keyFile, caFile); err != nil {
+		return err
+	}
+
+	var gcfgs []dbtesterpb.ConfigClientMachineAgentControl
+	switch {
+	case len(endpoints) > 0:
+		if databaseID == "" {
+			databaseID = "etcd__tip"
+		}
+		if !dbtesterpb.IsValidDatabaseID(databaseID) {
+			return fmt.Errorf("database id %q is unknown", databaseID)
+		}
+		gcfgs = append(gcfgs, dbtesterpb.ConfigClientMachineAgentControl{DatabaseID: databaseID, DatabaseEndpoints: endpoints})
+
+	case configPath != "":
+		cfg, err := dbtester.ReadConfig(configPath, false)
+		if err != nil {
+			return err
+		}
+		for id, gcfg := range cfg.DatabaseIDToConfigClientMachineAgentControl {
+			if (databaseID == "" && strings.HasPrefix(id, "etcd__")) || databaseID == id {
+				gcfgs = append(gcfgs, gcfg)
+			}
+		}
+		if len(gcfgs) == 0 {
+			return fmt.Errorf("no etcd database of %q is found in %q", databaseID, configPath)
+		}
+		sort.Slice(gcfgs, func(i, j int) bool { return gcfgs[i].DatabaseID < gcfgs[j].DatabaseID })
+
+	default:
+		return fmt.Errorf("either '--endpoints' or '--config' is required")
+	}
+
+	var rss []dbtester.MigrationBenchResult
+	steps := make([]control.Step, len(gcfgs))
+	for i := range gcfgs {
+		gcfg := gcfgs[i]
+		steps[i] = control.Step{
+			Name:       gcfg.DatabaseID,
+			DatabaseID: gcfg.DatabaseID,
+			Run: func(*dbtester.Config) error {
+				lg.Info("benchmarking migration", zap.String("database", gcfg.DatabaseID), zap.Strings("endpoints", gcfg.DatabaseEndpoints))
+				rs, err := dbtester.MigrationBench(lg, gcfg, opts)
+				if err != nil {
+					return fmt.Errorf("failed to benchmark %q (%v)", gcfg.DatabaseID, err)
+				}
+				rss = append(rss, rs...)
+				return nil
+			},
+		}
+	}
+	if err := control.RunSteps(control.Sequence{}, steps); err != nil {
+		return err
+	}
+
+	tw := tablewriter.NewWriter(os.Stdout)
+	tw.SetHeader([]string{"DATABASE", "DIRECTION", "KEYS", "ERRORS", "P50-MS", "P99-MS", "MAX-MS", "INVISIBLE", "MISMATCHES", "DELETE-P50-MS", "DELETE-P99-MS", "UNDELETED"})
+	for _, rs := range rss {
+		tw.Append([]string{
+			rs.DatabaseID,
+			rs.Direction,
+			fmt.Sprintf("%d", rs.Keys),
+			fmt.Sprintf("%d", rs.Errors),
+			fmt.Sprintf("%.3f", rs.P50Ms),
+			fmt.Sprintf("%.3f", rs.P99Ms),
+			fmt.Sprintf("%.3f", rs.MaxMs),
+			fmt.Sprintf("%d", rs.Invisible),
+			fmt.Sprintf("%d", rs.Mismatches),
+			fmt.Sprintf("%.3f", rs.DeleteP50Ms),
+			fmt.Sprintf("%.3f", rs.DeleteP99Ms),
+			fmt.Sprintf("%d", rs.Undeleted),
+		})
+	}
+	tw.SetAutoFormatHeaders(false)
+	tw.Render()
+	return nil
+}
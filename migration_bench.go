@@ -0,0 +1,358 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/coreos/dbtester/dbtesterpb"
+
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+)
+
+// migrationBenchKey is the prefix of the v2 keys of the migration benchmark.
+const migrationBenchKey = "/dbtester-migration-"
+
+// migrationPollInterval is the interval of the reads through
+// the other API, until the write or the delete is visible.
+const migrationPollInterval = time.Millisecond
+
+// Directions of the migration benchmark.
+const (
+	MigrationV2ToV3 = "v2-to-v3"
+	MigrationV3ToV2 = "v3-to-v2"
+)
+
+// etcdv2KeyNotFound is the error code of the etcd v2 keys API
+// for the keys that do not exist.
+const etcdv2KeyNotFound = 100
+
+// MigrationBenchOptions configures the migration benchmark.
+type MigrationBenchOptions struct {
+	// Keys is the number of keys to write through one API,
+	// and to read through the other, of each direction.
+	Keys int
+	// Clients is the number of keys written at a time.
+	Clients int
+	// V2V3Prefix is the prefix of the v3 keys that the v2 emulation of
+	// etcd stores the v2 keys under, of '--experimental-enable-v2v3'.
+	V2V3Prefix string
+	// Directions are 'v2-to-v3' and 'v3-to-v2', to write through
+	// the first API and to read through the second.
+	Directions []string
+	// Timeout is how long to read each key through the other API,
+	// until the write or the delete is taken as invisible.
+	Timeout time.Duration
+}
+
+// MigrationBenchResult is the cross-API visibility of one direction:
+// how long after a write or a delete through one API is acknowledged
+// it is visible through the other, and whether the value read is the
+// value written.
+type MigrationBenchResult struct {
+	DatabaseID string
+	Direction  string
+	Keys       int
+
+	// Errors is the number of the keys whose write, read or delete failed.
+	Errors int
+
+	// P50Ms, P99Ms and MaxMs are from the acknowledgement of each write
+	// to the first read of the value through the other API.
+	P50Ms float64
+	P99Ms float64
+	MaxMs float64
+	// Invisible is the number of the writes not visible before the timeout.
+	Invisible int
+	// Mismatches is the number of the keys read through the other API
+	// with a value other than written, which must be 0.
+	Mismatches int
+
+	// DeleteP50Ms and DeleteP99Ms are from the acknowledgement of each
+	// delete to the first read of no key through the other API.
+	DeleteP50Ms float64
+	DeleteP99Ms float64
+	// Undeleted is the number of the deletes not visible before the timeout.
+	Undeleted int
+}
+
+// migrationAPI writes and reads the i-th key of the benchmark
+// through one API of etcd.
+type migrationAPI struct {
+	put func(ctx context.Context, i int, v string) error
+	// get returns false if the key does not exist.
+	get func(ctx context.Context, i int) (string, bool, error)
+	del func(ctx context.Context, i int) error
+}
+
+// v2v3Key returns the v3 key that the v2 emulation of etcd stores the
+// v2 key under: the prefix, the depth of the key in the v2 directories,
+// and the key.
+func v2v3Key(prefix, v2key string) string {
+	p := path.Clean(path.Join("/", v2key))
+	return fmt.Sprintf("%s/%03d/k/%s", prefix, strings.Count(p, "/"), p)
+}
+
+// MigrationBench measures the visibility of the writes and the deletes
+// through the etcd v2 API to the v3 API, and vice versa, on the cluster
+// of 'gcfg' that emulates the v2 API with the v3 store.
+func MigrationBench(lg *zap.Logger, gcfg dbtesterpb.ConfigClientMachineAgentControl, opts MigrationBenchOptions) ([]MigrationBenchResult, error) {
+	switch gcfg.DatabaseID {
+	case "etcd__other", "etcd__tip", "etcd__v3_2", "etcd__v3_3":
+	default:
+		return nil, fmt.Errorf("%q has no etcd v2 and v3 APIs", gcfg.DatabaseID)
+	}
+	switch {
+	case opts.Keys < 1 || opts.Clients < 1:
+		return nil, fmt.Errorf("keys and clients must be positive (got %d, %d)", opts.Keys, opts.Clients)
+	case opts.Timeout <= 0:
+		return nil, fmt.Errorf("timeout must be positive (got %v)", opts.Timeout)
+	case opts.V2V3Prefix == "":
+		return nil, fmt.Errorf("no prefix of the v2 emulation; the v2 and v3 APIs do not share the keys without '--experimental-enable-v2v3'")
+	case len(gcfg.DatabaseEndpoints) == 0:
+		return nil, fmt.Errorf("no endpoint to benchmark %q", gcfg.DatabaseID)
+	}
+	for _, d := range opts.Directions {
+		if d != MigrationV2ToV3 && d != MigrationV3ToV2 {
+			return nil, fmt.Errorf("unknown direction %q (expected %q or %q)", d, MigrationV2ToV3, MigrationV3ToV2)
+		}
+	}
+	if bopts := gcfg.ConfigClientMachineBenchmarkOptions; bopts != nil && bopts.EtcdUsername != "" {
+		setEtcdAuth(bopts.EtcdUsername, bopts.EtcdPassword)
+	}
+
+	v3, closeV3 := newMigrationAPIEtcdv3(gcfg.DatabaseEndpoints, opts.V2V3Prefix)
+	defer closeV3()
+	v2 := newMigrationAPIEtcdv2(gcfg.DatabaseEndpoints)
+
+	var rss []MigrationBenchResult
+	for _, d := range opts.Directions {
+		from, to := v2, v3
+		if d == MigrationV3ToV2 {
+			from, to = v3, v2
+		}
+		lg.Info("benchmarking cross-API visibility", zap.String("direction", d), zap.Int("keys", opts.Keys))
+		rs := runMigrationBench(lg, from, to, opts)
+		rs.DatabaseID, rs.Direction = gcfg.DatabaseID, d
+		rss = append(rss, rs)
+	}
+	return rss, nil
+}
+
+func runMigrationBench(lg *zap.Logger, from, to *migrationAPI, opts MigrationBenchOptions) MigrationBenchResult {
+	rs := MigrationBenchResult{Keys: opts.Keys}
+	var (
+		mu               sync.Mutex
+		putLats, delLats []float64
+	)
+	keyc := make(chan int, opts.Clients)
+	go func() {
+		for i := 0; i < opts.Keys; i++ {
+			keyc <- i
+		}
+		close(keyc)
+	}()
+
+	var wg sync.WaitGroup
+	wg.Add(opts.Clients)
+	for c := 0; c < opts.Clients; c++ {
+		go func() {
+			defer wg.Done()
+			for i := range keyc {
+				o := migrateKey(from, to, i, opts.Timeout)
+				if o.err != nil {
+					lg.Warn("key failed", zap.Int("key", i), zap.Error(o.err))
+				}
+
+				mu.Lock()
+				switch {
+				case o.err != nil:
+					rs.Errors++
+				case !o.visible:
+					rs.Invisible++
+				default:
+					putLats = append(putLats, toMillisecond(o.putLat))
+					if o.deleted {
+						delLats = append(delLats, toMillisecond(o.delLat))
+					} else {
+						rs.Undeleted++
+					}
+				}
+				if o.mismatch {
+					rs.Mismatches++
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	rs.P50Ms = percentileOf(putLats, 50)
+	rs.P99Ms = percentileOf(putLats, 99)
+	rs.MaxMs = percentileOf(putLats, 100)
+	rs.DeleteP50Ms = percentileOf(delLats, 50)
+	rs.DeleteP99Ms = percentileOf(delLats, 99)
+	if rs.Mismatches > 0 {
+		lg.Warn("values read through the other API differ from the values written", zap.Int("mismatches", rs.Mismatches))
+	}
+	return rs
+}
+
+// migrationOutcome is of one key of the migration benchmark.
+type migrationOutcome struct {
+	visible  bool
+	putLat   time.Duration
+	mismatch bool
+	deleted  bool
+	delLat   time.Duration
+	err      error
+}
+
+// migrateKey writes the key through one API, reads it through the other
+// until the value is visible, and does the same for its delete.
+func migrateKey(from, to *migrationAPI, i int, timeout time.Duration) (o migrationOutcome) {
+	ctx := context.Background()
+	// not to read the value of a previous run as visible
+	v := fmt.Sprintf("%d-%d", i, time.Now().UnixNano())
+	if o.err = from.put(ctx, i, v); o.err != nil {
+		return o
+	}
+	acked := time.Now()
+	o.visible, o.err = pollMigration(timeout, func() (bool, error) {
+		got, ok, err := to.get(ctx, i)
+		if err != nil || !ok {
+			return false, err
+		}
+		if got != v {
+			o.mismatch = true
+			return false, nil
+		}
+		return true, nil
+	})
+	o.putLat = time.Since(acked)
+	if o.err != nil || !o.visible {
+		from.del(ctx, i)
+		return o
+	}
+
+	if o.err = from.del(ctx, i); o.err != nil {
+		return o
+	}
+	acked = time.Now()
+	o.deleted, o.err = pollMigration(timeout, func() (bool, error) {
+		_, ok, err := to.get(ctx, i)
+		return err == nil && !ok, err
+	})
+	o.delLat = time.Since(acked)
+	return o
+}
+
+// pollMigration calls 'done' until it returns true, or the timeout.
+func pollMigration(timeout time.Duration, done func() (bool, error)) (bool, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		ok, err := done()
+		if ok || err != nil {
+			return ok, err
+		}
+		if time.Now().After(deadline) {
+			return false, nil
+		}
+		time.Sleep(migrationPollInterval)
+	}
+}
+
+// newMigrationAPIEtcdv3 reads and writes the v3 keys that the v2
+// emulation stores the v2 keys of the benchmark under.
+func newMigrationAPIEtcdv3(endpoints []string, prefix string) (*migrationAPI, func()) {
+	cli := mustCreateConnEtcdv3(endpoints)
+	key := func(i int) string { return v2v3Key(prefix, fmt.Sprintf("%s%d", migrationBenchKey, i)) }
+	return &migrationAPI{
+		put: func(ctx context.Context, i int, v string) error {
+			_, err := cli.Put(ctx, key(i), v)
+			return err
+		},
+		get: func(ctx context.Context, i int) (string, bool, error) {
+			resp, err := cli.Get(ctx, key(i))
+			if err != nil || len(resp.Kvs) == 0 {
+				return "", false, err
+			}
+			return string(resp.Kvs[0].Value), true, nil
+		},
+		del: func(ctx context.Context, i int) error {
+			_, err := cli.Delete(ctx, key(i))
+			return err
+		},
+	}, func() { cli.Close() }
+}
+
+// newMigrationAPIEtcdv2 reads and writes the keys of the benchmark
+// with the etcd v2 keys API, over HTTP, in turn from the endpoints.
+func newMigrationAPIEtcdv2(endpoints []string) *migrationAPI {
+	keysURLs := make([]string, len(endpoints))
+	for i, ep := range endpoints {
+		keysURLs[i] = etcdv2KeysURL(ep)
+	}
+	cli := &http.Client{Transport: &http.Transport{TLSClientConfig: clientTLS}}
+	do := func(ctx context.Context, method string, i int, v string) (etcdv2Response, error) {
+		u := fmt.Sprintf("%s%s%d", keysURLs[i%len(keysURLs)], migrationBenchKey, i)
+		body := ""
+		switch method {
+		case "PUT":
+			body = url.Values{"value": {v}}.Encode()
+		case "GET":
+			// linearizable reads, as the reads of the v3 API
+			u += "?quorum=true"
+		}
+		req, err := http.NewRequest(method, u, strings.NewReader(body))
+		if err != nil {
+			return etcdv2Response{}, err
+		}
+		if method == "PUT" {
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		}
+		return etcdv2Do(cli, req.WithContext(ctx))
+	}
+	return &migrationAPI{
+		put: func(ctx context.Context, i int, v string) error {
+			_, err := do(ctx, "PUT", i, v)
+			return err
+		},
+		get: func(ctx context.Context, i int) (string, bool, error) {
+			resp, err := do(ctx, "GET", i, "")
+			if resp.ErrorCode == etcdv2KeyNotFound {
+				return "", false, nil
+			}
+			if err != nil {
+				return "", false, err
+			}
+			return resp.Node.Value, true, nil
+		},
+		del: func(ctx context.Context, i int) error {
+			resp, err := do(ctx, "DELETE", i, "")
+			if resp.ErrorCode == etcdv2KeyNotFound {
+				return nil
+			}
+			return err
+		},
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+)
+
+// newMigrationAPIMem returns the APIs of one in-memory store, where
+// the writes and the deletes through one API are visible through the
+// other after the delay. 'corrupt' is appended to the values read
+// through the other API.
+func newMigrationAPIMem(delay time.Duration, corrupt string) (from, to *migrationAPI) {
+	type entry struct {
+		v       string
+		deleted bool
+		at      time.Time
+	}
+	var (
+		mu sync.Mutex
+		kv = make(map[int]entry)
+	)
+	from = &migrationAPI{
+		put: func(ctx context.Context, i int, v string) error {
+			mu.Lock()
+			kv[i] = entry{v: v, at: time.Now().Add(delay)}
+			mu.Unlock()
+			return nil
+		},
+		get: func(ctx context.Context, i int) (string, bool, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			e, ok := kv[i]
+			return e.v, ok && !e.deleted, nil
+		},
+		del: func(ctx context.Context, i int) error {
+			mu.Lock()
+			kv[i] = entry{v: kv[i].v, deleted: true, at: time.Now().Add(delay)}
+			mu.Unlock()
+			return nil
+		},
+	}
+	to = &migrationAPI{
+		get: func(ctx context.Context, i int) (string, bool, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			e, ok := kv[i]
+			if !ok {
+				return "", false, nil
+			}
+			// the previous state until the delay
+			visible := time.Now().After(e.at)
+			if e.deleted != visible {
+				return e.v + corrupt, true, nil
+			}
+			return "", false, nil
+		},
+	}
+	return from, to
+}
+
+func TestRunMigrationBench(t *testing.T) {
+	from, to := newMigrationAPIMem(5*time.Millisecond, "")
+	rs := runMigrationBench(zap.NewNop(), from, to, MigrationBenchOptions{Keys: 20, Clients: 4, Timeout: time.Second})
+	if rs.Errors != 0 || rs.Invisible != 0 || rs.Mismatches != 0 || rs.Undeleted != 0 {
+		t.Fatalf("expected all keys visible, got %+v", rs)
+	}
+	if rs.P50Ms < 5 || rs.DeleteP50Ms < 5 {
+		t.Fatalf("expected the visibility latency of the delay, got %+v", rs)
+	}
+
+	from, to = newMigrationAPIMem(0, "-corrupted")
+	rs = runMigrationBench(zap.NewNop(), from, to, MigrationBenchOptions{Keys: 5, Clients: 1, Timeout: 20 * time.Millisecond})
+	if rs.Mismatches != 5 || rs.Invisible != 5 {
+		t.Fatalf("expected the corrupted values as mismatches, got %+v", rs)
+	}
+}
+
+func TestV2V3Key(t *testing.T) {
+	if k := v2v3Key("/v2", "/dbtester-migration-1"); k != "/v2/001/k//dbtester-migration-1" {
+		t.Fatalf("unexpected v3 key %q", k)
+	}
+	if k := v2v3Key("/v2", "a/b"); k != "/v2/002/k//a/b" {
+		t.Fatalf("unexpected v3 key %q", k)
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/coreos/dbtester/dbtesterpb"
+)
+
+// openMetricsWriter writes the results of the finished seconds in
+// OpenMetrics text format, one file per write, so that the files can be
+// backfilled into a time series database after the benchmark
+// (e.g. 'promtool tsdb create-blocks-from openmetrics').
+type openMetricsWriter struct {
+	dir string
+	// ext is the extension of the compression of the files, if compressed
+	ext    string
+	labels string
+
+	// requests and errors are the running totals of the counters
+	requests int64
+	errors   int64
+}
+
+func newOpenMetricsWriter(dir, ext, loaderID, databaseID, databaseTag string) (*openMetricsWriter, error) {
+	if err := os.MkdirAll(dir, 0777); err != nil {
+		return nil, err
+	}
+	labels := fmt.Sprintf(`database_id="%s",database_tag="%s",loader="%s"`,
+		escapeOpenMetricsLabel(databaseID), escapeOpenMetricsLabel(databaseTag), escapeOpenMetricsLabel(loaderID))
+	return &openMetricsWriter{dir: dir, ext: ext, labels: labels}, nil
+}
+
+// write writes the results, sorted by time, to a file named
+// after the first second of the results.
+func (w *openMetricsWriter) write(rs []*dbtesterpb.InterimResult) error {
+	if len(rs) == 0 {
+		return nil
+	}
+	var b []byte
+	b, w.requests, w.errors = formatOpenMetrics(rs, w.labels, w.requests, w.errors)
+	fpath := filepath.Join(w.dir, fmt.Sprintf("dbtester-%d.om", rs[0].UnixSecond)+w.ext)
+	f, err := createFile(fpath)
+	if err != nil {
+		return err
+	}
+	if _, err = f.Write(b); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// formatOpenMetrics formats the results as counters starting from
+// 'requests' and 'errors', and as latency gauges in microseconds.
+// It returns the text and the totals of the counters after the results.
+func formatOpenMetrics(rs []*dbtesterpb.InterimResult, labels string, requests, errors int64) ([]byte, int64, int64) {
+	var buf bytes.Buffer
+
+	buf.WriteString("# TYPE dbtester_requests counter\n")
+	buf.WriteString("# HELP dbtester_requests Total number of requests.\n")
+	reqN := requests
+	for _, r := range rs {
+		reqN += r.Requests
+		fmt.Fprintf(&buf, "dbtester_requests_total{%s} %d %d\n", labels, reqN, r.UnixSecond)
+	}
+
+	buf.WriteString("# TYPE dbtester_errors counter\n")
+	buf.WriteString("# HELP dbtester_errors Total number of failed requests.\n")
+	errN := errors
+	for _, r := range rs {
+		errN += r.Errors
+		fmt.Fprintf(&buf, "dbtester_errors_total{%s} %d %d\n", labels, errN, r.UnixSecond)
+	}
+
+	gauges := []struct {
+		name, help string
+		value      func(r *dbtesterpb.InterimResult) int64
+	}{
+		{"dbtester_latency_min_microseconds", "Minimum latency of the requests in the second.", func(r *dbtesterpb.InterimResult) int64 { return r.MinLatencyMicroseconds }},
+		{"dbtester_latency_avg_microseconds", "Average latency of the requests in the second.", func(r *dbtesterpb.InterimResult) int64 { return r.TotalLatencyMicroseconds / r.Requests }},
+		{"dbtester_latency_max_microseconds", "Maximum latency of the requests in the second.", func(r *dbtesterpb.InterimResult) int64 { return r.MaxLatencyMicroseconds }},
+	}
+	for _, g := range gauges {
+		fmt.Fprintf(&buf, "# TYPE %s gauge\n", g.name)
+		fmt.Fprintf(&buf, "# HELP %s %s\n", g.name, g.help)
+		for _, r := range rs {
+			if r.Requests == 0 {
+				continue
+			}
+			fmt.Fprintf(&buf, "%s{%s} %d %d\n", g.name, labels, g.value(r), r.UnixSecond)
+		}
+	}
+
+	buf.WriteString("# EOF\n")
+	return buf.Bytes(), reqN, errN
+}
+
+func escapeOpenMetricsLabel(v string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(v)
+}
@@ -0,0 +1,60 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"testing"
+
+	"github.com/coreos/dbtester/dbtesterpb"
+)
+
+func TestFormatOpenMetrics(t *testing.T) {
+	rs := []*dbtesterpb.InterimResult{
+		{UnixSecond: 100, Requests: 4, Errors: 1, MinLatencyMicroseconds: 10, MaxLatencyMicroseconds: 40, TotalLatencyMicroseconds: 100},
+		{UnixSecond: 101, Requests: 2, MinLatencyMicroseconds: 5, MaxLatencyMicroseconds: 15, TotalLatencyMicroseconds: 20},
+	}
+	b, reqN, errN := formatOpenMetrics(rs, `loader="a\"b"`, 10, 3)
+	exp := `# TYPE dbtester_requests counter
+# HELP dbtester_requests Total number of requests.
+dbtester_requests_total{loader="a\"b"} 14 100
+dbtester_requests_total{loader="a\"b"} 16 101
+# TYPE dbtester_errors counter
+# HELP dbtester_errors Total number of failed requests.
+dbtester_errors_total{loader="a\"b"} 4 100
+dbtester_errors_total{loader="a\"b"} 4 101
+# TYPE dbtester_latency_min_microseconds gauge
+# HELP dbtester_latency_min_microseconds Minimum latency of the requests in the second.
+dbtester_latency_min_microseconds{loader="a\"b"} 10 100
+dbtester_latency_min_microseconds{loader="a\"b"} 5 101
+# TYPE dbtester_latency_avg_microseconds gauge
+# HELP dbtester_latency_avg_microseconds Average latency of the requests in the second.
+dbtester_latency_avg_microseconds{loader="a\"b"} 25 100
+dbtester_latency_avg_microseconds{loader="a\"b"} 10 101
+# TYPE dbtester_latency_max_microseconds gauge
+# HELP dbtester_latency_max_microseconds Maximum latency of the requests in the second.
+dbtester_latency_max_microseconds{loader="a\"b"} 40 100
+dbtester_latency_max_microseconds{loader="a\"b"} 15 101
+# EOF
+`
+	if string(b) != exp {
+		t.Fatalf("expected\n%s\ngot\n%s", exp, b)
+	}
+	if reqN != 16 || errN != 4 {
+		t.Fatalf("expected totals 16, 4, got %d, %d", reqN, errN)
+	}
+	if v := escapeOpenMetricsLabel("a\\b\"c\nd"); v != `a\\b\"c\nd` {
+		t.Fatalf("unexpected escape %q", v)
+	}
+}
@@ -0,0 +1,271 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package flamegraph converts pprof profiles to the folded stacks
+// of flamegraph.pl, with no dependency on the pprof tool.
+package flamegraph
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+	"strings"
+)
+
+// Fold reads a pprof profile (gzipped or not), and writes its stacks
+// in folded format, one 'root;...;leaf value' line per stack. The value
+// is of the sample type 'sampleType' (e.g. 'cpu', 'inuse_space'), or the
+// last sample type if empty.
+func Fold(r io.Reader, w io.Writer, sampleType string) error {
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	if len(b) > 1 && b[0] == 0x1f && b[1] == 0x8b {
+		var gr *gzip.Reader
+		if gr, err = gzip.NewReader(bytes.NewReader(b)); err != nil {
+			return err
+		}
+		if b, err = ioutil.ReadAll(gr); err != nil {
+			return err
+		}
+	}
+	p, err := parseProfile(b)
+	if err != nil {
+		return err
+	}
+
+	idx := len(p.sampleTypes) - 1
+	if sampleType != "" {
+		idx = -1
+		for i, st := range p.sampleTypes {
+			if p.str(st) == sampleType {
+				idx = i
+			}
+		}
+		if idx < 0 {
+			return fmt.Errorf("sample type %q is not found", sampleType)
+		}
+	}
+	if idx < 0 {
+		return errors.New("profile has no sample type")
+	}
+
+	stacks := make(map[string]int64)
+	for _, s := range p.samples {
+		if idx >= len(s.values) || s.values[idx] == 0 {
+			continue
+		}
+		// locations are leaf first, and so are the inlined lines in each location
+		var frames []string
+		for i := len(s.locationIDs) - 1; i >= 0; i-- {
+			fns := p.locations[s.locationIDs[i]]
+			for j := len(fns) - 1; j >= 0; j-- {
+				frames = append(frames, p.str(p.functions[fns[j]]))
+			}
+		}
+		if len(frames) == 0 {
+			continue
+		}
+		stacks[strings.Join(frames, ";")] += s.values[idx]
+	}
+
+	keys := make([]string, 0, len(stacks))
+	for k := range stacks {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if _, err = fmt.Fprintf(w, "%s %d\n", k, stacks[k]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type sample struct {
+	locationIDs []uint64
+	values      []int64
+}
+
+// profile is the subset of 'profile.proto' to fold the stacks.
+type profile struct {
+	sampleTypes []int64
+	samples     []sample
+	// locations maps a location ID to its function IDs
+	locations map[uint64][]uint64
+	// functions maps a function ID to its name string index
+	functions map[uint64]int64
+	strings   []string
+}
+
+func (p *profile) str(i int64) string {
+	if i < 0 || int(i) >= len(p.strings) {
+		return ""
+	}
+	return p.strings[i]
+}
+
+// field is a protobuf field, with 'v' set for varints,
+// or 'b' set for length-delimited values.
+type field struct {
+	num  int
+	wire int
+	v    uint64
+	b    []byte
+}
+
+func readFields(b []byte, fn func(f field) error) error {
+	for len(b) > 0 {
+		key, n := binary.Uvarint(b)
+		if n <= 0 {
+			return errors.New("malformed profile")
+		}
+		b = b[n:]
+		f := field{num: int(key >> 3), wire: int(key & 7)}
+		switch f.wire {
+		case 0:
+			if f.v, n = binary.Uvarint(b); n <= 0 {
+				return errors.New("malformed profile")
+			}
+			b = b[n:]
+		case 1:
+			if len(b) < 8 {
+				return errors.New("malformed profile")
+			}
+			f.v = binary.LittleEndian.Uint64(b)
+			b = b[8:]
+		case 2:
+			l, n := binary.Uvarint(b)
+			if n <= 0 || uint64(len(b)-n) < l {
+				return errors.New("malformed profile")
+			}
+			f.b = b[n : n+int(l)]
+			b = b[n+int(l):]
+		case 5:
+			if len(b) < 4 {
+				return errors.New("malformed profile")
+			}
+			f.v = uint64(binary.LittleEndian.Uint32(b))
+			b = b[4:]
+		default:
+			return fmt.Errorf("unknown wire type %d", f.wire)
+		}
+		if err := fn(f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// varints returns the values of a repeated varint
+// field, which may or may not be packed.
+func varints(f field) ([]uint64, error) {
+	if f.wire == 0 {
+		return []uint64{f.v}, nil
+	}
+	var vs []uint64
+	for b := f.b; len(b) > 0; {
+		v, n := binary.Uvarint(b)
+		if n <= 0 {
+			return nil, errors.New("malformed packed field")
+		}
+		vs = append(vs, v)
+		b = b[n:]
+	}
+	return vs, nil
+}
+
+func parseProfile(b []byte) (*profile, error) {
+	p := &profile{locations: make(map[uint64][]uint64), functions: make(map[uint64]int64)}
+	err := readFields(b, func(f field) error {
+		switch f.num {
+		case 1: // sample_type
+			return readFields(f.b, func(vf field) error {
+				if vf.num == 1 {
+					p.sampleTypes = append(p.sampleTypes, int64(vf.v))
+				}
+				return nil
+			})
+
+		case 2: // sample
+			var s sample
+			if err := readFields(f.b, func(sf field) error {
+				vs, err := varints(sf)
+				if err != nil {
+					return err
+				}
+				switch sf.num {
+				case 1:
+					s.locationIDs = append(s.locationIDs, vs...)
+				case 2:
+					for _, v := range vs {
+						s.values = append(s.values, int64(v))
+					}
+				}
+				return nil
+			}); err != nil {
+				return err
+			}
+			p.samples = append(p.samples, s)
+
+		case 4: // location
+			var id uint64
+			var fns []uint64
+			if err := readFields(f.b, func(lf field) error {
+				switch lf.num {
+				case 1:
+					id = lf.v
+				case 4:
+					return readFields(lf.b, func(nf field) error {
+						if nf.num == 1 {
+							fns = append(fns, nf.v)
+						}
+						return nil
+					})
+				}
+				return nil
+			}); err != nil {
+				return err
+			}
+			p.locations[id] = fns
+
+		case 5: // function
+			var id uint64
+			var name int64
+			if err := readFields(f.b, func(ff field) error {
+				switch ff.num {
+				case 1:
+					id = ff.v
+				case 2:
+					name = int64(ff.v)
+				}
+				return nil
+			}); err != nil {
+				return err
+			}
+			p.functions[id] = name
+
+		case 6: // string_table
+			p.strings = append(p.strings, string(f.b))
+		}
+		return nil
+	})
+	return p, err
+}
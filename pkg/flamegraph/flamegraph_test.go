@@ -0,0 +1,68 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flamegraph
+
+import (
+	"bytes"
+	"runtime"
+	"runtime/pprof"
+	"strings"
+	"testing"
+)
+
+var sink [][]byte
+
+//go:noinline
+func allocate() {
+	for i := 0; i < 100; i++ {
+		sink = append(sink, make([]byte, 1024))
+	}
+}
+
+func TestFold(t *testing.T) {
+	old := runtime.MemProfileRate
+	runtime.MemProfileRate = 1
+	defer func() { runtime.MemProfileRate = old }()
+
+	allocate()
+	runtime.GC()
+
+	var prof bytes.Buffer
+	if err := pprof.Lookup("heap").WriteTo(&prof, 0); err != nil {
+		t.Fatal(err)
+	}
+	var folded bytes.Buffer
+	if err := Fold(bytes.NewReader(prof.Bytes()), &folded, "alloc_space"); err != nil {
+		t.Fatal(err)
+	}
+
+	found := false
+	for _, line := range strings.Split(strings.TrimSpace(folded.String()), "\n") {
+		idx := strings.LastIndex(line, " ")
+		if idx < 0 {
+			t.Fatalf("unexpected folded line %q", line)
+		}
+		if strings.Contains(line[:idx], "flamegraph.TestFold;") && strings.HasSuffix(line[:idx], "flamegraph.allocate") {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expected stack of 'allocate' from 'TestFold', got\n%s", folded.String())
+	}
+
+	if err := Fold(bytes.NewReader(prof.Bytes()), &folded, "unknown"); err == nil {
+		t.Fatal("expected error for unknown sample type")
+	}
+}
@@ -0,0 +1,335 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package hdrhistogram implements the High Dynamic Range histogram,
+// which records values with a fixed number of significant digits over
+// the whole range, so that the tail keeps its resolution with constant
+// memory, and writes the percentile distribution in the .hgrm format of
+// HdrHistogram for its plotters.
+package hdrhistogram
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"math/bits"
+)
+
+// Histogram records the counts of values in [1, highest], with
+// 'sigfigs' significant digits. It is not safe for concurrent use.
+type Histogram struct {
+	highest int64
+	sigfigs int
+
+	subBucketHalfCountMagnitude uint
+	subBucketHalfCount          int64
+	subBucketCount              int64
+	subBucketMask               int64
+	bucketCount                 int
+
+	counts     []int64
+	totalCount int64
+	min, max   int64
+	sum        float64
+}
+
+// New returns the histogram of the values up to 'highest', with the
+// significant digits in [1, 5].
+func New(highest int64, sigfigs int) (*Histogram, error) {
+	if sigfigs < 1 || sigfigs > 5 {
+		return nil, fmt.Errorf("significant digits must be in [1, 5] (got %d)", sigfigs)
+	}
+	if highest < 2 {
+		return nil, fmt.Errorf("highest value must be at least 2 (got %d)", highest)
+	}
+
+	// values up to this have the resolution of 1
+	singleUnit := 2 * int64(math.Pow10(sigfigs))
+	subBucketCountMagnitude := uint(math.Ceil(math.Log2(float64(singleUnit))))
+	h := &Histogram{
+		highest:                     highest,
+		sigfigs:                     sigfigs,
+		subBucketHalfCountMagnitude: subBucketCountMagnitude - 1,
+		min:                         math.MaxInt64,
+	}
+	h.subBucketCount = 1 << subBucketCountMagnitude
+	h.subBucketHalfCount = h.subBucketCount / 2
+	h.subBucketMask = h.subBucketCount - 1
+
+	h.bucketCount = 1
+	for smallestUntrackable := h.subBucketCount; smallestUntrackable <= highest; h.bucketCount++ {
+		if smallestUntrackable > math.MaxInt64/2 {
+			h.bucketCount++
+			break
+		}
+		smallestUntrackable <<= 1
+	}
+	h.counts = make([]int64, (h.bucketCount+1)*int(h.subBucketHalfCount))
+	return h, nil
+}
+
+func (h *Histogram) bucketIndex(v int64) int {
+	pow2Ceiling := 64 - bits.LeadingZeros64(uint64(v|h.subBucketMask))
+	return pow2Ceiling - int(h.subBucketHalfCountMagnitude+1)
+}
+
+func (h *Histogram) countsIndex(v int64) int {
+	b := h.bucketIndex(v)
+	sb := v >> uint(b)
+	return (b+1)<<h.subBucketHalfCountMagnitude + int(sb-h.subBucketHalfCount)
+}
+
+// valueFromIndex returns the lowest value of the counts index.
+func (h *Histogram) valueFromIndex(idx int) int64 {
+	b := idx>>h.subBucketHalfCountMagnitude - 1
+	sb := int64(idx)&(h.subBucketHalfCount-1) + h.subBucketHalfCount
+	if b < 0 {
+		sb -= h.subBucketHalfCount
+		b = 0
+	}
+	return sb << uint(b)
+}
+
+// highestEquivalent returns the highest value that counts as 'v'.
+func (h *Histogram) highestEquivalent(v int64) int64 {
+	b := uint(h.bucketIndex(v))
+	return (v>>b)<<b + int64(1)<<b - 1
+}
+
+// Record records the value, clamped to [1, highest].
+func (h *Histogram) Record(v int64) {
+	if v < 1 {
+		v = 1
+	}
+	if v > h.highest {
+		v = h.highest
+	}
+	h.counts[h.countsIndex(v)]++
+	h.totalCount++
+	h.sum += float64(v)
+	if v < h.min {
+		h.min = v
+	}
+	if v > h.max {
+		h.max = v
+	}
+}
+
+// Merge adds the values recorded in 'o', which must have the same
+// highest value and significant digits.
+func (h *Histogram) Merge(o *Histogram) error {
+	if h.highest != o.highest || h.sigfigs != o.sigfigs {
+		return fmt.Errorf("cannot merge histogram of highest %d with %d significant digits into highest %d with %d significant digits", o.highest, o.sigfigs, h.highest, h.sigfigs)
+	}
+	for i, c := range o.counts {
+		h.counts[i] += c
+	}
+	h.totalCount += o.totalCount
+	h.sum += o.sum
+	if o.min < h.min {
+		h.min = o.min
+	}
+	if o.max > h.max {
+		h.max = o.max
+	}
+	return nil
+}
+
+// SignificantFigures returns the significant digits of the values.
+func (h *Histogram) SignificantFigures() int { return h.sigfigs }
+
+// ForEach calls 'f' with the lowest value and the count of each
+// recorded value range, in increasing order of the values.
+func (h *Histogram) ForEach(f func(value, count int64)) {
+	for i, c := range h.counts {
+		if c > 0 {
+			f(h.valueFromIndex(i), c)
+		}
+	}
+}
+
+// TotalCount returns the number of recorded values.
+func (h *Histogram) TotalCount() int64 { return h.totalCount }
+
+// Min returns the smallest recorded value, 0 if none.
+func (h *Histogram) Min() int64 {
+	if h.totalCount == 0 {
+		return 0
+	}
+	return h.min
+}
+
+// Max returns the largest recorded value, 0 if none.
+func (h *Histogram) Max() int64 { return h.max }
+
+// Mean returns the mean of the recorded values.
+func (h *Histogram) Mean() float64 {
+	if h.totalCount == 0 {
+		return 0
+	}
+	return h.sum / float64(h.totalCount)
+}
+
+// StdDev returns the standard deviation of the recorded values,
+// at the resolution of the histogram.
+func (h *Histogram) StdDev() float64 {
+	if h.totalCount == 0 {
+		return 0
+	}
+	mean, dev := h.Mean(), 0.0
+	for i, c := range h.counts {
+		if c == 0 {
+			continue
+		}
+		d := float64(h.valueFromIndex(i)) - mean
+		dev += d * d * float64(c)
+	}
+	return math.Sqrt(dev / float64(h.totalCount))
+}
+
+// ValueAtPercentile returns the highest value that the percentile of
+// the recorded values are at or below, at the resolution of the histogram.
+func (h *Histogram) ValueAtPercentile(pct float64) int64 {
+	v, _ := h.valueAtPercentile(pct)
+	return v
+}
+
+// valueAtPercentile also returns the number of the values at or below.
+func (h *Histogram) valueAtPercentile(pct float64) (int64, int64) {
+	if h.totalCount == 0 {
+		return 0, 0
+	}
+	if pct > 100 {
+		pct = 100
+	}
+	target := int64(pct/100*float64(h.totalCount) + 0.5)
+	if target < 1 {
+		target = 1
+	}
+	var total int64
+	for i, c := range h.counts {
+		total += c
+		if total >= target {
+			v := h.highestEquivalent(h.valueFromIndex(i))
+			if v > h.max {
+				v = h.max
+			}
+			return v, total
+		}
+	}
+	return h.max, h.totalCount
+}
+
+// WritePercentiles writes the percentile distribution in the .hgrm
+// format, with the values divided by 'scale' (e.g. 1000 to write the
+// values recorded in microseconds as milliseconds). Each halving of the
+// distance to 100% has 'ticks' lines.
+func (h *Histogram) WritePercentiles(w io.Writer, scale float64, ticks int) error {
+	if ticks < 1 {
+		ticks = 5
+	}
+	if _, err := fmt.Fprintf(w, "%12s %14s %10s %14s\n\n", "Value", "Percentile", "TotalCount", "1/(1-Percentile)"); err != nil {
+		return err
+	}
+	if h.totalCount > 0 {
+		pct := 0.0
+		for {
+			v, total := h.valueAtPercentile(pct)
+			if total >= h.totalCount {
+				if _, err := fmt.Fprintf(w, "%12.3f %2.12f %10d\n", float64(v)/scale, 1.0, total); err != nil {
+					return err
+				}
+				break
+			}
+			if _, err := fmt.Fprintf(w, "%12.3f %2.12f %10d %14.2f\n", float64(v)/scale, pct/100, total, 1/(1-pct/100)); err != nil {
+				return err
+			}
+			// the ticks per half of the remaining distance to 100%
+			halfDistance := math.Pow(2, math.Floor(math.Log2(100/(100-pct)))+1)
+			pct += 100 / (float64(ticks) * halfDistance)
+		}
+	}
+	_, err := fmt.Fprintf(w, "#[Mean    = %12.3f, StdDeviation   = %12.3f]\n#[Max     = %12.3f, Total count    = %12d]\n#[Buckets = %12d, SubBuckets     = %12d]\n",
+		h.Mean()/scale, h.StdDev()/scale, float64(h.max)/scale, h.totalCount, h.bucketCount, h.subBucketCount)
+	return err
+}
+
+// Encode returns the histogram in the form of 'Decode', with the counts
+// of the recorded value ranges, to be merged in another process.
+func (h *Histogram) Encode() []byte {
+	var bts []byte
+	tmp := make([]byte, binary.MaxVarintLen64)
+	put := func(v uint64) {
+		n := binary.PutUvarint(tmp, v)
+		bts = append(bts, tmp[:n]...)
+	}
+	put(uint64(h.highest))
+	put(uint64(h.sigfigs))
+	put(uint64(h.min))
+	put(uint64(h.max))
+	put(math.Float64bits(h.sum))
+	prev := 0
+	for i, c := range h.counts {
+		if c == 0 {
+			continue
+		}
+		// the distance to the previous index, which is mostly small
+		put(uint64(i - prev))
+		put(uint64(c))
+		prev = i
+	}
+	return bts
+}
+
+// Decode returns the histogram of 'Encode'.
+func Decode(bts []byte) (*Histogram, error) {
+	var err error
+	get := func() uint64 {
+		if err != nil {
+			return 0
+		}
+		v, n := binary.Uvarint(bts)
+		if n <= 0 {
+			err = fmt.Errorf("malformed histogram")
+			return 0
+		}
+		bts = bts[n:]
+		return v
+	}
+	highest, sigfigs := int64(get()), int(get())
+	min, max, sum := int64(get()), int64(get()), math.Float64frombits(get())
+	if err != nil {
+		return nil, err
+	}
+	h, err := New(highest, sigfigs)
+	if err != nil {
+		return nil, err
+	}
+	h.min, h.max, h.sum = min, max, sum
+	idx := 0
+	for len(bts) > 0 {
+		idx += int(get())
+		c := int64(get())
+		if err != nil {
+			return nil, err
+		}
+		if idx < 0 || idx >= len(h.counts) {
+			return nil, fmt.Errorf("histogram index %d out of range [0, %d)", idx, len(h.counts))
+		}
+		h.counts[idx] += c
+		h.totalCount += c
+	}
+	return h, nil
+}
@@ -0,0 +1,185 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hdrhistogram
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestHistogram(t *testing.T) {
+	// 1 to 1,000,000 microseconds
+	h, err := New(3600*1000*1000, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for v := int64(1); v <= 1000000; v++ {
+		h.Record(v)
+	}
+	if h.TotalCount() != 1000000 || h.Min() != 1 || h.Max() != 1000000 {
+		t.Fatalf("unexpected count %d, min %d, max %d", h.TotalCount(), h.Min(), h.Max())
+	}
+	if m := h.Mean(); m != 500000.5 {
+		t.Fatalf("expected mean 500000.5, got %f", m)
+	}
+	for _, tt := range []struct {
+		pct float64
+		exp int64
+	}{
+		{50, 500000},
+		{90, 900000},
+		{99, 990000},
+		{99.9, 999000},
+		{99.99, 999900},
+		{100, 1000000},
+	} {
+		// within 3 significant digits
+		if v := h.ValueAtPercentile(tt.pct); math.Abs(float64(v-tt.exp)) > float64(tt.exp)/1000 {
+			t.Fatalf("p%v: expected %d, got %d", tt.pct, tt.exp, v)
+		}
+	}
+}
+
+func TestHistogramSmallValues(t *testing.T) {
+	h, err := New(1000, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// below 2*10^2, every value is exact
+	for v := int64(1); v <= 100; v++ {
+		h.Record(v)
+	}
+	for _, pct := range []float64{1, 25, 50, 99} {
+		if v := h.ValueAtPercentile(pct); v != int64(pct) {
+			t.Fatalf("p%v: expected %d, got %d", pct, int64(pct), v)
+		}
+	}
+	// clamped
+	h.Record(0)
+	h.Record(5000)
+	if h.Min() != 1 || h.Max() != 1000 {
+		t.Fatalf("unexpected min %d, max %d", h.Min(), h.Max())
+	}
+	if _, err = New(1000, 6); err == nil {
+		t.Fatal("expected error for 6 significant digits")
+	}
+}
+
+func TestHistogramMerge(t *testing.T) {
+	h1, err := New(1000, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h2, err := New(1000, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for v := int64(1); v <= 50; v++ {
+		h1.Record(v)
+	}
+	for v := int64(51); v <= 100; v++ {
+		h2.Record(v)
+	}
+	if err = h1.Merge(h2); err != nil {
+		t.Fatal(err)
+	}
+	if h1.TotalCount() != 100 || h1.Min() != 1 || h1.Max() != 100 || h1.Mean() != 50.5 {
+		t.Fatalf("unexpected count %d, min %d, max %d, mean %f", h1.TotalCount(), h1.Min(), h1.Max(), h1.Mean())
+	}
+	if v := h1.ValueAtPercentile(75); v != 75 {
+		t.Fatalf("p75: expected 75, got %d", v)
+	}
+
+	h3, err := New(1000, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = h1.Merge(h3); err == nil {
+		t.Fatal("expected error of different significant digits")
+	}
+}
+
+func TestHistogramForEach(t *testing.T) {
+	h, err := New(100000, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, v := range []int64{3, 3, 5, 50000} {
+		h.Record(v)
+	}
+	var vs, cs []int64
+	h.ForEach(func(v, c int64) {
+		vs, cs = append(vs, v), append(cs, c)
+	})
+	if len(vs) != 3 || vs[0] != 3 || cs[0] != 2 || vs[1] != 5 || cs[1] != 1 || cs[2] != 1 {
+		t.Fatalf("unexpected values %v, counts %v", vs, cs)
+	}
+	if vs[2] > 50000 || h.highestEquivalent(vs[2]) < 50000 {
+		t.Fatalf("expected the range of 50000, got %d", vs[2])
+	}
+}
+
+func TestHistogramEncode(t *testing.T) {
+	h, err := New(3600000, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for v := int64(1); v <= 10000; v += 7 {
+		h.Record(v)
+	}
+	h.Record(3000000)
+	d, err := Decode(h.Encode())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.TotalCount() != h.TotalCount() || d.Min() != h.Min() || d.Max() != h.Max() || d.Mean() != h.Mean() {
+		t.Fatalf("expected count %d, min %d, max %d, mean %f, got %d, %d, %d, %f", h.TotalCount(), h.Min(), h.Max(), h.Mean(), d.TotalCount(), d.Min(), d.Max(), d.Mean())
+	}
+	for _, pct := range []float64{50, 99, 99.9, 100} {
+		if d.ValueAtPercentile(pct) != h.ValueAtPercentile(pct) {
+			t.Fatalf("p%v: expected %d, got %d", pct, h.ValueAtPercentile(pct), d.ValueAtPercentile(pct))
+		}
+	}
+	if err = d.Merge(h); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = Decode([]byte{0x80}); err == nil {
+		t.Fatal("expected error of malformed histogram")
+	}
+}
+
+func TestWritePercentiles(t *testing.T) {
+	h, _ := New(1000000, 3)
+	for v := int64(1000); v <= 2000; v++ {
+		h.Record(v)
+	}
+	var buf bytes.Buffer
+	if err := h.WritePercentiles(&buf, 1000, 5); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if !strings.Contains(lines[0], "Percentile") || !strings.HasPrefix(lines[len(lines)-3], "#[Mean") {
+		t.Fatalf("unexpected output\n%s", buf.String())
+	}
+	first, last := strings.Fields(lines[2]), strings.Fields(lines[len(lines)-4])
+	if first[0] != "1.000" || first[1] != "0.000000000000" {
+		t.Fatalf("unexpected first line %q", lines[2])
+	}
+	if last[0] != "2.000" || last[1] != "1.000000000000" || last[2] != "1001" || len(last) != 3 {
+		t.Fatalf("unexpected last line %q", lines[len(lines)-4])
+	}
+}
@@ -0,0 +1,530 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package redis implements a minimal Redis client of the RESP protocol,
+// with the commands that the benchmarks issue, for standalone servers
+// and for Redis Cluster with its slot redirections.
+//
+// It stands in for go-redis (github.com/go-redis/redis), which is not
+// vendored. It keeps the API of the commands small, so that the Redis
+// client of the stress can move to go-redis once it is vendored.
+package redis
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+// DefaultDialTimeout is the timeout to connect to a server.
+var DefaultDialTimeout = 5 * time.Second
+
+// Error is an error reply of the server.
+type Error string
+
+func (e Error) Error() string { return string(e) }
+
+// errProtocol is returned on a malformed reply, after which
+// the connection is closed.
+var errProtocol = errors.New("redis: protocol error")
+
+// Conn is a connection to a Redis server. It is safe for concurrent
+// use, but sends one command at a time.
+type Conn struct {
+	addr string
+
+	mu   sync.Mutex
+	conn net.Conn
+	r    *bufio.Reader
+	w    *bufio.Writer
+}
+
+// Dial connects to the Redis server at 'addr'.
+func Dial(addr string) (*Conn, error) {
+	conn, err := net.DialTimeout("tcp", addr, DefaultDialTimeout)
+	if err != nil {
+		return nil, err
+	}
+	return &Conn{addr: addr, conn: conn, r: bufio.NewReader(conn), w: bufio.NewWriter(conn)}, nil
+}
+
+// Addr returns the address of the server.
+func (c *Conn) Addr() string { return c.addr }
+
+// Close closes the connection.
+func (c *Conn) Close() error { return c.conn.Close() }
+
+// Do sends the command and returns its reply: string of a status,
+// int64, []byte of a bulk string (nil if null), []interface{} of an
+// array, or Error of an error reply. The deadline of 'ctx' applies.
+func (c *Conn) Do(ctx context.Context, args ...[]byte) (interface{}, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if d, ok := ctx.Deadline(); ok {
+		c.conn.SetDeadline(d)
+	} else {
+		c.conn.SetDeadline(time.Time{})
+	}
+	if err := writeCommand(c.w, args); err != nil {
+		return nil, err
+	}
+	if err := c.w.Flush(); err != nil {
+		return nil, err
+	}
+	rp, err := readReply(c.r)
+	if err == errProtocol {
+		c.conn.Close()
+	}
+	if err == nil {
+		if e, ok := rp.(Error); ok {
+			return nil, e
+		}
+	}
+	return rp, err
+}
+
+func writeCommand(w *bufio.Writer, args [][]byte) error {
+	fmt.Fprintf(w, "*%d\r\n", len(args))
+	for _, a := range args {
+		fmt.Fprintf(w, "$%d\r\n", len(a))
+		w.Write(a)
+		if _, err := w.WriteString("\r\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func readLine(r *bufio.Reader) (string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	if len(line) < 3 || line[len(line)-2] != '\r' {
+		return "", errProtocol
+	}
+	return line[:len(line)-2], nil
+}
+
+func readReply(r *bufio.Reader) (interface{}, error) {
+	line, err := readLine(r)
+	if err != nil {
+		return nil, err
+	}
+	switch line[0] {
+	case '+':
+		return line[1:], nil
+	case '-':
+		return Error(line[1:]), nil
+	case ':':
+		n, err := strconv.ParseInt(line[1:], 10, 64)
+		if err != nil {
+			return nil, errProtocol
+		}
+		return n, nil
+	case '$':
+		n, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, errProtocol
+		}
+		if n < 0 {
+			return []byte(nil), nil
+		}
+		bts := make([]byte, n+2)
+		if _, err = io.ReadFull(r, bts); err != nil {
+			return nil, err
+		}
+		return bts[:n], nil
+	case '*':
+		n, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, errProtocol
+		}
+		if n < 0 {
+			return []interface{}(nil), nil
+		}
+		rps := make([]interface{}, n)
+		for i := range rps {
+			if rps[i], err = readReply(r); err != nil {
+				return nil, err
+			}
+		}
+		return rps, nil
+	}
+	return nil, errProtocol
+}
+
+// maxRedirects is the number of the MOVED and ASK redirections
+// that a command follows.
+const maxRedirects = 5
+
+// slotN is the number of the hash slots of Redis Cluster.
+const slotN = 16384
+
+// Client sends the commands to a standalone server, or to the nodes of
+// Redis Cluster that serve the slots of their keys.
+type Client struct {
+	endpoint string
+	cluster  bool
+
+	mu    sync.Mutex
+	conns map[string]*Conn
+	// slots maps the slots to the nodes that last redirected them;
+	// empty for the nodes not known yet, to send to 'endpoint'.
+	slots []string
+}
+
+// New returns a client of the server at 'endpoint', or of the cluster
+// that the node at 'endpoint' is of if 'cluster' is true. It connects
+// on the first command.
+func New(endpoint string, cluster bool) *Client {
+	c := &Client{endpoint: endpoint, cluster: cluster, conns: make(map[string]*Conn)}
+	if cluster {
+		c.slots = make([]string, slotN)
+	}
+	return c
+}
+
+// Close closes the connections of the client.
+func (c *Client) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for addr, conn := range c.conns {
+		conn.Close()
+		delete(c.conns, addr)
+	}
+	return nil
+}
+
+func (c *Client) conn(addr string) (*Conn, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if conn, ok := c.conns[addr]; ok {
+		return conn, nil
+	}
+	conn, err := Dial(addr)
+	if err != nil {
+		return nil, err
+	}
+	c.conns[addr] = conn
+	return conn, nil
+}
+
+// drop closes and forgets the connection after a network error,
+// to reconnect on the next command.
+func (c *Client) drop(conn *Conn) {
+	c.mu.Lock()
+	if c.conns[conn.addr] == conn {
+		delete(c.conns, conn.addr)
+	}
+	c.mu.Unlock()
+	conn.Close()
+}
+
+func (c *Client) nodeOf(key []byte) string {
+	if !c.cluster {
+		return c.endpoint
+	}
+	c.mu.Lock()
+	addr := c.slots[Slot(key)]
+	c.mu.Unlock()
+	if addr == "" {
+		return c.endpoint
+	}
+	return addr
+}
+
+// Do sends the command of the key to the node that serves it,
+// following the cluster redirections.
+func (c *Client) Do(ctx context.Context, key []byte, args ...[]byte) (interface{}, error) {
+	return c.doAt(ctx, c.nodeOf(key), args...)
+}
+
+func (c *Client) doAt(ctx context.Context, addr string, args ...[]byte) (interface{}, error) {
+	asking := false
+	for i := 0; ; i++ {
+		conn, err := c.conn(addr)
+		if err != nil {
+			return nil, err
+		}
+		if asking {
+			if _, err = conn.Do(ctx, []byte("ASKING")); err != nil {
+				return nil, err
+			}
+			asking = false
+		}
+		rp, err := conn.Do(ctx, args...)
+		e, ok := err.(Error)
+		if err != nil && !ok {
+			if _, isNet := err.(net.Error); isNet || err == io.EOF || err == errProtocol {
+				c.drop(conn)
+			}
+			return nil, err
+		}
+		if !ok || !c.cluster || i == maxRedirects {
+			return rp, err
+		}
+
+		// "MOVED <slot> <addr>" or "ASK <slot> <addr>"
+		fs := strings.Fields(string(e))
+		if len(fs) != 3 || (fs[0] != "MOVED" && fs[0] != "ASK") {
+			return nil, err
+		}
+		addr = redirectAddr(fs[2])
+		if fs[0] == "MOVED" {
+			if slot, serr := strconv.Atoi(fs[1]); serr == nil && slot >= 0 && slot < slotN {
+				c.mu.Lock()
+				c.slots[slot] = addr
+				c.mu.Unlock()
+			}
+		} else {
+			asking = true
+		}
+	}
+}
+
+// redirectAddr returns the "host:port" to dial of the address of a
+// redirection, which Redis writes with no brackets around IPv6 hosts,
+// as "::1:6379".
+func redirectAddr(addr string) string {
+	i := strings.LastIndex(addr, ":")
+	if i < 0 || strings.HasPrefix(addr, "[") || strings.Count(addr, ":") == 1 {
+		return addr
+	}
+	return net.JoinHostPort(addr[:i], addr[i+1:])
+}
+
+// NodeOf returns the address of the node that serves the key. In a
+// cluster, the node is asked for the key first, to follow its redirection
+// if the slot of the key is not known yet.
+func (c *Client) NodeOf(ctx context.Context, key []byte) (string, error) {
+	if c.cluster {
+		if _, err := c.Do(ctx, key, []byte("EXISTS"), key); err != nil {
+			return "", err
+		}
+	}
+	return c.nodeOf(key), nil
+}
+
+// KeyspaceChannel returns the channel of the keyspace notifications of
+// the key in database 0, the only database of Redis Cluster. The payload
+// of each notification is the command that changed the key, as "set".
+func KeyspaceChannel(key []byte) string {
+	return "__keyspace@0__:" + string(key)
+}
+
+// Subscription is a connection subscribed to Pub/Sub channels,
+// which takes no other commands.
+type Subscription struct {
+	c *Conn
+}
+
+// Subscribe connects to the server at 'addr' and subscribes to the
+// channels, returning once the server confirms them. The deadline of
+// 'ctx' applies to the subscription, not to the messages. In a cluster,
+// keyspace notifications are only sent by the node that serves the key.
+func Subscribe(ctx context.Context, addr string, channels ...string) (*Subscription, error) {
+	c, err := Dial(addr)
+	if err != nil {
+		return nil, err
+	}
+	if d, ok := ctx.Deadline(); ok {
+		c.conn.SetDeadline(d)
+	}
+	args := [][]byte{[]byte("SUBSCRIBE")}
+	for _, ch := range channels {
+		args = append(args, []byte(ch))
+	}
+	if err = writeCommand(c.w, args); err == nil {
+		err = c.w.Flush()
+	}
+	for range channels {
+		if err != nil {
+			break
+		}
+		var rp interface{}
+		if rp, err = readReply(c.r); err != nil {
+			break
+		}
+		if e, ok := rp.(Error); ok {
+			err = e
+			break
+		}
+		if rps, ok := rp.([]interface{}); !ok || len(rps) != 3 || !isKind(rps[0], "subscribe") {
+			err = fmt.Errorf("redis: unexpected SUBSCRIBE reply %v", rp)
+		}
+	}
+	if err != nil {
+		c.Close()
+		return nil, err
+	}
+	c.conn.SetDeadline(time.Time{})
+	return &Subscription{c: c}, nil
+}
+
+func isKind(rp interface{}, kind string) bool {
+	bts, ok := rp.([]byte)
+	return ok && string(bts) == kind
+}
+
+// Receive returns the channel and the payload of the next message. It
+// blocks until a message arrives, or the subscription is closed.
+func (s *Subscription) Receive() (channel string, payload []byte, err error) {
+	for {
+		rp, err := readReply(s.c.r)
+		if err != nil {
+			return "", nil, err
+		}
+		rps, ok := rp.([]interface{})
+		if !ok || len(rps) != 3 {
+			return "", nil, fmt.Errorf("redis: unexpected message %v", rp)
+		}
+		// skips the confirmations of the subscriptions
+		if !isKind(rps[0], "message") {
+			continue
+		}
+		ch, _ := rps[1].([]byte)
+		payload, _ = rps[2].([]byte)
+		return string(ch), payload, nil
+	}
+}
+
+// Close closes the connection, which unblocks 'Receive'.
+func (s *Subscription) Close() error { return s.c.Close() }
+
+// Get returns the value of the key, or nil if not found.
+func (c *Client) Get(ctx context.Context, key []byte) ([]byte, error) {
+	rp, err := c.Do(ctx, key, []byte("GET"), key)
+	if err != nil {
+		return nil, err
+	}
+	v, ok := rp.([]byte)
+	if !ok {
+		return nil, fmt.Errorf("redis: unexpected GET reply %v", rp)
+	}
+	return v, nil
+}
+
+// Set writes the value of the key.
+func (c *Client) Set(ctx context.Context, key, value []byte) error {
+	_, err := c.Do(ctx, key, []byte("SET"), key, value)
+	return err
+}
+
+// Del deletes the key, and returns false if it did not exist.
+func (c *Client) Del(ctx context.Context, key []byte) (bool, error) {
+	rp, err := c.Do(ctx, key, []byte("DEL"), key)
+	if err != nil {
+		return false, err
+	}
+	n, _ := rp.(int64)
+	return n > 0, nil
+}
+
+// Scan returns the keys of the node at 'addr' that match the glob
+// pattern, iterating SCAN with 'count' keys a call. In a cluster,
+// each node only returns its own keys.
+func (c *Client) Scan(ctx context.Context, addr, match string, count int) ([]string, error) {
+	var keys []string
+	cursor := "0"
+	for {
+		rp, err := c.doAt(ctx, addr, []byte("SCAN"), []byte(cursor), []byte("MATCH"), []byte(match), []byte("COUNT"), []byte(strconv.Itoa(count)))
+		if err != nil {
+			return nil, err
+		}
+		rps, ok := rp.([]interface{})
+		if !ok || len(rps) != 2 {
+			return nil, fmt.Errorf("redis: unexpected SCAN reply %v", rp)
+		}
+		next, _ := rps[0].([]byte)
+		ks, _ := rps[1].([]interface{})
+		for _, k := range ks {
+			if bts, ok := k.([]byte); ok {
+				keys = append(keys, string(bts))
+			}
+		}
+		if cursor = string(next); cursor == "0" || cursor == "" {
+			return keys, nil
+		}
+	}
+}
+
+// Info returns the section of INFO of the node at 'addr'
+// as its field names to values.
+func (c *Client) Info(ctx context.Context, addr, section string) (map[string]string, error) {
+	rp, err := c.doAt(ctx, addr, []byte("INFO"), []byte(section))
+	if err != nil {
+		return nil, err
+	}
+	bts, ok := rp.([]byte)
+	if !ok {
+		return nil, fmt.Errorf("redis: unexpected INFO reply %v", rp)
+	}
+	fs := make(map[string]string)
+	for _, line := range strings.Split(string(bts), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || line[0] == '#' {
+			continue
+		}
+		if i := strings.IndexByte(line, ':'); i > 0 {
+			fs[line[:i]] = line[i+1:]
+		}
+	}
+	return fs, nil
+}
+
+// Slot returns the hash slot of the key in Redis Cluster, of the part
+// in the first '{...}' hash tag if any.
+func Slot(key []byte) uint16 {
+	for i, b := range key {
+		if b != '{' {
+			continue
+		}
+		for j := i + 1; j < len(key); j++ {
+			if key[j] == '}' {
+				if j > i+1 {
+					key = key[i+1 : j]
+				}
+				return crc16(key) % slotN
+			}
+		}
+		break
+	}
+	return crc16(key) % slotN
+}
+
+// crc16 is CRC-16/XMODEM, of the Redis Cluster key hashing.
+func crc16(bts []byte) uint16 {
+	var crc uint16
+	for _, b := range bts {
+		crc ^= uint16(b) << 8
+		for i := 0; i < 8; i++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
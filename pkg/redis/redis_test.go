@@ -0,0 +1,259 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redis
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"sync"
+	"testing"
+
+	"golang.org/x/net/context"
+)
+
+func TestSlot(t *testing.T) {
+	if crc := crc16([]byte("123456789")); crc != 0x31c3 {
+		t.Fatalf("expected CRC 0x31c3, got %#x", crc)
+	}
+	if s := Slot([]byte("foo")); s != 12182 {
+		t.Fatalf("expected slot 12182, got %d", s)
+	}
+	if Slot([]byte("{user1000}.following")) != Slot([]byte("user1000")) {
+		t.Fatal("expected the slot of the hash tag")
+	}
+	if Slot([]byte("foo{}{bar}")) != crc16([]byte("foo{}{bar}"))%slotN {
+		t.Fatal("expected the slot of the whole key with an empty hash tag")
+	}
+}
+
+// fakeServer serves GET, SET, DEL, EXISTS and SCAN of its own keys, and
+// redirects the keys of 'moved' to another address. SUBSCRIBE connections
+// receive the keyspace notifications of SET.
+type fakeServer struct {
+	ln    net.Listener
+	mu    sync.Mutex
+	kv    map[string]string
+	moved map[string]string
+	subs  map[string][]*bufio.Writer
+}
+
+func newFakeServer(t *testing.T) *fakeServer {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &fakeServer{ln: ln, kv: make(map[string]string), moved: make(map[string]string), subs: make(map[string][]*bufio.Writer)}
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	return s
+}
+
+func (s *fakeServer) serve(conn net.Conn) {
+	defer conn.Close()
+	r, w := bufio.NewReader(conn), bufio.NewWriter(conn)
+	for {
+		rp, err := readReply(r)
+		if err != nil {
+			return
+		}
+		var args []string
+		for _, a := range rp.([]interface{}) {
+			args = append(args, string(a.([]byte)))
+		}
+		s.mu.Lock()
+		if len(args) > 1 && s.moved[args[1]] != "" {
+			fmt.Fprintf(w, "-MOVED %d %s\r\n", Slot([]byte(args[1])), s.moved[args[1]])
+		} else {
+			switch args[0] {
+			case "SET":
+				s.kv[args[1]] = args[2]
+				w.WriteString("+OK\r\n")
+				ch := KeyspaceChannel([]byte(args[1]))
+				for _, sw := range s.subs[ch] {
+					fmt.Fprintf(sw, "*3\r\n$7\r\nmessage\r\n$%d\r\n%s\r\n$3\r\nset\r\n", len(ch), ch)
+					sw.Flush()
+				}
+			case "SUBSCRIBE":
+				for i, ch := range args[1:] {
+					s.subs[ch] = append(s.subs[ch], w)
+					fmt.Fprintf(w, "*3\r\n$9\r\nsubscribe\r\n$%d\r\n%s\r\n:%d\r\n", len(ch), ch, i+1)
+				}
+			case "EXISTS":
+				if _, ok := s.kv[args[1]]; ok {
+					w.WriteString(":1\r\n")
+				} else {
+					w.WriteString(":0\r\n")
+				}
+			case "GET":
+				if v, ok := s.kv[args[1]]; ok {
+					fmt.Fprintf(w, "$%d\r\n%s\r\n", len(v), v)
+				} else {
+					w.WriteString("$-1\r\n")
+				}
+			case "DEL":
+				_, ok := s.kv[args[1]]
+				delete(s.kv, args[1])
+				if ok {
+					w.WriteString(":1\r\n")
+				} else {
+					w.WriteString(":0\r\n")
+				}
+			case "SCAN":
+				// all keys in one call, ignoring the pattern
+				fmt.Fprintf(w, "*2\r\n$1\r\n0\r\n*%d\r\n", len(s.kv))
+				for k := range s.kv {
+					fmt.Fprintf(w, "$%d\r\n%s\r\n", len(k), k)
+				}
+			default:
+				fmt.Fprintf(w, "-ERR unknown command '%s'\r\n", args[0])
+			}
+		}
+		// flushed under the lock, as of the notifications to subscribers
+		err = w.Flush()
+		s.mu.Unlock()
+		if err != nil {
+			return
+		}
+	}
+}
+
+func TestRedirectAddr(t *testing.T) {
+	for addr, exp := range map[string]string{
+		"127.0.0.1:6379":   "127.0.0.1:6379",
+		"::1:6379":         "[::1]:6379",
+		"fe80::1:2:6380":   "[fe80::1:2]:6380",
+		"[::1]:6379":       "[::1]:6379",
+		"redis-0.svc:6379": "redis-0.svc:6379",
+	} {
+		if got := redirectAddr(addr); got != exp {
+			t.Fatalf("%q: expected %q, got %q", addr, exp, got)
+		}
+	}
+}
+
+func TestClient(t *testing.T) {
+	s := newFakeServer(t)
+	defer s.ln.Close()
+
+	cli := New(s.ln.Addr().String(), false)
+	defer cli.Close()
+	ctx := context.Background()
+	if err := cli.Set(ctx, []byte("foo"), []byte("bar")); err != nil {
+		t.Fatal(err)
+	}
+	if v, err := cli.Get(ctx, []byte("foo")); err != nil || string(v) != "bar" {
+		t.Fatalf("expected 'bar', got %q (%v)", v, err)
+	}
+	if v, err := cli.Get(ctx, []byte("none")); err != nil || v != nil {
+		t.Fatalf("expected nil, got %q (%v)", v, err)
+	}
+	if ok, err := cli.Del(ctx, []byte("foo")); err != nil || !ok {
+		t.Fatalf("expected deleted, got %v (%v)", ok, err)
+	}
+	if _, err := cli.Do(ctx, nil, []byte("PING")); err == nil {
+		t.Fatal("expected error reply")
+	} else if _, ok := err.(Error); !ok {
+		t.Fatalf("expected Error, got %T", err)
+	}
+}
+
+func TestClientClusterRedirect(t *testing.T) {
+	a, b := newFakeServer(t), newFakeServer(t)
+	defer a.ln.Close()
+	defer b.ln.Close()
+	a.moved["foo"] = b.ln.Addr().String()
+
+	cli := New(a.ln.Addr().String(), true)
+	defer cli.Close()
+	ctx := context.Background()
+	if err := cli.Set(ctx, []byte("foo"), []byte("bar")); err != nil {
+		t.Fatal(err)
+	}
+	a.mu.Lock()
+	b.mu.Lock()
+	if b.kv["foo"] != "bar" || len(a.kv) != 0 {
+		t.Fatalf("expected the key on the redirected node, got %v and %v", a.kv, b.kv)
+	}
+	b.mu.Unlock()
+	a.mu.Unlock()
+	if addr := cli.nodeOf([]byte("foo")); addr != b.ln.Addr().String() {
+		t.Fatalf("expected the slot of 'foo' on %q, got %q", b.ln.Addr(), addr)
+	}
+	if keys, err := cli.Scan(ctx, b.ln.Addr().String(), "*", 10); err != nil || len(keys) != 1 || keys[0] != "foo" {
+		t.Fatalf("expected [foo], got %v (%v)", keys, err)
+	}
+
+	// not followed if not in cluster mode
+	standalone := New(a.ln.Addr().String(), false)
+	defer standalone.Close()
+	if err := standalone.Set(ctx, []byte("foo"), []byte("bar")); err == nil {
+		t.Fatal("expected MOVED error")
+	}
+}
+
+func TestSubscribe(t *testing.T) {
+	a, b := newFakeServer(t), newFakeServer(t)
+	defer a.ln.Close()
+	defer b.ln.Close()
+	a.moved["foo"] = b.ln.Addr().String()
+
+	cli := New(a.ln.Addr().String(), true)
+	defer cli.Close()
+	ctx := context.Background()
+	addr, err := cli.NodeOf(ctx, []byte("foo"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if addr != b.ln.Addr().String() {
+		t.Fatalf("expected 'foo' on %q, got %q", b.ln.Addr(), addr)
+	}
+	sub, err := Subscribe(ctx, addr, KeyspaceChannel([]byte("foo")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sub.Close()
+
+	for i := 0; i < 3; i++ {
+		if err = cli.Set(ctx, []byte("foo"), []byte("bar")); err != nil {
+			t.Fatal(err)
+		}
+		ch, payload, err := sub.Receive()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ch != "__keyspace@0__:foo" || string(payload) != "set" {
+			t.Fatalf("#%d: unexpected message %q %q", i, ch, payload)
+		}
+	}
+
+	// closing unblocks the receive
+	errc := make(chan error, 1)
+	go func() {
+		_, _, err := sub.Receive()
+		errc <- err
+	}()
+	sub.Close()
+	if err = <-errc; err == nil {
+		t.Fatal("expected error of closed subscription")
+	}
+}
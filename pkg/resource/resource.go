@@ -0,0 +1,110 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package resource samples the resource usage of the processes, and of
+// the disk device and the network interface of the host.
+package resource
+
+import (
+	"fmt"
+
+	"github.com/gyuho/linux-inspect/inspect"
+)
+
+// sectorBytes is the size of the sectors of '/proc/diskstats'.
+const sectorBytes = 512
+
+// Usage is the resource usage at a sample. The disk and network bytes
+// are the totals since boot, of the disk device and the network interface.
+type Usage struct {
+	CPUPercent float64
+	VMRSSBytes int64
+
+	DiskReadBytes  int64
+	DiskWriteBytes int64
+
+	NetworkReceiveBytes  int64
+	NetworkTransmitBytes int64
+}
+
+// Sample returns the usage of the process of the PID, or the sum of all
+// processes of the program if the PID is 0, and the usage of the disk
+// device and the network interface if not empty.
+func Sample(pid int64, program, diskDevice, networkInterface string) (Usage, error) {
+	var u Usage
+	var opt inspect.OpFunc
+	switch {
+	case pid > 0:
+		opt = inspect.WithPID(pid)
+	case program != "":
+		opt = inspect.WithProgram(program)
+	default:
+		return u, fmt.Errorf("resource: no PID or program to sample")
+	}
+	pss, err := inspect.GetPS(opt)
+	if err != nil {
+		return u, err
+	}
+	if len(pss) == 0 {
+		return u, fmt.Errorf("resource: no process of PID %d or program %q", pid, program)
+	}
+	for _, ps := range pss {
+		u.CPUPercent += ps.CPUNum
+		u.VMRSSBytes += int64(ps.VMRSSNum)
+	}
+	err = u.sampleDevices(diskDevice, networkInterface)
+	return u, err
+}
+
+// sampleDevices sets the totals of the disk device and the network
+// interface, of the ones that are not empty.
+func (u *Usage) sampleDevices(diskDevice, networkInterface string) error {
+	if diskDevice != "" {
+		dss, err := inspect.GetDS()
+		if err != nil {
+			return err
+		}
+		found := false
+		for _, ds := range dss {
+			if ds.Device == diskDevice {
+				u.DiskReadBytes = int64(ds.SectorsRead) * sectorBytes
+				u.DiskWriteBytes = int64(ds.SectorsWritten) * sectorBytes
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("resource: disk device %q is not found", diskDevice)
+		}
+	}
+	if networkInterface != "" {
+		nss, err := inspect.GetNS()
+		if err != nil {
+			return err
+		}
+		found := false
+		for _, ns := range nss {
+			if ns.Interface == networkInterface {
+				u.NetworkReceiveBytes = int64(ns.ReceiveBytesNum)
+				u.NetworkTransmitBytes = int64(ns.TransmitBytesNum)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("resource: network interface %q is not found", networkInterface)
+		}
+	}
+	return nil
+}
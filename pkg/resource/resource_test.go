@@ -0,0 +1,39 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import "testing"
+
+func TestSampleDevices(t *testing.T) {
+	var u Usage
+	if err := u.sampleDevices("", "lo"); err != nil {
+		t.Skipf("no loopback interface (%v)", err)
+	}
+	if u.NetworkReceiveBytes < 0 || u.DiskReadBytes != 0 {
+		t.Fatalf("unexpected usage %+v", u)
+	}
+	if err := u.sampleDevices("", "no-such-interface"); err == nil {
+		t.Fatal("expected error of the unknown interface")
+	}
+	if err := u.sampleDevices("no-such-device", ""); err == nil {
+		t.Fatal("expected error of the unknown device")
+	}
+}
+
+func TestSampleNoProcess(t *testing.T) {
+	if _, err := Sample(0, "", "", ""); err == nil {
+		t.Fatal("expected error of no PID or program")
+	}
+}
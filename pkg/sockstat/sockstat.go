@@ -0,0 +1,237 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sockstat samples the TCP sockets and the TCP counters of the
+// host, and its limits of the ephemeral ports and the conntrack entries.
+package sockstat
+
+import (
+	"bufio"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Counters are the TCP counters of '/proc/net/snmp', the totals since boot.
+type Counters struct {
+	ActiveOpens  int64
+	AttemptFails int64
+	EstabResets  int64
+	RetransSegs  int64
+	OutRsts      int64
+}
+
+// Sub returns the counters since the earlier counters.
+func (c Counters) Sub(earlier Counters) Counters {
+	return Counters{
+		ActiveOpens:  c.ActiveOpens - earlier.ActiveOpens,
+		AttemptFails: c.AttemptFails - earlier.AttemptFails,
+		EstabResets:  c.EstabResets - earlier.EstabResets,
+		RetransSegs:  c.RetransSegs - earlier.RetransSegs,
+		OutRsts:      c.OutRsts - earlier.OutRsts,
+	}
+}
+
+// ReadCounters reads the TCP counters of the host.
+func ReadCounters() (Counters, error) {
+	f, err := os.Open("/proc/net/snmp")
+	if err != nil {
+		return Counters{}, err
+	}
+	defer f.Close()
+	return parseSNMP(f)
+}
+
+// parseSNMP parses the 'Tcp:' lines of '/proc/net/snmp',
+// of the names and of the values.
+func parseSNMP(r io.Reader) (Counters, error) {
+	var c Counters
+	var names []string
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		fs := strings.Fields(sc.Text())
+		if len(fs) == 0 || fs[0] != "Tcp:" {
+			continue
+		}
+		if names == nil {
+			names = fs[1:]
+			continue
+		}
+		if len(fs[1:]) != len(names) {
+			return c, fmt.Errorf("sockstat: got %d TCP values of %d names", len(fs[1:]), len(names))
+		}
+		for i, name := range names {
+			var dst *int64
+			switch name {
+			case "ActiveOpens":
+				dst = &c.ActiveOpens
+			case "AttemptFails":
+				dst = &c.AttemptFails
+			case "EstabResets":
+				dst = &c.EstabResets
+			case "RetransSegs":
+				dst = &c.RetransSegs
+			case "OutRsts":
+				dst = &c.OutRsts
+			default:
+				continue
+			}
+			v, err := strconv.ParseInt(fs[i+1], 10, 64)
+			if err != nil {
+				return c, fmt.Errorf("sockstat: cannot parse %s %q (%v)", name, fs[i+1], err)
+			}
+			*dst = v
+		}
+		return c, nil
+	}
+	if err := sc.Err(); err != nil {
+		return c, err
+	}
+	return c, fmt.Errorf("sockstat: no TCP counters")
+}
+
+// Socket is a TCP socket of the host.
+type Socket struct {
+	// Remote is the remote address of 'host:port'.
+	Remote string
+	State  string
+}
+
+// socketStates are the names of the states of '/proc/net/tcp'.
+var socketStates = map[string]string{
+	"01": "ESTABLISHED",
+	"02": "SYN_SENT",
+	"03": "SYN_RECV",
+	"04": "FIN_WAIT1",
+	"05": "FIN_WAIT2",
+	"06": "TIME_WAIT",
+	"07": "CLOSE",
+	"08": "CLOSE_WAIT",
+	"09": "LAST_ACK",
+	"0A": "LISTEN",
+	"0B": "CLOSING",
+}
+
+// ReadSockets reads the IPv4 and IPv6 TCP sockets of the host.
+func ReadSockets() ([]Socket, error) {
+	var ss []Socket
+	for _, fpath := range []string{"/proc/net/tcp", "/proc/net/tcp6"} {
+		f, err := os.Open(fpath)
+		if err != nil {
+			if os.IsNotExist(err) && len(ss) > 0 {
+				// IPv6 is disabled
+				continue
+			}
+			return nil, err
+		}
+		s, err := parseSockets(f)
+		f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("sockstat: %s: %v", fpath, err)
+		}
+		ss = append(ss, s...)
+	}
+	return ss, nil
+}
+
+// parseSockets parses the lines of '/proc/net/tcp' or '/proc/net/tcp6'.
+func parseSockets(r io.Reader) ([]Socket, error) {
+	var ss []Socket
+	sc := bufio.NewScanner(r)
+	for first := true; sc.Scan(); first = false {
+		fs := strings.Fields(sc.Text())
+		if first || len(fs) < 4 {
+			// header
+			continue
+		}
+		remote, err := parseAddress(fs[2])
+		if err != nil {
+			return nil, err
+		}
+		state, ok := socketStates[fs[3]]
+		if !ok {
+			state = fs[3]
+		}
+		ss = append(ss, Socket{Remote: remote, State: state})
+	}
+	return ss, sc.Err()
+}
+
+// parseAddress parses the hexadecimal 'address:port', of the address
+// in the 32-bit words of the host byte order, little endian.
+func parseAddress(s string) (string, error) {
+	i := strings.IndexByte(s, ':')
+	if i < 0 {
+		return "", fmt.Errorf("cannot parse address %q", s)
+	}
+	b, err := hex.DecodeString(s[:i])
+	if err != nil || (len(b) != net.IPv4len && len(b) != net.IPv6len) {
+		return "", fmt.Errorf("cannot parse address %q", s)
+	}
+	for w := 0; w < len(b); w += 4 {
+		b[w], b[w+1], b[w+2], b[w+3] = b[w+3], b[w+2], b[w+1], b[w]
+	}
+	port, err := strconv.ParseUint(s[i+1:], 16, 16)
+	if err != nil {
+		return "", fmt.Errorf("cannot parse port of %q (%v)", s, err)
+	}
+	return net.JoinHostPort(net.IP(b).String(), strconv.FormatUint(port, 10)), nil
+}
+
+// ReadEphemeralPorts returns the number of the local ports of the host
+// for the outgoing connections, of 'ip_local_port_range'.
+func ReadEphemeralPorts() (int64, error) {
+	bts, err := ioutil.ReadFile("/proc/sys/net/ipv4/ip_local_port_range")
+	if err != nil {
+		return 0, err
+	}
+	fs := strings.Fields(string(bts))
+	if len(fs) != 2 {
+		return 0, fmt.Errorf("sockstat: cannot parse port range %q", bts)
+	}
+	low, err := strconv.ParseInt(fs[0], 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	high, err := strconv.ParseInt(fs[1], 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	return high - low + 1, nil
+}
+
+// ReadConntrack returns the number of the conntrack entries and its
+// limit. It returns an error if the conntrack module is not loaded.
+func ReadConntrack() (count, max int64, err error) {
+	if count, err = readInt("/proc/sys/net/netfilter/nf_conntrack_count"); err != nil {
+		return 0, 0, err
+	}
+	if max, err = readInt("/proc/sys/net/netfilter/nf_conntrack_max"); err != nil {
+		return 0, 0, err
+	}
+	return count, max, nil
+}
+
+func readInt(fpath string) (int64, error) {
+	bts, err := ioutil.ReadFile(fpath)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(strings.TrimSpace(string(bts)), 10, 64)
+}
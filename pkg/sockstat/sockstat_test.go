@@ -0,0 +1,92 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sockstat
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testSNMP = `Ip: Forwarding DefaultTTL InReceives
+Ip: 1 64 39421
+Tcp: RtoAlgorithm RtoMin RtoMax MaxConn ActiveOpens PassiveOpens AttemptFails EstabResets CurrEstab InSegs OutSegs RetransSegs InErrs OutRsts InCsumErrors
+Tcp: 1 200 120000 -1 1185 1147 24 1103 2 39221 39277 7 0 1086 0
+Udp: InDatagrams NoPorts
+Udp: 10 0
+`
+
+func TestParseSNMP(t *testing.T) {
+	c, err := parseSNMP(strings.NewReader(testSNMP))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := Counters{ActiveOpens: 1185, AttemptFails: 24, EstabResets: 1103, RetransSegs: 7, OutRsts: 1086}
+	if !reflect.DeepEqual(c, expected) {
+		t.Fatalf("expected %+v, got %+v", expected, c)
+	}
+	if d := c.Sub(Counters{ActiveOpens: 1000, RetransSegs: 2}); d.ActiveOpens != 185 || d.RetransSegs != 5 {
+		t.Fatalf("unexpected difference %+v", d)
+	}
+
+	if _, err = parseSNMP(strings.NewReader("Ip: Forwarding\nIp: 1\n")); err == nil {
+		t.Fatal("expected error of no TCP counters")
+	}
+}
+
+const testTCP = `  sl  local_address rem_address   st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode
+   0: 0100007F:0CEA 00000000:0000 0A 00000000:00000000 00:00000000 00000000     0        0 17612 1 0000000000000000 100 0 0 10 0
+   1: 0100007F:D2F4 0100007F:094B 01 00000000:00000000 00:00000000 00000000     0        0 18234 1 0000000000000000 20 4 30 10 -1
+   2: 0100007F:D2F6 0100007F:094B 06 00000000:00000000 03:00000F2F 00000000     0        0 0 3 0000000000000000
+`
+
+const testTCP6 = `  sl  local_address                         remote_address                        st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode
+   0: 00000000000000000000000001000000:D2F8 00000000000000000000000001000000:094B 01 00000000:00000000 00:00000000 00000000     0        0 18240 1 0000000000000000 20 4 30 10 -1
+`
+
+func TestParseSockets(t *testing.T) {
+	ss, err := parseSockets(strings.NewReader(testTCP))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []Socket{
+		{Remote: "0.0.0.0:0", State: "LISTEN"},
+		{Remote: "127.0.0.1:2379", State: "ESTABLISHED"},
+		{Remote: "127.0.0.1:2379", State: "TIME_WAIT"},
+	}
+	if !reflect.DeepEqual(ss, expected) {
+		t.Fatalf("expected %+v, got %+v", expected, ss)
+	}
+
+	ss, err = parseSockets(strings.NewReader(testTCP6))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ss) != 1 || ss[0].Remote != "[::1]:2379" {
+		t.Fatalf("unexpected IPv6 sockets %+v", ss)
+	}
+}
+
+func TestRead(t *testing.T) {
+	if _, err := ReadCounters(); err != nil {
+		t.Skipf("no TCP counters (%v)", err)
+	}
+	if _, err := ReadSockets(); err != nil {
+		t.Fatal(err)
+	}
+	if n, err := ReadEphemeralPorts(); err != nil || n <= 0 {
+		t.Fatalf("unexpected ephemeral ports %d (%v)", n, err)
+	}
+}
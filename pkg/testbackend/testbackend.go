@@ -0,0 +1,270 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package testbackend runs single-node databases in Docker containers,
+// for the integration tests of the benchmarks against real databases.
+// The tests run only if 'DBTESTER_INTEGRATION' is set, on the databases
+// of the endpoints of the environment (e.g. of 'docker-compose'), or in
+// the containers started for the test.
+package testbackend
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+// EtcdV2V3Prefix is the prefix of the v3 keys of the v2 API emulation
+// of the etcd server, as of '--experimental-enable-v2v3'.
+const EtcdV2V3Prefix = "/v2v3"
+
+var (
+	// ReadyTimeout is the timeout of the database to serve the clients
+	// after its container is started.
+	ReadyTimeout = time.Minute
+	// dialTimeout is the timeout of each readiness check
+	dialTimeout = 3 * time.Second
+)
+
+// backend is a database to run in a container.
+type backend struct {
+	image string
+	// port is the client port of the database in the container
+	port int
+	args []string
+	// env is the variable of the endpoint of the database already
+	// running, instead of starting a container
+	env   string
+	ready func(endpoint string) error
+}
+
+var backends = map[string]backend{
+	"etcd__tip": {
+		image: "quay.io/coreos/etcd:v3.3.10",
+		port:  2379,
+		args: []string{
+			"etcd",
+			"--name", "s1",
+			"--data-dir", "/etcd-data",
+			"--listen-client-urls", "http://0.0.0.0:2379",
+			"--advertise-client-urls", "http://127.0.0.1:2379",
+			"--experimental-enable-v2v3", EtcdV2V3Prefix,
+		},
+		env:   "DBTESTER_INTEGRATION_ETCD",
+		ready: etcdReady,
+	},
+	"zookeeper__r3_5_3_beta": {
+		image: "zookeeper:3.5",
+		port:  2181,
+		env:   "DBTESTER_INTEGRATION_ZOOKEEPER",
+		ready: zookeeperReady,
+	},
+	"consul__v1_0_2": {
+		image: "consul:1.0.2",
+		port:  8500,
+		args:  []string{"agent", "-dev", "-client", "0.0.0.0"},
+		env:   "DBTESTER_INTEGRATION_CONSUL",
+		ready: consulReady,
+	},
+}
+
+// DatabaseIDs returns the IDs of the databases to run, sorted.
+func DatabaseIDs() []string {
+	ids := make([]string, 0, len(backends))
+	for id := range backends {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Backend is a database to run the integration tests against.
+type Backend struct {
+	DatabaseID string
+	// Endpoint is the client endpoint of the database, on the host.
+	Endpoint string
+
+	// container is the ID of the container, empty if the database
+	// was already running
+	container string
+}
+
+// Start starts the database in a container, and waits until it
+// serves the clients.
+func Start(databaseID string) (*Backend, error) {
+	bk, ok := backends[databaseID]
+	if !ok {
+		return nil, fmt.Errorf("no container of %q (expected one of %v)", databaseID, DatabaseIDs())
+	}
+
+	args := append([]string{"run", "-d", "--rm", "-p", fmt.Sprintf("127.0.0.1::%d", bk.port), bk.image}, bk.args...)
+	out, err := docker(args...)
+	if err != nil {
+		return nil, err
+	}
+	b := &Backend{DatabaseID: databaseID, container: strings.TrimSpace(out)}
+
+	// e.g. '127.0.0.1:32768'
+	out, err = docker("port", b.container, fmt.Sprintf("%d/tcp", bk.port))
+	if err != nil {
+		b.Stop()
+		return nil, err
+	}
+	b.Endpoint = strings.TrimSpace(strings.SplitN(out, "\n", 2)[0])
+
+	if err = waitReady(bk, b.Endpoint); err != nil {
+		logs, _ := docker("logs", "--tail", "20", b.container)
+		b.Stop()
+		return nil, fmt.Errorf("%q is not ready at %q (%v)\n%s", databaseID, b.Endpoint, err, logs)
+	}
+	return b, nil
+}
+
+// Stop removes the container of the database, if started by 'Start'.
+func (b *Backend) Stop() error {
+	if b.container == "" {
+		return nil
+	}
+	_, err := docker("rm", "-f", b.container)
+	return err
+}
+
+// Require returns the database to test against, or skips the test if
+// 'DBTESTER_INTEGRATION' is not set, or if Docker is not installed to
+// start it. The database is of the endpoint of its environment variable
+// (e.g. 'DBTESTER_INTEGRATION_ETCD=localhost:2379') if set, or else
+// started in a container that 'Stop' removes.
+func Require(t testing.TB, databaseID string) *Backend {
+	if os.Getenv("DBTESTER_INTEGRATION") == "" {
+		t.Skip("set 'DBTESTER_INTEGRATION' to run the integration tests")
+	}
+	bk, ok := backends[databaseID]
+	if !ok {
+		t.Fatalf("no container of %q (expected one of %v)", databaseID, DatabaseIDs())
+	}
+
+	if ep := os.Getenv(bk.env); ep != "" {
+		if err := waitReady(bk, ep); err != nil {
+			t.Fatalf("%q of %q is not ready (%v)", ep, bk.env, err)
+		}
+		return &Backend{DatabaseID: databaseID, Endpoint: ep}
+	}
+
+	if _, err := exec.LookPath("docker"); err != nil {
+		t.Skipf("docker is not installed to start %q (%v)", databaseID, err)
+	}
+	b, err := Start(databaseID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func docker(args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command("docker", args...)
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("'docker %s' failed (%v, %q)", strings.Join(args, " "), err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.String(), nil
+}
+
+func waitReady(bk backend, endpoint string) error {
+	deadline := time.Now().Add(ReadyTimeout)
+	for {
+		err := bk.ready(endpoint)
+		if err == nil {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return err
+		}
+		time.Sleep(500 * time.Millisecond)
+	}
+}
+
+func httpGet(url string) (string, error) {
+	cli := &http.Client{Timeout: dialTimeout}
+	resp, err := cli.Get(url)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	bts, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("%q returned %q (%q)", url, resp.Status, bts)
+	}
+	return string(bts), nil
+}
+
+// etcdReady returns nil once the member has a leader.
+func etcdReady(endpoint string) error {
+	body, err := httpGet("http://" + endpoint + "/health")
+	if err != nil {
+		return err
+	}
+	if !strings.Contains(body, `"true"`) {
+		return fmt.Errorf("etcd is not healthy (%q)", body)
+	}
+	return nil
+}
+
+// zookeeperReady returns nil once the server serves the clients,
+// by the 'srvr' command, the only one allowed by default since 3.5.3.
+func zookeeperReady(endpoint string) error {
+	conn, err := net.DialTimeout("tcp", endpoint, dialTimeout)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(dialTimeout))
+	if _, err = conn.Write([]byte("srvr")); err != nil {
+		return err
+	}
+	sc := bufio.NewScanner(conn)
+	for sc.Scan() {
+		if strings.HasPrefix(sc.Text(), "Mode: ") {
+			return nil
+		}
+	}
+	if err = sc.Err(); err != nil {
+		return err
+	}
+	return fmt.Errorf("zookeeper is not serving")
+}
+
+// consulReady returns nil once the agent has elected itself.
+func consulReady(endpoint string) error {
+	body, err := httpGet("http://" + endpoint + "/v1/status/leader")
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(body) == `""` {
+		return fmt.Errorf("consul has no leader")
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testbackend
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReady(t *testing.T) {
+	healthy := false
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/health":
+			fmt.Fprintf(w, `{"health":"%v"}`, healthy)
+		case "/v1/status/leader":
+			if healthy {
+				fmt.Fprint(w, `"127.0.0.1:8300"`)
+			} else {
+				fmt.Fprint(w, `""`)
+			}
+		}
+	}))
+	defer ts.Close()
+	ep := strings.TrimPrefix(ts.URL, "http://")
+
+	if err := etcdReady(ep); err == nil {
+		t.Fatal("expected etcd not ready")
+	}
+	if err := consulReady(ep); err == nil {
+		t.Fatal("expected consul not ready")
+	}
+	healthy = true
+	if err := etcdReady(ep); err != nil {
+		t.Fatal(err)
+	}
+	if err := consulReady(ep); err != nil {
+		t.Fatal(err)
+	}
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			buf := make([]byte, 4)
+			conn.Read(buf)
+			if string(buf) == "srvr" {
+				conn.Write([]byte("Zookeeper version: 3.5.3-beta\nLatency min/avg/max: 0/0/0\nMode: standalone\n"))
+			}
+			conn.Close()
+		}
+	}()
+	if err = zookeeperReady(ln.Addr().String()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWaitReady(t *testing.T) {
+	defer func(d time.Duration) { ReadyTimeout = d }(ReadyTimeout)
+	ReadyTimeout = time.Second
+
+	n := 0
+	bk := backend{ready: func(string) error {
+		if n++; n < 3 {
+			return fmt.Errorf("not ready")
+		}
+		return nil
+	}}
+	if err := waitReady(bk, ""); err != nil || n != 3 {
+		t.Fatalf("expected ready after 3 checks, got %v after %d", err, n)
+	}
+
+	bk.ready = func(string) error { return fmt.Errorf("never ready") }
+	if err := waitReady(bk, ""); err == nil {
+		t.Fatal("expected timeout")
+	}
+}
+
+func TestDatabaseIDs(t *testing.T) {
+	ids := DatabaseIDs()
+	if len(ids) != 3 || ids[0] != "consul__v1_0_2" || ids[1] != "etcd__tip" || ids[2] != "zookeeper__r3_5_3_beta" {
+		t.Fatalf("unexpected database IDs %v", ids)
+	}
+	if _, err := Start("mock"); err == nil {
+		t.Fatal("expected error of no container")
+	}
+}
@@ -0,0 +1,273 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tikv
+
+import "github.com/golang/protobuf/proto"
+
+// The messages of kvproto (https://github.com/pingcap/kvproto) that the
+// client sends and receives, with only the fields that it uses. The other
+// fields of the replies are skipped on decoding.
+
+// gRPC methods of the PD and TiKV services.
+const (
+	methodGetMembers = "/pdpb.PD/GetMembers"
+	methodGetRegion  = "/pdpb.PD/GetRegion"
+	methodGetStore   = "/pdpb.PD/GetStore"
+
+	methodRawGet    = "/tikvpb.Tikv/RawGet"
+	methodRawPut    = "/tikvpb.Tikv/RawPut"
+	methodRawDelete = "/tikvpb.Tikv/RawDelete"
+	methodRawScan   = "/tikvpb.Tikv/RawScan"
+)
+
+// metapb
+
+type regionEpoch struct {
+	ConfVer uint64 `protobuf:"varint,1,opt,name=conf_ver,json=confVer"`
+	Version uint64 `protobuf:"varint,2,opt,name=version"`
+}
+
+func (m *regionEpoch) Reset()         { *m = regionEpoch{} }
+func (m *regionEpoch) String() string { return proto.CompactTextString(m) }
+func (*regionEpoch) ProtoMessage()    {}
+
+type peer struct {
+	ID      uint64 `protobuf:"varint,1,opt,name=id"`
+	StoreID uint64 `protobuf:"varint,2,opt,name=store_id,json=storeId"`
+}
+
+func (m *peer) Reset()         { *m = peer{} }
+func (m *peer) String() string { return proto.CompactTextString(m) }
+func (*peer) ProtoMessage()    {}
+
+type region struct {
+	ID          uint64       `protobuf:"varint,1,opt,name=id"`
+	StartKey    []byte       `protobuf:"bytes,2,opt,name=start_key,json=startKey,proto3"`
+	EndKey      []byte       `protobuf:"bytes,3,opt,name=end_key,json=endKey,proto3"`
+	RegionEpoch *regionEpoch `protobuf:"bytes,4,opt,name=region_epoch,json=regionEpoch"`
+	Peers       []*peer      `protobuf:"bytes,5,rep,name=peers"`
+}
+
+func (m *region) Reset()         { *m = region{} }
+func (m *region) String() string { return proto.CompactTextString(m) }
+func (*region) ProtoMessage()    {}
+
+type store struct {
+	ID      uint64 `protobuf:"varint,1,opt,name=id"`
+	Address string `protobuf:"bytes,2,opt,name=address"`
+}
+
+func (m *store) Reset()         { *m = store{} }
+func (m *store) String() string { return proto.CompactTextString(m) }
+func (*store) ProtoMessage()    {}
+
+// pdpb
+
+type requestHeader struct {
+	ClusterID uint64 `protobuf:"varint,1,opt,name=cluster_id,json=clusterId"`
+}
+
+func (m *requestHeader) Reset()         { *m = requestHeader{} }
+func (m *requestHeader) String() string { return proto.CompactTextString(m) }
+func (*requestHeader) ProtoMessage()    {}
+
+type pdError struct {
+	Type    int32  `protobuf:"varint,1,opt,name=type"`
+	Message string `protobuf:"bytes,2,opt,name=message"`
+}
+
+func (m *pdError) Reset()         { *m = pdError{} }
+func (m *pdError) String() string { return proto.CompactTextString(m) }
+func (*pdError) ProtoMessage()    {}
+
+type responseHeader struct {
+	ClusterID uint64   `protobuf:"varint,1,opt,name=cluster_id,json=clusterId"`
+	Error     *pdError `protobuf:"bytes,2,opt,name=error"`
+}
+
+func (m *responseHeader) Reset()         { *m = responseHeader{} }
+func (m *responseHeader) String() string { return proto.CompactTextString(m) }
+func (*responseHeader) ProtoMessage()    {}
+
+type getMembersRequest struct {
+	Header *requestHeader `protobuf:"bytes,1,opt,name=header"`
+}
+
+func (m *getMembersRequest) Reset()         { *m = getMembersRequest{} }
+func (m *getMembersRequest) String() string { return proto.CompactTextString(m) }
+func (*getMembersRequest) ProtoMessage()    {}
+
+type member struct {
+	Name     string `protobuf:"bytes,1,opt,name=name"`
+	MemberID uint64 `protobuf:"varint,2,opt,name=member_id,json=memberId"`
+}
+
+func (m *member) Reset()         { *m = member{} }
+func (m *member) String() string { return proto.CompactTextString(m) }
+func (*member) ProtoMessage()    {}
+
+type getMembersResponse struct {
+	Header  *responseHeader `protobuf:"bytes,1,opt,name=header"`
+	Members []*member       `protobuf:"bytes,2,rep,name=members"`
+}
+
+func (m *getMembersResponse) Reset()         { *m = getMembersResponse{} }
+func (m *getMembersResponse) String() string { return proto.CompactTextString(m) }
+func (*getMembersResponse) ProtoMessage()    {}
+
+type getRegionRequest struct {
+	Header    *requestHeader `protobuf:"bytes,1,opt,name=header"`
+	RegionKey []byte         `protobuf:"bytes,2,opt,name=region_key,json=regionKey,proto3"`
+}
+
+func (m *getRegionRequest) Reset()         { *m = getRegionRequest{} }
+func (m *getRegionRequest) String() string { return proto.CompactTextString(m) }
+func (*getRegionRequest) ProtoMessage()    {}
+
+type getRegionResponse struct {
+	Header *responseHeader `protobuf:"bytes,1,opt,name=header"`
+	Region *region         `protobuf:"bytes,2,opt,name=region"`
+	Leader *peer           `protobuf:"bytes,3,opt,name=leader"`
+}
+
+func (m *getRegionResponse) Reset()         { *m = getRegionResponse{} }
+func (m *getRegionResponse) String() string { return proto.CompactTextString(m) }
+func (*getRegionResponse) ProtoMessage()    {}
+
+type getStoreRequest struct {
+	Header  *requestHeader `protobuf:"bytes,1,opt,name=header"`
+	StoreID uint64         `protobuf:"varint,2,opt,name=store_id,json=storeId"`
+}
+
+func (m *getStoreRequest) Reset()         { *m = getStoreRequest{} }
+func (m *getStoreRequest) String() string { return proto.CompactTextString(m) }
+func (*getStoreRequest) ProtoMessage()    {}
+
+type getStoreResponse struct {
+	Header *responseHeader `protobuf:"bytes,1,opt,name=header"`
+	Store  *store          `protobuf:"bytes,2,opt,name=store"`
+}
+
+func (m *getStoreResponse) Reset()         { *m = getStoreResponse{} }
+func (m *getStoreResponse) String() string { return proto.CompactTextString(m) }
+func (*getStoreResponse) ProtoMessage()    {}
+
+// errorpb
+
+type regionError struct {
+	Message string `protobuf:"bytes,1,opt,name=message"`
+}
+
+func (m *regionError) Reset()         { *m = regionError{} }
+func (m *regionError) String() string { return proto.CompactTextString(m) }
+func (*regionError) ProtoMessage()    {}
+
+// kvrpcpb
+
+type kvContext struct {
+	RegionID    uint64       `protobuf:"varint,1,opt,name=region_id,json=regionId"`
+	RegionEpoch *regionEpoch `protobuf:"bytes,2,opt,name=region_epoch,json=regionEpoch"`
+	Peer        *peer        `protobuf:"bytes,3,opt,name=peer"`
+}
+
+func (m *kvContext) Reset()         { *m = kvContext{} }
+func (m *kvContext) String() string { return proto.CompactTextString(m) }
+func (*kvContext) ProtoMessage()    {}
+
+type rawGetRequest struct {
+	Context *kvContext `protobuf:"bytes,1,opt,name=context"`
+	Key     []byte     `protobuf:"bytes,2,opt,name=key,proto3"`
+}
+
+func (m *rawGetRequest) Reset()         { *m = rawGetRequest{} }
+func (m *rawGetRequest) String() string { return proto.CompactTextString(m) }
+func (*rawGetRequest) ProtoMessage()    {}
+
+type rawGetResponse struct {
+	RegionError *regionError `protobuf:"bytes,1,opt,name=region_error,json=regionError"`
+	Error       string       `protobuf:"bytes,2,opt,name=error"`
+	Value       []byte       `protobuf:"bytes,3,opt,name=value,proto3"`
+	NotFound    bool         `protobuf:"varint,4,opt,name=not_found,json=notFound"`
+}
+
+func (m *rawGetResponse) Reset()         { *m = rawGetResponse{} }
+func (m *rawGetResponse) String() string { return proto.CompactTextString(m) }
+func (*rawGetResponse) ProtoMessage()    {}
+
+type rawPutRequest struct {
+	Context *kvContext `protobuf:"bytes,1,opt,name=context"`
+	Key     []byte     `protobuf:"bytes,2,opt,name=key,proto3"`
+	Value   []byte     `protobuf:"bytes,3,opt,name=value,proto3"`
+}
+
+func (m *rawPutRequest) Reset()         { *m = rawPutRequest{} }
+func (m *rawPutRequest) String() string { return proto.CompactTextString(m) }
+func (*rawPutRequest) ProtoMessage()    {}
+
+type rawPutResponse struct {
+	RegionError *regionError `protobuf:"bytes,1,opt,name=region_error,json=regionError"`
+	Error       string       `protobuf:"bytes,2,opt,name=error"`
+}
+
+func (m *rawPutResponse) Reset()         { *m = rawPutResponse{} }
+func (m *rawPutResponse) String() string { return proto.CompactTextString(m) }
+func (*rawPutResponse) ProtoMessage()    {}
+
+type rawDeleteRequest struct {
+	Context *kvContext `protobuf:"bytes,1,opt,name=context"`
+	Key     []byte     `protobuf:"bytes,2,opt,name=key,proto3"`
+}
+
+func (m *rawDeleteRequest) Reset()         { *m = rawDeleteRequest{} }
+func (m *rawDeleteRequest) String() string { return proto.CompactTextString(m) }
+func (*rawDeleteRequest) ProtoMessage()    {}
+
+type rawDeleteResponse struct {
+	RegionError *regionError `protobuf:"bytes,1,opt,name=region_error,json=regionError"`
+	Error       string       `protobuf:"bytes,2,opt,name=error"`
+}
+
+func (m *rawDeleteResponse) Reset()         { *m = rawDeleteResponse{} }
+func (m *rawDeleteResponse) String() string { return proto.CompactTextString(m) }
+func (*rawDeleteResponse) ProtoMessage()    {}
+
+type rawScanRequest struct {
+	Context  *kvContext `protobuf:"bytes,1,opt,name=context"`
+	StartKey []byte     `protobuf:"bytes,2,opt,name=start_key,json=startKey,proto3"`
+	Limit    uint32     `protobuf:"varint,3,opt,name=limit"`
+	KeyOnly  bool       `protobuf:"varint,4,opt,name=key_only,json=keyOnly"`
+}
+
+func (m *rawScanRequest) Reset()         { *m = rawScanRequest{} }
+func (m *rawScanRequest) String() string { return proto.CompactTextString(m) }
+func (*rawScanRequest) ProtoMessage()    {}
+
+type kvPair struct {
+	Key   []byte `protobuf:"bytes,2,opt,name=key,proto3"`
+	Value []byte `protobuf:"bytes,3,opt,name=value,proto3"`
+}
+
+func (m *kvPair) Reset()         { *m = kvPair{} }
+func (m *kvPair) String() string { return proto.CompactTextString(m) }
+func (*kvPair) ProtoMessage()    {}
+
+type rawScanResponse struct {
+	RegionError *regionError `protobuf:"bytes,1,opt,name=region_error,json=regionError"`
+	Kvs         []*kvPair    `protobuf:"bytes,2,rep,name=kvs"`
+}
+
+func (m *rawScanResponse) Reset()         { *m = rawScanResponse{} }
+func (m *rawScanResponse) String() string { return proto.CompactTextString(m) }
+func (*rawScanResponse) ProtoMessage()    {}
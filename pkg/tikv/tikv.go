@@ -0,0 +1,334 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tikv implements a minimal client of the raw key-value API of
+// TiKV, with the commands that the benchmarks issue. It finds the region
+// of each key, and the store of its leader, from PD.
+//
+// It stands in for the raw key-value client of TiKV ('rawkv'), which is
+// not vendored, with a copy of the subset of kvproto that it sends. It
+// does not follow the changes of the protocol after TiKV 2.1, nor retry
+// on the errors of the stores other than the stale regions. The TiKV
+// client of the stress is to move to 'rawkv' once it is vendored.
+package tikv
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+)
+
+// DefaultDialTimeout is the timeout to reach PD.
+var DefaultDialTimeout = 5 * time.Second
+
+// maxRegionRetries is the number of the retries of a request whose
+// region is stale, such as after a leader change or a split.
+const maxRegionRetries = 5
+
+// RegionError is an error of the region that a request was sent to,
+// after the retries with the region reloaded from PD.
+type RegionError string
+
+func (e RegionError) Error() string { return "tikv: region error (" + string(e) + ")" }
+
+type regionInfo struct {
+	meta   *region
+	leader *peer
+}
+
+func (r *regionInfo) contains(key []byte) bool {
+	return bytes.Compare(key, r.meta.StartKey) >= 0 && (len(r.meta.EndKey) == 0 || bytes.Compare(key, r.meta.EndKey) < 0)
+}
+
+// Client sends the raw key-value requests to the leaders of the regions
+// of their keys. It is safe for concurrent use.
+type Client struct {
+	pd        *grpc.ClientConn
+	clusterID uint64
+
+	mu sync.Mutex
+	// regions are the regions located so far, dropped once stale
+	regions []*regionInfo
+	stores  map[uint64]string
+	conns   map[string]*grpc.ClientConn
+}
+
+// New returns a client of the cluster of the PD endpoints, of the first
+// one that replies.
+func New(pdEndpoints []string) (*Client, error) {
+	var errs []string
+	for _, ep := range pdEndpoints {
+		ep = strings.TrimPrefix(strings.TrimPrefix(ep, "http://"), "https://")
+		conn, err := grpc.Dial(ep, grpc.WithInsecure())
+		if err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), DefaultDialTimeout)
+		var resp getMembersResponse
+		err = grpc.Invoke(ctx, methodGetMembers, &getMembersRequest{Header: &requestHeader{}}, &resp, conn)
+		cancel()
+		if err == nil {
+			err = headerError(resp.Header)
+		}
+		if err != nil {
+			conn.Close()
+			errs = append(errs, fmt.Sprintf("%s (%v)", ep, err))
+			continue
+		}
+		return &Client{
+			pd:        conn,
+			clusterID: resp.Header.ClusterID,
+			stores:    make(map[uint64]string),
+			conns:     make(map[string]*grpc.ClientConn),
+		}, nil
+	}
+	return nil, fmt.Errorf("tikv: no PD endpoint reachable [%s]", strings.Join(errs, ", "))
+}
+
+func headerError(h *responseHeader) error {
+	if h != nil && h.Error != nil {
+		return fmt.Errorf("tikv: PD error (%s)", h.Error.Message)
+	}
+	return nil
+}
+
+// ClusterID returns the ID of the cluster, as PD replied.
+func (c *Client) ClusterID() uint64 { return c.clusterID }
+
+// PDMembers returns the names of the PD members.
+func (c *Client) PDMembers(ctx context.Context) ([]string, error) {
+	var resp getMembersResponse
+	if err := grpc.Invoke(ctx, methodGetMembers, &getMembersRequest{Header: &requestHeader{ClusterID: c.clusterID}}, &resp, c.pd); err != nil {
+		return nil, err
+	}
+	if err := headerError(resp.Header); err != nil {
+		return nil, err
+	}
+	names := make([]string, len(resp.Members))
+	for i, m := range resp.Members {
+		names[i] = m.Name
+	}
+	return names, nil
+}
+
+// Close closes the connections of the client.
+func (c *Client) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for addr, conn := range c.conns {
+		conn.Close()
+		delete(c.conns, addr)
+	}
+	return c.pd.Close()
+}
+
+// locate returns the region of the key, from PD if not located yet.
+func (c *Client) locate(ctx context.Context, key []byte) (*regionInfo, error) {
+	c.mu.Lock()
+	for _, r := range c.regions {
+		if r.contains(key) {
+			c.mu.Unlock()
+			return r, nil
+		}
+	}
+	c.mu.Unlock()
+
+	var resp getRegionResponse
+	if err := grpc.Invoke(ctx, methodGetRegion, &getRegionRequest{Header: &requestHeader{ClusterID: c.clusterID}, RegionKey: key}, &resp, c.pd); err != nil {
+		return nil, err
+	}
+	if err := headerError(resp.Header); err != nil {
+		return nil, err
+	}
+	if resp.Region == nil {
+		return nil, fmt.Errorf("tikv: no region of key %q", key)
+	}
+	r := &regionInfo{meta: resp.Region, leader: resp.Leader}
+	if r.leader == nil {
+		if len(r.meta.Peers) == 0 {
+			return nil, fmt.Errorf("tikv: no peer of region %d", r.meta.ID)
+		}
+		r.leader = r.meta.Peers[0]
+	}
+	c.mu.Lock()
+	c.regions = append(c.regions, r)
+	c.mu.Unlock()
+	return r, nil
+}
+
+// drop forgets the stale region, to locate its keys again.
+func (c *Client) drop(r *regionInfo) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i := range c.regions {
+		if c.regions[i] == r {
+			c.regions = append(c.regions[:i], c.regions[i+1:]...)
+			return
+		}
+	}
+}
+
+// storeConn returns the connection to the store, of the address from PD.
+func (c *Client) storeConn(ctx context.Context, storeID uint64) (*grpc.ClientConn, error) {
+	c.mu.Lock()
+	addr, ok := c.stores[storeID]
+	c.mu.Unlock()
+	if !ok {
+		var resp getStoreResponse
+		if err := grpc.Invoke(ctx, methodGetStore, &getStoreRequest{Header: &requestHeader{ClusterID: c.clusterID}, StoreID: storeID}, &resp, c.pd); err != nil {
+			return nil, err
+		}
+		if err := headerError(resp.Header); err != nil {
+			return nil, err
+		}
+		if resp.Store == nil || resp.Store.Address == "" {
+			return nil, fmt.Errorf("tikv: no address of store %d", storeID)
+		}
+		addr = resp.Store.Address
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.stores[storeID] = addr
+	if conn, ok := c.conns[addr]; ok {
+		return conn, nil
+	}
+	conn, err := grpc.Dial(addr, grpc.WithInsecure())
+	if err != nil {
+		return nil, err
+	}
+	c.conns[addr] = conn
+	return conn, nil
+}
+
+type kvRequest interface {
+	proto.Message
+	setContext(*kvContext)
+}
+
+func (m *rawGetRequest) setContext(ctx *kvContext)    { m.Context = ctx }
+func (m *rawPutRequest) setContext(ctx *kvContext)    { m.Context = ctx }
+func (m *rawDeleteRequest) setContext(ctx *kvContext) { m.Context = ctx }
+func (m *rawScanRequest) setContext(ctx *kvContext)   { m.Context = ctx }
+
+type kvResponse interface {
+	proto.Message
+	regionErr() *regionError
+	keyErr() string
+}
+
+func (m *rawGetResponse) regionErr() *regionError    { return m.RegionError }
+func (m *rawPutResponse) regionErr() *regionError    { return m.RegionError }
+func (m *rawDeleteResponse) regionErr() *regionError { return m.RegionError }
+func (m *rawScanResponse) regionErr() *regionError   { return m.RegionError }
+
+func (m *rawGetResponse) keyErr() string    { return m.Error }
+func (m *rawPutResponse) keyErr() string    { return m.Error }
+func (m *rawDeleteResponse) keyErr() string { return m.Error }
+func (m *rawScanResponse) keyErr() string   { return "" }
+
+// do sends the request of the key to the leader of its region, and
+// returns the region that replied. The request is retried with the
+// region reloaded from PD if stale.
+func (c *Client) do(ctx context.Context, key []byte, method string, req kvRequest, resp kvResponse) (*regionInfo, error) {
+	for i := 0; ; i++ {
+		r, err := c.locate(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+		conn, err := c.storeConn(ctx, r.leader.StoreID)
+		if err != nil {
+			return nil, err
+		}
+		req.setContext(&kvContext{RegionID: r.meta.ID, RegionEpoch: r.meta.RegionEpoch, Peer: r.leader})
+		if err = grpc.Invoke(ctx, method, req, resp, conn); err != nil {
+			// the leader may have moved with the store down
+			c.drop(r)
+			return nil, err
+		}
+		if re := resp.regionErr(); re != nil {
+			c.drop(r)
+			if i == maxRegionRetries {
+				return nil, RegionError(re.Message)
+			}
+			continue
+		}
+		if e := resp.keyErr(); e != "" {
+			return nil, fmt.Errorf("tikv: %s", e)
+		}
+		return r, nil
+	}
+}
+
+// Get returns the value of the key, or nil if not found.
+func (c *Client) Get(ctx context.Context, key []byte) ([]byte, error) {
+	var resp rawGetResponse
+	if _, err := c.do(ctx, key, methodRawGet, &rawGetRequest{Key: key}, &resp); err != nil {
+		return nil, err
+	}
+	if resp.NotFound {
+		return nil, nil
+	}
+	if resp.Value == nil {
+		// found, but empty
+		return []byte{}, nil
+	}
+	return resp.Value, nil
+}
+
+// Put writes the value of the key.
+func (c *Client) Put(ctx context.Context, key, value []byte) error {
+	var resp rawPutResponse
+	_, err := c.do(ctx, key, methodRawPut, &rawPutRequest{Key: key, Value: value}, &resp)
+	return err
+}
+
+// Delete deletes the key. Raw deletes do not tell if the key existed.
+func (c *Client) Delete(ctx context.Context, key []byte) error {
+	var resp rawDeleteResponse
+	_, err := c.do(ctx, key, methodRawDelete, &rawDeleteRequest{Key: key}, &resp)
+	return err
+}
+
+// Scan returns up to 'limit' keys from 'start' in order, across the
+// regions that they are in.
+func (c *Client) Scan(ctx context.Context, start []byte, limit int) ([][]byte, error) {
+	var keys [][]byte
+	for len(keys) < limit {
+		var resp rawScanResponse
+		n := limit - len(keys)
+		r, err := c.do(ctx, start, methodRawScan, &rawScanRequest{StartKey: start, Limit: uint32(n), KeyOnly: true}, &resp)
+		if err != nil {
+			return nil, err
+		}
+		for _, kv := range resp.Kvs {
+			keys = append(keys, kv.Key)
+		}
+		if len(resp.Kvs) < n {
+			// the rest of the region is scanned
+			if len(r.meta.EndKey) == 0 {
+				break
+			}
+			start = r.meta.EndKey
+		}
+	}
+	return keys, nil
+}
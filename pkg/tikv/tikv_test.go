@@ -0,0 +1,234 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tikv
+
+import (
+	"bytes"
+	"net"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+)
+
+// fakeCluster serves PD and one TiKV store on the same address, with
+// the keys split at "m" into 2 regions. The first request to region 2
+// fails with a region error, as after its leader moved.
+type fakeCluster struct {
+	ln  net.Listener
+	srv *grpc.Server
+
+	mu      sync.Mutex
+	kv      map[string][]byte
+	regions []*region
+	stale   bool
+}
+
+func newFakeCluster(t *testing.T) *fakeCluster {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fc := &fakeCluster{
+		ln:  ln,
+		srv: grpc.NewServer(),
+		kv:  make(map[string][]byte),
+		regions: []*region{
+			{ID: 1, EndKey: []byte("m"), RegionEpoch: &regionEpoch{Version: 1}, Peers: []*peer{{ID: 10, StoreID: 1}}},
+			{ID: 2, StartKey: []byte("m"), RegionEpoch: &regionEpoch{Version: 1}, Peers: []*peer{{ID: 20, StoreID: 1}}},
+		},
+		stale: true,
+	}
+	fc.srv.RegisterService(&grpc.ServiceDesc{
+		ServiceName: "pdpb.PD",
+		HandlerType: (*interface{})(nil),
+		Methods: []grpc.MethodDesc{
+			fc.method("GetMembers", func() interface{} { return &getMembersRequest{} }, fc.getMembers),
+			fc.method("GetRegion", func() interface{} { return &getRegionRequest{} }, fc.getRegion),
+			fc.method("GetStore", func() interface{} { return &getStoreRequest{} }, fc.getStore),
+		},
+	}, fc)
+	fc.srv.RegisterService(&grpc.ServiceDesc{
+		ServiceName: "tikvpb.Tikv",
+		HandlerType: (*interface{})(nil),
+		Methods: []grpc.MethodDesc{
+			fc.method("RawGet", func() interface{} { return &rawGetRequest{} }, fc.rawGet),
+			fc.method("RawPut", func() interface{} { return &rawPutRequest{} }, fc.rawPut),
+			fc.method("RawDelete", func() interface{} { return &rawDeleteRequest{} }, fc.rawDelete),
+			fc.method("RawScan", func() interface{} { return &rawScanRequest{} }, fc.rawScan),
+		},
+	}, fc)
+	go fc.srv.Serve(ln)
+	return fc
+}
+
+func (fc *fakeCluster) method(name string, newReq func() interface{}, handle func(interface{}) interface{}) grpc.MethodDesc {
+	return grpc.MethodDesc{
+		MethodName: name,
+		Handler: func(_ interface{}, _ context.Context, dec func(interface{}) error, _ grpc.UnaryServerInterceptor) (interface{}, error) {
+			req := newReq()
+			if err := dec(req); err != nil {
+				return nil, err
+			}
+			fc.mu.Lock()
+			defer fc.mu.Unlock()
+			return handle(req), nil
+		},
+	}
+}
+
+func (fc *fakeCluster) getMembers(interface{}) interface{} {
+	return &getMembersResponse{Header: &responseHeader{ClusterID: 7}, Members: []*member{{Name: "pd1", MemberID: 1}, {Name: "pd2", MemberID: 2}}}
+}
+
+func (fc *fakeCluster) regionOf(key []byte) *region {
+	for _, r := range fc.regions {
+		if (&regionInfo{meta: r}).contains(key) {
+			return r
+		}
+	}
+	return nil
+}
+
+func (fc *fakeCluster) getRegion(req interface{}) interface{} {
+	r := fc.regionOf(req.(*getRegionRequest).RegionKey)
+	return &getRegionResponse{Header: &responseHeader{ClusterID: 7}, Region: r, Leader: r.Peers[0]}
+}
+
+func (fc *fakeCluster) getStore(interface{}) interface{} {
+	return &getStoreResponse{Header: &responseHeader{ClusterID: 7}, Store: &store{ID: 1, Address: fc.ln.Addr().String()}}
+}
+
+// check returns the region error of the request, if any.
+func (fc *fakeCluster) check(ctx *kvContext, key []byte) *regionError {
+	r := fc.regionOf(key)
+	if ctx == nil || ctx.RegionID != r.ID {
+		return &regionError{Message: "key not in region"}
+	}
+	if r.ID == 2 && fc.stale {
+		fc.stale = false
+		return &regionError{Message: "not leader"}
+	}
+	return nil
+}
+
+func (fc *fakeCluster) rawGet(req interface{}) interface{} {
+	rq := req.(*rawGetRequest)
+	if re := fc.check(rq.Context, rq.Key); re != nil {
+		return &rawGetResponse{RegionError: re}
+	}
+	v, ok := fc.kv[string(rq.Key)]
+	return &rawGetResponse{Value: v, NotFound: !ok}
+}
+
+func (fc *fakeCluster) rawPut(req interface{}) interface{} {
+	rq := req.(*rawPutRequest)
+	if re := fc.check(rq.Context, rq.Key); re != nil {
+		return &rawPutResponse{RegionError: re}
+	}
+	fc.kv[string(rq.Key)] = rq.Value
+	return &rawPutResponse{}
+}
+
+func (fc *fakeCluster) rawDelete(req interface{}) interface{} {
+	rq := req.(*rawDeleteRequest)
+	if re := fc.check(rq.Context, rq.Key); re != nil {
+		return &rawDeleteResponse{RegionError: re}
+	}
+	delete(fc.kv, string(rq.Key))
+	return &rawDeleteResponse{}
+}
+
+func (fc *fakeCluster) rawScan(req interface{}) interface{} {
+	rq := req.(*rawScanRequest)
+	if re := fc.check(rq.Context, rq.StartKey); re != nil {
+		return &rawScanResponse{RegionError: re}
+	}
+	r := fc.regionOf(rq.StartKey)
+	var keys []string
+	for k := range fc.kv {
+		if bytes.Compare([]byte(k), rq.StartKey) >= 0 && (&regionInfo{meta: r}).contains([]byte(k)) {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	resp := &rawScanResponse{}
+	for _, k := range keys {
+		if uint32(len(resp.Kvs)) == rq.Limit {
+			break
+		}
+		resp.Kvs = append(resp.Kvs, &kvPair{Key: []byte(k)})
+	}
+	return resp
+}
+
+func TestClient(t *testing.T) {
+	fc := newFakeCluster(t)
+	defer fc.srv.Stop()
+
+	cli, err := New([]string{"http://" + fc.ln.Addr().String()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cli.Close()
+	if cli.clusterID != 7 {
+		t.Fatalf("expected cluster ID 7, got %d", cli.clusterID)
+	}
+
+	ctx := context.Background()
+	if names, err := cli.PDMembers(ctx); err != nil || len(names) != 2 || names[1] != "pd2" {
+		t.Fatalf("expected [pd1 pd2], got %v (%v)", names, err)
+	}
+	for _, k := range []string{"a", "b", "x", "y", "z"} {
+		if err = cli.Put(ctx, []byte(k), []byte("v-"+k)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if fc.stale {
+		t.Fatal("expected the region error of region 2 to be retried")
+	}
+	if v, err := cli.Get(ctx, []byte("y")); err != nil || string(v) != "v-y" {
+		t.Fatalf("expected 'v-y', got %q (%v)", v, err)
+	}
+	if v, err := cli.Get(ctx, []byte("none")); err != nil || v != nil {
+		t.Fatalf("expected nil, got %q (%v)", v, err)
+	}
+	if err = cli.Delete(ctx, []byte("z")); err != nil {
+		t.Fatal(err)
+	}
+
+	// across the regions
+	keys, err := cli.Scan(ctx, nil, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 3 || string(keys[0]) != "a" || string(keys[2]) != "x" {
+		t.Fatalf("expected [a b x], got %q", keys)
+	}
+	if keys, err = cli.Scan(ctx, nil, 100); err != nil || len(keys) != 4 {
+		t.Fatalf("expected 4 keys, got %q (%v)", keys, err)
+	}
+}
+
+func TestNewUnreachable(t *testing.T) {
+	defer func(d time.Duration) { DefaultDialTimeout = d }(DefaultDialTimeout)
+	DefaultDialTimeout = 100 * time.Millisecond
+	if _, err := New([]string{"127.0.0.1:1"}); err == nil {
+		t.Fatal("expected error of no PD reachable")
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plot
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+// Command implements 'plot' command.
+var Command = &cobra.Command{
+	Use:   "plot [flags] [label=]<result.json>...",
+	Short: "Plots the results of the runs.",
+	Long: `Plots the throughput over time, the latency CDF and the latency over time
+of the results of 'control --output-format json', overlaying the runs on
+each chart, such as to compare the databases of the same workload. Each
+run is labeled by the label before '=', or by the file name if none; the
+labels of the database IDs (e.g. 'etcd__tip=etcd.json') are drawn in the
+colors of the databases.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: commandFunc,
+}
+
+var (
+	outputDir string
+	format    string
+	title     string
+)
+
+func init() {
+	Command.PersistentFlags().StringVar(&outputDir, "output-dir", ".", "Directory to save the charts to.")
+	Command.PersistentFlags().StringVar(&format, "format", "png", "Format of the charts: 'png' or 'svg'.")
+	Command.PersistentFlags().StringVar(&title, "title", "", "Title of the charts. Empty for none.")
+}
+
+func commandFunc(cmd *cobra.Command, args []string) error {
+	if format != "png" && format != "svg" {
+		return fmt.Errorf("unknown '--format' %q (expected 'png' or 'svg')", format)
+	}
+	runs, err := readRuns(args)
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(outputDir, 0755); err != nil {
+		return err
+	}
+
+	for _, ch := range charts {
+		fpath := filepath.Join(outputDir, ch.name+"."+format)
+		if err = ch.draw(title, runs, fpath); err != nil {
+			return fmt.Errorf("failed to plot %q (%v)", ch.name, err)
+		}
+		lg.Info("saved chart", zap.String("path", fpath))
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plot
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/coreos/dbtester"
+	"github.com/coreos/dbtester/dbtesterpb"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/plotutil"
+	"gonum.org/v1/plot/vg"
+)
+
+var (
+	plotWidth  = 12 * vg.Inch
+	plotHeight = 8 * vg.Inch
+)
+
+// run is the result of a run to plot.
+type run struct {
+	label  string
+	result dbtester.ResultOutput
+}
+
+// readRuns reads the result files of the arguments of '[label=]path'.
+func readRuns(args []string) ([]run, error) {
+	runs := make([]run, 0, len(args))
+	labels := make(map[string]string)
+	for _, arg := range args {
+		label, fpath := "", arg
+		if ss := strings.SplitN(arg, "=", 2); len(ss) == 2 && ss[0] != "" {
+			label, fpath = ss[0], ss[1]
+		}
+		if label == "" {
+			label = strings.TrimSuffix(filepath.Base(fpath), filepath.Ext(fpath))
+		}
+		if prev, ok := labels[label]; ok {
+			return nil, fmt.Errorf("%q and %q have the same label %q", prev, fpath, label)
+		}
+		labels[label] = fpath
+
+		out, err := dbtester.ReadResultFile(fpath)
+		if err != nil {
+			return nil, err
+		}
+		runs = append(runs, run{label: label, result: out})
+	}
+	return runs, nil
+}
+
+// chart is a chart of the runs, a line of each.
+type chart struct {
+	name   string
+	xLabel string
+	yLabel string
+	points func(dbtester.ResultOutput) plotter.XYs
+}
+
+var charts = []chart{
+	{name: "throughput-over-time", xLabel: "Second", yLabel: "Throughput (Requests/Second)", points: throughputPoints},
+	{name: "latency-cdf", xLabel: "Latency (millisecond)", yLabel: "Percentile", points: latencyCDFPoints},
+	{name: "latency-over-time", xLabel: "Second", yLabel: "Average Latency (millisecond)", points: latencyPoints},
+}
+
+// draw saves the chart of the runs to 'fpath', in the format of its extension.
+func (ch chart) draw(title string, runs []run, fpath string) error {
+	plt, err := plot.New()
+	if err != nil {
+		return err
+	}
+	plt.Title.Text = title
+	plt.X.Label.Text = ch.xLabel
+	plt.Y.Label.Text = ch.yLabel
+	plt.Legend.Top = true
+
+	var ps []plot.Plotter
+	for i, r := range runs {
+		pts := ch.points(r.result)
+		if len(pts) == 0 {
+			return fmt.Errorf("run %q has no data points", r.label)
+		}
+		l, err := plotter.NewLine(pts)
+		if err != nil {
+			return err
+		}
+		// in the colors of the databases, if labeled by the database IDs
+		l.Color = dbtesterpb.GetRGBI(r.label, i)
+		l.Dashes = plotutil.Dashes(i)
+		ps = append(ps, l)
+		plt.Legend.Add(r.label, l)
+	}
+	plt.Add(ps...)
+	return plt.Save(plotWidth, plotHeight, fpath)
+}
+
+// throughputPoints returns the requests of each second, from the start.
+func throughputPoints(out dbtester.ResultOutput) plotter.XYs {
+	pts := make(plotter.XYs, len(out.TimeSeries))
+	for i, s := range out.TimeSeries {
+		pts[i].X = float64(s.UnixSecond - out.TimeSeries[0].UnixSecond)
+		pts[i].Y = float64(s.Requests)
+	}
+	return pts
+}
+
+// latencyPoints returns the average latency of each second, from the start.
+func latencyPoints(out dbtester.ResultOutput) plotter.XYs {
+	pts := make(plotter.XYs, len(out.TimeSeries))
+	for i, s := range out.TimeSeries {
+		pts[i].X = float64(s.UnixSecond - out.TimeSeries[0].UnixSecond)
+		pts[i].Y = s.AvgLatencyMs
+	}
+	return pts
+}
+
+// latencyCDFPoints returns the latencies of the percentiles, from
+// the fastest request at 0 to the slowest at 100.
+func latencyCDFPoints(out dbtester.ResultOutput) plotter.XYs {
+	if len(out.PercentilesMs) == 0 {
+		return nil
+	}
+	pts := make(plotter.XYs, 1, len(out.PercentilesMs)+2)
+	pts[0].X = out.FastestMs
+	for k, v := range out.PercentilesMs {
+		var p float64
+		if _, err := fmt.Sscanf(k, "p%g", &p); err != nil {
+			continue
+		}
+		pts = append(pts, struct{ X, Y float64 }{X: v, Y: p})
+	}
+	pts = append(pts, struct{ X, Y float64 }{X: out.SlowestMs, Y: 100})
+	sort.Slice(pts, func(i, j int) bool { return pts[i].Y < pts[j].Y })
+	return pts
+}
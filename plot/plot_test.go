@@ -0,0 +1,87 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plot
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/coreos/dbtester"
+)
+
+func TestPlot(t *testing.T) {
+	dir, err := ioutil.TempDir("", "plot")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	out := dbtester.ResultOutput{
+		Requests:      30,
+		FastestMs:     1,
+		SlowestMs:     9,
+		PercentilesMs: map[string]float64{"p99.9": 8, "p50": 2, "p99": 5},
+		TimeSeries: []dbtester.ResultSecond{
+			{UnixSecond: 100, Requests: 10, AvgLatencyMs: 2},
+			{UnixSecond: 101, Requests: 20, AvgLatencyMs: 3},
+		},
+	}
+	bts, err := json.Marshal(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fpath := filepath.Join(dir, "zk.json")
+	if err = ioutil.WriteFile(fpath, bts, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	runs, err := readRuns([]string{"etcd__tip=" + fpath, fpath})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if runs[0].label != "etcd__tip" || runs[1].label != "zk" {
+		t.Fatalf("unexpected labels %q, %q", runs[0].label, runs[1].label)
+	}
+	if _, err = readRuns([]string{fpath, fpath}); err == nil {
+		t.Fatal("expected error of the same labels")
+	}
+
+	pts := latencyCDFPoints(out)
+	exp := [][2]float64{{1, 0}, {2, 50}, {5, 99}, {8, 99.9}, {9, 100}}
+	if len(pts) != len(exp) {
+		t.Fatalf("expected %d points, got %v", len(exp), pts)
+	}
+	for i := range exp {
+		if pts[i].X != exp[i][0] || pts[i].Y != exp[i][1] {
+			t.Fatalf("#%d: expected %v, got %v", i, exp[i], pts[i])
+		}
+	}
+	if pts = throughputPoints(out); pts[1].X != 1 || pts[1].Y != 20 {
+		t.Fatalf("unexpected throughput %v", pts)
+	}
+
+	for _, ch := range charts {
+		fpath := filepath.Join(dir, ch.name+".svg")
+		if err = ch.draw("test", runs, fpath); err != nil {
+			t.Fatal(err)
+		}
+		if fi, err := os.Stat(fpath); err != nil || fi.Size() == 0 {
+			t.Fatalf("expected chart %q, got %v", fpath, err)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultProgressInterval is the default interval to print the progress.
+const DefaultProgressInterval = 5 * time.Second
+
+// progress prints the progress of a benchmark every interval, one line
+// at a time so that it reads well in the logs of long runs, with the
+// throughput of the last interval, the error rate and the time left.
+type progress struct {
+	// total is the number of requests of total-based runs, and duration
+	// is the length of duration-based runs; the other is zero.
+	total    int64
+	duration time.Duration
+	// interval is 0 to not print
+	interval time.Duration
+	w        io.Writer
+
+	// doneN and errN are updated atomically
+	doneN int64
+	errN  int64
+
+	start time.Time
+	stopc chan struct{}
+	donec chan struct{}
+}
+
+func newProgress(total int64, duration time.Duration) *progress {
+	return &progress{
+		total:    total,
+		duration: duration,
+		interval: DefaultProgressInterval,
+		w:        os.Stdout,
+		stopc:    make(chan struct{}),
+		donec:    make(chan struct{}),
+	}
+}
+
+func (p *progress) increment(err error) {
+	atomic.AddInt64(&p.doneN, 1)
+	if err != nil {
+		atomic.AddInt64(&p.errN, 1)
+	}
+}
+
+func (p *progress) run() {
+	p.start = time.Now()
+	if p.interval <= 0 {
+		close(p.donec)
+		return
+	}
+	go func() {
+		defer close(p.donec)
+		ticker := time.NewTicker(p.interval)
+		defer ticker.Stop()
+		lastN, lastT := int64(0), p.start
+		for {
+			select {
+			case now := <-ticker.C:
+				doneN := atomic.LoadInt64(&p.doneN)
+				fmt.Fprintln(p.w, p.line(doneN, atomic.LoadInt64(&p.errN), now.Sub(p.start), float64(doneN-lastN)/now.Sub(lastT).Seconds()))
+				lastN, lastT = doneN, now
+			case <-p.stopc:
+				return
+			}
+		}
+	}()
+}
+
+// finish stops printing, and prints the final progress.
+func (p *progress) finish() {
+	close(p.stopc)
+	<-p.donec
+	if p.interval <= 0 {
+		return
+	}
+	took := time.Since(p.start)
+	doneN := atomic.LoadInt64(&p.doneN)
+	fmt.Fprintln(p.w, p.line(doneN, atomic.LoadInt64(&p.errN), took, float64(doneN)/took.Seconds()))
+}
+
+// line formats the progress, where 'rps' is the current throughput.
+func (p *progress) line(doneN, errN int64, elapsed time.Duration, rps float64) string {
+	var pct float64
+	var eta time.Duration
+	switch {
+	case p.total > 0:
+		pct = 100 * float64(doneN) / float64(p.total)
+		if doneN > 0 && doneN < p.total {
+			// based on the average throughput, since the current one
+			// fluctuates too much for an estimate
+			eta = time.Duration(float64(elapsed) * float64(p.total-doneN) / float64(doneN))
+		}
+	case p.duration > 0:
+		pct = 100 * float64(elapsed) / float64(p.duration)
+		if elapsed < p.duration {
+			eta = p.duration - elapsed
+		}
+	}
+	if pct > 100 {
+		pct = 100
+	}
+	var errPct float64
+	if doneN > 0 {
+		errPct = 100 * float64(errN) / float64(doneN)
+	}
+
+	done := fmt.Sprintf("%d", doneN)
+	if p.total > 0 {
+		done = fmt.Sprintf("%d/%d", doneN, p.total)
+	}
+	return fmt.Sprintf("[%5.1f%%] %s requests | %.1f req/s | errors %d (%.2f%%) | elapsed %v | ETA %v",
+		pct, done, rps, errN, errPct, elapsed.Truncate(time.Second), eta.Truncate(time.Second))
+}
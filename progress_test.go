@@ -0,0 +1,57 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"testing"
+	"time"
+)
+
+func TestProgressLine(t *testing.T) {
+	tests := []struct {
+		p        *progress
+		doneN    int64
+		errN     int64
+		elapsed  time.Duration
+		rps      float64
+		expected string
+	}{
+		{
+			p:     newProgress(1000, 0),
+			doneN: 250, errN: 5, elapsed: 10 * time.Second, rps: 24.56,
+			expected: "[ 25.0%] 250/1000 requests | 24.6 req/s | errors 5 (2.00%) | elapsed 10s | ETA 30s",
+		},
+		{
+			p:     newProgress(1000, 0),
+			doneN: 1000, elapsed: 40 * time.Second, rps: 25,
+			expected: "[100.0%] 1000/1000 requests | 25.0 req/s | errors 0 (0.00%) | elapsed 40s | ETA 0s",
+		},
+		{
+			p:     newProgress(0, time.Minute),
+			doneN: 300, elapsed: 15*time.Second + 300*time.Millisecond, rps: 20,
+			expected: "[ 25.5%] 300 requests | 20.0 req/s | errors 0 (0.00%) | elapsed 15s | ETA 44s",
+		},
+		{
+			p:     newProgress(0, time.Minute),
+			doneN: 0, elapsed: 2 * time.Minute,
+			expected: "[100.0%] 0 requests | 0.0 req/s | errors 0 (0.00%) | elapsed 2m0s | ETA 0s",
+		},
+	}
+	for i, tt := range tests {
+		if s := tt.p.line(tt.doneN, tt.errN, tt.elapsed, tt.rps); s != tt.expected {
+			t.Fatalf("#%d: expected %q, got %q", i, tt.expected, s)
+		}
+	}
+}
@@ -16,49 +16,125 @@ package dbtester
 
 import (
 	"fmt"
+	"io"
+	"os"
 	"sync"
+	"sync/atomic"
 	"time"
 
-	"github.com/cheggaaa/pb"
 	"github.com/coreos/dbtester/dbtesterpb"
-	"github.com/coreos/etcd/pkg/report"
 	"golang.org/x/net/context"
 )
 
 type benchmark struct {
-	bar        *pb.ProgressBar
-	report     report.Report
-	reportDone <-chan report.Stats
-	stats      report.Stats
+	progress *progress
+	stats    runStats
+
+	// started is the start of the measured requests, after the warmup if any
+	started time.Time
+	// errs counts the failed requests by error
+	errMu sync.Mutex
+	errs  map[string]int
 
 	reqHandlers []ReqHandler
-	reqGen      func(chan<- request)
+	reqGen      func(context.Context, chan<- request)
 	reqDone     func()
-	wg          sync.WaitGroup
+
+	// ctx is of the run, to cancel the generator and the requests in flight
+	ctx context.Context
+
+	// pool runs the request handlers, of a size that can change
+	pool *workerPool
+	// rampUp is the duration to start all the handlers, one at a time
+	rampUp    time.Duration
+	rampStopc chan struct{}
+	// warmup discards the results at the start of the run if not nil
+	warmup *warmupWindow
 
 	mu           sync.RWMutex
 	inflightReqs chan request
+
+	// emptyN is the number of reads with empty responses
+	emptyN int64
+
+	// openLoop is true to measure latency from the scheduled time
+	openLoop bool
+
+	// collector receives the interim results if not nil
+	collector *collectorStream
+
+	// series aggregates the latencies by second
+	series *tieredTimeSeries
+
+	// sizes records the request and response sizes if not nil
+	sizes *sizeHistogram
+	// responses checks the response sizes if not nil
+	responses *responseMemory
+	// spikes records the latencies of every second if not nil
+	spikes *spikeRecovery
+	// members attributes the requests to the members if not nil
+	members *memberBreakdown
+	// zones attributes the requests to the server zones if not nil
+	zones *zoneBreakdown
+	// latencies merges the latencies recorded by the clients
+	latencies *latencyHistogram
+	// runLatencies merges 'latencies' when the benchmark finishes if not nil
+	runLatencies *latencyHistogram
+	// live publishes the progress on the metrics endpoint if not nil
+	live *liveMetrics
+	// timeseries streams the per-second counts to the file if not nil
+	timeseries *timeseriesStream
+	// keys saves the keys of the successful writes if not nil
+	keys *keyManifest
+
+	// databaseID and typ are of the requests, to wrap their errors
+	databaseID string
+	typ        string
+	// errCategories counts the request errors by category
+	errCategories errorCategories
+
+	// reqTimeout is the deadline of each request from its scheduled time
+	reqTimeout time.Duration
+	// schedule tracks the target rate if not nil
+	schedule *scheduleTracker
+
+	// traceEvery is the interval of requests to sample for tracing
+	traceEvery int64
+	reqN       int64
+	traceMu    sync.Mutex
+	traces     []requestTrace
 }
 
 // pass totalN in case that 'cfg' is manipulated
-func newBenchmark(totalN int64, clientsN int64, reqHandlers []ReqHandler, reqDone func(), reqGen func(chan<- request)) (b *benchmark) {
+func newBenchmark(totalN int64, clientsN int64, reqHandlers []ReqHandler, reqDone func(), reqGen func(context.Context, chan<- request)) (b *benchmark) {
 	b = &benchmark{
-		bar:         pb.New(int(totalN)),
+		progress:    newProgress(totalN, 0),
 		reqHandlers: reqHandlers,
 		reqGen:      reqGen,
 		reqDone:     reqDone,
-		wg:          sync.WaitGroup{},
+		ctx:         context.Background(),
+		errs:        make(map[string]int),
+		latencies:   mustLatencyHistogram(DefaultLatencyResolution),
+		series:      newSecondTimeSeries(),
 	}
-	b.inflightReqs = make(chan request, clientsN)
-
-	b.bar.Format("Bom !")
-	b.bar.Start()
-	b.report = report.NewReportSample("%4.4f")
+	// unbuffered, so that requests are generated as clients consume
+	b.inflightReqs = make(chan request)
 	return
 }
 
+// mergeLatenciesInto records the latencies at the significant digits of
+// 'lh', to merge them into 'lh' when the benchmark finishes. It does
+// nothing if 'lh' is nil.
+func (b *benchmark) mergeLatenciesInto(lh *latencyHistogram) {
+	if lh == nil {
+		return
+	}
+	b.latencies = mustLatencyHistogram(lh.sigfigs)
+	b.runLatencies = lh
+}
+
 // only useful when multiple ranges of requests are run with one report
-func (b *benchmark) reset(clientsN int64, reqHandlers []ReqHandler, reqDone func(), reqGen func(chan<- request)) {
+func (b *benchmark) reset(clientsN int64, reqHandlers []ReqHandler, reqDone func(), reqGen func(context.Context, chan<- request)) {
 	if len(reqHandlers) == 0 {
 		panic(fmt.Errorf("got 0 reqHandlers"))
 	}
@@ -68,7 +144,7 @@ func (b *benchmark) reset(clientsN int64, reqHandlers []ReqHandler, reqDone func
 
 	// inflight requests will be dropped!
 	b.mu.Lock()
-	b.inflightReqs = make(chan request, clientsN)
+	b.inflightReqs = make(chan request)
 	b.mu.Unlock()
 }
 
@@ -80,37 +156,209 @@ func (b *benchmark) getInflightsReqs() (ch chan request) {
 }
 
 func (b *benchmark) startRequests() {
-	for i := range b.reqHandlers {
-		b.wg.Add(1)
-		go func(rh ReqHandler) {
-			defer b.wg.Done()
-			for req := range b.getInflightsReqs() {
-				if rh == nil {
-					panic(fmt.Errorf("got nil rh"))
-				}
-				st := time.Now()
-				err := rh(context.Background(), &req)
-				b.report.Results() <- report.Result{Err: err, Start: st, End: time.Now()}
-				b.bar.Increment()
+	if b.series == nil {
+		b.series = newSecondTimeSeries()
+	}
+	b.progress.run()
+	b.pool = newWorkerPool(b.reqHandlers, b.work)
+	if b.rampUp > 0 {
+		b.pool.resize(1)
+		b.rampStopc = make(chan struct{})
+		go b.pool.ramp(b.rampUp, b.rampStopc)
+	} else {
+		b.pool.resize(len(b.reqHandlers))
+	}
+	if b.warmup != nil {
+		// the total time is measured from the end of the warmup
+		b.warmup.start(func() { b.started = time.Now() })
+	} else {
+		b.started = time.Now()
+	}
+	go b.reqGen(b.ctx, b.getInflightsReqs())
+}
+
+// work handles the requests with the handler until they are closed,
+// until the worker is stopped by a resize of the pool, or until the
+// run is cancelled.
+func (b *benchmark) work(rh ReqHandler, stopc <-chan struct{}) {
+	lats := b.latencies.client()
+	defer b.latencies.merge(lats)
+	reqs := b.getInflightsReqs()
+	for {
+		select {
+		case <-stopc:
+			return
+		case <-b.ctx.Done():
+			return
+		case req, ok := <-reqs:
+			if !ok {
+				return
 			}
-		}(b.reqHandlers[i])
+			b.handle(rh, req, lats)
+		}
 	}
-	go b.reqGen(b.getInflightsReqs())
-	b.reportDone = b.report.Stats()
+}
+
+// handle runs the request, and records its latency in 'lats'.
+func (b *benchmark) handle(rh ReqHandler, req request, lats *clientLatencies) {
+	if rh == nil {
+		panic(fmt.Errorf("got nil rh"))
+	}
+	sampled := b.traceEvery > 0 && atomic.AddInt64(&b.reqN, 1)%b.traceEvery == 0
+	if sampled || b.sizes != nil || b.responses != nil || b.members != nil || b.zones != nil {
+		// request handlers record the sizes and the headers in the trace
+		req.trace = &requestTrace{}
+	}
+	st := time.Now()
+	if req.trace != nil {
+		req.trace.seq = req.seq
+		if !req.scheduled.IsZero() {
+			req.trace.queueDelay = st.Sub(req.scheduled)
+		}
+	}
+	started := st
+	if b.openLoop && !req.scheduled.IsZero() {
+		// include the time waited for a free client
+		st = req.scheduled
+	}
+	ctx, cancel := b.ctx, func() {}
+	var deadline time.Time
+	if b.reqTimeout > 0 {
+		deadline = started.Add(b.reqTimeout)
+		if !req.scheduled.IsZero() {
+			deadline = req.scheduled.Add(b.reqTimeout)
+		}
+		ctx, cancel = context.WithDeadline(ctx, deadline)
+	}
+	if b.live != nil {
+		b.live.begin()
+	}
+	err := rh(ctx, &req)
+	end := time.Now()
+	cancel()
+	if b.live != nil {
+		b.live.end()
+	}
+	if b.ctx.Err() != nil {
+		// cut short by the cancellation of the run, not by the database
+		return
+	}
+	if b.warmup != nil && b.warmup.discard(st) {
+		if err == errEmptyResponse {
+			err = nil
+		}
+		if b.keys != nil && err == nil {
+			// the keys are written even if their results are discarded
+			b.keys.add(req.key())
+		}
+		b.progress.increment(err)
+		return
+	}
+	if b.schedule != nil {
+		scheduled := req.scheduled
+		if scheduled.IsZero() {
+			scheduled = started
+		}
+		b.schedule.record(scheduled, started, end, deadline)
+	}
+	if sampled {
+		b.addTrace(req.trace, st, end, err)
+	}
+	if err == errEmptyResponse {
+		atomic.AddInt64(&b.emptyN, 1)
+		err = nil
+	}
+	if b.responses != nil {
+		err = b.responses.check(req.trace.responseBytes, err)
+	}
+	if err != nil {
+		err = newRequestError(b.databaseID, requestOp(b.typ, &req), "", err)
+		b.errCategories.add(err)
+	}
+	if b.sizes != nil && err == nil {
+		b.sizes.record(req.trace.requestBytes, req.trace.responseBytes)
+	}
+	if b.members != nil && err == nil {
+		b.members.record(end, req.trace, end.Sub(st))
+	}
+	if b.zones != nil && err == nil {
+		b.zones.record(req.trace, end.Sub(st))
+	}
+	if b.keys != nil && err == nil {
+		b.keys.add(req.key())
+	}
+	if b.collector != nil {
+		b.collector.record(end, end.Sub(st), err)
+	}
+	if err == nil {
+		b.series.add(st, end.Sub(st))
+		lats.record(end.Sub(st))
+	} else {
+		b.errMu.Lock()
+		b.errs[err.Error()]++
+		b.errMu.Unlock()
+	}
+	if b.spikes != nil {
+		b.spikes.add(st, end.Sub(st))
+	}
+	if b.live != nil {
+		b.live.record(end.Sub(st), err)
+	}
+	if b.timeseries != nil {
+		b.timeseries.record(end.Sub(st), err)
+	}
+	b.progress.increment(err)
+}
+
+func (b *benchmark) addTrace(tr *requestTrace, start, end time.Time, err error) {
+	tr.start, tr.end = start, end
+	if err != nil {
+		tr.err = err.Error()
+	}
+	b.traceMu.Lock()
+	b.traces = append(b.traces, *tr)
+	b.traceMu.Unlock()
 }
 
 func (b *benchmark) waitRequestsEnd() {
-	b.wg.Wait()
+	b.pool.wait()
+	if b.rampStopc != nil {
+		close(b.rampStopc)
+		b.rampStopc = nil
+	}
 	if b.reqDone != nil {
 		b.reqDone() // cancel connections
 	}
 }
 
 func (b *benchmark) finishReports() {
-	close(b.report.Results())
-	b.bar.Finish()
-	st := <-b.reportDone
-	b.stats = st
+	if b.warmup != nil {
+		// in case that no request is done after the warmup
+		b.warmup.end()
+	}
+	b.progress.finish()
+	var total time.Duration
+	if !b.started.IsZero() {
+		total = time.Since(b.started)
+	}
+	b.latencies.mu.Lock()
+	lats := copyHistogram(b.latencies.h)
+	b.latencies.mu.Unlock()
+	b.errMu.Lock()
+	errs := make(map[string]int, len(b.errs))
+	for k, v := range b.errs {
+		errs[k] = v
+	}
+	b.errMu.Unlock()
+	b.stats = newRunStats(lats, total, errs, b.series.timeSeries())
+	if b.runLatencies != nil {
+		b.runLatencies.mu.Lock()
+		defer b.runLatencies.mu.Unlock()
+		if err := b.runLatencies.h.Merge(lats); err != nil {
+			// of the same significant digits by 'mergeLatenciesInto'
+			panic(err)
+		}
+	}
 }
 
 func (b *benchmark) waitAll() {
@@ -118,29 +366,121 @@ func (b *benchmark) waitAll() {
 	b.finishReports()
 }
 
-func printStats(st report.Stats) {
+// requestTimeout returns the configured deadline of each request, or 0.
+func requestTimeout(gcfg dbtesterpb.ConfigClientMachineAgentControl) time.Duration {
+	return time.Duration(gcfg.ConfigClientMachineBenchmarkOptions.RequestTimeoutMilliseconds) * time.Millisecond
+}
+
+func printStats(st runStats) {
 	// to be piped to cfg.Log via stdout when dbtester executed
-	if len(st.Lats) > 0 {
-		fmt.Printf("Total: %v\n", st.Total)
-		fmt.Printf("Slowest: %f secs\n", st.Slowest)
-		fmt.Printf("Fastest: %f secs\n", st.Fastest)
-		fmt.Printf("Average: %f secs\n", st.Average)
-		fmt.Printf("Requests/sec: %4.4f\n", st.RPS)
+	writeStats(os.Stdout, st)
+}
+
+func writeStats(w io.Writer, st runStats) error {
+	var err error
+	printf := func(format string, args ...interface{}) {
+		if err == nil {
+			_, err = fmt.Fprintf(w, format, args...)
+		}
+	}
+	if st.requests() > 0 {
+		printf("Total: %v\n", st.Total)
+		printf("Slowest: %f secs\n", st.Slowest)
+		printf("Fastest: %f secs\n", st.Fastest)
+		printf("Average: %f secs\n", st.Average)
+		printf("Requests/sec: %4.4f\n", st.RPS)
 	}
 	if len(st.ErrorDist) > 0 {
 		for k, v := range st.ErrorDist {
-			fmt.Printf("ERROR %q : %d\n", k, v)
+			printf("ERROR %q : %d\n", k, v)
+		}
+		if err == nil {
+			err = writeFailureDiagnoses(w, diagnoseFailures(st.ErrorDist))
 		}
 	} else {
-		fmt.Println("ERRRO: 0")
+		printf("ERRRO: 0\n")
 	}
+	return err
 }
 
-func (cfg *Config) generateReport(gcfg dbtesterpb.ConfigClientMachineAgentControl, h []ReqHandler, reqDone func(), reqGen func(chan<- request)) {
+func (cfg *Config) generateReport(gcfg dbtesterpb.ConfigClientMachineAgentControl, h []ReqHandler, reqDone func(), reqGen func(context.Context, chan<- request)) {
+	var stopRollingRestart func()
+	if gcfg.ConfigClientMachineBenchmarkOptions.ConfigClientMachineRollingRestart != nil {
+		stopRollingRestart = cfg.startRollingRestart(gcfg, h)
+	}
+	var stopConvergenceProbe func()
+	if cfg.convergenceProbe != nil {
+		stopConvergenceProbe = cfg.startConvergenceProbe(gcfg)
+	}
+	var stopChaos func()
+	if gcfg.ConfigClientMachineBenchmarkOptions.Chaos != "" {
+		stopChaos = cfg.startChaos(gcfg)
+	}
+	var stopMaintenance func()
+	if gcfg.ConfigClientMachineBenchmarkOptions.AutoCompactSeconds > 0 {
+		stopMaintenance = cfg.startMaintenance(gcfg)
+	}
+
 	b := newBenchmark(gcfg.ConfigClientMachineBenchmarkOptions.RequestNumber, gcfg.ConfigClientMachineBenchmarkOptions.ClientNumber, h, reqDone, reqGen)
+	b.traceEvery = traceEvery(gcfg)
+	b.databaseID = gcfg.DatabaseID
+	b.typ = gcfg.ConfigClientMachineBenchmarkOptions.Type
+	b.openLoop = gcfg.ConfigClientMachineBenchmarkOptions.OpenLoop
+	b.collector = cfg.collector
+	b.reqTimeout = requestTimeout(gcfg)
+	b.rampUp = rampUpDuration(gcfg)
+	b.warmup = newWarmupWindow(gcfg)
+	b.ctx = cfg.runContext()
+	b.progress.interval = cfg.ProgressInterval
+	if d := runDuration(gcfg); d > 0 {
+		b.progress.total, b.progress.duration = 0, d
+	}
+	b.sizes = cfg.sizes
+	b.responses = cfg.responses
+	b.spikes = cfg.spikes
+	b.members = cfg.members
+	b.zones = cfg.zones
+	b.mergeLatenciesInto(cfg.latencies)
+	b.live = cfg.live
+	b.timeseries = cfg.timeseries
+	if gcfg.ConfigClientMachineBenchmarkOptions.Type == "write" {
+		b.keys = cfg.keys
+	}
+	b.series = newTieredTimeSeries(gcfg)
+	b.schedule = cfg.schedule
 	b.startRequests()
 	b.waitAll()
+	if stopRollingRestart != nil {
+		// wait for the member being restarted if any
+		stopRollingRestart()
+	}
+	if stopChaos != nil {
+		stopChaos()
+	}
+	if stopMaintenance != nil {
+		// wait for the maintenance in progress if any
+		stopMaintenance()
+	}
+	if stopConvergenceProbe != nil {
+		// probe after the last writes
+		stopConvergenceProbe()
+	}
+
+	cfg.printResults(b.stats)
+	cfg.logErrorCategories(&b.errCategories)
 
-	printStats(b.stats)
+	cfg.emptyResponses = b.emptyN
+	cfg.warmupDiscarded = 0
+	if b.warmup != nil {
+		cfg.warmupDiscarded = b.warmup.discarded
+		cfg.lg.Sugar().Infof("discarded the results of %d warmup requests", cfg.warmupDiscarded)
+	}
+	if cfg.emptyResponses > 0 {
+		fmt.Printf("WARNING: %d out of %d reads returned empty response (key not found)\n", cfg.emptyResponses, b.stats.requests())
+		cfg.lg.Sugar().Warnf("%d reads returned empty response; latency of not-found responses is included in the report", cfg.emptyResponses)
+	}
 	cfg.saveAllStats(gcfg, b.stats, nil)
+	if b.traceEvery > 0 {
+		cfg.saveRequestTraceSample(b.traces)
+	}
 }
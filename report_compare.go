@@ -0,0 +1,127 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+)
+
+// comparedPercentiles are the latency percentiles to compare
+// of the results, as in 'percentiles_ms' of the JSON results.
+var comparedPercentiles = []string{"p50", "p90", "p99", "p99.9"}
+
+// CompareThresholds are the regressions of a result from its baseline
+// that fail the comparison. 0 is no regression allowed.
+type CompareThresholds struct {
+	// ThroughputDropPercent is of the requests per second.
+	ThroughputDropPercent float64
+	// LatencyIncreasePercent is of the average latency,
+	// and of each latency percentile.
+	LatencyIncreasePercent float64
+	// ErrorRateIncreasePoints is of the percentage of the failed
+	// requests, in percentage points.
+	ErrorRateIncreasePoints float64
+}
+
+// ResultDelta is the change of a metric from the baseline result.
+type ResultDelta struct {
+	Metric    string
+	Base      float64
+	Current   float64
+	Delta     float64
+	Unit      string
+	Regressed bool
+}
+
+// ReadResultFile reads the results that 'control' saved with
+// '--output-format json'.
+func ReadResultFile(fpath string) (ResultOutput, error) {
+	var out ResultOutput
+	bts, err := ioutil.ReadFile(fpath)
+	if err != nil {
+		return out, err
+	}
+	if err = json.Unmarshal(bts, &out); err != nil {
+		return out, fmt.Errorf("%q is not the JSON results of '--output-format json' (%v)", fpath, err)
+	}
+	if out.Requests == 0 && len(out.Errors) == 0 {
+		return out, fmt.Errorf("%q has no requests", fpath)
+	}
+	return out, nil
+}
+
+// errorRatePercent returns the percentage of the failed requests.
+func (out ResultOutput) errorRatePercent() float64 {
+	errN := 0
+	for _, v := range out.Errors {
+		errN += v
+	}
+	if total := out.Requests + errN; total > 0 {
+		return 100 * float64(errN) / float64(total)
+	}
+	return 0
+}
+
+// CompareResultFiles compares the JSON results of 'current' with
+// those of 'base': the throughput, the latencies and the error rate.
+func CompareResultFiles(base, current string, th CompareThresholds) ([]ResultDelta, error) {
+	bout, err := ReadResultFile(base)
+	if err != nil {
+		return nil, err
+	}
+	cout, err := ReadResultFile(current)
+	if err != nil {
+		return nil, err
+	}
+	return compareResults(bout, cout, th), nil
+}
+
+func compareResults(base, current ResultOutput, th CompareThresholds) []ResultDelta {
+	// the delta in percent of the base, positive for the increases
+	relative := func(metric string, b, c float64) ResultDelta {
+		d := ResultDelta{Metric: metric, Base: b, Current: c, Unit: "%"}
+		if b != 0 {
+			d.Delta = 100 * (c - b) / b
+		}
+		return d
+	}
+
+	ds := []ResultDelta{}
+	d := relative("requests-per-second", base.RequestsPerSecond, current.RequestsPerSecond)
+	d.Regressed = -d.Delta > th.ThroughputDropPercent
+	ds = append(ds, d)
+
+	d = relative("average-ms", base.AverageMs, current.AverageMs)
+	d.Regressed = d.Delta > th.LatencyIncreasePercent
+	ds = append(ds, d)
+	for _, p := range comparedPercentiles {
+		b, ok1 := base.PercentilesMs[p]
+		c, ok2 := current.PercentilesMs[p]
+		if !ok1 || !ok2 {
+			continue
+		}
+		d = relative(p+"-ms", b, c)
+		d.Regressed = d.Delta > th.LatencyIncreasePercent
+		ds = append(ds, d)
+	}
+
+	b, c := base.errorRatePercent(), current.errorRatePercent()
+	d = ResultDelta{Metric: "error-rate-percent", Base: b, Current: c, Delta: c - b, Unit: "pp"}
+	d.Regressed = d.Delta > th.ErrorRateIncreasePoints
+	ds = append(ds, d)
+	return ds
+}
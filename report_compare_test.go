@@ -0,0 +1,96 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCompareResults(t *testing.T) {
+	base := ResultOutput{
+		Requests:          990,
+		RequestsPerSecond: 1000,
+		AverageMs:         10,
+		PercentilesMs:     map[string]float64{"p50": 8, "p99": 20},
+		Errors:            map[string]int{"timeout": 10},
+	}
+	current := ResultOutput{
+		Requests:          980,
+		RequestsPerSecond: 900,
+		AverageMs:         10.5,
+		PercentilesMs:     map[string]float64{"p50": 8, "p99": 30},
+		Errors:            map[string]int{"timeout": 20},
+	}
+	ds := compareResults(base, current, CompareThresholds{ThroughputDropPercent: 5, LatencyIncreasePercent: 10, ErrorRateIncreasePoints: 2})
+	regressed := make(map[string]bool)
+	for _, d := range ds {
+		regressed[d.Metric] = d.Regressed
+	}
+	expected := map[string]bool{
+		"requests-per-second": true,
+		"average-ms":          false,
+		"p50-ms":              false,
+		"p99-ms":              true,
+		// from 1% to 2%
+		"error-rate-percent": false,
+	}
+	for k, v := range expected {
+		if r, ok := regressed[k]; !ok || r != v {
+			t.Fatalf("%q: expected regressed %v, got %v (%+v)", k, v, r, ds)
+		}
+	}
+	if len(ds) != len(expected) {
+		t.Fatalf("expected %d metrics, got %+v", len(expected), ds)
+	}
+}
+
+func TestCompareResultFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "compare")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	write := func(name string, out ResultOutput) string {
+		bts, err := json.Marshal(out)
+		if err != nil {
+			t.Fatal(err)
+		}
+		fpath := filepath.Join(dir, name)
+		if err = ioutil.WriteFile(fpath, bts, 0644); err != nil {
+			t.Fatal(err)
+		}
+		return fpath
+	}
+	out := ResultOutput{Requests: 100, RequestsPerSecond: 100, AverageMs: 1}
+	a, b := write("a.json", out), write("b.json", out)
+	ds, err := CompareResultFiles(a, b, CompareThresholds{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, d := range ds {
+		if d.Regressed || d.Delta != 0 {
+			t.Fatalf("expected no change, got %+v", d)
+		}
+	}
+
+	if _, err = CompareResultFiles(a, write("empty.json", ResultOutput{}), CompareThresholds{}); err == nil {
+		t.Fatal("expected error of results with no requests")
+	}
+}
@@ -0,0 +1,205 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// OutputFormats are the formats of the results of 'control --output-format'.
+var OutputFormats = []string{"text", "json", "csv"}
+
+// ResultSecond is the result of a second of the run.
+type ResultSecond struct {
+	UnixSecond   int64   `json:"unix_second"`
+	Requests     int64   `json:"requests"`
+	MinLatencyMs float64 `json:"min_latency_ms"`
+	AvgLatencyMs float64 `json:"avg_latency_ms"`
+	MaxLatencyMs float64 `json:"max_latency_ms"`
+}
+
+// ResultFailure is the errors of a run matched to a known failure.
+type ResultFailure struct {
+	Name        string   `json:"name"`
+	Errors      int      `json:"errors"`
+	Messages    []string `json:"messages"`
+	Explanation string   `json:"explanation"`
+	Remediation string   `json:"remediation"`
+}
+
+// ResultOutput is the result of a run, in JSON and CSV.
+type ResultOutput struct {
+	TotalSeconds      float64            `json:"total_seconds"`
+	Requests          int                `json:"requests"`
+	RequestsPerSecond float64            `json:"requests_per_second"`
+	FastestMs         float64            `json:"fastest_ms"`
+	SlowestMs         float64            `json:"slowest_ms"`
+	AverageMs         float64            `json:"average_ms"`
+	StddevMs          float64            `json:"stddev_ms"`
+	PercentilesMs     map[string]float64 `json:"percentiles_ms"`
+	Errors            map[string]int     `json:"errors"`
+	Failures          []ResultFailure    `json:"failures,omitempty"`
+	TimeSeries        []ResultSecond     `json:"timeseries"`
+}
+
+func newResultOutput(st runStats) ResultOutput {
+	out := ResultOutput{
+		TotalSeconds:      st.Total.Seconds(),
+		Requests:          int(st.requests()),
+		RequestsPerSecond: st.RPS,
+		FastestMs:         1000 * st.Fastest,
+		SlowestMs:         1000 * st.Slowest,
+		AverageMs:         1000 * st.Average,
+		StddevMs:          1000 * st.Stddev,
+		PercentilesMs:     make(map[string]float64),
+		Errors:            make(map[string]int),
+		TimeSeries:        make([]ResultSecond, 0, len(st.TimeSeries)),
+	}
+	if st.requests() > 0 {
+		for i, sec := range st.percentiles() {
+			out.PercentilesMs[strings.TrimSuffix(fmt.Sprintf("p%.1f", latencyPercentiles[i]), ".0")] = 1000 * sec
+		}
+	}
+	for k, v := range st.ErrorDist {
+		out.Errors[k] = v
+	}
+	for _, d := range diagnoseFailures(st.ErrorDist) {
+		out.Failures = append(out.Failures, ResultFailure{
+			Name:        d.signature.name,
+			Errors:      d.errors,
+			Messages:    d.messages,
+			Explanation: d.signature.explanation,
+			Remediation: d.signature.remediation,
+		})
+	}
+	for _, dp := range st.TimeSeries {
+		out.TimeSeries = append(out.TimeSeries, ResultSecond{
+			UnixSecond:   dp.Timestamp,
+			Requests:     dp.ThroughPut,
+			MinLatencyMs: toMillisecond(dp.MinLatency),
+			AvgLatencyMs: toMillisecond(dp.AvgLatency),
+			MaxLatencyMs: toMillisecond(dp.MaxLatency),
+		})
+	}
+	return out
+}
+
+// writeCSV writes the result in rows of SECTION, NAME and VALUE, so that
+// the sections of different shapes are in one file. The time series are
+// in the sections of each column, with the unix seconds as the names.
+func (out ResultOutput) writeCSV(w io.Writer) error {
+	f := func(v float64) string { return fmt.Sprintf("%4.4f", v) }
+	rows := [][]string{
+		{"SECTION", "NAME", "VALUE"},
+		{"summary", "total-seconds", f(out.TotalSeconds)},
+		{"summary", "requests", fmt.Sprintf("%d", out.Requests)},
+		{"summary", "requests-per-second", f(out.RequestsPerSecond)},
+		{"summary", "fastest-ms", f(out.FastestMs)},
+		{"summary", "slowest-ms", f(out.SlowestMs)},
+		{"summary", "average-ms", f(out.AverageMs)},
+		{"summary", "stddev-ms", f(out.StddevMs)},
+	}
+	pcts := make([]string, 0, len(out.PercentilesMs))
+	for k := range out.PercentilesMs {
+		pcts = append(pcts, k)
+	}
+	// "p99.9" after "p99"
+	sort.Slice(pcts, func(i, j int) bool {
+		var a, b float64
+		fmt.Sscanf(pcts[i], "p%g", &a)
+		fmt.Sscanf(pcts[j], "p%g", &b)
+		return a < b
+	})
+	for _, k := range pcts {
+		rows = append(rows, []string{"percentile-ms", k, f(out.PercentilesMs[k])})
+	}
+	errs := make([]string, 0, len(out.Errors))
+	for k := range out.Errors {
+		errs = append(errs, k)
+	}
+	sort.Strings(errs)
+	for _, k := range errs {
+		rows = append(rows, []string{"errors", k, fmt.Sprintf("%d", out.Errors[k])})
+	}
+	for _, fl := range out.Failures {
+		rows = append(rows,
+			[]string{"failure-errors", fl.Name, fmt.Sprintf("%d", fl.Errors)},
+			[]string{"failure-remediation", fl.Name, fl.Remediation},
+		)
+	}
+	for _, s := range out.TimeSeries {
+		sec := fmt.Sprintf("%d", s.UnixSecond)
+		rows = append(rows,
+			[]string{"requests", sec, fmt.Sprintf("%d", s.Requests)},
+			[]string{"min-latency-ms", sec, f(s.MinLatencyMs)},
+			[]string{"avg-latency-ms", sec, f(s.AvgLatencyMs)},
+			[]string{"max-latency-ms", sec, f(s.MaxLatencyMs)},
+		)
+	}
+	cw := csv.NewWriter(w)
+	cw.WriteAll(rows)
+	return cw.Error()
+}
+
+// printResults writes the result of the run in 'OutputFormat' to
+// 'OutputFile', or prints it to stdout if 'OutputFile' is empty.
+func (cfg *Config) printResults(st runStats) {
+	if (cfg.OutputFormat == "" || cfg.OutputFormat == "text") && cfg.OutputFile == "" {
+		printStats(st)
+		return
+	}
+
+	w := io.Writer(os.Stdout)
+	if cfg.OutputFile != "" {
+		f, err := createFile(cfg.OutputFile)
+		if err != nil {
+			cfg.lg.Warn("failed to create output file", zap.String("path", cfg.OutputFile), zap.Error(err))
+			return
+		}
+		defer func() {
+			if err := f.Close(); err != nil {
+				cfg.lg.Warn("failed to close output file", zap.String("path", cfg.OutputFile), zap.Error(err))
+			}
+		}()
+		w = f
+	}
+
+	var err error
+	switch cfg.OutputFormat {
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(newResultOutput(st))
+	case "csv":
+		err = newResultOutput(st).writeCSV(w)
+	default:
+		err = writeStats(w, st)
+	}
+	if err != nil {
+		cfg.lg.Warn("failed to write results", zap.String("format", cfg.OutputFormat), zap.Error(err))
+		return
+	}
+	if cfg.OutputFile != "" {
+		cfg.lg.Info("saved results", zap.String("format", cfg.OutputFormat), zap.String("path", cfg.OutputFile))
+	}
+}
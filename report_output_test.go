@@ -0,0 +1,99 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/coreos/dbtester/pkg/hdrhistogram"
+
+	"github.com/coreos/etcd/pkg/report"
+)
+
+// testLatencies returns the histogram of the latencies in seconds.
+func testLatencies(secs ...float64) *hdrhistogram.Histogram {
+	lh := mustLatencyHistogram(DefaultLatencyResolution)
+	cl := lh.client()
+	for _, sec := range secs {
+		cl.record(time.Duration(sec * float64(time.Second)))
+	}
+	return cl.h
+}
+
+func testResultStats() runStats {
+	return newRunStats(
+		testLatencies(0.001, 0.002, 0.003, 0.004),
+		2*time.Second,
+		map[string]int{"timeout": 1},
+		report.TimeSeries{
+			{Timestamp: 100, MinLatency: time.Millisecond, AvgLatency: 2 * time.Millisecond, MaxLatency: 3 * time.Millisecond, ThroughPut: 3},
+			{Timestamp: 101, MinLatency: 4 * time.Millisecond, AvgLatency: 4 * time.Millisecond, MaxLatency: 4 * time.Millisecond, ThroughPut: 1},
+		},
+	)
+}
+
+func TestResultOutputJSON(t *testing.T) {
+	bts, err := json.Marshal(newResultOutput(testResultStats()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out ResultOutput
+	if err = json.Unmarshal(bts, &out); err != nil {
+		t.Fatal(err)
+	}
+	if out.Requests != 4 || out.TotalSeconds != 2 || out.AverageMs != 2.5 || out.Errors["timeout"] != 1 {
+		t.Fatalf("unexpected output %+v", out)
+	}
+	if _, ok := out.PercentilesMs["p99.9"]; !ok {
+		t.Fatalf("expected p99.9, got %v", out.PercentilesMs)
+	}
+	if len(out.Failures) != 0 {
+		t.Fatalf("expected no known failures, got %+v", out.Failures)
+	}
+	exp := []ResultSecond{{UnixSecond: 100, Requests: 3, MinLatencyMs: 1, AvgLatencyMs: 2, MaxLatencyMs: 3}, {UnixSecond: 101, Requests: 1, MinLatencyMs: 4, AvgLatencyMs: 4, MaxLatencyMs: 4}}
+	if !reflect.DeepEqual(out.TimeSeries, exp) {
+		t.Fatalf("expected %+v, got %+v", exp, out.TimeSeries)
+	}
+}
+
+func TestResultOutputCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := newResultOutput(testResultStats()).writeCSV(&buf); err != nil {
+		t.Fatal(err)
+	}
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sections := make(map[string]int)
+	var pcts []string
+	for _, row := range rows[1:] {
+		sections[row[0]]++
+		if row[0] == "percentile-ms" {
+			pcts = append(pcts, row[1])
+		}
+	}
+	if sections["summary"] != 7 || sections["errors"] != 1 || sections["requests"] != 2 || sections["avg-latency-ms"] != 2 {
+		t.Fatalf("unexpected sections %v", sections)
+	}
+	if pcts[0] != "p10" || pcts[len(pcts)-1] != "p99.9" {
+		t.Fatalf("expected percentiles in order, got %v", pcts)
+	}
+}
@@ -19,12 +19,12 @@ import (
 	"math"
 	"path/filepath"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/coreos/dbtester/dbtesterpb"
 	"github.com/coreos/dbtester/pkg/remotestorage"
 
-	"github.com/coreos/etcd/pkg/report"
 	humanize "github.com/dustin/go-humanize"
 	"github.com/gyuho/dataframe"
 )
@@ -69,10 +69,10 @@ func (cfg *Config) SaveDiskSpaceUsageSummary(databaseID string, idxToResponse ma
 		return err
 	}
 
-	return fr.CSV(cfg.ConfigClientMachineInitial.ServerDiskSpaceUsageSummaryPath)
+	return saveCSV(fr, cfg.ConfigClientMachineInitial.ServerDiskSpaceUsageSummaryPath)
 }
 
-func (cfg *Config) saveDataLatencyDistributionSummary(st report.Stats) {
+func (cfg *Config) saveDataLatencyDistributionSummary(st runStats) {
 	fr := dataframe.New()
 
 	c1 := dataframe.NewColumn("TOTAL-SECONDS")
@@ -111,6 +111,255 @@ func (cfg *Config) saveDataLatencyDistributionSummary(st report.Stats) {
 		panic(err)
 	}
 
+	if cfg.harnessOverhead != nil {
+		overheadMs := toMillisecond(cfg.harnessOverhead.latency)
+		c7 := dataframe.NewColumn("HARNESS-OVERHEAD-LATENCY-MS")
+		c7.PushBack(dataframe.NewStringValue(fmt.Sprintf("%4.4f", overheadMs)))
+		if err := fr.AddColumn(c7); err != nil {
+			panic(err)
+		}
+
+		c8 := dataframe.NewColumn("HARNESS-LOOP-LATENCY-MS")
+		c8.PushBack(dataframe.NewStringValue(fmt.Sprintf("%4.4f", toMillisecond(cfg.harnessOverhead.loop))))
+		if err := fr.AddColumn(c8); err != nil {
+			panic(err)
+		}
+
+		c9 := dataframe.NewColumn("HARNESS-MAX-REQUESTS-PER-SECOND")
+		c9.PushBack(dataframe.NewStringValue(fmt.Sprintf("%4.4f", cfg.harnessOverhead.rps)))
+		if err := fr.AddColumn(c9); err != nil {
+			panic(err)
+		}
+
+		// subtract the harness cost from the observed average latency
+		adjusted := 1000*st.Average - overheadMs
+		if adjusted < 0 {
+			adjusted = 0
+		}
+		c10 := dataframe.NewColumn("AVERAGE-LATENCY-MS-ADJUSTED")
+		c10.PushBack(dataframe.NewStringValue(fmt.Sprintf("%4.4f", adjusted)))
+		if err := fr.AddColumn(c10); err != nil {
+			panic(err)
+		}
+	}
+
+	c11 := dataframe.NewColumn("EMPTY-RESPONSE-COUNT")
+	c11.PushBack(dataframe.NewStringValue(cfg.emptyResponses))
+	if err := fr.AddColumn(c11); err != nil {
+		panic(err)
+	}
+
+	if cfg.etcdRBACRootLatency > 0 {
+		rootMs := toMillisecond(cfg.etcdRBACRootLatency)
+		c12 := dataframe.NewColumn("ETCD-RBAC-ROOT-AVERAGE-LATENCY-MS")
+		c12.PushBack(dataframe.NewStringValue(fmt.Sprintf("%4.4f", rootMs)))
+		if err := fr.AddColumn(c12); err != nil {
+			panic(err)
+		}
+
+		c13 := dataframe.NewColumn("ETCD-RBAC-OVERHEAD-LATENCY-MS")
+		c13.PushBack(dataframe.NewStringValue(fmt.Sprintf("%4.4f", 1000*st.Average-rootMs)))
+		if err := fr.AddColumn(c13); err != nil {
+			panic(err)
+		}
+	}
+
+	if hs := cfg.hedgeStats; hs != nil {
+		c14 := dataframe.NewColumn("HEDGED-REQUEST-COUNT")
+		c14.PushBack(dataframe.NewStringValue(hs.hedged))
+		if err := fr.AddColumn(c14); err != nil {
+			panic(err)
+		}
+
+		c15 := dataframe.NewColumn("HEDGE-WIN-RATE-PERCENT")
+		c15.PushBack(dataframe.NewStringValue(fmt.Sprintf("%4.4f", hs.winRate())))
+		if err := fr.AddColumn(c15); err != nil {
+			panic(err)
+		}
+
+		// primary reads are never cancelled, so their p99
+		// is the p99 that would have been without hedging
+		c16 := dataframe.NewColumn("UNHEDGED-P99-LATENCY-MS")
+		c16.PushBack(dataframe.NewStringValue(fmt.Sprintf("%4.4f", 1000*hs.primaryPercentile(99))))
+		if err := fr.AddColumn(c16); err != nil {
+			panic(err)
+		}
+	}
+
+	if ts := cfg.txnStats; ts != nil {
+		c17 := dataframe.NewColumn("TXN-OVERLAP-PERCENT")
+		c17.PushBack(dataframe.NewStringValue(ts.overlapPercent))
+		if err := fr.AddColumn(c17); err != nil {
+			panic(err)
+		}
+
+		c18 := dataframe.NewColumn("TXN-COMMIT-COUNT")
+		c18.PushBack(dataframe.NewStringValue(ts.commits))
+		if err := fr.AddColumn(c18); err != nil {
+			panic(err)
+		}
+
+		c19 := dataframe.NewColumn("TXN-CONFLICT-COUNT")
+		c19.PushBack(dataframe.NewStringValue(ts.conflicts))
+		if err := fr.AddColumn(c19); err != nil {
+			panic(err)
+		}
+
+		c20 := dataframe.NewColumn("TXN-COMMIT-SUCCESS-RATE-PERCENT")
+		c20.PushBack(dataframe.NewStringValue(fmt.Sprintf("%4.4f", ts.successRate())))
+		if err := fr.AddColumn(c20); err != nil {
+			panic(err)
+		}
+	}
+
+	if ls := cfg.lockStats; ls != nil {
+		c21 := dataframe.NewColumn("LOCK-ACQUIRE-COUNT")
+		c21.PushBack(dataframe.NewStringValue(ls.acquires))
+		if err := fr.AddColumn(c21); err != nil {
+			panic(err)
+		}
+
+		c22 := dataframe.NewColumn("LOCK-INVALIDATE-COUNT")
+		c22.PushBack(dataframe.NewStringValue(ls.invalidations))
+		if err := fr.AddColumn(c22); err != nil {
+			panic(err)
+		}
+
+		// the lock-delay of the sessions is included
+		c23 := dataframe.NewColumn("LOCK-INVALIDATE-RELEASE-AVERAGE-MS")
+		c23.PushBack(dataframe.NewStringValue(fmt.Sprintf("%4.4f", toMillisecond(ls.averageInvalidate()))))
+		if err := fr.AddColumn(c23); err != nil {
+			panic(err)
+		}
+	}
+
+	if m := cfg.responses; m != nil {
+		c24 := dataframe.NewColumn("PEAK-RESPONSE-BYTES")
+		c24.PushBack(dataframe.NewStringValue(atomic.LoadInt64(&m.peakResponseBytes)))
+		if err := fr.AddColumn(c24); err != nil {
+			panic(err)
+		}
+
+		c25 := dataframe.NewColumn("PEAK-LOADER-HEAP-BYTES")
+		c25.PushBack(dataframe.NewStringValue(atomic.LoadInt64(&m.peakHeapBytes)))
+		if err := fr.AddColumn(c25); err != nil {
+			panic(err)
+		}
+	}
+
+	if cfg.MaxLoaderCPUs > 0 {
+		c26 := dataframe.NewColumn("LOADER-MAX-CPUS")
+		c26.PushBack(dataframe.NewStringValue(cfg.MaxLoaderCPUs))
+		if err := fr.AddColumn(c26); err != nil {
+			panic(err)
+		}
+
+		// empty if only GOMAXPROCS is capped
+		c27 := dataframe.NewColumn("LOADER-CGROUP-CPU-QUOTA")
+		c27.PushBack(dataframe.NewStringValue(cfg.loaderCPUQuota))
+		if err := fr.AddColumn(c27); err != nil {
+			panic(err)
+		}
+	}
+
+	if cfg.authBaselineLatency > 0 {
+		baseMs := toMillisecond(cfg.authBaselineLatency)
+		c28 := dataframe.NewColumn("AUTH-BASELINE-AVERAGE-LATENCY-MS")
+		c28.PushBack(dataframe.NewStringValue(fmt.Sprintf("%4.4f", baseMs)))
+		if err := fr.AddColumn(c28); err != nil {
+			panic(err)
+		}
+
+		c29 := dataframe.NewColumn("AUTH-OVERHEAD-LATENCY-MS")
+		c29.PushBack(dataframe.NewStringValue(fmt.Sprintf("%4.4f", 1000*st.Average-baseMs)))
+		if err := fr.AddColumn(c29); err != nil {
+			panic(err)
+		}
+	}
+
+	if bs := cfg.bankStats; bs != nil {
+		c30 := dataframe.NewColumn("BANK-ACCOUNT-COUNT")
+		c30.PushBack(dataframe.NewStringValue(bs.accounts))
+		if err := fr.AddColumn(c30); err != nil {
+			panic(err)
+		}
+
+		c31 := dataframe.NewColumn("BANK-TRANSFER-COMMIT-COUNT")
+		c31.PushBack(dataframe.NewStringValue(bs.commits))
+		if err := fr.AddColumn(c31); err != nil {
+			panic(err)
+		}
+
+		c32 := dataframe.NewColumn("BANK-TRANSFER-CONFLICT-COUNT")
+		c32.PushBack(dataframe.NewStringValue(bs.conflicts))
+		if err := fr.AddColumn(c32); err != nil {
+			panic(err)
+		}
+	}
+
+	if cfg.warmupDiscarded > 0 {
+		c33 := dataframe.NewColumn("WARMUP-DISCARDED-REQUEST-COUNT")
+		c33.PushBack(dataframe.NewStringValue(cfg.warmupDiscarded))
+		if err := fr.AddColumn(c33); err != nil {
+			panic(err)
+		}
+	}
+
+	if cfg.sockets != nil {
+		sm := cfg.sockets.summary()
+		c34 := dataframe.NewColumn("PEAK-SOCKET-COUNT")
+		c34.PushBack(dataframe.NewStringValue(sm.peakSockets))
+		if err := fr.AddColumn(c34); err != nil {
+			panic(err)
+		}
+
+		c35 := dataframe.NewColumn("PEAK-TIME-WAIT-SOCKET-COUNT")
+		c35.PushBack(dataframe.NewStringValue(sm.peakTimeWait))
+		if err := fr.AddColumn(c35); err != nil {
+			panic(err)
+		}
+
+		c36 := dataframe.NewColumn("TCP-RETRANSMITTED-SEGMENTS")
+		c36.PushBack(dataframe.NewStringValue(sm.counters.RetransSegs))
+		if err := fr.AddColumn(c36); err != nil {
+			panic(err)
+		}
+
+		c37 := dataframe.NewColumn("TCP-ESTABLISHED-RESETS")
+		c37.PushBack(dataframe.NewStringValue(sm.counters.EstabResets))
+		if err := fr.AddColumn(c37); err != nil {
+			panic(err)
+		}
+
+		c38 := dataframe.NewColumn("TCP-OUT-RESETS")
+		c38.PushBack(dataframe.NewStringValue(sm.counters.OutRsts))
+		if err := fr.AddColumn(c38); err != nil {
+			panic(err)
+		}
+
+		// -1 if the conntrack module is not loaded
+		c39 := dataframe.NewColumn("PEAK-CONNTRACK-ENTRIES")
+		c39.PushBack(dataframe.NewStringValue(sm.peakConntrack))
+		if err := fr.AddColumn(c39); err != nil {
+			panic(err)
+		}
+	}
+
+	if cfg.maintenance != nil {
+		compactions, defrags := cfg.maintenance.counts()
+		c40 := dataframe.NewColumn("COMPACTION-COUNT")
+		c40.PushBack(dataframe.NewStringValue(compactions))
+		if err := fr.AddColumn(c40); err != nil {
+			panic(err)
+		}
+
+		c41 := dataframe.NewColumn("DEFRAGMENTATION-COUNT")
+		c41.PushBack(dataframe.NewStringValue(defrags))
+		if err := fr.AddColumn(c41); err != nil {
+			panic(err)
+		}
+	}
+
 	if len(st.ErrorDist) > 0 {
 		for errName, errN := range st.ErrorDist {
 			errcol := dataframe.NewColumn(fmt.Sprintf("ERROR: %q", errName))
@@ -127,17 +376,17 @@ func (cfg *Config) saveDataLatencyDistributionSummary(st report.Stats) {
 		}
 	}
 
-	if err := fr.CSVHorizontal(cfg.ConfigClientMachineInitial.ClientLatencyDistributionSummaryPath); err != nil {
+	if err := saveCSVHorizontal(fr, cfg.ConfigClientMachineInitial.ClientLatencyDistributionSummaryPath); err != nil {
 		panic(err)
 	}
 }
 
-func (cfg *Config) saveDataLatencyDistributionPercentile(st report.Stats) {
-	pctls, seconds := report.Percentiles(st.Lats)
+func (cfg *Config) saveDataLatencyDistributionPercentile(st runStats) {
+	seconds := st.percentiles()
 	c1 := dataframe.NewColumn("LATENCY-PERCENTILE")
 	c2 := dataframe.NewColumn("LATENCY-MS")
-	for i := range pctls {
-		pct := fmt.Sprintf("p%.1f", pctls[i])
+	for i := range latencyPercentiles {
+		pct := fmt.Sprintf("p%.1f", latencyPercentiles[i])
 		if strings.HasSuffix(pct, ".0") {
 			pct = strings.Replace(pct, ".0", "", -1)
 		}
@@ -153,27 +402,24 @@ func (cfg *Config) saveDataLatencyDistributionPercentile(st report.Stats) {
 	if err := fr.AddColumn(c2); err != nil {
 		panic(err)
 	}
-	if err := fr.CSV(cfg.ConfigClientMachineInitial.ClientLatencyDistributionPercentilePath); err != nil {
+	if err := saveCSV(fr, cfg.ConfigClientMachineInitial.ClientLatencyDistributionPercentilePath); err != nil {
 		panic(err)
 	}
 }
 
-func (cfg *Config) saveDataLatencyDistributionAll(st report.Stats) {
+func (cfg *Config) saveDataLatencyDistributionAll(st runStats) {
+	if st.requests() == 0 {
+		cfg.lg.Warn("no latency recorded; skipping latency distribution")
+		return
+	}
 	min := int64(math.MaxInt64)
 	max := int64(-100000)
 	rm := make(map[int64]int64)
-	for _, lt := range st.Lats {
-		// convert second(float64) to millisecond
-		ms := lt * 1000
-
+	st.lats.ForEach(func(us, count int64) {
 		// truncate all digits below 10ms
 		// (e.g. 125.11ms becomes 120ms)
-		v := int64(math.Trunc(ms/10) * 10)
-		if _, ok := rm[v]; !ok {
-			rm[v] = 1
-		} else {
-			rm[v]++
-		}
+		v := us / 10000 * 10
+		rm[v] += count
 
 		if min > v {
 			min = v
@@ -181,7 +427,7 @@ func (cfg *Config) saveDataLatencyDistributionAll(st report.Stats) {
 		if max < v {
 			max = v
 		}
-	}
+	})
 
 	c1 := dataframe.NewColumn("LATENCY-MS")
 	c2 := dataframe.NewColumn("COUNT")
@@ -206,12 +452,12 @@ func (cfg *Config) saveDataLatencyDistributionAll(st report.Stats) {
 	if err := fr.AddColumn(c2); err != nil {
 		panic(err)
 	}
-	if err := fr.CSV(cfg.ConfigClientMachineInitial.ClientLatencyDistributionAllPath); err != nil {
+	if err := saveCSV(fr, cfg.ConfigClientMachineInitial.ClientLatencyDistributionAllPath); err != nil {
 		panic(err)
 	}
 }
 
-func (cfg *Config) saveDataLatencyThroughputTimeseries(gcfg dbtesterpb.ConfigClientMachineAgentControl, st report.Stats, clientNs []int64) {
+func (cfg *Config) saveDataLatencyThroughputTimeseries(gcfg dbtesterpb.ConfigClientMachineAgentControl, st runStats, clientNs []int64) {
 	if len(clientNs) == 0 && len(gcfg.ConfigClientMachineBenchmarkOptions.ConnectionClientNumbers) == 0 {
 		clientNs = make([]int64, len(st.TimeSeries))
 		for i := range clientNs {
@@ -254,7 +500,7 @@ func (cfg *Config) saveDataLatencyThroughputTimeseries(gcfg dbtesterpb.ConfigCli
 		panic(err)
 	}
 
-	if err := fr.CSV(cfg.ConfigClientMachineInitial.ClientLatencyThroughputTimeseriesPath); err != nil {
+	if err := saveCSV(fr, cfg.ConfigClientMachineInitial.ClientLatencyThroughputTimeseriesPath); err != nil {
 		panic(err)
 	}
 
@@ -285,16 +531,33 @@ func (cfg *Config) saveDataLatencyThroughputTimeseries(gcfg dbtesterpb.ConfigCli
 		panic(err)
 	}
 
-	if err := frr.CSV(cfg.ConfigClientMachineInitial.ClientLatencyByKeyNumberPath); err != nil {
+	if err := saveCSV(frr, cfg.ConfigClientMachineInitial.ClientLatencyByKeyNumberPath); err != nil {
 		panic(err)
 	}
 }
 
-func (cfg *Config) saveAllStats(gcfg dbtesterpb.ConfigClientMachineAgentControl, stats report.Stats, clientNs []int64) {
+func (cfg *Config) saveAllStats(gcfg dbtesterpb.ConfigClientMachineAgentControl, stats runStats, clientNs []int64) {
 	cfg.saveDataLatencyDistributionSummary(stats)
 	cfg.saveDataLatencyDistributionPercentile(stats)
 	cfg.saveDataLatencyDistributionAll(stats)
 	cfg.saveDataLatencyThroughputTimeseries(gcfg, stats, clientNs)
+	cfg.saveEndpointTraffic()
+	cfg.saveRollingRestart()
+	cfg.saveConvergence()
+	cfg.saveChaos()
+	cfg.saveMaintenance()
+	cfg.saveLatencyCorrelation(stats)
+	cfg.saveResourceUsage(stats)
+	cfg.saveSocketStats()
+	cfg.saveIdentityLeases()
+	cfg.saveLearnerReads()
+	cfg.saveTLSHandshakes()
+	cfg.saveSchedule()
+	cfg.saveSizeHistogram()
+	cfg.saveSpikeRecovery(gcfg)
+	cfg.saveMemberBreakdown()
+	cfg.saveZoneBreakdown()
+	cfg.saveLatencyHistogram()
 }
 
 // UploadToGoogle uploads target file to Google Cloud Storage.
@@ -0,0 +1,136 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"time"
+
+	"github.com/coreos/dbtester/pkg/hdrhistogram"
+
+	"github.com/coreos/etcd/pkg/report"
+)
+
+// latencyPercentiles are the percentiles of the reports.
+var latencyPercentiles = []float64{10, 25, 50, 75, 90, 95, 99, 99.9}
+
+// runStats is the result of a run. The latency summary and the
+// percentiles are of the histogram merged from the clients, so that
+// they are at the significant digits of '--latency-resolution'.
+type runStats struct {
+	// Total is the duration of the run, after the warmup if any.
+	Total time.Duration
+	// RPS is the number of successful requests per second.
+	RPS float64
+
+	// Fastest, Slowest, Average and Stddev are of the latencies
+	// of the successful requests, in seconds.
+	Fastest float64
+	Slowest float64
+	Average float64
+	Stddev  float64
+
+	// ErrorDist is the number of failed requests by error.
+	ErrorDist map[string]int
+	// TimeSeries is of the successful requests by second.
+	TimeSeries report.TimeSeries
+
+	// lats are the latencies of the successful requests in microseconds.
+	lats *hdrhistogram.Histogram
+}
+
+// newRunStats returns the stats of the latencies, which it takes the
+// ownership of, and summarizes them.
+func newRunStats(lats *hdrhistogram.Histogram, total time.Duration, errs map[string]int, ts report.TimeSeries) runStats {
+	if errs == nil {
+		errs = make(map[string]int)
+	}
+	st := runStats{Total: total, ErrorDist: errs, TimeSeries: ts, lats: lats}
+	st.summarize()
+	return st
+}
+
+// summarize sets the summary of the latencies and the throughput.
+func (st *runStats) summarize() {
+	st.RPS, st.Fastest, st.Slowest, st.Average, st.Stddev = 0, 0, 0, 0, 0
+	n := st.requests()
+	if n == 0 {
+		return
+	}
+	if st.Total > 0 {
+		st.RPS = float64(n) / st.Total.Seconds()
+	}
+	st.Fastest = float64(st.lats.Min()) / 1e6
+	st.Slowest = float64(st.lats.Max()) / 1e6
+	st.Average = st.lats.Mean() / 1e6
+	st.Stddev = st.lats.StdDev() / 1e6
+}
+
+// requests returns the number of successful requests.
+func (st runStats) requests() int64 {
+	if st.lats == nil {
+		return 0
+	}
+	return st.lats.TotalCount()
+}
+
+// percentile returns the latency of the percentile in seconds, 0 if none.
+func (st runStats) percentile(pct float64) float64 {
+	if st.requests() == 0 {
+		return 0
+	}
+	return float64(st.lats.ValueAtPercentile(pct)) / 1e6
+}
+
+// percentiles returns the latencies of 'latencyPercentiles' in seconds.
+func (st runStats) percentiles() []float64 {
+	vs := make([]float64, len(latencyPercentiles))
+	for i, pct := range latencyPercentiles {
+		vs[i] = st.percentile(pct)
+	}
+	return vs
+}
+
+// mergeLatencies adds the latencies and the errors of 'o', without
+// summarizing them.
+func (st *runStats) mergeLatencies(o runStats) {
+	if st.ErrorDist == nil {
+		st.ErrorDist = make(map[string]int)
+	}
+	for k, v := range o.ErrorDist {
+		st.ErrorDist[k] += v
+	}
+	if o.lats == nil {
+		return
+	}
+	if st.lats == nil {
+		st.lats = copyHistogram(o.lats)
+		return
+	}
+	if err := st.lats.Merge(o.lats); err != nil {
+		// the histograms of one run have the same significant digits
+		panic(err)
+	}
+}
+
+func copyHistogram(h *hdrhistogram.Histogram) *hdrhistogram.Histogram {
+	c, err := hdrhistogram.New(int64(latencyHighest/time.Microsecond), h.SignificantFigures())
+	if err != nil {
+		panic(err)
+	}
+	if err = c.Merge(h); err != nil {
+		panic(err)
+	}
+	return c
+}
@@ -0,0 +1,210 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/coreos/dbtester/dbtesterpb"
+	"github.com/coreos/etcd/pkg/report"
+)
+
+// defaultFullResolutionSeconds is the default recent window
+// of the time series kept at full resolution.
+const defaultFullResolutionSeconds = 3600
+
+// seriesPoint aggregates the latencies of a second, or of a bucket.
+type seriesPoint struct {
+	min, max, total time.Duration
+	count           int64
+}
+
+func (p *seriesPoint) add(lat time.Duration) {
+	if p.count == 0 || lat < p.min {
+		p.min = lat
+	}
+	if lat > p.max {
+		p.max = lat
+	}
+	p.total += lat
+	p.count++
+}
+
+func (p *seriesPoint) merge(o *seriesPoint) {
+	if o.count == 0 {
+		return
+	}
+	if p.count == 0 || o.min < p.min {
+		p.min = o.min
+	}
+	if o.max > p.max {
+		p.max = o.max
+	}
+	p.total += o.total
+	p.count += o.count
+}
+
+// tieredTimeSeries aggregates the latencies by second, but only keeps
+// the last 'window' seconds at full resolution. Older seconds are merged
+// into 'step'-second buckets, so that the memory stays bounded however long the run is,
+// while the time series still covers the entire run.
+type tieredTimeSeries struct {
+	window, step int64
+
+	mu sync.Mutex
+	// first and latest are the first and the latest seconds seen
+	first, latest int64
+	// secs is keyed by unix second, and buckets by the first second
+	// of the bucket. A bucket is only created once all of its seconds
+	// are out of the window, so that the two never overlap.
+	secs    map[int64]*seriesPoint
+	buckets map[int64]*seriesPoint
+}
+
+// newTieredTimeSeries returns nil if 'time_series_downsample_seconds' is not set.
+func newTieredTimeSeries(gcfg dbtesterpb.ConfigClientMachineAgentControl) *tieredTimeSeries {
+	step := gcfg.ConfigClientMachineBenchmarkOptions.TimeSeriesDownsampleSeconds
+	if step <= 1 {
+		return nil
+	}
+	window := gcfg.ConfigClientMachineBenchmarkOptions.TimeSeriesFullResolutionSeconds
+	if window <= 0 {
+		window = defaultFullResolutionSeconds
+	}
+	return &tieredTimeSeries{
+		window:  window,
+		step:    step,
+		first:   math.MaxInt64,
+		secs:    make(map[int64]*seriesPoint),
+		buckets: make(map[int64]*seriesPoint),
+	}
+}
+
+// newSecondTimeSeries returns the time series that keeps every second
+// of the run at full resolution.
+func newSecondTimeSeries() *tieredTimeSeries {
+	return &tieredTimeSeries{
+		window:  math.MaxInt64 / 2,
+		step:    1,
+		first:   math.MaxInt64,
+		secs:    make(map[int64]*seriesPoint),
+		buckets: make(map[int64]*seriesPoint),
+	}
+}
+
+func (ts *tieredTimeSeries) bucket(sec int64) int64 { return sec - sec%ts.step }
+
+// expired returns true if all seconds of the bucket of 'sec' are out of the window.
+func (ts *tieredTimeSeries) expired(sec int64) bool {
+	return ts.bucket(sec)+ts.step-1 <= ts.latest-ts.window
+}
+
+func (ts *tieredTimeSeries) add(start time.Time, lat time.Duration) {
+	sec := start.Unix()
+
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	if sec < ts.first {
+		ts.first = sec
+	}
+	if sec > ts.latest {
+		ts.latest = sec
+		ts.compact()
+	}
+
+	m, k := ts.secs, sec
+	if ts.expired(sec) {
+		m, k = ts.buckets, ts.bucket(sec)
+	}
+	p, ok := m[k]
+	if !ok {
+		p = &seriesPoint{}
+		m[k] = p
+	}
+	p.add(lat)
+}
+
+// compact merges the seconds out of the window into their buckets.
+func (ts *tieredTimeSeries) compact() {
+	for sec, p := range ts.secs {
+		if !ts.expired(sec) {
+			continue
+		}
+		b, ok := ts.buckets[ts.bucket(sec)]
+		if !ok {
+			b = &seriesPoint{}
+			ts.buckets[ts.bucket(sec)] = b
+		}
+		b.merge(p)
+		delete(ts.secs, sec)
+	}
+}
+
+// timeSeries returns one data point per bucket, followed by one data
+// point per second of the window, with the missing ones filled in.
+// The throughput of a bucket is the average per second of the bucket,
+// and its timestamp is the first second of the bucket in the run.
+func (ts *tieredTimeSeries) timeSeries() report.TimeSeries {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	if len(ts.secs) == 0 && len(ts.buckets) == 0 {
+		return nil
+	}
+	var tss report.TimeSeries
+	for b, p := range ts.buckets {
+		// the run may start or end in the middle of a bucket
+		from, to := b, b+ts.step-1
+		if from < ts.first {
+			from = ts.first
+		}
+		if to > ts.latest {
+			to = ts.latest
+		}
+		tss = append(tss, p.dataPoint(from, to-from+1))
+	}
+	var from int64 = math.MaxInt64
+	for sec := range ts.secs {
+		if sec < from {
+			from = sec
+		}
+	}
+	for sec := from; sec <= ts.latest && len(ts.secs) > 0; sec++ {
+		p, ok := ts.secs[sec]
+		if !ok {
+			p = &seriesPoint{}
+		}
+		tss = append(tss, p.dataPoint(sec, 1))
+	}
+	sort.Sort(tss)
+	return tss
+}
+
+func (p *seriesPoint) dataPoint(ts, seconds int64) report.DataPoint {
+	dp := report.DataPoint{
+		Timestamp:  ts,
+		MinLatency: p.min,
+		MaxLatency: p.max,
+		ThroughPut: (p.count + seconds/2) / seconds,
+	}
+	if p.count > 0 {
+		dp.AvgLatency = p.total / time.Duration(p.count)
+	}
+	return dp
+}
@@ -0,0 +1,65 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/coreos/dbtester/dbtesterpb"
+	"github.com/coreos/etcd/pkg/report"
+)
+
+func TestTieredTimeSeries(t *testing.T) {
+	gcfg := dbtesterpb.ConfigClientMachineAgentControl{
+		ConfigClientMachineBenchmarkOptions: &dbtesterpb.ConfigClientMachineBenchmarkOptions{},
+	}
+	if ts := newTieredTimeSeries(gcfg); ts != nil {
+		t.Fatalf("expected nil without 'time_series_downsample_seconds', got %+v", ts)
+	}
+	gcfg.ConfigClientMachineBenchmarkOptions.TimeSeriesDownsampleSeconds = 10
+	gcfg.ConfigClientMachineBenchmarkOptions.TimeSeriesFullResolutionSeconds = 5
+	ts := newTieredTimeSeries(gcfg)
+
+	// 2 requests per second from 105 to 134, except for 131
+	for sec := int64(105); sec < 135; sec++ {
+		if sec == 131 {
+			continue
+		}
+		ts.add(time.Unix(sec, 0), time.Duration(sec))
+		ts.add(time.Unix(sec, 0), time.Duration(sec+2))
+	}
+	// late result of an expired second
+	ts.add(time.Unix(108, 0), time.Duration(1))
+
+	if len(ts.secs) > int(ts.window+ts.step) {
+		t.Fatalf("expected at most %d seconds at full resolution, got %d", ts.window+ts.step, len(ts.secs))
+	}
+	exp := report.TimeSeries{
+		// 105 ~ 109, the run starts in the middle of the bucket
+		{Timestamp: 105, MinLatency: 1, AvgLatency: 98, MaxLatency: 111, ThroughPut: 2},
+		{Timestamp: 110, MinLatency: 110, AvgLatency: 115, MaxLatency: 121, ThroughPut: 2},
+		{Timestamp: 120, MinLatency: 120, AvgLatency: 125, MaxLatency: 131, ThroughPut: 2},
+		{Timestamp: 130, MinLatency: 130, AvgLatency: 131, MaxLatency: 132, ThroughPut: 2},
+		{Timestamp: 131},
+		{Timestamp: 132, MinLatency: 132, AvgLatency: 133, MaxLatency: 134, ThroughPut: 2},
+		{Timestamp: 133, MinLatency: 133, AvgLatency: 134, MaxLatency: 135, ThroughPut: 2},
+		{Timestamp: 134, MinLatency: 134, AvgLatency: 135, MaxLatency: 136, ThroughPut: 2},
+	}
+	if tss := ts.timeSeries(); !reflect.DeepEqual(tss, exp) {
+		t.Fatalf("expected\n%+v\ngot\n%+v", exp, tss)
+	}
+}
@@ -0,0 +1,190 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/coreos/etcd/etcdserver/api/v3rpc/rpctypes"
+	"github.com/samuel/go-zookeeper/zk"
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Categories of the request errors.
+const (
+	ErrCategoryTimeout     = "timeout"
+	ErrCategoryCanceled    = "canceled"
+	ErrCategoryUnavailable = "unavailable"
+	ErrCategoryAuth        = "auth"
+	ErrCategoryTooLarge    = "too-large"
+	ErrCategoryConflict    = "conflict"
+	ErrCategoryOther       = "other"
+)
+
+// RequestError is the error of a request of the stress, with the
+// database, the operation and the endpoint it failed on.
+type RequestError struct {
+	// Database is the database ID.
+	Database string
+	// Op is the operation of the request: write, read or txn.
+	Op string
+	// Endpoint is the endpoint that the request was sent to.
+	// Empty if the client balances the requests over the endpoints.
+	Endpoint string
+	// Category is one of the 'ErrCategory' constants.
+	Category string
+	// Err is the error that the client returned.
+	Err error
+}
+
+// Error returns the error of the client, so that the error
+// distribution of the report is the same as of the client errors.
+func (e *RequestError) Error() string { return e.Err.Error() }
+
+// Unwrap returns the error of the client.
+func (e *RequestError) Unwrap() error { return e.Err }
+
+// newRequestError wraps the error of the client, or fills the database
+// and operation of the error if already wrapped with its endpoint.
+func newRequestError(database, op, endpoint string, err error) error {
+	if err == nil {
+		return nil
+	}
+	if re, ok := err.(*RequestError); ok {
+		if re.Database == "" {
+			re.Database = database
+		}
+		if re.Op == "" {
+			re.Op = op
+		}
+		if re.Endpoint == "" {
+			re.Endpoint = endpoint
+		}
+		return re
+	}
+	return &RequestError{Database: database, Op: op, Endpoint: endpoint, Category: errorCategory(err), Err: err}
+}
+
+// requestOp returns the operation of the request of the benchmark type.
+func requestOp(typ string, req *request) string {
+	switch typ {
+	case "read-oneshot":
+		return "read"
+	case "read-write":
+		if req.write {
+			return "write"
+		}
+		return "read"
+	}
+	return typ
+}
+
+// ErrorCategory returns the category of the request error,
+// or 'ErrCategoryOther' if it is not a 'RequestError'.
+func ErrorCategory(err error) string {
+	if re, ok := err.(*RequestError); ok {
+		return re.Category
+	}
+	return ErrCategoryOther
+}
+
+// errorCategory classifies the error of the etcd, Zookeeper or Consul client.
+func errorCategory(err error) string {
+	switch err {
+	case context.DeadlineExceeded:
+		return ErrCategoryTimeout
+	case context.Canceled:
+		return ErrCategoryCanceled
+	case errBlackout, zk.ErrConnectionClosed, zk.ErrNoServer, zk.ErrSessionExpired:
+		return ErrCategoryUnavailable
+	case zk.ErrNoAuth:
+		return ErrCategoryAuth
+	case zk.ErrBadVersion, zk.ErrNodeExists:
+		return ErrCategoryConflict
+	case rpctypes.ErrRequestTooLarge, rpctypes.ErrGRPCRequestTooLarge, errResponseTooLarge:
+		return ErrCategoryTooLarge
+	}
+
+	code := codes.Unknown
+	if ev, ok := err.(rpctypes.EtcdError); ok {
+		code = ev.Code()
+	} else if st, ok := status.FromError(err); ok {
+		code = st.Code()
+	}
+	switch code {
+	case codes.DeadlineExceeded:
+		return ErrCategoryTimeout
+	case codes.Canceled:
+		return ErrCategoryCanceled
+	case codes.Unavailable:
+		return ErrCategoryUnavailable
+	case codes.ResourceExhausted:
+		return ErrCategoryTooLarge
+	case codes.PermissionDenied, codes.Unauthenticated:
+		return ErrCategoryAuth
+	case codes.FailedPrecondition:
+		if strings.Contains(err.Error(), "authentication") {
+			return ErrCategoryAuth
+		}
+	}
+
+	// Consul client errors are of the HTTP status code and body
+	s := err.Error()
+	switch {
+	case strings.Contains(s, "timeout"), strings.Contains(s, "deadline exceeded"):
+		return ErrCategoryTimeout
+	case strings.Contains(s, "connection refused"), strings.Contains(s, "connection reset"), strings.Contains(s, "no such host"):
+		return ErrCategoryUnavailable
+	case strings.Contains(s, "response code: 403"), strings.Contains(s, "Permission denied"), strings.Contains(s, "ACL not found"):
+		return ErrCategoryAuth
+	case strings.Contains(s, "response code: 413"), strings.Contains(s, "too large"):
+		return ErrCategoryTooLarge
+	}
+	return ErrCategoryOther
+}
+
+// errorCategories counts the request errors by category.
+type errorCategories struct {
+	mu     sync.Mutex
+	counts map[string]int64
+}
+
+func (c *errorCategories) add(err error) {
+	c.mu.Lock()
+	if c.counts == nil {
+		c.counts = make(map[string]int64)
+	}
+	c.counts[ErrorCategory(err)]++
+	c.mu.Unlock()
+}
+
+// logErrorCategories logs the number of the request errors by category.
+func (cfg *Config) logErrorCategories(c *errorCategories) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	cs := make([]string, 0, len(c.counts))
+	for k := range c.counts {
+		cs = append(cs, k)
+	}
+	sort.Strings(cs)
+	for _, k := range cs {
+		cfg.lg.Warn("request errors", zap.String("category", k), zap.Int64("errors", c.counts[k]))
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/coreos/etcd/etcdserver/api/v3rpc/rpctypes"
+	"github.com/samuel/go-zookeeper/zk"
+	"golang.org/x/net/context"
+)
+
+func TestErrorCategory(t *testing.T) {
+	tests := []struct {
+		err      error
+		category string
+	}{
+		{context.DeadlineExceeded, ErrCategoryTimeout},
+		{context.Canceled, ErrCategoryCanceled},
+		{errBlackout, ErrCategoryUnavailable},
+		{rpctypes.ErrNoLeader, ErrCategoryUnavailable},
+		{rpctypes.ErrGRPCPermissionDenied, ErrCategoryAuth},
+		{rpctypes.ErrRequestTooLarge, ErrCategoryTooLarge},
+		{errResponseTooLarge, ErrCategoryTooLarge},
+		{zk.ErrNoAuth, ErrCategoryAuth},
+		{zk.ErrBadVersion, ErrCategoryConflict},
+		{errors.New("Unexpected response code: 413 (Value exceeds 524288 byte limit)"), ErrCategoryTooLarge},
+		{errors.New("dial tcp 10.0.0.1:8500: connect: connection refused"), ErrCategoryUnavailable},
+		{errMockInjected, ErrCategoryOther},
+	}
+	for i, tt := range tests {
+		err := newRequestError("mock", "write", "", tt.err)
+		if c := ErrorCategory(err); c != tt.category {
+			t.Errorf("#%d: %v: expected category %q, got %q", i, tt.err, tt.category, c)
+		}
+		if err.Error() != tt.err.Error() {
+			t.Errorf("#%d: expected error %q, got %q", i, tt.err, err)
+		}
+	}
+}
+
+func TestNewRequestErrorEndpoint(t *testing.T) {
+	err := newRequestError("", "", "10.0.0.1:2379", context.DeadlineExceeded)
+	err = newRequestError("etcd__tip", requestOp("read-write", &request{write: true}), "", err)
+	re, ok := err.(*RequestError)
+	if !ok {
+		t.Fatalf("expected *RequestError, got %T", err)
+	}
+	exp := RequestError{Database: "etcd__tip", Op: "write", Endpoint: "10.0.0.1:2379", Category: ErrCategoryTimeout, Err: context.DeadlineExceeded}
+	if *re != exp {
+		t.Fatalf("expected %+v, got %+v", exp, *re)
+	}
+	if newRequestError("mock", "read", "", nil) != nil {
+		t.Fatal("expected nil error")
+	}
+}
@@ -0,0 +1,273 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/coreos/dbtester/dbtesterpb"
+	"github.com/coreos/dbtester/pkg/resource"
+
+	"github.com/coreos/etcd/pkg/report"
+	"github.com/gyuho/dataframe"
+	"go.uber.org/zap"
+)
+
+// resourceSource is a database process to sample,
+// of an agent or of a local program.
+type resourceSource struct {
+	name   string
+	sample func() (resource.Usage, error)
+}
+
+type resourceSample struct {
+	unixSecond int64
+	usage      resource.Usage
+}
+
+// resourceMonitor samples the resource usage of the database
+// processes in the background while the benchmark runs.
+type resourceMonitor struct {
+	lg       *zap.Logger
+	interval time.Duration
+	sources  []resourceSource
+
+	mu sync.Mutex
+	// samples are the samples of each source, in order
+	samples [][]resourceSample
+
+	stopOnce sync.Once
+	stopc    chan struct{}
+	donec    chan struct{}
+}
+
+// resourceSources returns the sources of 'resource_monitor'.
+func (cfg *Config) resourceSources(gcfg dbtesterpb.ConfigClientMachineAgentControl) ([]resourceSource, error) {
+	rcfg := gcfg.ConfigClientMachineBenchmarkOptions.ConfigClientMachineResourceMonitor
+	var srcs []resourceSource
+	if rcfg.Agents {
+		if len(gcfg.AgentEndpoints) == 0 {
+			return nil, fmt.Errorf("'resource_monitor' of 'agents' requires 'agent_endpoints'")
+		}
+		for i, ep := range gcfg.AgentEndpoints {
+			idx := i
+			srcs = append(srcs, resourceSource{name: ep, sample: func() (resource.Usage, error) {
+				resp, err := cfg.sendRequest(gcfg.DatabaseID, dbtesterpb.Operation_Metrics, idx)
+				if err != nil {
+					return resource.Usage{}, err
+				}
+				return resource.Usage{
+					CPUPercent:           resp.CPUPercent,
+					VMRSSBytes:           resp.VMRSSBytes,
+					DiskReadBytes:        resp.DiskReadBytes,
+					DiskWriteBytes:       resp.DiskWriteBytes,
+					NetworkReceiveBytes:  resp.NetworkReceiveBytes,
+					NetworkTransmitBytes: resp.NetworkTransmitBytes,
+				}, nil
+			}})
+		}
+	}
+	for _, program := range rcfg.Programs {
+		program := program
+		srcs = append(srcs, resourceSource{name: "local-" + program, sample: func() (resource.Usage, error) {
+			return resource.Sample(0, program, rcfg.DiskDevice, rcfg.NetworkInterface)
+		}})
+	}
+	if len(srcs) == 0 {
+		return nil, fmt.Errorf("'resource_monitor' requires 'agents' or 'programs'")
+	}
+	return srcs, nil
+}
+
+func newResourceMonitor(lg *zap.Logger, rcfg *dbtesterpb.ConfigClientMachineResourceMonitor, srcs []resourceSource) *resourceMonitor {
+	m := &resourceMonitor{
+		lg:       lg,
+		interval: time.Second,
+		sources:  srcs,
+		samples:  make([][]resourceSample, len(srcs)),
+		stopc:    make(chan struct{}),
+		donec:    make(chan struct{}),
+	}
+	if rcfg.IntervalSeconds > 0 {
+		m.interval = time.Duration(rcfg.IntervalSeconds) * time.Second
+	}
+	go m.run()
+	return m
+}
+
+func (m *resourceMonitor) sample(now time.Time) {
+	var wg sync.WaitGroup
+	wg.Add(len(m.sources))
+	for i := range m.sources {
+		go func(i int) {
+			defer wg.Done()
+			u, err := m.sources[i].sample()
+			if err != nil {
+				m.lg.Warn("failed to sample resource usage", zap.String("source", m.sources[i].name), zap.Error(err))
+				return
+			}
+			m.mu.Lock()
+			m.samples[i] = append(m.samples[i], resourceSample{unixSecond: now.Unix(), usage: u})
+			m.mu.Unlock()
+		}(i)
+	}
+	wg.Wait()
+}
+
+func (m *resourceMonitor) run() {
+	defer close(m.donec)
+	m.sample(time.Now())
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case now := <-ticker.C:
+			m.sample(now)
+		case <-m.stopc:
+			return
+		}
+	}
+}
+
+func (m *resourceMonitor) stop() {
+	m.stopOnce.Do(func() {
+		close(m.stopc)
+		<-m.donec
+	})
+}
+
+// resourcePoint is the usage of a source in the sample interval
+// that ends at 'unixSecond', with the throughput of the interval.
+type resourcePoint struct {
+	unixSecond int64
+	source     string
+	cpuPercent float64
+	vmrssBytes int64
+	// the bytes per second of the interval
+	diskRead        float64
+	diskWrite       float64
+	networkReceive  float64
+	networkTransmit float64
+	// requests is the requests per second of the interval
+	requests float64
+}
+
+// points returns the usage of every sample interval of every source.
+func (m *resourceMonitor) points(ts report.TimeSeries) []resourcePoint {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var ps []resourcePoint
+	for i, src := range m.sources {
+		ss := m.samples[i]
+		for j := 1; j < len(ss); j++ {
+			prev, cur := ss[j-1], ss[j]
+			secs := float64(cur.unixSecond - prev.unixSecond)
+			if secs <= 0 {
+				continue
+			}
+			var reqN int64
+			for _, pt := range ts {
+				if pt.Timestamp > prev.unixSecond && pt.Timestamp <= cur.unixSecond {
+					reqN += pt.ThroughPut
+				}
+			}
+			ps = append(ps, resourcePoint{
+				unixSecond:      cur.unixSecond,
+				source:          src.name,
+				cpuPercent:      cur.usage.CPUPercent,
+				vmrssBytes:      cur.usage.VMRSSBytes,
+				diskRead:        float64(cur.usage.DiskReadBytes-prev.usage.DiskReadBytes) / secs,
+				diskWrite:       float64(cur.usage.DiskWriteBytes-prev.usage.DiskWriteBytes) / secs,
+				networkReceive:  float64(cur.usage.NetworkReceiveBytes-prev.usage.NetworkReceiveBytes) / secs,
+				networkTransmit: float64(cur.usage.NetworkTransmitBytes-prev.usage.NetworkTransmitBytes) / secs,
+				requests:        float64(reqN) / secs,
+			})
+		}
+	}
+	return ps
+}
+
+func (cfg *Config) saveResourceUsage(st runStats) {
+	m := cfg.resources
+	if m == nil {
+		return
+	}
+	m.stop()
+
+	ps := m.points(st.TimeSeries)
+	for _, src := range m.sources {
+		var cpus, reqs []float64
+		var maxRSS int64
+		for _, p := range ps {
+			if p.source != src.name {
+				continue
+			}
+			cpus, reqs = append(cpus, p.cpuPercent), append(reqs, p.requests)
+			if maxRSS < p.vmrssBytes {
+				maxRSS = p.vmrssBytes
+			}
+		}
+		if len(cpus) == 0 {
+			cfg.lg.Warn("no resource usage sampled", zap.String("source", src.name))
+			continue
+		}
+		var avg float64
+		for _, v := range cpus {
+			avg += v
+		}
+		avg /= float64(len(cpus))
+		cfg.lg.Sugar().Infof("resource usage [source: %q | average CPU: %.2f%% | max VMRSS: %d bytes | CPU correlation with throughput: %.4f]",
+			src.name, avg, maxRSS, pearson(cpus, reqs))
+	}
+
+	fpath := cfg.ConfigClientMachineInitial.ClientResourceUsagePath
+	if fpath == "" {
+		cfg.lg.Warn("'client_resource_usage_path' is not set; skipping resource usage")
+		return
+	}
+	c1 := dataframe.NewColumn("UNIX-SECOND")
+	c2 := dataframe.NewColumn("SOURCE")
+	c3 := dataframe.NewColumn("CPU-PERCENT")
+	c4 := dataframe.NewColumn("VMRSS-BYTES")
+	c5 := dataframe.NewColumn("DISK-READ-BYTES-PER-SECOND")
+	c6 := dataframe.NewColumn("DISK-WRITE-BYTES-PER-SECOND")
+	c7 := dataframe.NewColumn("NETWORK-RECEIVE-BYTES-PER-SECOND")
+	c8 := dataframe.NewColumn("NETWORK-TRANSMIT-BYTES-PER-SECOND")
+	c9 := dataframe.NewColumn("REQUESTS-PER-SECOND")
+	for _, p := range ps {
+		c1.PushBack(dataframe.NewStringValue(p.unixSecond))
+		c2.PushBack(dataframe.NewStringValue(p.source))
+		c3.PushBack(dataframe.NewStringValue(fmt.Sprintf("%.2f", p.cpuPercent)))
+		c4.PushBack(dataframe.NewStringValue(p.vmrssBytes))
+		c5.PushBack(dataframe.NewStringValue(fmt.Sprintf("%.2f", p.diskRead)))
+		c6.PushBack(dataframe.NewStringValue(fmt.Sprintf("%.2f", p.diskWrite)))
+		c7.PushBack(dataframe.NewStringValue(fmt.Sprintf("%.2f", p.networkReceive)))
+		c8.PushBack(dataframe.NewStringValue(fmt.Sprintf("%.2f", p.networkTransmit)))
+		c9.PushBack(dataframe.NewStringValue(fmt.Sprintf("%.2f", p.requests)))
+	}
+	fr := dataframe.New()
+	for _, col := range []dataframe.Column{c1, c2, c3, c4, c5, c6, c7, c8, c9} {
+		if err := fr.AddColumn(col); err != nil {
+			panic(err)
+		}
+	}
+	if err := saveCSV(fr, fpath); err != nil {
+		panic(err)
+	}
+	cfg.lg.Info("saved resource usage", zap.String("path", fpath))
+}
@@ -0,0 +1,100 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/coreos/dbtester/dbtesterpb"
+	"github.com/coreos/dbtester/pkg/resource"
+
+	"github.com/coreos/etcd/pkg/report"
+	"go.uber.org/zap"
+)
+
+func TestResourceSources(t *testing.T) {
+	cfg := &Config{lg: zap.NewNop()}
+	gcfg := dbtesterpb.ConfigClientMachineAgentControl{
+		DatabaseID: "etcd__v3_3",
+		ConfigClientMachineBenchmarkOptions: &dbtesterpb.ConfigClientMachineBenchmarkOptions{
+			ConfigClientMachineResourceMonitor: &dbtesterpb.ConfigClientMachineResourceMonitor{},
+		},
+	}
+	if _, err := cfg.resourceSources(gcfg); err == nil {
+		t.Fatal("expected error of no source")
+	}
+	rcfg := gcfg.ConfigClientMachineBenchmarkOptions.ConfigClientMachineResourceMonitor
+	rcfg.Agents = true
+	if _, err := cfg.resourceSources(gcfg); err == nil {
+		t.Fatal("expected error of no agent endpoint")
+	}
+	gcfg.AgentEndpoints = []string{"10.0.0.1:3500", "10.0.0.2:3500"}
+	rcfg.Programs = []string{"etcd"}
+	srcs, err := cfg.resourceSources(gcfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(srcs) != 3 || srcs[1].name != "10.0.0.2:3500" || srcs[2].name != "local-etcd" {
+		t.Fatalf("unexpected sources %+v", srcs)
+	}
+}
+
+func TestResourceMonitor(t *testing.T) {
+	m := &resourceMonitor{
+		lg:      zap.NewNop(),
+		sources: []resourceSource{{name: "a"}, {name: "b"}},
+		samples: [][]resourceSample{
+			{
+				{unixSecond: 10, usage: resource.Usage{CPUPercent: 10, DiskWriteBytes: 1000, NetworkReceiveBytes: 50}},
+				{unixSecond: 12, usage: resource.Usage{CPUPercent: 90, VMRSSBytes: 4096, DiskWriteBytes: 5000, NetworkReceiveBytes: 250}},
+			},
+			// only one sample, of no interval
+			{{unixSecond: 10}},
+		},
+		stopc: make(chan struct{}),
+		donec: make(chan struct{}),
+	}
+	close(m.donec)
+
+	ts := report.TimeSeries{{Timestamp: 10, ThroughPut: 100}, {Timestamp: 11, ThroughPut: 300}, {Timestamp: 12, ThroughPut: 500}}
+	ps := m.points(ts)
+	if len(ps) != 1 {
+		t.Fatalf("expected 1 point, got %+v", ps)
+	}
+	if p := ps[0]; p.source != "a" || p.diskWrite != 2000 || p.networkReceive != 100 || p.requests != 400 || p.cpuPercent != 90 {
+		t.Fatalf("unexpected point %+v", p)
+	}
+
+	dir, err := ioutil.TempDir("", "resources")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	cfg := &Config{lg: zap.NewNop(), resources: m}
+	cfg.ConfigClientMachineInitial.ClientResourceUsagePath = filepath.Join(dir, "resources.csv")
+	cfg.saveResourceUsage(runStats{TimeSeries: ts})
+	bts, err := ioutil.ReadFile(cfg.ConfigClientMachineInitial.ClientResourceUsagePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(bts)), "\n")
+	if exp := "12,a,90.00,4096,0.00,2000.00,100.00,0.00,400.00"; len(lines) != 2 || lines[1] != exp {
+		t.Fatalf("expected %q, got %q", exp, lines)
+	}
+}
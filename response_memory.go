@@ -0,0 +1,133 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"errors"
+	"runtime"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errResponseTooLarge is the error of the responses over
+// 'MaxResponseBytes', or that the client refused to receive.
+var errResponseTooLarge = errors.New("response too large (over '--max-response-bytes')")
+
+// heapSampleInterval is the interval to sample the heap of the loader.
+const heapSampleInterval = 500 * time.Millisecond
+
+// responseMemory tracks the peak response size of the requests and the
+// peak heap of the loader, so that huge responses, such as of ranges over
+// the whole key space, are reported and fail over 'MaxResponseBytes',
+// instead of growing the heap until the loader is killed silently.
+type responseMemory struct {
+	// maxBytes is the limit of each response, 0 for no limit.
+	maxBytes int64
+
+	// updated atomically
+	peakResponseBytes int64
+	peakHeapBytes     int64
+	tooLargeN         int64
+
+	stopc chan struct{}
+	donec chan struct{}
+}
+
+// newResponseMemory returns the tracker of the responses, with the heap
+// of the loader sampled until 'stop'.
+func newResponseMemory(maxBytes int64) *responseMemory {
+	m := &responseMemory{
+		maxBytes: maxBytes,
+		stopc:    make(chan struct{}),
+		donec:    make(chan struct{}),
+	}
+	m.sampleHeap()
+	go func() {
+		defer close(m.donec)
+		ticker := time.NewTicker(heapSampleInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-m.stopc:
+				m.sampleHeap()
+				return
+			case <-ticker.C:
+				m.sampleHeap()
+			}
+		}
+	}()
+	return m
+}
+
+func (m *responseMemory) sampleHeap() {
+	var ms runtime.MemStats
+	runtime.ReadMemStats(&ms)
+	storeMax(&m.peakHeapBytes, int64(ms.HeapAlloc))
+}
+
+// storeMax stores 'v' to 'addr' if greater than the stored value.
+func storeMax(addr *int64, v int64) {
+	for {
+		cur := atomic.LoadInt64(addr)
+		if v <= cur || atomic.CompareAndSwapInt64(addr, cur, v) {
+			return
+		}
+	}
+}
+
+// check records the size of the response of the request, and returns
+// 'errResponseTooLarge' if it is over the limit, or if the client refused
+// to receive it (as etcd clients do over their receive limit).
+func (m *responseMemory) check(responseBytes int, err error) error {
+	if err != nil {
+		if st, ok := status.FromError(err); ok && st.Code() == codes.ResourceExhausted && m.maxBytes > 0 {
+			atomic.AddInt64(&m.tooLargeN, 1)
+			return errResponseTooLarge
+		}
+		return err
+	}
+	storeMax(&m.peakResponseBytes, int64(responseBytes))
+	if m.maxBytes > 0 && int64(responseBytes) > m.maxBytes {
+		atomic.AddInt64(&m.tooLargeN, 1)
+		return errResponseTooLarge
+	}
+	return nil
+}
+
+// stop stops sampling the heap.
+func (m *responseMemory) stop() {
+	close(m.stopc)
+	<-m.donec
+}
+
+func (cfg *Config) logResponseMemory() {
+	m := cfg.responses
+	if m == nil {
+		return
+	}
+	cfg.lg.Info(
+		"loader memory",
+		zap.Int64("peak-response-bytes", atomic.LoadInt64(&m.peakResponseBytes)),
+		zap.Int64("peak-heap-bytes", atomic.LoadInt64(&m.peakHeapBytes)),
+		zap.Int64("max-response-bytes", m.maxBytes),
+	)
+	if n := atomic.LoadInt64(&m.tooLargeN); n > 0 {
+		cfg.lg.Warn("responses over '--max-response-bytes' failed", zap.Int64("responses", n), zap.Int64("max-response-bytes", m.maxBytes))
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"errors"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestResponseMemory(t *testing.T) {
+	m := newResponseMemory(1000)
+	defer m.stop()
+
+	for _, n := range []int{10, 800, 20} {
+		if err := m.check(n, nil); err != nil {
+			t.Fatalf("expected no error of %d bytes, got %v", n, err)
+		}
+	}
+	if err := m.check(1001, nil); err != errResponseTooLarge {
+		t.Fatalf("expected %v, got %v", errResponseTooLarge, err)
+	}
+	// refused by the client, as over its receive limit
+	if err := m.check(0, status.Error(codes.ResourceExhausted, "grpc: received message larger than max (2000 vs. 1000)")); err != errResponseTooLarge {
+		t.Fatalf("expected %v, got %v", errResponseTooLarge, err)
+	}
+	errOther := errors.New("other")
+	if err := m.check(0, errOther); err != errOther {
+		t.Fatalf("expected %v, got %v", errOther, err)
+	}
+
+	if m.peakResponseBytes != 1001 {
+		t.Fatalf("expected peak response of 1001 bytes, got %d", m.peakResponseBytes)
+	}
+	if m.tooLargeN != 2 {
+		t.Fatalf("expected 2 responses too large, got %d", m.tooLargeN)
+	}
+	if m.peakHeapBytes <= 0 {
+		t.Fatalf("expected the heap sampled, got %d", m.peakHeapBytes)
+	}
+}
+
+func TestResponseMemoryNoLimit(t *testing.T) {
+	m := newResponseMemory(0)
+	defer m.stop()
+	if err := m.check(1<<30, nil); err != nil {
+		t.Fatalf("expected no limit, got %v", err)
+	}
+}
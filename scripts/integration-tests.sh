@@ -0,0 +1,23 @@
+#!/usr/bin/env bash
+set -e
+
+if ! [[ "$0" =~ "scripts/integration-tests.sh" ]]; then
+    echo "must be run from repository root"
+    exit 255
+fi
+
+COMPOSE="docker-compose -f scripts/docker-compose.integration.yml"
+
+echo "Starting databases..."
+${COMPOSE} up -d
+trap "${COMPOSE} down" EXIT
+
+export DBTESTER_INTEGRATION=1
+export DBTESTER_INTEGRATION_ETCD=127.0.0.1:2379
+export DBTESTER_INTEGRATION_ZOOKEEPER=127.0.0.1:2181
+export DBTESTER_INTEGRATION_CONSUL=127.0.0.1:8500
+
+echo "Running integration tests...";
+go test -v -tags integration -run Integration -timeout 10m .
+
+echo "Success";
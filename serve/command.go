@@ -0,0 +1,125 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serve
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/coreos/dbtester"
+	"github.com/coreos/dbtester/control"
+	"github.com/coreos/dbtester/dbtesterpb"
+
+	"github.com/coreos/etcd/pkg/netutil"
+	"github.com/gyuho/linux-inspect/df"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+type flags struct {
+	httpPort         string
+	dataDir          string
+	diskDevice       string
+	networkInterface string
+
+	schedule           string
+	scheduleConfig     string
+	scheduleDatabaseID string
+}
+
+var globalFlags flags
+
+func init() {
+	dn, err := df.GetDevice("/")
+	if err != nil {
+		lg.Warn("cannot get disk device mounted at '/'", zap.Error(err))
+	}
+	nm, err := netutil.GetDefaultInterfaces()
+	if err != nil {
+		lg.Warn("cannot detect default network interface", zap.Error(err))
+	}
+	var nt string
+	for k := range nm {
+		nt = k
+		break
+	}
+
+	Command.PersistentFlags().StringVar(&globalFlags.httpPort, "http-port", ":3700", "Port to serve the benchmark API.")
+	Command.PersistentFlags().StringVar(&globalFlags.dataDir, "data-dir", "dbtester-serve", "Directory to save the configuration and results of each run.")
+	Command.PersistentFlags().StringVar(&globalFlags.diskDevice, "disk-device", dn, "Disk device to collect disk statistics metrics from.")
+	Command.PersistentFlags().StringVar(&globalFlags.networkInterface, "network-interface", nt, "Network interface to record in/outgoing packets.")
+
+	Command.PersistentFlags().StringVar(&globalFlags.schedule, "schedule", "", "Cron-style schedule (e.g. \"0 3 * * *\") to rerun '--schedule-config' periodically.")
+	Command.PersistentFlags().StringVar(&globalFlags.scheduleConfig, "schedule-config", "", "YAML configuration to run on '--schedule'.")
+	Command.PersistentFlags().StringVar(&globalFlags.scheduleDatabaseID, "schedule-database-id", "", "Database ID to run on '--schedule'.")
+}
+
+// Command implements 'serve' command.
+var Command = &cobra.Command{
+	Use:   "serve",
+	Short: "Runs benchmarks submitted over HTTP.",
+	RunE:  commandFunc,
+}
+
+func commandFunc(cmd *cobra.Command, args []string) error {
+	var (
+		sched     *schedule
+		schedBody []byte
+	)
+	if globalFlags.schedule != "" {
+		var err error
+		if sched, err = parseSchedule(globalFlags.schedule); err != nil {
+			return err
+		}
+		if schedBody, err = ioutil.ReadFile(globalFlags.scheduleConfig); err != nil {
+			return fmt.Errorf("cannot read '--schedule-config' (%v)", err)
+		}
+		if !dbtesterpb.IsValidDatabaseID(globalFlags.scheduleDatabaseID) {
+			return fmt.Errorf("'--schedule-database-id' %q is unknown", globalFlags.scheduleDatabaseID)
+		}
+	}
+	if err := os.MkdirAll(globalFlags.dataDir, 0777); err != nil {
+		return err
+	}
+	srv := newServer(lg, globalFlags.dataDir, func(cfg *dbtester.Config, databaseID string) error {
+		return control.Run(cfg, databaseID, globalFlags.diskDevice, globalFlags.networkInterface)
+	})
+
+	stopc := make(chan struct{})
+	go srv.runQueue(stopc)
+	if sched != nil {
+		go srv.runSchedule(sched, globalFlags.scheduleDatabaseID, schedBody, stopc)
+		lg.Info("scheduled runs", zap.String("schedule", globalFlags.schedule), zap.String("config", globalFlags.scheduleConfig), zap.String("database", globalFlags.scheduleDatabaseID))
+	}
+
+	errc := make(chan error, 1)
+	go func() { errc <- http.ListenAndServe(globalFlags.httpPort, srv.httpHandler()) }()
+	lg.Info("serve started", zap.String("http-server-port", globalFlags.httpPort), zap.String("data-dir", globalFlags.dataDir))
+
+	notifier := make(chan os.Signal, 1)
+	signal.Notify(notifier, syscall.SIGINT, syscall.SIGTERM)
+	var err error
+	select {
+	case sig := <-notifier:
+		lg.Info("received signal", zap.String("signal", sig.String()))
+	case err = <-errc:
+	}
+	close(stopc)
+	return err
+}
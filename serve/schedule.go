@@ -0,0 +1,165 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serve
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// schedule is a cron-style schedule of
+// "minute hour day-of-month month day-of-week".
+type schedule struct {
+	minute, hour, dom, month, dow map[int]bool
+
+	// domAny and dowAny are true when the field is '*';
+	// as in cron, the day matches either field when both are restricted.
+	domAny, dowAny bool
+}
+
+// parseSchedule parses the cron expression. Each field is '*',
+// a number, a range 'a-b', a step '*/n' or 'a-b/n', or a list of them.
+func parseSchedule(spec string) (*schedule, error) {
+	fs := strings.Fields(spec)
+	if len(fs) != 5 {
+		return nil, fmt.Errorf("schedule %q must have 5 fields (got %d)", spec, len(fs))
+	}
+	bounds := [5][2]int{{0, 59}, {0, 23}, {1, 31}, {1, 12}, {0, 6}}
+	var ms [5]map[int]bool
+	for i, f := range fs {
+		m, err := parseScheduleField(f, bounds[i][0], bounds[i][1])
+		if err != nil {
+			return nil, fmt.Errorf("schedule %q: %v", spec, err)
+		}
+		ms[i] = m
+	}
+	return &schedule{
+		minute: ms[0],
+		hour:   ms[1],
+		dom:    ms[2],
+		month:  ms[3],
+		dow:    ms[4],
+		domAny: fs[2] == "*",
+		dowAny: fs[4] == "*",
+	}, nil
+}
+
+func parseScheduleField(f string, min, max int) (map[int]bool, error) {
+	m := make(map[int]bool)
+	for _, part := range strings.Split(f, ",") {
+		rg, step := part, 1
+		if idx := strings.Index(part, "/"); idx != -1 {
+			n, err := strconv.Atoi(part[idx+1:])
+			if err != nil || n <= 0 {
+				return nil, fmt.Errorf("invalid step in %q", part)
+			}
+			rg, step = part[:idx], n
+		}
+		lo, hi := min, max
+		if rg != "*" {
+			ss := strings.SplitN(rg, "-", 2)
+			var err error
+			if lo, err = strconv.Atoi(ss[0]); err != nil {
+				return nil, fmt.Errorf("invalid value in %q", part)
+			}
+			hi = lo
+			if len(ss) == 2 {
+				if hi, err = strconv.Atoi(ss[1]); err != nil {
+					return nil, fmt.Errorf("invalid value in %q", part)
+				}
+			}
+		}
+		if lo < min || hi > max || lo > hi {
+			return nil, fmt.Errorf("%q is out of range [%d, %d]", part, min, max)
+		}
+		for v := lo; v <= hi; v += step {
+			m[v] = true
+		}
+	}
+	return m, nil
+}
+
+func (s *schedule) matchDay(t time.Time) bool {
+	dom, dow := s.dom[t.Day()], s.dow[int(t.Weekday())]
+	switch {
+	case s.domAny && s.dowAny:
+		return true
+	case s.domAny:
+		return dow
+	case s.dowAny:
+		return dom
+	default:
+		return dom || dow
+	}
+}
+
+// next returns the first time after t that matches the schedule,
+// or zero time if none is found within 5 years (e.g. "0 0 31 2 *").
+func (s *schedule) next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	end := t.AddDate(5, 0, 0)
+	for t.Before(end) {
+		switch {
+		case !s.month[int(t.Month())]:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+		case !s.matchDay(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+		case !s.hour[t.Hour()]:
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+		case !s.minute[t.Minute()]:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// runSchedule submits the configuration at every scheduled time,
+// skipping the time if the previous scheduled run has not finished.
+func (s *server) runSchedule(sched *schedule, databaseID string, body []byte, stopc <-chan struct{}) {
+	var last *run
+	for {
+		now := time.Now()
+		nt := sched.next(now)
+		if nt.IsZero() {
+			s.lg.Warn("schedule has no next time; stopping")
+			return
+		}
+		s.lg.Info("next scheduled run", zap.Time("at", nt))
+		select {
+		case <-time.After(nt.Sub(now)):
+		case <-stopc:
+			return
+		}
+
+		if last != nil {
+			if st := s.status(last).State; st == stateQueued || st == stateRunning {
+				s.lg.Warn("skipping scheduled run; previous run is still in progress", zap.String("run", last.ID), zap.String("state", st))
+				continue
+			}
+		}
+		r, err := s.submit(databaseID, body)
+		if err != nil {
+			s.lg.Warn("failed to submit scheduled run", zap.Error(err))
+			continue
+		}
+		last = r
+	}
+}
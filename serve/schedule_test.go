@@ -0,0 +1,55 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serve
+
+import (
+	"testing"
+	"time"
+)
+
+func TestScheduleNext(t *testing.T) {
+	// 2017-06-01 is Thursday
+	now := time.Date(2017, time.June, 1, 10, 30, 15, 0, time.UTC)
+	tests := []struct {
+		spec string
+		next time.Time
+	}{
+		{"* * * * *", time.Date(2017, time.June, 1, 10, 31, 0, 0, time.UTC)},
+		{"0 3 * * *", time.Date(2017, time.June, 2, 3, 0, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2017, time.June, 1, 10, 45, 0, 0, time.UTC)},
+		{"0 9-17/4 * * *", time.Date(2017, time.June, 1, 13, 0, 0, 0, time.UTC)},
+		{"0 0 * * 1", time.Date(2017, time.June, 5, 0, 0, 0, 0, time.UTC)},
+		{"0 0 1,15 * *", time.Date(2017, time.June, 15, 0, 0, 0, 0, time.UTC)},
+		{"0 0 1 1 *", time.Date(2018, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 31 2 *", time.Time{}},
+	}
+	for i, tt := range tests {
+		s, err := parseSchedule(tt.spec)
+		if err != nil {
+			t.Fatalf("#%d: %v", i, err)
+		}
+		if nt := s.next(now); !nt.Equal(tt.next) {
+			t.Fatalf("#%d: %q expected %v, got %v", i, tt.spec, tt.next, nt)
+		}
+	}
+}
+
+func TestParseScheduleError(t *testing.T) {
+	for i, spec := range []string{"", "* * * *", "60 * * * *", "* 5-1 * * *", "*/0 * * * *", "a * * * *"} {
+		if _, err := parseSchedule(spec); err == nil {
+			t.Fatalf("#%d: expected error for %q", i, spec)
+		}
+	}
+}
@@ -0,0 +1,327 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serve
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/coreos/dbtester"
+	"github.com/coreos/dbtester/dbtesterpb"
+
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	stateQueued    = "queued"
+	stateRunning   = "running"
+	stateSucceeded = "succeeded"
+	stateFailed    = "failed"
+)
+
+// maxConfigBytes is the maximum size of a submitted configuration.
+const maxConfigBytes = 1 << 20
+
+// archiveName is the file in the data directory that every finished
+// run is appended to, one JSON line per run, to track results over time.
+const archiveName = "archive.jsonl"
+
+// run is a submitted benchmark.
+type run struct {
+	ID         string    `json:"id"`
+	DatabaseID string    `json:"database_id"`
+	State      string    `json:"state"`
+	Step       string    `json:"step,omitempty"`
+	Error      string    `json:"error,omitempty"`
+	Submitted  time.Time `json:"submitted"`
+	Started    time.Time `json:"started,omitempty"`
+	Finished   time.Time `json:"finished,omitempty"`
+	// Results are the files saved by the run so far.
+	Results []string `json:"results,omitempty"`
+
+	dir string
+	cfg *dbtester.Config
+}
+
+// runFunc runs the benchmark of the configuration, as 'control' does.
+type runFunc func(cfg *dbtester.Config, databaseID string) error
+
+type server struct {
+	lg      *zap.Logger
+	dataDir string
+	runFn   runFunc
+
+	mu   sync.Mutex
+	seq  int
+	runs map[string]*run
+
+	queue chan *run
+}
+
+func newServer(lg *zap.Logger, dataDir string, fn runFunc) *server {
+	return &server{
+		lg:      lg,
+		dataDir: dataDir,
+		runFn:   fn,
+		runs:    make(map[string]*run),
+		queue:   make(chan *run, 100),
+	}
+}
+
+// runQueue runs the submitted benchmarks one at a time,
+// so that they do not interfere with each other.
+func (s *server) runQueue(stopc <-chan struct{}) {
+	for {
+		select {
+		case r := <-s.queue:
+			s.execute(r)
+		case <-stopc:
+			return
+		}
+	}
+}
+
+func (s *server) execute(r *run) {
+	s.update(r, func() {
+		r.State, r.Started = stateRunning, time.Now()
+	})
+	r.cfg.OnProgress = func(step string) {
+		s.update(r, func() { r.Step = step })
+	}
+	s.lg.Info("starting run", zap.String("run", r.ID), zap.String("database", r.DatabaseID))
+
+	err := func() (err error) {
+		// do not take down the server with the run
+		defer func() {
+			if rv := recover(); rv != nil {
+				err = fmt.Errorf("panic: %v", rv)
+			}
+		}()
+		return s.runFn(r.cfg, r.DatabaseID)
+	}()
+
+	s.update(r, func() {
+		r.Finished = time.Now()
+		r.State = stateSucceeded
+		if err != nil {
+			r.State, r.Error = stateFailed, err.Error()
+		}
+	})
+	s.lg.Info("finished run", zap.String("run", r.ID), zap.String("state", r.State), zap.Duration("took", r.Finished.Sub(r.Started)))
+
+	if err = s.appendArchive(r); err != nil {
+		s.lg.Warn("failed to append to archive", zap.String("run", r.ID), zap.Error(err))
+	}
+}
+
+func (s *server) appendArchive(r *run) error {
+	bts, err := json.Marshal(s.status(r))
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(filepath.Join(s.dataDir, archiveName), os.O_RDWR|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = f.Write(append(bts, '\n'))
+	return err
+}
+
+func (s *server) update(r *run, fn func()) {
+	s.mu.Lock()
+	fn()
+	s.mu.Unlock()
+}
+
+// submit saves the configuration with the path prefix of the run
+// directory, so that the results of runs do not overwrite each other.
+func (s *server) submit(databaseID string, body []byte) (*run, error) {
+	if !dbtesterpb.IsValidDatabaseID(databaseID) {
+		return nil, fmt.Errorf("database id %q is unknown", databaseID)
+	}
+	var m yaml.MapSlice
+	if err := yaml.Unmarshal(body, &m); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	s.seq++
+	id := fmt.Sprintf("%s-%d", time.Now().Format("20060102-150405"), s.seq)
+	s.mu.Unlock()
+
+	dir := filepath.Join(s.dataDir, id)
+	if err := os.MkdirAll(dir, 0777); err != nil {
+		return nil, err
+	}
+	m = setPathPrefix(m, dir)
+	bts, err := yaml.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	fpath := filepath.Join(dir, "config.yaml")
+	if err = ioutil.WriteFile(fpath, bts, 0644); err != nil {
+		return nil, err
+	}
+	cfg, err := dbtester.ReadConfig(fpath, false)
+	if err != nil {
+		os.RemoveAll(dir)
+		return nil, err
+	}
+	if _, ok := cfg.DatabaseIDToConfigClientMachineAgentControl[databaseID]; !ok {
+		os.RemoveAll(dir)
+		return nil, fmt.Errorf("%q is not found in the configuration", databaseID)
+	}
+
+	r := &run{ID: id, DatabaseID: databaseID, State: stateQueued, Submitted: time.Now(), dir: dir, cfg: cfg}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	select {
+	case s.queue <- r:
+	default:
+		os.RemoveAll(dir)
+		return nil, fmt.Errorf("too many queued runs")
+	}
+	s.runs[id] = r
+	s.lg.Info("queued run", zap.String("run", id), zap.String("database", databaseID))
+	return r, nil
+}
+
+// setPathPrefix sets 'config_client_machine_initial.path_prefix'.
+func setPathPrefix(m yaml.MapSlice, prefix string) yaml.MapSlice {
+	for i := range m {
+		if m[i].Key != "config_client_machine_initial" {
+			continue
+		}
+		initial, _ := m[i].Value.(yaml.MapSlice)
+		found := false
+		for j := range initial {
+			if initial[j].Key == "path_prefix" {
+				initial[j].Value, found = prefix, true
+			}
+		}
+		if !found {
+			initial = append(initial, yaml.MapItem{Key: "path_prefix", Value: prefix})
+		}
+		m[i].Value = initial
+		return m
+	}
+	return append(m, yaml.MapItem{Key: "config_client_machine_initial", Value: yaml.MapSlice{{Key: "path_prefix", Value: prefix}}})
+}
+
+// status returns the copy of the run, with the result files so far.
+func (s *server) status(r *run) run {
+	s.mu.Lock()
+	cp := *r
+	s.mu.Unlock()
+
+	fs, _ := ioutil.ReadDir(r.dir)
+	for _, f := range fs {
+		if !f.IsDir() && f.Name() != "config.yaml" {
+			cp.Results = append(cp.Results, f.Name())
+		}
+	}
+	return cp
+}
+
+func (s *server) list() []run {
+	s.mu.Lock()
+	rs := make([]*run, 0, len(s.runs))
+	for _, r := range s.runs {
+		rs = append(rs, r)
+	}
+	s.mu.Unlock()
+	sort.Slice(rs, func(i, j int) bool { return rs[i].Submitted.Before(rs[j].Submitted) })
+
+	ss := make([]run, len(rs))
+	for i, r := range rs {
+		ss[i] = s.status(r)
+	}
+	return ss
+}
+
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, code int, err error) {
+	writeJSON(w, code, map[string]string{"error": err.Error()})
+}
+
+// httpHandler serves:
+//
+//	POST /runs?database-id=ID   submits the YAML configuration in the body
+//	GET  /runs                  lists all runs
+//	GET  /runs/RUN              returns the state of the run
+//	GET  /runs/RUN/results/FILE returns the result file of the run
+func (s *server) httpHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/runs", func(w http.ResponseWriter, req *http.Request) {
+		switch req.Method {
+		case http.MethodGet:
+			writeJSON(w, http.StatusOK, s.list())
+
+		case http.MethodPost:
+			body, err := ioutil.ReadAll(http.MaxBytesReader(w, req.Body, maxConfigBytes))
+			if err != nil {
+				writeError(w, http.StatusBadRequest, err)
+				return
+			}
+			r, err := s.submit(req.URL.Query().Get("database-id"), body)
+			if err != nil {
+				writeError(w, http.StatusBadRequest, err)
+				return
+			}
+			writeJSON(w, http.StatusCreated, s.status(r))
+
+		default:
+			writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %q is not allowed", req.Method))
+		}
+	})
+	mux.HandleFunc("/runs/", func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %q is not allowed", req.Method))
+			return
+		}
+		ss := strings.Split(strings.TrimPrefix(req.URL.Path, "/runs/"), "/")
+		s.mu.Lock()
+		r, ok := s.runs[ss[0]]
+		s.mu.Unlock()
+		if !ok {
+			writeError(w, http.StatusNotFound, fmt.Errorf("run %q is not found", ss[0]))
+			return
+		}
+		switch {
+		case len(ss) == 1:
+			writeJSON(w, http.StatusOK, s.status(r))
+		case len(ss) == 3 && ss[1] == "results" && ss[2] != "" && ss[2] != "." && ss[2] != "..":
+			http.ServeFile(w, req, filepath.Join(r.dir, ss[2]))
+		default:
+			writeError(w, http.StatusNotFound, fmt.Errorf("%q is not found", req.URL.Path))
+		}
+	})
+	return mux
+}
@@ -0,0 +1,100 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serve
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/coreos/dbtester"
+
+	"go.uber.org/zap"
+)
+
+func TestServer(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dbtester-serve")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	srv := newServer(zap.NewNop(), dir, func(cfg *dbtester.Config, databaseID string) error {
+		cfg.Progress("step 2: starting tests")
+		return ioutil.WriteFile(cfg.ConfigClientMachineInitial.ClientLatencyDistributionSummaryPath, []byte("ok"), 0644)
+	})
+	stopc := make(chan struct{})
+	defer close(stopc)
+	go srv.runQueue(stopc)
+
+	ts := httptest.NewServer(srv.httpHandler())
+	defer ts.Close()
+
+	body, err := ioutil.ReadFile(filepath.Join("..", "test-configs", "write-100K-keys-mock.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := http.Post(ts.URL+"/runs?database-id=mock", "application/x-yaml", bytes.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var r run
+	if err = json.NewDecoder(resp.Body).Decode(&r); err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("expected %d, got %d", http.StatusCreated, resp.StatusCode)
+	}
+
+	for i := 0; r.State != stateSucceeded; i++ {
+		if i == 100 || r.State == stateFailed {
+			t.Fatalf("run did not succeed: %+v", r)
+		}
+		time.Sleep(10 * time.Millisecond)
+		if resp, err = http.Get(ts.URL + "/runs/" + r.ID); err != nil {
+			t.Fatal(err)
+		}
+		json.NewDecoder(resp.Body).Decode(&r)
+		resp.Body.Close()
+	}
+	if r.Step != "step 2: starting tests" || len(r.Results) != 1 {
+		t.Fatalf("unexpected run %+v", r)
+	}
+
+	resp, err = http.Get(ts.URL + "/runs/" + r.ID + "/results/" + r.Results[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	bts, _ := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if string(bts) != "ok" {
+		t.Fatalf("expected result 'ok', got %q", bts)
+	}
+
+	if resp, err = http.Post(ts.URL+"/runs?database-id=unknown", "application/x-yaml", bytes.NewReader(body)); err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected %d for unknown database, got %d", http.StatusBadRequest, resp.StatusCode)
+	}
+}
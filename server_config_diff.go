@@ -0,0 +1,108 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+)
+
+// DiffServerConfigs writes the differences of the database server
+// configurations that the agents captured in a base and a current run
+// of the same database, by node, with the lines only in the base run
+// as removed and the lines only in the current run as added. The nodes
+// are labeled with the database tag, if not empty.
+func DiffServerConfigs(w io.Writer, databaseTag string, base, current []string) error {
+	baseLabel, currentLabel := "base", "current"
+	if databaseTag != "" {
+		baseLabel, currentLabel = "base "+databaseTag, "current "+databaseTag
+	}
+	if len(base) != len(current) {
+		fmt.Fprintf(w, "\n%d node(s) in %s, %d in %s\n", len(base), baseLabel, len(current), currentLabel)
+	}
+	for i := range base {
+		if i >= len(current) {
+			break
+		}
+		a, err := readConfigLines(base[i])
+		if err != nil {
+			return err
+		}
+		b, err := readConfigLines(current[i])
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "\n--- %s (node %d)\n+++ %s (node %d)\n", baseLabel, i+1, currentLabel, i+1)
+		removed, added := diffLines(a, b)
+		if len(removed) == 0 && len(added) == 0 {
+			fmt.Fprintln(w, "  (no difference)")
+			continue
+		}
+		for _, line := range removed {
+			fmt.Fprintln(w, "- "+line)
+		}
+		for _, line := range added {
+			fmt.Fprintln(w, "+ "+line)
+		}
+	}
+	return nil
+}
+
+// readConfigLines reads the configuration file written by agent,
+// skipping comments and empty lines.
+func readConfigLines(fpath string) ([]string, error) {
+	bts, err := ioutil.ReadFile(fpath)
+	if err != nil {
+		return nil, err
+	}
+	var lines []string
+	for _, line := range strings.Split(string(bts), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines, nil
+}
+
+// diffLines returns the lines only in 'a', and the lines only in 'b',
+// preserving the original order.
+func diffLines(a, b []string) (removed, added []string) {
+	inA, inB := make(map[string]int), make(map[string]int)
+	for _, line := range a {
+		inA[line]++
+	}
+	for _, line := range b {
+		inB[line]++
+	}
+	for _, line := range a {
+		if inB[line] > 0 {
+			inB[line]--
+			continue
+		}
+		removed = append(removed, line)
+	}
+	for _, line := range b {
+		if inA[line] > 0 {
+			inA[line]--
+			continue
+		}
+		added = append(added, line)
+	}
+	return removed, added
+}
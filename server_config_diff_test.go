@@ -0,0 +1,78 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDiffServerConfigs(t *testing.T) {
+	dir, err := ioutil.TempDir("", "server-config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	write := func(name, data string) string {
+		fpath := filepath.Join(dir, name)
+		if err := ioutil.WriteFile(fpath, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return fpath
+	}
+	base := []string{
+		write("base-1", "# node: 1\n/usr/bin/etcd\n--name etcd-1\n--snapshot-count 10000\n"),
+		write("base-2", "# node: 2\n/usr/bin/etcd\n--name etcd-2\n"),
+	}
+	current := []string{
+		write("current-1", "# node: 1\n/usr/bin/etcd\n--name etcd-1\n--snapshot-count 100000\n--quota-backend-bytes 8000000000\n"),
+		write("current-2", "# node: 2\n\n/usr/bin/etcd\n--name etcd-2\n"),
+	}
+
+	buf := new(bytes.Buffer)
+	if err = DiffServerConfigs(buf, "etcd-v3.3", base, current); err != nil {
+		t.Fatal(err)
+	}
+	exp := `
+--- base etcd-v3.3 (node 1)
++++ current etcd-v3.3 (node 1)
+- --snapshot-count 10000
++ --snapshot-count 100000
++ --quota-backend-bytes 8000000000
+
+--- base etcd-v3.3 (node 2)
++++ current etcd-v3.3 (node 2)
+  (no difference)
+`
+	if buf.String() != exp {
+		t.Fatalf("expected %q, got %q", exp, buf.String())
+	}
+
+	buf.Reset()
+	if err = DiffServerConfigs(buf, "", base, current[:1]); err != nil {
+		t.Fatal(err)
+	}
+	if exp := "\n2 node(s) in base, 1 in current\n\n--- base (node 1)\n"; !bytes.HasPrefix(buf.Bytes(), []byte(exp)) {
+		t.Fatalf("expected prefix %q, got %q", exp, buf.String())
+	}
+
+	if err = DiffServerConfigs(buf, "", []string{filepath.Join(dir, "missing")}, current[:1]); err == nil {
+		t.Fatal("expected error of the missing configuration")
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"github.com/gyuho/dataframe"
+	"go.uber.org/zap"
+)
+
+// sizeBuckets is the number of buckets of the size histograms:
+// 0 bytes, and then the powers of 2 up to 2^62 bytes.
+const sizeBuckets = 64
+
+// sizeHistogram counts the request and response sizes in power-of-2
+// buckets. Response sizes of range reads are often multi-modal
+// (e.g. prefix scans over the keys of varying counts), which
+// explains the multi-modal latency distributions.
+type sizeHistogram struct {
+	// updated atomically
+	requests  [sizeBuckets]int64
+	responses [sizeBuckets]int64
+
+	requestBytes  int64
+	responseBytes int64
+	n             int64
+}
+
+// sizeBucket returns the index of the smallest bucket of 2^(i-1) bytes
+// that holds 'size', where the first bucket only holds 0 bytes.
+func sizeBucket(size int) int {
+	if size <= 0 {
+		return 0
+	}
+	i := 1
+	for v := 1; v < size && i < sizeBuckets-1; v <<= 1 {
+		i++
+	}
+	return i
+}
+
+// sizeBucketBytes returns the upper bound of the i-th bucket.
+func sizeBucketBytes(i int) int64 {
+	if i == 0 {
+		return 0
+	}
+	return 1 << uint(i-1)
+}
+
+func (h *sizeHistogram) record(requestBytes, responseBytes int) {
+	atomic.AddInt64(&h.requests[sizeBucket(requestBytes)], 1)
+	atomic.AddInt64(&h.responses[sizeBucket(responseBytes)], 1)
+	atomic.AddInt64(&h.requestBytes, int64(requestBytes))
+	atomic.AddInt64(&h.responseBytes, int64(responseBytes))
+	atomic.AddInt64(&h.n, 1)
+}
+
+// buckets returns the first and the last non-empty buckets.
+func (h *sizeHistogram) buckets() (first, last int) {
+	first, last = -1, -1
+	for i := 0; i < sizeBuckets; i++ {
+		if atomic.LoadInt64(&h.requests[i]) == 0 && atomic.LoadInt64(&h.responses[i]) == 0 {
+			continue
+		}
+		if first == -1 {
+			first = i
+		}
+		last = i
+	}
+	return first, last
+}
+
+func (cfg *Config) saveSizeHistogram() {
+	h := cfg.sizes
+	if h == nil {
+		return
+	}
+	n := atomic.LoadInt64(&h.n)
+	if n == 0 {
+		cfg.lg.Warn("no request sizes recorded; skipping size histogram")
+		return
+	}
+	cfg.lg.Sugar().Infof("request and response sizes [requests: %d | average request: %d bytes | average response: %d bytes]",
+		n, atomic.LoadInt64(&h.requestBytes)/n, atomic.LoadInt64(&h.responseBytes)/n)
+
+	c1 := dataframe.NewColumn("SIZE-BYTES-UPPER-BOUND")
+	c2 := dataframe.NewColumn("REQUESTS")
+	c3 := dataframe.NewColumn("REQUESTS-PERCENT")
+	c4 := dataframe.NewColumn("RESPONSES")
+	c5 := dataframe.NewColumn("RESPONSES-PERCENT")
+	first, last := h.buckets()
+	for i := first; i <= last; i++ {
+		reqN, respN := atomic.LoadInt64(&h.requests[i]), atomic.LoadInt64(&h.responses[i])
+		c1.PushBack(dataframe.NewStringValue(sizeBucketBytes(i)))
+		c2.PushBack(dataframe.NewStringValue(reqN))
+		c3.PushBack(dataframe.NewStringValue(fmt.Sprintf("%4.4f", 100*float64(reqN)/float64(n))))
+		c4.PushBack(dataframe.NewStringValue(respN))
+		c5.PushBack(dataframe.NewStringValue(fmt.Sprintf("%4.4f", 100*float64(respN)/float64(n))))
+	}
+	fr := dataframe.New()
+	for _, col := range []dataframe.Column{c1, c2, c3, c4, c5} {
+		if err := fr.AddColumn(col); err != nil {
+			panic(err)
+		}
+	}
+	fpath := cfg.ConfigClientMachineInitial.ClientSizeHistogramPath
+	if err := saveCSV(fr, fpath); err != nil {
+		panic(err)
+	}
+	cfg.lg.Info("saved size histogram", zap.String("path", fpath))
+}
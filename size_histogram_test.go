@@ -0,0 +1,61 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import "testing"
+
+func TestSizeBucket(t *testing.T) {
+	tests := []struct {
+		size  int
+		bytes int64
+	}{
+		{-1, 0},
+		{0, 0},
+		{1, 1},
+		{2, 2},
+		{3, 4},
+		{4, 4},
+		{5, 8},
+		{1024, 1024},
+		{1025, 2048},
+	}
+	for i, tt := range tests {
+		if v := sizeBucketBytes(sizeBucket(tt.size)); v != tt.bytes {
+			t.Fatalf("#%d: size %d expected bucket of %d bytes, got %d", i, tt.size, tt.bytes, v)
+		}
+	}
+}
+
+func TestSizeHistogram(t *testing.T) {
+	h := &sizeHistogram{}
+	if first, last := h.buckets(); first != -1 || last != -1 {
+		t.Fatalf("expected no buckets, got [%d, %d]", first, last)
+	}
+	h.record(300, 0)
+	h.record(300, 100)
+	h.record(260, 5000)
+	if first, last := h.buckets(); first != 0 || last != sizeBucket(5000) {
+		t.Fatalf("expected buckets [0, %d], got [%d, %d]", sizeBucket(5000), first, last)
+	}
+	if n := h.requests[sizeBucket(300)]; n != 3 {
+		t.Fatalf("expected 3 requests of 512 bytes, got %d", n)
+	}
+	if n := h.responses[sizeBucket(100)]; n != 1 {
+		t.Fatalf("expected 1 response of 128 bytes, got %d", n)
+	}
+	if h.n != 3 || h.requestBytes != 860 || h.responseBytes != 5100 {
+		t.Fatalf("unexpected totals %d, %d, %d", h.n, h.requestBytes, h.responseBytes)
+	}
+}
@@ -0,0 +1,298 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/coreos/dbtester/pkg/sockstat"
+
+	"github.com/gyuho/dataframe"
+	"go.uber.org/zap"
+)
+
+// socketLimitPercent is the usage of the ephemeral ports or of the
+// conntrack entries, over which the limit is likely the bottleneck.
+const socketLimitPercent = 80
+
+// socketSample is the TCP sockets of the loader host at a second.
+type socketSample struct {
+	unixSecond int64
+	// established, timeWait and other are the sockets
+	// to each endpoint, in the order of the endpoints
+	established []int
+	timeWait    []int
+	other       []int
+	// counters are the TCP counters since the previous sample
+	counters sockstat.Counters
+	// conntrack is the number of the conntrack entries, or -1 if unknown
+	conntrack int64
+}
+
+// socketStats samples the TCP sockets of the loader host to the
+// database endpoints, and the TCP counters of the host, while the
+// benchmark runs.
+type socketStats struct {
+	lg        *zap.Logger
+	interval  time.Duration
+	endpoints []string
+	// remotes maps the resolved 'ip:port' of the endpoints to their index
+	remotes map[string]int
+
+	ephemeralPorts int64
+	conntrackMax   int64
+
+	mu       sync.Mutex
+	samples  []socketSample
+	first    sockstat.Counters
+	last     sockstat.Counters
+	stopOnce sync.Once
+	stopc    chan struct{}
+	donec    chan struct{}
+}
+
+// resolveRemotes maps the 'ip:port' of the endpoints to their index.
+// The endpoints that do not resolve are left out.
+func resolveRemotes(lg *zap.Logger, endpoints []string) map[string]int {
+	remotes := make(map[string]int)
+	for i, ep := range endpoints {
+		host, port, err := net.SplitHostPort(endpointHostPort(ep))
+		if err != nil {
+			lg.Warn("cannot count the sockets of the endpoint", zap.String("endpoint", ep), zap.Error(err))
+			continue
+		}
+		addrs, err := net.LookupHost(host)
+		if err != nil {
+			lg.Warn("cannot count the sockets of the endpoint", zap.String("endpoint", ep), zap.Error(err))
+			continue
+		}
+		for _, addr := range addrs {
+			remotes[net.JoinHostPort(net.ParseIP(addr).String(), port)] = i
+		}
+	}
+	return remotes
+}
+
+func newSocketStats(lg *zap.Logger, endpoints []string) (*socketStats, error) {
+	first, err := sockstat.ReadCounters()
+	if err != nil {
+		return nil, fmt.Errorf("cannot read the TCP counters of the loader host (%v)", err)
+	}
+	s := &socketStats{
+		lg:           lg,
+		interval:     time.Second,
+		endpoints:    endpoints,
+		remotes:      resolveRemotes(lg, endpoints),
+		conntrackMax: -1,
+		first:        first,
+		last:         first,
+		stopc:        make(chan struct{}),
+		donec:        make(chan struct{}),
+	}
+	if s.ephemeralPorts, err = sockstat.ReadEphemeralPorts(); err != nil {
+		lg.Warn("cannot read the ephemeral port range", zap.Error(err))
+	}
+	if _, max, err := sockstat.ReadConntrack(); err == nil {
+		s.conntrackMax = max
+	}
+	go s.run()
+	return s, nil
+}
+
+func (s *socketStats) sample(now time.Time) {
+	ss, err := sockstat.ReadSockets()
+	if err != nil {
+		s.lg.Warn("failed to sample sockets", zap.Error(err))
+		return
+	}
+	c, err := sockstat.ReadCounters()
+	if err != nil {
+		s.lg.Warn("failed to sample TCP counters", zap.Error(err))
+		return
+	}
+
+	sm := socketSample{
+		unixSecond:  now.Unix(),
+		established: make([]int, len(s.endpoints)),
+		timeWait:    make([]int, len(s.endpoints)),
+		other:       make([]int, len(s.endpoints)),
+		conntrack:   -1,
+	}
+	for _, sk := range ss {
+		i, ok := s.remotes[sk.Remote]
+		if !ok {
+			continue
+		}
+		switch sk.State {
+		case "ESTABLISHED":
+			sm.established[i]++
+		case "TIME_WAIT":
+			sm.timeWait[i]++
+		default:
+			sm.other[i]++
+		}
+	}
+	if n, _, err := sockstat.ReadConntrack(); err == nil {
+		sm.conntrack = n
+	}
+
+	s.mu.Lock()
+	sm.counters = c.Sub(s.last)
+	s.last = c
+	s.samples = append(s.samples, sm)
+	s.mu.Unlock()
+}
+
+func (s *socketStats) run() {
+	defer close(s.donec)
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case now := <-ticker.C:
+			s.sample(now)
+		case <-s.stopc:
+			return
+		}
+	}
+}
+
+// stop stops sampling, with the last sample at the end of the run.
+func (s *socketStats) stop() {
+	s.stopOnce.Do(func() {
+		close(s.stopc)
+		<-s.donec
+		s.sample(time.Now())
+	})
+}
+
+// socketSummary is the peaks of the sockets and the TCP counters of the run.
+type socketSummary struct {
+	peakSockets  int
+	peakTimeWait int
+	// peakEndpointSockets is the peak of the sockets to one endpoint,
+	// which share the ephemeral ports of the local address
+	peakEndpointSockets int
+	peakConntrack       int64
+	counters            sockstat.Counters
+}
+
+func (s *socketStats) summary() socketSummary {
+	s.stop()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sm := socketSummary{peakConntrack: -1, counters: s.last.Sub(s.first)}
+	for _, sp := range s.samples {
+		var total, timeWait int
+		for i := range s.endpoints {
+			n := sp.established[i] + sp.timeWait[i] + sp.other[i]
+			if sm.peakEndpointSockets < n {
+				sm.peakEndpointSockets = n
+			}
+			total += n
+			timeWait += sp.timeWait[i]
+		}
+		if sm.peakSockets < total {
+			sm.peakSockets = total
+		}
+		if sm.peakTimeWait < timeWait {
+			sm.peakTimeWait = timeWait
+		}
+		if sm.peakConntrack < sp.conntrack {
+			sm.peakConntrack = sp.conntrack
+		}
+	}
+	return sm
+}
+
+// warnLimits warns if the sockets reach the limits of the loader host.
+func (s *socketStats) warnLimits(sm socketSummary) {
+	if s.ephemeralPorts > 0 && int64(sm.peakEndpointSockets)*100 >= s.ephemeralPorts*socketLimitPercent {
+		s.lg.Warn("sockets to one endpoint near the ephemeral port range; the loader may run out of local ports",
+			zap.Int("peak-endpoint-sockets", sm.peakEndpointSockets),
+			zap.Int64("ephemeral-ports", s.ephemeralPorts),
+		)
+	}
+	if s.conntrackMax > 0 && sm.peakConntrack*100 >= s.conntrackMax*socketLimitPercent {
+		s.lg.Warn("conntrack entries near the limit; new connections may be dropped",
+			zap.Int64("peak-conntrack", sm.peakConntrack),
+			zap.Int64("conntrack-max", s.conntrackMax),
+		)
+	}
+}
+
+func (cfg *Config) saveSocketStats() {
+	s := cfg.sockets
+	if s == nil {
+		return
+	}
+	sm := s.summary()
+	cfg.lg.Sugar().Infof("socket stats [peak sockets: %d | peak TIME_WAIT: %d | retransmitted segments: %d | established resets: %d | out resets: %d | attempt fails: %d]",
+		sm.peakSockets, sm.peakTimeWait, sm.counters.RetransSegs, sm.counters.EstabResets, sm.counters.OutRsts, sm.counters.AttemptFails)
+	s.warnLimits(sm)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c1 := dataframe.NewColumn("UNIX-SECOND")
+	c2 := dataframe.NewColumn("ENDPOINT")
+	c3 := dataframe.NewColumn("ESTABLISHED")
+	c4 := dataframe.NewColumn("TIME-WAIT")
+	c5 := dataframe.NewColumn("OTHER-STATES")
+	c6 := dataframe.NewColumn("TCP-ACTIVE-OPENS")
+	c7 := dataframe.NewColumn("TCP-RETRANSMITTED-SEGMENTS")
+	c8 := dataframe.NewColumn("TCP-ESTABLISHED-RESETS")
+	c9 := dataframe.NewColumn("TCP-OUT-RESETS")
+	c10 := dataframe.NewColumn("TCP-ATTEMPT-FAILS")
+	c11 := dataframe.NewColumn("CONNTRACK-ENTRIES")
+	for _, sp := range s.samples {
+		row := func(ep string, established, timeWait, other int) {
+			c1.PushBack(dataframe.NewStringValue(sp.unixSecond))
+			c2.PushBack(dataframe.NewStringValue(ep))
+			c3.PushBack(dataframe.NewStringValue(established))
+			c4.PushBack(dataframe.NewStringValue(timeWait))
+			c5.PushBack(dataframe.NewStringValue(other))
+			// the counters are of the host, the same for all endpoints
+			c6.PushBack(dataframe.NewStringValue(sp.counters.ActiveOpens))
+			c7.PushBack(dataframe.NewStringValue(sp.counters.RetransSegs))
+			c8.PushBack(dataframe.NewStringValue(sp.counters.EstabResets))
+			c9.PushBack(dataframe.NewStringValue(sp.counters.OutRsts))
+			c10.PushBack(dataframe.NewStringValue(sp.counters.AttemptFails))
+			c11.PushBack(dataframe.NewStringValue(sp.conntrack))
+		}
+		if len(s.endpoints) == 0 {
+			// only the counters of the host, such as of the mock database
+			row("", 0, 0, 0)
+		}
+		for i, ep := range s.endpoints {
+			row(ep, sp.established[i], sp.timeWait[i], sp.other[i])
+		}
+	}
+	fr := dataframe.New()
+	for _, col := range []dataframe.Column{c1, c2, c3, c4, c5, c6, c7, c8, c9, c10, c11} {
+		if err := fr.AddColumn(col); err != nil {
+			panic(err)
+		}
+	}
+	fpath := cfg.ConfigClientMachineInitial.ClientSocketStatsPath
+	if err := saveCSV(fr, fpath); err != nil {
+		panic(err)
+	}
+	cfg.lg.Info("saved socket stats", zap.String("path", fpath))
+}
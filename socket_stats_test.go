@@ -0,0 +1,81 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/coreos/dbtester/pkg/sockstat"
+
+	"go.uber.org/zap"
+)
+
+func TestSocketStats(t *testing.T) {
+	if _, err := sockstat.ReadCounters(); err != nil {
+		t.Skipf("no TCP counters (%v)", err)
+	}
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+		}
+	}()
+	for i := 0; i < 3; i++ {
+		conn, err := net.Dial("tcp", ln.Addr().String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer conn.Close()
+	}
+
+	s, err := newSocketStats(zap.NewNop(), []string{"http://" + ln.Addr().String(), "127.0.0.1:1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir, err := ioutil.TempDir("", "sockets")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	cfg := &Config{lg: zap.NewNop(), sockets: s}
+	cfg.ConfigClientMachineInitial.ClientSocketStatsPath = filepath.Join(dir, "sockets.csv")
+	cfg.saveSocketStats()
+
+	sm := s.summary()
+	if sm.peakSockets != 3 || sm.peakEndpointSockets != 3 {
+		t.Fatalf("expected 3 sockets to the endpoint, got %+v", sm)
+	}
+	bts, err := ioutil.ReadFile(cfg.ConfigClientMachineInitial.ClientSocketStatsPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(bts)), "\n")
+	if len(lines) != 3 || !strings.Contains(lines[1], ",http://"+ln.Addr().String()+",3,0,0,") || !strings.Contains(lines[2], ",127.0.0.1:1,0,0,0,") {
+		t.Fatalf("unexpected socket stats %q", lines)
+	}
+}
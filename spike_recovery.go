@@ -0,0 +1,211 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/coreos/dbtester/dbtesterpb"
+
+	"github.com/gyuho/dataframe"
+	"go.uber.org/zap"
+)
+
+// spikeEvent is a fault that may spike the latency.
+type spikeEvent struct {
+	name string
+	at   time.Time
+}
+
+// spikeRecovery records the latencies of every second and the injected
+// faults, to measure how long the p99 latency takes to return to the
+// baseline before each fault.
+type spikeRecovery struct {
+	mu     sync.Mutex
+	lats   map[int64][]time.Duration
+	events []spikeEvent
+}
+
+func newSpikeRecovery() *spikeRecovery {
+	return &spikeRecovery{lats: make(map[int64][]time.Duration)}
+}
+
+func (s *spikeRecovery) add(start time.Time, lat time.Duration) {
+	sec := start.Unix()
+	s.mu.Lock()
+	s.lats[sec] = append(s.lats[sec], lat)
+	s.mu.Unlock()
+}
+
+// mark records the fault. It is a no-op on nil.
+func (s *spikeRecovery) mark(name string, at time.Time) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	s.events = append(s.events, spikeEvent{name: name, at: at})
+	s.mu.Unlock()
+}
+
+// spikeRecoveryResult is the recovery of the p99 latency after a fault.
+type spikeRecoveryResult struct {
+	event       spikeEvent
+	baselineP99 time.Duration
+	threshold   time.Duration
+	peakP99     time.Duration
+	// recovery is the time from the second of the fault to the first
+	// second within the threshold after the spike, 0 if not spiked.
+	recovery  time.Duration
+	recovered bool
+	// noBaseline is true if no request was made before the fault
+	noBaseline bool
+}
+
+// p99 returns the 99th percentile of the latencies, sorting them in place.
+func p99(lats []time.Duration) time.Duration {
+	sort.Slice(lats, func(i, j int) bool { return lats[i] < lats[j] })
+	return lats[(len(lats)*99)/100]
+}
+
+// results measures the recovery after each fault. The baseline is the p99
+// latency of the 'BaselineSeconds' before the fault. The recovery is
+// searched up to the next fault or the end of the benchmark; seconds
+// with no request are treated as over the threshold.
+func (s *spikeRecovery) results(scfg *dbtesterpb.ConfigClientMachineSpikeRecovery) []spikeRecoveryResult {
+	withinPercent, baselineSeconds := int64(20), int64(10)
+	if scfg.WithinPercent > 0 {
+		withinPercent = scfg.WithinPercent
+	}
+	if scfg.BaselineSeconds > 0 {
+		baselineSeconds = scfg.BaselineSeconds
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var last int64
+	for sec := range s.lats {
+		if sec > last {
+			last = sec
+		}
+	}
+	evs := make([]spikeEvent, len(s.events))
+	copy(evs, s.events)
+	sort.Slice(evs, func(i, j int) bool { return evs[i].at.Before(evs[j].at) })
+
+	rs := make([]spikeRecoveryResult, 0, len(evs))
+	for i, ev := range evs {
+		rs = append(rs, spikeRecoveryResult{event: ev})
+		r := &rs[len(rs)-1]
+
+		sec := ev.at.Unix()
+		var baseline []time.Duration
+		for b := sec - baselineSeconds; b < sec; b++ {
+			baseline = append(baseline, s.lats[b]...)
+		}
+		if len(baseline) == 0 {
+			r.noBaseline = true
+			continue
+		}
+		r.baselineP99 = p99(baseline)
+		r.threshold = r.baselineP99 + r.baselineP99*time.Duration(withinPercent)/100
+
+		end := last
+		if i < len(evs)-1 {
+			end = evs[i+1].at.Unix() - 1
+		}
+		spiked := false
+		for cur := sec; cur <= end; cur++ {
+			lats := s.lats[cur]
+			over := len(lats) == 0
+			if !over {
+				p := p99(lats)
+				if p > r.peakP99 {
+					r.peakP99 = p
+				}
+				over = p > r.threshold
+			}
+			if over {
+				spiked = true
+				continue
+			}
+			if spiked {
+				r.recovery, r.recovered = time.Duration(cur-sec)*time.Second, true
+				break
+			}
+		}
+		if !spiked {
+			r.recovered = true
+		}
+	}
+	return rs
+}
+
+func (cfg *Config) saveSpikeRecovery(gcfg dbtesterpb.ConfigClientMachineAgentControl) {
+	s := cfg.spikes
+	if s == nil {
+		return
+	}
+	rs := s.results(gcfg.ConfigClientMachineBenchmarkOptions.ConfigClientMachineSpikeRecovery)
+	if len(rs) == 0 {
+		cfg.lg.Warn("no fault injected; skipping spike recovery")
+		return
+	}
+
+	c1 := dataframe.NewColumn("EVENT")
+	c2 := dataframe.NewColumn("UNIX-SECOND")
+	c3 := dataframe.NewColumn("BASELINE-P99-MS")
+	c4 := dataframe.NewColumn("THRESHOLD-MS")
+	c5 := dataframe.NewColumn("PEAK-P99-MS")
+	c6 := dataframe.NewColumn("RECOVERY-SECONDS")
+	c7 := dataframe.NewColumn("RECOVERED")
+	for _, r := range rs {
+		if r.noBaseline {
+			cfg.lg.Warn("no request before fault; skipping its spike recovery", zap.String("event", r.event.name))
+			continue
+		}
+		if r.recovered {
+			cfg.lg.Sugar().Infof("spike recovery [event: %q | baseline p99: %v | peak p99: %v | recovery: %v]", r.event.name, r.baselineP99, r.peakP99, r.recovery)
+		} else {
+			cfg.lg.Sugar().Warnf("spike recovery [event: %q | baseline p99: %v | peak p99: %v | not recovered]", r.event.name, r.baselineP99, r.peakP99)
+		}
+		c1.PushBack(dataframe.NewStringValue(r.event.name))
+		c2.PushBack(dataframe.NewStringValue(r.event.at.Unix()))
+		c3.PushBack(dataframe.NewStringValue(fmt.Sprintf("%4.4f", toMillisecond(r.baselineP99))))
+		c4.PushBack(dataframe.NewStringValue(fmt.Sprintf("%4.4f", toMillisecond(r.threshold))))
+		c5.PushBack(dataframe.NewStringValue(fmt.Sprintf("%4.4f", toMillisecond(r.peakP99))))
+		c6.PushBack(dataframe.NewStringValue(fmt.Sprintf("%d", int64(r.recovery/time.Second))))
+		c7.PushBack(dataframe.NewStringValue(fmt.Sprintf("%v", r.recovered)))
+	}
+
+	fpath := cfg.ConfigClientMachineInitial.ClientSpikeRecoveryPath
+	if fpath == "" {
+		cfg.lg.Warn("'client_spike_recovery_path' is not set; skipping spike recovery")
+		return
+	}
+	fr := dataframe.New()
+	for _, col := range []dataframe.Column{c1, c2, c3, c4, c5, c6, c7} {
+		if err := fr.AddColumn(col); err != nil {
+			panic(err)
+		}
+	}
+	if err := saveCSV(fr, fpath); err != nil {
+		panic(err)
+	}
+	cfg.lg.Info("saved spike recovery", zap.String("path", fpath))
+}
@@ -0,0 +1,73 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"testing"
+	"time"
+
+	"github.com/coreos/dbtester/dbtesterpb"
+)
+
+func TestSpikeRecovery(t *testing.T) {
+	s := newSpikeRecovery()
+	base := time.Unix(1000, 0)
+	// 10ms for 20 seconds, except 100ms at 10 and 11, and 30ms at 15
+	for sec := 0; sec < 20; sec++ {
+		lat := 10 * time.Millisecond
+		switch sec {
+		case 10, 11:
+			lat = 100 * time.Millisecond
+		case 15:
+			lat = 30 * time.Millisecond
+		}
+		for i := 0; i < 10; i++ {
+			s.add(base.Add(time.Duration(sec)*time.Second), lat)
+		}
+	}
+	s.mark("kill n1", base.Add(10*time.Second))
+	s.mark("kill n2", base.Add(15*time.Second))
+	s.mark("kill n3", base.Add(-time.Second))
+
+	rs := s.results(&dbtesterpb.ConfigClientMachineSpikeRecovery{WithinPercent: 20, BaselineSeconds: 5})
+	if len(rs) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(rs))
+	}
+	if !rs[0].noBaseline {
+		t.Fatalf("expected no baseline, got %+v", rs[0])
+	}
+	if r := rs[1]; r.baselineP99 != 10*time.Millisecond || r.threshold != 12*time.Millisecond || r.peakP99 != 100*time.Millisecond || r.recovery != 2*time.Second || !r.recovered {
+		t.Fatalf("unexpected result %+v", r)
+	}
+	// baseline includes the spike at 10 and 11
+	if r := rs[2]; r.baselineP99 != 100*time.Millisecond || r.recovery != 0 || !r.recovered {
+		t.Fatalf("unexpected result %+v", r)
+	}
+
+	// not recovered until the end
+	s = newSpikeRecovery()
+	for sec := 0; sec < 10; sec++ {
+		lat := 10 * time.Millisecond
+		if sec >= 5 {
+			lat = 50 * time.Millisecond
+		}
+		s.add(base.Add(time.Duration(sec)*time.Second), lat)
+	}
+	s.mark("partition", base.Add(5*time.Second))
+	rs = s.results(&dbtesterpb.ConfigClientMachineSpikeRecovery{})
+	if len(rs) != 1 || rs[0].recovered || rs[0].peakP99 != 50*time.Millisecond {
+		t.Fatalf("unexpected result %+v", rs)
+	}
+}
@@ -16,20 +16,16 @@ package dbtester
 
 import (
 	"fmt"
-	"math"
 	"os"
-	"sort"
 	"sync"
 	"time"
 
 	"github.com/coreos/dbtester/dbtesterpb"
 
 	"github.com/coreos/etcd/clientv3"
-	"github.com/coreos/etcd/pkg/report"
 	consulapi "github.com/hashicorp/consul/api"
 	"go.uber.org/zap"
 	"golang.org/x/net/context"
-	"golang.org/x/time/rate"
 )
 
 type values struct {
@@ -39,6 +35,9 @@ type values struct {
 }
 
 func newValues(gcfg dbtesterpb.ConfigClientMachineAgentControl) (v values, rerr error) {
+	if tmpl := gcfg.ConfigClientMachineBenchmarkOptions.ValueTemplate; tmpl != "" {
+		return newTemplateValues(tmpl, gcfg.ConfigClientMachineBenchmarkOptions.ValueSeed, gcfg.ConfigClientMachineBenchmarkOptions.ValueSizeBytes)
+	}
 	v.bytes = [][]byte{randBytes(gcfg.ConfigClientMachineBenchmarkOptions.ValueSizeBytes)}
 	v.strings = []string{string(v.bytes[0])}
 	v.sampleSize = 1
@@ -52,28 +51,330 @@ func (cfg *Config) Stress(databaseID string) error {
 		return fmt.Errorf("%q does not exist", databaseID)
 	}
 
+	if err := checkClientTLS(databaseID); err != nil {
+		return err
+	}
 	vals, err := newValues(gcfg)
 	if err != nil {
 		return err
 	}
 
+	if cfg.AuthOverhead {
+		if cfg.authBaselineLatency, err = cfg.runAuthBaseline(gcfg, vals); err != nil {
+			return err
+		}
+	}
+
+	if auth := gcfg.ConfigClientMachineBenchmarkOptions.ZookeeperDigestAuth; auth != "" {
+		prevAuth, prevACL := zkAuth, zkCreateACL
+		defer func() { zkAuth, zkCreateACL = prevAuth, prevACL }()
+		if err = setZkDigestAuth(auth); err != nil {
+			return err
+		}
+		cfg.lg.Info("creating znodes with digest ACL", zap.String("database", gcfg.DatabaseID))
+	}
+
+	if user := gcfg.ConfigClientMachineBenchmarkOptions.EtcdUsername; user != "" {
+		prevUser, prevPassword := etcdAuthUser, etcdAuthPassword
+		defer setEtcdAuth(prevUser, prevPassword)
+		setEtcdAuth(user, gcfg.ConfigClientMachineBenchmarkOptions.EtcdPassword)
+		cfg.lg.Info("running as etcd user", zap.String("user", user))
+	}
+	if token := gcfg.ConfigClientMachineBenchmarkOptions.ConsulToken; token != "" {
+		prevToken := consulToken
+		defer func() { consulToken = prevToken }()
+		consulToken = token
+		cfg.lg.Info("running with Consul ACL token", zap.String("database", gcfg.DatabaseID))
+	}
+
+	if ns := gcfg.ConfigClientMachineBenchmarkOptions.Namespace; ns != "" {
+		switch gcfg.DatabaseID {
+		case "zookeeper__r3_5_3_beta", "zetcd__beta":
+			if err = createZkParents(gcfg.DatabaseEndpoints, "/"+ns); err != nil {
+				return err
+			}
+		}
+		cfg.lg.Info("writing keys under namespace", zap.String("namespace", ns), zap.String("database", gcfg.DatabaseID))
+	}
+
+	switch mode := gcfg.ConfigClientMachineBenchmarkOptions.EtcdRBAC; mode {
+	case "":
+	case "root", "restricted":
+		switch gcfg.DatabaseID {
+		case "etcd__other", "etcd__tip", "etcd__v3_2", "etcd__v3_3":
+		default:
+			return fmt.Errorf("'etcd_rbac' is not supported for %q", gcfg.DatabaseID)
+		}
+		disableAuth, err := setupEtcdRBAC(gcfg.DatabaseEndpoints)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if err := disableAuth(); err != nil {
+				cfg.lg.Warn("failed to disable etcd authentication", zap.Error(err))
+			}
+			// the clients after the stress connect with no credentials
+			setEtcdAuth("", "")
+		}()
+		setEtcdAuth(etcdRootUser, etcdRootPassword)
+		if mode == "restricted" {
+			if cfg.etcdRBACRootLatency, err = cfg.runEtcdRBACBaseline(gcfg, vals); err != nil {
+				return err
+			}
+			setEtcdAuth(etcdRestrictedUser, etcdRestrictedPasswd)
+		}
+		cfg.lg.Info("running as etcd user", zap.String("user", etcdAuthUser))
+	default:
+		return fmt.Errorf("unknown 'etcd_rbac' %q", mode)
+	}
+
+	// 'mixed' workloads have their own rate limits
+	if gcfg.ConfigClientMachineBenchmarkOptions.OpenLoop && gcfg.ConfigClientMachineBenchmarkOptions.RateLimitRequestsPerSecond <= 0 && gcfg.ConfigClientMachineBenchmarkOptions.Type != "mixed" {
+		return fmt.Errorf("'open_loop' requires 'rate_limit_requests_per_second'")
+	}
+	if err = validateKeyDistribution(gcfg.ConfigClientMachineBenchmarkOptions.KeyDistribution); err != nil {
+		return err
+	}
+	if err = validateKeyOrder(gcfg); err != nil {
+		return err
+	}
+	if err = validateDuration(gcfg); err != nil {
+		return err
+	}
+	if err = validateWarmup(gcfg); err != nil {
+		return err
+	}
+	if err = validateMaintenance(gcfg); err != nil {
+		return err
+	}
+	if d := runDuration(gcfg); d > 0 {
+		cfg.lg.Info("running for duration instead of 'request_number'", zap.Duration("duration", d))
+	}
+
+	if ep, dir := cfg.ConfigClientMachineInitial.CollectorEndpoint, cfg.ConfigClientMachineInitial.ClientOpenMetricsDir; ep != "" || dir != "" || cfg.collectorClient != nil {
+		if cfg.collectorClient != nil {
+			// the coordinator of the worker collects the results
+			ep = ""
+		}
+		if cfg.collector, err = newCollectorStream(cfg.lg, gcfg, cfg.collectorClient, ep, dir, compressExts[cfg.Compress], cfg.LatencyResolution); err != nil {
+			return err
+		}
+		defer func() {
+			cfg.collector.close()
+			cfg.collector = nil
+		}()
+		cfg.lg.Info("reporting interim results", zap.String("collector-endpoint", ep), zap.String("openmetrics-dir", dir), zap.String("loader", cfg.collector.loaderID))
+	}
+
+	if gcfg.ConfigClientMachineBenchmarkOptions.ConfigClientMachineConvergenceProbe != nil {
+		if cfg.convergenceProbe, err = newConvergenceProbe(cfg.lg, gcfg); err != nil {
+			return err
+		}
+		defer cfg.convergenceProbe.close()
+	}
+
+	if gcfg.ConfigClientMachineBenchmarkOptions.ConfigClientMachineEtcdMetrics != nil {
+		switch gcfg.DatabaseID {
+		case "etcd__other", "etcd__tip", "etcd__v3_2", "etcd__v3_3":
+		default:
+			return fmt.Errorf("'etcd_metrics' is not supported for %q", gcfg.DatabaseID)
+		}
+		cfg.etcdMetrics = newEtcdMetricsScraper(cfg.lg, gcfg)
+		defer func() {
+			cfg.etcdMetrics.stop()
+			cfg.etcdMetrics = nil
+		}()
+	}
+
+	if rcfg := gcfg.ConfigClientMachineBenchmarkOptions.ConfigClientMachineResourceMonitor; rcfg != nil {
+		var srcs []resourceSource
+		if srcs, err = cfg.resourceSources(gcfg); err != nil {
+			return err
+		}
+		cfg.resources = newResourceMonitor(cfg.lg, rcfg, srcs)
+		defer func() {
+			cfg.resources.stop()
+			cfg.resources = nil
+		}()
+	}
+
+	if cfg.ConfigClientMachineInitial.ClientSocketStatsPath != "" {
+		if cfg.sockets, err = newSocketStats(cfg.lg, gcfg.DatabaseEndpoints); err != nil {
+			return err
+		}
+		defer func() {
+			cfg.sockets.stop()
+			cfg.sockets = nil
+		}()
+	}
+
+	if gcfg.ConfigClientMachineBenchmarkOptions.ConfigClientMachineIdentityLease != nil {
+		if cfg.identityLeases, err = newIdentityLeases(cfg.lg, gcfg); err != nil {
+			return err
+		}
+		defer func() {
+			cfg.identityLeases.close()
+			cfg.identityLeases = nil
+		}()
+	}
+
+	if gcfg.ConfigClientMachineBenchmarkOptions.ConfigClientMachineLearnerReads != nil {
+		if cfg.learnerReads, err = newLearnerReads(cfg.lg, gcfg); err != nil {
+			return err
+		}
+		defer func() {
+			cfg.learnerReads.stop()
+			cfg.learnerReads = nil
+		}()
+	}
+
+	if gcfg.ConfigClientMachineBenchmarkOptions.ConfigClientMachineTLSHandshake != nil {
+		if cfg.tlsHandshakes, err = newTLSHandshakes(cfg.lg, gcfg); err != nil {
+			return err
+		}
+		defer func() {
+			cfg.tlsHandshakes.stop()
+			cfg.tlsHandshakes = nil
+		}()
+	}
+
+	if cfg.SaveKeysPath != "" {
+		if cfg.keys, err = newKeyManifest(cfg.SaveKeysPath); err != nil {
+			return err
+		}
+		defer func() {
+			n, kerr := cfg.keys.close()
+			if kerr != nil {
+				cfg.lg.Warn("failed to save keys", zap.String("path", cfg.SaveKeysPath), zap.Error(kerr))
+			} else {
+				cfg.lg.Info("saved keys", zap.String("path", cfg.SaveKeysPath), zap.Int64("keys", n))
+			}
+			cfg.keys = nil
+		}()
+	}
+	if cfg.KeysFromPath != "" {
+		if cfg.keysFrom, err = readKeyManifest(cfg.KeysFromPath); err != nil {
+			return err
+		}
+		defer func() { cfg.keysFrom = nil }()
+		cfg.lg.Info("loaded keys", zap.String("path", cfg.KeysFromPath), zap.Int("keys", len(cfg.keysFrom)))
+	}
+
+	if cfg.ConfigClientMachineInitial.ClientSizeHistogramPath != "" {
+		cfg.sizes = &sizeHistogram{}
+		defer func() { cfg.sizes = nil }()
+	}
+
+	if gcfg.ConfigClientMachineBenchmarkOptions.ConfigClientMachineSpikeRecovery != nil {
+		cfg.spikes = newSpikeRecovery()
+		defer func() { cfg.spikes = nil }()
+	}
+
+	if cfg.ConfigClientMachineInitial.ClientMemberBreakdownPath != "" || cfg.ConfigClientMachineInitial.ClientTermChangePath != "" {
+		cfg.members = newMemberBreakdown(cfg.lg)
+		defer func() { cfg.members = nil }()
+	}
+
+	if cfg.ConfigClientMachineInitial.ClientZoneLatencyPath != "" && len(cfg.EndpointZones) > 0 {
+		mz, err := cfg.memberZones(gcfg)
+		if err != nil {
+			return err
+		}
+		cfg.zones = newZoneBreakdown(cfg.LoaderZone, mz)
+		defer func() { cfg.zones = nil }()
+	}
+
+	if cfg.MaxResponseBytes > 0 {
+		// etcd clients refuse the responses before receiving them
+		etcdMaxRecvBytes = int(cfg.MaxResponseBytes)
+		defer func() { etcdMaxRecvBytes = 0 }()
+		cfg.lg.Info("limiting response size", zap.Int64("max-response-bytes", cfg.MaxResponseBytes))
+	}
+	if err = checkEtcdAPIVersion(gcfg); err != nil {
+		return err
+	}
+	if v := gcfg.ConfigClientMachineBenchmarkOptions.EtcdAPIVersion; v != "" {
+		etcdAPIVersion = v
+		defer func() { etcdAPIVersion = "" }()
+		cfg.lg.Info("using etcd client API", zap.String("etcd-api-version", v))
+	}
+	cfg.responses = newResponseMemory(cfg.MaxResponseBytes)
+	defer func() {
+		cfg.responses.stop()
+		cfg.logResponseMemory()
+		cfg.responses = nil
+	}()
+
+	if cfg.latencies, err = newLatencyHistogram(cfg.LatencyResolution); err != nil {
+		return err
+	}
+	defer func() { cfg.latencies = nil }()
+
+	if gcfg.ConfigClientMachineBenchmarkOptions.RequestTimeoutMilliseconds > 0 && gcfg.ConfigClientMachineBenchmarkOptions.RateLimitRequestsPerSecond > 0 {
+		switch {
+		case gcfg.ConfigClientMachineBenchmarkOptions.Type == "mixed":
+			cfg.lg.Warn("schedule is not tracked for 'mixed' workloads; each workload has its own rate")
+		case len(gcfg.ConfigClientMachineBenchmarkOptions.ConnectionClientNumbers) > 0:
+			cfg.lg.Warn("schedule is not tracked with 'connection_client_numbers'")
+		default:
+			cfg.schedule = newScheduleTracker(gcfg)
+			defer func() { cfg.schedule = nil }()
+		}
+	}
+
+	if gcfg.ConfigClientMachineBenchmarkOptions.CalibrateHarness {
+		cfg.harnessOverhead = cfg.calibrateHarness(gcfg, vals)
+	}
+
 	switch gcfg.ConfigClientMachineBenchmarkOptions.Type {
 	case "write":
+		if err = checkPutIgnoresEtcdv3(gcfg); err != nil {
+			return err
+		}
+		if opts := gcfg.ConfigClientMachineBenchmarkOptions; opts.EtcdIgnoreValue || opts.EtcdIgnoreLease {
+			// the key space to update
+			copied := gcfg
+			popts := *opts
+			popts.Prepopulate = opts.KeySpaceSize
+			copied.ConfigClientMachineBenchmarkOptions = &popts
+			if err = cfg.prepopulate(copied, vals); err != nil {
+				return err
+			}
+		}
 		cfg.lg.Info("write generateReport is started...")
 
 		// fixed number of client numbers
 		if len(gcfg.ConfigClientMachineBenchmarkOptions.ConnectionClientNumbers) == 0 {
-			h, done := newWriteHandlers(cfg.lg, gcfg)
-			reqGen := func(inflightReqs chan<- request) { generateWrites(gcfg, 0, vals, inflightReqs) }
+			var h []ReqHandler
+			var done func()
+			if gcfg.ConfigClientMachineBenchmarkOptions.ConfigClientMachineHealthRouting != nil {
+				newHandlers := func(copied dbtesterpb.ConfigClientMachineAgentControl) ([]ReqHandler, func()) {
+					return newWriteHandlers(cfg.lg, copied)
+				}
+				if h, done, err = cfg.newHealthRoutedHandlers(gcfg, newHandlers); err != nil {
+					return err
+				}
+			} else {
+				h, done = newWriteHandlers(cfg.lg, gcfg)
+			}
+			reqGen := func(ctx context.Context, inflightReqs chan<- request) {
+				generateWrites(ctx, gcfg, cfg.keyOffset, vals, inflightReqs)
+			}
 			cfg.generateReport(gcfg, h, done, reqGen)
 
 		} else {
 			// variable client numbers
 			rs := assignRequest(gcfg.ConfigClientMachineBenchmarkOptions.ConnectionClientNumbers, gcfg.ConfigClientMachineBenchmarkOptions.RequestNumber)
 
-			var stats []report.Stats
+			var stats []runStats
+			var traces []requestTrace
 			reqCompleted := int64(0)
 			for i := 0; i < len(rs); i++ {
+				if i > 0 && cfg.Cooldown > 0 {
+					cfg.lg.Sugar().Infof("cooling down for %v before next client number %d", cfg.Cooldown, gcfg.ConfigClientMachineBenchmarkOptions.ConnectionClientNumbers[i])
+					time.Sleep(cfg.Cooldown)
+				}
+
 				copied := gcfg
 				copied.ConfigClientMachineBenchmarkOptions.ConnectionNumber = gcfg.ConfigClientMachineBenchmarkOptions.ConnectionClientNumbers[i]
 				copied.ConfigClientMachineBenchmarkOptions.ClientNumber = gcfg.ConfigClientMachineBenchmarkOptions.ConnectionClientNumbers[i]
@@ -89,13 +390,42 @@ func (cfg *Config) Stress(databaseID string) error {
 				}()
 
 				h, done := newWriteHandlers(cfg.lg, copied)
-				reqGen := func(inflightReqs chan<- request) { generateWrites(copied, reqCompleted, vals, inflightReqs) }
+				reqGen := func(ctx context.Context, inflightReqs chan<- request) {
+					generateWrites(ctx, copied, reqCompleted, vals, inflightReqs)
+				}
 				b := newBenchmark(copied.ConfigClientMachineBenchmarkOptions.RequestNumber, copied.ConfigClientMachineBenchmarkOptions.ClientNumber, h, done, reqGen)
 
+				b.traceEvery = traceEvery(copied)
+				b.databaseID = copied.DatabaseID
+				b.typ = copied.ConfigClientMachineBenchmarkOptions.Type
+				b.openLoop = copied.ConfigClientMachineBenchmarkOptions.OpenLoop
+				b.collector = cfg.collector
+				b.reqTimeout = requestTimeout(copied)
+				b.ctx = cfg.runContext()
+				b.progress.interval = cfg.ProgressInterval
+				b.sizes = cfg.sizes
+				b.responses = cfg.responses
+				b.spikes = cfg.spikes
+				b.members = cfg.members
+				b.zones = cfg.zones
+				b.mergeLatenciesInto(cfg.latencies)
+				b.live = cfg.live
+				b.timeseries = cfg.timeseries
+				b.keys = cfg.keys
+				b.series = newTieredTimeSeries(copied)
+
+				var stopConvergenceProbe func()
+				if cfg.convergenceProbe != nil {
+					stopConvergenceProbe = cfg.startConvergenceProbe(copied)
+				}
+
 				// wait until rs[i] requests are finished
 				// do not end reports yet
 				b.startRequests()
 				b.waitRequestsEnd()
+				if stopConvergenceProbe != nil {
+					stopConvergenceProbe()
+				}
 
 				cfg.lg.Info("finishing reports...")
 				now := time.Now()
@@ -104,15 +434,15 @@ func (cfg *Config) Stress(databaseID string) error {
 
 				reqCompleted += rs[i]
 				stats = append(stats, b.stats)
+				traces = append(traces, b.traces...)
 			}
 			cfg.lg.Info("combining all reports")
 
-			combined := report.Stats{ErrorDist: make(map[string]int)}
+			combined := runStats{ErrorDist: make(map[string]int)}
 			combinedClientNumber := make([]int64, 0, gcfg.ConfigClientMachineBenchmarkOptions.RequestNumber)
 			for i, st := range stats {
-				combined.AvgTotal += st.AvgTotal
 				combined.Total += st.Total
-				combined.Lats = append(combined.Lats, st.Lats...)
+				combined.mergeLatencies(st)
 				combined.TimeSeries = append(combined.TimeSeries, st.TimeSeries...)
 				//
 				// Need to handle duplicate unix second timestamps when two ranges are merged.
@@ -136,38 +466,20 @@ func (cfg *Config) Stress(databaseID string) error {
 					clientNs[i] = clientN
 				}
 				combinedClientNumber = append(combinedClientNumber, clientNs...)
-
-				for k, v := range st.ErrorDist {
-					if _, ok := combined.ErrorDist[k]; !ok {
-						combined.ErrorDist[k] = v
-					} else {
-						combined.ErrorDist[k] += v
-					}
-				}
 			}
 			if len(combined.TimeSeries) != len(combinedClientNumber) {
 				return fmt.Errorf("len(combined.TimeSeries) %d != len(combinedClientNumber) %d", len(combined.TimeSeries), len(combinedClientNumber))
 			}
 
-			combined.Average = combined.AvgTotal / float64(len(combined.Lats))
-			combined.RPS = float64(len(combined.Lats)) / combined.Total.Seconds()
-			cfg.lg.Sugar().Infof("got total %d data points and total %f seconds (RPS %f)", len(combined.Lats), combined.Total.Seconds(), combined.RPS)
-
-			for i := range combined.Lats {
-				dev := combined.Lats[i] - combined.Average
-				combined.Stddev += dev * dev
-			}
-			combined.Stddev = math.Sqrt(combined.Stddev / float64(len(combined.Lats)))
-
-			sort.Float64s(combined.Lats)
-			if len(combined.Lats) > 0 {
-				combined.Fastest = combined.Lats[0]
-				combined.Slowest = combined.Lats[len(combined.Lats)-1]
-			}
+			combined.summarize()
+			cfg.lg.Sugar().Infof("got total %d data points and total %f seconds (RPS %f)", combined.requests(), combined.Total.Seconds(), combined.RPS)
 
 			cfg.lg.Info("combined all reports")
-			printStats(combined)
+			cfg.printResults(combined)
 			cfg.saveAllStats(gcfg, combined, combinedClientNumber)
+			if len(traces) > 0 {
+				cfg.saveRequestTraceSample(traces)
+			}
 		}
 
 		cfg.lg.Info("write generateReport is finished...")
@@ -181,6 +493,12 @@ func (cfg *Config) Stress(databaseID string) error {
 			totalKeysFunc = getTotalKeysZk
 		case "consul__v1_0_2", "cetcd__beta":
 			totalKeysFunc = getTotalKeysConsul
+		case "redis__v4_0":
+			totalKeysFunc = getTotalKeysRedis
+		case "tikv__v2_1":
+			totalKeysFunc = func(lg *zap.Logger, _ []string) map[string]int64 { return getTotalKeysTikv(lg, tikvPDEndpoints(gcfg)) }
+		case "mock":
+			totalKeysFunc = getTotalKeysMock
 		default:
 			cfg.lg.Fatal("unknown database ID", zap.String("database", gcfg.DatabaseID))
 		}
@@ -190,77 +508,227 @@ func (cfg *Config) Stress(databaseID string) error {
 		}
 
 	case "read":
-		key, value := sameKey(gcfg.ConfigClientMachineBenchmarkOptions.KeySizeBytes), vals.strings[0]
+		key, value := namespaced(gcfg, sameKey(gcfg.ConfigClientMachineBenchmarkOptions.KeySizeBytes)), vals.strings[0]
+		if cfg.KeysPerRequest > 1 {
+			if err := checkBatchReads(gcfg, cfg.keysFrom, cfg.KeysPerRequest); err != nil {
+				return err
+			}
+		}
+		if cfg.RangeReads {
+			if err := checkRangeReads(gcfg, cfg.keysFrom, cfg.KeysPerRequest); err != nil {
+				return err
+			}
+		}
 
-		switch gcfg.DatabaseID {
-		case "etcd__other", "etcd__tip", "etcd__v3_2", "etcd__v3_3":
-			cfg.lg.Sugar().Infof("write started [request: PUT | key: %q | database: %q]", key, gcfg.DatabaseID)
-			var err error
-			for i := 0; i < 7; i++ {
-				clients := mustCreateClientsEtcdv3(gcfg.DatabaseEndpoints, etcdv3ClientCfg{
-					totalConns:   1,
-					totalClients: 1,
-				})
-				_, err = clients[0].Do(context.Background(), clientv3.OpPut(key, value))
+		if len(cfg.keysFrom) > 0 {
+			cfg.lg.Info("reading the keys of '--keys-from'", zap.Int("keys", len(cfg.keysFrom)))
+		} else if gcfg.ConfigClientMachineBenchmarkOptions.Prepopulate > 0 {
+			if err := cfg.prepopulate(gcfg, vals); err != nil {
+				return err
+			}
+		} else {
+			switch gcfg.DatabaseID {
+			case "etcd__other", "etcd__tip", "etcd__v3_2", "etcd__v3_3":
+				cfg.lg.Sugar().Infof("write started [request: PUT | key: %q | database: %q]", key, gcfg.DatabaseID)
+				var err error
+				for i := 0; i < 7; i++ {
+					clients := mustCreateClientsEtcdv3(gcfg.DatabaseEndpoints, etcdv3ClientCfg{
+						totalConns:   1,
+						totalClients: 1,
+					})
+					_, err = clients[0].Do(cfg.runContext(), clientv3.OpPut(key, value))
+					if err != nil {
+						continue
+					}
+					cfg.lg.Sugar().Infof("write done [request: PUT | key: %q | database: %q]", key, gcfg.DatabaseID)
+					break
+				}
 				if err != nil {
-					continue
+					cfg.lg.Sugar().Fatalf("write error [request: PUT | key: %q | database: %q]", key, gcfg.DatabaseID)
+					os.Exit(1)
 				}
-				cfg.lg.Sugar().Infof("write done [request: PUT | key: %q | database: %q]", key, gcfg.DatabaseID)
-				break
-			}
-			if err != nil {
-				cfg.lg.Sugar().Fatalf("write error [request: PUT | key: %q | database: %q]", key, gcfg.DatabaseID)
-				os.Exit(1)
-			}
 
-		case "zookeeper__r3_5_3_beta", "zetcd__beta":
-			cfg.lg.Sugar().Infof("write started [request: PUT | key: %q | database: %q]", key, gcfg.DatabaseID)
-			var err error
-			for i := 0; i < 7; i++ {
-				conns := mustCreateConnsZk(gcfg.DatabaseEndpoints, gcfg.ConfigClientMachineBenchmarkOptions.ConnectionNumber)
-				_, err = conns[0].Create("/"+key, vals.bytes[0], zkCreateFlags, zkCreateACL)
+			case "zookeeper__r3_5_3_beta", "zetcd__beta":
+				cfg.lg.Sugar().Infof("write started [request: PUT | key: %q | database: %q]", key, gcfg.DatabaseID)
+				var err error
+				for i := 0; i < 7; i++ {
+					conns := mustCreateConnsZk(gcfg.DatabaseEndpoints, gcfg.ConfigClientMachineBenchmarkOptions.ConnectionNumber)
+					_, err = conns[0].Create("/"+key, vals.bytes[0], zkCreateFlags, zkCreateACL)
+					if err != nil {
+						continue
+					}
+					for j := range conns {
+						conns[j].Close()
+					}
+					cfg.lg.Sugar().Infof("write done [request: PUT | key: %q | database: %q]", key, gcfg.DatabaseID)
+					break
+				}
 				if err != nil {
-					continue
+					cfg.lg.Sugar().Fatalf("write error [request: PUT | key: %q | database: %q]", key, gcfg.DatabaseID)
+					os.Exit(1)
 				}
-				for j := range conns {
-					conns[j].Close()
+
+			case "consul__v1_0_2", "cetcd__beta":
+				cfg.lg.Sugar().Infof("write started [request: PUT | key: %q | database: %q]", key, gcfg.DatabaseID)
+				var err error
+				for i := 0; i < 7; i++ {
+					clients := mustCreateConnsConsul(gcfg.DatabaseEndpoints, gcfg.ConfigClientMachineBenchmarkOptions.ConnectionNumber)
+					_, err = clients[0].Put(&consulapi.KVPair{Key: key, Value: vals.bytes[0]}, nil)
+					if err != nil {
+						continue
+					}
+					cfg.lg.Sugar().Infof("write done [request: PUT | key: %q | database: %q]", key, gcfg.DatabaseID)
+					break
+				}
+				if err != nil {
+					cfg.lg.Sugar().Fatalf("write done [request: PUT | key: %q | database: %q]", key, gcfg.DatabaseID)
+					os.Exit(1)
+				}
+
+			case "redis__v4_0":
+				cfg.lg.Sugar().Infof("write started [request: PUT | key: %q | database: %q]", key, gcfg.DatabaseID)
+				clis := mustCreateConnsRedis(gcfg.DatabaseEndpoints, 1, gcfg.Flag_Redis_V4_0)
+				err := clis[0].Set(cfg.runContext(), []byte(key), vals.bytes[0])
+				clis[0].Close()
+				if err != nil {
+					cfg.lg.Sugar().Fatalf("write error [request: PUT | key: %q | database: %q]", key, gcfg.DatabaseID)
+					os.Exit(1)
 				}
 				cfg.lg.Sugar().Infof("write done [request: PUT | key: %q | database: %q]", key, gcfg.DatabaseID)
-				break
-			}
-			if err != nil {
-				cfg.lg.Sugar().Fatalf("write error [request: PUT | key: %q | database: %q]", key, gcfg.DatabaseID)
-				os.Exit(1)
-			}
 
-		case "consul__v1_0_2", "cetcd__beta":
-			cfg.lg.Sugar().Infof("write started [request: PUT | key: %q | database: %q]", key, gcfg.DatabaseID)
-			var err error
-			for i := 0; i < 7; i++ {
-				clients := mustCreateConnsConsul(gcfg.DatabaseEndpoints, gcfg.ConfigClientMachineBenchmarkOptions.ConnectionNumber)
-				_, err = clients[0].Put(&consulapi.KVPair{Key: key, Value: vals.bytes[0]}, nil)
+			case "tikv__v2_1":
+				cfg.lg.Sugar().Infof("write started [request: PUT | key: %q | database: %q]", key, gcfg.DatabaseID)
+				clis := mustCreateConnsTikv(gcfg, 1)
+				err := clis[0].Put(cfg.runContext(), []byte(key), vals.bytes[0])
+				clis[0].Close()
 				if err != nil {
-					continue
+					cfg.lg.Sugar().Fatalf("write error [request: PUT | key: %q | database: %q]", key, gcfg.DatabaseID)
+					os.Exit(1)
 				}
 				cfg.lg.Sugar().Infof("write done [request: PUT | key: %q | database: %q]", key, gcfg.DatabaseID)
-				break
+
+			case "mock":
+				mockDB.put(key, vals.bytes[0])
+
+			default:
+				panic(fmt.Sprintf("%q is unknown database ID", gcfg.DatabaseID))
 			}
-			if err != nil {
-				cfg.lg.Sugar().Fatalf("write done [request: PUT | key: %q | database: %q]", key, gcfg.DatabaseID)
-				os.Exit(1)
+		}
+
+		var h []ReqHandler
+		var done func()
+		if gcfg.ConfigClientMachineBenchmarkOptions.HedgeAfterMicroseconds > 0 {
+			if h, done, err = cfg.newHedgedReadHandlers(gcfg); err != nil {
+				return err
+			}
+		} else if gcfg.ConfigClientMachineBenchmarkOptions.ConfigClientMachineHealthRouting != nil {
+			if h, done, err = cfg.newHealthRoutedHandlers(gcfg, newReadHandlers); err != nil {
+				return err
 			}
+		} else {
+			h, done = newReadHandlers(gcfg)
+		}
+		reqGen := func(ctx context.Context, inflightReqs chan<- request) {
+			generateReads(ctx, gcfg, key, cfg.keysFrom, inflightReqs)
+		}
+		switch n := cfg.KeysPerRequest; {
+		case cfg.RangeReads:
+			cfg.lg.Info("reading keys in ranges of prefixes", zap.Int64("keys-per-request", n))
+			reqGen = func(ctx context.Context, inflightReqs chan<- request) {
+				generateRangeReads(ctx, gcfg, n, inflightReqs)
+			}
+		case n > 1:
+			cfg.lg.Info("reading keys in batches", zap.Int64("keys-per-request", n))
+			reqGen = func(ctx context.Context, inflightReqs chan<- request) {
+				generateBatchReads(ctx, gcfg, cfg.keysFrom, n, inflightReqs)
+			}
+		}
+		cfg.generateReport(gcfg, h, done, reqGen)
+		cfg.lg.Info("read generateReport is finished...")
 
-		default:
-			panic(fmt.Sprintf("%q is unknown database ID", gcfg.DatabaseID))
+	case "txn":
+		if gcfg.ConfigClientMachineBenchmarkOptions.TxnKeyNumber <= 0 {
+			return fmt.Errorf("'txn_key_number' must be positive for 'txn' (got %d)", gcfg.ConfigClientMachineBenchmarkOptions.TxnKeyNumber)
 		}
 
-		h, done := newReadHandlers(gcfg)
-		reqGen := func(inflightReqs chan<- request) { generateReads(gcfg, key, inflightReqs) }
+		// write all keys first, so that every transaction
+		// reads, checks and overwrites existing keys
+		copied := gcfg
+		opts := *gcfg.ConfigClientMachineBenchmarkOptions
+		copied.ConfigClientMachineBenchmarkOptions = &opts
+		copied.ConfigClientMachineBenchmarkOptions.Prepopulate = txnKeyTotal(gcfg)
+		if err = cfg.prepopulate(copied, vals); err != nil {
+			return err
+		}
+
+		h, done, err := cfg.newTxnHandlers(gcfg)
+		if err != nil {
+			return err
+		}
+		reqGen := func(ctx context.Context, inflightReqs chan<- request) {
+			generateWrites(ctx, gcfg, 0, vals, inflightReqs)
+		}
 		cfg.generateReport(gcfg, h, done, reqGen)
-		cfg.lg.Info("read generateReport is finished...")
+		cfg.lg.Sugar().Infof("txn commit success rate %.4f%% [overlap: %d%% | committed: %d | conflicted: %d]",
+			cfg.txnStats.successRate(), cfg.txnStats.overlapPercent, cfg.txnStats.commits, cfg.txnStats.conflicts)
+		cfg.lg.Info("txn generateReport is finished...")
+
+	case "bank":
+		cfg.lg.Info("bank generateReport is started...")
+		if err = cfg.runBank(gcfg, vals); err != nil {
+			return err
+		}
+		cfg.lg.Info("bank generateReport is finished...")
+
+	case "lock":
+		h, done, err := cfg.newLockHandlers(gcfg)
+		if err != nil {
+			return err
+		}
+		reqGen := func(ctx context.Context, inflightReqs chan<- request) {
+			generateWrites(ctx, gcfg, 0, vals, inflightReqs)
+		}
+		cfg.generateReport(gcfg, h, done, reqGen)
+		cfg.lg.Sugar().Infof("lock acquired %d times [invalidated: %d | average time to release after invalidation: %v]",
+			cfg.lockStats.acquires, cfg.lockStats.invalidations, cfg.lockStats.averageInvalidate())
+		cfg.lg.Info("lock generateReport is finished...")
+
+	case "read-write":
+		if err = validateReadWrite(gcfg); err != nil {
+			return err
+		}
+		if err = checkPutIgnoresEtcdv3(gcfg); err != nil {
+			return err
+		}
+		if err = cfg.prepopulate(gcfg, vals); err != nil {
+			return err
+		}
+
+		h, done, err := cfg.newReadWriteHandlers(gcfg)
+		if err != nil {
+			return err
+		}
+		reqGen := func(ctx context.Context, inflightReqs chan<- request) {
+			generateReadWrites(ctx, gcfg, vals, inflightReqs)
+		}
+		cfg.generateReport(gcfg, h, done, reqGen)
+		readEnd := gcfg.ConfigClientMachineBenchmarkOptions.ReadPercentEnd
+		if readEnd == 0 {
+			readEnd = gcfg.ConfigClientMachineBenchmarkOptions.ReadPercent
+		}
+		cfg.lg.Sugar().Infof("read-write requests [read percent: %d%% to %d%% | reads: %d | writes: %d]",
+			gcfg.ConfigClientMachineBenchmarkOptions.ReadPercent, readEnd, cfg.readWriteStats.reads, cfg.readWriteStats.writes)
+		cfg.lg.Info("read-write generateReport is finished...")
+
+	case "mixed":
+		cfg.lg.Info("mixed generateReport is started...")
+		if err = cfg.runWorkloads(gcfg, vals); err != nil {
+			return err
+		}
+		cfg.lg.Info("mixed generateReport is finished...")
 
 	case "read-oneshot":
-		key, value := sameKey(gcfg.ConfigClientMachineBenchmarkOptions.KeySizeBytes), vals.strings[0]
+		key, value := namespaced(gcfg, sameKey(gcfg.ConfigClientMachineBenchmarkOptions.KeySizeBytes)), vals.strings[0]
 		cfg.lg.Sugar().Infof("writing key for read-oneshot [key: %q | database: %q]", key, gcfg.DatabaseID)
 		var err error
 		switch gcfg.DatabaseID {
@@ -269,7 +737,7 @@ func (cfg *Config) Stress(databaseID string) error {
 				totalConns:   1,
 				totalClients: 1,
 			})
-			_, err = clients[0].Do(context.Background(), clientv3.OpPut(key, value))
+			_, err = clients[0].Do(cfg.runContext(), clientv3.OpPut(key, value))
 			clients[0].Close()
 
 		case "zookeeper__r3_5_3_beta", "zetcd__beta":
@@ -281,6 +749,19 @@ func (cfg *Config) Stress(databaseID string) error {
 			clients := mustCreateConnsConsul(gcfg.DatabaseEndpoints, 1)
 			_, err = clients[0].Put(&consulapi.KVPair{Key: key, Value: vals.bytes[0]}, nil)
 
+		case "redis__v4_0":
+			clis := mustCreateConnsRedis(gcfg.DatabaseEndpoints, 1, gcfg.Flag_Redis_V4_0)
+			err = clis[0].Set(cfg.runContext(), []byte(key), vals.bytes[0])
+			clis[0].Close()
+
+		case "tikv__v2_1":
+			clis := mustCreateConnsTikv(gcfg, 1)
+			err = clis[0].Put(cfg.runContext(), []byte(key), vals.bytes[0])
+			clis[0].Close()
+
+		case "mock":
+			mockDB.put(key, vals.bytes[0])
+
 		default:
 			panic(fmt.Sprintf("%q is unknown database ID", gcfg.DatabaseID))
 		}
@@ -290,7 +771,9 @@ func (cfg *Config) Stress(databaseID string) error {
 		}
 
 		h := newReadOneshotHandlers(cfg.lg, gcfg)
-		reqGen := func(inflightReqs chan<- request) { generateReads(gcfg, key, inflightReqs) }
+		reqGen := func(ctx context.Context, inflightReqs chan<- request) {
+			generateReads(ctx, gcfg, key, nil, inflightReqs)
+		}
 		cfg.generateReport(gcfg, h, nil, reqGen)
 		cfg.lg.Info("read-oneshot generateReport is finished...")
 	}
@@ -332,6 +815,25 @@ func newReadHandlers(gcfg dbtesterpb.ConfigClientMachineAgentControl) (rhs []Req
 			rhs[i] = newGetConsul(conns[i])
 		}
 
+	case "redis__v4_0":
+		clis := mustCreateConnsRedis(gcfg.DatabaseEndpoints, gcfg.ConfigClientMachineBenchmarkOptions.ConnectionNumber, gcfg.Flag_Redis_V4_0)
+		for i := range rhs {
+			rhs[i] = newGetRedis(clis[i%len(clis)])
+		}
+		done = closeConnsRedis(clis)
+
+	case "tikv__v2_1":
+		clis := mustCreateConnsTikv(gcfg, gcfg.ConfigClientMachineBenchmarkOptions.ConnectionNumber)
+		for i := range rhs {
+			rhs[i] = newGetTikv(clis[i%len(clis)])
+		}
+		done = closeConnsTikv(clis)
+
+	case "mock":
+		for i := range rhs {
+			rhs[i] = newGetMock(gcfg.Flag_Mock)
+		}
+
 	default:
 		panic(fmt.Sprintf("%q is unknown database ID", gcfg.DatabaseID))
 	}
@@ -361,7 +863,7 @@ func newWriteHandlers(lg *zap.Logger, gcfg dbtesterpb.ConfigClientMachineAgentCo
 
 	case "zookeeper__r3_5_3_beta", "zetcd__beta":
 		if gcfg.ConfigClientMachineBenchmarkOptions.SameKey {
-			key := sameKey(gcfg.ConfigClientMachineBenchmarkOptions.KeySizeBytes)
+			key := namespaced(gcfg, sameKey(gcfg.ConfigClientMachineBenchmarkOptions.KeySizeBytes))
 			valueBts := randBytes(gcfg.ConfigClientMachineBenchmarkOptions.ValueSizeBytes)
 			lg.Sugar().Infof("write started [request: PUT | key: %q | database: %q]", key, gcfg.DatabaseID)
 			var err error
@@ -385,9 +887,13 @@ func newWriteHandlers(lg *zap.Logger, gcfg dbtesterpb.ConfigClientMachineAgentCo
 
 		conns := mustCreateConnsZk(gcfg.DatabaseEndpoints, gcfg.ConfigClientMachineBenchmarkOptions.ConnectionNumber)
 		for i := range conns {
-			if gcfg.ConfigClientMachineBenchmarkOptions.SameKey {
+			switch {
+			case gcfg.ConfigClientMachineBenchmarkOptions.SameKey:
 				rhs[i] = newPutOverwriteZK(conns[i])
-			} else {
+			case gcfg.ConfigClientMachineBenchmarkOptions.KeySpaceSize > 0:
+				// keys are written more than once
+				rhs[i] = newPutUpsertZK(conns[i])
+			default:
 				rhs[i] = newPutCreateZK(conns[i])
 			}
 		}
@@ -405,6 +911,25 @@ func newWriteHandlers(lg *zap.Logger, gcfg dbtesterpb.ConfigClientMachineAgentCo
 			rhs[i] = newPutConsul(conns[i])
 		}
 
+	case "redis__v4_0":
+		clis := mustCreateConnsRedis(gcfg.DatabaseEndpoints, gcfg.ConfigClientMachineBenchmarkOptions.ConnectionNumber, gcfg.Flag_Redis_V4_0)
+		for i := range rhs {
+			rhs[i] = newPutRedis(clis[i%len(clis)])
+		}
+		done = closeConnsRedis(clis)
+
+	case "tikv__v2_1":
+		clis := mustCreateConnsTikv(gcfg, gcfg.ConfigClientMachineBenchmarkOptions.ConnectionNumber)
+		for i := range rhs {
+			rhs[i] = newPutTikv(clis[i%len(clis)])
+		}
+		done = closeConnsTikv(clis)
+
+	case "mock":
+		for i := range rhs {
+			rhs[i] = newPutMock(gcfg.Flag_Mock)
+		}
+
 	default:
 		lg.Sugar().Fatalf("%q is unknown database ID", gcfg.DatabaseID)
 	}
@@ -414,6 +939,12 @@ func newWriteHandlers(lg *zap.Logger, gcfg dbtesterpb.ConfigClientMachineAgentCo
 			lg.Sugar().Fatalf("%d-th write handler is nil (out of %d)", k, len(rhs))
 		}
 	}
+
+	if gcfg.ConfigClientMachineBenchmarkOptions.KeySpaceSize > 0 && gcfg.ConfigClientMachineBenchmarkOptions.KeyPartitioned {
+		for k := range rhs {
+			rhs[k] = newPartitionedWriteHandler(gcfg, rhs[k], int64(k))
+		}
+	}
 	return
 }
 
@@ -448,63 +979,106 @@ func newReadOneshotHandlers(lg *zap.Logger, gcfg dbtesterpb.ConfigClientMachineA
 				return newGetConsul(conns[0])(ctx, req)
 			}
 		}
+
+	case "redis__v4_0":
+		for i := range rhs {
+			rhs[i] = func(ctx context.Context, req *request) error {
+				clis := mustCreateConnsRedis(gcfg.DatabaseEndpoints, 1, gcfg.Flag_Redis_V4_0)
+				defer clis[0].Close()
+				return newGetRedis(clis[0])(ctx, req)
+			}
+		}
+
+	case "tikv__v2_1":
+		for i := range rhs {
+			rhs[i] = func(ctx context.Context, req *request) error {
+				clis := mustCreateConnsTikv(gcfg, 1)
+				defer clis[0].Close()
+				return newGetTikv(clis[0])(ctx, req)
+			}
+		}
+
+	case "mock":
+		for i := range rhs {
+			rhs[i] = newGetMock(gcfg.Flag_Mock)
+		}
+
 	default:
 		lg.Sugar().Fatalf("%q is unknown database ID", gcfg.DatabaseID)
 	}
 	return rhs
 }
 
-func generateReads(gcfg dbtesterpb.ConfigClientMachineAgentControl, key string, inflightReqs chan<- request) {
+// generateReads reads 'keys' from '--keys-from' if not empty, or the
+// prepopulated keys, in order or by 'key_distribution', or 'key' if
+// neither is set.
+func generateReads(ctx context.Context, gcfg dbtesterpb.ConfigClientMachineAgentControl, key string, keys []string, inflightReqs chan<- request) {
 	defer close(inflightReqs)
 
-	var rateLimiter *rate.Limiter
-	if gcfg.ConfigClientMachineBenchmarkOptions.RateLimitRequestsPerSecond > 0 {
-		rateLimiter = rate.NewLimiter(
-			rate.Limit(gcfg.ConfigClientMachineBenchmarkOptions.RateLimitRequestsPerSecond),
-			int(gcfg.ConfigClientMachineBenchmarkOptions.RateLimitRequestsPerSecond),
-		)
+	n := gcfg.ConfigClientMachineBenchmarkOptions.Prepopulate
+	if len(keys) > 0 {
+		n = int64(len(keys))
+	}
+	var keyIndex func(int64) int64
+	if n > 0 {
+		keyIndex = newKeyIndexer(gcfg.ConfigClientMachineBenchmarkOptions.KeyDistribution, n)
 	}
 
-	for i := int64(0); i < gcfg.ConfigClientMachineBenchmarkOptions.RequestNumber; i++ {
-		if rateLimiter != nil {
-			rateLimiter.Wait(context.TODO())
+	fd := newFeeder(gcfg)
+	limit := newRequestLimit(ctx, gcfg)
+	for i := int64(0); limit.more(i); i++ {
+		k := key
+		if len(keys) > 0 {
+			k = keys[keyIndex(i)]
+		} else if n > 0 {
+			k = namespaced(gcfg, sequentialKey(gcfg.ConfigClientMachineBenchmarkOptions.KeySizeBytes, keyIndex(i)))
 		}
 
+		var req request
 		switch gcfg.DatabaseID {
 		case "etcd__other", "etcd__tip", "etcd__v3_2", "etcd__v3_3":
 			opts := []clientv3.OpOption{clientv3.WithRange("")}
 			if gcfg.ConfigClientMachineBenchmarkOptions.StaleRead {
 				opts = append(opts, clientv3.WithSerializable())
 			}
-			inflightReqs <- request{etcdv3Op: clientv3.OpGet(key, opts...)}
+			req = request{etcdv3Op: clientv3.OpGet(k, opts...)}
 
 		case "zookeeper__r3_5_3_beta", "zetcd__beta":
-			op := zkOp{key: key}
+			op := zkOp{key: k}
 			if gcfg.ConfigClientMachineBenchmarkOptions.StaleRead {
 				op.staleRead = true
 			}
-			inflightReqs <- request{zkOp: op}
+			req = request{zkOp: op}
 
 		case "consul__v1_0_2", "cetcd__beta":
-			op := consulOp{key: key}
+			op := consulOp{key: k}
 			if gcfg.ConfigClientMachineBenchmarkOptions.StaleRead {
 				op.staleRead = true
 			}
-			inflightReqs <- request{consulOp: op}
+			req = request{consulOp: op}
+
+		case "redis__v4_0":
+			req = request{redisOp: redisOp{key: k}}
+
+		case "tikv__v2_1":
+			req = request{tikvOp: tikvOp{key: k}}
+
+		case "mock":
+			req = request{mockOp: mockOp{key: k}}
+
 		default:
 			panic(fmt.Sprintf("%q is unknown database ID", gcfg.DatabaseID))
 		}
+		req.seq = i
+		req.scheduled = fd.next()
+		if !limit.issue(inflightReqs, req) {
+			return
+		}
 	}
 }
 
-func generateWrites(gcfg dbtesterpb.ConfigClientMachineAgentControl, startIdx int64, vals values, inflightReqs chan<- request) {
-	var rateLimiter *rate.Limiter
-	if gcfg.ConfigClientMachineBenchmarkOptions.RateLimitRequestsPerSecond > 0 {
-		rateLimiter = rate.NewLimiter(
-			rate.Limit(gcfg.ConfigClientMachineBenchmarkOptions.RateLimitRequestsPerSecond),
-			int(gcfg.ConfigClientMachineBenchmarkOptions.RateLimitRequestsPerSecond),
-		)
-	}
+func generateWrites(ctx context.Context, gcfg dbtesterpb.ConfigClientMachineAgentControl, startIdx int64, vals values, inflightReqs chan<- request) {
+	fd := newFeeder(gcfg)
 
 	var wg sync.WaitGroup
 	defer func() {
@@ -512,31 +1086,68 @@ func generateWrites(gcfg dbtesterpb.ConfigClientMachineAgentControl, startIdx in
 		wg.Wait()
 	}()
 
-	for i := int64(0); i < gcfg.ConfigClientMachineBenchmarkOptions.RequestNumber; i++ {
-		k := sequentialKey(gcfg.ConfigClientMachineBenchmarkOptions.KeySizeBytes, i+startIdx)
+	var keyIndex func(int64) int64
+	if n := gcfg.ConfigClientMachineBenchmarkOptions.KeySpaceSize; n > 0 {
+		dist := gcfg.ConfigClientMachineBenchmarkOptions.KeyDistribution
+		if dist == "" {
+			dist = "uniform"
+		}
+		keyIndex = newKeyIndexer(dist, n)
+	}
+	keyOrder := newKeyOrder(gcfg.ConfigClientMachineBenchmarkOptions.KeyOrder, gcfg.ConfigClientMachineBenchmarkOptions.RequestNumber)
+
+	limit := newRequestLimit(ctx, gcfg)
+	for i := int64(0); limit.more(i); i++ {
+		k := sequentialKey(gcfg.ConfigClientMachineBenchmarkOptions.KeySizeBytes, keyOrder(i)+startIdx)
+		if keyIndex != nil {
+			// shared key space; overwritten by each client if partitioned
+			k = sequentialKey(gcfg.ConfigClientMachineBenchmarkOptions.KeySizeBytes, keyIndex(i))
+		}
 		if gcfg.ConfigClientMachineBenchmarkOptions.SameKey {
 			k = sameKey(gcfg.ConfigClientMachineBenchmarkOptions.KeySizeBytes)
 		}
+		k = namespaced(gcfg, k)
 
 		v := vals.bytes[i%int64(vals.sampleSize)]
 		vs := vals.strings[i%int64(vals.sampleSize)]
 
-		if rateLimiter != nil {
-			rateLimiter.Wait(context.TODO())
+		req := newPutRequest(gcfg, k, v, vs)
+		req.seq = i + startIdx
+		req.scheduled = fd.next()
+		if !limit.issue(inflightReqs, req) {
+			return
 		}
+	}
+}
 
-		switch gcfg.DatabaseID {
-		case "etcd__other", "etcd__tip", "etcd__v3_2", "etcd__v3_3":
-			inflightReqs <- request{etcdv3Op: clientv3.OpPut(k, vs)}
+// namespaced prefixes the key with the configured namespace.
+func namespaced(gcfg dbtesterpb.ConfigClientMachineAgentControl, k string) string {
+	return gcfg.ConfigClientMachineBenchmarkOptions.Namespace + k
+}
 
-		case "zookeeper__r3_5_3_beta", "zetcd__beta":
-			inflightReqs <- request{zkOp: zkOp{key: "/" + k, value: v}}
+// newPutRequest returns the write request of the key and value.
+// 'vs' is the string of 'v', to avoid conversion on every request.
+func newPutRequest(gcfg dbtesterpb.ConfigClientMachineAgentControl, k string, v []byte, vs string) request {
+	switch gcfg.DatabaseID {
+	case "etcd__other", "etcd__tip", "etcd__v3_2", "etcd__v3_3":
+		return request{etcdv3Op: newPutOpEtcdv3(gcfg.ConfigClientMachineBenchmarkOptions, k, vs)}
 
-		case "consul__v1_0_2", "cetcd__beta":
-			inflightReqs <- request{consulOp: consulOp{key: k, value: v}}
+	case "zookeeper__r3_5_3_beta", "zetcd__beta":
+		return request{zkOp: zkOp{key: "/" + k, value: v}}
 
-		default:
-			panic(fmt.Sprintf("%q is unknown database ID", gcfg.DatabaseID))
-		}
+	case "consul__v1_0_2", "cetcd__beta":
+		return request{consulOp: consulOp{key: k, value: v}}
+
+	case "redis__v4_0":
+		return request{redisOp: redisOp{key: k, value: v}}
+
+	case "tikv__v2_1":
+		return request{tikvOp: tikvOp{key: k, value: v}}
+
+	case "mock":
+		return request{mockOp: mockOp{key: k, value: v}}
+
+	default:
+		panic(fmt.Sprintf("%q is unknown database ID", gcfg.DatabaseID))
 	}
 }
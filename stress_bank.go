@@ -0,0 +1,225 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"fmt"
+	mrand "math/rand"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/coreos/dbtester/dbtesterpb"
+
+	"golang.org/x/net/context"
+)
+
+const (
+	defaultBankAccountNumber  = 10
+	defaultBankInitialBalance = 100
+)
+
+// bankBackend moves the balances between the accounts of 'bank',
+// for one client.
+type bankBackend struct {
+	// open writes the balance to all accounts.
+	open func(ctx context.Context, keys []string, balance int64) error
+	// balances reads the balances of all accounts.
+	balances func(ctx context.Context, keys []string) ([]int64, error)
+	// transfer reads the balances of both accounts, and moves the amount,
+	// or the whole balance if less, in one transaction only if neither
+	// account has changed since the read. It returns false if the
+	// transaction is rolled back on conflict.
+	transfer func(ctx context.Context, from, to string, amount int64, tr *requestTrace) (bool, error)
+}
+
+// bankStats counts the outcomes of 'bank' transfers.
+type bankStats struct {
+	accounts       int64
+	initialBalance int64
+	commits        int64
+	conflicts      int64
+}
+
+// bankOptions returns the number of accounts and the initial balance.
+func bankOptions(gcfg dbtesterpb.ConfigClientMachineAgentControl) (accountN, balance int64) {
+	accountN, balance = gcfg.ConfigClientMachineBenchmarkOptions.BankAccountNumber, gcfg.ConfigClientMachineBenchmarkOptions.BankInitialBalance
+	if accountN == 0 {
+		accountN = defaultBankAccountNumber
+	}
+	if balance == 0 {
+		balance = defaultBankInitialBalance
+	}
+	return accountN, balance
+}
+
+// bankKeys returns the keys of the accounts.
+func bankKeys(gcfg dbtesterpb.ConfigClientMachineAgentControl, prefix string, accountN int64) []string {
+	keys := make([]string, accountN)
+	for i := range keys {
+		keys[i] = prefix + namespaced(gcfg, "bank-"+sequentialKey(gcfg.ConfigClientMachineBenchmarkOptions.KeySizeBytes, int64(i)))
+	}
+	return keys
+}
+
+func parseBalance(key string, v []byte) (int64, error) {
+	b, err := strconv.ParseInt(string(v), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("balance of %q is not a number (%v)", key, err)
+	}
+	return b, nil
+}
+
+func formatBalance(b int64) string {
+	return strconv.FormatInt(b, 10)
+}
+
+// checkBankInvariant returns an error if the balances of the accounts
+// do not add up to the initial total, or any balance is negative,
+// which a transfer that is not serializable would cause.
+func checkBankInvariant(keys []string, balances []int64, initialBalance int64) (total int64, err error) {
+	for i, b := range balances {
+		if b < 0 {
+			return 0, fmt.Errorf("balance of %q is negative %d", keys[i], b)
+		}
+		total += b
+	}
+	if exp := initialBalance * int64(len(keys)); total != exp {
+		return total, fmt.Errorf("total balance %d is not %d", total, exp)
+	}
+	return total, nil
+}
+
+func newBankHandler(b *bankBackend, keys []string, maxAmount, client int64, bs *bankStats) ReqHandler {
+	// each handler is called by one client goroutine
+	rnd := mrand.New(mrand.NewSource(time.Now().UnixNano() + client))
+	return func(ctx context.Context, req *request) error {
+		from := rnd.Intn(len(keys))
+		to := rnd.Intn(len(keys) - 1)
+		if to >= from {
+			to++
+		}
+		if req.trace != nil {
+			req.trace.requestBytes = len(keys[from]) + len(keys[to])
+		}
+
+		committed, err := b.transfer(ctx, keys[from], keys[to], 1+rnd.Int63n(maxAmount), req.trace)
+		if err != nil {
+			return err
+		}
+		if committed {
+			atomic.AddInt64(&bs.commits, 1)
+		} else {
+			atomic.AddInt64(&bs.conflicts, 1)
+		}
+		return nil
+	}
+}
+
+// newBankBackends returns the backends of the clients, and the prefix
+// of the keys of the database.
+func newBankBackends(gcfg dbtesterpb.ConfigClientMachineAgentControl) (bs []*bankBackend, prefix string, done func(), err error) {
+	bs = make([]*bankBackend, gcfg.ConfigClientMachineBenchmarkOptions.ClientNumber)
+	switch gcfg.DatabaseID {
+	case "etcd__other", "etcd__tip", "etcd__v3_2", "etcd__v3_3":
+		clients := mustCreateClientsEtcdv3(gcfg.DatabaseEndpoints, etcdv3ClientCfg{
+			totalConns:   gcfg.ConfigClientMachineBenchmarkOptions.ConnectionNumber,
+			totalClients: gcfg.ConfigClientMachineBenchmarkOptions.ClientNumber,
+		})
+		for i := range bs {
+			bs[i] = newBankEtcd3(clients[i])
+		}
+		done = func() {
+			for i := range clients {
+				clients[i].Close()
+			}
+		}
+
+	case "zookeeper__r3_5_3_beta", "zetcd__beta":
+		conns := mustCreateConnsZk(gcfg.DatabaseEndpoints, gcfg.ConfigClientMachineBenchmarkOptions.ConnectionNumber)
+		for i := range bs {
+			bs[i] = newBankZK(conns[i%len(conns)])
+		}
+		prefix = "/"
+		done = func() {
+			for i := range conns {
+				conns[i].Close()
+			}
+		}
+
+	case "consul__v1_0_2", "cetcd__beta":
+		conns := mustCreateConnsConsul(gcfg.DatabaseEndpoints, gcfg.ConfigClientMachineBenchmarkOptions.ConnectionNumber)
+		for i := range bs {
+			bs[i] = newBankConsul(conns[i%len(conns)])
+		}
+
+	case "mock":
+		for i := range bs {
+			bs[i] = newBankMock(gcfg.Flag_Mock)
+		}
+
+	default:
+		return nil, "", nil, fmt.Errorf("'bank' is not supported for %q", gcfg.DatabaseID)
+	}
+	return bs, prefix, done, nil
+}
+
+// runBank opens the accounts with the initial balance, transfers between
+// them, and checks that the total balance is unchanged after.
+func (cfg *Config) runBank(gcfg dbtesterpb.ConfigClientMachineAgentControl, vals values) error {
+	accountN, balance := bankOptions(gcfg)
+	if accountN < 2 || balance < 1 {
+		return fmt.Errorf("'bank' requires at least 2 accounts of positive balance (got %d, %d)", accountN, balance)
+	}
+
+	backends, prefix, done, err := newBankBackends(gcfg)
+	if err != nil {
+		return err
+	}
+	if done != nil {
+		// the accounts are checked before the clients are closed
+		defer done()
+	}
+	keys := bankKeys(gcfg, prefix, accountN)
+	if err = backends[0].open(cfg.runContext(), keys, balance); err != nil {
+		return fmt.Errorf("cannot open the accounts (%v)", err)
+	}
+	cfg.lg.Sugar().Infof("opened bank accounts [accounts: %d | balance: %d]", accountN, balance)
+
+	bs := &bankStats{accounts: accountN, initialBalance: balance}
+	cfg.bankStats = bs
+	h := make([]ReqHandler, len(backends))
+	for i := range backends {
+		h[i] = newBankHandler(backends[i], keys, balance, int64(i), bs)
+	}
+	reqGen := func(ctx context.Context, inflightReqs chan<- request) {
+		generateWrites(ctx, gcfg, 0, vals, inflightReqs)
+	}
+	cfg.generateReport(gcfg, h, nil, reqGen)
+
+	// reads with a new context, since the run may have been cancelled
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	balances, err := backends[0].balances(ctx, keys)
+	if err != nil {
+		return fmt.Errorf("cannot read the balances to check (%v)", err)
+	}
+	total, err := checkBankInvariant(keys, balances, balance)
+	if err != nil {
+		return fmt.Errorf("'bank' invariant is violated: %v [committed: %d | conflicted: %d]", err, bs.commits, bs.conflicts)
+	}
+	cfg.lg.Sugar().Infof("bank invariant holds [total balance: %d | committed: %d | conflicted: %d]", total, bs.commits, bs.conflicts)
+	return nil
+}
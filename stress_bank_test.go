@@ -0,0 +1,83 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/coreos/dbtester/dbtesterpb"
+
+	"golang.org/x/net/context"
+)
+
+func TestBankMock(t *testing.T) {
+	gcfg := dbtesterpb.ConfigClientMachineAgentControl{
+		DatabaseID:                          "mock",
+		ConfigClientMachineBenchmarkOptions: &dbtesterpb.ConfigClientMachineBenchmarkOptions{ClientNumber: 8, KeySizeBytes: 8},
+	}
+	accountN, balance := bankOptions(gcfg)
+	if accountN != defaultBankAccountNumber || balance != defaultBankInitialBalance {
+		t.Fatalf("unexpected defaults %d, %d", accountN, balance)
+	}
+	backends, prefix, _, err := newBankBackends(gcfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys := bankKeys(gcfg, prefix, accountN)
+	if err = backends[0].open(context.Background(), keys, balance); err != nil {
+		t.Fatal(err)
+	}
+
+	bs := &bankStats{}
+	var wg sync.WaitGroup
+	for i := range backends {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			h := newBankHandler(backends[i], keys, balance, int64(i), bs)
+			for j := 0; j < 500; j++ {
+				if err := h(context.Background(), &request{}); err != nil {
+					t.Error(err)
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+	if bs.commits+bs.conflicts != 4000 || bs.commits == 0 {
+		t.Fatalf("unexpected transfers [committed: %d | conflicted: %d]", bs.commits, bs.conflicts)
+	}
+
+	balances, err := backends[0].balances(context.Background(), keys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total, err := checkBankInvariant(keys, balances, balance); err != nil || total != accountN*balance {
+		t.Fatalf("expected total %d, got %d (%v)", accountN*balance, total, err)
+	}
+}
+
+func TestCheckBankInvariant(t *testing.T) {
+	keys := []string{"a", "b", "c"}
+	if _, err := checkBankInvariant(keys, []int64{0, 15, 15}, 10); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := checkBankInvariant(keys, []int64{10, 10, 11}, 10); err == nil {
+		t.Fatal("expected error of changed total")
+	}
+	if _, err := checkBankInvariant(keys, []int64{-1, 16, 15}, 10); err == nil {
+		t.Fatal("expected error of negative balance")
+	}
+}
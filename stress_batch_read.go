@@ -0,0 +1,142 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"fmt"
+
+	"github.com/coreos/dbtester/dbtesterpb"
+
+	"github.com/coreos/etcd/clientv3"
+	"golang.org/x/net/context"
+)
+
+// batchReadKeyTotal returns the number of keys that batch reads cycle
+// through; the keys of '--keys-from' if not empty, or the prepopulated keys.
+func batchReadKeyTotal(gcfg dbtesterpb.ConfigClientMachineAgentControl, keys []string) int64 {
+	if len(keys) > 0 {
+		return int64(len(keys))
+	}
+	return gcfg.ConfigClientMachineBenchmarkOptions.Prepopulate
+}
+
+func checkBatchReads(gcfg dbtesterpb.ConfigClientMachineAgentControl, keys []string, perRequest int64) error {
+	total := batchReadKeyTotal(gcfg, keys)
+	if total == 0 {
+		return fmt.Errorf("'--keys-per-request' requires 'prepopulate' or '--keys-from'")
+	}
+	if perRequest > total {
+		return fmt.Errorf("'--keys-per-request' %d exceeds the %d keys to read", perRequest, total)
+	}
+	return nil
+}
+
+// generateBatchReads reads 'perRequest' keys in each request, cycling
+// through the keys of '--keys-from' if not empty, or the prepopulated keys.
+func generateBatchReads(ctx context.Context, gcfg dbtesterpb.ConfigClientMachineAgentControl, keys []string, perRequest int64, inflightReqs chan<- request) {
+	defer close(inflightReqs)
+
+	opts := gcfg.ConfigClientMachineBenchmarkOptions
+	total := batchReadKeyTotal(gcfg, keys)
+	// prepopulated keys are sorted if all of them are zero-padded, so
+	// that a batch of consecutive keys is the interval of its first and last
+	sorted := len(keys) == 0 && int64(len(sequentialKey(opts.KeySizeBytes, total-1))) <= opts.KeySizeBytes
+
+	var keyIndex func(int64) int64
+	if opts.KeyDistribution != "" {
+		// the first key of each batch
+		keyIndex = newKeyIndexer(opts.KeyDistribution, total)
+	}
+
+	fd := newFeeder(gcfg)
+	limit := newRequestLimit(ctx, gcfg)
+	for i := int64(0); limit.more(i); i++ {
+		start := (i * perRequest) % total
+		if keyIndex != nil {
+			start = keyIndex(i)
+		}
+		batch := make([]string, perRequest)
+		for j := range batch {
+			idx := (start + int64(j)) % total
+			if len(keys) > 0 {
+				batch[j] = keys[idx]
+			} else {
+				batch[j] = namespaced(gcfg, sequentialKey(opts.KeySizeBytes, idx))
+			}
+		}
+		req := newBatchReadRequest(gcfg, batch, sorted && start+perRequest <= total)
+		req.seq = i
+		req.scheduled = fd.next()
+		if !limit.issue(inflightReqs, req) {
+			return
+		}
+	}
+}
+
+// newBatchReadRequest returns the request to read all keys of 'batch'.
+// etcd reads the keys in a range request if 'interval' is true; that is,
+// no other key is in between, or in a transaction of gets otherwise.
+// Zookeeper 3.5 has no multi-read, and Consul reads one key per request,
+// so their reads of the batch are pipelined on the connection.
+func newBatchReadRequest(gcfg dbtesterpb.ConfigClientMachineAgentControl, batch []string, interval bool) request {
+	staleRead := gcfg.ConfigClientMachineBenchmarkOptions.StaleRead
+
+	var req request
+	switch gcfg.DatabaseID {
+	case "etcd__other", "etcd__tip", "etcd__v3_2", "etcd__v3_3":
+		var opts []clientv3.OpOption
+		if staleRead {
+			opts = append(opts, clientv3.WithSerializable())
+		}
+		if interval {
+			opts = append(opts, clientv3.WithRange(batch[len(batch)-1]+"\x00"), clientv3.WithLimit(int64(len(batch))))
+			req = request{etcdv3Op: clientv3.OpGet(batch[0], opts...)}
+		} else {
+			gets := make([]clientv3.Op, len(batch))
+			for i, k := range batch {
+				gets[i] = clientv3.OpGet(k, opts...)
+			}
+			req = request{etcdv3Op: clientv3.OpTxn(nil, gets, nil)}
+		}
+
+	case "zookeeper__r3_5_3_beta", "zetcd__beta":
+		req = request{zkOp: zkOp{key: batch[0], staleRead: staleRead}}
+
+	case "consul__v1_0_2", "cetcd__beta":
+		req = request{consulOp: consulOp{key: batch[0], staleRead: staleRead}}
+
+	case "redis__v4_0":
+		req = request{redisOp: redisOp{key: batch[0]}}
+
+	case "tikv__v2_1":
+		req = request{tikvOp: tikvOp{key: batch[0]}}
+
+	case "mock":
+		req = request{mockOp: mockOp{key: batch[0]}}
+
+	default:
+		panic(fmt.Sprintf("%q is unknown database ID", gcfg.DatabaseID))
+	}
+	req.batch = batch
+	return req
+}
+
+// batchBytes returns the total size of the keys of the batch.
+func batchBytes(batch []string) (n int) {
+	for _, k := range batch {
+		n += len(k)
+	}
+	return n
+}
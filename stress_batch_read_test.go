@@ -0,0 +1,88 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/coreos/dbtester/dbtesterpb"
+
+	"golang.org/x/net/context"
+)
+
+func TestGenerateBatchReads(t *testing.T) {
+	gcfg := dbtesterpb.ConfigClientMachineAgentControl{
+		DatabaseID: "etcd__v3_3",
+		ConfigClientMachineBenchmarkOptions: &dbtesterpb.ConfigClientMachineBenchmarkOptions{
+			RequestNumber: 3,
+			KeySizeBytes:  2,
+			Prepopulate:   5,
+		},
+	}
+	if err := checkBatchReads(gcfg, nil, 6); err == nil {
+		t.Fatal("expected error for more keys per request than prepopulated")
+	}
+
+	reqs := make(chan request)
+	go generateBatchReads(context.Background(), gcfg, nil, 2, reqs)
+	var got []request
+	for req := range reqs {
+		got = append(got, req)
+	}
+	if len(got) != 3 {
+		t.Fatalf("expected 3 requests, got %d", len(got))
+	}
+	exp := [][]string{{"00", "01"}, {"02", "03"}, {"04", "00"}}
+	for i, req := range got {
+		if !reflect.DeepEqual(req.batch, exp[i]) {
+			t.Fatalf("#%d: expected %q, got %q", i, exp[i], req.batch)
+		}
+	}
+	// consecutive keys are read in a range, and wrapped ones in a transaction
+	if op := got[1].etcdv3Op; !op.IsGet() || string(op.KeyBytes()) != "02" || string(op.RangeBytes()) != "03\x00" {
+		t.Fatalf("expected range [02, 03], got %+v", op)
+	}
+	if op := got[2].etcdv3Op; !op.IsTxn() {
+		t.Fatalf("expected transaction, got %+v", op)
+	}
+
+	// keys of '--keys-from' are not sorted
+	reqs = make(chan request)
+	go generateBatchReads(context.Background(), gcfg, []string{"b", "a", "c"}, 2, reqs)
+	req := <-reqs
+	for range reqs {
+	}
+	if !req.etcdv3Op.IsTxn() || !reflect.DeepEqual(req.batch, []string{"b", "a"}) {
+		t.Fatalf("unexpected request %+v", req)
+	}
+}
+
+func TestGetMockBatch(t *testing.T) {
+	mockDB.put("batch-1", []byte("foo"))
+	mockDB.put("batch-2", []byte("bar"))
+
+	req := &request{batch: []string{"batch-1", "batch-2"}, trace: &requestTrace{}}
+	if err := newGetMock(nil)(context.Background(), req); err != nil {
+		t.Fatal(err)
+	}
+	if req.trace.requestBytes != 14 || req.trace.responseBytes != 6 {
+		t.Fatalf("unexpected trace %+v", req.trace)
+	}
+	req = &request{batch: []string{"batch-1", "batch-missing"}}
+	if err := newGetMock(nil)(context.Background(), req); err != errEmptyResponse {
+		t.Fatalf("expected %v, got %v", errEmptyResponse, err)
+	}
+}
@@ -15,14 +15,61 @@
 package dbtester
 
 import (
+	"errors"
+	"strings"
+	"time"
+
 	"github.com/coreos/etcd/clientv3"
 	"golang.org/x/net/context"
 )
 
+// errEmptyResponse is returned by read request handlers
+// when the request succeeds but the key is not found.
+// It is counted separately from errors, since benchmarking
+// not-found responses reports misleadingly fast reads.
+var errEmptyResponse = errors.New("empty response")
+
 type request struct {
 	etcdv3Op clientv3.Op
 	zkOp     zkOp
 	consulOp consulOp
+	redisOp  redisOp
+	tikvOp   tikvOp
+	mockOp   mockOp
+
+	// seq is the order of the request in the generation.
+	seq int64
+	// scheduled is the time that the request is due.
+	scheduled time.Time
+
+	// batch are the keys of a batch read, read in one request.
+	// It is empty for single-key requests.
+	batch []string
+	// rangePrefix is the prefix of the keys of a range read, read in
+	// one request, and rangeKeys the number of the keys of the prefix.
+	// It is empty for other requests.
+	rangePrefix string
+	rangeKeys   int
+
+	// trace is not nil if the request is sampled for tracing
+	trace *requestTrace
+
+	// write is true for the writes of 'read-write'.
+	write bool
+}
+
+// key returns the key of the request, without the leading '/' of Zookeeper writes.
+func (req *request) key() string {
+	switch {
+	case len(req.etcdv3Op.KeyBytes()) > 0:
+		return string(req.etcdv3Op.KeyBytes())
+	case req.zkOp.key != "":
+		return strings.TrimPrefix(req.zkOp.key, "/")
+	case req.consulOp.key != "":
+		return req.consulOp.key
+	default:
+		return req.mockOp.key
+	}
 }
 
 // ReqHandler wraps request handler.
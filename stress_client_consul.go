@@ -15,6 +15,10 @@
 package dbtester
 
 import (
+	"fmt"
+	"sync"
+	"time"
+
 	consulapi "github.com/hashicorp/consul/api"
 	"go.uber.org/zap"
 	"golang.org/x/net/context"
@@ -26,28 +30,56 @@ type consulOp struct {
 	staleRead bool
 }
 
+// consulToken is the ACL token that all Consul clients send, if not empty.
+var consulToken string
+
+// newConsulConfig returns the configuration of the Consul client of
+// the endpoint, with the ACL token and the TLS of all Consul clients.
+func newConsulConfig(endpoint string) *consulapi.Config {
+	dcfg := consulapi.DefaultConfig()
+	dcfg.Address = endpoint // x.x.x.x:8500
+	if consulToken != "" {
+		dcfg.Token = consulToken
+	}
+	if clientTLS != nil {
+		dcfg.Scheme = "https"
+		dcfg.Transport.TLSClientConfig = clientTLS.Clone()
+	}
+	return dcfg
+}
+
 func mustCreateConnsConsul(endpoints []string, total int64) []*consulapi.KV {
+	clis := mustCreateClientsConsul(endpoints, total)
 	css := make([]*consulapi.KV, total)
 	for i := range css {
+		css[i] = clis[i].KV()
+	}
+	return css
+}
+
+func mustCreateClientsConsul(endpoints []string, total int64) []*consulapi.Client {
+	clis := make([]*consulapi.Client, total)
+	for i := range clis {
 		endpoint := endpoints[dialTotal%len(endpoints)]
 		dialTotal++
 
-		dcfg := consulapi.DefaultConfig()
-		dcfg.Address = endpoint // x.x.x.x:8500
-		cli, err := consulapi.NewClient(dcfg)
+		cli, err := consulapi.NewClient(newConsulConfig(endpoint))
 		if err != nil {
 			panic(err)
 		}
 
-		css[i] = cli.KV()
+		clis[i] = cli
 	}
-	return css
+	return clis
 }
 
 func newPutConsul(conn *consulapi.KV) ReqHandler {
 	return func(ctx context.Context, req *request) error {
 		op := req.consulOp
 		_, err := conn.Put(&consulapi.KVPair{Key: op.key, Value: op.value}, nil)
+		if req.trace != nil {
+			req.trace.requestBytes = len(op.key) + len(op.value)
+		}
 		return err
 	}
 }
@@ -63,7 +95,96 @@ func newGetConsul(conn *consulapi.KV) ReqHandler {
 			opt.AllowStale = false
 			opt.RequireConsistent = true
 		}
-		_, _, err := conn.Get(req.consulOp.key, opt)
+		if req.rangePrefix != "" {
+			return listConsul(conn, req, opt)
+		}
+		if len(req.batch) > 0 {
+			return getBatchConsul(conn, req, opt)
+		}
+		kv, meta, err := conn.Get(req.consulOp.key, opt)
+		if err != nil {
+			return err
+		}
+		if req.trace != nil {
+			req.trace.requestBytes = len(req.consulOp.key)
+			if kv != nil {
+				req.trace.responseBytes = len(kv.Value)
+			}
+			if meta != nil {
+				req.trace.revision = int64(meta.LastIndex)
+			}
+		}
+		if kv == nil {
+			return errEmptyResponse
+		}
+		return nil
+	}
+}
+
+// newDeleteConsul returns the delete handler. Consul does not
+// report whether the key existed, so no delete is empty.
+// getBatchConsul reads the keys of the batch with concurrent requests,
+// pipelined on the keep-alive connections of the client.
+func getBatchConsul(conn *consulapi.KV, req *request, opt *consulapi.QueryOptions) error {
+	pairs, errs := make([]*consulapi.KVPair, len(req.batch)), make([]error, len(req.batch))
+	var wg sync.WaitGroup
+	wg.Add(len(req.batch))
+	for i, k := range req.batch {
+		go func(i int, k string) {
+			defer wg.Done()
+			pairs[i], _, errs[i] = conn.Get(k, opt)
+		}(i, k)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	if req.trace != nil {
+		req.trace.requestBytes = batchBytes(req.batch)
+		for _, kv := range pairs {
+			if kv != nil {
+				req.trace.responseBytes += len(kv.Value)
+			}
+		}
+	}
+	for _, kv := range pairs {
+		if kv == nil {
+			return errEmptyResponse
+		}
+	}
+	return nil
+}
+
+// listConsul reads the keys of the prefix of the range read in one request.
+func listConsul(conn *consulapi.KV, req *request, opt *consulapi.QueryOptions) error {
+	pairs, meta, err := conn.List(req.rangePrefix, opt)
+	if err != nil {
+		return err
+	}
+	if req.trace != nil {
+		req.trace.requestBytes = len(req.rangePrefix)
+		for _, kv := range pairs {
+			req.trace.responseBytes += len(kv.Key) + len(kv.Value)
+		}
+		if meta != nil {
+			req.trace.revision = int64(meta.LastIndex)
+		}
+	}
+	if len(pairs) < req.rangeKeys {
+		return errEmptyResponse
+	}
+	return nil
+}
+
+func newDeleteConsul(conn *consulapi.KV) ReqHandler {
+	return func(ctx context.Context, req *request) error {
+		_, err := conn.Delete(req.consulOp.key, nil)
+		if req.trace != nil {
+			req.trace.requestBytes = len(req.consulOp.key)
+		}
 		return err
 	}
 }
@@ -75,3 +196,164 @@ func getTotalKeysConsul(lg *zap.Logger, endpoints []string) map[string]int64 {
 	}
 	return rs
 }
+
+// newTxnConsul reads the keys, and writes them with check-and-set
+// operations in a transaction, which is rolled back if any of
+// the keys has been modified since the read.
+func newTxnConsul(conn *consulapi.KV) txnFunc {
+	return func(ctx context.Context, keys []string, value []byte, tr *requestTrace) (bool, error) {
+		ops := make(consulapi.KVTxnOps, len(keys))
+		for i, k := range keys {
+			kv, _, err := conn.Get(k, &consulapi.QueryOptions{RequireConsistent: true})
+			if err != nil {
+				return false, err
+			}
+			var idx uint64
+			if kv != nil {
+				idx = kv.ModifyIndex
+			}
+			ops[i] = &consulapi.KVTxnOp{Verb: consulapi.KVCAS, Key: k, Value: value, Index: idx}
+		}
+		ok, _, meta, err := conn.Txn(ops, nil)
+		if err != nil {
+			return false, err
+		}
+		if tr != nil && meta != nil {
+			tr.revision = int64(meta.LastIndex)
+		}
+		return ok, nil
+	}
+}
+
+// consulLocker locks the keys with the session of the client: the lock
+// is held by acquiring the key with the session, and is released by
+// releasing the key, or by Consul once the session is invalidated.
+type consulLocker struct {
+	kv        *consulapi.KV
+	sessions  *consulapi.Session
+	lockDelay time.Duration
+	// session is created on the first lock, and after invalidations
+	session string
+}
+
+func newLockerConsul(cli *consulapi.Client, lockDelay time.Duration) locker {
+	return &consulLocker{kv: cli.KV(), sessions: cli.Session(), lockDelay: lockDelay}
+}
+
+func (l *consulLocker) lock(ctx context.Context, key string) error {
+	if l.session == "" {
+		id, _, err := l.sessions.Create(&consulapi.SessionEntry{
+			Behavior:  consulapi.SessionBehaviorRelease,
+			LockDelay: l.lockDelay,
+		}, (&consulapi.WriteOptions{}).WithContext(ctx))
+		if err != nil {
+			return err
+		}
+		l.session = id
+	}
+	var idx uint64
+	for {
+		ok, _, err := l.kv.Acquire(&consulapi.KVPair{Key: key, Session: l.session}, (&consulapi.WriteOptions{}).WithContext(ctx))
+		if err != nil {
+			return err
+		}
+		if ok {
+			return nil
+		}
+		// wait for the holder to release, or for the lock-delay
+		// of an invalidated holder to pass
+		kv, meta, err := l.kv.Get(key, (&consulapi.QueryOptions{WaitIndex: idx, WaitTime: time.Second}).WithContext(ctx))
+		if err != nil {
+			return err
+		}
+		if kv != nil && kv.Session != "" {
+			idx = meta.LastIndex
+		} else {
+			idx = 0
+		}
+	}
+}
+
+func (l *consulLocker) unlock(ctx context.Context, key string) error {
+	ok, _, err := l.kv.Release(&consulapi.KVPair{Key: key, Session: l.session}, (&consulapi.WriteOptions{}).WithContext(ctx))
+	if err == nil && !ok {
+		// the session was invalidated while the lock was held
+		err = errLockLost
+	}
+	return err
+}
+
+func (l *consulLocker) invalidate(ctx context.Context, key string) error {
+	id := l.session
+	l.session = ""
+	if _, err := l.sessions.Destroy(id, (&consulapi.WriteOptions{}).WithContext(ctx)); err != nil {
+		return err
+	}
+	var idx uint64
+	for {
+		kv, meta, err := l.kv.Get(key, (&consulapi.QueryOptions{WaitIndex: idx, RequireConsistent: true}).WithContext(ctx))
+		if err != nil {
+			return err
+		}
+		if kv == nil || kv.Session != id {
+			return nil
+		}
+		idx = meta.LastIndex
+	}
+}
+
+// newBankConsul moves the balances of 'bank' with check-and-set
+// operations of the modify indexes read, in a transaction.
+func newBankConsul(conn *consulapi.KV) *bankBackend {
+	balances := func(keys []string) ([]int64, []uint64, error) {
+		bs, idxs := make([]int64, len(keys)), make([]uint64, len(keys))
+		for i, k := range keys {
+			kv, _, err := conn.Get(k, &consulapi.QueryOptions{RequireConsistent: true})
+			if err != nil {
+				return nil, nil, err
+			}
+			if kv == nil {
+				return nil, nil, fmt.Errorf("account %q is not found", k)
+			}
+			if bs[i], err = parseBalance(k, kv.Value); err != nil {
+				return nil, nil, err
+			}
+			idxs[i] = kv.ModifyIndex
+		}
+		return bs, idxs, nil
+	}
+	return &bankBackend{
+		open: func(ctx context.Context, keys []string, balance int64) error {
+			for _, k := range keys {
+				if _, err := conn.Put(&consulapi.KVPair{Key: k, Value: []byte(formatBalance(balance))}, nil); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		balances: func(ctx context.Context, keys []string) ([]int64, error) {
+			bs, _, err := balances(keys)
+			return bs, err
+		},
+		transfer: func(ctx context.Context, from, to string, amount int64, tr *requestTrace) (bool, error) {
+			bs, idxs, err := balances([]string{from, to})
+			if err != nil {
+				return false, err
+			}
+			if amount > bs[0] {
+				amount = bs[0]
+			}
+			ok, _, meta, err := conn.Txn(consulapi.KVTxnOps{
+				&consulapi.KVTxnOp{Verb: consulapi.KVCAS, Key: from, Value: []byte(formatBalance(bs[0] - amount)), Index: idxs[0]},
+				&consulapi.KVTxnOp{Verb: consulapi.KVCAS, Key: to, Value: []byte(formatBalance(bs[1] + amount)), Index: idxs[1]},
+			}, nil)
+			if err != nil {
+				return false, err
+			}
+			if tr != nil && meta != nil {
+				tr.revision = int64(meta.LastIndex)
+			}
+			return ok, nil
+		},
+	}
+}
@@ -22,18 +22,94 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/coreos/dbtester/dbtesterpb"
 	"github.com/coreos/etcd/clientv3"
+	"github.com/coreos/etcd/etcdserver/etcdserverpb"
 	"go.uber.org/zap"
 	"golang.org/x/net/context"
 )
 
 func newPutEtcd3(conn clientv3.KV) ReqHandler {
 	return func(ctx context.Context, req *request) error {
-		_, err := conn.Do(ctx, req.etcdv3Op)
+		resp, err := conn.Do(ctx, req.etcdv3Op)
+		if req.trace != nil && err == nil && resp.Put() != nil {
+			req.trace.requestBytes = len(req.etcdv3Op.KeyBytes()) + len(req.etcdv3Op.ValueBytes())
+			req.trace.responseBytes = (*etcdserverpb.PutResponse)(resp.Put()).Size()
+			traceEtcdHeader(req.trace, resp.Put().Header)
+		}
 		return err
 	}
 }
 
+// newPutOpEtcdv3 returns the put of the key, which keeps the value or the
+// lease of the existing key with 'etcd_ignore_value' or 'etcd_ignore_lease'.
+func newPutOpEtcdv3(opts *dbtesterpb.ConfigClientMachineBenchmarkOptions, k, vs string) clientv3.Op {
+	var ops []clientv3.OpOption
+	if opts.EtcdIgnoreValue {
+		// etcd rejects the put with a value
+		vs = ""
+		ops = append(ops, clientv3.WithIgnoreValue())
+	}
+	if opts.EtcdIgnoreLease {
+		ops = append(ops, clientv3.WithIgnoreLease())
+	}
+	return clientv3.OpPut(k, vs, ops...)
+}
+
+// checkPutIgnoresEtcdv3 returns an error if 'etcd_ignore_value' or
+// 'etcd_ignore_lease' is set for writes that are not of existing etcd
+// keys. Both fail with "key not found" on the keys that do not exist.
+func checkPutIgnoresEtcdv3(gcfg dbtesterpb.ConfigClientMachineAgentControl) error {
+	opts := gcfg.ConfigClientMachineBenchmarkOptions
+	if !opts.EtcdIgnoreValue && !opts.EtcdIgnoreLease {
+		return nil
+	}
+	switch gcfg.DatabaseID {
+	case "etcd__other", "etcd__tip", "etcd__v3_2", "etcd__v3_3":
+	default:
+		return fmt.Errorf("'etcd_ignore_value' and 'etcd_ignore_lease' are not supported for %q", gcfg.DatabaseID)
+	}
+	if opts.Type == "write" && (opts.KeySpaceSize == 0 || opts.SameKey) {
+		return fmt.Errorf("'etcd_ignore_value' and 'etcd_ignore_lease' of 'write' require 'key_space_size' without 'same_key', to write existing keys")
+	}
+	return nil
+}
+
+func traceEtcdHeader(tr *requestTrace, h *etcdserverpb.ResponseHeader) {
+	if h == nil {
+		return
+	}
+	tr.revision = h.Revision
+	tr.raftTerm = h.RaftTerm
+	tr.member = fmt.Sprintf("%x", h.MemberId)
+}
+
+// etcdMaxRecvBytes is the receive limit of the etcd clients,
+// of 'MaxResponseBytes'. 0 for the default limit.
+var etcdMaxRecvBytes int
+
+// etcdAPIVersion is the etcd client API of the etcd clients,
+// of 'etcd_api_version'. "" for the vendored clientv3.
+var etcdAPIVersion string
+
+// checkEtcdAPIVersion returns an error if 'etcd_api_version' is not
+// a known API, or is set for databases other than etcd.
+func checkEtcdAPIVersion(gcfg dbtesterpb.ConfigClientMachineAgentControl) error {
+	v := gcfg.ConfigClientMachineBenchmarkOptions.EtcdAPIVersion
+	switch v {
+	case "":
+		return nil
+	case "clientv3", "grpc":
+	default:
+		return fmt.Errorf("unknown 'etcd_api_version' %q (must be 'clientv3' or 'grpc')", v)
+	}
+	switch gcfg.DatabaseID {
+	case "etcd__other", "etcd__tip", "etcd__v3_2", "etcd__v3_3":
+		return nil
+	}
+	return fmt.Errorf("'etcd_api_version' is not supported for %q", gcfg.DatabaseID)
+}
+
 // dialTotal counts the number of mustCreateConn calls so that endpoint
 // connections can be handed out in round-robin order
 var dialTotal int
@@ -46,9 +122,20 @@ func mustCreateConnEtcdv3(endpoints []string) *clientv3.Client {
 	// 	Endpoints: []string{endpoint},
 	// }
 
+	if etcdAPIVersion == "grpc" {
+		// no balancer; the connections are spread over the endpoints
+		endpoints = []string{endpoints[dialTotal%len(endpoints)]}
+		dialTotal++
+	}
+
 	// let etcd client v3 balancer handle round robin
 	cfg := clientv3.Config{
 		Endpoints: endpoints,
+		Username:  etcdAuthUser,
+		Password:  etcdAuthPassword,
+		TLS:       clientTLS,
+
+		MaxCallRecvMsgSize: etcdMaxRecvBytes,
 	}
 
 	client, err := clientv3.New(cfg)
@@ -56,6 +143,10 @@ func mustCreateConnEtcdv3(endpoints []string) *clientv3.Client {
 		fmt.Fprintf(os.Stderr, "dial error: %v\n", err)
 		os.Exit(1)
 	}
+	if etcdAPIVersion == "grpc" {
+		// the KV service of the versioned proto, without the retries
+		client.KV = clientv3.NewKVFromKVClient(etcdserverpb.NewKVClient(client.ActiveConnection()), client)
+	}
 	return client
 }
 
@@ -79,8 +170,55 @@ func mustCreateClientsEtcdv3(endpoints []string, cfg etcdv3ClientCfg) []*clientv
 
 func newGetEtcd3(conn clientv3.KV) ReqHandler {
 	return func(ctx context.Context, req *request) error {
-		_, err := conn.Do(ctx, req.etcdv3Op)
-		return err
+		resp, err := conn.Do(ctx, req.etcdv3Op)
+		if err != nil {
+			return err
+		}
+		if txn := resp.Txn(); txn != nil {
+			// batch read of the keys with no interval
+			found := 0
+			for _, r := range txn.Responses {
+				if rr := r.GetResponseRange(); rr != nil {
+					found += len(rr.Kvs)
+				}
+			}
+			if req.trace != nil {
+				req.trace.requestBytes = batchBytes(req.batch)
+				req.trace.responseBytes = (*etcdserverpb.TxnResponse)(txn).Size()
+				traceEtcdHeader(req.trace, txn.Header)
+			}
+			if found < len(req.batch) {
+				return errEmptyResponse
+			}
+			return nil
+		}
+		if req.trace != nil && resp.Get() != nil {
+			req.trace.requestBytes = len(req.etcdv3Op.KeyBytes()) + len(req.etcdv3Op.RangeBytes())
+			req.trace.responseBytes = (*etcdserverpb.RangeResponse)(resp.Get()).Size()
+			traceEtcdHeader(req.trace, resp.Get().Header)
+		}
+		if resp.Get() != nil && (len(resp.Get().Kvs) == 0 || len(resp.Get().Kvs) < len(req.batch) || len(resp.Get().Kvs) < req.rangeKeys) {
+			return errEmptyResponse
+		}
+		return nil
+	}
+}
+
+func newDeleteEtcd3(conn clientv3.KV) ReqHandler {
+	return func(ctx context.Context, req *request) error {
+		resp, err := conn.Do(ctx, req.etcdv3Op)
+		if err != nil {
+			return err
+		}
+		if req.trace != nil && resp.Del() != nil {
+			req.trace.requestBytes = len(req.etcdv3Op.KeyBytes())
+			req.trace.responseBytes = (*etcdserverpb.DeleteRangeResponse)(resp.Del()).Size()
+			traceEtcdHeader(req.trace, resp.Del().Header)
+		}
+		if resp.Del() != nil && resp.Del().Deleted == 0 {
+			return errEmptyResponse
+		}
+		return nil
 	}
 }
 
@@ -122,3 +260,104 @@ func getTotalKeysEtcdv3(lg *zap.Logger, endpoints []string) map[string]int64 {
 	lg.Info("getTotalKeysEtcdv3", zap.String("response", fmt.Sprintf("%+v", rs)))
 	return rs
 }
+
+// newTxnEtcd3 reads the keys, and writes them in a transaction
+// only if none of them has been modified since the read.
+func newTxnEtcd3(conn clientv3.KV) txnFunc {
+	return func(ctx context.Context, keys []string, value []byte, tr *requestTrace) (bool, error) {
+		gets := make([]clientv3.Op, len(keys))
+		for i, k := range keys {
+			gets[i] = clientv3.OpGet(k)
+		}
+		rresp, err := conn.Txn(ctx).Then(gets...).Commit()
+		if err != nil {
+			return false, err
+		}
+
+		cmps := make([]clientv3.Cmp, len(keys))
+		puts := make([]clientv3.Op, len(keys))
+		for i, k := range keys {
+			var rev int64
+			if kvs := rresp.Responses[i].GetResponseRange().Kvs; len(kvs) > 0 {
+				rev = kvs[0].ModRevision
+			}
+			cmps[i] = clientv3.Compare(clientv3.ModRevision(k), "=", rev)
+			puts[i] = clientv3.OpPut(k, string(value))
+		}
+		wresp, err := conn.Txn(ctx).If(cmps...).Then(puts...).Commit()
+		if err != nil {
+			return false, err
+		}
+		if tr != nil {
+			tr.responseBytes = (*etcdserverpb.TxnResponse)(wresp).Size()
+			traceEtcdHeader(tr, wresp.Header)
+		}
+		return wresp.Succeeded, nil
+	}
+}
+
+// newBankEtcd3 moves the balances of 'bank' in transactions
+// comparing the mod revisions of both accounts.
+func newBankEtcd3(conn clientv3.KV) *bankBackend {
+	balances := func(ctx context.Context, keys []string) ([]int64, []int64, error) {
+		// all in one transaction, of one revision
+		gets := make([]clientv3.Op, len(keys))
+		for i, k := range keys {
+			gets[i] = clientv3.OpGet(k)
+		}
+		resp, err := conn.Txn(ctx).Then(gets...).Commit()
+		if err != nil {
+			return nil, nil, err
+		}
+		bs, revs := make([]int64, len(keys)), make([]int64, len(keys))
+		for i, k := range keys {
+			kvs := resp.Responses[i].GetResponseRange().Kvs
+			if len(kvs) == 0 {
+				return nil, nil, fmt.Errorf("account %q is not found", k)
+			}
+			if bs[i], err = parseBalance(k, kvs[0].Value); err != nil {
+				return nil, nil, err
+			}
+			revs[i] = kvs[0].ModRevision
+		}
+		return bs, revs, nil
+	}
+	return &bankBackend{
+		open: func(ctx context.Context, keys []string, balance int64) error {
+			for _, k := range keys {
+				if _, err := conn.Put(ctx, k, formatBalance(balance)); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		balances: func(ctx context.Context, keys []string) ([]int64, error) {
+			bs, _, err := balances(ctx, keys)
+			return bs, err
+		},
+		transfer: func(ctx context.Context, from, to string, amount int64, tr *requestTrace) (bool, error) {
+			bs, revs, err := balances(ctx, []string{from, to})
+			if err != nil {
+				return false, err
+			}
+			if amount > bs[0] {
+				amount = bs[0]
+			}
+			resp, err := conn.Txn(ctx).If(
+				clientv3.Compare(clientv3.ModRevision(from), "=", revs[0]),
+				clientv3.Compare(clientv3.ModRevision(to), "=", revs[1]),
+			).Then(
+				clientv3.OpPut(from, formatBalance(bs[0]-amount)),
+				clientv3.OpPut(to, formatBalance(bs[1]+amount)),
+			).Commit()
+			if err != nil {
+				return false, err
+			}
+			if tr != nil {
+				tr.responseBytes = (*etcdserverpb.TxnResponse)(resp).Size()
+				traceEtcdHeader(tr, resp.Header)
+			}
+			return resp.Succeeded, nil
+		},
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/coreos/dbtester/dbtesterpb"
+
+	"github.com/coreos/etcd/clientv3"
+	"github.com/coreos/etcd/etcdserver/api/v3rpc/rpctypes"
+	"golang.org/x/net/context"
+)
+
+const (
+	etcdRootUser         = "root"
+	etcdRootPassword     = "dbtester-root"
+	etcdRestrictedUser   = "dbtester"
+	etcdRestrictedRole   = "dbtester"
+	etcdRestrictedPasswd = "dbtester"
+)
+
+// etcdAuthUser and etcdAuthPassword are the credentials
+// that all etcd clients authenticate with, if not empty.
+var (
+	etcdAuthUser     string
+	etcdAuthPassword string
+)
+
+func setEtcdAuth(user, password string) {
+	etcdAuthUser, etcdAuthPassword = user, password
+}
+
+// setupEtcdRBAC adds the root user, and the restricted user with
+// read-write permission on all keys from the key prefix "\x00",
+// and then enables authentication. It returns the function that
+// disables authentication as root, to leave the cluster as it was.
+// If authentication is already enabled, as by an earlier run that
+// did not finish, the users are set up as root and it is left enabled.
+func setupEtcdRBAC(endpoints []string) (disable func() error, err error) {
+	ccfg := clientv3.Config{Endpoints: endpoints, DialTimeout: 5 * time.Second, TLS: clientTLS}
+	cli, err := clientv3.New(ccfg)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { cli.Close() }()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	enabled := false
+	_, err = cli.UserAdd(ctx, etcdRootUser, etcdRootPassword)
+	if err == rpctypes.ErrUserEmpty {
+		// anonymous requests are rejected once authentication is enabled
+		enabled = true
+		cli.Close()
+		rcfg := ccfg
+		rcfg.Username, rcfg.Password = etcdRootUser, etcdRootPassword
+		if cli, err = clientv3.New(rcfg); err != nil {
+			return nil, fmt.Errorf("etcd authentication is already enabled, and failed as %q (%v)", etcdRootUser, err)
+		}
+		_, err = cli.UserAdd(ctx, etcdRootUser, etcdRootPassword)
+	}
+	if err != nil && err != rpctypes.ErrUserAlreadyExist {
+		return nil, err
+	}
+	if _, err = cli.UserGrantRole(ctx, etcdRootUser, "root"); err != nil {
+		return nil, err
+	}
+	if _, err = cli.RoleAdd(ctx, etcdRestrictedRole); err != nil && err != rpctypes.ErrRoleAlreadyExist {
+		return nil, err
+	}
+	// rangeEnd "\x00" is all keys >= key
+	if _, err = cli.RoleGrantPermission(ctx, etcdRestrictedRole, "\x00", "\x00", clientv3.PermissionType(clientv3.PermReadWrite)); err != nil {
+		return nil, err
+	}
+	if _, err = cli.UserAdd(ctx, etcdRestrictedUser, etcdRestrictedPasswd); err != nil && err != rpctypes.ErrUserAlreadyExist {
+		return nil, err
+	}
+	if _, err = cli.UserGrantRole(ctx, etcdRestrictedUser, etcdRestrictedRole); err != nil {
+		return nil, err
+	}
+	if enabled {
+		return func() error { return nil }, nil
+	}
+	if _, err = cli.AuthEnable(ctx); err != nil {
+		return nil, err
+	}
+	return func() error { return disableEtcdAuth(ccfg) }, nil
+}
+
+// disableEtcdAuth disables authentication as root.
+func disableEtcdAuth(ccfg clientv3.Config) error {
+	ccfg.Username, ccfg.Password = etcdRootUser, etcdRootPassword
+	cli, err := clientv3.New(ccfg)
+	if err != nil {
+		return err
+	}
+	defer cli.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	_, err = cli.AuthDisable(ctx)
+	return err
+}
+
+// runEtcdRBACBaseline runs the workload as root, which skips
+// permission checks, to measure the overhead of the restricted user.
+func (cfg *Config) runEtcdRBACBaseline(gcfg dbtesterpb.ConfigClientMachineAgentControl, vals values) (time.Duration, error) {
+	return cfg.runBaseline(gcfg, vals, "etcd RBAC baseline as root")
+}
@@ -0,0 +1,87 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"testing"
+
+	"github.com/coreos/dbtester/dbtesterpb"
+)
+
+func TestNewPutOpEtcdv3(t *testing.T) {
+	opts := &dbtesterpb.ConfigClientMachineBenchmarkOptions{}
+	if op := newPutOpEtcdv3(opts, "foo", "bar"); string(op.ValueBytes()) != "bar" {
+		t.Fatalf("expected value 'bar', got %q", op.ValueBytes())
+	}
+
+	// the value of the key is kept
+	opts.EtcdIgnoreValue = true
+	if op := newPutOpEtcdv3(opts, "foo", "bar"); !op.IsPut() || len(op.ValueBytes()) != 0 {
+		t.Fatalf("expected put with no value, got %q", op.ValueBytes())
+	}
+}
+
+func TestCheckPutIgnoresEtcdv3(t *testing.T) {
+	tests := []struct {
+		databaseID   string
+		typ          string
+		keySpaceSize int64
+		ignoreLease  bool
+		ok           bool
+	}{
+		{"etcd__v3_3", "write", 0, false, true},
+		{"etcd__v3_3", "write", 100, true, true},
+		{"etcd__v3_3", "read-write", 0, true, true},
+		{"etcd__v3_3", "write", 0, true, false},
+		{"consul__v1_0_2", "write", 100, true, false},
+	}
+	for i, tt := range tests {
+		gcfg := dbtesterpb.ConfigClientMachineAgentControl{
+			DatabaseID: tt.databaseID,
+			ConfigClientMachineBenchmarkOptions: &dbtesterpb.ConfigClientMachineBenchmarkOptions{
+				Type:            tt.typ,
+				KeySpaceSize:    tt.keySpaceSize,
+				EtcdIgnoreLease: tt.ignoreLease,
+			},
+		}
+		if err := checkPutIgnoresEtcdv3(gcfg); (err == nil) != tt.ok {
+			t.Fatalf("#%d: expected ok %v, got %v", i, tt.ok, err)
+		}
+	}
+}
+
+func TestCheckEtcdAPIVersion(t *testing.T) {
+	tests := []struct {
+		databaseID string
+		version    string
+		ok         bool
+	}{
+		{"etcd__v3_3", "", true},
+		{"etcd__v3_3", "clientv3", true},
+		{"etcd__tip", "grpc", true},
+		{"etcd__v3_3", "v2", false},
+		{"zetcd__beta", "grpc", false},
+		{"consul__v1_0_2", "", true},
+	}
+	for i, tt := range tests {
+		gcfg := dbtesterpb.ConfigClientMachineAgentControl{
+			DatabaseID:                          tt.databaseID,
+			ConfigClientMachineBenchmarkOptions: &dbtesterpb.ConfigClientMachineBenchmarkOptions{EtcdAPIVersion: tt.version},
+		}
+		if err := checkEtcdAPIVersion(gcfg); (err == nil) != tt.ok {
+			t.Fatalf("#%d: expected ok %v, got %v", i, tt.ok, err)
+		}
+	}
+}
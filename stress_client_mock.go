@@ -0,0 +1,356 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"errors"
+	"fmt"
+	mrand "math/rand"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/coreos/dbtester/dbtesterpb"
+
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+)
+
+type mockOp struct {
+	key   string
+	value []byte
+}
+
+// errMockInjected is returned by the mock database
+// with the probability of 'error_rate_percent'.
+var errMockInjected = errors.New("mock: injected error")
+
+// mockStore is the in-process database of 'mock' database ID.
+// It lives as long as the control process.
+type mockStore struct {
+	mu sync.RWMutex
+	kv map[string][]byte
+	// ver is the number of writes of each key
+	ver map[string]int64
+}
+
+var mockDB = &mockStore{kv: make(map[string][]byte), ver: make(map[string]int64)}
+
+func (s *mockStore) put(key string, value []byte) {
+	s.mu.Lock()
+	s.kv[key] = value
+	s.ver[key]++
+	s.mu.Unlock()
+}
+
+func (s *mockStore) version(key string) int64 {
+	s.mu.RLock()
+	v := s.ver[key]
+	s.mu.RUnlock()
+	return v
+}
+
+// cas writes all keys only if their versions are unchanged.
+func (s *mockStore) cas(keys []string, vers []int64, value []byte) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, k := range keys {
+		if s.ver[k] != vers[i] {
+			return false
+		}
+	}
+	for _, k := range keys {
+		s.kv[k] = value
+		s.ver[k]++
+	}
+	return true
+}
+
+// casEach writes each key its own value only if the versions of
+// all keys are unchanged.
+func (s *mockStore) casEach(keys []string, vers []int64, values [][]byte) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, k := range keys {
+		if s.ver[k] != vers[i] {
+			return false
+		}
+	}
+	for i, k := range keys {
+		s.kv[k] = values[i]
+		s.ver[k]++
+	}
+	return true
+}
+
+// getVersion returns the value of the key with its version.
+func (s *mockStore) getVersion(key string) ([]byte, int64, bool) {
+	s.mu.RLock()
+	v, ok := s.kv[key]
+	ver := s.ver[key]
+	s.mu.RUnlock()
+	return v, ver, ok
+}
+
+// list returns the number of the keys of the prefix, and the total
+// size of their values, scanning all keys.
+func (s *mockStore) list(prefix string) (keys, size int) {
+	s.mu.RLock()
+	for k, v := range s.kv {
+		if strings.HasPrefix(k, prefix) {
+			keys++
+			size += len(v)
+		}
+	}
+	s.mu.RUnlock()
+	return keys, size
+}
+
+func (s *mockStore) get(key string) ([]byte, bool) {
+	s.mu.RLock()
+	v, ok := s.kv[key]
+	s.mu.RUnlock()
+	return v, ok
+}
+
+// delete returns false if the key does not exist.
+func (s *mockStore) delete(key string) bool {
+	s.mu.Lock()
+	_, ok := s.kv[key]
+	delete(s.kv, key)
+	s.mu.Unlock()
+	return ok
+}
+
+func (s *mockStore) size() int64 {
+	s.mu.RLock()
+	n := int64(len(s.kv))
+	s.mu.RUnlock()
+	return n
+}
+
+// mockDelay sleeps for the configured artificial latency,
+// and returns an injected error at the configured rate.
+func mockDelay(ctx context.Context, flag *dbtesterpb.Flag_Mock) error {
+	if flag == nil {
+		return nil
+	}
+	lat := time.Duration(flag.LatencyMicroseconds) * time.Microsecond
+	if flag.LatencyJitterMicroseconds > 0 {
+		lat += time.Duration(mrand.Int63n(flag.LatencyJitterMicroseconds)) * time.Microsecond
+	}
+	if lat > 0 {
+		select {
+		case <-time.After(lat):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	if flag.ErrorRatePercent > 0 && mrand.Int63n(100) < flag.ErrorRatePercent {
+		return errMockInjected
+	}
+	return nil
+}
+
+func newPutMock(flag *dbtesterpb.Flag_Mock) ReqHandler {
+	return func(ctx context.Context, req *request) error {
+		if err := mockDelay(ctx, flag); err != nil {
+			return err
+		}
+		mockDB.put(req.mockOp.key, req.mockOp.value)
+		if req.trace != nil {
+			req.trace.requestBytes = len(req.mockOp.key) + len(req.mockOp.value)
+			req.trace.revision = mockDB.size()
+			req.trace.member = "mock"
+		}
+		return nil
+	}
+}
+
+func newGetMock(flag *dbtesterpb.Flag_Mock) ReqHandler {
+	return func(ctx context.Context, req *request) error {
+		if err := mockDelay(ctx, flag); err != nil {
+			return err
+		}
+		if req.rangePrefix != "" {
+			found, size := mockDB.list(req.rangePrefix)
+			if req.trace != nil {
+				req.trace.requestBytes = len(req.rangePrefix)
+				req.trace.responseBytes = size
+				req.trace.member = "mock"
+			}
+			if found < req.rangeKeys {
+				return errEmptyResponse
+			}
+			return nil
+		}
+		if len(req.batch) > 0 {
+			found := 0
+			for _, k := range req.batch {
+				v, ok := mockDB.get(k)
+				if ok {
+					found++
+				}
+				if req.trace != nil {
+					req.trace.responseBytes += len(v)
+				}
+			}
+			if req.trace != nil {
+				req.trace.requestBytes = batchBytes(req.batch)
+				req.trace.member = "mock"
+			}
+			if found < len(req.batch) {
+				return errEmptyResponse
+			}
+			return nil
+		}
+		v, ok := mockDB.get(req.mockOp.key)
+		if req.trace != nil {
+			req.trace.requestBytes = len(req.mockOp.key)
+			req.trace.responseBytes = len(v)
+			req.trace.member = "mock"
+		}
+		if !ok {
+			return errEmptyResponse
+		}
+		return nil
+	}
+}
+
+func newDeleteMock(flag *dbtesterpb.Flag_Mock) ReqHandler {
+	return func(ctx context.Context, req *request) error {
+		if err := mockDelay(ctx, flag); err != nil {
+			return err
+		}
+		ok := mockDB.delete(req.mockOp.key)
+		if req.trace != nil {
+			req.trace.requestBytes = len(req.mockOp.key)
+			req.trace.member = "mock"
+		}
+		if !ok {
+			return errEmptyResponse
+		}
+		return nil
+	}
+}
+
+func newTxnMock(flag *dbtesterpb.Flag_Mock) txnFunc {
+	return func(ctx context.Context, keys []string, value []byte, tr *requestTrace) (bool, error) {
+		vers := make([]int64, len(keys))
+		for i, k := range keys {
+			vers[i] = mockDB.version(k)
+		}
+		if err := mockDelay(ctx, flag); err != nil {
+			return false, err
+		}
+		return mockDB.cas(keys, vers, value), nil
+	}
+}
+
+// mockLocks are the locks of the keys of 'mock' database ID,
+// each a channel that holds one value while the lock is held.
+var mockLocks = struct {
+	mu    sync.Mutex
+	locks map[string]chan struct{}
+}{locks: make(map[string]chan struct{})}
+
+func mockLock(key string) chan struct{} {
+	mockLocks.mu.Lock()
+	defer mockLocks.mu.Unlock()
+	ch, ok := mockLocks.locks[key]
+	if !ok {
+		ch = make(chan struct{}, 1)
+		mockLocks.locks[key] = ch
+	}
+	return ch
+}
+
+// mockLocker locks the keys of 'mock' database ID, where an
+// invalidation releases the lock at once, as a release does.
+type mockLocker struct {
+	flag *dbtesterpb.Flag_Mock
+}
+
+func newLockerMock(flag *dbtesterpb.Flag_Mock) locker {
+	return &mockLocker{flag: flag}
+}
+
+func (l *mockLocker) lock(ctx context.Context, key string) error {
+	if err := mockDelay(ctx, l.flag); err != nil {
+		return err
+	}
+	select {
+	case mockLock(key) <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (l *mockLocker) unlock(ctx context.Context, key string) error {
+	<-mockLock(key)
+	return mockDelay(ctx, l.flag)
+}
+
+func (l *mockLocker) invalidate(ctx context.Context, key string) error {
+	return l.unlock(ctx, key)
+}
+
+func getTotalKeysMock(lg *zap.Logger, endpoints []string) map[string]int64 {
+	return map[string]int64{"mock": mockDB.size()}
+}
+
+func newBankMock(flag *dbtesterpb.Flag_Mock) *bankBackend {
+	balances := func(keys []string) ([]int64, []int64, error) {
+		bs, vers := make([]int64, len(keys)), make([]int64, len(keys))
+		for i, k := range keys {
+			v, ver, ok := mockDB.getVersion(k)
+			if !ok {
+				return nil, nil, fmt.Errorf("account %q is not found", k)
+			}
+			var err error
+			if bs[i], err = parseBalance(k, v); err != nil {
+				return nil, nil, err
+			}
+			vers[i] = ver
+		}
+		return bs, vers, nil
+	}
+	return &bankBackend{
+		open: func(ctx context.Context, keys []string, balance int64) error {
+			for _, k := range keys {
+				mockDB.put(k, []byte(formatBalance(balance)))
+			}
+			return nil
+		},
+		balances: func(ctx context.Context, keys []string) ([]int64, error) {
+			bs, _, err := balances(keys)
+			return bs, err
+		},
+		transfer: func(ctx context.Context, from, to string, amount int64, tr *requestTrace) (bool, error) {
+			bs, vers, err := balances([]string{from, to})
+			if err != nil {
+				return false, err
+			}
+			if err = mockDelay(ctx, flag); err != nil {
+				return false, err
+			}
+			if amount > bs[0] {
+				amount = bs[0]
+			}
+			return mockDB.casEach([]string{from, to}, vers, [][]byte{[]byte(formatBalance(bs[0] - amount)), []byte(formatBalance(bs[1] + amount))}), nil
+		},
+	}
+}
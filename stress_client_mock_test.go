@@ -0,0 +1,51 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/coreos/dbtester/dbtesterpb"
+
+	"golang.org/x/net/context"
+)
+
+func TestMock(t *testing.T) {
+	req := &request{mockOp: mockOp{key: "foo", value: []byte("bar")}}
+	if err := newPutMock(nil)(context.Background(), req); err != nil {
+		t.Fatal(err)
+	}
+	if v, ok := mockDB.get("foo"); !ok || !bytes.Equal(v, []byte("bar")) {
+		t.Fatalf("expected %q, got %q", "bar", v)
+	}
+
+	missing := &request{mockOp: mockOp{key: "missing"}}
+	if err := newGetMock(nil)(context.Background(), missing); err != errEmptyResponse {
+		t.Fatalf("expected %v, got %v", errEmptyResponse, err)
+	}
+
+	flag := &dbtesterpb.Flag_Mock{ErrorRatePercent: 100}
+	if err := newGetMock(flag)(context.Background(), req); err != errMockInjected {
+		t.Fatalf("expected %v, got %v", errMockInjected, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	flag = &dbtesterpb.Flag_Mock{LatencyMicroseconds: 1000000}
+	if err := newGetMock(flag)(ctx, req); err != context.Canceled {
+		t.Fatalf("expected %v, got %v", context.Canceled, err)
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"github.com/coreos/dbtester/dbtesterpb"
+	"github.com/coreos/dbtester/pkg/redis"
+
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+)
+
+type redisOp struct {
+	key   string
+	value []byte
+}
+
+// redisScanCount is the number of keys that each SCAN call returns.
+const redisScanCount = 1000
+
+// mustCreateConnsRedis returns 'total' clients over the endpoints, of
+// Redis Cluster if 'cluster_mode' is set. Each client connects on its
+// first command, and sends one command at a time.
+func mustCreateConnsRedis(endpoints []string, total int64, flag *dbtesterpb.Flag_Redis_V4_0) []*redis.Client {
+	cluster := flag != nil && flag.ClusterMode
+	clis := make([]*redis.Client, total)
+	for i := range clis {
+		endpoint := endpoints[dialTotal%len(endpoints)]
+		dialTotal++
+		clis[i] = redis.New(endpoint, cluster)
+	}
+	return clis
+}
+
+func closeConnsRedis(clis []*redis.Client) func() {
+	return func() {
+		for i := range clis {
+			clis[i].Close()
+		}
+	}
+}
+
+func newPutRedis(cli *redis.Client) ReqHandler {
+	return func(ctx context.Context, req *request) error {
+		op := req.redisOp
+		err := cli.Set(ctx, []byte(op.key), op.value)
+		if req.trace != nil {
+			req.trace.requestBytes = len(op.key) + len(op.value)
+		}
+		return err
+	}
+}
+
+func newGetRedis(cli *redis.Client) ReqHandler {
+	return func(ctx context.Context, req *request) error {
+		if len(req.batch) > 0 {
+			// GET each key, which may be in different slots of a cluster
+			found := 0
+			for _, k := range req.batch {
+				v, err := cli.Get(ctx, []byte(k))
+				if err != nil {
+					return err
+				}
+				if v != nil {
+					found++
+				}
+				if req.trace != nil {
+					req.trace.responseBytes += len(v)
+				}
+			}
+			if req.trace != nil {
+				req.trace.requestBytes = batchBytes(req.batch)
+			}
+			if found < len(req.batch) {
+				return errEmptyResponse
+			}
+			return nil
+		}
+
+		v, err := cli.Get(ctx, []byte(req.redisOp.key))
+		if err != nil {
+			return err
+		}
+		if req.trace != nil {
+			req.trace.requestBytes = len(req.redisOp.key)
+			req.trace.responseBytes = len(v)
+		}
+		if v == nil {
+			return errEmptyResponse
+		}
+		return nil
+	}
+}
+
+func newDeleteRedis(cli *redis.Client) ReqHandler {
+	return func(ctx context.Context, req *request) error {
+		ok, err := cli.Del(ctx, []byte(req.redisOp.key))
+		if err != nil {
+			return err
+		}
+		if req.trace != nil {
+			req.trace.requestBytes = len(req.redisOp.key)
+		}
+		if !ok {
+			return errEmptyResponse
+		}
+		return nil
+	}
+}
+
+// getTotalKeysRedis counts the keys of each endpoint with SCAN. The nodes
+// of Redis Cluster only count the keys of their own slots.
+func getTotalKeysRedis(lg *zap.Logger, endpoints []string) map[string]int64 {
+	rs := make(map[string]int64)
+	for _, ep := range endpoints {
+		cli := redis.New(ep, false)
+		keys, err := cli.Scan(context.Background(), ep, "*", redisScanCount)
+		cli.Close()
+		if err != nil {
+			lg.Warn("failed to SCAN", zap.String("endpoint", ep), zap.Error(err))
+		}
+		rs[ep] = int64(len(keys))
+	}
+	return rs
+}
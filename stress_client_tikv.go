@@ -0,0 +1,170 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"github.com/coreos/dbtester/dbtesterpb"
+	"github.com/coreos/dbtester/pkg/tikv"
+
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+)
+
+type tikvOp struct {
+	key   string
+	value []byte
+}
+
+// tikvScanLimit is the number of keys that each raw scan returns.
+const tikvScanLimit = 10000
+
+// tikvRawKV is the raw key-value API that the requests use, of the
+// client in pkg/tikv until the TiKV client ('rawkv') can be vendored,
+// which then only needs to be adapted to it.
+type tikvRawKV interface {
+	// Get returns the value of the key, or nil if not found.
+	Get(ctx context.Context, key []byte) ([]byte, error)
+	Put(ctx context.Context, key, value []byte) error
+	Delete(ctx context.Context, key []byte) error
+}
+
+// tikvPDEndpoints returns the PD endpoints of 'tikv__v2_1.pd_endpoints',
+// or 'database_endpoints' if not set.
+func tikvPDEndpoints(gcfg dbtesterpb.ConfigClientMachineAgentControl) []string {
+	if gcfg.Flag_Tikv_V2_1 != nil && len(gcfg.Flag_Tikv_V2_1.PDEndpoints) > 0 {
+		return gcfg.Flag_Tikv_V2_1.PDEndpoints
+	}
+	return gcfg.DatabaseEndpoints
+}
+
+// mustCreateConnsTikv returns 'total' clients of the cluster, each
+// asking PD from a different endpoint first.
+func mustCreateConnsTikv(gcfg dbtesterpb.ConfigClientMachineAgentControl, total int64) []*tikv.Client {
+	endpoints := tikvPDEndpoints(gcfg)
+	clis := make([]*tikv.Client, total)
+	for i := range clis {
+		first := dialTotal % len(endpoints)
+		dialTotal++
+		eps := append(append([]string{}, endpoints[first:]...), endpoints[:first]...)
+		cli, err := tikv.New(eps)
+		if err != nil {
+			panic(err)
+		}
+		clis[i] = cli
+	}
+	return clis
+}
+
+func closeConnsTikv(clis []*tikv.Client) func() {
+	return func() {
+		for i := range clis {
+			clis[i].Close()
+		}
+	}
+}
+
+func newPutTikv(cli tikvRawKV) ReqHandler {
+	return func(ctx context.Context, req *request) error {
+		op := req.tikvOp
+		err := cli.Put(ctx, []byte(op.key), op.value)
+		if req.trace != nil {
+			req.trace.requestBytes = len(op.key) + len(op.value)
+		}
+		return err
+	}
+}
+
+func newGetTikv(cli tikvRawKV) ReqHandler {
+	return func(ctx context.Context, req *request) error {
+		if len(req.batch) > 0 {
+			// raw gets of each key, which may be in different regions
+			found := 0
+			for _, k := range req.batch {
+				v, err := cli.Get(ctx, []byte(k))
+				if err != nil {
+					return err
+				}
+				if v != nil {
+					found++
+				}
+				if req.trace != nil {
+					req.trace.responseBytes += len(v)
+				}
+			}
+			if req.trace != nil {
+				req.trace.requestBytes = batchBytes(req.batch)
+			}
+			if found < len(req.batch) {
+				return errEmptyResponse
+			}
+			return nil
+		}
+
+		v, err := cli.Get(ctx, []byte(req.tikvOp.key))
+		if err != nil {
+			return err
+		}
+		if req.trace != nil {
+			req.trace.requestBytes = len(req.tikvOp.key)
+			req.trace.responseBytes = len(v)
+		}
+		if v == nil {
+			return errEmptyResponse
+		}
+		return nil
+	}
+}
+
+// newDeleteTikv deletes the key. Raw deletes succeed whether or not
+// the key exists, so deletes of missing keys are not counted as empty.
+func newDeleteTikv(cli tikvRawKV) ReqHandler {
+	return func(ctx context.Context, req *request) error {
+		err := cli.Delete(ctx, []byte(req.tikvOp.key))
+		if req.trace != nil {
+			req.trace.requestBytes = len(req.tikvOp.key)
+		}
+		return err
+	}
+}
+
+// getTotalKeysTikv counts the keys of the cluster with raw scans,
+// reported under the first PD endpoint since the keys are not by node.
+func getTotalKeysTikv(lg *zap.Logger, endpoints []string) map[string]int64 {
+	rs := make(map[string]int64)
+	cli, err := tikv.New(endpoints)
+	if err != nil {
+		lg.Warn("failed to connect to PD", zap.Strings("endpoints", endpoints), zap.Error(err))
+		return rs
+	}
+	defer cli.Close()
+
+	var total int64
+	var start []byte
+	for {
+		keys, err := cli.Scan(context.Background(), start, tikvScanLimit)
+		if err != nil {
+			lg.Warn("failed to scan", zap.Strings("endpoints", endpoints), zap.Error(err))
+			break
+		}
+		total += int64(len(keys))
+		if len(keys) < tikvScanLimit {
+			break
+		}
+		// the next key after the last one
+		start = append(keys[len(keys)-1], 0)
+	}
+	rs[endpoints[0]] = total
+	return rs
+}
@@ -17,6 +17,9 @@ package dbtester
 import (
 	"errors"
 	"fmt"
+	"net"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/samuel/go-zookeeper/zk"
@@ -27,8 +30,24 @@ import (
 var (
 	zkCreateFlags = int32(0)
 	zkCreateACL   = zk.WorldACL(zk.PermAll)
+
+	// zkAuth is the 'digest' scheme credential to authenticate
+	// clients with, when znodes are created with non-open ACLs.
+	zkAuth []byte
 )
 
+// setZkDigestAuth makes all znodes to be created with the digest ACL
+// of the given 'user:password', and all clients to authenticate as the user.
+func setZkDigestAuth(auth string) error {
+	ss := strings.SplitN(auth, ":", 2)
+	if len(ss) != 2 || ss[0] == "" {
+		return fmt.Errorf("expected 'user:password' for Zookeeper digest auth, got %q", auth)
+	}
+	zkCreateACL = zk.DigestACL(zk.PermAll, ss[0], ss[1])
+	zkAuth = []byte(auth)
+	return nil
+}
+
 type zkOp struct {
 	key       string
 	value     []byte
@@ -40,34 +59,91 @@ func mustCreateConnsZk(endpoints []string, total int64) []*zk.Conn {
 	for i := range zks {
 		endpoint := endpoints[dialTotal%len(endpoints)]
 		dialTotal++
-		conn, _, err := zk.Connect([]string{endpoint}, time.Second)
+		dial := zk.Dialer(net.DialTimeout)
+		if clientTLS != nil {
+			dial = dialZkTLS
+		}
+		conn, _, err := zk.Connect([]string{endpoint}, time.Second, zk.WithDialer(dial))
 		if err != nil {
 			panic(err)
 		}
+		if zkAuth != nil {
+			if err = conn.AddAuth("digest", zkAuth); err != nil {
+				panic(err)
+			}
+		}
 		zks[i] = conn
 	}
 	return zks
 }
 
+// createZkParents creates the parent znodes of the path,
+// so that keys can be created under the namespace.
+func createZkParents(endpoints []string, fpath string) error {
+	conns := mustCreateConnsZk(endpoints, 1)
+	defer conns[0].Close()
+
+	ss := strings.Split(fpath, "/")
+	for i := 2; i < len(ss); i++ {
+		p := strings.Join(ss[:i], "/")
+		if _, err := conns[0].Create(p, nil, zkCreateFlags, zkCreateACL); err != nil && err != zk.ErrNodeExists {
+			return fmt.Errorf("%v while creating %q", err, p)
+		}
+	}
+	return nil
+}
+
 func newPutCreateZK(conn *zk.Conn) ReqHandler {
 	return func(ctx context.Context, req *request) error {
 		op := req.zkOp
 		_, err := conn.Create(op.key, op.value, zkCreateFlags, zkCreateACL)
+		if req.trace != nil {
+			req.trace.requestBytes = len(op.key) + len(op.value)
+			req.trace.member = conn.Server()
+		}
 		return err
 	}
 }
 
 func newPutOverwriteZK(conn *zk.Conn) ReqHandler {
 	// samekey
+	return func(ctx context.Context, req *request) error {
+		op := req.zkOp
+		stat, err := conn.Set(op.key, op.value, int32(-1))
+		if req.trace != nil {
+			req.trace.requestBytes = len(op.key) + len(op.value)
+			req.trace.member = conn.Server()
+			if stat != nil {
+				req.trace.revision = stat.Mzxid
+			}
+		}
+		return err
+	}
+}
+
+func newPutUpsertZK(conn *zk.Conn) ReqHandler {
 	return func(ctx context.Context, req *request) error {
 		op := req.zkOp
 		_, err := conn.Set(op.key, op.value, int32(-1))
+		if err == zk.ErrNoNode {
+			_, err = conn.Create(op.key, op.value, zkCreateFlags, zkCreateACL)
+			if err == zk.ErrNodeExists {
+				// created by another client in between
+				_, err = conn.Set(op.key, op.value, int32(-1))
+			}
+		}
 		return err
 	}
 }
 
 func newGetZK(conn *zk.Conn) ReqHandler {
 	return func(ctx context.Context, req *request) error {
+		if req.rangePrefix != "" {
+			return getRangeZK(conn, req)
+		}
+		if len(req.batch) > 0 {
+			return getBatchZK(conn, req)
+		}
 		errt := ""
 		if !req.zkOp.staleRead {
 			_, err := conn.Sync("/" + req.zkOp.key)
@@ -75,7 +151,18 @@ func newGetZK(conn *zk.Conn) ReqHandler {
 				errt += err.Error()
 			}
 		}
-		_, _, err := conn.Get("/" + req.zkOp.key)
+		data, stat, err := conn.Get("/" + req.zkOp.key)
+		if req.trace != nil {
+			req.trace.requestBytes = len(req.zkOp.key) + 1
+			req.trace.responseBytes = len(data)
+			req.trace.member = conn.Server()
+			if stat != nil {
+				req.trace.revision = stat.Mzxid
+			}
+		}
+		if err == zk.ErrNoNode && errt == "" {
+			return errEmptyResponse
+		}
 		if err != nil {
 			if errt != "" {
 				errt += "; "
@@ -89,6 +176,125 @@ func newGetZK(conn *zk.Conn) ReqHandler {
 	}
 }
 
+// getBatchZK reads the keys of the batch with concurrent gets,
+// pipelined on the connection, after one sync if not stale.
+func getBatchZK(conn *zk.Conn, req *request) error {
+	if !req.zkOp.staleRead {
+		if _, err := conn.Sync("/" + req.batch[0]); err != nil {
+			return err
+		}
+	}
+	sizes, errs := make([]int, len(req.batch)), make([]error, len(req.batch))
+	var wg sync.WaitGroup
+	wg.Add(len(req.batch))
+	for i, k := range req.batch {
+		go func(i int, k string) {
+			defer wg.Done()
+			data, _, err := conn.Get("/" + k)
+			sizes[i], errs[i] = len(data), err
+		}(i, k)
+	}
+	wg.Wait()
+
+	if req.trace != nil {
+		req.trace.requestBytes = batchBytes(req.batch) + len(req.batch)
+		for _, n := range sizes {
+			req.trace.responseBytes += n
+		}
+		req.trace.member = conn.Server()
+	}
+	empty := false
+	for i, err := range errs {
+		switch err {
+		case nil:
+		case zk.ErrNoNode:
+			empty = true
+		default:
+			return fmt.Errorf("%q while getting %q", err.Error(), "/"+req.batch[i])
+		}
+	}
+	if empty {
+		return errEmptyResponse
+	}
+	return nil
+}
+
+// getRangeZK reads the keys of the prefix of the range read: Zookeeper
+// has no range, so it lists the children of the parent znode, and gets
+// the children of the prefix with concurrent gets, pipelined on the
+// connection, after one sync if not stale.
+func getRangeZK(conn *zk.Conn, req *request) error {
+	parent, name := zkRangeParent(req.rangePrefix)
+	if !req.zkOp.staleRead {
+		if _, err := conn.Sync(parent); err != nil {
+			return err
+		}
+	}
+	children, stat, err := conn.Children(parent)
+	if err != nil {
+		return fmt.Errorf("%q while listing %q", err.Error(), parent)
+	}
+	var keys []string
+	for _, c := range children {
+		if strings.HasPrefix(c, name) {
+			keys = append(keys, strings.TrimSuffix(parent, "/")+"/"+c)
+		}
+	}
+
+	sizes, errs := make([]int, len(keys)), make([]error, len(keys))
+	var wg sync.WaitGroup
+	wg.Add(len(keys))
+	for i, k := range keys {
+		go func(i int, k string) {
+			defer wg.Done()
+			data, _, err := conn.Get(k)
+			sizes[i], errs[i] = len(data), err
+		}(i, k)
+	}
+	wg.Wait()
+
+	if req.trace != nil {
+		req.trace.requestBytes = len(parent) + len(keys) + batchBytes(keys)
+		req.trace.responseBytes = batchBytes(children)
+		for _, n := range sizes {
+			req.trace.responseBytes += n
+		}
+		req.trace.member = conn.Server()
+		if stat != nil {
+			req.trace.revision = stat.Pzxid
+		}
+	}
+	found := 0
+	for i, err := range errs {
+		switch err {
+		case nil:
+			found++
+		case zk.ErrNoNode:
+			// deleted since listed
+		default:
+			return fmt.Errorf("%q while getting %q", err.Error(), keys[i])
+		}
+	}
+	if found < req.rangeKeys {
+		return errEmptyResponse
+	}
+	return nil
+}
+
+func newDeleteZK(conn *zk.Conn) ReqHandler {
+	return func(ctx context.Context, req *request) error {
+		err := conn.Delete("/"+req.zkOp.key, -1)
+		if req.trace != nil {
+			req.trace.requestBytes = len(req.zkOp.key) + 1
+			req.trace.member = conn.Server()
+		}
+		if err == zk.ErrNoNode {
+			return errEmptyResponse
+		}
+		return err
+	}
+}
+
 func getTotalKeysZk(lg *zap.Logger, endpoints []string) map[string]int64 {
 	rs := make(map[string]int64)
 	stats, ok := zk.FLWSrvr(endpoints, 5*time.Second)
@@ -104,3 +310,100 @@ func getTotalKeysZk(lg *zap.Logger, endpoints []string) map[string]int64 {
 	}
 	return rs
 }
+
+// newTxnZK reads the znodes, and sets them with Multi
+// only if none of their versions has changed since the read.
+func newTxnZK(conn *zk.Conn) txnFunc {
+	return func(ctx context.Context, keys []string, value []byte, tr *requestTrace) (bool, error) {
+		ops := make([]interface{}, len(keys))
+		for i, k := range keys {
+			_, stat, err := conn.Get(k)
+			if err != nil {
+				return false, err
+			}
+			ops[i] = &zk.SetDataRequest{Path: k, Data: value, Version: stat.Version}
+		}
+		mr, err := conn.Multi(ops...)
+		for _, r := range mr {
+			if r.Error == zk.ErrBadVersion {
+				return false, nil
+			}
+		}
+		if err == zk.ErrBadVersion {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		if tr != nil && len(mr) > 0 && mr[0].Stat != nil {
+			tr.revision = mr[0].Stat.Mzxid
+		}
+		return true, nil
+	}
+}
+
+// newBankZK moves the balances of 'bank' with Multi
+// setting both znodes of the versions read.
+func newBankZK(conn *zk.Conn) *bankBackend {
+	balances := func(keys []string) ([]int64, []int32, error) {
+		bs, vers := make([]int64, len(keys)), make([]int32, len(keys))
+		for i, k := range keys {
+			v, stat, err := conn.Get(k)
+			if err != nil {
+				return nil, nil, err
+			}
+			if bs[i], err = parseBalance(k, v); err != nil {
+				return nil, nil, err
+			}
+			vers[i] = stat.Version
+		}
+		return bs, vers, nil
+	}
+	return &bankBackend{
+		open: func(ctx context.Context, keys []string, balance int64) error {
+			for _, k := range keys {
+				v := []byte(formatBalance(balance))
+				_, err := conn.Set(k, v, int32(-1))
+				if err == zk.ErrNoNode {
+					_, err = conn.Create(k, v, zkCreateFlags, zkCreateACL)
+				}
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		balances: func(ctx context.Context, keys []string) ([]int64, error) {
+			bs, _, err := balances(keys)
+			return bs, err
+		},
+		transfer: func(ctx context.Context, from, to string, amount int64, tr *requestTrace) (bool, error) {
+			bs, vers, err := balances([]string{from, to})
+			if err != nil {
+				return false, err
+			}
+			if amount > bs[0] {
+				amount = bs[0]
+			}
+			mr, err := conn.Multi(
+				&zk.SetDataRequest{Path: from, Data: []byte(formatBalance(bs[0] - amount)), Version: vers[0]},
+				&zk.SetDataRequest{Path: to, Data: []byte(formatBalance(bs[1] + amount)), Version: vers[1]},
+			)
+			for _, r := range mr {
+				if r.Error == zk.ErrBadVersion {
+					return false, nil
+				}
+			}
+			if err == zk.ErrBadVersion {
+				return false, nil
+			}
+			if err != nil {
+				return false, err
+			}
+			if tr != nil && len(mr) > 0 && mr[0].Stat != nil {
+				tr.revision = mr[0].Stat.Mzxid
+			}
+			return true, nil
+		},
+	}
+}
@@ -0,0 +1,221 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/coreos/dbtester/dbtesterpb"
+
+	"github.com/olekukonko/tablewriter"
+	"go.uber.org/zap"
+)
+
+// clusterNames returns the names of 'ClusterEndpoints', sorted.
+func (cfg *Config) clusterNames() []string {
+	names := make([]string, 0, len(cfg.ClusterEndpoints))
+	for name := range cfg.ClusterEndpoints {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// clusterPath returns the path of the cluster, with the
+// cluster name before the extension, and before the
+// extension of the compression if compressed.
+func clusterPath(fpath, name string) string {
+	if fpath == "" {
+		return ""
+	}
+	ext := compressedExt(fpath)
+	ext = filepath.Ext(strings.TrimSuffix(fpath, ext)) + ext
+	return strings.TrimSuffix(fpath, ext) + "-" + name + ext
+}
+
+// clusterResultPaths are the paths of the results of the stress,
+// which each cluster saves separately.
+func (cfg *Config) clusterResultPaths() []*string {
+	ci := &cfg.ConfigClientMachineInitial
+	return []*string{
+		&ci.ClientLatencyThroughputTimeseriesPath,
+		&ci.ClientLatencyDistributionAllPath,
+		&ci.ClientLatencyDistributionPercentilePath,
+		&ci.ClientLatencyDistributionSummaryPath,
+		&ci.ClientLatencyByKeyNumberPath,
+		&ci.ClientRequestTraceSamplePath,
+		&ci.ClientEndpointTrafficPath,
+		&ci.ClientRollingRestartPath,
+		&ci.ClientWorkloadSummaryPath,
+		&ci.ClientConvergencePath,
+		&ci.ClientConvergenceSummaryPath,
+		&ci.ClientChaosPath,
+		&ci.ClientServerLatencyCorrelationPath,
+		&ci.ClientIdentityLeasePath,
+		&ci.ClientSchedulePath,
+		&ci.ClientInterferencePath,
+		&ci.ClientLearnerReadsPath,
+		&ci.ClientTLSHandshakePath,
+		&ci.ClientOpenMetricsDir,
+		&ci.ClientSizeHistogramPath,
+		&ci.ClientSpikeRecoveryPath,
+		&ci.ClientLatencyHgrmPath,
+		&ci.ClientMemberBreakdownPath,
+		&ci.ClientTermChangePath,
+		&ci.ClientResourceUsagePath,
+		&ci.ClientZoneLatencyPath,
+		&ci.ClientSocketStatsPath,
+		&ci.ClientMaintenancePath,
+		&cfg.SaveKeysPath,
+		&cfg.OutputFile,
+	}
+}
+
+// forCluster returns a copy of the configuration that stresses the
+// cluster of the endpoints from its own clients, and saves its results
+// to the configured paths with the cluster name.
+func (cfg *Config) forCluster(databaseID, name string, endpoints []string) (*Config, error) {
+	gcfg, ok := cfg.DatabaseIDToConfigClientMachineAgentControl[databaseID]
+	if !ok {
+		return nil, fmt.Errorf("%q does not exist", databaseID)
+	}
+	gcfg.DatabaseEndpoints = endpoints
+
+	copied := *cfg
+	copied.lg = cfg.lg.With(zap.String("cluster", name))
+	copied.DatabaseIDToConfigClientMachineAgentControl = map[string]dbtesterpb.ConfigClientMachineAgentControl{databaseID: gcfg}
+	for _, p := range copied.clusterResultPaths() {
+		*p = clusterPath(*p, name)
+	}
+	return &copied, nil
+}
+
+// StressClusters stresses the clusters of 'ClusterEndpoints' at the same
+// time, with the same workload from separate clients, so that the clusters
+// are compared under the same conditions of the client machine.
+func (cfg *Config) StressClusters(databaseID string) error {
+	names := cfg.clusterNames()
+	if len(names) < 2 {
+		return fmt.Errorf("at least 2 clusters are required (got %d)", len(names))
+	}
+	ccfgs := make([]*Config, len(names))
+	for i, name := range names {
+		var err error
+		if ccfgs[i], err = cfg.forCluster(databaseID, name, cfg.ClusterEndpoints[name]); err != nil {
+			return err
+		}
+		cfg.lg.Info("stressing cluster", zap.String("cluster", name), zap.Strings("endpoints", cfg.ClusterEndpoints[name]))
+	}
+
+	errc := make(chan error, len(names))
+	for i := range ccfgs {
+		go func(i int) {
+			err := ccfgs[i].Stress(databaseID)
+			if err != nil {
+				err = fmt.Errorf("cluster %q: %v", names[i], err)
+			}
+			errc <- err
+		}(i)
+	}
+	var errs []string
+	for range ccfgs {
+		if err := <-errc; err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%s", strings.Join(errs, "; "))
+	}
+
+	summaries := make([]string, len(ccfgs))
+	for i, c := range ccfgs {
+		summaries[i] = c.ConfigClientMachineInitial.ClientLatencyDistributionSummaryPath
+	}
+	rows, err := compareClusterSummaries(names, summaries)
+	if err != nil {
+		cfg.lg.Warn("failed to compare clusters", zap.Error(err))
+		return nil
+	}
+	tw := tablewriter.NewWriter(os.Stdout)
+	tw.SetHeader(rows[0])
+	for _, row := range rows[1:] {
+		tw.Append(row)
+	}
+	tw.SetAutoFormatHeaders(false)
+	tw.Render()
+	return nil
+}
+
+// ClusterResultPaths returns the result paths of the clusters
+// of 'ClusterEndpoints', that exist.
+func (cfg *Config) ClusterResultPaths() (paths []string) {
+	for _, name := range cfg.clusterNames() {
+		for _, p := range cfg.clusterResultPaths() {
+			// keys are not results, and directories are not uploaded
+			if p == &cfg.SaveKeysPath || p == &cfg.ConfigClientMachineInitial.ClientOpenMetricsDir {
+				continue
+			}
+			if fpath := clusterPath(*p, name); fpath != "" && exist(fpath) {
+				paths = append(paths, fpath)
+			}
+		}
+	}
+	return paths
+}
+
+// compareClusterSummaries returns the rows of the latency distribution
+// summaries of the clusters, of a metric and its value per row, side
+// by side with the header first.
+func compareClusterSummaries(names, summaryPaths []string) ([][]string, error) {
+	rows := [][]string{append([]string{"METRIC"}, names...)}
+	var metrics []string
+	values := make([]map[string]string, len(names))
+	for i, fpath := range summaryPaths {
+		f, err := openFile(fpath)
+		if err != nil {
+			return nil, err
+		}
+		rd := csv.NewReader(f)
+		rd.FieldsPerRecord = -1
+		recs, err := rd.ReadAll()
+		f.Close()
+		if err != nil {
+			return nil, err
+		}
+		values[i] = make(map[string]string)
+		for _, rec := range recs {
+			if len(rec) < 2 {
+				continue
+			}
+			if i == 0 {
+				metrics = append(metrics, rec[0])
+			}
+			values[i][rec[0]] = rec[1]
+		}
+	}
+	for _, m := range metrics {
+		row := []string{m}
+		for i := range names {
+			row = append(row, values[i][m])
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
@@ -0,0 +1,84 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/coreos/dbtester/dbtesterpb"
+
+	"go.uber.org/zap"
+)
+
+func TestForCluster(t *testing.T) {
+	cfg := &Config{
+		lg:           zap.NewNop(),
+		SaveKeysPath: "/tmp/keys.txt",
+		DatabaseIDToConfigClientMachineAgentControl: map[string]dbtesterpb.ConfigClientMachineAgentControl{
+			"mock": {DatabaseID: "mock", DatabaseEndpoints: []string{"x"}},
+		},
+	}
+	cfg.ClientLatencyDistributionSummaryPath = "/tmp/summary.csv"
+	cfg.ClientOpenMetricsDir = "/tmp/openmetrics"
+
+	c, err := cfg.forCluster("mock", "b", []string{"b1", "b2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if eps := c.DatabaseIDToConfigClientMachineAgentControl["mock"].DatabaseEndpoints; !reflect.DeepEqual(eps, []string{"b1", "b2"}) {
+		t.Fatalf("unexpected endpoints %q", eps)
+	}
+	if c.ClientLatencyDistributionSummaryPath != "/tmp/summary-b.csv" || c.ClientOpenMetricsDir != "/tmp/openmetrics-b" || c.SaveKeysPath != "/tmp/keys-b.txt" {
+		t.Fatalf("unexpected paths %q, %q, %q", c.ClientLatencyDistributionSummaryPath, c.ClientOpenMetricsDir, c.SaveKeysPath)
+	}
+	if c.ClientChaosPath != "" {
+		t.Fatalf("expected empty path, got %q", c.ClientChaosPath)
+	}
+	if p := clusterPath("/tmp/summary.csv.gz", "b"); p != "/tmp/summary-b.csv.gz" {
+		t.Fatalf("unexpected compressed path %q", p)
+	}
+	// the original is unchanged
+	if cfg.ClientLatencyDistributionSummaryPath != "/tmp/summary.csv" || cfg.DatabaseIDToConfigClientMachineAgentControl["mock"].DatabaseEndpoints[0] != "x" {
+		t.Fatalf("original configuration is changed")
+	}
+}
+
+func TestCompareClusterSummaries(t *testing.T) {
+	dir, err := ioutil.TempDir("", "clusters")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	a, b := filepath.Join(dir, "a.csv"), filepath.Join(dir, "b.csv")
+	ioutil.WriteFile(a, []byte("TOTAL-SECONDS,1.0000\nREQUESTS-PER-SECOND,100.0000\n"), 0644)
+	ioutil.WriteFile(b, []byte("TOTAL-SECONDS,2.0000\nREQUESTS-PER-SECOND,50.0000\n"), 0644)
+	rows, err := compareClusterSummaries([]string{"a", "b"}, []string{a, b})
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := [][]string{
+		{"METRIC", "a", "b"},
+		{"TOTAL-SECONDS", "1.0000", "2.0000"},
+		{"REQUESTS-PER-SECOND", "100.0000", "50.0000"},
+	}
+	if !reflect.DeepEqual(rows, exp) {
+		t.Fatalf("expected %q, got %q", exp, rows)
+	}
+}
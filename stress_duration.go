@@ -0,0 +1,111 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/coreos/dbtester/dbtesterpb"
+	"golang.org/x/net/context"
+)
+
+// runDuration returns the configured duration of the run,
+// or 0 if the run is of 'request_number' requests.
+func runDuration(gcfg dbtesterpb.ConfigClientMachineAgentControl) time.Duration {
+	return time.Duration(gcfg.ConfigClientMachineBenchmarkOptions.DurationSeconds) * time.Second
+}
+
+// expectedRequests returns the number of requests of the run, estimated
+// from the rate limit for duration-based runs, or 0 if unknown.
+func expectedRequests(gcfg dbtesterpb.ConfigClientMachineAgentControl) int64 {
+	opts := gcfg.ConfigClientMachineBenchmarkOptions
+	if opts.DurationSeconds <= 0 {
+		return opts.RequestNumber
+	}
+	return opts.RateLimitRequestsPerSecond * opts.DurationSeconds
+}
+
+// validateDuration returns an error if the benchmark options
+// cannot run for 'duration_seconds'.
+func validateDuration(gcfg dbtesterpb.ConfigClientMachineAgentControl) error {
+	opts := gcfg.ConfigClientMachineBenchmarkOptions
+	switch {
+	case opts.DurationSeconds < 0:
+		return fmt.Errorf("'duration_seconds' must not be negative (got %d)", opts.DurationSeconds)
+	case opts.DurationSeconds == 0:
+		return nil
+	case len(opts.ConnectionClientNumbers) > 0:
+		return fmt.Errorf("'duration_seconds' is not supported with 'connection_client_numbers'")
+	case opts.ReadPercentEnd > 0:
+		return fmt.Errorf("'duration_seconds' is not supported with 'read_percent_end', which drifts over 'request_number'")
+	}
+
+	// writes of each workload are on the keys after the previous
+	// one's 'request_number', that a duration would overrun
+	writeN := 0
+	for _, wl := range opts.ConfigClientMachineWorkloads {
+		if wl.Type == "write" {
+			writeN++
+		}
+	}
+	if writeN > 1 {
+		return fmt.Errorf("'duration_seconds' supports at most 1 'write' workload (got %d)", writeN)
+	}
+	return nil
+}
+
+// requestLimit bounds the requests that a generator issues: the first
+// 'request_number' requests, or the requests until 'duration_seconds'
+// after the first one, and none once the run is cancelled.
+type requestLimit struct {
+	ctx      context.Context
+	total    int64
+	duration time.Duration
+	end      time.Time
+}
+
+func newRequestLimit(ctx context.Context, gcfg dbtesterpb.ConfigClientMachineAgentControl) *requestLimit {
+	return &requestLimit{
+		ctx:      ctx,
+		total:    gcfg.ConfigClientMachineBenchmarkOptions.RequestNumber,
+		duration: runDuration(gcfg),
+	}
+}
+
+// more returns true if the i-th request is to be issued.
+func (l *requestLimit) more(i int64) bool {
+	if l.ctx.Err() != nil {
+		return false
+	}
+	if l.duration <= 0 {
+		return i < l.total
+	}
+	if i == 0 {
+		l.end = time.Now().Add(l.duration)
+	}
+	return time.Now().Before(l.end)
+}
+
+// issue sends the request to the clients, and returns false if the run
+// is cancelled first, when the clients no longer receive.
+func (l *requestLimit) issue(inflightReqs chan<- request, req request) bool {
+	select {
+	case inflightReqs <- req:
+		return true
+	case <-l.ctx.Done():
+		return false
+	}
+}
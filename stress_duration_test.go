@@ -0,0 +1,90 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"testing"
+	"time"
+
+	"github.com/coreos/dbtester/dbtesterpb"
+	"golang.org/x/net/context"
+)
+
+func TestRequestLimit(t *testing.T) {
+	gcfg := dbtesterpb.ConfigClientMachineAgentControl{
+		DatabaseID:                          "mock",
+		ConfigClientMachineBenchmarkOptions: &dbtesterpb.ConfigClientMachineBenchmarkOptions{RequestNumber: 3},
+	}
+	n := int64(0)
+	for limit := newRequestLimit(context.Background(), gcfg); limit.more(n); n++ {
+	}
+	if n != 3 {
+		t.Fatalf("expected 3 requests, got %d", n)
+	}
+
+	limit := &requestLimit{ctx: context.Background(), duration: 50 * time.Millisecond}
+	start := time.Now()
+	for n = 0; limit.more(n); n++ {
+		time.Sleep(time.Millisecond)
+	}
+	if took := time.Since(start); took < 50*time.Millisecond || n == 0 {
+		t.Fatalf("expected requests for 50ms, got %d in %v", n, took)
+	}
+}
+
+func TestGenerateReadsDuration(t *testing.T) {
+	gcfg := dbtesterpb.ConfigClientMachineAgentControl{
+		DatabaseID: "mock",
+		ConfigClientMachineBenchmarkOptions: &dbtesterpb.ConfigClientMachineBenchmarkOptions{
+			DurationSeconds:            1,
+			RateLimitRequestsPerSecond: 100,
+		},
+	}
+	if err := validateDuration(gcfg); err != nil {
+		t.Fatal(err)
+	}
+	if n := expectedRequests(gcfg); n != 100 {
+		t.Fatalf("expected 100 requests, got %d", n)
+	}
+	ch := make(chan request)
+	go generateReads(context.Background(), gcfg, "foo", nil, ch)
+	n := 0
+	for range ch {
+		n++
+	}
+	// 'request_number' is 0, and the rate limit allows a burst of 100
+	if n < 100 || n > 201 {
+		t.Fatalf("expected about 200 requests in 1 second, got %d", n)
+	}
+}
+
+func TestValidateDuration(t *testing.T) {
+	opts := &dbtesterpb.ConfigClientMachineBenchmarkOptions{
+		DurationSeconds: 10,
+		ConfigClientMachineWorkloads: []*dbtesterpb.ConfigClientMachineWorkload{
+			{Name: "a", Type: "write"},
+			{Name: "b", Type: "write"},
+		},
+	}
+	gcfg := dbtesterpb.ConfigClientMachineAgentControl{ConfigClientMachineBenchmarkOptions: opts}
+	if err := validateDuration(gcfg); err == nil {
+		t.Fatal("expected error of 2 'write' workloads")
+	}
+	opts.ConfigClientMachineWorkloads = opts.ConfigClientMachineWorkloads[:1]
+	opts.ReadPercentEnd = 50
+	if err := validateDuration(gcfg); err == nil {
+		t.Fatal("expected error of 'read_percent_end'")
+	}
+}
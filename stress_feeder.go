@@ -0,0 +1,75 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"time"
+
+	"github.com/coreos/dbtester/dbtesterpb"
+
+	"golang.org/x/net/context"
+	"golang.org/x/time/rate"
+)
+
+// feeder paces the request generation. Requests are handed to the
+// clients over an unbuffered channel, so that the generation follows
+// the consumption of the clients, and the scheduled time of each
+// request is the time it is handed over (or due, with 'open_loop').
+type feeder struct {
+	rateLimiter *rate.Limiter
+
+	// openLoop schedules requests at fixed intervals from the start,
+	// even if the clients fall behind the schedule.
+	openLoop bool
+	interval time.Duration
+	start    time.Time
+	n        int64
+}
+
+func newFeeder(gcfg dbtesterpb.ConfigClientMachineAgentControl) *feeder {
+	f := &feeder{}
+	rps := gcfg.ConfigClientMachineBenchmarkOptions.RateLimitRequestsPerSecond
+	if rps <= 0 {
+		return f
+	}
+	if gcfg.ConfigClientMachineBenchmarkOptions.OpenLoop {
+		f.openLoop = true
+		f.interval = time.Second / time.Duration(rps)
+		return f
+	}
+	f.rateLimiter = rate.NewLimiter(rate.Limit(rps), int(rps))
+	return f
+}
+
+// next blocks until the next request is due, and returns its scheduled time.
+func (f *feeder) next() time.Time {
+	switch {
+	case f.openLoop:
+		if f.n == 0 {
+			f.start = time.Now()
+		}
+		due := f.start.Add(time.Duration(f.n) * f.interval)
+		f.n++
+		if d := time.Until(due); d > 0 {
+			time.Sleep(d)
+		}
+		// already late if clients are behind
+		return due
+
+	case f.rateLimiter != nil:
+		f.rateLimiter.Wait(context.TODO())
+	}
+	return time.Now()
+}
@@ -0,0 +1,40 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"testing"
+	"time"
+
+	"github.com/coreos/dbtester/dbtesterpb"
+)
+
+func TestFeederOpenLoop(t *testing.T) {
+	gcfg := dbtesterpb.ConfigClientMachineAgentControl{
+		ConfigClientMachineBenchmarkOptions: &dbtesterpb.ConfigClientMachineBenchmarkOptions{
+			RateLimitRequestsPerSecond: 1000,
+			OpenLoop:                   true,
+		},
+	}
+	fd := newFeeder(gcfg)
+	first := fd.next()
+	// fall behind the schedule
+	time.Sleep(10 * time.Millisecond)
+	for i := 1; i < 5; i++ {
+		if d := fd.next().Sub(first); d != time.Duration(i)*time.Millisecond {
+			t.Fatalf("#%d: expected scheduled at %v, got %v", i, time.Duration(i)*time.Millisecond, d)
+		}
+	}
+}
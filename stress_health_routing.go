@@ -0,0 +1,256 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/coreos/dbtester/dbtesterpb"
+
+	"github.com/gyuho/dataframe"
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+)
+
+// errBlackout is returned for requests to the blacked-out endpoint.
+var errBlackout = errors.New("endpoint blackout (simulated)")
+
+type endpointState struct {
+	consecutiveErrs int64
+	unhealthy       bool
+	// probeAt is the time to send the next probe request, if unhealthy
+	probeAt time.Time
+}
+
+type endpointTraffic struct {
+	requests  int64
+	errs      int64
+	unhealthy bool
+}
+
+// endpointRouter routes each request to a healthy endpoint,
+// and records how the traffic shifted between endpoints.
+type endpointRouter struct {
+	cfg       *dbtesterpb.ConfigClientMachineHealthRouting
+	endpoints []string
+	started   time.Time
+
+	mu     sync.Mutex
+	next   int
+	states []endpointState
+	// traffic is the per-second traffic of each endpoint
+	traffic map[int64][]endpointTraffic
+}
+
+func newEndpointRouter(cfg *dbtesterpb.ConfigClientMachineHealthRouting, endpoints []string) *endpointRouter {
+	return &endpointRouter{
+		cfg:       cfg,
+		endpoints: endpoints,
+		started:   time.Now(),
+		states:    make([]endpointState, len(endpoints)),
+		traffic:   make(map[int64][]endpointTraffic),
+	}
+}
+
+// pick returns the next healthy endpoint in round-robin order, or
+// an unhealthy endpoint that is due for a probe. If all endpoints are
+// unhealthy, it keeps round-robin over all endpoints.
+func (r *endpointRouter) pick(now time.Time) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	n := len(r.endpoints)
+	start := r.next
+	r.next = (r.next + 1) % n
+	for i := 0; i < n; i++ {
+		idx := (start + i) % n
+		st := &r.states[idx]
+		if !st.unhealthy {
+			return idx
+		}
+		if !now.Before(st.probeAt) {
+			st.probeAt = now.Add(r.probeInterval())
+			return idx
+		}
+	}
+	return start
+}
+
+func (r *endpointRouter) probeInterval() time.Duration {
+	if r.cfg.ProbeIntervalMilliseconds <= 0 {
+		return time.Second
+	}
+	return time.Duration(r.cfg.ProbeIntervalMilliseconds) * time.Millisecond
+}
+
+func (r *endpointRouter) blackedOut(idx int, now time.Time) bool {
+	if r.cfg.BlackoutDurationSeconds <= 0 || int64(idx) != r.cfg.BlackoutEndpointIndex {
+		return false
+	}
+	start := r.started.Add(time.Duration(r.cfg.BlackoutStartSecond) * time.Second)
+	end := start.Add(time.Duration(r.cfg.BlackoutDurationSeconds) * time.Second)
+	return !now.Before(start) && now.Before(end)
+}
+
+func (r *endpointRouter) record(idx int, now time.Time, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	st := &r.states[idx]
+	if err != nil {
+		st.consecutiveErrs++
+		if r.cfg.ErrorThreshold > 0 && st.consecutiveErrs >= r.cfg.ErrorThreshold && !st.unhealthy {
+			st.unhealthy = true
+			st.probeAt = now.Add(r.probeInterval())
+		}
+	} else {
+		st.consecutiveErrs = 0
+		st.unhealthy = false
+	}
+
+	sec := now.Unix()
+	ts, ok := r.traffic[sec]
+	if !ok {
+		ts = make([]endpointTraffic, len(r.endpoints))
+		r.traffic[sec] = ts
+	}
+	ts[idx].requests++
+	if err != nil {
+		ts[idx].errs++
+	}
+	ts[idx].unhealthy = st.unhealthy
+}
+
+func (r *endpointRouter) handler(perEndpoint [][]ReqHandler, client int) ReqHandler {
+	return func(ctx context.Context, req *request) error {
+		now := time.Now()
+		idx := r.pick(now)
+
+		var err error
+		if r.blackedOut(idx, now) {
+			err = errBlackout
+		} else {
+			err = perEndpoint[idx][client](ctx, req)
+		}
+
+		rerr := err
+		if rerr == errEmptyResponse {
+			rerr = nil
+		}
+		r.record(idx, time.Now(), rerr)
+		if rerr != nil {
+			return newRequestError("", "", r.endpoints[idx], rerr)
+		}
+		return err
+	}
+}
+
+// newHealthRoutedHandlers creates the handlers for each endpoint
+// with the given function, and routes requests by endpoint health.
+// Each endpoint has its own connections for all clients.
+func (cfg *Config) newHealthRoutedHandlers(
+	gcfg dbtesterpb.ConfigClientMachineAgentControl,
+	newHandlers func(dbtesterpb.ConfigClientMachineAgentControl) ([]ReqHandler, func()),
+) ([]ReqHandler, func(), error) {
+	eps := gcfg.DatabaseEndpoints
+	if len(eps) < 2 {
+		return nil, nil, fmt.Errorf("'health_routing' requires at least 2 endpoints (got %v)", eps)
+	}
+
+	perEndpoint := make([][]ReqHandler, len(eps))
+	dones := make([]func(), len(eps))
+	for i := range eps {
+		copied := gcfg
+		copied.DatabaseEndpoints = []string{eps[i]}
+		perEndpoint[i], dones[i] = newHandlers(copied)
+	}
+
+	r := newEndpointRouter(gcfg.ConfigClientMachineBenchmarkOptions.ConfigClientMachineHealthRouting, eps)
+	cfg.endpointRouter = r
+
+	rhs := make([]ReqHandler, gcfg.ConfigClientMachineBenchmarkOptions.ClientNumber)
+	for i := range rhs {
+		rhs[i] = r.handler(perEndpoint, i)
+	}
+	done := func() {
+		for _, d := range dones {
+			if d != nil {
+				d()
+			}
+		}
+	}
+	cfg.lg.Info("routing requests by endpoint health", zap.Strings("endpoints", eps))
+	return rhs, done, nil
+}
+
+// saveEndpointTraffic saves the per-second requests, errors,
+// and health of each endpoint, to show how the traffic shifted.
+func (cfg *Config) saveEndpointTraffic() {
+	r := cfg.endpointRouter
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	totals := make([]endpointTraffic, len(r.endpoints))
+	secs := make([]int64, 0, len(r.traffic))
+	for sec, ts := range r.traffic {
+		secs = append(secs, sec)
+		for i := range ts {
+			totals[i].requests += ts[i].requests
+			totals[i].errs += ts[i].errs
+		}
+	}
+	for i, ep := range r.endpoints {
+		cfg.lg.Sugar().Infof("endpoint traffic [endpoint: %q | requests: %d | errors: %d]", ep, totals[i].requests, totals[i].errs)
+	}
+
+	fpath := cfg.ConfigClientMachineInitial.ClientEndpointTrafficPath
+	if fpath == "" {
+		cfg.lg.Warn("'client_endpoint_traffic_path' is not set; skipping endpoint traffic")
+		return
+	}
+	sort.Slice(secs, func(i, j int) bool { return secs[i] < secs[j] })
+
+	c1 := dataframe.NewColumn("UNIX-SECOND")
+	c2 := dataframe.NewColumn("ENDPOINT")
+	c3 := dataframe.NewColumn("REQUESTS")
+	c4 := dataframe.NewColumn("ERRORS")
+	c5 := dataframe.NewColumn("HEALTHY")
+	for _, sec := range secs {
+		for i, tr := range r.traffic[sec] {
+			c1.PushBack(dataframe.NewStringValue(sec))
+			c2.PushBack(dataframe.NewStringValue(r.endpoints[i]))
+			c3.PushBack(dataframe.NewStringValue(tr.requests))
+			c4.PushBack(dataframe.NewStringValue(tr.errs))
+			c5.PushBack(dataframe.NewStringValue(!tr.unhealthy))
+		}
+	}
+	fr := dataframe.New()
+	for _, col := range []dataframe.Column{c1, c2, c3, c4, c5} {
+		if err := fr.AddColumn(col); err != nil {
+			panic(err)
+		}
+	}
+	if err := saveCSV(fr, fpath); err != nil {
+		panic(err)
+	}
+	cfg.lg.Info("saved endpoint traffic", zap.String("path", fpath))
+}
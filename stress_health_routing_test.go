@@ -0,0 +1,72 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"testing"
+	"time"
+
+	"github.com/coreos/dbtester/dbtesterpb"
+
+	"golang.org/x/net/context"
+)
+
+func TestEndpointRouter(t *testing.T) {
+	r := newEndpointRouter(&dbtesterpb.ConfigClientMachineHealthRouting{
+		ErrorThreshold:            2,
+		ProbeIntervalMilliseconds: 1000,
+		BlackoutEndpointIndex:     1,
+		BlackoutDurationSeconds:   10,
+	}, []string{"a", "b", "c"})
+
+	var sent [3]int
+	perEndpoint := make([][]ReqHandler, 3)
+	for i := range perEndpoint {
+		idx := i
+		perEndpoint[i] = []ReqHandler{func(context.Context, *request) error {
+			sent[idx]++
+			return nil
+		}}
+	}
+	h := r.handler(perEndpoint, 0)
+
+	var errs int
+	for i := 0; i < 30; i++ {
+		if re, ok := h(context.Background(), &request{}).(*RequestError); ok && re.Err == errBlackout && re.Endpoint == "b" {
+			errs++
+		}
+	}
+	// blacked-out endpoint is routed out after 2 errors,
+	// and not probed again within the probe interval
+	if errs != 2 {
+		t.Fatalf("expected 2 blackout errors, got %d", errs)
+	}
+	if sent[1] != 0 || sent[0]+sent[2] != 28 {
+		t.Fatalf("unexpected traffic %v", sent)
+	}
+	if !r.states[1].unhealthy {
+		t.Fatal("expected endpoint 1 unhealthy")
+	}
+
+	// probe after the interval
+	r.states[1].probeAt = time.Now().Add(-time.Second)
+	r.cfg.BlackoutDurationSeconds = 0
+	for i := 0; i < 3; i++ {
+		h(context.Background(), &request{})
+	}
+	if r.states[1].unhealthy || sent[1] != 1 {
+		t.Fatalf("expected endpoint 1 recovered with 1 request, got %v", sent)
+	}
+}
@@ -0,0 +1,155 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/coreos/dbtester/dbtesterpb"
+
+	"golang.org/x/net/context"
+)
+
+// hedgeStats counts hedged reads. The primary reads are never
+// cancelled, so that their latencies show the latency without hedging.
+type hedgeStats struct {
+	after time.Duration
+
+	// hedged is the number of reads that issued a duplicate read.
+	hedged int64
+	// won is the number of reads that the duplicate read returned first.
+	won int64
+
+	mu         sync.Mutex
+	primaryLat []float64
+}
+
+func (hs *hedgeStats) addPrimary(took time.Duration) {
+	hs.mu.Lock()
+	hs.primaryLat = append(hs.primaryLat, took.Seconds())
+	hs.mu.Unlock()
+}
+
+func (hs *hedgeStats) winRate() float64 {
+	if hs.hedged == 0 {
+		return 0
+	}
+	return 100 * float64(hs.won) / float64(hs.hedged)
+}
+
+// primaryPercentile returns the percentile of primary read latencies in seconds.
+func (hs *hedgeStats) primaryPercentile(pct float64) float64 {
+	hs.mu.Lock()
+	defer hs.mu.Unlock()
+	if len(hs.primaryLat) == 0 {
+		return 0
+	}
+	sort.Float64s(hs.primaryLat)
+	idx := int(float64(len(hs.primaryLat)) * pct / 100)
+	if idx >= len(hs.primaryLat) {
+		idx = len(hs.primaryLat) - 1
+	}
+	return hs.primaryLat[idx]
+}
+
+type hedgeResult struct {
+	err   error
+	hedge bool
+	trace *requestTrace
+}
+
+// newHedgedReadHandler sends a duplicate read with the secondary handler
+// if the primary has not returned after 'hs.after', and returns the first result.
+func newHedgedReadHandler(primary, secondary ReqHandler, hs *hedgeStats) ReqHandler {
+	return func(ctx context.Context, req *request) error {
+		rc := make(chan hedgeResult, 2)
+		run := func(ctx context.Context, rh ReqHandler, hedge bool) {
+			copied := *req
+			if req.trace != nil {
+				copied.trace = &requestTrace{}
+			}
+			st := time.Now()
+			err := rh(ctx, &copied)
+			if !hedge {
+				hs.addPrimary(time.Since(st))
+			}
+			rc <- hedgeResult{err: err, hedge: hedge, trace: copied.trace}
+		}
+		go run(context.Background(), primary, false)
+
+		var rs hedgeResult
+		select {
+		case rs = <-rc:
+		case <-time.After(hs.after):
+			atomic.AddInt64(&hs.hedged, 1)
+			hctx, cancel := context.WithCancel(ctx)
+			defer cancel()
+			go run(hctx, secondary, true)
+
+			rs = <-rc
+			if rs.err != nil {
+				// the other may still succeed
+				rs = <-rc
+			}
+			if rs.hedge && rs.err == nil {
+				atomic.AddInt64(&hs.won, 1)
+			}
+		}
+		if req.trace != nil && rs.trace != nil {
+			*req.trace = *rs.trace
+		}
+		return rs.err
+	}
+}
+
+// newHedgedReadHandlers creates read handlers whose duplicate reads
+// are sent to the next endpoint of each primary read handler.
+func (cfg *Config) newHedgedReadHandlers(gcfg dbtesterpb.ConfigClientMachineAgentControl) ([]ReqHandler, func(), error) {
+	eps := gcfg.DatabaseEndpoints
+	if len(eps) < 2 {
+		return nil, nil, fmt.Errorf("'hedge_after_microseconds' requires at least 2 endpoints (got %v)", eps)
+	}
+
+	// rotate endpoints and reset the round-robin dial counter,
+	// so that each secondary connects to the next endpoint of the primary
+	d0 := dialTotal
+	primary, primaryDone := newReadHandlers(gcfg)
+	dialTotal = d0
+	copied := gcfg
+	copied.DatabaseEndpoints = append(append([]string{}, eps[1:]...), eps[0])
+	secondary, secondaryDone := newReadHandlers(copied)
+
+	hs := &hedgeStats{after: time.Duration(gcfg.ConfigClientMachineBenchmarkOptions.HedgeAfterMicroseconds) * time.Microsecond}
+	cfg.hedgeStats = hs
+
+	rhs := make([]ReqHandler, len(primary))
+	for i := range rhs {
+		rhs[i] = newHedgedReadHandler(primary[i], secondary[i], hs)
+	}
+	done := func() {
+		if primaryDone != nil {
+			primaryDone()
+		}
+		if secondaryDone != nil {
+			secondaryDone()
+		}
+	}
+	cfg.lg.Sugar().Infof("hedging reads after %v [database: %q]", hs.after, gcfg.DatabaseID)
+	return rhs, done, nil
+}
@@ -0,0 +1,47 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+func TestHedgedReadHandler(t *testing.T) {
+	slow := func(context.Context, *request) error {
+		time.Sleep(50 * time.Millisecond)
+		return nil
+	}
+	fast := func(context.Context, *request) error { return nil }
+
+	hs := &hedgeStats{after: 5 * time.Millisecond}
+	if err := newHedgedReadHandler(fast, slow, hs)(context.Background(), &request{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := newHedgedReadHandler(slow, fast, hs)(context.Background(), &request{}); err != nil {
+		t.Fatal(err)
+	}
+	if hs.hedged != 1 || hs.won != 1 {
+		t.Fatalf("expected 1 hedged and 1 won, got %d hedged and %d won", hs.hedged, hs.won)
+	}
+
+	// wait for the slow primary to record its latency
+	time.Sleep(100 * time.Millisecond)
+	if p := hs.primaryPercentile(99); p < 0.05 {
+		t.Fatalf("expected primary p99 over 50ms, got %v", p)
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"fmt"
+
+	"github.com/coreos/dbtester/dbtesterpb"
+
+	"github.com/gyuho/dataframe"
+	"go.uber.org/zap"
+)
+
+// interferenceResult compares the latency of a workload
+// run alone, and run with the other workloads.
+type interferenceResult struct {
+	name string
+	typ  string
+
+	isolatedAvg, isolatedP99 float64
+	sharedAvg, sharedP99     float64
+}
+
+// slowdown returns the ratio of the shared latency to the isolated
+// latency, or 0 if the workload had no successful request alone.
+func slowdown(shared, isolated float64) float64 {
+	if isolated == 0 {
+		return 0
+	}
+	return shared / isolated
+}
+
+func newInterferenceResults(results []workloadResult, isolated []runStats) []interferenceResult {
+	rs := make([]interferenceResult, len(results))
+	for i, r := range results {
+		rs[i] = interferenceResult{
+			name:        r.name,
+			typ:         r.typ,
+			isolatedAvg: isolated[i].Average,
+			isolatedP99: isolated[i].percentile(99),
+			sharedAvg:   r.stats.Average,
+			sharedP99:   r.stats.percentile(99),
+		}
+	}
+	return rs
+}
+
+// runIsolatedWorkloads runs each of the 'mixed' workloads alone,
+// one after another, to be the baseline of the noisy neighbors.
+func (cfg *Config) runIsolatedWorkloads(gcfg dbtesterpb.ConfigClientMachineAgentControl, vals values, writeIdx *int64) []runStats {
+	wls := gcfg.ConfigClientMachineBenchmarkOptions.ConfigClientMachineWorkloads
+	stats := make([]runStats, len(wls))
+	for i, wl := range wls {
+		cfg.lg.Info("starting isolated workload", zap.String("name", wl.Name), zap.String("type", wl.Type))
+		wcfg := newWorkloadConfig(gcfg, wl)
+		var startIdx int64
+		if wl.Type == "write" {
+			startIdx = reserveKeys(wcfg, writeIdx)
+		}
+		b := cfg.newWorkloadBenchmark(wcfg, wl, vals, startIdx)
+		b.startRequests()
+		b.waitAll()
+		stats[i] = b.stats
+		cfg.emptyResponses += b.emptyN
+
+		cfg.lg.Sugar().Infof("isolated workload %q [type: %s | requests: %d | errors: %d | RPS: %.4f | average latency: %.4f ms]",
+			wl.Name, wl.Type, b.stats.requests(), errorTotal(b.stats), b.stats.RPS, 1000*b.stats.Average)
+	}
+	return stats
+}
+
+func (cfg *Config) saveInterference(rs []interferenceResult) error {
+	for _, r := range rs {
+		cfg.lg.Sugar().Infof("workload %q interference [isolated p99: %.4f ms | shared p99: %.4f ms | slowdown: %.2fx]",
+			r.name, 1000*r.isolatedP99, 1000*r.sharedP99, slowdown(r.sharedP99, r.isolatedP99))
+	}
+
+	fpath := cfg.ConfigClientMachineInitial.ClientInterferencePath
+	if fpath == "" {
+		cfg.lg.Warn("'client_interference_path' is not set; skipping interference results")
+		return nil
+	}
+
+	c1 := dataframe.NewColumn("WORKLOAD")
+	c2 := dataframe.NewColumn("TYPE")
+	c3 := dataframe.NewColumn("ISOLATED-AVERAGE-LATENCY-MS")
+	c4 := dataframe.NewColumn("ISOLATED-P99-LATENCY-MS")
+	c5 := dataframe.NewColumn("SHARED-AVERAGE-LATENCY-MS")
+	c6 := dataframe.NewColumn("SHARED-P99-LATENCY-MS")
+	c7 := dataframe.NewColumn("AVERAGE-SLOWDOWN")
+	c8 := dataframe.NewColumn("P99-SLOWDOWN")
+	for _, r := range rs {
+		c1.PushBack(dataframe.NewStringValue(r.name))
+		c2.PushBack(dataframe.NewStringValue(r.typ))
+		c3.PushBack(dataframe.NewStringValue(fmt.Sprintf("%4.4f", 1000*r.isolatedAvg)))
+		c4.PushBack(dataframe.NewStringValue(fmt.Sprintf("%4.4f", 1000*r.isolatedP99)))
+		c5.PushBack(dataframe.NewStringValue(fmt.Sprintf("%4.4f", 1000*r.sharedAvg)))
+		c6.PushBack(dataframe.NewStringValue(fmt.Sprintf("%4.4f", 1000*r.sharedP99)))
+		c7.PushBack(dataframe.NewStringValue(fmt.Sprintf("%4.4f", slowdown(r.sharedAvg, r.isolatedAvg))))
+		c8.PushBack(dataframe.NewStringValue(fmt.Sprintf("%4.4f", slowdown(r.sharedP99, r.isolatedP99))))
+	}
+	fr := dataframe.New()
+	for _, col := range []dataframe.Column{c1, c2, c3, c4, c5, c6, c7, c8} {
+		if err := fr.AddColumn(col); err != nil {
+			return err
+		}
+	}
+	if err := saveCSV(fr, fpath); err != nil {
+		return err
+	}
+	cfg.lg.Info("saved interference results", zap.String("path", fpath))
+	return nil
+}
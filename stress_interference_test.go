@@ -0,0 +1,63 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"testing"
+
+	"github.com/coreos/dbtester/dbtesterpb"
+)
+
+func TestNewInterferenceResults(t *testing.T) {
+	results := []workloadResult{
+		{name: "put", typ: "write", stats: newRunStats(testLatencies(0.002, 0.002), 0, nil, nil)},
+		{name: "get", typ: "read", stats: newRunStats(testLatencies(0.001, 0.005), 0, nil, nil)},
+	}
+	isolated := []runStats{
+		newRunStats(testLatencies(0.002, 0.002), 0, nil, nil),
+		newRunStats(testLatencies(0.001, 0.001), 0, nil, nil),
+	}
+	rs := newInterferenceResults(results, isolated)
+	if s := slowdown(rs[0].sharedP99, rs[0].isolatedP99); s != 1 {
+		t.Fatalf("expected no slowdown of %q, got %f", rs[0].name, s)
+	}
+	if s := slowdown(rs[1].sharedP99, rs[1].isolatedP99); s != 5 {
+		t.Fatalf("expected p99 slowdown 5 of %q, got %f", rs[1].name, s)
+	}
+	if s := slowdown(1, 0); s != 0 {
+		t.Fatalf("expected 0 with no isolated latency, got %f", s)
+	}
+}
+
+func TestValidateWorkloadsInterference(t *testing.T) {
+	gcfg := dbtesterpb.ConfigClientMachineAgentControl{
+		ConfigClientMachineBenchmarkOptions: &dbtesterpb.ConfigClientMachineBenchmarkOptions{
+			Type:                "mixed",
+			Prepopulate:         10,
+			MeasureInterference: true,
+			ConfigClientMachineWorkloads: []*dbtesterpb.ConfigClientMachineWorkload{
+				{Name: "get", Type: "read", Percent: 70},
+				{Name: "put", Type: "write", Percent: 30},
+			},
+		},
+	}
+	if err := validateWorkloads(gcfg); err != nil {
+		t.Fatal(err)
+	}
+	gcfg.ConfigClientMachineBenchmarkOptions.ConfigClientMachineWorkloads[1].Type = "delete"
+	if err := validateWorkloads(gcfg); err == nil {
+		t.Fatal("expected error on 'delete' workload with 'measure_interference'")
+	}
+}
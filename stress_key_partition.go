@@ -0,0 +1,68 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	mrand "math/rand"
+	"time"
+
+	"github.com/coreos/dbtester/dbtesterpb"
+
+	"golang.org/x/net/context"
+)
+
+// keyRange returns the disjoint key range [start, start+n)
+// of the client, out of the key space.
+func keyRange(keySpace, clientN, client int64) (start, n int64) {
+	n = keySpace / clientN
+	if n < 1 {
+		// more clients than keys; clients share keys
+		return client % keySpace, 1
+	}
+	return client * n, n
+}
+
+// newPartitionedWriteHandler rewrites each write request with
+// a random key from the key range owned by the client.
+func newPartitionedWriteHandler(gcfg dbtesterpb.ConfigClientMachineAgentControl, rh ReqHandler, client int64) ReqHandler {
+	start, n := keyRange(
+		gcfg.ConfigClientMachineBenchmarkOptions.KeySpaceSize,
+		gcfg.ConfigClientMachineBenchmarkOptions.ClientNumber,
+		client,
+	)
+	// each handler is called by one client goroutine
+	rnd := mrand.New(mrand.NewSource(time.Now().UnixNano() + client))
+	return func(ctx context.Context, req *request) error {
+		k := namespaced(gcfg, sequentialKey(gcfg.ConfigClientMachineBenchmarkOptions.KeySizeBytes, start+rnd.Int63n(n)))
+		v := requestValue(req)
+		copied := newPutRequest(gcfg, k, v, string(v))
+		copied.trace = req.trace
+		return rh(ctx, &copied)
+	}
+}
+
+// requestValue returns the value of the write request.
+func requestValue(req *request) []byte {
+	switch {
+	case req.etcdv3Op.IsPut():
+		return req.etcdv3Op.ValueBytes()
+	case req.zkOp.value != nil:
+		return req.zkOp.value
+	case req.consulOp.value != nil:
+		return req.consulOp.value
+	default:
+		return req.mockOp.value
+	}
+}
@@ -0,0 +1,34 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import "testing"
+
+func TestKeyRange(t *testing.T) {
+	tests := []struct {
+		keySpace, clientN, client int64
+		start, n                  int64
+	}{
+		{1000, 10, 0, 0, 100},
+		{1000, 10, 9, 900, 100},
+		{5, 10, 7, 2, 1},
+	}
+	for i, tt := range tests {
+		start, n := keyRange(tt.keySpace, tt.clientN, tt.client)
+		if start != tt.start || n != tt.n {
+			t.Fatalf("#%d: expected [%d, +%d), got [%d, +%d)", i, tt.start, tt.n, start, n)
+		}
+	}
+}
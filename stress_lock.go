@@ -0,0 +1,127 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"errors"
+	"fmt"
+	mrand "math/rand"
+	"sync/atomic"
+	"time"
+
+	"github.com/coreos/dbtester/dbtesterpb"
+
+	"golang.org/x/net/context"
+)
+
+// errLockLost is returned when a lock is released
+// by the database before the client releases it.
+var errLockLost = errors.New("lock lost before release")
+
+// locker acquires and releases the locks of the keys, for one client.
+type locker interface {
+	// lock blocks until the client holds the lock of the key.
+	lock(ctx context.Context, key string) error
+	// unlock releases the lock of the key.
+	unlock(ctx context.Context, key string) error
+	// invalidate ends the session that holds the lock of the key,
+	// and returns once the database released the lock.
+	invalidate(ctx context.Context, key string) error
+}
+
+// lockStats counts the outcomes of 'lock' requests.
+type lockStats struct {
+	acquires      int64
+	invalidations int64
+	// invalidateNanoseconds is the total time from the invalidations
+	// of the sessions to the releases of their locks.
+	invalidateNanoseconds int64
+}
+
+// averageInvalidate returns the average time for the database
+// to release the lock of an invalidated session.
+func (ls *lockStats) averageInvalidate() time.Duration {
+	if ls.invalidations == 0 {
+		return 0
+	}
+	return time.Duration(ls.invalidateNanoseconds / ls.invalidations)
+}
+
+// lockKey returns the key of the i-th lock.
+func lockKey(gcfg dbtesterpb.ConfigClientMachineAgentControl, i int64) string {
+	return namespaced(gcfg, "lock-"+sequentialKey(gcfg.ConfigClientMachineBenchmarkOptions.KeySizeBytes, i))
+}
+
+func newLockHandler(gcfg dbtesterpb.ConfigClientMachineAgentControl, lk locker, client int64, ls *lockStats) ReqHandler {
+	opts := gcfg.ConfigClientMachineBenchmarkOptions
+	keyN := opts.LockKeyNumber
+	if keyN < 1 {
+		keyN = 1
+	}
+	hold := time.Duration(opts.LockHoldMilliseconds) * time.Millisecond
+	// each handler is called by one client goroutine
+	rnd := mrand.New(mrand.NewSource(time.Now().UnixNano() + client))
+	return func(ctx context.Context, req *request) error {
+		key := lockKey(gcfg, rnd.Int63n(keyN))
+		if err := lk.lock(ctx, key); err != nil {
+			return err
+		}
+		atomic.AddInt64(&ls.acquires, 1)
+		if hold > 0 {
+			select {
+			case <-time.After(hold):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		if rnd.Int63n(100) < opts.LockInvalidatePercent {
+			now := time.Now()
+			if err := lk.invalidate(ctx, key); err != nil {
+				return err
+			}
+			atomic.AddInt64(&ls.invalidations, 1)
+			atomic.AddInt64(&ls.invalidateNanoseconds, int64(time.Since(now)))
+			return nil
+		}
+		return lk.unlock(ctx, key)
+	}
+}
+
+// newLockHandlers returns the handlers that acquire, hold and release
+// the locks of the keys. Each request is a full lock cycle.
+func (cfg *Config) newLockHandlers(gcfg dbtesterpb.ConfigClientMachineAgentControl) (rhs []ReqHandler, done func(), err error) {
+	ls := &lockStats{}
+	cfg.lockStats = ls
+
+	rhs = make([]ReqHandler, gcfg.ConfigClientMachineBenchmarkOptions.ClientNumber)
+	switch gcfg.DatabaseID {
+	case "consul__v1_0_2":
+		// sessions are per client, so are the clients
+		clis := mustCreateClientsConsul(gcfg.DatabaseEndpoints, gcfg.ConfigClientMachineBenchmarkOptions.ClientNumber)
+		lockDelay := time.Duration(gcfg.ConfigClientMachineBenchmarkOptions.ConsulLockDelayMilliseconds) * time.Millisecond
+		for i := range rhs {
+			rhs[i] = newLockHandler(gcfg, newLockerConsul(clis[i], lockDelay), int64(i), ls)
+		}
+
+	case "mock":
+		for i := range rhs {
+			rhs[i] = newLockHandler(gcfg, newLockerMock(gcfg.Flag_Mock), int64(i), ls)
+		}
+
+	default:
+		return nil, nil, fmt.Errorf("'lock' is not supported for %q", gcfg.DatabaseID)
+	}
+	return rhs, done, nil
+}
@@ -0,0 +1,115 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/coreos/dbtester/dbtesterpb"
+
+	"golang.org/x/net/context"
+)
+
+func TestLockMock(t *testing.T) {
+	gcfg := dbtesterpb.ConfigClientMachineAgentControl{
+		DatabaseID: "mock",
+		ConfigClientMachineBenchmarkOptions: &dbtesterpb.ConfigClientMachineBenchmarkOptions{
+			KeySizeBytes:          8,
+			LockKeyNumber:         1,
+			LockHoldMilliseconds:  1,
+			LockInvalidatePercent: 50,
+		},
+	}
+	ls := &lockStats{}
+	var (
+		mu      sync.Mutex
+		holders int
+	)
+	lk := &countingLocker{locker: newLockerMock(nil), mu: &mu, holders: &holders, t: t}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			h := newLockHandler(gcfg, lk, int64(i), ls)
+			for j := 0; j < 10; j++ {
+				if err := h(context.Background(), &request{}); err != nil {
+					t.Error(err)
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if ls.acquires != 40 {
+		t.Fatalf("expected 40 acquires, got %d", ls.acquires)
+	}
+	if ls.invalidations == 0 || ls.invalidations == 40 {
+		t.Fatalf("expected some of the locks invalidated, got %d", ls.invalidations)
+	}
+
+	// the lock is held until released
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	key := lockKey(gcfg, 0)
+	if err := lk.lock(context.Background(), key); err != nil {
+		t.Fatal(err)
+	}
+	if err := lk.lock(ctx, key); err != context.DeadlineExceeded {
+		t.Fatalf("expected %v, got %v", context.DeadlineExceeded, err)
+	}
+	if err := lk.unlock(context.Background(), key); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// countingLocker fails the test if the lock is held by two clients.
+type countingLocker struct {
+	locker
+	mu      *sync.Mutex
+	holders *int
+	t       *testing.T
+}
+
+func (l *countingLocker) lock(ctx context.Context, key string) error {
+	if err := l.locker.lock(ctx, key); err != nil {
+		return err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if *l.holders++; *l.holders > 1 {
+		l.t.Errorf("expected one holder of %q, got %d", key, *l.holders)
+	}
+	return nil
+}
+
+func (l *countingLocker) release() {
+	l.mu.Lock()
+	*l.holders--
+	l.mu.Unlock()
+}
+
+func (l *countingLocker) unlock(ctx context.Context, key string) error {
+	l.release()
+	return l.locker.unlock(ctx, key)
+}
+
+func (l *countingLocker) invalidate(ctx context.Context, key string) error {
+	l.release()
+	return l.locker.invalidate(ctx, key)
+}
@@ -0,0 +1,80 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"fmt"
+
+	"github.com/coreos/dbtester/dbtesterpb"
+
+	humanize "github.com/dustin/go-humanize"
+	"golang.org/x/net/context"
+)
+
+// prepopulate writes 'prepopulate' number of sequential keys before
+// 'read' benchmark, so that reads do not hit not-found keys, whose
+// latency would be reported as if they were real reads.
+// The writes are not included in the report.
+func (cfg *Config) prepopulate(gcfg dbtesterpb.ConfigClientMachineAgentControl, vals values) error {
+	return cfg.prepopulateRange(gcfg, vals, 0, gcfg.ConfigClientMachineBenchmarkOptions.Prepopulate, cfg.keys)
+}
+
+// prepopulateRange writes 'n' sequential keys from the index 'startIdx',
+// and saves the keys to 'keys' if not nil.
+func (cfg *Config) prepopulateRange(gcfg dbtesterpb.ConfigClientMachineAgentControl, vals values, startIdx, n int64, keys *keyManifest) error {
+	// benchmark options are shared by pointer, copy before overwriting
+	copied := gcfg
+	opts := *gcfg.ConfigClientMachineBenchmarkOptions
+	copied.ConfigClientMachineBenchmarkOptions = &opts
+	copied.ConfigClientMachineBenchmarkOptions.RequestNumber = n
+	copied.ConfigClientMachineBenchmarkOptions.RateLimitRequestsPerSecond = 0
+	copied.ConfigClientMachineBenchmarkOptions.DurationSeconds = 0
+	copied.ConfigClientMachineBenchmarkOptions.SameKey = false
+	copied.ConfigClientMachineBenchmarkOptions.KeySpaceSize = 0
+	// the keys do not exist yet
+	copied.ConfigClientMachineBenchmarkOptions.EtcdIgnoreValue = false
+	copied.ConfigClientMachineBenchmarkOptions.EtcdIgnoreLease = false
+	if copied.ConfigClientMachineBenchmarkOptions.ClientNumber <= 0 {
+		copied.ConfigClientMachineBenchmarkOptions.ClientNumber = 1
+	}
+	if copied.ConfigClientMachineBenchmarkOptions.ConnectionNumber <= 0 {
+		copied.ConfigClientMachineBenchmarkOptions.ConnectionNumber = 1
+	}
+	reqN := copied.ConfigClientMachineBenchmarkOptions.RequestNumber
+	clientN := copied.ConfigClientMachineBenchmarkOptions.ClientNumber
+
+	dataset := uint64(reqN * (opts.KeySizeBytes + opts.ValueSizeBytes))
+	cfg.lg.Sugar().Infof("prepopulate started [keys: %d | from: %d | dataset: %s | clients: %d | database: %q]", reqN, startIdx, humanize.Bytes(dataset), clientN, gcfg.DatabaseID)
+	h, done := newWriteHandlers(cfg.lg, copied)
+	reqGen := func(ctx context.Context, inflightReqs chan<- request) {
+		generateWrites(ctx, copied, startIdx, vals, inflightReqs)
+	}
+	b := newBenchmark(reqN, clientN, h, done, reqGen)
+	b.progress.interval = cfg.ProgressInterval
+	b.keys = keys
+	b.ctx = cfg.runContext()
+	b.startRequests()
+	b.waitAll()
+
+	var errCnt int
+	for _, v := range b.stats.ErrorDist {
+		errCnt += v
+	}
+	if errCnt > 0 {
+		return fmt.Errorf("prepopulate failed for %d out of %d keys (%v)", errCnt, reqN, b.stats.ErrorDist)
+	}
+	cfg.lg.Sugar().Infof("prepopulate done [keys: %d | took: %v | RPS: %.4f | database: %q]", reqN, b.stats.Total, b.stats.RPS, gcfg.DatabaseID)
+	return nil
+}
@@ -0,0 +1,316 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"fmt"
+	"math"
+	"sync/atomic"
+	"time"
+
+	"github.com/coreos/dbtester/dbtesterpb"
+
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+	"golang.org/x/time/rate"
+)
+
+// Priorities of the 'mixed' workloads.
+const (
+	priorityGuaranteed = "guaranteed"
+	priorityBestEffort = "best-effort"
+)
+
+const (
+	// priorityMetPercent is the share of the guaranteed rate, over which
+	// a 'guaranteed' workload is not behind in a second.
+	priorityMetPercent = 95
+	// priorityBackoff divides the rate of the 'best-effort' workloads
+	// while a 'guaranteed' workload is behind.
+	priorityBackoff = 2
+	// priorityRecovery multiplies the rate of the 'best-effort'
+	// workloads while all 'guaranteed' workloads keep up.
+	priorityRecovery = 1.25
+)
+
+// priorityClass paces the requests of one workload of a priority.
+type priorityClass struct {
+	name     string
+	priority string
+	// target is the guaranteed rate of a 'guaranteed' workload, or the
+	// share of the rate left of a 'best-effort' one, Inf if unlimited
+	target rate.Limit
+
+	// limiter paces a 'best-effort' workload, nil for a 'guaranteed' one,
+	// whose own rate limit paces it
+	limiter *rate.Limiter
+
+	// issued is the number of requests handed to the clients
+	// since the previous tick of the controller
+	issued int64
+	// done is 1 once the workload has no more requests
+	done int32
+
+	// seconds is the number of the ticks the workload ran
+	seconds int
+	// metSeconds is the number of the ticks a 'guaranteed'
+	// workload kept up with its rate
+	metSeconds int
+	// throttles is the number of the ticks a 'best-effort'
+	// workload was throttled for the 'guaranteed' ones
+	throttles int
+}
+
+// gate returns the request generator that paces the requests of 'reqGen'
+// by the class, and counts the requests handed to the clients.
+func (c *priorityClass) gate(reqGen func(context.Context, chan<- request)) func(context.Context, chan<- request) {
+	return func(ctx context.Context, inflightReqs chan<- request) {
+		defer close(inflightReqs)
+		defer atomic.StoreInt32(&c.done, 1)
+
+		reqs := make(chan request)
+		go reqGen(ctx, reqs)
+		for req := range reqs {
+			if c.limiter != nil {
+				if c.limiter.Wait(ctx) != nil {
+					// drain until the generator sees the cancel
+					continue
+				}
+				req.scheduled = time.Now()
+			}
+			select {
+			case inflightReqs <- req:
+				atomic.AddInt64(&c.issued, 1)
+			case <-ctx.Done():
+			}
+		}
+	}
+}
+
+// targetString returns the target rate of the class, empty if unlimited.
+func (c *priorityClass) targetString() string {
+	if c.target == rate.Inf {
+		return ""
+	}
+	return fmt.Sprintf("%4.4f", float64(c.target))
+}
+
+// priorityController throttles the 'best-effort' workloads while any
+// 'guaranteed' workload falls behind its rate, and lets them recover
+// up to their share of the rate while all keep up, so that the
+// saturation of the database shifts to the 'best-effort' workloads.
+type priorityController struct {
+	lg      *zap.Logger
+	classes []*priorityClass
+}
+
+// validatePriorities returns an error if the priorities of the 'mixed'
+// workloads are invalid. Either all or none of the workloads have one.
+func validatePriorities(opts *dbtesterpb.ConfigClientMachineBenchmarkOptions) error {
+	var guaranteedN, bestEffortN, requestN int
+	guaranteedRate := int64(0)
+	for _, wl := range opts.ConfigClientMachineWorkloads {
+		if wl.Type == "watch" {
+			// watchers send no requests to throttle
+			continue
+		}
+		requestN++
+		switch wl.Priority {
+		case "":
+			if wl.RateShare != 0 {
+				return fmt.Errorf("workload %q has 'rate_share' but no 'priority'", wl.Name)
+			}
+		case priorityGuaranteed:
+			if wl.RateLimitRequestsPerSecond <= 0 {
+				return fmt.Errorf("'guaranteed' workload %q requires 'rate_limit_requests_per_second'", wl.Name)
+			}
+			if wl.RateShare != 0 {
+				return fmt.Errorf("'rate_share' is only for 'best-effort' workloads (got %q)", wl.Name)
+			}
+			guaranteedN++
+			guaranteedRate += wl.RateLimitRequestsPerSecond
+		case priorityBestEffort:
+			if wl.RateLimitRequestsPerSecond != 0 {
+				return fmt.Errorf("'best-effort' workload %q is paced by 'rate_share', not 'rate_limit_requests_per_second'", wl.Name)
+			}
+			if wl.RateShare < 0 {
+				return fmt.Errorf("workload %q has negative 'rate_share' %d", wl.Name, wl.RateShare)
+			}
+			bestEffortN++
+		default:
+			return fmt.Errorf("workload %q has unknown 'priority' %q (expected 'guaranteed' or 'best-effort')", wl.Name, wl.Priority)
+		}
+	}
+	switch {
+	case guaranteedN+bestEffortN == 0:
+		return nil
+	case guaranteedN+bestEffortN != requestN:
+		return fmt.Errorf("'priority' must be set for all workloads or none")
+	case guaranteedN == 0 || bestEffortN == 0:
+		return fmt.Errorf("'priority' requires 'guaranteed' and 'best-effort' workloads")
+	case opts.OpenLoop:
+		return fmt.Errorf("'priority' is not supported with 'open_loop'")
+	case opts.RateLimitRequestsPerSecond > 0 && opts.RateLimitRequestsPerSecond <= guaranteedRate:
+		return fmt.Errorf("'rate_limit_requests_per_second' %d leaves no rate over the guaranteed %d", opts.RateLimitRequestsPerSecond, guaranteedRate)
+	}
+	return nil
+}
+
+// newPriorityController returns the controller of the workloads, or nil
+// if they have no priorities. The 'best-effort' workloads share the
+// rate of 'rate_limit_requests_per_second' left by the 'guaranteed'
+// ones by 'rate_share', or are unlimited if not set.
+func newPriorityController(lg *zap.Logger, opts *dbtesterpb.ConfigClientMachineBenchmarkOptions) *priorityController {
+	var wls []*dbtesterpb.ConfigClientMachineWorkload
+	for _, wl := range opts.ConfigClientMachineWorkloads {
+		if wl.Type != "watch" {
+			wls = append(wls, wl)
+		}
+	}
+	if len(wls) == 0 || wls[0].Priority == "" {
+		return nil
+	}
+	left, shares := opts.RateLimitRequestsPerSecond, int64(0)
+	for _, wl := range wls {
+		if wl.Priority == priorityGuaranteed {
+			left -= wl.RateLimitRequestsPerSecond
+		} else {
+			shares += rateShare(wl)
+		}
+	}
+
+	pc := &priorityController{lg: lg}
+	for _, wl := range wls {
+		c := &priorityClass{name: wl.Name, priority: wl.Priority}
+		switch {
+		case wl.Priority == priorityGuaranteed:
+			c.target = rate.Limit(wl.RateLimitRequestsPerSecond)
+		case opts.RateLimitRequestsPerSecond > 0:
+			c.target = rate.Limit(float64(left) * float64(rateShare(wl)) / float64(shares))
+			// bursts of 10ms, not to sleep for each request at high rates
+			c.limiter = rate.NewLimiter(c.target, int(c.target)/100+1)
+		default:
+			c.target = rate.Inf
+			c.limiter = rate.NewLimiter(rate.Inf, 1)
+		}
+		pc.classes = append(pc.classes, c)
+	}
+	return pc
+}
+
+// rateShare returns the 'rate_share' of the workload, 1 if not set.
+func rateShare(wl *dbtesterpb.ConfigClientMachineWorkload) int64 {
+	if wl.RateShare > 0 {
+		return wl.RateShare
+	}
+	return 1
+}
+
+// adjust adjusts the rates of the 'best-effort' workloads by the
+// requests handed to the clients in the 'elapsed' since the last tick.
+func (pc *priorityController) adjust(elapsed time.Duration) {
+	issued := make([]float64, len(pc.classes))
+	behind := false
+	for i, c := range pc.classes {
+		issued[i] = float64(atomic.SwapInt64(&c.issued, 0)) / elapsed.Seconds()
+		if atomic.LoadInt32(&c.done) == 1 {
+			continue
+		}
+		c.seconds++
+		if c.priority != priorityGuaranteed {
+			continue
+		}
+		if issued[i]*100 >= float64(c.target)*priorityMetPercent {
+			c.metSeconds++
+		} else {
+			behind = true
+		}
+	}
+
+	for i, c := range pc.classes {
+		if c.limiter == nil || atomic.LoadInt32(&c.done) == 1 {
+			continue
+		}
+		cur := c.limiter.Limit()
+		var next rate.Limit
+		if behind {
+			c.throttles++
+			// from the issued rate, if the limit was not what paced it
+			next = rate.Limit(math.Min(float64(cur), issued[i]) / priorityBackoff)
+			if next < 1 {
+				next = 1
+			}
+		} else {
+			next = cur * priorityRecovery
+			switch {
+			case cur == rate.Inf:
+				continue
+			case c.target == rate.Inf && float64(cur) > 2*issued[i]:
+				// the clients, not the limit, pace the workload again
+				next = rate.Inf
+			case next > c.target:
+				next = c.target
+			case c.target != rate.Inf && next < cur+c.target/10:
+				// not to take long to recover from the throttles
+				next = cur + c.target/10
+				if next > c.target {
+					next = c.target
+				}
+			}
+		}
+		if next != cur {
+			c.limiter.SetLimit(next)
+			pc.lg.Debug("adjusted best-effort rate", zap.String("workload", c.name), zap.Float64("rate", float64(next)), zap.Bool("throttled", behind))
+		}
+	}
+}
+
+// start adjusts the rates every second from now,
+// and returns the function to stop it.
+func (pc *priorityController) start() (stop func()) {
+	stopc, donec := make(chan struct{}), make(chan struct{})
+	go func() {
+		defer close(donec)
+		ticker := time.NewTicker(time.Second)
+		defer ticker.Stop()
+		last := time.Now()
+		for {
+			select {
+			case now := <-ticker.C:
+				pc.adjust(now.Sub(last))
+				last = now
+			case <-stopc:
+				return
+			}
+		}
+	}()
+	return func() {
+		close(stopc)
+		<-donec
+	}
+}
+
+// class returns the class of the workload of the name, or nil.
+func (pc *priorityController) class(name string) *priorityClass {
+	if pc == nil {
+		return nil
+	}
+	for _, c := range pc.classes {
+		if c.name == name {
+			return c
+		}
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"testing"
+	"time"
+
+	"github.com/coreos/dbtester/dbtesterpb"
+
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+	"golang.org/x/time/rate"
+)
+
+func TestValidatePriorities(t *testing.T) {
+	opts := &dbtesterpb.ConfigClientMachineBenchmarkOptions{
+		RateLimitRequestsPerSecond: 3000,
+		ConfigClientMachineWorkloads: []*dbtesterpb.ConfigClientMachineWorkload{
+			{Name: "put", Type: "write", Percent: 30, Priority: "guaranteed", RateLimitRequestsPerSecond: 1000},
+			{Name: "range", Type: "read", Percent: 70, Priority: "best-effort"},
+			// watchers send no requests, so take no priority
+			{Name: "watch", Type: "watch", Watchers: 10},
+		},
+	}
+	if err := validatePriorities(opts); err != nil {
+		t.Fatal(err)
+	}
+
+	opts.RateLimitRequestsPerSecond = 1000
+	if err := validatePriorities(opts); err == nil {
+		t.Fatal("expected error of no rate left for 'best-effort'")
+	}
+	opts.RateLimitRequestsPerSecond = 0
+
+	opts.ConfigClientMachineWorkloads[1].Priority = ""
+	if err := validatePriorities(opts); err == nil {
+		t.Fatal("expected error of workload with no priority")
+	}
+	opts.ConfigClientMachineWorkloads[1].Priority = "best-effort"
+
+	opts.ConfigClientMachineWorkloads[0].RateLimitRequestsPerSecond = 0
+	if err := validatePriorities(opts); err == nil {
+		t.Fatal("expected error of 'guaranteed' with no rate")
+	}
+}
+
+func TestPriorityControllerAdjust(t *testing.T) {
+	pc := newPriorityController(zap.NewNop(), &dbtesterpb.ConfigClientMachineBenchmarkOptions{
+		RateLimitRequestsPerSecond: 3000,
+		ConfigClientMachineWorkloads: []*dbtesterpb.ConfigClientMachineWorkload{
+			{Name: "put", Priority: "guaranteed", RateLimitRequestsPerSecond: 1000},
+			{Name: "range", Priority: "best-effort", RateShare: 3},
+			{Name: "delete", Priority: "best-effort"},
+		},
+	})
+	put, rng, del := pc.class("put"), pc.class("range"), pc.class("delete")
+	if rng.target != 1500 || del.target != 500 {
+		t.Fatalf("expected the 2000 left shared 3:1, got %v, %v", rng.target, del.target)
+	}
+
+	// 'put' falls behind
+	put.issued, rng.issued, del.issued = 500, 1500, 500
+	pc.adjust(time.Second)
+	if put.metSeconds != 0 || rng.throttles != 1 {
+		t.Fatalf("expected throttle, got met %d, throttles %d", put.metSeconds, rng.throttles)
+	}
+	if l := rng.limiter.Limit(); l != 750 {
+		t.Fatalf("expected 'range' halved to 750, got %v", l)
+	}
+
+	// 'put' keeps up again
+	put.issued, rng.issued, del.issued = 990, 750, 250
+	pc.adjust(time.Second)
+	if put.metSeconds != 1 || rng.throttles != 1 {
+		t.Fatalf("expected no throttle, got met %d, throttles %d", put.metSeconds, rng.throttles)
+	}
+	if l := rng.limiter.Limit(); l != 750*priorityRecovery {
+		t.Fatalf("expected 'range' to recover to %v, got %v", 750*priorityRecovery, l)
+	}
+	for i := 0; i < 10; i++ {
+		put.issued = 1000
+		pc.adjust(time.Second)
+	}
+	if l := rng.limiter.Limit(); l != rng.target {
+		t.Fatalf("expected 'range' to recover up to its share %v, got %v", rng.target, l)
+	}
+}
+
+func TestPriorityClassGate(t *testing.T) {
+	c := &priorityClass{name: "range", priority: "best-effort", target: rate.Inf, limiter: rate.NewLimiter(rate.Inf, 1)}
+	reqGen := c.gate(func(ctx context.Context, inflightReqs chan<- request) {
+		defer close(inflightReqs)
+		for i := int64(0); i < 5; i++ {
+			inflightReqs <- request{seq: i}
+		}
+	})
+	inflightReqs := make(chan request)
+	go reqGen(context.Background(), inflightReqs)
+	n := 0
+	for range inflightReqs {
+		n++
+	}
+	if n != 5 || c.issued != 5 || c.done != 1 {
+		t.Fatalf("expected 5 requests handed over, got %d (issued %d, done %d)", n, c.issued, c.done)
+	}
+}
@@ -0,0 +1,142 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/coreos/dbtester/dbtesterpb"
+
+	"github.com/coreos/etcd/clientv3"
+	"golang.org/x/net/context"
+)
+
+// checkRangeReads returns an error if the prepopulated keys cannot be read
+// in the ranges of the prefixes of 'perRequest' keys: the keys must be
+// zero-padded, so that the keys of a prefix are consecutive, and
+// 'perRequest' must be a power of 10 that divides the number of keys.
+func checkRangeReads(gcfg dbtesterpb.ConfigClientMachineAgentControl, keys []string, perRequest int64) error {
+	opts := gcfg.ConfigClientMachineBenchmarkOptions
+	switch gcfg.DatabaseID {
+	case "etcd__other", "etcd__tip", "etcd__v3_2", "etcd__v3_3",
+		"zookeeper__r3_5_3_beta", "zetcd__beta",
+		"consul__v1_0_2", "cetcd__beta",
+		"mock":
+	default:
+		return fmt.Errorf("'--range-reads' is not supported for %q", gcfg.DatabaseID)
+	}
+	switch {
+	case len(keys) > 0:
+		return fmt.Errorf("'--range-reads' reads the prepopulated keys, not '--keys-from'")
+	case opts.Prepopulate == 0:
+		return fmt.Errorf("'--range-reads' requires 'prepopulate'")
+	case rangeDigits(perRequest) == 0:
+		return fmt.Errorf("'--range-reads' requires '--keys-per-request' of a power of 10 over 1 (got %d)", perRequest)
+	case opts.Prepopulate%perRequest != 0:
+		return fmt.Errorf("'--keys-per-request' %d does not divide the %d prepopulated keys into ranges", perRequest, opts.Prepopulate)
+	case int64(len(sequentialKey(opts.KeySizeBytes, opts.Prepopulate-1))) > opts.KeySizeBytes:
+		return fmt.Errorf("'key_size_bytes' %d is too small to zero-pad the %d prepopulated keys", opts.KeySizeBytes, opts.Prepopulate)
+	}
+	return nil
+}
+
+// rangeDigits returns the number of the trailing digits that the keys of
+// a range of 'n' keys differ in, or 0 if 'n' is not a power of 10 over 1.
+func rangeDigits(n int64) int {
+	d := 0
+	for ; n > 1 && n%10 == 0; n /= 10 {
+		d++
+	}
+	if n != 1 {
+		return 0
+	}
+	return d
+}
+
+// generateRangeReads reads the prepopulated keys in the ranges of the
+// prefixes of 'perRequest' keys, in order or by 'key_distribution'.
+func generateRangeReads(ctx context.Context, gcfg dbtesterpb.ConfigClientMachineAgentControl, perRequest int64, inflightReqs chan<- request) {
+	defer close(inflightReqs)
+
+	opts := gcfg.ConfigClientMachineBenchmarkOptions
+	ranges := opts.Prepopulate / perRequest
+	digits := rangeDigits(perRequest)
+
+	var rangeIndex func(int64) int64
+	if opts.KeyDistribution != "" {
+		rangeIndex = newKeyIndexer(opts.KeyDistribution, ranges)
+	}
+
+	fd := newFeeder(gcfg)
+	limit := newRequestLimit(ctx, gcfg)
+	for i := int64(0); limit.more(i); i++ {
+		idx := i % ranges
+		if rangeIndex != nil {
+			idx = rangeIndex(i)
+		}
+		first := sequentialKey(opts.KeySizeBytes, idx*perRequest)
+		prefix := namespaced(gcfg, first[:len(first)-digits])
+
+		req := newRangeReadRequest(gcfg, prefix)
+		req.rangeKeys = int(perRequest)
+		req.seq = i
+		req.scheduled = fd.next()
+		if !limit.issue(inflightReqs, req) {
+			return
+		}
+	}
+}
+
+// newRangeReadRequest returns the request to read all keys of the prefix.
+func newRangeReadRequest(gcfg dbtesterpb.ConfigClientMachineAgentControl, prefix string) request {
+	staleRead := gcfg.ConfigClientMachineBenchmarkOptions.StaleRead
+
+	var req request
+	switch gcfg.DatabaseID {
+	case "etcd__other", "etcd__tip", "etcd__v3_2", "etcd__v3_3":
+		opts := []clientv3.OpOption{clientv3.WithPrefix()}
+		if staleRead {
+			opts = append(opts, clientv3.WithSerializable())
+		}
+		req = request{etcdv3Op: clientv3.OpGet(prefix, opts...)}
+
+	case "zookeeper__r3_5_3_beta", "zetcd__beta":
+		req = request{zkOp: zkOp{key: prefix, staleRead: staleRead}}
+
+	case "consul__v1_0_2", "cetcd__beta":
+		req = request{consulOp: consulOp{key: prefix, staleRead: staleRead}}
+
+	case "mock":
+		req = request{mockOp: mockOp{key: prefix}}
+
+	default:
+		panic(fmt.Sprintf("%q does not support range reads", gcfg.DatabaseID))
+	}
+	req.rangePrefix = prefix
+	return req
+}
+
+// zkRangeParent returns the parent znode of the keys of the prefix,
+// and the prefix of their names; the keys of "ns/00" are the children
+// of "/ns" of the names of prefix "00".
+func zkRangeParent(prefix string) (parent, name string) {
+	p := "/" + prefix
+	i := strings.LastIndex(p, "/")
+	if i == 0 {
+		return "/", p[1:]
+	}
+	return p[:i], p[i+1:]
+}
@@ -0,0 +1,118 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"testing"
+
+	"github.com/coreos/dbtester/dbtesterpb"
+
+	"golang.org/x/net/context"
+)
+
+func TestCheckRangeReads(t *testing.T) {
+	gcfg := func(id string, prepopulate, keySize int64) dbtesterpb.ConfigClientMachineAgentControl {
+		return dbtesterpb.ConfigClientMachineAgentControl{
+			DatabaseID:                          id,
+			ConfigClientMachineBenchmarkOptions: &dbtesterpb.ConfigClientMachineBenchmarkOptions{Prepopulate: prepopulate, KeySizeBytes: keySize},
+		}
+	}
+	tests := []struct {
+		gcfg       dbtesterpb.ConfigClientMachineAgentControl
+		keys       []string
+		perRequest int64
+		ok         bool
+	}{
+		{gcfg("consul__v1_0_2", 1000, 8), nil, 100, true},
+		{gcfg("zookeeper__r3_5_3_beta", 1000, 3), nil, 10, true},
+		{gcfg("redis__v4_0", 1000, 8), nil, 100, false},
+		{gcfg("etcd__tip", 1000, 8), []string{"a"}, 10, false},
+		{gcfg("etcd__tip", 0, 8), nil, 10, false},
+		{gcfg("etcd__tip", 1000, 8), nil, 1, false},
+		{gcfg("etcd__tip", 1000, 8), nil, 50, false},
+		{gcfg("etcd__tip", 1050, 8), nil, 100, false},
+		// keys over 999 are not zero-padded to 3 bytes
+		{gcfg("etcd__tip", 10000, 3), nil, 10, false},
+	}
+	for i, tt := range tests {
+		if err := checkRangeReads(tt.gcfg, tt.keys, tt.perRequest); (err == nil) != tt.ok {
+			t.Fatalf("#%d: expected ok %v, got %v", i, tt.ok, err)
+		}
+	}
+}
+
+func TestGenerateRangeReads(t *testing.T) {
+	gcfg := dbtesterpb.ConfigClientMachineAgentControl{
+		DatabaseID: "consul__v1_0_2",
+		ConfigClientMachineBenchmarkOptions: &dbtesterpb.ConfigClientMachineBenchmarkOptions{
+			RequestNumber: 4,
+			KeySizeBytes:  4,
+			Prepopulate:   300,
+			Namespace:     "ns/",
+		},
+	}
+	reqs := make(chan request)
+	go generateRangeReads(context.Background(), gcfg, 100, reqs)
+	var got []request
+	for req := range reqs {
+		got = append(got, req)
+	}
+	exp := []string{"ns/00", "ns/01", "ns/02", "ns/00"}
+	if len(got) != len(exp) {
+		t.Fatalf("expected %d requests, got %d", len(exp), len(got))
+	}
+	for i, req := range got {
+		if req.consulOp.key != exp[i] || req.rangePrefix != exp[i] || req.rangeKeys != 100 {
+			t.Fatalf("#%d: expected range of %q, got %+v", i, exp[i], req)
+		}
+	}
+
+	gcfg.DatabaseID = "etcd__v3_3"
+	op := newRangeReadRequest(gcfg, "ns/01").etcdv3Op
+	if !op.IsGet() || string(op.KeyBytes()) != "ns/01" || string(op.RangeBytes()) != "ns/02" {
+		t.Fatalf("expected prefix range of %q, got %+v", "ns/01", op)
+	}
+}
+
+func TestZkRangeParent(t *testing.T) {
+	tests := []struct{ prefix, parent, name string }{
+		{"00", "/", "00"},
+		{"ns/00", "/ns", "00"},
+		{"a/b/", "/a/b", ""},
+	}
+	for i, tt := range tests {
+		if parent, name := zkRangeParent(tt.prefix); parent != tt.parent || name != tt.name {
+			t.Fatalf("#%d: expected %q, %q, got %q, %q", i, tt.parent, tt.name, parent, name)
+		}
+	}
+}
+
+func TestGetMockRange(t *testing.T) {
+	mockDB.put("range/10", []byte("foo"))
+	mockDB.put("range/11", []byte("bar"))
+	mockDB.put("range/20", []byte("baz"))
+
+	req := &request{rangePrefix: "range/1", rangeKeys: 2, trace: &requestTrace{}}
+	if err := newGetMock(nil)(context.Background(), req); err != nil {
+		t.Fatal(err)
+	}
+	if req.trace.requestBytes != 7 || req.trace.responseBytes != 6 {
+		t.Fatalf("unexpected trace %+v", req.trace)
+	}
+	req = &request{rangePrefix: "range/2", rangeKeys: 2}
+	if err := newGetMock(nil)(context.Background(), req); err != errEmptyResponse {
+		t.Fatalf("expected %v, got %v", errEmptyResponse, err)
+	}
+}
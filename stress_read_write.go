@@ -0,0 +1,220 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"github.com/coreos/dbtester/dbtesterpb"
+
+	"github.com/coreos/etcd/clientv3"
+	"golang.org/x/net/context"
+)
+
+// readWriteStats counts the reads and writes of 'read-write'.
+type readWriteStats struct {
+	reads  int64
+	writes int64
+}
+
+func validateReadWrite(gcfg dbtesterpb.ConfigClientMachineAgentControl) error {
+	opts := gcfg.ConfigClientMachineBenchmarkOptions
+	switch {
+	case opts.ReadPercent <= 0 || opts.ReadPercent >= 100:
+		return fmt.Errorf("'read_percent' must be in (0, 100) for 'read-write' (got %d)", opts.ReadPercent)
+	case opts.ReadPercentEnd < 0 || opts.ReadPercentEnd >= 100:
+		return fmt.Errorf("'read_percent_end' must be in (0, 100) for 'read-write' (got %d)", opts.ReadPercentEnd)
+	case opts.Prepopulate <= 0:
+		return fmt.Errorf("'read-write' requires 'prepopulate'")
+	case opts.SameKey || opts.KeySpaceSize > 0:
+		return fmt.Errorf("'same_key' and 'key_space_size' are not supported for 'read-write'")
+	}
+	return nil
+}
+
+// readWriteMix decides which requests of 'read-write' are writes. The
+// write percent drifts linearly from the first to the last request, and
+// writes are spread evenly; with no drift, every 100 requests have
+// exactly '100 - read_percent' writes.
+type readWriteMix struct {
+	// write percents at the first and the last request
+	first, last int64
+	// steps is the number of requests after the first
+	steps int64
+
+	// acc accumulates the write percents scaled by 'steps',
+	// and a write is due each time it reaches '100 * steps'
+	acc int64
+}
+
+func newReadWriteMix(opts *dbtesterpb.ConfigClientMachineBenchmarkOptions) *readWriteMix {
+	m := &readWriteMix{first: 100 - opts.ReadPercent, steps: opts.RequestNumber - 1}
+	m.last = m.first
+	if opts.ReadPercentEnd > 0 {
+		m.last = 100 - opts.ReadPercentEnd
+	}
+	if m.steps < 1 {
+		m.steps = 1
+	}
+	return m
+}
+
+// write returns true if the i-th request is a write.
+// It must be called for each request in order.
+func (m *readWriteMix) write(i int64) bool {
+	m.acc += m.first*m.steps + (m.last-m.first)*i
+	if m.acc >= 100*m.steps {
+		m.acc -= 100 * m.steps
+		return true
+	}
+	return false
+}
+
+// newReadWriteHandler dispatches each request to the read or the write
+// handler, which share the connection of the client.
+func newReadWriteHandler(read, write ReqHandler, st *readWriteStats) ReqHandler {
+	return func(ctx context.Context, req *request) error {
+		if req.write {
+			atomic.AddInt64(&st.writes, 1)
+			return write(ctx, req)
+		}
+		atomic.AddInt64(&st.reads, 1)
+		return read(ctx, req)
+	}
+}
+
+// newReadWriteHandlers returns the handlers of 'read-write', counting
+// the requests in 'cfg.readWriteStats'.
+func (cfg *Config) newReadWriteHandlers(gcfg dbtesterpb.ConfigClientMachineAgentControl) (rhs []ReqHandler, done func(), err error) {
+	st := &readWriteStats{}
+	cfg.readWriteStats = st
+
+	rhs = make([]ReqHandler, gcfg.ConfigClientMachineBenchmarkOptions.ClientNumber)
+	switch gcfg.DatabaseID {
+	case "etcd__other", "etcd__tip", "etcd__v3_2", "etcd__v3_3":
+		clients := mustCreateClientsEtcdv3(gcfg.DatabaseEndpoints, etcdv3ClientCfg{
+			totalConns:   gcfg.ConfigClientMachineBenchmarkOptions.ConnectionNumber,
+			totalClients: gcfg.ConfigClientMachineBenchmarkOptions.ClientNumber,
+		})
+		for i := range rhs {
+			rhs[i] = newReadWriteHandler(newGetEtcd3(clients[i]), newPutEtcd3(clients[i]), st)
+		}
+		done = func() {
+			for i := range clients {
+				clients[i].Close()
+			}
+		}
+
+	case "zookeeper__r3_5_3_beta", "zetcd__beta":
+		conns := mustCreateConnsZk(gcfg.DatabaseEndpoints, gcfg.ConfigClientMachineBenchmarkOptions.ConnectionNumber)
+		for i := range rhs {
+			// writes overwrite the prepopulated keys
+			conn := conns[i%len(conns)]
+			rhs[i] = newReadWriteHandler(newGetZK(conn), newPutUpsertZK(conn), st)
+		}
+		done = func() {
+			for i := range conns {
+				conns[i].Close()
+			}
+		}
+
+	case "consul__v1_0_2", "cetcd__beta":
+		conns := mustCreateConnsConsul(gcfg.DatabaseEndpoints, gcfg.ConfigClientMachineBenchmarkOptions.ConnectionNumber)
+		for i := range rhs {
+			conn := conns[i%len(conns)]
+			rhs[i] = newReadWriteHandler(newGetConsul(conn), newPutConsul(conn), st)
+		}
+
+	case "redis__v4_0":
+		clis := mustCreateConnsRedis(gcfg.DatabaseEndpoints, gcfg.ConfigClientMachineBenchmarkOptions.ConnectionNumber, gcfg.Flag_Redis_V4_0)
+		for i := range rhs {
+			cli := clis[i%len(clis)]
+			rhs[i] = newReadWriteHandler(newGetRedis(cli), newPutRedis(cli), st)
+		}
+		done = closeConnsRedis(clis)
+
+	case "tikv__v2_1":
+		clis := mustCreateConnsTikv(gcfg, gcfg.ConfigClientMachineBenchmarkOptions.ConnectionNumber)
+		for i := range rhs {
+			cli := clis[i%len(clis)]
+			rhs[i] = newReadWriteHandler(newGetTikv(cli), newPutTikv(cli), st)
+		}
+		done = closeConnsTikv(clis)
+
+	case "mock":
+		for i := range rhs {
+			rhs[i] = newReadWriteHandler(newGetMock(gcfg.Flag_Mock), newPutMock(gcfg.Flag_Mock), st)
+		}
+
+	default:
+		return nil, nil, fmt.Errorf("'read-write' is not supported for %q", gcfg.DatabaseID)
+	}
+	return rhs, done, nil
+}
+
+// generateReadWrites reads and overwrites the prepopulated keys, in order
+// or by 'key_distribution', with 'read_percent' of the requests as reads,
+// drifting to 'read_percent_end' if set.
+func generateReadWrites(ctx context.Context, gcfg dbtesterpb.ConfigClientMachineAgentControl, vals values, inflightReqs chan<- request) {
+	defer close(inflightReqs)
+
+	opts := gcfg.ConfigClientMachineBenchmarkOptions
+	keyIndex := newKeyIndexer(opts.KeyDistribution, opts.Prepopulate)
+	mix := newReadWriteMix(opts)
+	fd := newFeeder(gcfg)
+	limit := newRequestLimit(ctx, gcfg)
+	for i := int64(0); limit.more(i); i++ {
+		k := namespaced(gcfg, sequentialKey(opts.KeySizeBytes, keyIndex(i)))
+
+		var req request
+		if mix.write(i) {
+			req = newPutRequest(gcfg, k, vals.bytes[i%int64(vals.sampleSize)], vals.strings[i%int64(vals.sampleSize)])
+			req.write = true
+		} else {
+			switch gcfg.DatabaseID {
+			case "etcd__other", "etcd__tip", "etcd__v3_2", "etcd__v3_3":
+				var ops []clientv3.OpOption
+				if opts.StaleRead {
+					ops = append(ops, clientv3.WithSerializable())
+				}
+				req = request{etcdv3Op: clientv3.OpGet(k, ops...)}
+
+			case "zookeeper__r3_5_3_beta", "zetcd__beta":
+				req = request{zkOp: zkOp{key: k, staleRead: opts.StaleRead}}
+
+			case "consul__v1_0_2", "cetcd__beta":
+				req = request{consulOp: consulOp{key: k, staleRead: opts.StaleRead}}
+
+			case "redis__v4_0":
+				req = request{redisOp: redisOp{key: k}}
+
+			case "tikv__v2_1":
+				req = request{tikvOp: tikvOp{key: k}}
+
+			case "mock":
+				req = request{mockOp: mockOp{key: k}}
+
+			default:
+				panic(fmt.Sprintf("%q is unknown database ID", gcfg.DatabaseID))
+			}
+		}
+		req.seq = i
+		req.scheduled = fd.next()
+		if !limit.issue(inflightReqs, req) {
+			return
+		}
+	}
+}
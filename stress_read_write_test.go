@@ -0,0 +1,102 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"testing"
+
+	"github.com/coreos/dbtester/dbtesterpb"
+	"golang.org/x/net/context"
+)
+
+func TestReadWriteMix(t *testing.T) {
+	for _, readPercent := range []int64{1, 50, 95, 99} {
+		mix := newReadWriteMix(&dbtesterpb.ConfigClientMachineBenchmarkOptions{RequestNumber: 1000, ReadPercent: readPercent})
+		writes := int64(0)
+		for i := int64(0); i < 1000; i++ {
+			if mix.write(i) {
+				writes++
+			}
+			// evenly spread in every 100 requests
+			if (i+1)%100 == 0 && writes != (i+1)/100*(100-readPercent) {
+				t.Fatalf("read percent %d: expected %d writes in %d requests, got %d", readPercent, (i+1)/100*(100-readPercent), i+1, writes)
+			}
+		}
+	}
+
+	// 90% reads to 50% reads; 30% writes on average
+	mix := newReadWriteMix(&dbtesterpb.ConfigClientMachineBenchmarkOptions{RequestNumber: 1000, ReadPercent: 90, ReadPercentEnd: 50})
+	writes := make([]int, 10)
+	for i := int64(0); i < 1000; i++ {
+		if mix.write(i) {
+			writes[i/100]++
+		}
+	}
+	total := 0
+	for _, n := range writes {
+		total += n
+	}
+	if total != 300 {
+		t.Fatalf("expected 300 writes, got %d", total)
+	}
+	if writes[0] < 10 || writes[0] > 13 || writes[9] < 47 || writes[9] > 50 {
+		t.Fatalf("expected about 10%% writes first and 50%% last, got %v", writes)
+	}
+}
+
+func TestGenerateReadWrites(t *testing.T) {
+	gcfg := dbtesterpb.ConfigClientMachineAgentControl{
+		DatabaseID: "mock",
+		ConfigClientMachineBenchmarkOptions: &dbtesterpb.ConfigClientMachineBenchmarkOptions{
+			Type:           "read-write",
+			RequestNumber:  100,
+			KeySizeBytes:   8,
+			ValueSizeBytes: 16,
+			Prepopulate:    10,
+			ReadPercent:    90,
+		},
+	}
+	if err := validateReadWrite(gcfg); err != nil {
+		t.Fatal(err)
+	}
+	vals, err := newValues(gcfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ch := make(chan request, 100)
+	generateReadWrites(context.Background(), gcfg, vals, ch)
+
+	keys, writes := make(map[string]struct{}), 0
+	for req := range ch {
+		if req.write {
+			writes++
+			if len(req.mockOp.value) != 16 {
+				t.Fatalf("expected value of 16 bytes, got %d", len(req.mockOp.value))
+			}
+		}
+		keys[req.mockOp.key] = struct{}{}
+	}
+	if writes != 10 {
+		t.Fatalf("expected 10 writes, got %d", writes)
+	}
+	if len(keys) != 10 {
+		t.Fatalf("expected 10 prepopulated keys, got %d", len(keys))
+	}
+
+	gcfg.ConfigClientMachineBenchmarkOptions.ReadPercent = 100
+	if err := validateReadWrite(gcfg); err == nil {
+		t.Fatal("expected error for 100% reads")
+	}
+}
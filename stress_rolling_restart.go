@@ -0,0 +1,206 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/coreos/dbtester/dbtesterpb"
+
+	"github.com/gyuho/dataframe"
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+)
+
+// restartWindow is the period from the shutdown of a member
+// to the shutdown of the next member, or to the end of the benchmark.
+type restartWindow struct {
+	// member is the agent index, or -1 for the baseline
+	// before the first member shutdown.
+	member   int
+	shutdown time.Time
+	restart  time.Time
+
+	requests int64
+	errs     int64
+	total    time.Duration
+	slowest  time.Duration
+}
+
+// rollingRestart restarts the members one at a time while the workload
+// runs, and attributes the requests to the window of each restart.
+type rollingRestart struct {
+	cfg *dbtesterpb.ConfigClientMachineRollingRestart
+
+	mu      sync.Mutex
+	windows []restartWindow
+}
+
+func newRollingRestart(cfg *dbtesterpb.ConfigClientMachineRollingRestart) *rollingRestart {
+	return &rollingRestart{cfg: cfg, windows: []restartWindow{{member: -1}}}
+}
+
+func (rr *rollingRestart) record(took time.Duration, err error) {
+	rr.mu.Lock()
+	w := &rr.windows[len(rr.windows)-1]
+	w.requests++
+	if err != nil && err != errEmptyResponse {
+		w.errs++
+	}
+	w.total += took
+	if took > w.slowest {
+		w.slowest = took
+	}
+	rr.mu.Unlock()
+}
+
+func (rr *rollingRestart) handler(rh ReqHandler) ReqHandler {
+	return func(ctx context.Context, req *request) error {
+		st := time.Now()
+		err := rh(ctx, req)
+		rr.record(time.Since(st), err)
+		return err
+	}
+}
+
+// run restarts the members one by one, until all members are restarted
+// or 'stopc' is closed. A member that is shut down is always restarted.
+func (rr *rollingRestart) run(cfg *Config, databaseID string, agentN int, stopc <-chan struct{}) {
+	wait := func(d time.Duration) bool {
+		select {
+		case <-time.After(d):
+			return true
+		case <-stopc:
+			return false
+		}
+	}
+	if !wait(time.Duration(rr.cfg.StartSecond) * time.Second) {
+		return
+	}
+
+	for i := 0; i < agentN; i++ {
+		rr.mu.Lock()
+		rr.windows = append(rr.windows, restartWindow{member: i, shutdown: time.Now()})
+		rr.mu.Unlock()
+
+		cfg.lg.Info("rolling restart: shutting down member", zap.Int("index", i))
+		if _, err := cfg.sendRequest(databaseID, dbtesterpb.Operation_Shutdown, i); err != nil {
+			cfg.lg.Warn("rolling restart: failed to shut down member; stopping", zap.Int("index", i), zap.Error(err))
+			return
+		}
+		cfg.spikes.mark(fmt.Sprintf("rolling restart member %d", i), time.Now())
+		stopped := !wait(time.Duration(rr.cfg.DownSeconds) * time.Second)
+
+		cfg.lg.Info("rolling restart: restarting member", zap.Int("index", i))
+		resp, err := cfg.sendRequest(databaseID, dbtesterpb.Operation_Restart, i)
+		if err != nil {
+			cfg.lg.Warn("rolling restart: failed to restart member; stopping", zap.Int("index", i), zap.Error(err))
+			return
+		}
+		rr.mu.Lock()
+		rr.windows[len(rr.windows)-1].restart = time.Unix(0, resp.DatabaseStartUnixNanosecond)
+		rr.mu.Unlock()
+
+		if stopped || (i < agentN-1 && !wait(time.Duration(rr.cfg.PauseSeconds)*time.Second)) {
+			cfg.lg.Warn("rolling restart: benchmark finished before all members are restarted", zap.Int("restarted", i+1), zap.Int("members", agentN))
+			return
+		}
+	}
+}
+
+// startRollingRestart wraps the handlers to record the requests,
+// starts restarting the members, and returns the function to wait
+// for the restarts after the benchmark.
+func (cfg *Config) startRollingRestart(gcfg dbtesterpb.ConfigClientMachineAgentControl, h []ReqHandler) (stop func()) {
+	rr := newRollingRestart(gcfg.ConfigClientMachineBenchmarkOptions.ConfigClientMachineRollingRestart)
+	cfg.rollingRestart = rr
+	for i := range h {
+		h[i] = rr.handler(h[i])
+	}
+
+	stopc, donec := make(chan struct{}), make(chan struct{})
+	go func() {
+		rr.run(cfg, gcfg.DatabaseID, len(gcfg.AgentEndpoints), stopc)
+		close(donec)
+	}()
+	return func() {
+		close(stopc)
+		<-donec
+	}
+}
+
+func (cfg *Config) saveRollingRestart() {
+	rr := cfg.rollingRestart
+	if rr == nil {
+		return
+	}
+	rr.mu.Lock()
+	defer rr.mu.Unlock()
+
+	for _, w := range rr.windows {
+		var avg time.Duration
+		if w.requests > 0 {
+			avg = w.total / time.Duration(w.requests)
+		}
+		cfg.lg.Sugar().Infof("rolling restart impact [member: %d | requests: %d | errors: %d | average latency: %v | slowest latency: %v]", w.member, w.requests, w.errs, avg, w.slowest)
+	}
+
+	fpath := cfg.ConfigClientMachineInitial.ClientRollingRestartPath
+	if fpath == "" {
+		cfg.lg.Warn("'client_rolling_restart_path' is not set; skipping rolling restart impact")
+		return
+	}
+
+	c1 := dataframe.NewColumn("MEMBER")
+	c2 := dataframe.NewColumn("SHUTDOWN-UNIX-NANOSECOND")
+	c3 := dataframe.NewColumn("RESTART-UNIX-NANOSECOND")
+	c4 := dataframe.NewColumn("REQUESTS")
+	c5 := dataframe.NewColumn("ERRORS")
+	c6 := dataframe.NewColumn("AVERAGE-LATENCY-MS")
+	c7 := dataframe.NewColumn("SLOWEST-LATENCY-MS")
+	for _, w := range rr.windows {
+		member, shutdown, restart := "baseline", int64(0), int64(0)
+		if w.member >= 0 {
+			member = fmt.Sprintf("%d", w.member)
+			shutdown = w.shutdown.UnixNano()
+		}
+		if !w.restart.IsZero() {
+			restart = w.restart.UnixNano()
+		}
+		var avg float64
+		if w.requests > 0 {
+			avg = toMillisecond(w.total) / float64(w.requests)
+		}
+		c1.PushBack(dataframe.NewStringValue(member))
+		c2.PushBack(dataframe.NewStringValue(shutdown))
+		c3.PushBack(dataframe.NewStringValue(restart))
+		c4.PushBack(dataframe.NewStringValue(w.requests))
+		c5.PushBack(dataframe.NewStringValue(w.errs))
+		c6.PushBack(dataframe.NewStringValue(fmt.Sprintf("%4.4f", avg)))
+		c7.PushBack(dataframe.NewStringValue(fmt.Sprintf("%4.4f", toMillisecond(w.slowest))))
+	}
+	fr := dataframe.New()
+	for _, col := range []dataframe.Column{c1, c2, c3, c4, c5, c6, c7} {
+		if err := fr.AddColumn(col); err != nil {
+			panic(err)
+		}
+	}
+	if err := saveCSV(fr, fpath); err != nil {
+		panic(err)
+	}
+	cfg.lg.Info("saved rolling restart impact", zap.String("path", fpath))
+}
@@ -0,0 +1,40 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/coreos/dbtester/dbtesterpb"
+)
+
+func TestRollingRestartRecord(t *testing.T) {
+	rr := newRollingRestart(&dbtesterpb.ConfigClientMachineRollingRestart{})
+	rr.record(time.Millisecond, nil)
+	rr.record(time.Millisecond, errEmptyResponse)
+
+	rr.windows = append(rr.windows, restartWindow{member: 0, shutdown: time.Now()})
+	rr.record(3*time.Millisecond, errors.New("connection refused"))
+	rr.record(time.Millisecond, nil)
+
+	if w := rr.windows[0]; w.requests != 2 || w.errs != 0 {
+		t.Fatalf("unexpected baseline %+v", w)
+	}
+	if w := rr.windows[1]; w.requests != 2 || w.errs != 1 || w.slowest != 3*time.Millisecond || w.total != 4*time.Millisecond {
+		t.Fatalf("unexpected restart window %+v", w)
+	}
+}
@@ -0,0 +1,187 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/coreos/dbtester/dbtesterpb"
+
+	"github.com/gyuho/dataframe"
+	"go.uber.org/zap"
+)
+
+// scheduleTolerance is the fraction of the target rate per second
+// that the backlog may reach before the interval is behind the schedule.
+const scheduleTolerance = 0.05
+
+// scheduleSecond is the requests started in one second of the schedule.
+type scheduleSecond struct {
+	started int64
+	// missed is the number of requests that did not
+	// complete by their deadline
+	missed int64
+}
+
+// scheduleTracker tracks whether the clients sustain the target rate,
+// by the second from the first scheduled request.
+type scheduleTracker struct {
+	targetRPS int64
+	total     int64
+	timeout   time.Duration
+
+	mu sync.Mutex
+	// first is the scheduled time of the first request
+	first time.Time
+	// seconds is keyed by the unix second of the request start
+	seconds map[int64]*scheduleSecond
+}
+
+func newScheduleTracker(gcfg dbtesterpb.ConfigClientMachineAgentControl) *scheduleTracker {
+	return &scheduleTracker{
+		targetRPS: gcfg.ConfigClientMachineBenchmarkOptions.RateLimitRequestsPerSecond,
+		total:     expectedRequests(gcfg),
+		timeout:   time.Duration(gcfg.ConfigClientMachineBenchmarkOptions.RequestTimeoutMilliseconds) * time.Millisecond,
+		seconds:   make(map[int64]*scheduleSecond),
+	}
+}
+
+// record records the request scheduled at 'scheduled' and started at
+// 'start' with the deadline 'deadline', that completed at 'end'.
+func (s *scheduleTracker) record(scheduled, start, end, deadline time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.first.IsZero() || scheduled.Before(s.first) {
+		s.first = scheduled
+	}
+	sec := start.Unix()
+	ss, ok := s.seconds[sec]
+	if !ok {
+		ss = &scheduleSecond{}
+		s.seconds[sec] = ss
+	}
+	ss.started++
+	if end.After(deadline) {
+		ss.missed++
+	}
+}
+
+// scheduleInterval is the schedule at the end of one second.
+type scheduleInterval struct {
+	unixSecond int64
+	started    int64
+	missed     int64
+	// backlog is the number of requests due by the
+	// end of the second, but not started yet
+	backlog int64
+	behind  bool
+}
+
+func (s *scheduleTracker) intervals() []scheduleInterval {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.seconds) == 0 {
+		return nil
+	}
+	var last int64
+	for sec := range s.seconds {
+		if sec > last {
+			last = sec
+		}
+	}
+	tolerance := int64(float64(s.targetRPS) * scheduleTolerance)
+
+	var ivs []scheduleInterval
+	var started int64
+	for sec := s.first.Unix(); sec <= last; sec++ {
+		iv := scheduleInterval{unixSecond: sec}
+		if ss, ok := s.seconds[sec]; ok {
+			iv.started, iv.missed = ss.started, ss.missed
+		}
+		started += iv.started
+
+		// requests due by the end of the second, one at the first scheduled time
+		due := 1 + int64(float64(s.targetRPS)*time.Unix(sec+1, 0).Sub(s.first).Seconds())
+		if due > s.total {
+			due = s.total
+		}
+		if due > started {
+			iv.backlog = due - started
+		}
+		iv.behind = iv.backlog > tolerance
+		ivs = append(ivs, iv)
+	}
+	return ivs
+}
+
+func (cfg *Config) saveSchedule() {
+	s := cfg.schedule
+	if s == nil {
+		return
+	}
+	ivs := s.intervals()
+
+	var behind, started, missed, maxBacklog int64
+	for _, iv := range ivs {
+		if iv.behind {
+			behind++
+		}
+		started += iv.started
+		missed += iv.missed
+		if iv.backlog > maxBacklog {
+			maxBacklog = iv.backlog
+		}
+	}
+	if len(ivs) > 0 && started > 0 {
+		cfg.lg.Sugar().Infof("rate %d requests/sec [behind schedule: %d out of %d intervals (%.2f%%) | max backlog: %d | deadline misses: %d out of %d (%.2f%%) in %v]",
+			s.targetRPS, behind, len(ivs), 100*float64(behind)/float64(len(ivs)), maxBacklog, missed, started, 100*float64(missed)/float64(started), s.timeout)
+	}
+	if behind > 0 {
+		cfg.lg.Sugar().Warnf("target rate %d requests/sec was not sustained in %d intervals; clients could not keep up", s.targetRPS, behind)
+	}
+
+	fpath := cfg.ConfigClientMachineInitial.ClientSchedulePath
+	if fpath == "" {
+		cfg.lg.Warn("'client_schedule_path' is not set; skipping schedule")
+		return
+	}
+	c1 := dataframe.NewColumn("UNIX-SECOND")
+	c2 := dataframe.NewColumn("TARGET-REQUESTS-PER-SECOND")
+	c3 := dataframe.NewColumn("STARTED")
+	c4 := dataframe.NewColumn("BACKLOG")
+	c5 := dataframe.NewColumn("DEADLINE-MISSES")
+	c6 := dataframe.NewColumn("BEHIND-SCHEDULE")
+	for _, iv := range ivs {
+		c1.PushBack(dataframe.NewStringValue(iv.unixSecond))
+		c2.PushBack(dataframe.NewStringValue(s.targetRPS))
+		c3.PushBack(dataframe.NewStringValue(iv.started))
+		c4.PushBack(dataframe.NewStringValue(iv.backlog))
+		c5.PushBack(dataframe.NewStringValue(iv.missed))
+		c6.PushBack(dataframe.NewStringValue(fmt.Sprintf("%v", iv.behind)))
+	}
+	fr := dataframe.New()
+	for _, col := range []dataframe.Column{c1, c2, c3, c4, c5, c6} {
+		if err := fr.AddColumn(col); err != nil {
+			panic(err)
+		}
+	}
+	if err := saveCSV(fr, fpath); err != nil {
+		panic(err)
+	}
+	cfg.lg.Info("saved schedule", zap.String("path", fpath))
+}
@@ -0,0 +1,56 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"testing"
+	"time"
+)
+
+func TestScheduleTrackerIntervals(t *testing.T) {
+	s := &scheduleTracker{targetRPS: 100, total: 300, timeout: 10 * time.Millisecond, seconds: make(map[int64]*scheduleSecond)}
+	first := time.Unix(1000, 0)
+
+	// 100 requests in the first second, 50 in the second,
+	// and the rest in the third second
+	for i := 0; i < 300; i++ {
+		scheduled := first.Add(time.Duration(i) * 10 * time.Millisecond)
+		start := scheduled
+		switch {
+		case i >= 150:
+			start = time.Unix(1002, 0)
+		case i >= 100:
+			start = time.Unix(1001, 0)
+		}
+		s.record(scheduled, start, start.Add(time.Millisecond), scheduled.Add(s.timeout))
+	}
+
+	ivs := s.intervals()
+	if len(ivs) != 3 {
+		t.Fatalf("expected 3 intervals, got %d", len(ivs))
+	}
+	if ivs[0].behind || ivs[0].backlog != 1 {
+		t.Fatalf("expected first interval on schedule, got %+v", ivs[0])
+	}
+	if !ivs[1].behind || ivs[1].backlog != 51 {
+		t.Fatalf("expected second interval behind with backlog 51, got %+v", ivs[1])
+	}
+	if ivs[2].behind || ivs[2].backlog != 0 {
+		t.Fatalf("expected third interval to catch up, got %+v", ivs[2])
+	}
+	if ivs[0].missed != 0 || ivs[2].missed != 50 {
+		t.Fatalf("unexpected deadline misses %d, %d", ivs[0].missed, ivs[2].missed)
+	}
+}
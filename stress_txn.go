@@ -0,0 +1,149 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"fmt"
+	mrand "math/rand"
+	"sync/atomic"
+	"time"
+
+	"github.com/coreos/dbtester/dbtesterpb"
+
+	"golang.org/x/net/context"
+)
+
+// txnFunc reads the keys, and writes the value to all keys in one
+// transaction only if none of the keys has changed since the read.
+// It returns false if the transaction is rolled back on conflict.
+type txnFunc func(ctx context.Context, keys []string, value []byte, tr *requestTrace) (bool, error)
+
+// txnStats counts the outcomes of 'txn' transactions.
+type txnStats struct {
+	overlapPercent int64
+	commits        int64
+	conflicts      int64
+}
+
+// successRate returns the percentage of transactions committed,
+// out of all transactions that did not fail with errors.
+func (ts *txnStats) successRate() float64 {
+	total := ts.commits + ts.conflicts
+	if total == 0 {
+		return 0
+	}
+	return 100 * float64(ts.commits) / float64(total)
+}
+
+// txnKeyIndexes returns the sequential key numbers of a transaction of
+// the client. Each key is one of the 'keyN' keys shared by all clients
+// with 'overlapPercent' probability, or one of the 'keyN' keys owned by
+// the client otherwise. All keys are in [0, keyN*(clientN+1)).
+func txnKeyIndexes(rnd *mrand.Rand, keyN, overlapPercent, client int64) []int64 {
+	idxs := make([]int64, keyN)
+	for i := range idxs {
+		if rnd.Int63n(100) < overlapPercent {
+			idxs[i] = int64(i)
+		} else {
+			idxs[i] = keyN*(client+1) + int64(i)
+		}
+	}
+	return idxs
+}
+
+// txnKeyTotal returns the number of keys that 'txn' transactions touch.
+func txnKeyTotal(gcfg dbtesterpb.ConfigClientMachineAgentControl) int64 {
+	opts := gcfg.ConfigClientMachineBenchmarkOptions
+	return opts.TxnKeyNumber * (opts.ClientNumber + 1)
+}
+
+func newTxnHandler(gcfg dbtesterpb.ConfigClientMachineAgentControl, tx txnFunc, prefix string, client int64, ts *txnStats) ReqHandler {
+	keyN := gcfg.ConfigClientMachineBenchmarkOptions.TxnKeyNumber
+	keySize := gcfg.ConfigClientMachineBenchmarkOptions.KeySizeBytes
+	// each handler is called by one client goroutine
+	rnd := mrand.New(mrand.NewSource(time.Now().UnixNano() + client))
+	return func(ctx context.Context, req *request) error {
+		idxs := txnKeyIndexes(rnd, keyN, ts.overlapPercent, client)
+		keys := make([]string, len(idxs))
+		for i, idx := range idxs {
+			keys[i] = prefix + namespaced(gcfg, sequentialKey(keySize, idx))
+		}
+		v := requestValue(req)
+		if req.trace != nil {
+			req.trace.requestBytes = len(keys) * (int(keySize) + len(v))
+		}
+
+		committed, err := tx(ctx, keys, v, req.trace)
+		if err != nil {
+			return err
+		}
+		if committed {
+			atomic.AddInt64(&ts.commits, 1)
+		} else {
+			atomic.AddInt64(&ts.conflicts, 1)
+		}
+		return nil
+	}
+}
+
+// newTxnHandlers returns the read-check-write transaction handlers.
+// Rolled back transactions are not errors, and counted in 'cfg.txnStats'.
+func (cfg *Config) newTxnHandlers(gcfg dbtesterpb.ConfigClientMachineAgentControl) (rhs []ReqHandler, done func(), err error) {
+	ts := &txnStats{overlapPercent: gcfg.ConfigClientMachineBenchmarkOptions.TxnOverlapPercent}
+	cfg.txnStats = ts
+
+	rhs = make([]ReqHandler, gcfg.ConfigClientMachineBenchmarkOptions.ClientNumber)
+	switch gcfg.DatabaseID {
+	case "etcd__other", "etcd__tip", "etcd__v3_2", "etcd__v3_3":
+		clients := mustCreateClientsEtcdv3(gcfg.DatabaseEndpoints, etcdv3ClientCfg{
+			totalConns:   gcfg.ConfigClientMachineBenchmarkOptions.ConnectionNumber,
+			totalClients: gcfg.ConfigClientMachineBenchmarkOptions.ClientNumber,
+		})
+		for i := range rhs {
+			rhs[i] = newTxnHandler(gcfg, newTxnEtcd3(clients[i]), "", int64(i), ts)
+		}
+		done = func() {
+			for i := range clients {
+				clients[i].Close()
+			}
+		}
+
+	case "zookeeper__r3_5_3_beta", "zetcd__beta":
+		conns := mustCreateConnsZk(gcfg.DatabaseEndpoints, gcfg.ConfigClientMachineBenchmarkOptions.ConnectionNumber)
+		for i := range rhs {
+			rhs[i] = newTxnHandler(gcfg, newTxnZK(conns[i%len(conns)]), "/", int64(i), ts)
+		}
+		done = func() {
+			for i := range conns {
+				conns[i].Close()
+			}
+		}
+
+	case "consul__v1_0_2", "cetcd__beta":
+		conns := mustCreateConnsConsul(gcfg.DatabaseEndpoints, gcfg.ConfigClientMachineBenchmarkOptions.ConnectionNumber)
+		for i := range rhs {
+			rhs[i] = newTxnHandler(gcfg, newTxnConsul(conns[i%len(conns)]), "", int64(i), ts)
+		}
+
+	case "mock":
+		for i := range rhs {
+			rhs[i] = newTxnHandler(gcfg, newTxnMock(gcfg.Flag_Mock), "", int64(i), ts)
+		}
+
+	default:
+		return nil, nil, fmt.Errorf("'txn' is not supported for %q", gcfg.DatabaseID)
+	}
+	return rhs, done, nil
+}
@@ -0,0 +1,59 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	mrand "math/rand"
+	"testing"
+
+	"golang.org/x/net/context"
+)
+
+func TestTxnKeyIndexes(t *testing.T) {
+	rnd := mrand.New(mrand.NewSource(1))
+	for _, idx := range txnKeyIndexes(rnd, 3, 0, 2) {
+		if idx < 9 || idx >= 12 {
+			t.Fatalf("expected keys owned by client in [9, 12), got %d", idx)
+		}
+	}
+	for i, idx := range txnKeyIndexes(rnd, 3, 100, 2) {
+		if idx != int64(i) {
+			t.Fatalf("expected shared key %d, got %d", i, idx)
+		}
+	}
+}
+
+func TestTxnMock(t *testing.T) {
+	keys := []string{"txn-a", "txn-b"}
+	tx := newTxnMock(nil)
+	if ok, err := tx(context.Background(), keys, []byte("v1"), nil); !ok || err != nil {
+		t.Fatalf("expected commit, got %v (%v)", ok, err)
+	}
+
+	// conflicting write between the read and the commit
+	vers := []int64{mockDB.version(keys[0]), mockDB.version(keys[1])}
+	mockDB.put(keys[1], []byte("other"))
+	if mockDB.cas(keys, vers, []byte("v2")) {
+		t.Fatal("expected conflict")
+	}
+	if v, _ := mockDB.get(keys[0]); string(v) != "v1" {
+		t.Fatalf("expected rolled back %q, got %q", "v1", v)
+	}
+
+	ts := &txnStats{commits: 3, conflicts: 1}
+	if ts.successRate() != 75 {
+		t.Fatalf("expected 75%%, got %f", ts.successRate())
+	}
+}
@@ -0,0 +1,481 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/coreos/dbtester/dbtesterpb"
+	"github.com/coreos/dbtester/pkg/hdrhistogram"
+
+	"github.com/gyuho/dataframe"
+	"github.com/olekukonko/tablewriter"
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+)
+
+// workerName returns the name of the worker, to save its own results with.
+func workerName(index int64) string { return fmt.Sprintf("worker-%d", index) }
+
+// workerShare returns the share of 'total' of the worker 'index' out of
+// 'number' workers, with the remainder to the first workers.
+func workerShare(total, index, number int64) int64 {
+	n := total / number
+	if index < total%number {
+		n++
+	}
+	return n
+}
+
+// forWorker returns the workload of the worker 'index' out of 'number'
+// workers, with its share of the clients, the requests and the rate,
+// and the index of its first key, so that the writes of the workers
+// do not overlap.
+func forWorker(gcfg dbtesterpb.ConfigClientMachineAgentControl, index, number int64) (dbtesterpb.ConfigClientMachineAgentControl, int64, error) {
+	if number < 1 || index < 0 || index >= number {
+		return gcfg, 0, fmt.Errorf("worker %d out of %d is invalid", index, number)
+	}
+	opts := *gcfg.ConfigClientMachineBenchmarkOptions
+	if len(opts.ConnectionClientNumbers) > 0 {
+		return gcfg, 0, fmt.Errorf("'connection_client_numbers' is not supported with workers")
+	}
+	if opts.ClientNumber < number {
+		return gcfg, 0, fmt.Errorf("'client_number' %d is less than the number of workers %d", opts.ClientNumber, number)
+	}
+	opts.ClientNumber = workerShare(opts.ClientNumber, index, number)
+	if opts.ConnectionNumber > 0 {
+		// at least a connection per worker
+		opts.ConnectionNumber = workerShare(opts.ConnectionNumber, index, number)
+		if opts.ConnectionNumber == 0 {
+			opts.ConnectionNumber = 1
+		}
+	}
+	var keyOffset int64
+	if total := opts.RequestNumber; total > 0 {
+		opts.RequestNumber = workerShare(total, index, number)
+		keyOffset = index*(total/number) + min64(index, total%number)
+	}
+	if rate := opts.RateLimitRequestsPerSecond; rate > 0 {
+		opts.RateLimitRequestsPerSecond = workerShare(rate, index, number)
+		if opts.RateLimitRequestsPerSecond == 0 {
+			opts.RateLimitRequestsPerSecond = 1
+		}
+	}
+	if index > 0 {
+		// the first worker maintains the cluster for all
+		opts.AutoCompactSeconds, opts.AutoDefrag = 0, false
+	}
+	gcfg.ConfigClientMachineBenchmarkOptions = &opts
+	return gcfg, keyOffset, nil
+}
+
+func min64(a, b int64) int64 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// workerSecond is the combined results of the workers in one second.
+type workerSecond struct {
+	requests     int64
+	errors       int64
+	minLatency   int64
+	maxLatency   int64
+	totalLatency int64
+}
+
+// workerTotal is the totals of one worker.
+type workerTotal struct {
+	loaderID string
+	requests int64
+	errors   int64
+	done     bool
+}
+
+// workerResults merges the interim results that the workers stream
+// back into the results by the second, as the 'collector' does, and
+// their latency histograms into one.
+type workerResults struct {
+	mu      sync.Mutex
+	seconds map[int64]*workerSecond
+	workers map[string]*workerTotal
+	lats    *hdrhistogram.Histogram
+	// latsErr is the first error to decode or merge the histograms
+	latsErr error
+}
+
+func newWorkerResults() *workerResults {
+	return &workerResults{
+		seconds: make(map[int64]*workerSecond),
+		workers: make(map[string]*workerTotal),
+	}
+}
+
+func (wr *workerResults) add(worker string, rs *dbtesterpb.InterimResults) {
+	wr.mu.Lock()
+	defer wr.mu.Unlock()
+
+	wt, ok := wr.workers[worker]
+	if !ok {
+		wt = &workerTotal{loaderID: rs.LoaderID}
+		wr.workers[worker] = wt
+	}
+	wt.done = rs.Done
+	if len(rs.LatencyHistogram) > 0 && wr.latsErr == nil {
+		wr.latsErr = wr.mergeLatencies(rs.LatencyHistogram)
+	}
+	for _, r := range rs.Results {
+		wt.requests += r.Requests
+		wt.errors += r.Errors
+
+		ws, ok := wr.seconds[r.UnixSecond]
+		if !ok {
+			ws = &workerSecond{minLatency: r.MinLatencyMicroseconds}
+			wr.seconds[r.UnixSecond] = ws
+		}
+		ws.requests += r.Requests
+		ws.errors += r.Errors
+		ws.totalLatency += r.TotalLatencyMicroseconds
+		if r.MinLatencyMicroseconds < ws.minLatency {
+			ws.minLatency = r.MinLatencyMicroseconds
+		}
+		if r.MaxLatencyMicroseconds > ws.maxLatency {
+			ws.maxLatency = r.MaxLatencyMicroseconds
+		}
+	}
+}
+
+// mergeLatencies merges the encoded histogram of a worker.
+// It must be called with 'wr.mu' held.
+func (wr *workerResults) mergeLatencies(bts []byte) error {
+	h, err := hdrhistogram.Decode(bts)
+	if err != nil {
+		return err
+	}
+	if wr.lats == nil {
+		wr.lats = h
+		return nil
+	}
+	return wr.lats.Merge(h)
+}
+
+// sortedSeconds returns the unix seconds of the results in order.
+// It must be called with 'wr.mu' held.
+func (wr *workerResults) sortedSeconds() []int64 {
+	secs := make([]int64, 0, len(wr.seconds))
+	for sec := range wr.seconds {
+		secs = append(secs, sec)
+	}
+	sort.Slice(secs, func(i, j int) bool { return secs[i] < secs[j] })
+	return secs
+}
+
+func microseconds(us int64) time.Duration { return time.Duration(us) * time.Microsecond }
+
+// save writes the merged time series, summary and percentiles to the
+// configured paths of the time series and the latency distribution
+// summary and percentiles, in the same columns as of a single loader.
+// Each worker also saves its own, with its name in the paths.
+func (wr *workerResults) save(cfg *Config, gcfg dbtesterpb.ConfigClientMachineAgentControl) error {
+	wr.mu.Lock()
+	defer wr.mu.Unlock()
+
+	secs := wr.sortedSeconds()
+	if len(secs) == 0 {
+		return fmt.Errorf("no results from the workers")
+	}
+	if wr.latsErr != nil {
+		return fmt.Errorf("failed to merge latency histograms (%v)", wr.latsErr)
+	}
+	if wr.lats != nil {
+		cfg.saveDataLatencyDistributionPercentile(runStats{lats: wr.lats})
+	}
+
+	c1 := dataframe.NewColumn("UNIX-SECOND")
+	c2 := dataframe.NewColumn("CONTROL-CLIENT-NUM")
+	c3 := dataframe.NewColumn("MIN-LATENCY-MS")
+	c4 := dataframe.NewColumn("AVG-LATENCY-MS")
+	c5 := dataframe.NewColumn("MAX-LATENCY-MS")
+	c6 := dataframe.NewColumn("AVG-THROUGHPUT")
+	var requests, errors, totalLatency int64
+	fastest, slowest := wr.seconds[secs[0]].minLatency, int64(0)
+	for _, sec := range secs {
+		ws := wr.seconds[sec]
+		requests += ws.requests
+		errors += ws.errors
+		totalLatency += ws.totalLatency
+		if ws.minLatency < fastest {
+			fastest = ws.minLatency
+		}
+		if ws.maxLatency > slowest {
+			slowest = ws.maxLatency
+		}
+		var avg int64
+		if ws.requests > 0 {
+			avg = ws.totalLatency / ws.requests
+		}
+		c1.PushBack(dataframe.NewStringValue(fmt.Sprintf("%d", sec)))
+		c2.PushBack(dataframe.NewStringValue(fmt.Sprintf("%d", gcfg.ConfigClientMachineBenchmarkOptions.ClientNumber)))
+		c3.PushBack(dataframe.NewStringValue(fmt.Sprintf("%f", toMillisecond(microseconds(ws.minLatency)))))
+		c4.PushBack(dataframe.NewStringValue(fmt.Sprintf("%f", toMillisecond(microseconds(avg)))))
+		c5.PushBack(dataframe.NewStringValue(fmt.Sprintf("%f", toMillisecond(microseconds(ws.maxLatency)))))
+		c6.PushBack(dataframe.NewStringValue(fmt.Sprintf("%d", ws.requests)))
+	}
+	fr := dataframe.New()
+	for _, c := range []dataframe.Column{c1, c2, c3, c4, c5, c6} {
+		if err := fr.AddColumn(c); err != nil {
+			return err
+		}
+	}
+	if err := saveCSV(fr, cfg.ConfigClientMachineInitial.ClientLatencyThroughputTimeseriesPath); err != nil {
+		return err
+	}
+
+	// the requests of the last second finish within it
+	seconds := float64(secs[len(secs)-1] - secs[0] + 1)
+	var avg int64
+	if requests > 0 {
+		avg = totalLatency / requests
+	}
+	sfr := dataframe.New()
+	for _, kv := range [][2]string{
+		{"TOTAL-SECONDS", fmt.Sprintf("%4.4f", seconds)},
+		{"REQUESTS-PER-SECOND", fmt.Sprintf("%4.4f", float64(requests)/seconds)},
+		{"SLOWEST-LATENCY-MS", fmt.Sprintf("%4.4f", toMillisecond(microseconds(slowest)))},
+		{"FASTEST-LATENCY-MS", fmt.Sprintf("%4.4f", toMillisecond(microseconds(fastest)))},
+		{"AVERAGE-LATENCY-MS", fmt.Sprintf("%4.4f", toMillisecond(microseconds(avg)))},
+		{"WORKER-NUM", fmt.Sprintf("%d", len(wr.workers))},
+		{"ERROR", fmt.Sprintf("%d", errors)},
+	} {
+		c := dataframe.NewColumn(kv[0])
+		c.PushBack(dataframe.NewStringValue(kv[1]))
+		if err := sfr.AddColumn(c); err != nil {
+			return err
+		}
+	}
+	return saveCSVHorizontal(sfr, cfg.ConfigClientMachineInitial.ClientLatencyDistributionSummaryPath)
+}
+
+// printWorkers prints the totals of each worker.
+func (wr *workerResults) printWorkers(w io.Writer) {
+	wr.mu.Lock()
+	defer wr.mu.Unlock()
+
+	names := make([]string, 0, len(wr.workers))
+	for name := range wr.workers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	tw := tablewriter.NewWriter(w)
+	tw.SetHeader([]string{"WORKER", "LOADER", "REQUESTS", "ERRORS", "DONE"})
+	for _, name := range names {
+		wt := wr.workers[name]
+		tw.Append([]string{name, wt.loaderID, fmt.Sprintf("%d", wt.requests), fmt.Sprintf("%d", wt.errors), fmt.Sprintf("%v", wt.done)})
+	}
+	tw.SetAutoFormatHeaders(false)
+	tw.Render()
+}
+
+// StressWorkers fans the workload out to the 'worker' processes of
+// 'Workers', each with its share of the clients, the requests and the
+// rate, so that the load is not limited by one client machine. The
+// interim results that the workers stream back are merged into the time
+// series and the summary of the configured paths.
+func (cfg *Config) StressWorkers(databaseID string) error {
+	gcfg, ok := cfg.DatabaseIDToConfigClientMachineAgentControl[databaseID]
+	if !ok {
+		return fmt.Errorf("%q does not exist", databaseID)
+	}
+	number := int64(len(cfg.Workers))
+	for i := int64(0); i < number; i++ {
+		if _, _, err := forWorker(gcfg, i, number); err != nil {
+			return err
+		}
+	}
+
+	ctx, cancel := context.WithCancel(cfg.runContext())
+	defer cancel()
+
+	wr := newWorkerResults()
+	errc := make(chan error, number)
+	for i, ep := range cfg.Workers {
+		cfg.lg.Info("stressing from worker", zap.String("worker", ep), zap.Int("index", i))
+		go func(i int64, ep string) {
+			err := cfg.stressWorker(ctx, gcfg, i, number, ep, wr)
+			if err != nil {
+				err = fmt.Errorf("worker %q: %v", ep, err)
+				// the others are stopped, since the results are incomplete
+				cancel()
+			}
+			errc <- err
+		}(int64(i), ep)
+	}
+	var errs []string
+	for range cfg.Workers {
+		if err := <-errc; err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+
+	wr.printWorkers(os.Stdout)
+	if err := wr.save(cfg, gcfg); err != nil {
+		errs = append(errs, fmt.Sprintf("failed to save merged results (%v)", err))
+	} else {
+		cfg.lg.Info("saved merged results of workers",
+			zap.String("timeseries-path", cfg.ConfigClientMachineInitial.ClientLatencyThroughputTimeseriesPath),
+			zap.String("summary-path", cfg.ConfigClientMachineInitial.ClientLatencyDistributionSummaryPath),
+		)
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// stressWorker sends the workload to the worker of the endpoint,
+// and merges the interim results that it streams back until done.
+func (cfg *Config) stressWorker(ctx context.Context, gcfg dbtesterpb.ConfigClientMachineAgentControl, index, number int64, ep string, wr *workerResults) error {
+	conn, err := grpc.Dial(ep, grpc.WithInsecure())
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	initial := cfg.ConfigClientMachineInitial
+	stream, err := dbtesterpb.NewWorkerClient(conn).Stress(ctx, &dbtesterpb.WorkerRequest{
+		ConfigClientMachineInitial:      &initial,
+		ConfigClientMachineAgentControl: &gcfg,
+		WorkerIndex:                     index,
+		WorkerNumber:                    number,
+		LatencyResolution:               int64(cfg.LatencyResolution),
+	})
+	if err != nil {
+		return err
+	}
+	for {
+		rs, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		wr.add(ep, rs)
+	}
+}
+
+// WorkerResultPaths returns the paths of the merged results
+// of the workers, that exist.
+func (cfg *Config) WorkerResultPaths() (paths []string) {
+	if len(cfg.Workers) == 0 {
+		return nil
+	}
+	for _, fpath := range []string{
+		cfg.ConfigClientMachineInitial.ClientLatencyThroughputTimeseriesPath,
+		cfg.ConfigClientMachineInitial.ClientLatencyDistributionSummaryPath,
+		cfg.ConfigClientMachineInitial.ClientLatencyDistributionPercentilePath,
+	} {
+		if fpath != "" && exist(fpath) {
+			paths = append(paths, fpath)
+		}
+	}
+	return paths
+}
+
+// workerCollector sends the interim results of the worker
+// back to the coordinator, on the stream of its request.
+type workerCollector struct {
+	stream dbtesterpb.Worker_StressServer
+}
+
+func (c *workerCollector) Report(ctx context.Context, rs *dbtesterpb.InterimResults, opts ...grpc.CallOption) (*dbtesterpb.CollectorResponse, error) {
+	if err := c.stream.Send(rs); err != nil {
+		return nil, err
+	}
+	return &dbtesterpb.CollectorResponse{Success: true}, nil
+}
+
+type workerServer struct {
+	lg *zap.Logger
+
+	mu   sync.Mutex
+	busy bool
+}
+
+// NewWorkerServer returns the server of the 'worker' command, that runs
+// the share of the workload of the coordinating 'control' from its
+// client machine, with the results saved with the worker name in the
+// paths of the coordinator.
+func NewWorkerServer(lg *zap.Logger) dbtesterpb.WorkerServer {
+	return &workerServer{lg: lg}
+}
+
+// Stress runs the workload, one at a time since the clients share
+// the dialing state, streaming back the interim results every second.
+func (s *workerServer) Stress(req *dbtesterpb.WorkerRequest, stream dbtesterpb.Worker_StressServer) error {
+	if req.ConfigClientMachineInitial == nil || req.ConfigClientMachineAgentControl == nil {
+		return fmt.Errorf("no workload in the request")
+	}
+	gcfg, keyOffset, err := forWorker(*req.ConfigClientMachineAgentControl, req.WorkerIndex, req.WorkerNumber)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	if s.busy {
+		s.mu.Unlock()
+		return fmt.Errorf("worker is already stressing")
+	}
+	s.busy = true
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		s.busy = false
+		s.mu.Unlock()
+	}()
+
+	name := workerName(req.WorkerIndex)
+	cfg := &Config{
+		lg:                         s.lg.With(zap.String("worker", name)),
+		ConfigClientMachineInitial: *req.ConfigClientMachineInitial,
+		DatabaseIDToConfigClientMachineAgentControl: map[string]dbtesterpb.ConfigClientMachineAgentControl{gcfg.DatabaseID: gcfg},
+		ProgressInterval:  DefaultProgressInterval,
+		LatencyResolution: int(req.LatencyResolution),
+		Context:           stream.Context(),
+		collectorClient:   &workerCollector{stream: stream},
+		keyOffset:         keyOffset,
+	}
+	for _, p := range cfg.clusterResultPaths() {
+		*p = clusterPath(*p, name)
+	}
+	cfg.lg.Info("stressing share of workload",
+		zap.String("database", gcfg.DatabaseID),
+		zap.Int64("clients", gcfg.ConfigClientMachineBenchmarkOptions.ClientNumber),
+		zap.Int64("requests", gcfg.ConfigClientMachineBenchmarkOptions.RequestNumber),
+		zap.Int64("key-offset", keyOffset),
+	)
+	if err = cfg.Stress(gcfg.DatabaseID); err != nil {
+		return err
+	}
+	return stream.Context().Err()
+}
@@ -0,0 +1,185 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/coreos/dbtester/dbtesterpb"
+	"github.com/coreos/dbtester/pkg/hdrhistogram"
+
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+)
+
+func TestForWorker(t *testing.T) {
+	gcfg := dbtesterpb.ConfigClientMachineAgentControl{
+		DatabaseID: "mock",
+		ConfigClientMachineBenchmarkOptions: &dbtesterpb.ConfigClientMachineBenchmarkOptions{
+			ClientNumber:               10,
+			ConnectionNumber:           2,
+			RequestNumber:              100,
+			RateLimitRequestsPerSecond: 1000,
+		},
+	}
+	var clients, requests, rate int64
+	next := int64(0)
+	for i := int64(0); i < 3; i++ {
+		w, keyOffset, err := forWorker(gcfg, i, 3)
+		if err != nil {
+			t.Fatal(err)
+		}
+		opts := w.ConfigClientMachineBenchmarkOptions
+		if keyOffset != next {
+			t.Fatalf("worker %d: expected key offset %d, got %d", i, next, keyOffset)
+		}
+		if opts.ConnectionNumber < 1 {
+			t.Fatalf("worker %d: expected a connection, got %d", i, opts.ConnectionNumber)
+		}
+		next += opts.RequestNumber
+		clients += opts.ClientNumber
+		requests += opts.RequestNumber
+		rate += opts.RateLimitRequestsPerSecond
+	}
+	if clients != 10 || requests != 100 || rate != 1000 {
+		t.Fatalf("expected the shares to add up to 10, 100, 1000, got %d, %d, %d", clients, requests, rate)
+	}
+	if gcfg.ConfigClientMachineBenchmarkOptions.ClientNumber != 10 {
+		t.Fatal("original configuration is changed")
+	}
+
+	if _, _, err := forWorker(gcfg, 0, 11); err == nil {
+		t.Fatal("expected error of more workers than clients")
+	}
+	gcfg.ConfigClientMachineBenchmarkOptions.ConnectionClientNumbers = []int64{1, 2}
+	if _, _, err := forWorker(gcfg, 0, 2); err == nil {
+		t.Fatal("expected error of 'connection_client_numbers'")
+	}
+}
+
+// fakeWorker streams back the results of one second, of as many
+// requests as its share of the requests, each of (index+1) milliseconds.
+type fakeWorker struct{}
+
+func (fakeWorker) Stress(req *dbtesterpb.WorkerRequest, stream dbtesterpb.Worker_StressServer) error {
+	gcfg, _, err := forWorker(*req.ConfigClientMachineAgentControl, req.WorkerIndex, req.WorkerNumber)
+	if err != nil {
+		return err
+	}
+	n := gcfg.ConfigClientMachineBenchmarkOptions.RequestNumber
+	lats, err := hdrhistogram.New(int64(latencyHighest/time.Microsecond), int(req.LatencyResolution))
+	if err != nil {
+		return err
+	}
+	for i := int64(0); i < n; i++ {
+		lats.Record(1000 * (req.WorkerIndex + 1))
+	}
+	return stream.Send(&dbtesterpb.InterimResults{
+		LoaderID:         fmt.Sprintf("fake-%d", req.WorkerIndex),
+		Done:             true,
+		LatencyHistogram: lats.Encode(),
+		Results: []*dbtesterpb.InterimResult{
+			{UnixSecond: 10, Requests: n, Errors: req.WorkerIndex, MinLatencyMicroseconds: 1000, MaxLatencyMicroseconds: 3000 * (req.WorkerIndex + 1), TotalLatencyMicroseconds: 2000 * n},
+		},
+	})
+}
+
+func TestStressWorkers(t *testing.T) {
+	dir, err := ioutil.TempDir("", "workers")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	var eps []string
+	for i := 0; i < 2; i++ {
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatal(err)
+		}
+		srv := grpc.NewServer()
+		dbtesterpb.RegisterWorkerServer(srv, fakeWorker{})
+		go srv.Serve(ln)
+		defer srv.Stop()
+		eps = append(eps, ln.Addr().String())
+	}
+
+	cfg := &Config{
+		lg:      zap.NewNop(),
+		Workers: eps,
+		// sent to the workers
+		LatencyResolution: 2,
+		DatabaseIDToConfigClientMachineAgentControl: map[string]dbtesterpb.ConfigClientMachineAgentControl{
+			"mock": {
+				DatabaseID:                          "mock",
+				ConfigClientMachineBenchmarkOptions: &dbtesterpb.ConfigClientMachineBenchmarkOptions{ClientNumber: 4, RequestNumber: 7},
+			},
+		},
+	}
+	cfg.ClientLatencyThroughputTimeseriesPath = filepath.Join(dir, "timeseries.csv")
+	cfg.ClientLatencyDistributionSummaryPath = filepath.Join(dir, "summary.csv")
+	cfg.ClientLatencyDistributionPercentilePath = filepath.Join(dir, "percentile.csv")
+	if err = cfg.StressWorkers("mock"); err != nil {
+		t.Fatal(err)
+	}
+
+	bts, err := ioutil.ReadFile(cfg.ClientLatencyThroughputTimeseriesPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(bts)), "\n")
+	if exp := "10,4,1.000000,2.000000,6.000000,7"; len(lines) != 2 || lines[1] != exp {
+		t.Fatalf("expected the merged second %q, got %q", exp, lines)
+	}
+	bts, err = ioutil.ReadFile(cfg.ClientLatencyDistributionSummaryPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, exp := range []string{"REQUESTS-PER-SECOND,7.0000", "WORKER-NUM,2", "ERROR,1"} {
+		if !strings.Contains(string(bts), exp) {
+			t.Fatalf("expected %q in the summary, got %q", exp, bts)
+		}
+	}
+	bts, err = ioutil.ReadFile(cfg.ClientLatencyDistributionPercentilePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 4 requests of 1 ms and 3 of 2 ms, from the merged histograms
+	for _, exp := range []string{"\np50,1.00", "\np75,2.0", "\np99,2.0"} {
+		if !strings.Contains(string(bts), exp) {
+			t.Fatalf("expected %q in the percentiles, got %q", exp, bts)
+		}
+	}
+	if paths := cfg.WorkerResultPaths(); len(paths) != 3 {
+		t.Fatalf("expected 3 result paths, got %q", paths)
+	}
+
+	// a worker that is not reachable fails the run
+	cfg.Workers = []string{eps[0], "127.0.0.1:1"}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cfg.Context = ctx
+	if err = cfg.StressWorkers("mock"); err == nil || !strings.Contains(err.Error(), "127.0.0.1:1") {
+		t.Fatalf("expected error of the unreachable worker, got %v", err)
+	}
+}
@@ -0,0 +1,617 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/coreos/dbtester/dbtesterpb"
+
+	"github.com/coreos/etcd/clientv3"
+	"github.com/coreos/etcd/pkg/report"
+	"github.com/gyuho/dataframe"
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+)
+
+// workloadResult is the result of one of the 'mixed' workloads.
+type workloadResult struct {
+	name    string
+	typ     string
+	clientN int64
+	emptyN  int64
+	stats   runStats
+	// class is the priority of the workload, nil if none
+	class *priorityClass
+	// watch is the result of a 'watch' workload, nil if not
+	watch *watchWorkloadResult
+}
+
+// workloadShare returns the share of 'n' for the workload, at least 1.
+func workloadShare(n, percent int64) int64 {
+	if v := n * percent / 100; v > 0 {
+		return v
+	}
+	return 1
+}
+
+// newWorkloadConfig returns the options of the workload,
+// with its share of the requests and clients.
+func newWorkloadConfig(gcfg dbtesterpb.ConfigClientMachineAgentControl, wl *dbtesterpb.ConfigClientMachineWorkload) dbtesterpb.ConfigClientMachineAgentControl {
+	copied := gcfg
+	opts := *gcfg.ConfigClientMachineBenchmarkOptions
+	copied.ConfigClientMachineBenchmarkOptions = &opts
+
+	opts.Type = wl.Type
+	opts.RequestNumber = workloadShare(gcfg.ConfigClientMachineBenchmarkOptions.RequestNumber, wl.Percent)
+	opts.ClientNumber = workloadShare(gcfg.ConfigClientMachineBenchmarkOptions.ClientNumber, wl.Percent)
+	opts.ConnectionNumber = workloadShare(gcfg.ConfigClientMachineBenchmarkOptions.ConnectionNumber, wl.Percent)
+	if opts.ConnectionNumber > opts.ClientNumber {
+		opts.ConnectionNumber = opts.ClientNumber
+	}
+	opts.RateLimitRequestsPerSecond = wl.RateLimitRequestsPerSecond
+	opts.ConfigClientMachineWorkloads = nil
+	return copied
+}
+
+func validateWorkloads(gcfg dbtesterpb.ConfigClientMachineAgentControl) error {
+	opts := gcfg.ConfigClientMachineBenchmarkOptions
+	if len(opts.ConfigClientMachineWorkloads) == 0 {
+		return fmt.Errorf("'mixed' requires 'workloads'")
+	}
+	switch {
+	case opts.ConfigClientMachineRollingRestart != nil:
+		return fmt.Errorf("'rolling_restart' is not supported for 'mixed'")
+	case opts.ConfigClientMachineHealthRouting != nil:
+		return fmt.Errorf("'health_routing' is not supported for 'mixed'")
+	case opts.HedgeAfterMicroseconds > 0:
+		return fmt.Errorf("'hedge_after_microseconds' is not supported for 'mixed'")
+	case len(opts.ConnectionClientNumbers) > 0:
+		return fmt.Errorf("'connection_client_numbers' is not supported for 'mixed'")
+	case opts.MeasureInterference && len(opts.ConfigClientMachineWorkloads) < 2:
+		return fmt.Errorf("'measure_interference' requires at least 2 workloads")
+	}
+
+	names := make(map[string]struct{})
+	total := int64(0)
+	for _, wl := range opts.ConfigClientMachineWorkloads {
+		if wl.Name == "" {
+			return fmt.Errorf("workload of type %q has no 'name'", wl.Type)
+		}
+		if _, ok := names[wl.Name]; ok {
+			return fmt.Errorf("duplicate workload %q", wl.Name)
+		}
+		names[wl.Name] = struct{}{}
+
+		switch wl.Type {
+		case "write":
+		case "delete":
+			// deletes alone would leave no keys for the workloads together
+			if opts.MeasureInterference {
+				return fmt.Errorf("workload %q of type 'delete' is not supported with 'measure_interference'", wl.Name)
+			}
+		case "read":
+			if opts.Prepopulate <= 0 {
+				return fmt.Errorf("workload %q of type %q requires 'prepopulate'", wl.Name, wl.Type)
+			}
+		case "watch":
+			if err := validateWatchWorkload(gcfg, wl); err != nil {
+				return err
+			}
+			// watchers take no share of the requests and clients
+			continue
+		default:
+			return fmt.Errorf("workload %q has unsupported type %q (expected 'write', 'read', 'delete' or 'watch')", wl.Name, wl.Type)
+		}
+		if wl.Watchers != 0 {
+			return fmt.Errorf("workload %q of type %q has 'watchers' (only for 'watch')", wl.Name, wl.Type)
+		}
+		if wl.Percent <= 0 {
+			return fmt.Errorf("workload %q has non-positive 'percent' %d", wl.Name, wl.Percent)
+		}
+		if opts.OpenLoop && wl.RateLimitRequestsPerSecond <= 0 {
+			return fmt.Errorf("'open_loop' requires 'rate_limit_requests_per_second' of workload %q", wl.Name)
+		}
+		total += wl.Percent
+	}
+	if total != 100 {
+		return fmt.Errorf("workload 'percent' must add up to 100 (got %d)", total)
+	}
+	return nil
+}
+
+// runWorkloads runs the 'mixed' workloads concurrently, each with its own
+// clients and rate limit, and reports each workload and all combined.
+// Reads are on the prepopulated keys, and writes and deletes are on their
+// own new keys after them, so that the workloads do not depend on each
+// other. The keys of the deletes are written before the workloads start.
+func (cfg *Config) runWorkloads(gcfg dbtesterpb.ConfigClientMachineAgentControl, vals values) error {
+	if err := validateWorkloads(gcfg); err != nil {
+		return err
+	}
+	if err := validatePriorities(gcfg.ConfigClientMachineBenchmarkOptions); err != nil {
+		return err
+	}
+	if gcfg.ConfigClientMachineBenchmarkOptions.Prepopulate > 0 {
+		if err := cfg.prepopulate(gcfg, vals); err != nil {
+			return err
+		}
+	}
+
+	wls := gcfg.ConfigClientMachineBenchmarkOptions.ConfigClientMachineWorkloads
+	writeIdx := gcfg.ConfigClientMachineBenchmarkOptions.Prepopulate
+
+	var isolated []runStats
+	if gcfg.ConfigClientMachineBenchmarkOptions.MeasureInterference {
+		isolated = cfg.runIsolatedWorkloads(gcfg, vals, &writeIdx)
+	}
+
+	deleteIdx := make([]int64, len(wls))
+	for i, wl := range wls {
+		if wl.Type != "delete" {
+			continue
+		}
+		wcfg := newWorkloadConfig(gcfg, wl)
+		n := deleteKeyN(wcfg)
+		deleteIdx[i] = writeIdx
+		writeIdx += n
+		// deleted keys are not saved to the key manifest
+		if err := cfg.prepopulateRange(wcfg, vals, deleteIdx[i], n, nil); err != nil {
+			return err
+		}
+	}
+
+	pc := newPriorityController(cfg.lg, gcfg.ConfigClientMachineBenchmarkOptions)
+	results := make([]workloadResult, len(wls))
+	bs := make([]*benchmark, len(wls))
+	for i, wl := range wls {
+		if wl.Type == "watch" {
+			results[i] = workloadResult{name: wl.Name, typ: wl.Type, clientN: wl.Watchers}
+			continue
+		}
+		wcfg := newWorkloadConfig(gcfg, wl)
+		startIdx := deleteIdx[i]
+		if wl.Type == "write" {
+			startIdx = reserveKeys(wcfg, &writeIdx)
+		}
+		bs[i] = cfg.newWorkloadBenchmark(wcfg, wl, vals, startIdx)
+		results[i] = workloadResult{name: wl.Name, typ: wl.Type, clientN: wcfg.ConfigClientMachineBenchmarkOptions.ClientNumber}
+		if c := pc.class(wl.Name); c != nil {
+			bs[i].reqGen = c.gate(bs[i].reqGen)
+			results[i].class = c
+		}
+
+		cfg.lg.Info("starting workload",
+			zap.String("name", wl.Name),
+			zap.String("type", wl.Type),
+			zap.Int64("requests", wcfg.ConfigClientMachineBenchmarkOptions.RequestNumber),
+			zap.Int64("clients", wcfg.ConfigClientMachineBenchmarkOptions.ClientNumber),
+			zap.Int64("rate-limit", wcfg.ConfigClientMachineBenchmarkOptions.RateLimitRequestsPerSecond),
+			zap.String("priority", wl.Priority),
+		)
+	}
+
+	// watchers are registered before the other workloads start
+	watchers := make([]*workloadWatchers, len(wls))
+	for i, wl := range wls {
+		if wl.Type != "watch" {
+			continue
+		}
+		w, err := cfg.startWorkloadWatchers(gcfg, wl)
+		if err != nil {
+			for _, w := range watchers {
+				if w != nil {
+					w.stop()
+				}
+			}
+			return err
+		}
+		watchers[i] = w
+	}
+
+	var stopConvergenceProbe func()
+	if cfg.convergenceProbe != nil {
+		stopConvergenceProbe = cfg.startConvergenceProbe(gcfg)
+	}
+	var stopChaos func()
+	if gcfg.ConfigClientMachineBenchmarkOptions.Chaos != "" {
+		stopChaos = cfg.startChaos(gcfg)
+	}
+	var stopMaintenance func()
+	if gcfg.ConfigClientMachineBenchmarkOptions.AutoCompactSeconds > 0 {
+		stopMaintenance = cfg.startMaintenance(gcfg)
+	}
+
+	var stopPriorities func()
+	if pc != nil {
+		stopPriorities = pc.start()
+	}
+
+	var wg sync.WaitGroup
+	for _, b := range bs {
+		if b == nil {
+			continue
+		}
+		wg.Add(1)
+		b.startRequests()
+		go func(b *benchmark) {
+			defer wg.Done()
+			b.waitAll()
+		}(b)
+	}
+	wg.Wait()
+	for i, w := range watchers {
+		if w != nil {
+			rs := w.stop()
+			results[i].watch = &rs
+		}
+	}
+	if stopPriorities != nil {
+		stopPriorities()
+	}
+	if stopChaos != nil {
+		stopChaos()
+	}
+	if stopMaintenance != nil {
+		// wait for the maintenance in progress if any
+		stopMaintenance()
+	}
+	if stopConvergenceProbe != nil {
+		stopConvergenceProbe()
+	}
+
+	var stats []runStats
+	var traces []requestTrace
+	clientN := int64(0)
+	for i, b := range bs {
+		if w := results[i].watch; w != nil {
+			cfg.lg.Sugar().Infof("workload %q [type: watch | watchers: %d | events: %d | errors: %d | events per second: %.4f | maximum lag: %d revisions]",
+				results[i].name, w.watchers, w.events, w.errors, w.eventsPerSecond, w.maxLag)
+			continue
+		}
+		results[i].stats, results[i].emptyN = b.stats, b.emptyN
+		stats = append(stats, b.stats)
+		traces = append(traces, b.traces...)
+		clientN += results[i].clientN
+		cfg.emptyResponses += b.emptyN
+
+		cfg.lg.Sugar().Infof("workload %q [type: %s | requests: %d | empty: %d | errors: %d | RPS: %.4f | average latency: %.4f ms]",
+			results[i].name, results[i].typ, b.stats.requests(), b.emptyN, errorTotal(b.stats), b.stats.RPS, 1000*b.stats.Average)
+		if c := results[i].class; c != nil && c.priority == priorityGuaranteed {
+			cfg.lg.Sugar().Infof("workload %q [priority: %s | target RPS: %s | achieved RPS: %.4f | seconds at target: %d/%d]",
+				c.name, c.priority, c.targetString(), b.stats.RPS, c.metSeconds, c.seconds)
+		} else if c != nil {
+			cfg.lg.Sugar().Infof("workload %q [priority: %s | target RPS: %s | achieved RPS: %.4f | throttled seconds: %d/%d]",
+				c.name, c.priority, c.targetString(), b.stats.RPS, c.throttles, c.seconds)
+		}
+	}
+
+	combined := combineConcurrentStats(stats)
+	cfg.lg.Info("combined all workloads")
+	cfg.printResults(combined)
+
+	clientNs := make([]int64, len(combined.TimeSeries))
+	for i := range clientNs {
+		clientNs[i] = clientN
+	}
+	cfg.saveAllStats(gcfg, combined, clientNs)
+	if len(traces) > 0 {
+		cfg.saveRequestTraceSample(traces)
+	}
+	if isolated != nil {
+		if err := cfg.saveInterference(newInterferenceResults(results, isolated)); err != nil {
+			return err
+		}
+	}
+	return cfg.saveWorkloadSummary(results)
+}
+
+// reserveKeys returns the index of the first of the new keys of the
+// workload, and advances 'writeIdx' by its number of requests.
+func reserveKeys(wcfg dbtesterpb.ConfigClientMachineAgentControl, writeIdx *int64) int64 {
+	startIdx := *writeIdx
+	*writeIdx += wcfg.ConfigClientMachineBenchmarkOptions.RequestNumber
+	return startIdx
+}
+
+// deleteKeyN returns the number of the keys of a delete workload,
+// the keys of its requests in 'request_number' or 'duration_seconds'.
+func deleteKeyN(wcfg dbtesterpb.ConfigClientMachineAgentControl) int64 {
+	if n := expectedRequests(wcfg); n > 0 {
+		return n
+	}
+	return wcfg.ConfigClientMachineBenchmarkOptions.RequestNumber
+}
+
+// newWorkloadBenchmark returns the benchmark of the workload, with its
+// own clients. Writes and deletes are on the keys from 'startIdx'.
+func (cfg *Config) newWorkloadBenchmark(wcfg dbtesterpb.ConfigClientMachineAgentControl, wl *dbtesterpb.ConfigClientMachineWorkload, vals values, startIdx int64) *benchmark {
+	var h []ReqHandler
+	var done func()
+	var reqGen func(context.Context, chan<- request)
+	switch wl.Type {
+	case "write":
+		h, done = newWriteHandlers(cfg.lg, wcfg)
+		reqGen = func(ctx context.Context, inflightReqs chan<- request) {
+			generateWrites(ctx, wcfg, startIdx, vals, inflightReqs)
+		}
+	case "read":
+		h, done = newReadHandlers(wcfg)
+		reqGen = func(ctx context.Context, inflightReqs chan<- request) {
+			generateReads(ctx, wcfg, "", cfg.keysFrom, inflightReqs)
+		}
+	case "delete":
+		h, done = newDeleteHandlers(wcfg)
+		reqGen = func(ctx context.Context, inflightReqs chan<- request) {
+			generateDeletes(ctx, wcfg, startIdx, cfg.keysFrom, inflightReqs)
+		}
+	}
+
+	b := newBenchmark(wcfg.ConfigClientMachineBenchmarkOptions.RequestNumber, wcfg.ConfigClientMachineBenchmarkOptions.ClientNumber, h, done, reqGen)
+	// the progress of concurrent benchmarks would interleave
+	b.progress.interval = 0
+	b.traceEvery = traceEvery(wcfg)
+	b.databaseID = wcfg.DatabaseID
+	b.typ = wl.Type
+	b.openLoop = wcfg.ConfigClientMachineBenchmarkOptions.OpenLoop
+	b.collector = cfg.collector
+	b.reqTimeout = requestTimeout(wcfg)
+	b.rampUp = rampUpDuration(wcfg)
+	b.warmup = newWarmupWindow(wcfg)
+	b.ctx = cfg.runContext()
+	if d := runDuration(wcfg); d > 0 {
+		b.progress.total, b.progress.duration = 0, d
+	}
+	b.series = newTieredTimeSeries(wcfg)
+	b.sizes = cfg.sizes
+	b.spikes = cfg.spikes
+	b.members = cfg.members
+	b.zones = cfg.zones
+	b.mergeLatenciesInto(cfg.latencies)
+	b.live = cfg.live
+	b.timeseries = cfg.timeseries
+	if wl.Type == "write" {
+		b.keys = cfg.keys
+	}
+	return b
+}
+
+func errorTotal(st runStats) int {
+	n := 0
+	for _, v := range st.ErrorDist {
+		n += v
+	}
+	return n
+}
+
+// combineConcurrentStats combines the stats of the benchmarks that ran
+// at the same time. Time series are merged by the second.
+func combineConcurrentStats(stats []runStats) runStats {
+	combined := runStats{ErrorDist: make(map[string]int)}
+	points := make(map[int64]report.DataPoint)
+	for _, st := range stats {
+		combined.mergeLatencies(st)
+		if st.Total > combined.Total {
+			combined.Total = st.Total
+		}
+		for _, p := range st.TimeSeries {
+			cur, ok := points[p.Timestamp]
+			if !ok {
+				points[p.Timestamp] = p
+				continue
+			}
+			if p.MinLatency < cur.MinLatency {
+				cur.MinLatency = p.MinLatency
+			}
+			if p.MaxLatency > cur.MaxLatency {
+				cur.MaxLatency = p.MaxLatency
+			}
+			// weighted by the number of requests in the second
+			if n := cur.ThroughPut + p.ThroughPut; n > 0 {
+				cur.AvgLatency = time.Duration((int64(cur.AvgLatency)*cur.ThroughPut + int64(p.AvgLatency)*p.ThroughPut) / n)
+			}
+			cur.ThroughPut += p.ThroughPut
+			points[p.Timestamp] = cur
+		}
+	}
+	for _, p := range points {
+		combined.TimeSeries = append(combined.TimeSeries, p)
+	}
+	sort.Sort(combined.TimeSeries)
+
+	combined.summarize()
+	return combined
+}
+
+func newDeleteHandlers(gcfg dbtesterpb.ConfigClientMachineAgentControl) (rhs []ReqHandler, done func()) {
+	rhs = make([]ReqHandler, gcfg.ConfigClientMachineBenchmarkOptions.ClientNumber)
+	switch gcfg.DatabaseID {
+	case "etcd__other", "etcd__tip", "etcd__v3_2", "etcd__v3_3":
+		clients := mustCreateClientsEtcdv3(gcfg.DatabaseEndpoints, etcdv3ClientCfg{
+			totalConns:   gcfg.ConfigClientMachineBenchmarkOptions.ConnectionNumber,
+			totalClients: gcfg.ConfigClientMachineBenchmarkOptions.ClientNumber,
+		})
+		for i := range clients {
+			rhs[i] = newDeleteEtcd3(clients[i].KV)
+		}
+		done = func() {
+			for i := range clients {
+				clients[i].Close()
+			}
+		}
+
+	case "zookeeper__r3_5_3_beta", "zetcd__beta":
+		conns := mustCreateConnsZk(gcfg.DatabaseEndpoints, gcfg.ConfigClientMachineBenchmarkOptions.ConnectionNumber)
+		for i := range rhs {
+			rhs[i] = newDeleteZK(conns[i%len(conns)])
+		}
+		done = func() {
+			for i := range conns {
+				conns[i].Close()
+			}
+		}
+
+	case "consul__v1_0_2", "cetcd__beta":
+		conns := mustCreateConnsConsul(gcfg.DatabaseEndpoints, gcfg.ConfigClientMachineBenchmarkOptions.ConnectionNumber)
+		for i := range rhs {
+			rhs[i] = newDeleteConsul(conns[i%len(conns)])
+		}
+
+	case "redis__v4_0":
+		clis := mustCreateConnsRedis(gcfg.DatabaseEndpoints, gcfg.ConfigClientMachineBenchmarkOptions.ConnectionNumber, gcfg.Flag_Redis_V4_0)
+		for i := range rhs {
+			rhs[i] = newDeleteRedis(clis[i%len(clis)])
+		}
+		done = closeConnsRedis(clis)
+
+	case "tikv__v2_1":
+		clis := mustCreateConnsTikv(gcfg, gcfg.ConfigClientMachineBenchmarkOptions.ConnectionNumber)
+		for i := range rhs {
+			rhs[i] = newDeleteTikv(clis[i%len(clis)])
+		}
+		done = closeConnsTikv(clis)
+
+	case "mock":
+		for i := range rhs {
+			rhs[i] = newDeleteMock(gcfg.Flag_Mock)
+		}
+
+	default:
+		panic(fmt.Sprintf("%q is unknown database ID", gcfg.DatabaseID))
+	}
+	return rhs, done
+}
+
+// generateDeletes deletes the keys of 'deleteKeyN' from 'startIdx' in
+// order, or 'keys' from '--keys-from' if not empty.
+func generateDeletes(ctx context.Context, gcfg dbtesterpb.ConfigClientMachineAgentControl, startIdx int64, keys []string, inflightReqs chan<- request) {
+	defer close(inflightReqs)
+
+	fd := newFeeder(gcfg)
+	n := deleteKeyN(gcfg)
+	limit := newRequestLimit(ctx, gcfg)
+	for i := int64(0); limit.more(i); i++ {
+		var k string
+		if len(keys) > 0 {
+			k = keys[i%int64(len(keys))]
+		} else {
+			k = namespaced(gcfg, sequentialKey(gcfg.ConfigClientMachineBenchmarkOptions.KeySizeBytes, startIdx+i%n))
+		}
+
+		var req request
+		switch gcfg.DatabaseID {
+		case "etcd__other", "etcd__tip", "etcd__v3_2", "etcd__v3_3":
+			req = request{etcdv3Op: clientv3.OpDelete(k)}
+		case "zookeeper__r3_5_3_beta", "zetcd__beta":
+			req = request{zkOp: zkOp{key: k}}
+		case "consul__v1_0_2", "cetcd__beta":
+			req = request{consulOp: consulOp{key: k}}
+		case "redis__v4_0":
+			req = request{redisOp: redisOp{key: k}}
+		case "tikv__v2_1":
+			req = request{tikvOp: tikvOp{key: k}}
+		case "mock":
+			req = request{mockOp: mockOp{key: k}}
+		default:
+			panic(fmt.Sprintf("%q is unknown database ID", gcfg.DatabaseID))
+		}
+		req.seq = i
+		req.scheduled = fd.next()
+		if !limit.issue(inflightReqs, req) {
+			return
+		}
+	}
+}
+
+func (cfg *Config) saveWorkloadSummary(results []workloadResult) error {
+	fpath := cfg.ConfigClientMachineInitial.ClientWorkloadSummaryPath
+	if fpath == "" {
+		cfg.lg.Warn("'client_workload_summary_path' is not set; skipping workload summary")
+		return nil
+	}
+
+	c1 := dataframe.NewColumn("WORKLOAD")
+	c2 := dataframe.NewColumn("TYPE")
+	c3 := dataframe.NewColumn("CLIENT-NUM")
+	c4 := dataframe.NewColumn("REQUESTS")
+	c5 := dataframe.NewColumn("EMPTY-RESPONSE-COUNT")
+	c6 := dataframe.NewColumn("ERRORS")
+	c7 := dataframe.NewColumn("AVG-THROUGHPUT")
+	c8 := dataframe.NewColumn("AVERAGE-LATENCY-MS")
+	c9 := dataframe.NewColumn("SLOWEST-LATENCY-MS")
+	c10 := dataframe.NewColumn("PRIORITY")
+	c11 := dataframe.NewColumn("TARGET-THROUGHPUT")
+	c12 := dataframe.NewColumn("SECONDS-AT-TARGET")
+	c13 := dataframe.NewColumn("THROTTLED-SECONDS")
+	c14 := dataframe.NewColumn("WATCH-EVENTS")
+	c15 := dataframe.NewColumn("WATCH-EVENTS-PER-SECOND")
+	c16 := dataframe.NewColumn("WATCH-MAX-LAG-REVISIONS")
+	withClass, withWatch := false, false
+	for _, r := range results {
+		c1.PushBack(dataframe.NewStringValue(r.name))
+		c2.PushBack(dataframe.NewStringValue(r.typ))
+		c3.PushBack(dataframe.NewStringValue(r.clientN))
+		c4.PushBack(dataframe.NewStringValue(r.stats.requests()))
+		c5.PushBack(dataframe.NewStringValue(r.emptyN))
+		if w := r.watch; w != nil {
+			withWatch = true
+			c6.PushBack(dataframe.NewStringValue(w.errors))
+			c14.PushBack(dataframe.NewStringValue(w.events))
+			c15.PushBack(dataframe.NewStringValue(fmt.Sprintf("%4.4f", w.eventsPerSecond)))
+			c16.PushBack(dataframe.NewStringValue(w.maxLag))
+		} else {
+			c6.PushBack(dataframe.NewStringValue(errorTotal(r.stats)))
+			c14.PushBack(dataframe.NewStringValue(""))
+			c15.PushBack(dataframe.NewStringValue(""))
+			c16.PushBack(dataframe.NewStringValue(""))
+		}
+		c7.PushBack(dataframe.NewStringValue(fmt.Sprintf("%4.4f", r.stats.RPS)))
+		c8.PushBack(dataframe.NewStringValue(fmt.Sprintf("%4.4f", 1000*r.stats.Average)))
+		c9.PushBack(dataframe.NewStringValue(fmt.Sprintf("%4.4f", 1000*r.stats.Slowest)))
+		if c := r.class; c != nil {
+			withClass = true
+			c10.PushBack(dataframe.NewStringValue(c.priority))
+			c11.PushBack(dataframe.NewStringValue(c.targetString()))
+			// only the 'guaranteed' workloads have the target to keep up with
+			if c.priority == priorityGuaranteed {
+				c12.PushBack(dataframe.NewStringValue(c.metSeconds))
+			} else {
+				c12.PushBack(dataframe.NewStringValue(""))
+			}
+			c13.PushBack(dataframe.NewStringValue(c.throttles))
+		}
+	}
+	cols := []dataframe.Column{c1, c2, c3, c4, c5, c6, c7, c8, c9}
+	if withClass {
+		cols = append(cols, c10, c11, c12, c13)
+	}
+	if withWatch {
+		cols = append(cols, c14, c15, c16)
+	}
+	fr := dataframe.New()
+	for _, col := range cols {
+		if err := fr.AddColumn(col); err != nil {
+			return err
+		}
+	}
+	if err := saveCSV(fr, fpath); err != nil {
+		return err
+	}
+	cfg.lg.Info("saved workload summary", zap.String("path", fpath))
+	return nil
+}
@@ -0,0 +1,132 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/coreos/dbtester/dbtesterpb"
+
+	"github.com/coreos/etcd/pkg/report"
+	"golang.org/x/net/context"
+)
+
+func TestValidateWorkloads(t *testing.T) {
+	gcfg := dbtesterpb.ConfigClientMachineAgentControl{
+		ConfigClientMachineBenchmarkOptions: &dbtesterpb.ConfigClientMachineBenchmarkOptions{
+			Type:        "mixed",
+			Prepopulate: 10,
+			ConfigClientMachineWorkloads: []*dbtesterpb.ConfigClientMachineWorkload{
+				{Name: "range", Type: "read", Percent: 70},
+				{Name: "put", Type: "write", Percent: 30},
+			},
+		},
+	}
+	if err := validateWorkloads(gcfg); err != nil {
+		t.Fatal(err)
+	}
+	gcfg.ConfigClientMachineBenchmarkOptions.ConfigClientMachineWorkloads[1].Percent = 20
+	if err := validateWorkloads(gcfg); err == nil {
+		t.Fatal("expected error on percents not adding up to 100")
+	}
+}
+
+func TestValidateWatchWorkload(t *testing.T) {
+	tests := []struct {
+		databaseID string
+		wl         dbtesterpb.ConfigClientMachineWorkload
+		interfere  bool
+		ok         bool
+	}{
+		{"etcd__tip", dbtesterpb.ConfigClientMachineWorkload{Name: "watch", Type: "watch", Watchers: 100}, false, true},
+		{"mock", dbtesterpb.ConfigClientMachineWorkload{Name: "watch", Type: "watch", Watchers: 100}, false, false},
+		{"etcd__tip", dbtesterpb.ConfigClientMachineWorkload{Name: "watch", Type: "watch"}, false, false},
+		{"etcd__tip", dbtesterpb.ConfigClientMachineWorkload{Name: "watch", Type: "watch", Watchers: 100, Percent: 10}, false, false},
+		{"etcd__tip", dbtesterpb.ConfigClientMachineWorkload{Name: "watch", Type: "watch", Watchers: 100, RateLimitRequestsPerSecond: 10}, false, false},
+		{"etcd__tip", dbtesterpb.ConfigClientMachineWorkload{Name: "watch", Type: "watch", Watchers: 100}, true, false},
+		{"etcd__tip", dbtesterpb.ConfigClientMachineWorkload{Name: "watch", Type: "read", Watchers: 100, Percent: 10}, false, false},
+	}
+	for i, tt := range tests {
+		wl := tt.wl
+		gcfg := dbtesterpb.ConfigClientMachineAgentControl{
+			DatabaseID: tt.databaseID,
+			ConfigClientMachineBenchmarkOptions: &dbtesterpb.ConfigClientMachineBenchmarkOptions{
+				Type:                "mixed",
+				Prepopulate:         10,
+				MeasureInterference: tt.interfere,
+				ConfigClientMachineWorkloads: []*dbtesterpb.ConfigClientMachineWorkload{
+					{Name: "range", Type: "read", Percent: 70},
+					{Name: "put", Type: "write", Percent: 30},
+					&wl,
+				},
+			},
+		}
+		if wl.Type == "read" {
+			gcfg.ConfigClientMachineBenchmarkOptions.ConfigClientMachineWorkloads[0].Percent -= wl.Percent
+		}
+		err := validateWorkloads(gcfg)
+		if tt.ok != (err == nil) {
+			t.Fatalf("#%d: expected ok %v, got error %v", i, tt.ok, err)
+		}
+	}
+}
+
+func TestCombineConcurrentStats(t *testing.T) {
+	combined := combineConcurrentStats([]runStats{
+		newRunStats(testLatencies(1, 2), 2*time.Second, nil,
+			report.TimeSeries{{Timestamp: 1, AvgLatency: time.Millisecond, ThroughPut: 2}},
+		),
+		newRunStats(testLatencies(4), time.Second, nil,
+			report.TimeSeries{
+				{Timestamp: 1, AvgLatency: 4 * time.Millisecond, ThroughPut: 1},
+				{Timestamp: 2, ThroughPut: 1},
+			},
+		),
+	})
+	if combined.RPS != 1.5 {
+		t.Fatalf("expected 3 requests in 2 seconds, got RPS %f", combined.RPS)
+	}
+	if len(combined.TimeSeries) != 2 {
+		t.Fatalf("expected 2 seconds, got %+v", combined.TimeSeries)
+	}
+	if p := combined.TimeSeries[0]; p.ThroughPut != 3 || p.AvgLatency != 2*time.Millisecond {
+		t.Fatalf("unexpected merged second %+v", p)
+	}
+	if combined.Fastest != 1 || combined.Slowest != 4 {
+		t.Fatalf("unexpected fastest %f, slowest %f", combined.Fastest, combined.Slowest)
+	}
+}
+
+func TestGenerateDeletes(t *testing.T) {
+	gcfg := dbtesterpb.ConfigClientMachineAgentControl{
+		DatabaseID: "mock",
+		ConfigClientMachineBenchmarkOptions: &dbtesterpb.ConfigClientMachineBenchmarkOptions{
+			RequestNumber: 3,
+			KeySizeBytes:  2,
+		},
+	}
+	ch := make(chan request)
+	go generateDeletes(context.Background(), gcfg, 10, nil, ch)
+	var keys []string
+	for req := range ch {
+		keys = append(keys, req.mockOp.key)
+	}
+	// the keys after the prepopulated ones that the reads read
+	if expected := []string{"10", "11", "12"}; !reflect.DeepEqual(keys, expected) {
+		t.Fatalf("expected %v, got %v", expected, keys)
+	}
+}
@@ -0,0 +1,203 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/coreos/dbtester/dbtesterpb"
+
+	"github.com/coreos/etcd/clientv3"
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+)
+
+// watchWorkloadTimeout is how long to wait for the watchers
+// of a 'watch' workload to be registered.
+const watchWorkloadTimeout = 10 * time.Second
+
+// watchWorkloadResult is the result of a 'watch' workload.
+type watchWorkloadResult struct {
+	watchers int64
+	// events is the number of events received by all watchers.
+	events int64
+	// errors is the number of watchers whose watches failed.
+	errors int64
+	// maxLag is the maximum number of revisions that a watcher was
+	// behind the newest revision, sampled every second and at the end
+	// of the other workloads.
+	maxLag          int64
+	eventsPerSecond float64
+}
+
+// workloadWatchers are the watchers of a 'watch' workload. They watch
+// the prefix of 'namespace', or all keys, on etcd v3, while the other
+// workloads run.
+type workloadWatchers struct {
+	lg     *zap.Logger
+	name   string
+	clis   []*clientv3.Client
+	cancel func()
+	wg     sync.WaitGroup
+	lag    *watchLagGauge
+	start  time.Time
+
+	// events and errors are updated atomically
+	events int64
+	errors int64
+
+	stopc, donec chan struct{}
+}
+
+func validateWatchWorkload(gcfg dbtesterpb.ConfigClientMachineAgentControl, wl *dbtesterpb.ConfigClientMachineWorkload) error {
+	switch gcfg.DatabaseID {
+	case "etcd__other", "etcd__tip", "etcd__v3_2", "etcd__v3_3":
+	default:
+		return fmt.Errorf("workload %q of type 'watch' is only supported with etcd v3 (got %q)", wl.Name, gcfg.DatabaseID)
+	}
+	opts := gcfg.ConfigClientMachineBenchmarkOptions
+	switch {
+	case wl.Watchers <= 0:
+		return fmt.Errorf("workload %q of type 'watch' requires positive 'watchers' (got %d)", wl.Name, wl.Watchers)
+	case wl.Percent != 0:
+		return fmt.Errorf("workload %q of type 'watch' takes no 'percent' (got %d)", wl.Name, wl.Percent)
+	case wl.RateLimitRequestsPerSecond != 0 || wl.Priority != "" || wl.RateShare != 0:
+		return fmt.Errorf("workload %q of type 'watch' takes no rate limit or 'priority'", wl.Name)
+	case opts.MeasureInterference:
+		return fmt.Errorf("workload %q of type 'watch' is not supported with 'measure_interference'", wl.Name)
+	}
+	return nil
+}
+
+// startWorkloadWatchers registers the watchers of the workload, and
+// returns once all of them are registered.
+func (cfg *Config) startWorkloadWatchers(gcfg dbtesterpb.ConfigClientMachineAgentControl, wl *dbtesterpb.ConfigClientMachineWorkload) (*workloadWatchers, error) {
+	n := wl.Watchers
+	conns := gcfg.ConfigClientMachineBenchmarkOptions.ConnectionNumber
+	if conns <= 0 || conns > n {
+		conns = n
+	}
+	w := &workloadWatchers{
+		lg:    cfg.lg,
+		name:  wl.Name,
+		clis:  make([]*clientv3.Client, conns),
+		lag:   newWatchLagGauge(int(n), gcfg.DatabaseID, wl.Name),
+		stopc: make(chan struct{}),
+		donec: make(chan struct{}),
+	}
+	for i := range w.clis {
+		w.clis[i] = mustCreateConnEtcdv3(gcfg.DatabaseEndpoints)
+	}
+	ctx, cancel := context.WithCancel(cfg.runContext())
+	w.cancel = cancel
+
+	prefix := gcfg.ConfigClientMachineBenchmarkOptions.Namespace
+	var readyWg sync.WaitGroup
+	readyWg.Add(int(n))
+	w.wg.Add(int(n))
+	for i := 0; i < int(n); i++ {
+		go func(i int) {
+			defer w.wg.Done()
+			var readyOnce sync.Once
+			ready := func() { readyOnce.Do(readyWg.Done) }
+			defer ready()
+
+			// watches all keys if the prefix is empty
+			wch := w.clis[i%len(w.clis)].Watch(ctx, prefix, clientv3.WithPrefix(), clientv3.WithCreatedNotify())
+			for wresp := range wch {
+				if err := wresp.Err(); err != nil {
+					atomic.AddInt64(&w.errors, 1)
+					w.lg.Warn("watcher failed", zap.String("workload", w.name), zap.Int("watcher", i), zap.Error(err))
+					return
+				}
+				if wresp.Created {
+					ready()
+					continue
+				}
+				atomic.AddInt64(&w.events, int64(len(wresp.Events)))
+				if len(wresp.Events) > 0 {
+					w.lag.observe(i, wresp.Events[len(wresp.Events)-1].Kv.ModRevision)
+				}
+			}
+		}(i)
+	}
+	if !waitGroupTimeout(&readyWg, watchWorkloadTimeout) {
+		w.close()
+		return nil, fmt.Errorf("watchers of workload %q not registered in %v", wl.Name, watchWorkloadTimeout)
+	}
+
+	go func() {
+		defer close(w.donec)
+		ticker := time.NewTicker(time.Second)
+		defer ticker.Stop()
+		for {
+			select {
+			case now := <-ticker.C:
+				w.sampleLag(now)
+			case <-w.stopc:
+				return
+			}
+		}
+	}()
+	w.start = time.Now()
+	cfg.lg.Info("started watchers", zap.String("workload", wl.Name), zap.Int64("watchers", n), zap.Int64("connections", conns), zap.String("prefix", prefix))
+	return w, nil
+}
+
+// sampleLag samples the lag of the watchers behind the newest revision.
+func (w *workloadWatchers) sampleLag(now time.Time) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	resp, err := w.clis[0].Get(ctx, "dbtester-watch-revision")
+	cancel()
+	if err != nil {
+		w.lg.Warn("failed to get the newest revision", zap.String("workload", w.name), zap.Error(err))
+		return
+	}
+	w.lag.write(resp.Header.Revision)
+	w.lag.record(w.lg, now)
+}
+
+// stop samples the lag at the end of the other workloads, then stops
+// the watchers and returns their result.
+func (w *workloadWatchers) stop() watchWorkloadResult {
+	close(w.stopc)
+	<-w.donec
+	w.sampleLag(time.Now())
+	took := time.Since(w.start)
+	w.close()
+
+	rs := watchWorkloadResult{
+		watchers: int64(len(w.lag.observed)),
+		events:   atomic.LoadInt64(&w.events),
+		errors:   atomic.LoadInt64(&w.errors),
+		maxLag:   w.lag.maxLag(),
+	}
+	if took > 0 {
+		rs.eventsPerSecond = float64(rs.events) / took.Seconds()
+	}
+	return rs
+}
+
+// close cancels the watches and closes the connections.
+func (w *workloadWatchers) close() {
+	w.cancel()
+	w.wg.Wait()
+	for _, cli := range w.clis {
+		cli.Close()
+	}
+}
@@ -0,0 +1,149 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// timeseriesStream writes the completed requests, the errors and the
+// average latency of every second to a CSV file as the stress runs, so
+// that stalls can be located in time while the run is still going, or
+// after it crashed. Seconds with no completed requests are written as
+// zeros.
+type timeseriesStream struct {
+	lg *zap.Logger
+	f  *os.File
+
+	mu sync.Mutex
+	// sec is the unix second being counted
+	sec          int64
+	completed    int64
+	errors       int64
+	totalLatency time.Duration
+
+	stopc chan struct{}
+	donec chan struct{}
+}
+
+func newTimeseriesStream(lg *zap.Logger, fpath string, now time.Time) (*timeseriesStream, error) {
+	f, err := os.Create(fpath)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = f.WriteString("UNIX-SECOND,COMPLETED,ERRORS,AVG-LATENCY-MS\n"); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return &timeseriesStream{
+		lg:    lg,
+		f:     f,
+		sec:   now.Unix(),
+		stopc: make(chan struct{}),
+		donec: make(chan struct{}),
+	}, nil
+}
+
+// record counts a completed request.
+func (s *timeseriesStream) record(lat time.Duration, err error) {
+	s.mu.Lock()
+	s.completed++
+	if err != nil {
+		s.errors++
+	}
+	s.totalLatency += lat
+	s.mu.Unlock()
+}
+
+// flush writes the rows of the seconds before 'now', with the counts
+// of the last one, and starts counting the second of 'now'.
+func (s *timeseriesStream) flush(now time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	last := now.Unix() - 1
+	if s.sec > last {
+		return nil
+	}
+	for ; s.sec < last; s.sec++ {
+		// the ticks were late
+		if _, err := fmt.Fprintf(s.f, "%d,0,0,%f\n", s.sec, 0.0); err != nil {
+			return err
+		}
+	}
+	var avg float64
+	if s.completed > 0 {
+		avg = toMillisecond(s.totalLatency / time.Duration(s.completed))
+	}
+	if _, err := fmt.Fprintf(s.f, "%d,%d,%d,%f\n", s.sec, s.completed, s.errors, avg); err != nil {
+		return err
+	}
+	s.sec++
+	s.completed, s.errors, s.totalLatency = 0, 0, 0
+	return nil
+}
+
+func (s *timeseriesStream) run() {
+	defer close(s.donec)
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case now := <-ticker.C:
+			if err := s.flush(now); err != nil {
+				s.lg.Warn("failed to write timeseries", zap.String("path", s.f.Name()), zap.Error(err))
+			}
+		case <-s.stopc:
+			return
+		}
+	}
+}
+
+// close writes the rows up to the current second, which is partial,
+// and closes the file.
+func (s *timeseriesStream) close() error {
+	close(s.stopc)
+	<-s.donec
+	err := s.flush(time.Unix(time.Now().Unix()+1, 0))
+	if cerr := s.f.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// StreamTimeseries writes the per-second completed requests, errors and
+// average latency of the stress to the file as it runs, until the returned
+// function is called. The rows are of the requests of this process, not
+// of its workers.
+func (cfg *Config) StreamTimeseries(fpath string) (stop func(), err error) {
+	s, err := newTimeseriesStream(cfg.lg, fpath, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	go s.run()
+	cfg.timeseries = s
+	cfg.lg.Info("streaming timeseries", zap.String("path", fpath))
+
+	return func() {
+		cfg.timeseries = nil
+		if err := s.close(); err != nil {
+			cfg.lg.Warn("failed to close timeseries", zap.String("path", fpath), zap.Error(err))
+		}
+	}, nil
+}
@@ -0,0 +1,85 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func TestTimeseriesStream(t *testing.T) {
+	dir, err := ioutil.TempDir("", "timeseries")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	fpath := filepath.Join(dir, "timeseries.csv")
+
+	s, err := newTimeseriesStream(zap.NewNop(), fpath, time.Unix(100, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.record(time.Millisecond, nil)
+	s.record(3*time.Millisecond, errors.New("fail"))
+	if err = s.flush(time.Unix(101, 500)); err != nil {
+		t.Fatal(err)
+	}
+	// the stall of 101 and 102 is written as zeros
+	s.record(2*time.Millisecond, nil)
+	if err = s.flush(time.Unix(104, 0)); err != nil {
+		t.Fatal(err)
+	}
+
+	// rows are readable before the stream is closed
+	bts, err := ioutil.ReadFile(fpath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := `UNIX-SECOND,COMPLETED,ERRORS,AVG-LATENCY-MS
+100,2,1,2.000000
+101,0,0,0.000000
+102,0,0,0.000000
+103,1,0,2.000000
+`
+	if string(bts) != exp {
+		t.Fatalf("expected\n%s\ngot\n%s", exp, bts)
+	}
+	if err = s.f.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// the partial last second is written on close
+	if s, err = newTimeseriesStream(zap.NewNop(), fpath, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	go s.run()
+	s.record(time.Millisecond, nil)
+	if err = s.close(); err != nil {
+		t.Fatal(err)
+	}
+	if bts, err = ioutil.ReadFile(fpath); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasSuffix(string(bts), ",1,0,1.000000\n") {
+		t.Fatalf("expected the partial last second, got\n%s", bts)
+	}
+}
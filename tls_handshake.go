@@ -0,0 +1,256 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/coreos/dbtester/dbtesterpb"
+
+	"github.com/gyuho/dataframe"
+	"go.uber.org/zap"
+)
+
+// tlsHandshakeInterval is the default interval to dial every endpoint.
+const tlsHandshakeInterval = 100 * time.Millisecond
+
+// handshakeStats are the handshakes of a mode.
+type handshakeStats struct {
+	lats []float64
+	errN int
+}
+
+// tlsHandshakes dials every endpoint periodically with new connections,
+// once with no session cache for a full handshake, and once with the
+// session cache shared by all dials to resume the session, which is
+// how short-lived connections pay for TLS.
+type tlsHandshakes struct {
+	lg        *zap.Logger
+	endpoints []string
+	interval  time.Duration
+	// full has no session cache, and resume shares one
+	full, resume *tls.Config
+
+	stopc, donec chan struct{}
+
+	mu sync.Mutex
+	// fullStats include the resumptions that fell back to full handshakes
+	fullStats, resumedStats handshakeStats
+	resumeTried             int
+}
+
+// tlsEndpoint returns the host and port of the endpoint, without the scheme.
+func tlsEndpoint(ep string) string {
+	if strings.Contains(ep, "://") {
+		if u, err := url.Parse(ep); err == nil {
+			return u.Host
+		}
+	}
+	return ep
+}
+
+func newTLSHandshakes(lg *zap.Logger, gcfg dbtesterpb.ConfigClientMachineAgentControl) (*tlsHandshakes, error) {
+	tcfg := gcfg.ConfigClientMachineBenchmarkOptions.ConfigClientMachineTLSHandshake
+	th := &tlsHandshakes{
+		lg:       lg,
+		interval: tlsHandshakeInterval,
+		full:     &tls.Config{InsecureSkipVerify: tcfg.InsecureSkipVerify},
+		stopc:    make(chan struct{}),
+		donec:    make(chan struct{}),
+	}
+	if ms := tcfg.IntervalMilliseconds; ms > 0 {
+		th.interval = time.Duration(ms) * time.Millisecond
+	}
+	eps := tcfg.Endpoints
+	if len(eps) == 0 {
+		eps = gcfg.DatabaseEndpoints
+	}
+	for _, ep := range eps {
+		th.endpoints = append(th.endpoints, tlsEndpoint(ep))
+	}
+	if len(th.endpoints) == 0 {
+		return nil, fmt.Errorf("'tls_handshake' got no endpoint")
+	}
+
+	if tcfg.CAFile != "" {
+		bts, err := ioutil.ReadFile(tcfg.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(bts) {
+			return nil, fmt.Errorf("no certificate found in %q", tcfg.CAFile)
+		}
+		th.full.RootCAs = pool
+	}
+	if tcfg.CertFile != "" || tcfg.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(tcfg.CertFile, tcfg.KeyFile)
+		if err != nil {
+			return nil, err
+		}
+		th.full.Certificates = []tls.Certificate{cert}
+	}
+	th.resume = th.full.Clone()
+	th.resume.ClientSessionCache = tls.NewLRUClientSessionCache(len(th.endpoints))
+
+	go th.run()
+	lg.Sugar().Infof("dialing %d TLS endpoints every %v", len(th.endpoints), th.interval)
+	return th, nil
+}
+
+func (th *tlsHandshakes) run() {
+	defer close(th.donec)
+	ticker := time.NewTicker(th.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			for _, ep := range th.endpoints {
+				th.record(false, ep)
+				th.record(true, ep)
+			}
+		case <-th.stopc:
+			return
+		}
+	}
+}
+
+func (th *tlsHandshakes) record(resume bool, ep string) {
+	resumed, took, err := th.handshake(resume, ep)
+
+	th.mu.Lock()
+	defer th.mu.Unlock()
+	if resume {
+		th.resumeTried++
+	}
+	st := &th.fullStats
+	if resumed {
+		st = &th.resumedStats
+	}
+	if err != nil {
+		st.errN++
+		return
+	}
+	st.lats = append(st.lats, took.Seconds())
+}
+
+// handshake dials the endpoint, and returns true if the session is
+// resumed, with the duration of the handshake, excluding the TCP dial.
+func (th *tlsHandshakes) handshake(resume bool, ep string) (bool, time.Duration, error) {
+	timeout := th.interval * 10
+	raw, err := net.DialTimeout("tcp", ep, timeout)
+	if err != nil {
+		return false, 0, err
+	}
+	defer raw.Close()
+
+	tcfg := th.full
+	if resume {
+		tcfg = th.resume
+	}
+	tcfg = tcfg.Clone()
+	if tcfg.ServerName == "" {
+		tcfg.ServerName, _, _ = net.SplitHostPort(ep)
+	}
+	conn := tls.Client(raw, tcfg)
+	conn.SetDeadline(time.Now().Add(timeout))
+
+	now := time.Now()
+	if err = conn.Handshake(); err != nil {
+		return false, 0, err
+	}
+	took := time.Since(now)
+
+	if resume {
+		// TLS 1.3 servers send the session tickets after the handshake,
+		// which the client only processes on read
+		conn.SetReadDeadline(time.Now().Add(10 * time.Millisecond))
+		conn.Read(make([]byte, 1))
+	}
+	return conn.ConnectionState().DidResume, took, nil
+}
+
+func (th *tlsHandshakes) stop() {
+	close(th.stopc)
+	<-th.donec
+}
+
+func (cfg *Config) saveTLSHandshakes() {
+	th := cfg.tlsHandshakes
+	if th == nil {
+		return
+	}
+	th.mu.Lock()
+	defer th.mu.Unlock()
+
+	resumeRate := 0.0
+	if th.resumeTried > 0 {
+		resumeRate = 100 * float64(len(th.resumedStats.lats)) / float64(th.resumeTried)
+	}
+	cfg.lg.Sugar().Infof("TLS session resumption [tried: %d | resumed: %d | rate: %.2f%%]", th.resumeTried, len(th.resumedStats.lats), resumeRate)
+
+	c1 := dataframe.NewColumn("MODE")
+	c2 := dataframe.NewColumn("HANDSHAKES")
+	c3 := dataframe.NewColumn("ERRORS")
+	c4 := dataframe.NewColumn("AVERAGE-MS")
+	c5 := dataframe.NewColumn("P50-MS")
+	c6 := dataframe.NewColumn("P99-MS")
+	for _, m := range []struct {
+		mode string
+		st   handshakeStats
+	}{{"full", th.fullStats}, {"resumed", th.resumedStats}} {
+		avg := 0.0
+		for _, v := range m.st.lats {
+			avg += v
+		}
+		if len(m.st.lats) > 0 {
+			avg /= float64(len(m.st.lats))
+		}
+		p50, p99 := percentileOf(m.st.lats, 50), percentileOf(m.st.lats, 99)
+		cfg.lg.Sugar().Infof("TLS %s handshakes [handshakes: %d | errors: %d | average: %.4f ms | p50: %.4f ms | p99: %.4f ms]",
+			m.mode, len(m.st.lats), m.st.errN, 1000*avg, 1000*p50, 1000*p99)
+
+		c1.PushBack(dataframe.NewStringValue(m.mode))
+		c2.PushBack(dataframe.NewStringValue(len(m.st.lats)))
+		c3.PushBack(dataframe.NewStringValue(m.st.errN))
+		c4.PushBack(dataframe.NewStringValue(fmt.Sprintf("%4.4f", 1000*avg)))
+		c5.PushBack(dataframe.NewStringValue(fmt.Sprintf("%4.4f", 1000*p50)))
+		c6.PushBack(dataframe.NewStringValue(fmt.Sprintf("%4.4f", 1000*p99)))
+	}
+
+	fpath := cfg.ConfigClientMachineInitial.ClientTLSHandshakePath
+	if fpath == "" {
+		cfg.lg.Warn("'client_tls_handshake_path' is not set; skipping TLS handshakes")
+		return
+	}
+	fr := dataframe.New()
+	for _, col := range []dataframe.Column{c1, c2, c3, c4, c5, c6} {
+		if err := fr.AddColumn(col); err != nil {
+			panic(err)
+		}
+	}
+	if err := saveCSV(fr, fpath); err != nil {
+		panic(err)
+	}
+	cfg.lg.Info("saved TLS handshakes", zap.String("path", fpath))
+}
@@ -0,0 +1,71 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"encoding/pem"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/coreos/dbtester/dbtesterpb"
+
+	"go.uber.org/zap"
+)
+
+func TestTLSHandshakes(t *testing.T) {
+	srv := httptest.NewTLSServer(http.NotFoundHandler())
+	defer srv.Close()
+
+	dir, err := ioutil.TempDir("", "tls")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	ca := filepath.Join(dir, "ca.pem")
+	if err = ioutil.WriteFile(ca, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw}), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	gcfg := dbtesterpb.ConfigClientMachineAgentControl{
+		DatabaseEndpoints: []string{srv.URL},
+		ConfigClientMachineBenchmarkOptions: &dbtesterpb.ConfigClientMachineBenchmarkOptions{
+			ConfigClientMachineTLSHandshake: &dbtesterpb.ConfigClientMachineTLSHandshake{CAFile: ca, IntervalMilliseconds: 20},
+		},
+	}
+	th, err := newTLSHandshakes(zap.NewNop(), gcfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(300 * time.Millisecond)
+	th.stop()
+
+	th.mu.Lock()
+	defer th.mu.Unlock()
+	if th.fullStats.errN+th.resumedStats.errN > 0 {
+		t.Fatalf("expected no error, got %d and %d", th.fullStats.errN, th.resumedStats.errN)
+	}
+	if len(th.fullStats.lats) == 0 || len(th.resumedStats.lats) == 0 {
+		t.Fatalf("expected full and resumed handshakes, got %d and %d", len(th.fullStats.lats), len(th.resumedStats.lats))
+	}
+	// only the first resumption has no session
+	if n := th.resumeTried - len(th.resumedStats.lats); n != 1 {
+		t.Fatalf("expected 1 resumption to fall back, got %d", n)
+	}
+}
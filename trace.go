@@ -0,0 +1,106 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/coreos/dbtester/dbtesterpb"
+
+	"github.com/gyuho/dataframe"
+	"go.uber.org/zap"
+)
+
+// requestTrace is the metadata of a sampled request,
+// filled by request handlers when 'request.trace' is not nil.
+type requestTrace struct {
+	start time.Time
+	end   time.Time
+
+	requestBytes  int
+	responseBytes int
+
+	// revision is etcd revision, Zookeeper zxid, or Consul index.
+	revision int64
+	// raftTerm is etcd raft term.
+	raftTerm uint64
+	// member is the server that responded, if known.
+	member string
+
+	// seq is the order of the request in the generation.
+	seq int64
+	// queueDelay is the time from the scheduled time
+	// of the request to the time a client sends it.
+	queueDelay time.Duration
+
+	err string
+}
+
+// traceEvery returns the interval of requests to sample,
+// or 0 if 'trace_sample_number' is not set.
+func traceEvery(gcfg dbtesterpb.ConfigClientMachineAgentControl) int64 {
+	n := gcfg.ConfigClientMachineBenchmarkOptions.TraceSampleNumber
+	if n <= 0 {
+		return 0
+	}
+	every := expectedRequests(gcfg) / n
+	if every < 1 {
+		every = 1
+	}
+	return every
+}
+
+func (cfg *Config) saveRequestTraceSample(traces []requestTrace) {
+	fpath := cfg.ConfigClientMachineInitial.ClientRequestTraceSamplePath
+	if fpath == "" {
+		cfg.lg.Warn("'client_request_trace_sample_path' is not set; skipping request trace sample", zap.Int("samples", len(traces)))
+		return
+	}
+
+	c1 := dataframe.NewColumn("UNIX-NANOSECOND")
+	c2 := dataframe.NewColumn("LATENCY-MS")
+	c3 := dataframe.NewColumn("REQUEST-BYTES")
+	c4 := dataframe.NewColumn("RESPONSE-BYTES")
+	c5 := dataframe.NewColumn("REVISION")
+	c6 := dataframe.NewColumn("RAFT-TERM")
+	c7 := dataframe.NewColumn("MEMBER")
+	c8 := dataframe.NewColumn("SEQUENCE")
+	c9 := dataframe.NewColumn("QUEUE-DELAY-MS")
+	c10 := dataframe.NewColumn("ERROR")
+	for _, tr := range traces {
+		c1.PushBack(dataframe.NewStringValue(tr.start.UnixNano()))
+		c2.PushBack(dataframe.NewStringValue(fmt.Sprintf("%4.4f", toMillisecond(tr.end.Sub(tr.start)))))
+		c3.PushBack(dataframe.NewStringValue(tr.requestBytes))
+		c4.PushBack(dataframe.NewStringValue(tr.responseBytes))
+		c5.PushBack(dataframe.NewStringValue(tr.revision))
+		c6.PushBack(dataframe.NewStringValue(fmt.Sprintf("%d", tr.raftTerm)))
+		c7.PushBack(dataframe.NewStringValue(tr.member))
+		c8.PushBack(dataframe.NewStringValue(tr.seq))
+		c9.PushBack(dataframe.NewStringValue(fmt.Sprintf("%4.4f", toMillisecond(tr.queueDelay))))
+		c10.PushBack(dataframe.NewStringValue(tr.err))
+	}
+
+	fr := dataframe.New()
+	for _, col := range []dataframe.Column{c1, c2, c3, c4, c5, c6, c7, c8, c9, c10} {
+		if err := fr.AddColumn(col); err != nil {
+			panic(err)
+		}
+	}
+	if err := saveCSV(fr, fpath); err != nil {
+		panic(err)
+	}
+	cfg.lg.Info("saved request trace sample", zap.String("path", fpath), zap.Int("samples", len(traces)))
+}
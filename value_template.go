@@ -0,0 +1,227 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"encoding/binary"
+	"fmt"
+	mrand "math/rand"
+	"sort"
+	"strings"
+)
+
+// valueTemplateSamples is the number of distinct values of a template,
+// so that the values do not compress across requests more than they
+// would in production.
+const valueTemplateSamples = 64
+
+// valueTemplates generate a value of the size from the random source.
+// A value smaller than the shortest document of the template is truncated.
+var valueTemplates = map[string]func(rnd *mrand.Rand, size int) []byte{
+	"json":     jsonValue,
+	"yaml":     yamlValue,
+	"protobuf": protobufValue,
+}
+
+var (
+	templateServices = []string{"api", "auth", "billing", "cache", "checkout", "edge", "events", "frontend", "gateway", "ingest", "inventory", "jobs", "metrics", "orders", "payments", "queue", "search", "storage", "users", "worker"}
+	templateRegions  = []string{"us-east-1", "us-west-2", "eu-west-1", "eu-central-1", "ap-northeast-1"}
+	templateKnobs    = []string{"timeout", "replicas", "endpoint", "retries", "log-level", "region", "max-connections", "feature-enabled", "tls-enabled", "pool-size", "rate-limit", "image"}
+	templateLogLevel = []string{"debug", "info", "warn", "error"}
+)
+
+// templateEntry is a configuration entry, with the value for its knob.
+type templateEntry struct{ key, value string }
+
+func randTemplateEntry(rnd *mrand.Rand, i int) templateEntry {
+	svc := templateServices[rnd.Intn(len(templateServices))]
+	knob := templateKnobs[rnd.Intn(len(templateKnobs))]
+	var v string
+	switch knob {
+	case "timeout":
+		v = fmt.Sprintf("%ds", 1+rnd.Intn(60))
+	case "replicas", "retries", "pool-size":
+		v = fmt.Sprintf("%d", 1+rnd.Intn(16))
+	case "max-connections", "rate-limit":
+		v = fmt.Sprintf("%d", 64<<uint(rnd.Intn(8)))
+	case "endpoint":
+		v = fmt.Sprintf("%s-%d.%s.internal:%d", svc, rnd.Intn(8), templateRegions[rnd.Intn(len(templateRegions))], 8000+rnd.Intn(1000))
+	case "log-level":
+		v = templateLogLevel[rnd.Intn(len(templateLogLevel))]
+	case "region":
+		v = templateRegions[rnd.Intn(len(templateRegions))]
+	case "feature-enabled", "tls-enabled":
+		v = fmt.Sprintf("%t", rnd.Intn(2) == 0)
+	case "image":
+		v = fmt.Sprintf("registry.internal/%s:v%d.%d.%d", svc, 1+rnd.Intn(3), rnd.Intn(20), rnd.Intn(10))
+	}
+	return templateEntry{key: fmt.Sprintf("%s.%s.%d", svc, knob, i), value: v}
+}
+
+// truncateOrPad returns 'b' of the size, padded with 'pad'.
+func truncateOrPad(b []byte, size int, pad byte) []byte {
+	if len(b) >= size {
+		return b[:size]
+	}
+	for len(b) < size {
+		b = append(b, pad)
+	}
+	return b
+}
+
+// jsonValue is a ConfigMap-like JSON object, padded with
+// whitespace before the closing braces.
+func jsonValue(rnd *mrand.Rand, size int) []byte {
+	svc := templateServices[rnd.Intn(len(templateServices))]
+	b := []byte(fmt.Sprintf(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"%s-config","namespace":"%s","resourceVersion":"%d"},"data":{`,
+		svc, templateRegions[rnd.Intn(len(templateRegions))], rnd.Int63n(1<<32)))
+	const tail = "}}"
+	for i := 0; ; i++ {
+		e := randTemplateEntry(rnd, i)
+		s := fmt.Sprintf("%q:%q", e.key, e.value)
+		if i > 0 {
+			s = "," + s
+		}
+		if len(b)+len(s)+len(tail) > size {
+			break
+		}
+		b = append(b, s...)
+	}
+	if len(b)+len(tail) > size {
+		return truncateOrPad(b, size, ' ')
+	}
+	b = truncateOrPad(b, size-len(tail), ' ')
+	return append(b, tail...)
+}
+
+// yamlValue is a ConfigMap-like YAML document, padded with a comment.
+func yamlValue(rnd *mrand.Rand, size int) []byte {
+	svc := templateServices[rnd.Intn(len(templateServices))]
+	b := []byte(fmt.Sprintf("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: %s-config\n  namespace: %s\n  resourceVersion: \"%d\"\ndata:\n",
+		svc, templateRegions[rnd.Intn(len(templateRegions))], rnd.Int63n(1<<32)))
+	for i := 0; ; i++ {
+		e := randTemplateEntry(rnd, i)
+		s := fmt.Sprintf("  %s: %q\n", e.key, e.value)
+		if len(b)+len(s) > size {
+			break
+		}
+		b = append(b, s...)
+	}
+	if r := size - len(b); r >= 2 {
+		b = append(b, '#')
+		b = truncateOrPad(b, size-1, ' ')
+		b = append(b, '\n')
+	}
+	return truncateOrPad(b, size, '\n')
+}
+
+// protobuf wire types
+const (
+	protoVarint  = 0
+	protoFixed64 = 1
+	protoBytes   = 2
+)
+
+func appendProtoTag(b []byte, field, wire int) []byte {
+	return appendProtoVarint(b, uint64(field<<3|wire))
+}
+
+func appendProtoVarint(b []byte, v uint64) []byte {
+	var buf [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(buf[:], v)
+	return append(b, buf[:n]...)
+}
+
+func appendProtoBytes(b []byte, field int, v []byte) []byte {
+	b = appendProtoTag(b, field, protoBytes)
+	b = appendProtoVarint(b, uint64(len(v)))
+	return append(b, v...)
+}
+
+// protoPaddingField is the unknown field of the padding.
+const protoPaddingField = 15
+
+// appendProtoPadding pads 'b' by 'n' bytes with unknown fields, where 'n' is not 1.
+func appendProtoPadding(b []byte, n int) []byte {
+	for n > 0 {
+		if n == 2 {
+			b = appendProtoTag(b, protoPaddingField, protoVarint)
+			return append(b, 0)
+		}
+		// tag, and then the length that fits with itself
+		for l := n - 2; l >= 0; l-- {
+			if 1+len(appendProtoVarint(nil, uint64(l)))+l == n {
+				return appendProtoBytes(b, protoPaddingField, make([]byte, l))
+			}
+		}
+		// no length fits (e.g. 130 bytes); pad 2 bytes first
+		b = appendProtoTag(b, protoPaddingField, protoVarint)
+		b = append(b, 0)
+		n -= 2
+	}
+	return b
+}
+
+// protobufValue is a protobuf-encoded ConfigMap-like message, of name (1),
+// namespace (2), resource version (3), timestamp (4) and entries (5) of
+// key (1) and value (2), padded with an unknown field.
+func protobufValue(rnd *mrand.Rand, size int) []byte {
+	var b []byte
+	b = appendProtoBytes(b, 1, []byte(templateServices[rnd.Intn(len(templateServices))]+"-config"))
+	b = appendProtoBytes(b, 2, []byte(templateRegions[rnd.Intn(len(templateRegions))]))
+	b = appendProtoTag(b, 3, protoVarint)
+	b = appendProtoVarint(b, uint64(rnd.Int63n(1<<32)))
+	b = appendProtoTag(b, 4, protoFixed64)
+	var ts [8]byte
+	binary.LittleEndian.PutUint64(ts[:], uint64(1500000000+rnd.Int63n(1<<26)))
+	b = append(b, ts[:]...)
+
+	for i := 0; ; i++ {
+		e := randTemplateEntry(rnd, i)
+		entry := appendProtoBytes(nil, 1, []byte(e.key))
+		entry = appendProtoBytes(entry, 2, []byte(e.value))
+		s := appendProtoBytes(nil, 5, entry)
+		// never leave 1 byte, which no field fits
+		if n := len(b) + len(s); n > size || n == size-1 {
+			break
+		}
+		b = append(b, s...)
+	}
+	if r := size - len(b); r < 0 || r == 1 {
+		return truncateOrPad(b, size, 0)
+	}
+	return appendProtoPadding(b, size-len(b))
+}
+
+// newTemplateValues generates the values of the template from the seed.
+func newTemplateValues(template string, seed, size int64) (v values, err error) {
+	gen, ok := valueTemplates[template]
+	if !ok {
+		names := make([]string, 0, len(valueTemplates))
+		for k := range valueTemplates {
+			names = append(names, k)
+		}
+		sort.Strings(names)
+		return v, fmt.Errorf("unknown 'value_template' %q (expected one of %s)", template, strings.Join(names, ", "))
+	}
+	rnd := mrand.New(mrand.NewSource(seed))
+	for i := 0; i < valueTemplateSamples; i++ {
+		bts := gen(rnd, int(size))
+		v.bytes = append(v.bytes, bts)
+		v.strings = append(v.strings, string(bts))
+	}
+	v.sampleSize = valueTemplateSamples
+	return v, nil
+}
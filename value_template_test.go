@@ -0,0 +1,113 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"gopkg.in/yaml.v2"
+)
+
+func TestTemplateValues(t *testing.T) {
+	for tmpl := range valueTemplates {
+		for _, size := range []int64{8, 130, 256, 1024, 4097} {
+			v1, err := newTemplateValues(tmpl, 7, size)
+			if err != nil {
+				t.Fatal(err)
+			}
+			v2, _ := newTemplateValues(tmpl, 7, size)
+			v3, _ := newTemplateValues(tmpl, 8, size)
+			if v1.sampleSize != valueTemplateSamples {
+				t.Fatalf("%s: expected %d samples, got %d", tmpl, valueTemplateSamples, v1.sampleSize)
+			}
+			for i, b := range v1.bytes {
+				if int64(len(b)) != size {
+					t.Fatalf("%s/%d #%d: expected %d bytes, got %d", tmpl, size, i, size, len(b))
+				}
+				if !bytes.Equal(b, v2.bytes[i]) {
+					t.Fatalf("%s/%d #%d: expected same values of same seed", tmpl, size, i)
+				}
+				if size < 256 {
+					continue
+				}
+				if bytes.Equal(b, v3.bytes[i]) {
+					t.Fatalf("%s/%d #%d: expected different values of different seeds", tmpl, size, i)
+				}
+				if err := parseTemplateValue(tmpl, b); err != nil {
+					t.Fatalf("%s/%d #%d: %v (%q)", tmpl, size, i, err, b)
+				}
+			}
+		}
+	}
+	if _, err := newTemplateValues("xml", 0, 100); err == nil {
+		t.Fatal("expected error for unknown template")
+	}
+}
+
+func parseTemplateValue(tmpl string, b []byte) error {
+	switch tmpl {
+	case "json":
+		var m map[string]interface{}
+		return json.Unmarshal(b, &m)
+	case "yaml":
+		var m map[string]interface{}
+		if err := yaml.Unmarshal(b, &m); err != nil {
+			return err
+		}
+		if _, ok := m["data"]; !ok {
+			return fmt.Errorf("'data' not found")
+		}
+		return nil
+	case "protobuf":
+		return skipProtoFields(b)
+	}
+	return fmt.Errorf("unknown template %q", tmpl)
+}
+
+// skipProtoFields walks the protobuf wire format to the end.
+func skipProtoFields(b []byte) error {
+	for len(b) > 0 {
+		tag, n := binary.Uvarint(b)
+		if n <= 0 {
+			return fmt.Errorf("bad tag")
+		}
+		b = b[n:]
+		switch tag & 7 {
+		case protoVarint:
+			if _, n = binary.Uvarint(b); n <= 0 {
+				return fmt.Errorf("bad varint")
+			}
+			b = b[n:]
+		case protoFixed64:
+			if len(b) < 8 {
+				return fmt.Errorf("bad fixed64")
+			}
+			b = b[8:]
+		case protoBytes:
+			l, n := binary.Uvarint(b)
+			if n <= 0 || uint64(len(b)-n) < l {
+				return fmt.Errorf("bad length")
+			}
+			b = b[n+int(l):]
+		default:
+			return fmt.Errorf("unexpected wire type %d", tag&7)
+		}
+	}
+	return nil
+}
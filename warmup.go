@@ -0,0 +1,96 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/coreos/dbtester/dbtesterpb"
+)
+
+// warmupWindow discards the results of the requests at the start of
+// the run, until both the first 'warmup_request_number' requests are
+// done and 'warmup_seconds' has passed.
+type warmupWindow struct {
+	requests int64
+	duration time.Duration
+	until    time.Time
+
+	// doneN is the number of requests done, including the discarded
+	doneN     int64
+	discarded int64
+
+	once  sync.Once
+	onEnd func()
+}
+
+// newWarmupWindow returns nil if the options have no warmup.
+func newWarmupWindow(gcfg dbtesterpb.ConfigClientMachineAgentControl) *warmupWindow {
+	opts := gcfg.ConfigClientMachineBenchmarkOptions
+	if opts.WarmupRequestNumber <= 0 && opts.WarmupSeconds <= 0 {
+		return nil
+	}
+	return &warmupWindow{
+		requests: opts.WarmupRequestNumber,
+		duration: time.Duration(opts.WarmupSeconds) * time.Second,
+	}
+}
+
+// start starts the window, to call onEnd once the first request
+// after the window is done.
+func (w *warmupWindow) start(onEnd func()) {
+	w.until = time.Now().Add(w.duration)
+	w.onEnd = onEnd
+}
+
+// discard returns true if the request started at 'st' is in the window.
+func (w *warmupWindow) discard(st time.Time) bool {
+	n := atomic.AddInt64(&w.doneN, 1)
+	if n <= w.requests || st.Before(w.until) {
+		atomic.AddInt64(&w.discarded, 1)
+		return true
+	}
+	w.end()
+	return false
+}
+
+// end ends the window, if not ended yet.
+func (w *warmupWindow) end() {
+	w.once.Do(w.onEnd)
+}
+
+// validateWarmup returns an error if the warmup would leave
+// no requests to measure.
+func validateWarmup(gcfg dbtesterpb.ConfigClientMachineAgentControl) error {
+	opts := gcfg.ConfigClientMachineBenchmarkOptions
+	switch {
+	case opts.WarmupRequestNumber < 0:
+		return fmt.Errorf("'warmup_request_number' must not be negative (got %d)", opts.WarmupRequestNumber)
+	case opts.WarmupSeconds < 0:
+		return fmt.Errorf("'warmup_seconds' must not be negative (got %d)", opts.WarmupSeconds)
+	case opts.WarmupRequestNumber == 0 && opts.WarmupSeconds == 0:
+		return nil
+	case len(opts.ConnectionClientNumbers) > 0:
+		return fmt.Errorf("warmup is not supported with 'connection_client_numbers'")
+	case opts.DurationSeconds == 0 && opts.WarmupRequestNumber >= opts.RequestNumber:
+		return fmt.Errorf("'warmup_request_number' %d leaves none of 'request_number' %d to measure", opts.WarmupRequestNumber, opts.RequestNumber)
+	case opts.DurationSeconds > 0 && opts.WarmupSeconds >= opts.DurationSeconds:
+		return fmt.Errorf("'warmup_seconds' %d leaves none of 'duration_seconds' %d to measure", opts.WarmupSeconds, opts.DurationSeconds)
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"testing"
+	"time"
+
+	"github.com/coreos/dbtester/dbtesterpb"
+
+	"golang.org/x/net/context"
+)
+
+func TestBenchmarkWarmup(t *testing.T) {
+	h := make([]ReqHandler, 4)
+	for i := range h {
+		h[i] = func(ctx context.Context, req *request) error {
+			time.Sleep(time.Millisecond)
+			return nil
+		}
+	}
+	b := newBenchmark(200, 4, h, nil, func(ctx context.Context, inflightReqs chan<- request) {
+		for i := 0; i < 200; i++ {
+			inflightReqs <- request{}
+		}
+		close(inflightReqs)
+	})
+	b.progress.interval = 0
+	b.warmup = newWarmupWindow(dbtesterpb.ConfigClientMachineAgentControl{
+		ConfigClientMachineBenchmarkOptions: &dbtesterpb.ConfigClientMachineBenchmarkOptions{WarmupRequestNumber: 50},
+	})
+	b.startRequests()
+	b.waitAll()
+
+	if b.warmup.discarded != 50 || b.stats.requests() != 150 {
+		t.Fatalf("expected 50 discarded and 150 reported, got %d and %d", b.warmup.discarded, b.stats.requests())
+	}
+}
+
+func TestBenchmarkWarmupAll(t *testing.T) {
+	h := []ReqHandler{func(ctx context.Context, req *request) error { return nil }}
+	b := newBenchmark(10, 1, h, nil, func(ctx context.Context, inflightReqs chan<- request) {
+		for i := 0; i < 10; i++ {
+			inflightReqs <- request{}
+		}
+		close(inflightReqs)
+	})
+	b.progress.interval = 0
+	b.warmup = &warmupWindow{duration: time.Minute}
+	b.startRequests()
+
+	donec := make(chan struct{})
+	go func() {
+		b.waitAll()
+		close(donec)
+	}()
+	select {
+	case <-donec:
+	case <-time.After(5 * time.Second):
+		t.Fatal("took too long to finish the reports of no measured request")
+	}
+	if b.warmup.discarded != 10 || b.stats.requests() != 0 {
+		t.Fatalf("expected 10 discarded and none reported, got %d and %d", b.warmup.discarded, b.stats.requests())
+	}
+}
+
+func TestValidateWarmup(t *testing.T) {
+	tests := []struct {
+		opts dbtesterpb.ConfigClientMachineBenchmarkOptions
+		ok   bool
+	}{
+		{dbtesterpb.ConfigClientMachineBenchmarkOptions{RequestNumber: 100}, true},
+		{dbtesterpb.ConfigClientMachineBenchmarkOptions{RequestNumber: 100, WarmupRequestNumber: 10, WarmupSeconds: 1}, true},
+		{dbtesterpb.ConfigClientMachineBenchmarkOptions{RequestNumber: 100, WarmupRequestNumber: 100}, false},
+		{dbtesterpb.ConfigClientMachineBenchmarkOptions{RequestNumber: 100, WarmupRequestNumber: -1}, false},
+		{dbtesterpb.ConfigClientMachineBenchmarkOptions{DurationSeconds: 60, WarmupSeconds: 10}, true},
+		{dbtesterpb.ConfigClientMachineBenchmarkOptions{DurationSeconds: 60, WarmupSeconds: 60}, false},
+		{dbtesterpb.ConfigClientMachineBenchmarkOptions{RequestNumber: 100, WarmupSeconds: 1, ConnectionClientNumbers: []int64{1, 10}}, false},
+	}
+	for i, tt := range tests {
+		opts := tt.opts
+		err := validateWarmup(dbtesterpb.ConfigClientMachineAgentControl{ConfigClientMachineBenchmarkOptions: &opts})
+		if (err == nil) != tt.ok {
+			t.Fatalf("#%d: expected ok %v, got %v", i, tt.ok, err)
+		}
+	}
+}
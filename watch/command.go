@@ -0,0 +1,257 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package watch
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/coreos/dbtester"
+	"github.com/coreos/dbtester/control"
+	"github.com/coreos/dbtester/dbtesterpb"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+// Command implements 'watch' command.
+var Command = &cobra.Command{
+	Use:   "watch",
+	Short: "Benchmarks the event delivery latency of watchers.",
+	Long: `Creates watchers of one key, writes the key, and measures the latency
+from the start of each write to the receipt of its event on each watcher,
+with etcd v3 (and v2) watches, Zookeeper watches, Consul blocking queries
+and Redis keyspace notifications, which are enabled on the node of the key
+while the benchmark runs.
+Verifies that each watcher receives the writes in order, and reports the
+gaps, duplicates and out-of-order events, and samples how many writes each
+watcher is behind, served with '--metrics-addr'.
+
+With '--catch-up-backlog', writes the backlog first, then measures how fast
+etcd v3 watchers catch up through it from its first revision.`,
+	RunE: commandFunc,
+}
+
+var (
+	databaseID string
+	configPath string
+	endpoints  []string
+	certFile   string
+	keyFile    string
+	caFile     string
+	etcdv2     bool
+	opts       dbtester.WatchBenchOptions
+
+	catchUpOpts dbtester.WatchCatchUpOptions
+)
+
+func init() {
+	ids := dbtesterpb.GetAllDatabaseIDs()
+	Command.PersistentFlags().StringVar(&databaseID, "database-id", "", "Database ID to benchmark: "+strings.Join(ids, ", ")+". Empty to benchmark all databases of '--config'.")
+	Command.PersistentFlags().StringVarP(&configPath, "config", "c", "", "YAML configuration file path, to benchmark its databases.")
+	Command.PersistentFlags().StringSliceVar(&endpoints, "endpoints", nil, "Database endpoints to benchmark, instead of the endpoints of '--config'.")
+	Command.PersistentFlags().StringVar(&certFile, "cert", "", "Client certificate to connect to the database with TLS (etcd, Zookeeper 'secureClientPort' and Consul HTTPS). Requires '--key'.")
+	Command.PersistentFlags().StringVar(&keyFile, "key", "", "Private key of '--cert'.")
+	Command.PersistentFlags().StringVar(&caFile, "cacert", "", "CA certificate to verify the database servers with, to connect with TLS. Empty to verify with the system roots when '--cert' is set.")
+	Command.PersistentFlags().BoolVar(&etcdv2, "etcd-v2", false, "'true' to also benchmark the etcd v2 API, which etcd serves with '--enable-v2'.")
+	Command.PersistentFlags().IntVar(&opts.Watchers, "watchers", 100, "Number of watchers.")
+	Command.PersistentFlags().IntVar(&opts.Connections, "connections", 10, "Number of client connections that the watchers share.")
+	Command.PersistentFlags().IntVar(&opts.Puts, "puts", 100, "Number of writes, each of which triggers an event on every watcher.")
+	Command.PersistentFlags().DurationVar(&opts.PutInterval, "put-interval", 10*time.Millisecond, "Interval between writes.")
+	Command.PersistentFlags().DurationVar(&opts.Timeout, "timeout", 10*time.Second, "Timeout to register the watchers, and to receive the last write.")
+	Command.PersistentFlags().IntVar(&opts.SlowWatchers, "slow-watchers", 0, "Number of watchers that are slow consumers, taking '--watcher-delay' to process each event, to see how the backend handles them (buffered, dropped or cancelled watches), with the outcome of each watcher.")
+	Command.PersistentFlags().DurationVar(&opts.WatcherDelay, "watcher-delay", 0, "Time that each of '--slow-watchers' takes to process an event before receiving the next one.")
+	Command.PersistentFlags().DurationVar(&opts.LagInterval, "lag-interval", time.Second, "Interval to sample and log how many writes each watcher is behind.")
+	Command.PersistentFlags().StringVar(&opts.MetricsAddr, "metrics-addr", "", "Address to serve the lag of each watcher at '/metrics' in Prometheus format (e.g. ':9100') while the benchmark runs. Empty to not serve.")
+	Command.PersistentFlags().IntVar(&catchUpOpts.Backlog, "catch-up-backlog", 0, "Number of writes before the watchers start, to measure how fast the watchers catch up from the revision of the first write, as after a long disconnect, and how much the catch-up slows the writes of the server, instead of the event delivery latency. etcd v3 only. 0 to not measure.")
+	Command.PersistentFlags().IntVar(&catchUpOpts.ValueSizeBytes, "catch-up-value-size", 256, "Size of the value of each write of '--catch-up-backlog'.")
+	Command.PersistentFlags().DurationVar(&catchUpOpts.ProbeInterval, "catch-up-probe-interval", 100*time.Millisecond, "Interval between the writes that probe the write latency, before and while the watchers of '--catch-up-backlog' catch up.")
+}
+
+func commandFunc(cmd *cobra.Command, args []string) error {
+	if err := dbtester.SetClientTLS(certFile, keyFile, caFile); err != nil {
+		return err
+	}
+
+	var gcfgs []dbtesterpb.ConfigClientMachineAgentControl
+	switch {
+	case len(endpoints) > 0:
+		if !dbtesterpb.IsValidDatabaseID(databaseID) {
+			return fmt.Errorf("database id %q is unknown", databaseID)
+		}
+		gcfgs = append(gcfgs, dbtesterpb.ConfigClientMachineAgentControl{DatabaseID: databaseID, DatabaseEndpoints: endpoints})
+
+	case configPath != "":
+		cfg, err := dbtester.ReadConfig(configPath, false)
+		if err != nil {
+			return err
+		}
+		for id, gcfg := range cfg.DatabaseIDToConfigClientMachineAgentControl {
+			if databaseID == "" || databaseID == id {
+				gcfgs = append(gcfgs, gcfg)
+			}
+		}
+		if len(gcfgs) == 0 {
+			return fmt.Errorf("%q is not found in %q", databaseID, configPath)
+		}
+		sort.Slice(gcfgs, func(i, j int) bool { return gcfgs[i].DatabaseID < gcfgs[j].DatabaseID })
+
+	default:
+		return fmt.Errorf("either '--endpoints' or '--config' is required")
+	}
+
+	if catchUpOpts.Backlog > 0 {
+		catchUpOpts.Watchers, catchUpOpts.Connections, catchUpOpts.Timeout = opts.Watchers, opts.Connections, opts.Timeout
+		return catchUp(gcfgs)
+	}
+
+	var rss []dbtester.WatchBenchResult
+	var steps []control.Step
+	for i := range gcfgs {
+		gcfg := gcfgs[i]
+		backends, err := dbtester.WatchBackends(gcfg.DatabaseID, etcdv2)
+		if err != nil {
+			return err
+		}
+		for _, backend := range backends {
+			backend := backend
+			steps = append(steps, control.Step{
+				Name:       gcfg.DatabaseID + "/" + backend,
+				DatabaseID: gcfg.DatabaseID,
+				Run: func(*dbtester.Config) error {
+					lg.Info("benchmarking watchers", zap.String("database", gcfg.DatabaseID), zap.String("backend", backend), zap.Strings("endpoints", gcfg.DatabaseEndpoints))
+					rs, err := dbtester.WatchBench(lg, gcfg, backend, opts)
+					if err != nil {
+						return fmt.Errorf("failed to benchmark %q with %q (%v)", gcfg.DatabaseID, backend, err)
+					}
+					rss = append(rss, rs)
+					return nil
+				},
+			})
+		}
+	}
+	if err := control.RunSteps(control.Sequence{}, steps); err != nil {
+		return err
+	}
+
+	tw := tablewriter.NewWriter(os.Stdout)
+	tw.SetHeader([]string{"DATABASE", "BACKEND", "WATCHERS", "PUTS", "EVENTS", "MISSED", "ERRORS", "VERIFIED", "GAPS", "DUPLICATES", "OUT-OF-ORDER", "MAX-LAG", "P50-MS", "P99-MS", "MAX-MS"})
+	for _, rs := range rss {
+		tw.Append([]string{
+			rs.DatabaseID,
+			rs.Backend,
+			fmt.Sprintf("%d", rs.Watchers),
+			fmt.Sprintf("%d", rs.Puts),
+			fmt.Sprintf("%d", rs.Events),
+			fmt.Sprintf("%d", rs.Missed),
+			fmt.Sprintf("%d", rs.Errors),
+			fmt.Sprintf("%v", rs.Verified),
+			fmt.Sprintf("%d", rs.Violations.Gaps),
+			fmt.Sprintf("%d", rs.Violations.Duplicates),
+			fmt.Sprintf("%d", rs.Violations.OutOfOrder),
+			fmt.Sprintf("%d", rs.MaxLag),
+			fmt.Sprintf("%.3f", rs.P50Ms),
+			fmt.Sprintf("%.3f", rs.P99Ms),
+			fmt.Sprintf("%.3f", rs.MaxMs),
+		})
+	}
+	tw.SetAutoFormatHeaders(false)
+	tw.Render()
+	for _, rs := range rss {
+		if !rs.Verified {
+			fmt.Printf("%q (%s) cannot be verified: its watchers read the values after the notifications, so that GAPS, DUPLICATES and OUT-OF-ORDER are of the values read, not of the events\n", rs.Backend, rs.DatabaseID)
+		}
+	}
+
+	if opts.SlowWatchers > 0 {
+		printOutcomes(rss)
+	}
+	return nil
+}
+
+// printOutcomes prints the outcome of each watcher, slow consumers first.
+func printOutcomes(rss []dbtester.WatchBenchResult) {
+	tw := tablewriter.NewWriter(os.Stdout)
+	tw.SetHeader([]string{"DATABASE", "BACKEND", "WATCHER", "SLOW", "OUTCOME", "EVENTS", "MISSED", "VERIFIED", "GAPS", "DUPLICATES", "OUT-OF-ORDER", "P99-MS", "ERROR"})
+	for _, rs := range rss {
+		for _, o := range rs.Outcomes {
+			tw.Append([]string{
+				rs.DatabaseID,
+				rs.Backend,
+				fmt.Sprintf("%d", o.Watcher),
+				fmt.Sprintf("%v", o.Slow),
+				o.Outcome,
+				fmt.Sprintf("%d", o.Events),
+				fmt.Sprintf("%d", o.Missed),
+				fmt.Sprintf("%v", rs.Verified),
+				fmt.Sprintf("%d", o.Violations.Gaps),
+				fmt.Sprintf("%d", o.Violations.Duplicates),
+				fmt.Sprintf("%d", o.Violations.OutOfOrder),
+				fmt.Sprintf("%.3f", o.P99Ms),
+				o.Error,
+			})
+		}
+	}
+	tw.SetAutoFormatHeaders(false)
+	tw.Render()
+}
+
+func catchUp(gcfgs []dbtesterpb.ConfigClientMachineAgentControl) error {
+	var rss []dbtester.WatchCatchUpResult
+	steps := make([]control.Step, len(gcfgs))
+	for i := range gcfgs {
+		gcfg := gcfgs[i]
+		steps[i] = control.Step{
+			Name:       gcfg.DatabaseID,
+			DatabaseID: gcfg.DatabaseID,
+			Run: func(*dbtester.Config) error {
+				lg.Info("benchmarking watch catch-up", zap.String("database", gcfg.DatabaseID), zap.Int("backlog", catchUpOpts.Backlog), zap.Strings("endpoints", gcfg.DatabaseEndpoints))
+				rs, err := dbtester.WatchCatchUp(lg, gcfg, catchUpOpts)
+				if err != nil {
+					return fmt.Errorf("failed to benchmark catch-up of %q (%v)", gcfg.DatabaseID, err)
+				}
+				rss = append(rss, rs)
+				return nil
+			},
+		}
+	}
+	if err := control.RunSteps(control.Sequence{}, steps); err != nil {
+		return err
+	}
+
+	tw := tablewriter.NewWriter(os.Stdout)
+	tw.SetHeader([]string{"DATABASE", "BACKLOG", "WATCHERS", "CAUGHT-UP", "ERRORS", "P50-SECONDS", "MAX-SECONDS", "EVENTS-PER-SECOND", "BASELINE-PROBE-P99-MS", "CATCH-UP-PROBE-P99-MS"})
+	for _, rs := range rss {
+		tw.Append([]string{
+			rs.DatabaseID,
+			fmt.Sprintf("%d", rs.Backlog),
+			fmt.Sprintf("%d", rs.Watchers),
+			fmt.Sprintf("%d", rs.CaughtUp),
+			fmt.Sprintf("%d", rs.Errors),
+			fmt.Sprintf("%.3f", rs.P50Seconds),
+			fmt.Sprintf("%.3f", rs.MaxSeconds),
+			fmt.Sprintf("%.1f", rs.EventsPerSecond),
+			fmt.Sprintf("%.3f", rs.BaselineProbeP99Ms),
+			fmt.Sprintf("%.3f", rs.CatchUpProbeP99Ms),
+		})
+	}
+	tw.SetAutoFormatHeaders(false)
+	tw.Render()
+	return nil
+}
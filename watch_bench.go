@@ -0,0 +1,734 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/coreos/dbtester/dbtesterpb"
+	"github.com/coreos/dbtester/pkg/redis"
+
+	"github.com/coreos/etcd/clientv3"
+	consulapi "github.com/hashicorp/consul/api"
+	"github.com/samuel/go-zookeeper/zk"
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+)
+
+// watch benchmark backends
+const (
+	WatchBackendEtcdv3    = "etcd-v3"
+	WatchBackendEtcdv2    = "etcd-v2"
+	WatchBackendZookeeper = "zookeeper"
+	WatchBackendConsul    = "consul"
+	WatchBackendRedis     = "redis"
+)
+
+// watchBenchKey is the key that the watch benchmark writes and watches.
+const watchBenchKey = "dbtester-watch-bench"
+
+// WatchBenchOptions configures the watch benchmark.
+type WatchBenchOptions struct {
+	// Watchers is the number of watchers of the key.
+	Watchers int
+	// Connections is the number of client connections that the
+	// watchers share, in round-robin order.
+	Connections int
+	// Puts is the number of writes of the key, each of which
+	// triggers an event on every watcher.
+	Puts int
+	// PutInterval is the interval between the writes, so that Zookeeper
+	// watches are set again, and Consul blocking queries issued again,
+	// before the next write.
+	PutInterval time.Duration
+	// Timeout is how long to wait for the watchers to be registered,
+	// and for the events of the last write.
+	Timeout time.Duration
+
+	// SlowWatchers is the number of watchers that are slow consumers,
+	// which take 'WatcherDelay' to process each event before receiving
+	// the next one.
+	SlowWatchers int
+	WatcherDelay time.Duration
+
+	// LagInterval is the interval to sample how many writes each
+	// watcher is behind. 0 defaults to one second.
+	LagInterval time.Duration
+	// MetricsAddr is the address to serve the watch lag at '/metrics'
+	// in Prometheus format while the benchmark runs. Empty to not serve.
+	MetricsAddr string
+}
+
+// watcher outcomes
+const (
+	// WatcherCaughtUp is of the watchers that received the last write.
+	WatcherCaughtUp = "caught-up"
+	// WatcherBehind is of the watchers that had not received the last
+	// write by the timeout, such as of the events buffered for them.
+	WatcherBehind = "behind"
+	// WatcherFailed is of the watchers whose watches were dropped or
+	// cancelled, such as of the slow consumers.
+	WatcherFailed = "failed"
+)
+
+// WatcherOutcome is how one watcher kept up with the writes.
+type WatcherOutcome struct {
+	Watcher int
+	Slow    bool
+	Outcome string
+	// Error is of the failed watchers.
+	Error string
+
+	// Events is the number of writes that the watcher received.
+	Events int
+	// Missed is the number of writes that the watcher never received.
+	Missed int
+	P99Ms  float64
+
+	// Violations are of the order of the writes that the watcher
+	// received, not verified if 'Verified' of the result is false.
+	Violations WatchViolations
+}
+
+// WatchBenchResult is the event delivery latency of a backend, from
+// the start of the triggering put to the receipt on each watcher.
+type WatchBenchResult struct {
+	Backend    string
+	DatabaseID string
+	Watchers   int
+	Puts       int
+
+	// Events is the number of writes received by all watchers.
+	Events int64
+	// Missed is the number of writes that watchers never received.
+	// Zookeeper, Consul and Redis watchers read the latest value after
+	// each notification, so that writes in between are missed.
+	Missed int64
+	// Errors is the number of watchers that failed.
+	Errors int
+	// Outcomes are of each watcher.
+	Outcomes []WatcherOutcome
+	// Verified is true if the events of the backend carry the written
+	// values, so that 'Violations' show the gaps, the duplicates and the
+	// reordering of the events. Zookeeper and Redis notifications carry
+	// no value, and Consul queries return only the latest value, so that
+	// their violations are of the values that the watchers read.
+	Verified bool
+	// Violations are of the order of the writes received by all watchers.
+	Violations WatchViolations
+
+	P50Ms float64
+	P99Ms float64
+	MaxMs float64
+
+	// MaxLag is the maximum number of writes that a watcher
+	// was behind, of the lag sampled while writing.
+	MaxLag int64
+}
+
+// WatchBackends returns the watch backends of the database.
+// etcd is watched with its v3 API, and its v2 API if 'etcdv2' is true.
+func WatchBackends(databaseID string, etcdv2 bool) ([]string, error) {
+	switch databaseID {
+	case "etcd__other", "etcd__tip", "etcd__v3_2", "etcd__v3_3":
+		if etcdv2 {
+			return []string{WatchBackendEtcdv3, WatchBackendEtcdv2}, nil
+		}
+		return []string{WatchBackendEtcdv3}, nil
+	case "zookeeper__r3_5_3_beta", "zetcd__beta":
+		return []string{WatchBackendZookeeper}, nil
+	case "consul__v1_0_2", "cetcd__beta":
+		return []string{WatchBackendConsul}, nil
+	case "redis__v4_0":
+		return []string{WatchBackendRedis}, nil
+	case "tikv__v2_1", "mock":
+		return nil, fmt.Errorf("%q does not support watch", databaseID)
+	}
+	return nil, fmt.Errorf("%q is unknown database ID", databaseID)
+}
+
+// WatchBench measures the event delivery latency of the watchers of
+// the backend, on the endpoints of 'gcfg' with the credentials of its
+// benchmark options.
+func WatchBench(lg *zap.Logger, gcfg dbtesterpb.ConfigClientMachineAgentControl, backend string, opts WatchBenchOptions) (WatchBenchResult, error) {
+	rs := WatchBenchResult{Backend: backend, DatabaseID: gcfg.DatabaseID, Watchers: opts.Watchers, Puts: opts.Puts}
+	if opts.Watchers < 1 || opts.Puts < 1 || opts.Connections < 1 {
+		return rs, fmt.Errorf("watchers, puts and connections must be positive (got %d, %d, %d)", opts.Watchers, opts.Puts, opts.Connections)
+	}
+	if opts.Connections > opts.Watchers {
+		opts.Connections = opts.Watchers
+	}
+	if opts.SlowWatchers < 0 || opts.SlowWatchers > opts.Watchers {
+		return rs, fmt.Errorf("slow watchers must be in [0, %d] (got %d)", opts.Watchers, opts.SlowWatchers)
+	}
+	if opts.SlowWatchers > 0 && opts.WatcherDelay <= 0 {
+		return rs, fmt.Errorf("slow watchers require a positive watcher delay (got %v)", opts.WatcherDelay)
+	}
+	if len(gcfg.DatabaseEndpoints) == 0 {
+		return rs, fmt.Errorf("no endpoint to watch %q", gcfg.DatabaseID)
+	}
+	if bopts := gcfg.ConfigClientMachineBenchmarkOptions; bopts != nil {
+		if bopts.EtcdUsername != "" {
+			setEtcdAuth(bopts.EtcdUsername, bopts.EtcdPassword)
+		}
+		if bopts.ConsulToken != "" {
+			consulToken = bopts.ConsulToken
+		}
+	}
+
+	var (
+		b   *watchBackend
+		err error
+	)
+	switch backend {
+	case WatchBackendEtcdv3:
+		b = newWatchBackendEtcdv3(gcfg.DatabaseEndpoints, opts.Connections)
+	case WatchBackendEtcdv2:
+		b = newWatchBackendEtcdv2(gcfg.DatabaseEndpoints, opts.Connections)
+	case WatchBackendZookeeper:
+		b = newWatchBackendZk(gcfg.DatabaseEndpoints, opts.Connections)
+	case WatchBackendConsul:
+		b = newWatchBackendConsul(gcfg.DatabaseEndpoints, opts.Connections)
+	case WatchBackendRedis:
+		if b, err = newWatchBackendRedis(gcfg.DatabaseEndpoints, opts.Connections, gcfg.Flag_Redis_V4_0); err != nil {
+			return rs, err
+		}
+	default:
+		return rs, fmt.Errorf("unknown watch backend %q", backend)
+	}
+	defer b.close()
+	rs.Verified = b.verifiable
+
+	g := newWatchLagGauge(opts.Watchers, gcfg.DatabaseID, backend)
+	if opts.MetricsAddr != "" {
+		stop, err := g.serve(lg, opts.MetricsAddr)
+		if err != nil {
+			return rs, err
+		}
+		defer stop()
+	}
+
+	lats, outcomes, err := runWatchBench(lg, b, opts, g)
+	if err != nil {
+		return rs, err
+	}
+	rs.MaxLag = g.maxLag()
+	for _, o := range outcomes {
+		if o.Outcome == WatcherFailed {
+			rs.Errors++
+		}
+		rs.Violations.add(o.Violations)
+	}
+	rs.Outcomes = outcomes
+	summarizeWatchBench(&rs, lats)
+	return rs, nil
+}
+
+func summarizeWatchBench(rs *WatchBenchResult, lats []float64) {
+	rs.Events = int64(len(lats))
+	if rs.Missed = int64(rs.Watchers)*int64(rs.Puts) - rs.Events; rs.Missed < 0 {
+		rs.Missed = 0
+	}
+	rs.P50Ms = percentileOf(lats, 50)
+	rs.P99Ms = percentileOf(lats, 99)
+	for _, l := range lats {
+		if l > rs.MaxMs {
+			rs.MaxMs = l
+		}
+	}
+}
+
+// watchBackend writes and watches the benchmark key.
+type watchBackend struct {
+	// put writes the key with the sequence number.
+	put func(seq int64) error
+	// watch watches the key on the connection until 'ctx' is done.
+	// It calls 'ready' once the watch is registered, and 'recv' with
+	// the value of each event that the watcher receives, or with the
+	// value read after each notification if the events carry no value.
+	watch func(ctx context.Context, conn int, ready func(), recv func(v []byte, at time.Time)) error
+	// verifiable is true if each event carries the value of its write.
+	verifiable bool
+	close      func()
+}
+
+// runWatchBench writes the key 'opts.Puts' times, and returns the
+// latencies in milliseconds of all events, and the outcomes of the watchers.
+// The lag of the watchers is sampled into 'g' while writing.
+func runWatchBench(lg *zap.Logger, b *watchBackend, opts WatchBenchOptions, g *watchLagGauge) ([]float64, []WatcherOutcome, error) {
+	// watchers start from the value before the first write,
+	// and the writes are of the sequence numbers from 1
+	if err := b.put(0); err != nil {
+		return nil, nil, err
+	}
+	putAt := make([]int64, opts.Puts)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var (
+		readyWg, doneWg, exitWg sync.WaitGroup
+
+		mu       sync.Mutex
+		lats     []float64
+		outcomes = make([]WatcherOutcome, opts.Watchers)
+	)
+	readyWg.Add(opts.Watchers)
+	doneWg.Add(opts.Watchers)
+	exitWg.Add(opts.Watchers)
+	for i := 0; i < opts.Watchers; i++ {
+		go func(i int) {
+			defer exitWg.Done()
+			var readyOnce, doneOnce sync.Once
+			ready := func() { readyOnce.Do(readyWg.Done) }
+			done := func() { doneOnce.Do(doneWg.Done) }
+			// failed watchers are not waited for
+			defer done()
+			defer ready()
+
+			slow := i < opts.SlowWatchers
+			var (
+				ls   []float64
+				last bool
+				vf   = newWatchVerifier()
+			)
+			err := b.watch(ctx, i%opts.Connections, ready, func(v []byte, at time.Time) {
+				seq, ok := vf.observe(watchBenchKey, v)
+				if !ok || seq < 1 || seq > int64(len(putAt)) {
+					// counted as a violation, with no write to measure
+					return
+				}
+				g.observe(i, seq)
+				if t := atomic.LoadInt64(&putAt[seq-1]); t > 0 {
+					ls = append(ls, float64(at.UnixNano()-t)/float64(time.Millisecond))
+				}
+				if seq == int64(len(putAt)) {
+					last = true
+					done()
+				}
+				if slow {
+					// the backend delivers the next event after this returns
+					select {
+					case <-time.After(opts.WatcherDelay):
+					case <-ctx.Done():
+					}
+				}
+			})
+
+			o := WatcherOutcome{Watcher: i, Slow: slow, Outcome: WatcherBehind, Events: len(ls), P99Ms: percentileOf(ls, 99), Violations: vf.result()}
+			if o.Missed = opts.Puts - o.Events; o.Missed < 0 {
+				o.Missed = 0
+			}
+			switch {
+			case err != nil && ctx.Err() == nil:
+				o.Outcome, o.Error = WatcherFailed, err.Error()
+				lg.Warn("watcher failed", zap.Int("watcher", i), zap.Bool("slow", slow), zap.Error(err))
+			case last:
+				o.Outcome = WatcherCaughtUp
+			}
+			mu.Lock()
+			lats = append(lats, ls...)
+			outcomes[i] = o
+			mu.Unlock()
+		}(i)
+	}
+
+	if !waitGroupTimeout(&readyWg, opts.Timeout) {
+		lg.Warn("not all watchers registered", zap.Duration("timeout", opts.Timeout))
+	}
+	lg.Info("started watchers", zap.Int("watchers", opts.Watchers), zap.Int("connections", opts.Connections), zap.Int("slow-watchers", opts.SlowWatchers))
+
+	lagInterval := opts.LagInterval
+	if lagInterval <= 0 {
+		lagInterval = time.Second
+	}
+	stopc, lagDonec := make(chan struct{}), make(chan struct{})
+	go func() {
+		g.run(lg, lagInterval, stopc)
+		close(lagDonec)
+	}()
+	stopLag := func() {
+		close(stopc)
+		<-lagDonec
+	}
+
+	for i := range putAt {
+		seq := int64(i) + 1
+		atomic.StoreInt64(&putAt[i], time.Now().UnixNano())
+		if err := b.put(seq); err != nil {
+			stopLag()
+			cancel()
+			exitWg.Wait()
+			return nil, nil, err
+		}
+		g.write(seq)
+		time.Sleep(opts.PutInterval)
+	}
+
+	if !waitGroupTimeout(&doneWg, opts.Timeout) {
+		lg.Warn("not all watchers received the last write", zap.Duration("timeout", opts.Timeout))
+	}
+	stopLag()
+	cancel()
+	exitWg.Wait()
+	return lats, outcomes, nil
+}
+
+// waitGroupTimeout returns false if 'wg' is not done in the timeout.
+func waitGroupTimeout(wg *sync.WaitGroup, timeout time.Duration) bool {
+	donec := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(donec)
+	}()
+	select {
+	case <-donec:
+		return true
+	case <-time.After(timeout):
+		return false
+	}
+}
+
+// watch backends use the last connection for writes, so that
+// the writes are not queued behind the events to the watchers
+
+func newWatchBackendEtcdv3(endpoints []string, conns int) *watchBackend {
+	clis := make([]*clientv3.Client, conns+1)
+	for i := range clis {
+		clis[i] = mustCreateConnEtcdv3(endpoints)
+	}
+	return &watchBackend{
+		put: func(seq int64) error {
+			_, err := clis[conns].Put(context.Background(), watchBenchKey, string(withSeq(seq, nil)))
+			return err
+		},
+		watch: func(ctx context.Context, conn int, ready func(), recv func([]byte, time.Time)) error {
+			wch := clis[conn].Watch(ctx, watchBenchKey, clientv3.WithCreatedNotify())
+			for wresp := range wch {
+				at := time.Now()
+				if err := wresp.Err(); err != nil {
+					return err
+				}
+				if wresp.Created {
+					ready()
+					continue
+				}
+				for _, ev := range wresp.Events {
+					recv(ev.Kv.Value, at)
+				}
+			}
+			return ctx.Err()
+		},
+		verifiable: true,
+		close: func() {
+			clis[conns].Delete(context.Background(), watchBenchKey)
+			for _, cli := range clis {
+				cli.Close()
+			}
+		},
+	}
+}
+
+// etcdv2Response is the response of the etcd v2 keys API.
+type etcdv2Response struct {
+	Node struct {
+		Value         string `json:"value"`
+		ModifiedIndex uint64 `json:"modifiedIndex"`
+	} `json:"node"`
+	ErrorCode int    `json:"errorCode"`
+	Message   string `json:"message"`
+}
+
+func etcdv2Do(cli *http.Client, req *http.Request) (etcdv2Response, error) {
+	var resp etcdv2Response
+	hresp, err := cli.Do(req)
+	if err != nil {
+		return resp, err
+	}
+	defer hresp.Body.Close()
+	if err = json.NewDecoder(hresp.Body).Decode(&resp); err != nil {
+		return resp, err
+	}
+	if hresp.StatusCode >= 300 {
+		return resp, fmt.Errorf("etcd v2 error %d (%s)", resp.ErrorCode, resp.Message)
+	}
+	return resp, nil
+}
+
+// etcdv2KeysURL returns the URL of the etcd v2 keys API of the endpoint.
+func etcdv2KeysURL(ep string) string {
+	if !strings.Contains(ep, "://") {
+		scheme := "http://"
+		if clientTLS != nil {
+			scheme = "https://"
+		}
+		ep = scheme + ep
+	}
+	return strings.TrimSuffix(ep, "/") + "/v2/keys"
+}
+
+// newWatchBackendEtcdv2 watches with the etcd v2 keys API, over HTTP,
+// which the etcd v3 servers serve with '--enable-v2'.
+func newWatchBackendEtcdv2(endpoints []string, conns int) *watchBackend {
+	keyURLs := make([]string, len(endpoints))
+	for i, ep := range endpoints {
+		keyURLs[i] = etcdv2KeysURL(ep) + "/" + watchBenchKey
+	}
+	clis := make([]*http.Client, conns+1)
+	for i := range clis {
+		// separate transports, for separate connections
+		clis[i] = &http.Client{Transport: &http.Transport{TLSClientConfig: clientTLS}}
+	}
+
+	// index is the etcd index of the value before the first write
+	var index uint64
+	return &watchBackend{
+		put: func(seq int64) error {
+			req, err := http.NewRequest("PUT", keyURLs[0], strings.NewReader(url.Values{"value": {string(withSeq(seq, nil))}}.Encode()))
+			if err != nil {
+				return err
+			}
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			resp, err := etcdv2Do(clis[conns], req)
+			if err == nil && seq < 0 {
+				atomic.StoreUint64(&index, resp.Node.ModifiedIndex)
+			}
+			return err
+		},
+		watch: func(ctx context.Context, conn int, ready func(), recv func([]byte, time.Time)) error {
+			// waits from the index, so that no write is missed
+			// before the first request is sent
+			waitIndex := atomic.LoadUint64(&index) + 1
+			ready()
+			for {
+				req, err := http.NewRequest("GET", fmt.Sprintf("%s?wait=true&waitIndex=%d", keyURLs[conn%len(keyURLs)], waitIndex), nil)
+				if err != nil {
+					return err
+				}
+				resp, err := etcdv2Do(clis[conn], req.WithContext(ctx))
+				if err != nil {
+					return err
+				}
+				recv([]byte(resp.Node.Value), time.Now())
+				waitIndex = resp.Node.ModifiedIndex + 1
+			}
+		},
+		verifiable: true,
+		close: func() {
+			if req, err := http.NewRequest("DELETE", keyURLs[0], nil); err == nil {
+				etcdv2Do(clis[conns], req)
+			}
+		},
+	}
+}
+
+// newWatchBackendZk watches with the one-time Zookeeper watches, which
+// are set again by reading the node after each event. The events carry
+// no value, so that the watchers receive the values read, and the writes
+// between an event and the read are missed.
+func newWatchBackendZk(endpoints []string, conns int) *watchBackend {
+	zconns := mustCreateConnsZk(endpoints, int64(conns+1))
+	path := "/" + watchBenchKey
+	return &watchBackend{
+		put: func(seq int64) error {
+			v := withSeq(seq, nil)
+			_, err := zconns[conns].Set(path, v, -1)
+			if err == zk.ErrNoNode {
+				_, err = zconns[conns].Create(path, v, zkCreateFlags, zkCreateACL)
+			}
+			return err
+		},
+		watch: func(ctx context.Context, conn int, ready func(), recv func([]byte, time.Time)) error {
+			_, _, ech, err := zconns[conn].GetW(path)
+			if err != nil {
+				return err
+			}
+			ready()
+			for {
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case ev := <-ech:
+					if ev.Err != nil {
+						return ev.Err
+					}
+					data, _, nech, err := zconns[conn].GetW(path)
+					if err != nil {
+						return err
+					}
+					ech = nech
+					recv(data, time.Now())
+				}
+			}
+		},
+		close: func() {
+			zconns[conns].Delete(path, -1)
+			for _, c := range zconns {
+				c.Close()
+			}
+		},
+	}
+}
+
+// newWatchBackendConsul watches with Consul blocking queries, which
+// return the latest value, so that the writes between two queries
+// are missed.
+func newWatchBackendConsul(endpoints []string, conns int) *watchBackend {
+	kvs := mustCreateConnsConsul(endpoints, int64(conns+1))
+	return &watchBackend{
+		put: func(seq int64) error {
+			_, err := kvs[conns].Put(&consulapi.KVPair{Key: watchBenchKey, Value: withSeq(seq, nil)}, nil)
+			return err
+		},
+		watch: func(ctx context.Context, conn int, ready func(), recv func([]byte, time.Time)) error {
+			pair, meta, err := kvs[conn].Get(watchBenchKey, nil)
+			if err != nil {
+				return err
+			}
+			if pair == nil {
+				return fmt.Errorf("%q not found", watchBenchKey)
+			}
+			index := meta.LastIndex
+			ready()
+			for {
+				pair, meta, err := kvs[conn].Get(watchBenchKey, (&consulapi.QueryOptions{WaitIndex: index}).WithContext(ctx))
+				if err != nil {
+					return err
+				}
+				at := time.Now()
+				// the query also returns on timeouts, of the same index
+				if meta.LastIndex == index || pair == nil {
+					continue
+				}
+				index = meta.LastIndex
+				recv(pair.Value, at)
+			}
+		},
+		close: func() {
+			kvs[conns].Delete(watchBenchKey, nil)
+		},
+	}
+}
+
+// redisWatchTimeout is the timeout of the commands that set up and
+// clean up the Redis keyspace notifications.
+const redisWatchTimeout = 10 * time.Second
+
+// redisKeyspaceEvents returns the 'notify-keyspace-events' of the server
+// with the keyspace channel (K) of the string commands ($) enabled.
+func redisKeyspaceEvents(events string) string {
+	if !strings.Contains(events, "K") {
+		events += "K"
+	}
+	// 'A' is the alias of all commands
+	if !strings.ContainsAny(events, "$A") {
+		events += "$"
+	}
+	return events
+}
+
+// newWatchBackendRedis watches with the keyspace notifications of Redis
+// Pub/Sub, which it enables on the node of the key until closed. The
+// notifications are fire-and-forget and carry no value, so that the
+// watchers read the value after each one, and the writes between a
+// notification and the read are missed. Each watcher subscribes on its
+// own connection, which takes no other commands; the connections of
+// 'conns' read the value.
+func newWatchBackendRedis(endpoints []string, conns int, flag *dbtesterpb.Flag_Redis_V4_0) (*watchBackend, error) {
+	clis := mustCreateConnsRedis(endpoints, int64(conns+1), flag)
+	closeClis := closeConnsRedis(clis)
+	key := []byte(watchBenchKey)
+
+	ctx, cancel := context.WithTimeout(context.Background(), redisWatchTimeout)
+	defer cancel()
+	// the nodes of a cluster only notify of the keys of their own slots
+	addr, err := clis[conns].NodeOf(ctx, key)
+	if err != nil {
+		closeClis()
+		return nil, err
+	}
+	rp, err := clis[conns].Do(ctx, key, []byte("CONFIG"), []byte("GET"), []byte("notify-keyspace-events"))
+	if err != nil {
+		closeClis()
+		return nil, fmt.Errorf("failed to get 'notify-keyspace-events' of %q (%v)", addr, err)
+	}
+	// the reply is the name and the value
+	var events string
+	if rps, ok := rp.([]interface{}); ok && len(rps) == 2 {
+		if bts, ok := rps[1].([]byte); ok {
+			events = string(bts)
+		}
+	}
+	if _, err = clis[conns].Do(ctx, key, []byte("CONFIG"), []byte("SET"), []byte("notify-keyspace-events"), []byte(redisKeyspaceEvents(events))); err != nil {
+		closeClis()
+		return nil, fmt.Errorf("failed to enable keyspace notifications of %q (%v)", addr, err)
+	}
+
+	return &watchBackend{
+		put: func(seq int64) error {
+			return clis[conns].Set(context.Background(), key, withSeq(seq, nil))
+		},
+		watch: func(ctx context.Context, conn int, ready func(), recv func([]byte, time.Time)) error {
+			sub, err := redis.Subscribe(ctx, addr, redis.KeyspaceChannel(key))
+			if err != nil {
+				return err
+			}
+			defer sub.Close()
+			go func() {
+				<-ctx.Done()
+				sub.Close()
+			}()
+
+			// the benchmark does not write until all are subscribed
+			ready()
+			for {
+				_, payload, err := sub.Receive()
+				if err != nil {
+					if ctx.Err() != nil {
+						return ctx.Err()
+					}
+					// such as of the slow consumers, which the server
+					// disconnects past 'client-output-buffer-limit'
+					return err
+				}
+				if string(payload) != "set" {
+					continue
+				}
+				v, err := clis[conn].Get(ctx, key)
+				if err != nil {
+					if ctx.Err() != nil {
+						return ctx.Err()
+					}
+					return err
+				}
+				recv(v, time.Now())
+			}
+		},
+		close: func() {
+			ctx, cancel := context.WithTimeout(context.Background(), redisWatchTimeout)
+			defer cancel()
+			clis[conns].Del(ctx, key)
+			clis[conns].Do(ctx, key, []byte("CONFIG"), []byte("SET"), []byte("notify-keyspace-events"), []byte(events))
+			closeClis()
+		},
+	}, nil
+}
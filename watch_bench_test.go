@@ -0,0 +1,167 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+)
+
+// newWatchBackendChan broadcasts the writes to the watchers over channels,
+// and drops the write of 'skip' for the watchers. 'dup' is the write to
+// deliver twice, and 'malformed' the write to deliver malformed, if not 0.
+func newWatchBackendChan(watchers int, skip, dup, malformed int64) *watchBackend {
+	var mu sync.Mutex
+	chs := make([]chan []byte, watchers)
+	for i := range chs {
+		chs[i] = make(chan []byte, 1000)
+	}
+	next := 0
+	return &watchBackend{
+		put: func(seq int64) error {
+			// the value before the first write is of no event
+			if seq == 0 || seq == skip {
+				return nil
+			}
+			v := withSeq(seq, nil)
+			if seq == malformed {
+				v = []byte("malformed")
+			}
+			for _, ch := range chs {
+				ch <- v
+				if seq == dup {
+					ch <- v
+				}
+			}
+			return nil
+		},
+		watch: func(ctx context.Context, conn int, ready func(), recv func([]byte, time.Time)) error {
+			mu.Lock()
+			ch := chs[next]
+			next++
+			mu.Unlock()
+			ready()
+			for {
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case v := <-ch:
+					recv(v, time.Now())
+				}
+			}
+		},
+		verifiable: true,
+		close:      func() {},
+	}
+}
+
+func TestRunWatchBench(t *testing.T) {
+	opts := WatchBenchOptions{Watchers: 5, Connections: 2, Puts: 20, Timeout: 5 * time.Second}
+	lats, outcomes, err := runWatchBench(zap.NewNop(), newWatchBackendChan(opts.Watchers, 3, 0, 0), opts, newWatchLagGauge(opts.Watchers, "mock", "chan"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, o := range outcomes {
+		if o.Outcome != WatcherCaughtUp || o.Events != 19 || o.Missed != 1 {
+			t.Fatalf("expected caught up with 19 events, got %+v", o)
+		}
+		if v := o.Violations; v.Gaps != 1 || v.total() != 1 {
+			t.Fatalf("expected 1 gap of the skipped write, got %+v", v)
+		}
+	}
+	rs := WatchBenchResult{Watchers: opts.Watchers, Puts: opts.Puts}
+	summarizeWatchBench(&rs, lats)
+	if rs.Events != 95 || rs.Missed != 5 {
+		t.Fatalf("expected 95 events and 5 missed, got %+v", rs)
+	}
+	if rs.P50Ms < 0 || rs.P50Ms > rs.P99Ms || rs.P99Ms > rs.MaxMs {
+		t.Fatalf("unexpected percentiles %+v", rs)
+	}
+}
+
+func TestRunWatchBenchViolations(t *testing.T) {
+	opts := WatchBenchOptions{Watchers: 2, Connections: 1, Puts: 10, Timeout: 5 * time.Second}
+	_, outcomes, err := runWatchBench(zap.NewNop(), newWatchBackendChan(opts.Watchers, 0, 4, 7), opts, newWatchLagGauge(opts.Watchers, "mock", "chan"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, o := range outcomes {
+		// the malformed write is also a gap of the next write
+		expected := WatchViolations{Gaps: 1, Duplicates: 1, Malformed: 1}
+		if o.Violations != expected {
+			t.Fatalf("expected %+v, got %+v", expected, o.Violations)
+		}
+		if o.Events != 10 {
+			t.Fatalf("expected 10 events with the duplicate, got %d", o.Events)
+		}
+	}
+}
+
+func TestRunWatchBenchSlowWatchers(t *testing.T) {
+	opts := WatchBenchOptions{Watchers: 4, Connections: 2, Puts: 20, Timeout: 50 * time.Millisecond, SlowWatchers: 1, WatcherDelay: 20 * time.Millisecond, LagInterval: 5 * time.Millisecond}
+	g := newWatchLagGauge(opts.Watchers, "mock", "chan")
+	_, outcomes, err := runWatchBench(zap.NewNop(), newWatchBackendChan(opts.Watchers, 0, 0, 0), opts, g)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g.maxLag() == 0 {
+		t.Fatal("expected the slow watcher to lag behind the writes")
+	}
+	if o := outcomes[0]; !o.Slow || o.Outcome != WatcherBehind || o.Events == 0 || o.Events == opts.Puts {
+		t.Fatalf("expected the slow watcher behind, got %+v", o)
+	}
+	for _, o := range outcomes[1:] {
+		if o.Slow || o.Outcome != WatcherCaughtUp {
+			t.Fatalf("expected the other watchers caught up, got %+v", o)
+		}
+	}
+}
+
+func TestWatchBackends(t *testing.T) {
+	if bs, err := WatchBackends("etcd__v3_3", true); err != nil || len(bs) != 2 {
+		t.Fatalf("expected v3 and v2, got %v (%v)", bs, err)
+	}
+	if bs, err := WatchBackends("zetcd__beta", false); err != nil || bs[0] != WatchBackendZookeeper {
+		t.Fatalf("expected %q, got %v (%v)", WatchBackendZookeeper, bs, err)
+	}
+	if bs, err := WatchBackends("redis__v4_0", false); err != nil || bs[0] != WatchBackendRedis {
+		t.Fatalf("expected %q, got %v (%v)", WatchBackendRedis, bs, err)
+	}
+	if _, err := WatchBackends("mock", false); err == nil {
+		t.Fatal("expected error for mock")
+	}
+}
+
+func TestRedisKeyspaceEvents(t *testing.T) {
+	tests := []struct {
+		events string
+		exp    string
+	}{
+		{"", "K$"},
+		{"Ex", "ExK$"},
+		{"K$", "K$"},
+		{"KA", "KA"},
+		{"Eg$", "Eg$K"},
+	}
+	for i, tt := range tests {
+		if got := redisKeyspaceEvents(tt.events); got != tt.exp {
+			t.Fatalf("#%d: expected %q, got %q", i, tt.exp, got)
+		}
+	}
+}
@@ -0,0 +1,260 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/coreos/dbtester/dbtesterpb"
+
+	"github.com/coreos/etcd/clientv3"
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+)
+
+// watchCatchUpProbeKey is the key that the catch-up benchmark writes
+// to probe the write latency of the server.
+const watchCatchUpProbeKey = watchBenchKey + "-probe"
+
+// WatchCatchUpOptions configures the watch catch-up benchmark.
+type WatchCatchUpOptions struct {
+	// Backlog is the number of writes before the watchers start,
+	// which every watcher receives from the past revision.
+	Backlog int
+	// ValueSizeBytes is the size of the value of each write.
+	ValueSizeBytes int
+	// Watchers is the number of watchers that catch up at the same time.
+	Watchers int
+	// Connections is the number of client connections that the
+	// watchers share, in round-robin order.
+	Connections int
+	// ProbeInterval is the interval between the writes that probe
+	// the write latency, before and while the watchers catch up.
+	ProbeInterval time.Duration
+	// Timeout is how long to wait for the watchers to catch up.
+	Timeout time.Duration
+}
+
+// WatchCatchUpResult is how fast the watchers catch up through the
+// backlog, and how the catch-up slows the writes of the server.
+type WatchCatchUpResult struct {
+	DatabaseID string
+	Backlog    int
+	Watchers   int
+
+	// CaughtUp is the number of watchers that received all the backlog.
+	CaughtUp int
+	// Errors is the number of watchers that failed, such as of
+	// the backlog being compacted.
+	Errors int
+
+	// P50Seconds and MaxSeconds are of the times of the watchers
+	// to receive all the backlog.
+	P50Seconds float64
+	MaxSeconds float64
+	// EventsPerSecond is the events received by all watchers per second,
+	// until the last watcher caught up.
+	EventsPerSecond float64
+
+	// BaselineProbeP99Ms and CatchUpProbeP99Ms are the p99 latencies
+	// of the probe writes before and while the watchers catch up.
+	BaselineProbeP99Ms float64
+	CatchUpProbeP99Ms  float64
+}
+
+// watchCatchUpBackend writes the backlog and watches from its start.
+type watchCatchUpBackend struct {
+	// put writes the key, and returns the revision of the write.
+	put func(ctx context.Context, key string, v []byte) (int64, error)
+	// watch watches the benchmark key on the connection from the
+	// revision until 'ctx' is done, and calls 'recv' with the number
+	// of the events of each response.
+	watch func(ctx context.Context, conn int, rev int64, recv func(n int)) error
+	close func()
+}
+
+// WatchCatchUp measures the watchers that start from a revision far in
+// the past, as after a long disconnect, and catch up through the backlog
+// of events since. Only etcd v3 keeps the history of the events to catch
+// up through; Zookeeper watches and Consul blocking queries only return
+// the latest value.
+func WatchCatchUp(lg *zap.Logger, gcfg dbtesterpb.ConfigClientMachineAgentControl, opts WatchCatchUpOptions) (WatchCatchUpResult, error) {
+	rs := WatchCatchUpResult{DatabaseID: gcfg.DatabaseID, Backlog: opts.Backlog, Watchers: opts.Watchers}
+	if opts.Backlog < 1 || opts.Watchers < 1 || opts.Connections < 1 {
+		return rs, fmt.Errorf("backlog, watchers and connections must be positive (got %d, %d, %d)", opts.Backlog, opts.Watchers, opts.Connections)
+	}
+	if opts.ProbeInterval <= 0 {
+		return rs, fmt.Errorf("probe interval must be positive (got %v)", opts.ProbeInterval)
+	}
+	if opts.Connections > opts.Watchers {
+		opts.Connections = opts.Watchers
+	}
+	switch gcfg.DatabaseID {
+	case "etcd__other", "etcd__tip", "etcd__v3_2", "etcd__v3_3":
+	default:
+		return rs, fmt.Errorf("%q does not keep the history of the events to catch up through", gcfg.DatabaseID)
+	}
+	if len(gcfg.DatabaseEndpoints) == 0 {
+		return rs, fmt.Errorf("no endpoint to watch %q", gcfg.DatabaseID)
+	}
+	if bopts := gcfg.ConfigClientMachineBenchmarkOptions; bopts != nil && bopts.EtcdUsername != "" {
+		setEtcdAuth(bopts.EtcdUsername, bopts.EtcdPassword)
+	}
+
+	b := newWatchCatchUpEtcdv3(gcfg.DatabaseEndpoints, opts.Connections)
+	defer b.close()
+	return runWatchCatchUp(lg, b, opts, rs)
+}
+
+func runWatchCatchUp(lg *zap.Logger, b *watchCatchUpBackend, opts WatchCatchUpOptions, rs WatchCatchUpResult) (WatchCatchUpResult, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	val := make([]byte, opts.ValueSizeBytes)
+	var startRev int64
+	for seq := 0; seq < opts.Backlog; seq++ {
+		rev, err := b.put(ctx, watchBenchKey, withSeq(int64(seq), val))
+		if err != nil {
+			return rs, err
+		}
+		if seq == 0 {
+			startRev = rev
+		}
+	}
+	lg.Info("wrote backlog", zap.Int("backlog", opts.Backlog), zap.Int64("start-revision", startRev))
+
+	// probe writes until 'stopc' is closed, and returns the latencies
+	probe := func(stopc <-chan struct{}) []float64 {
+		var lats []float64
+		ticker := time.NewTicker(opts.ProbeInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+			case <-stopc:
+				return lats
+			}
+			now := time.Now()
+			if _, err := b.put(ctx, watchCatchUpProbeKey, nil); err != nil {
+				lg.Warn("probe write failed", zap.Error(err))
+				continue
+			}
+			lats = append(lats, float64(time.Since(now))/float64(time.Millisecond))
+		}
+	}
+	// the latencies of the probe writes before the catch-up are the baseline
+	stopc := make(chan struct{})
+	time.AfterFunc(10*opts.ProbeInterval, func() { close(stopc) })
+	baseline := probe(stopc)
+
+	var (
+		doneWg, exitWg sync.WaitGroup
+
+		mu    sync.Mutex
+		tooks []float64
+		errN  int
+	)
+	doneWg.Add(opts.Watchers)
+	exitWg.Add(opts.Watchers)
+	start := time.Now()
+	for i := 0; i < opts.Watchers; i++ {
+		go func(i int) {
+			defer exitWg.Done()
+			var doneOnce sync.Once
+			done := func() { doneOnce.Do(doneWg.Done) }
+			defer done()
+
+			var received int64
+			err := b.watch(ctx, i%opts.Connections, startRev, func(n int) {
+				if received += int64(n); received < int64(opts.Backlog) {
+					return
+				}
+				doneOnce.Do(func() {
+					mu.Lock()
+					tooks = append(tooks, time.Since(start).Seconds())
+					mu.Unlock()
+					doneWg.Done()
+				})
+			})
+			if err != nil && ctx.Err() == nil {
+				mu.Lock()
+				errN++
+				mu.Unlock()
+				lg.Warn("watcher failed", zap.Int("watcher", i), zap.Error(err))
+			}
+		}(i)
+	}
+
+	probeStopc, probeDonec := make(chan struct{}), make(chan []float64, 1)
+	go func() { probeDonec <- probe(probeStopc) }()
+	if !waitGroupTimeout(&doneWg, opts.Timeout) {
+		lg.Warn("not all watchers caught up", zap.Duration("timeout", opts.Timeout))
+	}
+	close(probeStopc)
+	during := <-probeDonec
+	cancel()
+	exitWg.Wait()
+
+	mu.Lock()
+	defer mu.Unlock()
+	rs.CaughtUp, rs.Errors = len(tooks), errN
+	rs.P50Seconds = percentileOf(tooks, 50)
+	for _, took := range tooks {
+		if took > rs.MaxSeconds {
+			rs.MaxSeconds = took
+		}
+	}
+	if rs.MaxSeconds > 0 {
+		rs.EventsPerSecond = float64(rs.CaughtUp) * float64(opts.Backlog) / rs.MaxSeconds
+	}
+	rs.BaselineProbeP99Ms = percentileOf(baseline, 99)
+	rs.CatchUpProbeP99Ms = percentileOf(during, 99)
+	return rs, nil
+}
+
+func newWatchCatchUpEtcdv3(endpoints []string, conns int) *watchCatchUpBackend {
+	clis := make([]*clientv3.Client, conns+1)
+	for i := range clis {
+		clis[i] = mustCreateConnEtcdv3(endpoints)
+	}
+	return &watchCatchUpBackend{
+		put: func(ctx context.Context, key string, v []byte) (int64, error) {
+			resp, err := clis[conns].Put(ctx, key, string(v))
+			if err != nil {
+				return 0, err
+			}
+			return resp.Header.Revision, nil
+		},
+		watch: func(ctx context.Context, conn int, rev int64, recv func(int)) error {
+			for wresp := range clis[conn].Watch(ctx, watchBenchKey, clientv3.WithRev(rev)) {
+				if err := wresp.Err(); err != nil {
+					// e.g. of the backlog being compacted
+					return err
+				}
+				recv(len(wresp.Events))
+			}
+			return ctx.Err()
+		},
+		close: func() {
+			clis[conns].Delete(context.Background(), watchBenchKey)
+			clis[conns].Delete(context.Background(), watchCatchUpProbeKey)
+			for _, cli := range clis {
+				cli.Close()
+			}
+		},
+	}
+}
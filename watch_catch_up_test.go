@@ -0,0 +1,103 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/coreos/dbtester/dbtesterpb"
+
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+)
+
+// newWatchCatchUpMem keeps the history of the benchmark key in memory,
+// and fails the watches from before 'compacted'.
+func newWatchCatchUpMem(compacted int64) *watchCatchUpBackend {
+	var (
+		mu  sync.Mutex
+		rev int64
+		// revs are the revisions of the benchmark key
+		revs []int64
+	)
+	return &watchCatchUpBackend{
+		put: func(ctx context.Context, key string, v []byte) (int64, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			rev++
+			if key == watchBenchKey {
+				revs = append(revs, rev)
+			}
+			return rev, nil
+		},
+		watch: func(ctx context.Context, conn int, from int64, recv func(int)) error {
+			if from < compacted {
+				return fmt.Errorf("required revision %d has been compacted", from)
+			}
+			mu.Lock()
+			var n int
+			for _, r := range revs {
+				if r >= from {
+					n++
+				}
+			}
+			mu.Unlock()
+			// in responses of at most 10 events
+			for ; n > 0; n -= 10 {
+				if n < 10 {
+					recv(n)
+					break
+				}
+				recv(10)
+			}
+			<-ctx.Done()
+			return ctx.Err()
+		},
+		close: func() {},
+	}
+}
+
+func TestRunWatchCatchUp(t *testing.T) {
+	opts := WatchCatchUpOptions{Backlog: 95, Watchers: 4, Connections: 2, ProbeInterval: time.Millisecond, Timeout: 5 * time.Second}
+	rs, err := runWatchCatchUp(zap.NewNop(), newWatchCatchUpMem(0), opts, WatchCatchUpResult{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rs.CaughtUp != 4 || rs.Errors != 0 {
+		t.Fatalf("expected 4 watchers caught up, got %+v", rs)
+	}
+	if rs.P50Seconds > rs.MaxSeconds || rs.EventsPerSecond <= 0 || rs.BaselineProbeP99Ms < 0 {
+		t.Fatalf("unexpected result %+v", rs)
+	}
+
+	opts.Timeout = 100 * time.Millisecond
+	if rs, err = runWatchCatchUp(zap.NewNop(), newWatchCatchUpMem(2), opts, WatchCatchUpResult{}); err != nil {
+		t.Fatal(err)
+	}
+	if rs.CaughtUp != 0 || rs.Errors != 4 {
+		t.Fatalf("expected 4 watchers of the compacted backlog failed, got %+v", rs)
+	}
+}
+
+func TestWatchCatchUpUnsupported(t *testing.T) {
+	gcfg := dbtesterpb.ConfigClientMachineAgentControl{DatabaseID: "consul__v1_0_2", DatabaseEndpoints: []string{"127.0.0.1:8500"}}
+	opts := WatchCatchUpOptions{Backlog: 1, Watchers: 1, Connections: 1, ProbeInterval: time.Millisecond}
+	if _, err := WatchCatchUp(zap.NewNop(), gcfg, opts); err == nil {
+		t.Fatal("expected error of Consul")
+	}
+}
@@ -0,0 +1,180 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+)
+
+// watchLagSample is the watch lag of all watchers at a point in time.
+// Revisions are the sequence numbers of the writes of the watched key.
+type watchLagSample struct {
+	unixSecond int64
+	// written is the newest revision written by the put workload.
+	written int64
+	// lags is the newest written revision minus the newest
+	// revision observed by each watcher.
+	lags []int64
+}
+
+func (s watchLagSample) max() int64 {
+	var m int64
+	for _, l := range s.lags {
+		if l > m {
+			m = l
+		}
+	}
+	return m
+}
+
+// watchLagGauge tracks how far each watcher is behind the writers,
+// when watch and put workloads run together.
+type watchLagGauge struct {
+	// labels are of the published metrics
+	labels string
+
+	mu       sync.Mutex
+	written  int64
+	observed []int64
+	samples  []watchLagSample
+}
+
+func newWatchLagGauge(watcherN int, databaseID, backend string) *watchLagGauge {
+	return &watchLagGauge{
+		labels: fmt.Sprintf(`database_id="%s",backend="%s"`,
+			escapeOpenMetricsLabel(databaseID), escapeOpenMetricsLabel(backend)),
+		observed: make([]int64, watcherN),
+	}
+}
+
+// write records the revision returned by a put.
+func (g *watchLagGauge) write(rev int64) {
+	g.mu.Lock()
+	if rev > g.written {
+		g.written = rev
+	}
+	g.mu.Unlock()
+}
+
+// observe records the revision of an event received by the watcher.
+func (g *watchLagGauge) observe(watcher int, rev int64) {
+	g.mu.Lock()
+	if rev > g.observed[watcher] {
+		g.observed[watcher] = rev
+	}
+	g.mu.Unlock()
+}
+
+// sample returns the current lag of each watcher.
+func (g *watchLagGauge) sample(now time.Time) watchLagSample {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	s := watchLagSample{unixSecond: now.Unix(), written: g.written, lags: make([]int64, len(g.observed))}
+	for i, rev := range g.observed {
+		if g.written > rev {
+			s.lags[i] = g.written - rev
+		}
+	}
+	return s
+}
+
+// record samples and logs the current lag of each watcher.
+func (g *watchLagGauge) record(lg *zap.Logger, now time.Time) watchLagSample {
+	s := g.sample(now)
+	g.mu.Lock()
+	g.samples = append(g.samples, s)
+	g.mu.Unlock()
+	lg.Sugar().Infof("watch lag [newest written revision: %d | maximum lag: %d | lags: %v]", s.written, s.max(), s.lags)
+	return s
+}
+
+// run publishes the lag gauge at every interval until 'stopc' is closed.
+func (g *watchLagGauge) run(lg *zap.Logger, interval time.Duration, stopc <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case now := <-ticker.C:
+			g.record(lg, now)
+		case <-stopc:
+			return
+		}
+	}
+}
+
+// maxLag returns the maximum lag of all the published samples.
+func (g *watchLagGauge) maxLag() int64 {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	var m int64
+	for _, s := range g.samples {
+		if l := s.max(); l > m {
+			m = l
+		}
+	}
+	return m
+}
+
+// format formats the current lag in the Prometheus text format.
+func (g *watchLagGauge) format() []byte {
+	s := g.sample(time.Now())
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "# HELP dbtester_watch_written_revision Newest revision written by the put workload.\n")
+	fmt.Fprintf(&buf, "# TYPE dbtester_watch_written_revision gauge\n")
+	fmt.Fprintf(&buf, "dbtester_watch_written_revision{%s} %d\n", g.labels, s.written)
+	fmt.Fprintf(&buf, "# HELP dbtester_watch_lag_revisions Number of written revisions that the watcher has not observed.\n")
+	fmt.Fprintf(&buf, "# TYPE dbtester_watch_lag_revisions gauge\n")
+	for i, l := range s.lags {
+		fmt.Fprintf(&buf, "dbtester_watch_lag_revisions{%s,watcher=\"%d\"} %d\n", g.labels, i, l)
+	}
+	fmt.Fprintf(&buf, "# HELP dbtester_watch_max_lag_revisions Maximum lag of all watchers.\n")
+	fmt.Fprintf(&buf, "# TYPE dbtester_watch_max_lag_revisions gauge\n")
+	fmt.Fprintf(&buf, "dbtester_watch_max_lag_revisions{%s} %d\n", g.labels, s.max())
+	return buf.Bytes()
+}
+
+func (g *watchLagGauge) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	w.Write(g.format())
+}
+
+// serve serves the lag gauge at '/metrics' of the address,
+// until the returned function is called.
+func (g *watchLagGauge) serve(lg *zap.Logger, addr string) (stop func(), err error) {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", g)
+	srv := &http.Server{Handler: mux}
+	go srv.Serve(ln)
+	lg.Info("serving watch lag metrics", zap.String("address", ln.Addr().String()))
+
+	return func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		srv.Shutdown(ctx)
+		cancel()
+	}, nil
+}
@@ -0,0 +1,95 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func TestWatchLagGauge(t *testing.T) {
+	g := newWatchLagGauge(2, "mock", "chan")
+	g.write(10)
+	g.write(7)
+	g.observe(0, 10)
+	g.observe(1, 4)
+	g.observe(1, 3)
+
+	s := g.sample(time.Unix(1, 0))
+	if s.written != 10 || s.lags[0] != 0 || s.lags[1] != 6 || s.max() != 6 {
+		t.Fatalf("unexpected sample %+v", s)
+	}
+
+	out := string(g.format())
+	for _, exp := range []string{
+		`dbtester_watch_written_revision{database_id="mock",backend="chan"} 10`,
+		`dbtester_watch_lag_revisions{database_id="mock",backend="chan",watcher="0"} 0`,
+		`dbtester_watch_lag_revisions{database_id="mock",backend="chan",watcher="1"} 6`,
+		`dbtester_watch_max_lag_revisions{database_id="mock",backend="chan"} 6`,
+	} {
+		if !strings.Contains(out, exp+"\n") {
+			t.Fatalf("expected %q in\n%s", exp, out)
+		}
+	}
+}
+
+func TestWatchLagGaugeServe(t *testing.T) {
+	g := newWatchLagGauge(1, "mock", "chan")
+	g.write(3)
+
+	stopc := make(chan struct{})
+	donec := make(chan struct{})
+	go func() {
+		g.run(zap.NewNop(), time.Millisecond, stopc)
+		close(donec)
+	}()
+	time.Sleep(10 * time.Millisecond)
+	close(stopc)
+	<-donec
+	if g.maxLag() != 3 {
+		t.Fatalf("expected maximum lag 3, got %d", g.maxLag())
+	}
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := ln.Addr().String()
+	ln.Close()
+	stop, err := g.serve(zap.NewNop(), addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stop()
+
+	resp, err := http.Get("http://" + addr + "/metrics")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	bts, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(bts), "# TYPE dbtester_watch_lag_revisions gauge") {
+		t.Fatalf("unexpected metrics %q", bts)
+	}
+}
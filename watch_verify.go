@@ -0,0 +1,122 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+)
+
+// seqWidth is the number of leading bytes of a value
+// that encode its per-key sequence number.
+const seqWidth = 20
+
+// withSeq returns a copy of the value with the sequence number
+// written in its first 'seqWidth' bytes. Values shorter than
+// 'seqWidth' are extended, so that the sequence is never truncated.
+func withSeq(seq int64, v []byte) []byte {
+	n := len(v)
+	if n < seqWidth {
+		n = seqWidth
+	}
+	bts := make([]byte, n)
+	copy(bts, v)
+	copy(bts, fmt.Sprintf("%0*d", seqWidth, seq))
+	return bts
+}
+
+// parseSeq returns the sequence number embedded by 'withSeq'.
+func parseSeq(v []byte) (int64, error) {
+	if len(v) < seqWidth {
+		return 0, fmt.Errorf("value is too short for sequence number (got %d bytes)", len(v))
+	}
+	return strconv.ParseInt(string(v[:seqWidth]), 10, 64)
+}
+
+// WatchViolations counts the ordering violations observed by watchers.
+type WatchViolations struct {
+	// Gaps is the number of missing events between two observed events.
+	Gaps int64
+	// Duplicates is the number of events observed more than once.
+	Duplicates int64
+	// OutOfOrder is the number of events observed after a later event.
+	OutOfOrder int64
+	// Malformed is the number of events without a valid sequence number.
+	Malformed int64
+}
+
+func (v WatchViolations) total() int64 {
+	return v.Gaps + v.Duplicates + v.OutOfOrder + v.Malformed
+}
+
+func (v *WatchViolations) add(o WatchViolations) {
+	v.Gaps += o.Gaps
+	v.Duplicates += o.Duplicates
+	v.OutOfOrder += o.OutOfOrder
+	v.Malformed += o.Malformed
+}
+
+// watchVerifier verifies per-key ordering of the events received
+// by a watcher, where each key is written with sequence numbers 1, 2, 3...
+type watchVerifier struct {
+	mu         sync.Mutex
+	last       map[string]int64
+	violations WatchViolations
+}
+
+func newWatchVerifier() *watchVerifier {
+	return &watchVerifier{last: make(map[string]int64)}
+}
+
+// observe records an event of the key with the value, and returns
+// the sequence number of the value, or false if it is malformed.
+func (w *watchVerifier) observe(key string, v []byte) (int64, bool) {
+	seq, err := parseSeq(v)
+	if err != nil {
+		w.mu.Lock()
+		w.violations.Malformed++
+		w.mu.Unlock()
+		return 0, false
+	}
+	w.observeSeq(key, seq)
+	return seq, true
+}
+
+// observeSeq records an event of the key with the sequence number.
+func (w *watchVerifier) observeSeq(key string, seq int64) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	last := w.last[key]
+	switch {
+	case seq == last+1:
+		w.last[key] = seq
+	case seq > last+1:
+		w.violations.Gaps += seq - last - 1
+		w.last[key] = seq
+	case seq == last:
+		w.violations.Duplicates++
+	default:
+		w.violations.OutOfOrder++
+	}
+}
+
+// result returns the violations observed so far.
+func (w *watchVerifier) result() WatchViolations {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.violations
+}
@@ -0,0 +1,40 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import "testing"
+
+func TestWatchVerifier(t *testing.T) {
+	v := []byte("value")
+	w := newWatchVerifier()
+	for _, seq := range []int64{1, 2, 2, 5, 4, 6} {
+		w.observe("foo", withSeq(seq, v))
+	}
+	w.observe("bar", withSeq(1, v))
+	w.observe("bar", []byte("malformed"))
+
+	expected := WatchViolations{Gaps: 2, Duplicates: 1, OutOfOrder: 1, Malformed: 1}
+	if rs := w.result(); rs != expected {
+		t.Fatalf("expected %+v, got %+v", expected, rs)
+	}
+	if expected.total() != 5 {
+		t.Fatalf("expected 5 violations, got %d", expected.total())
+	}
+
+	seq, err := parseSeq(withSeq(123, make([]byte, 1024)))
+	if err != nil || seq != 123 {
+		t.Fatalf("expected 123, got %d (%v)", seq, err)
+	}
+}
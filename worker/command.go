@@ -0,0 +1,65 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/coreos/dbtester"
+	"github.com/coreos/dbtester/dbtesterpb"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+)
+
+var grpcPort string
+
+func init() {
+	Command.PersistentFlags().StringVar(&grpcPort, "worker-port", ":3700", "Port to serve worker gRPC server for the coordinating 'control --workers'.")
+}
+
+// Command implements 'worker' command.
+var Command = &cobra.Command{
+	Use:   "worker",
+	Short: "Generates load for a coordinating 'control'.",
+	RunE:  commandFunc,
+}
+
+func commandFunc(cmd *cobra.Command, args []string) error {
+	ln, err := net.Listen("tcp", grpcPort)
+	if err != nil {
+		return err
+	}
+	grpcServer := grpc.NewServer()
+	dbtesterpb.RegisterWorkerServer(grpcServer, dbtester.NewWorkerServer(lg))
+
+	errc := make(chan error, 1)
+	go func() { errc <- grpcServer.Serve(ln) }()
+	lg.Info("worker started", zap.String("grpc-server-port", grpcPort))
+
+	notifier := make(chan os.Signal, 1)
+	signal.Notify(notifier, syscall.SIGINT, syscall.SIGTERM)
+	select {
+	case sig := <-notifier:
+		lg.Info("received signal", zap.String("signal", sig.String()))
+	case err = <-errc:
+	}
+	grpcServer.Stop()
+	return err
+}
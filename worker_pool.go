@@ -0,0 +1,125 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"sync"
+	"time"
+
+	"github.com/coreos/dbtester/dbtesterpb"
+)
+
+// workerPool runs a goroutine for each active request handler, where
+// the number of active handlers can be changed while the requests run.
+// The handlers keep their connections while inactive, so that a resize
+// does not add the connection setup to the latencies.
+type workerPool struct {
+	handlers []ReqHandler
+	work     func(rh ReqHandler, stopc <-chan struct{})
+
+	mu sync.Mutex
+	// stopcs are of the active handlers, the first len(stopcs) ones
+	stopcs []chan struct{}
+	// done is true once waited, to not start workers after
+	done bool
+	wg   sync.WaitGroup
+}
+
+func newWorkerPool(handlers []ReqHandler, work func(rh ReqHandler, stopc <-chan struct{})) *workerPool {
+	return &workerPool{handlers: handlers, work: work}
+}
+
+// resize starts or stops the workers to run n handlers, at least 1 so
+// that the requests are never left without a consumer, and at most all
+// of them. It returns the number of the active handlers.
+func (p *workerPool) resize(n int) int {
+	if n < 1 {
+		n = 1
+	}
+	if n > len(p.handlers) {
+		n = len(p.handlers)
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for !p.done && len(p.stopcs) < n {
+		stopc := make(chan struct{})
+		p.stopcs = append(p.stopcs, stopc)
+		p.wg.Add(1)
+		go func(rh ReqHandler) {
+			defer p.wg.Done()
+			p.work(rh, stopc)
+		}(p.handlers[len(p.stopcs)-1])
+	}
+	for len(p.stopcs) > n {
+		// the worker returns after its request in flight if any
+		close(p.stopcs[len(p.stopcs)-1])
+		p.stopcs = p.stopcs[:len(p.stopcs)-1]
+	}
+	return n
+}
+
+// size returns the number of the active handlers.
+func (p *workerPool) size() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.stopcs)
+}
+
+// wait waits for the workers to return, after the requests are closed.
+func (p *workerPool) wait() {
+	p.mu.Lock()
+	p.done = true
+	p.mu.Unlock()
+	p.wg.Wait()
+}
+
+// minRampInterval is the shortest interval between the steps of a ramp.
+const minRampInterval = 10 * time.Millisecond
+
+// ramp grows the pool linearly from its size to all the handlers over
+// d, and returns when done or when stopc closes.
+func (p *workerPool) ramp(d time.Duration, stopc <-chan struct{}) {
+	from, to := p.size(), len(p.handlers)
+	if d <= 0 || from >= to {
+		p.resize(to)
+		return
+	}
+	interval := d / time.Duration(to-from)
+	if interval < minRampInterval {
+		interval = minRampInterval
+	}
+	start := time.Now()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stopc:
+			return
+		case now := <-ticker.C:
+			elapsed := now.Sub(start)
+			if elapsed >= d {
+				p.resize(to)
+				return
+			}
+			p.resize(from + int(int64(to-from)*int64(elapsed)/int64(d)))
+		}
+	}
+}
+
+// rampUpDuration returns the configured duration to start all the clients,
+// or 0 to start them at once.
+func rampUpDuration(gcfg dbtesterpb.ConfigClientMachineAgentControl) time.Duration {
+	return time.Duration(gcfg.ConfigClientMachineBenchmarkOptions.RampUpSeconds) * time.Second
+}
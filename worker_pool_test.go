@@ -0,0 +1,143 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/coreos/dbtester/dbtesterpb"
+	"golang.org/x/net/context"
+)
+
+func TestWorkerPoolResize(t *testing.T) {
+	var running int64
+	reqs := make(chan struct{})
+	work := func(rh ReqHandler, stopc <-chan struct{}) {
+		atomic.AddInt64(&running, 1)
+		defer atomic.AddInt64(&running, -1)
+		for {
+			select {
+			case <-stopc:
+				return
+			case _, ok := <-reqs:
+				if !ok {
+					return
+				}
+			}
+		}
+	}
+	p := newWorkerPool(make([]ReqHandler, 4), work)
+
+	waitRunning := func(n int64) {
+		for i := 0; i < 100 && atomic.LoadInt64(&running) != n; i++ {
+			time.Sleep(5 * time.Millisecond)
+		}
+		if got := atomic.LoadInt64(&running); got != n {
+			t.Fatalf("expected %d workers running, got %d", n, got)
+		}
+	}
+	for _, tt := range []struct {
+		n, expected int
+	}{
+		{2, 2},
+		{10, 4},
+		{1, 1},
+		{0, 1},
+		{3, 3},
+	} {
+		if got := p.resize(tt.n); got != tt.expected {
+			t.Fatalf("resize(%d) expected %d, got %d", tt.n, tt.expected, got)
+		}
+		if p.size() != tt.expected {
+			t.Fatalf("size expected %d, got %d", tt.expected, p.size())
+		}
+		waitRunning(int64(tt.expected))
+	}
+
+	close(reqs)
+	p.wait()
+	waitRunning(0)
+	if p.resize(4); atomic.LoadInt64(&running) != 0 {
+		t.Fatal("expected no worker started after wait")
+	}
+}
+
+func TestBenchmarkRampUp(t *testing.T) {
+	var handled int64
+	h := make([]ReqHandler, 8)
+	for i := range h {
+		h[i] = func(ctx context.Context, req *request) error {
+			atomic.AddInt64(&handled, 1)
+			time.Sleep(time.Millisecond)
+			return nil
+		}
+	}
+	b := newBenchmark(200, 8, h, nil, func(ctx context.Context, inflightReqs chan<- request) {
+		for i := 0; i < 200; i++ {
+			inflightReqs <- request{}
+		}
+		close(inflightReqs)
+	})
+	b.progress.interval = 0
+	b.rampUp = 100 * time.Millisecond
+	b.startRequests()
+	if n := b.pool.size(); n != 1 {
+		t.Fatalf("expected 1 client at the start of the ramp, got %d", n)
+	}
+	b.waitAll()
+
+	if handled != 200 || b.stats.requests() != 200 {
+		t.Fatalf("expected 200 requests, got %d handled and %d reported", handled, b.stats.requests())
+	}
+}
+
+func TestBenchmarkCancel(t *testing.T) {
+	h := make([]ReqHandler, 4)
+	for i := range h {
+		// blocks until cancelled, as a request to an unresponsive database
+		h[i] = func(ctx context.Context, req *request) error {
+			<-ctx.Done()
+			return ctx.Err()
+		}
+	}
+	gcfg := dbtesterpb.ConfigClientMachineAgentControl{
+		DatabaseID:                          "mock",
+		ConfigClientMachineBenchmarkOptions: &dbtesterpb.ConfigClientMachineBenchmarkOptions{RequestNumber: 1000},
+	}
+	b := newBenchmark(1000, 4, h, nil, func(ctx context.Context, inflightReqs chan<- request) {
+		generateReads(ctx, gcfg, "foo", nil, inflightReqs)
+	})
+	b.progress.interval = 0
+	ctx, cancel := context.WithCancel(context.Background())
+	b.ctx = ctx
+	b.startRequests()
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	donec := make(chan struct{})
+	go func() {
+		b.waitAll()
+		close(donec)
+	}()
+	select {
+	case <-donec:
+	case <-time.After(5 * time.Second):
+		t.Fatal("took too long to cancel the requests in flight")
+	}
+	if b.stats.requests() != 0 || len(b.stats.ErrorDist) != 0 {
+		t.Fatalf("expected no result of the cancelled requests, got %d and %v", b.stats.requests(), b.stats.ErrorDist)
+	}
+}
@@ -0,0 +1,207 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/coreos/dbtester/dbtesterpb"
+	"github.com/coreos/dbtester/pkg/hdrhistogram"
+
+	"github.com/gyuho/dataframe"
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+)
+
+// unknownZone is the zone of the requests whose server is not known,
+// or has no zone.
+const unknownZone = "unknown"
+
+// ParseEndpointZones parses the zones of the endpoints, of the form
+// "endpoint=zone", where the endpoint is "host:port" or only the host.
+func ParseEndpointZones(ss []string) (map[string]string, error) {
+	zones := make(map[string]string, len(ss))
+	for _, s := range ss {
+		kv := strings.SplitN(s, "=", 2)
+		if len(kv) != 2 || kv[0] == "" || kv[1] == "" {
+			return nil, fmt.Errorf("endpoint zone %q is not 'endpoint=zone'", s)
+		}
+		zones[kv[0]] = kv[1]
+	}
+	return zones, nil
+}
+
+// zoneOf returns the zone of the server address, or of its host.
+func zoneOf(zones map[string]string, addr string) (string, bool) {
+	addr = endpointHostPort(addr)
+	if z, ok := zones[addr]; ok {
+		return z, true
+	}
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		z, ok := zones[host]
+		return z, ok
+	}
+	return "", false
+}
+
+type zoneStats struct {
+	requests     int64
+	totalLatency time.Duration
+	h            *hdrhistogram.Histogram
+}
+
+// zoneBreakdown attributes each request to the zone of the member that
+// served it, from the member that the request handlers record in the
+// traces, and records the latencies of the loader zone to each server zone.
+type zoneBreakdown struct {
+	loaderZone string
+	// memberZones maps the members of the traces to the zones:
+	// the endpoints of Zookeeper, and the member IDs of etcd
+	memberZones map[string]string
+
+	mu    sync.Mutex
+	zones map[string]*zoneStats
+}
+
+func newZoneBreakdown(loaderZone string, memberZones map[string]string) *zoneBreakdown {
+	if loaderZone == "" {
+		loaderZone = unknownZone
+	}
+	return &zoneBreakdown{loaderZone: loaderZone, memberZones: memberZones, zones: make(map[string]*zoneStats)}
+}
+
+// record records the request of the trace.
+func (z *zoneBreakdown) record(tr *requestTrace, took time.Duration) {
+	zone, ok := z.memberZones[tr.member]
+	if !ok && tr.member != "" {
+		zone, ok = zoneOf(z.memberZones, tr.member)
+	}
+	if !ok {
+		zone = unknownZone
+	}
+
+	z.mu.Lock()
+	defer z.mu.Unlock()
+	zs, ok := z.zones[zone]
+	if !ok {
+		h, err := hdrhistogram.New(int64(latencyHighest/time.Microsecond), DefaultLatencyResolution)
+		if err != nil {
+			// the same arguments as of 'newLatencyHistogram'
+			panic(err)
+		}
+		zs = &zoneStats{h: h}
+		z.zones[zone] = zs
+	}
+	zs.requests++
+	zs.totalLatency += took
+	zs.h.Record(int64(took / time.Microsecond))
+}
+
+// memberZones maps the members that the responses of the database tell
+// to the zones of their endpoints. Zookeeper clients tell the endpoints,
+// and etcd responses tell the member IDs, of the client URLs of the
+// members. The other databases do not tell the members.
+func (cfg *Config) memberZones(gcfg dbtesterpb.ConfigClientMachineAgentControl) (map[string]string, error) {
+	mz := make(map[string]string, len(cfg.EndpointZones))
+	for k, z := range cfg.EndpointZones {
+		mz[k] = z
+	}
+	for _, ep := range gcfg.DatabaseEndpoints {
+		if z, ok := zoneOf(cfg.EndpointZones, ep); ok {
+			mz[ep] = z
+		} else {
+			cfg.lg.Warn("endpoint has no zone", zap.String("endpoint", ep))
+		}
+	}
+
+	switch gcfg.DatabaseID {
+	case "etcd__other", "etcd__tip", "etcd__v3_2", "etcd__v3_3":
+		cli := mustCreateConnEtcdv3(gcfg.DatabaseEndpoints)
+		defer cli.Close()
+		ctx, cancel := context.WithTimeout(context.Background(), capabilityProbeTimeout)
+		defer cancel()
+		resp, err := cli.MemberList(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("cannot list the members to map to the zones (%v)", err)
+		}
+		for _, m := range resp.Members {
+			for _, u := range m.ClientURLs {
+				if z, ok := zoneOf(mz, u); ok {
+					mz[fmt.Sprintf("%x", m.ID)] = z
+					break
+				}
+			}
+		}
+	case "zookeeper__r3_5_3_beta", "zetcd__beta", "mock":
+	default:
+		cfg.lg.Warn("responses do not tell the members; requests are of the unknown zone", zap.String("database", gcfg.DatabaseID))
+	}
+	return mz, nil
+}
+
+func (cfg *Config) saveZoneBreakdown() {
+	z := cfg.zones
+	if z == nil {
+		return
+	}
+	z.mu.Lock()
+	defer z.mu.Unlock()
+
+	names := make([]string, 0, len(z.zones))
+	var total int64
+	for name, zs := range z.zones {
+		names = append(names, name)
+		total += zs.requests
+	}
+	sort.Strings(names)
+	cfg.lg.Sugar().Infof("zone breakdown [loader zone: %s | server zones: %d | requests: %d]", z.loaderZone, len(names), total)
+
+	c1 := dataframe.NewColumn("LOADER-ZONE")
+	c2 := dataframe.NewColumn("SERVER-ZONE")
+	c3 := dataframe.NewColumn("REQUESTS")
+	c4 := dataframe.NewColumn("REQUESTS-PERCENT")
+	c5 := dataframe.NewColumn("AVERAGE-LATENCY-MS")
+	c6 := dataframe.NewColumn("P50-LATENCY-MS")
+	c7 := dataframe.NewColumn("P99-LATENCY-MS")
+	c8 := dataframe.NewColumn("SLOWEST-LATENCY-MS")
+	for _, name := range names {
+		zs := z.zones[name]
+		ms := func(v int64) string { return fmt.Sprintf("%4.4f", float64(v)/1000) }
+		c1.PushBack(dataframe.NewStringValue(z.loaderZone))
+		c2.PushBack(dataframe.NewStringValue(name))
+		c3.PushBack(dataframe.NewStringValue(zs.requests))
+		c4.PushBack(dataframe.NewStringValue(fmt.Sprintf("%4.4f", 100*float64(zs.requests)/float64(total))))
+		c5.PushBack(dataframe.NewStringValue(fmt.Sprintf("%4.4f", toMillisecond(zs.totalLatency/time.Duration(zs.requests)))))
+		c6.PushBack(dataframe.NewStringValue(ms(zs.h.ValueAtPercentile(50))))
+		c7.PushBack(dataframe.NewStringValue(ms(zs.h.ValueAtPercentile(99))))
+		c8.PushBack(dataframe.NewStringValue(ms(zs.h.Max())))
+	}
+	fr := dataframe.New()
+	for _, col := range []dataframe.Column{c1, c2, c3, c4, c5, c6, c7, c8} {
+		if err := fr.AddColumn(col); err != nil {
+			panic(err)
+		}
+	}
+	fpath := cfg.ConfigClientMachineInitial.ClientZoneLatencyPath
+	if err := saveCSV(fr, fpath); err != nil {
+		panic(err)
+	}
+	cfg.lg.Info("saved zone breakdown", zap.String("path", fpath))
+}
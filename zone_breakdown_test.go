@@ -0,0 +1,98 @@
+// Copyright 2017 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtester
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/coreos/dbtester/dbtesterpb"
+
+	"go.uber.org/zap"
+)
+
+func TestParseEndpointZones(t *testing.T) {
+	zones, err := ParseEndpointZones([]string{"etcd1=zone-a", "10.0.0.2:2379=zone-b"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for addr, exp := range map[string]string{
+		"etcd1:2379":            "zone-a",
+		"http://etcd1:2379":     "zone-a",
+		"10.0.0.2:2379":         "zone-b",
+		"https://10.0.0.2:2379": "zone-b",
+	} {
+		if z, ok := zoneOf(zones, addr); !ok || z != exp {
+			t.Fatalf("%q: expected %q, got %q", addr, exp, z)
+		}
+	}
+	if _, ok := zoneOf(zones, "10.0.0.2:2380"); ok {
+		t.Fatal("expected no zone of another port")
+	}
+	for _, s := range []string{"etcd1", "=zone-a", "etcd1="} {
+		if _, err = ParseEndpointZones([]string{s}); err == nil {
+			t.Fatalf("%q: expected error", s)
+		}
+	}
+}
+
+func TestZoneBreakdown(t *testing.T) {
+	cfg := &Config{
+		lg:            zap.NewNop(),
+		EndpointZones: map[string]string{"zk1": "zone-a", "zk2:2181": "zone-b"},
+	}
+	mz, err := cfg.memberZones(dbtesterpb.ConfigClientMachineAgentControl{
+		DatabaseID:        "zookeeper__r3_5_3_beta",
+		DatabaseEndpoints: []string{"zk1:2181", "zk2:2181", "zk3:2181"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	z := newZoneBreakdown("zone-a", mz)
+	for i, member := range []string{"zk1:2181", "zk1:2181", "zk2:2181", "zk3:2181", ""} {
+		z.record(&requestTrace{member: member}, time.Duration(i+1)*time.Millisecond)
+	}
+	if len(z.zones) != 3 || z.zones["zone-a"].requests != 2 || z.zones["zone-b"].requests != 1 || z.zones[unknownZone].requests != 2 {
+		t.Fatalf("unexpected zones %+v", z.zones)
+	}
+
+	dir, err := ioutil.TempDir("", "zones")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	cfg.zones = z
+	cfg.ConfigClientMachineInitial.ClientZoneLatencyPath = filepath.Join(dir, "zones.csv")
+	cfg.saveZoneBreakdown()
+
+	bts, err := ioutil.ReadFile(cfg.ConfigClientMachineInitial.ClientZoneLatencyPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(bts)), "\n")
+	if len(lines) != 4 || lines[0] != "LOADER-ZONE,SERVER-ZONE,REQUESTS,REQUESTS-PERCENT,AVERAGE-LATENCY-MS,P50-LATENCY-MS,P99-LATENCY-MS,SLOWEST-LATENCY-MS" {
+		t.Fatalf("unexpected zone breakdown %q", lines)
+	}
+	if exp := "zone-a,unknown,2,40.0000,4.5000,"; !strings.HasPrefix(lines[1], exp) {
+		t.Fatalf("expected %q, got %q", exp, lines[1])
+	}
+	if exp := "zone-a,zone-a,2,40.0000,1.5000,1.0000,2.0000,2.0000"; lines[2] != exp {
+		t.Fatalf("expected %q, got %q", exp, lines[2])
+	}
+}